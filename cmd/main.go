@@ -15,6 +15,7 @@ import (
 	"github.com/PhantomX7/athleton/pkg/logger"
 	"github.com/PhantomX7/athleton/pkg/validator"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -64,6 +65,11 @@ func appOptions(cfg *config.Config, log *zap.Logger) fx.Option {
 		fx.Supply(log),
 		fx.Provide(
 			bootstrap.SetUpDatabase,
+			fx.Annotate(
+				bootstrap.NewMetricsRegistry,
+				fx.As(fx.Self()),
+				fx.As(new(prometheus.Registerer)),
+			),
 			middlewares.NewMiddleware,
 			validator.New,
 			bootstrap.SetupServer,
@@ -32,6 +32,14 @@ func main() {
 		&models.Config{},
 		&models.Log{},
 		&models.AdminRole{},
+		&models.UserToken{},
+		&models.TwoFactorRecoveryCode{},
+		&models.APIKey{},
+		&models.PasswordHistory{},
+		&models.PersonalDataHistory{},
+		&models.SecurityEvent{},
+		&models.UserIdentity{},
+		&models.UserAdminRole{},
 	)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load gorm schema: %v\n", err)
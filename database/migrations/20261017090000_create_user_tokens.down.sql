@@ -0,0 +1,6 @@
+-- reverse: create index "idx_user_tokens_user_id" to table: "user_tokens"
+DROP INDEX "idx_user_tokens_user_id";
+-- reverse: create index "idx_user_tokens_token_hash" to table: "user_tokens"
+DROP INDEX "idx_user_tokens_token_hash";
+-- reverse: create "user_tokens" table
+DROP TABLE "user_tokens";
@@ -0,0 +1,17 @@
+-- create "user_tokens" table
+CREATE TABLE "user_tokens" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "purpose" character varying(50) NOT NULL,
+  "token_hash" text NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "consumed_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_user_tokens_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_user_tokens_token_hash" to table: "user_tokens"
+CREATE UNIQUE INDEX "idx_user_tokens_token_hash" ON "user_tokens" ("token_hash");
+-- create index "idx_user_tokens_user_id" to table: "user_tokens"
+CREATE INDEX "idx_user_tokens_user_id" ON "user_tokens" ("user_id");
@@ -0,0 +1,5 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamptz NULL;
+-- accounts that predate email verification are treated as verified, so
+-- turning on AUTH_REQUIRE_EMAIL_VERIFICATION does not lock them out
+UPDATE "users" SET "email_verified_at" = "created_at";
@@ -0,0 +1,8 @@
+-- reverse: create index "idx_two_factor_recovery_codes_user_id" to table: "two_factor_recovery_codes"
+DROP INDEX "idx_two_factor_recovery_codes_user_id";
+-- reverse: create index "idx_two_factor_recovery_codes_code_hash" to table: "two_factor_recovery_codes"
+DROP INDEX "idx_two_factor_recovery_codes_code_hash";
+-- reverse: create "two_factor_recovery_codes" table
+DROP TABLE "two_factor_recovery_codes";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "two_factor_last_step", DROP COLUMN "two_factor_enabled_at", DROP COLUMN "two_factor_secret";
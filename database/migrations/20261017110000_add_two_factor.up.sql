@@ -0,0 +1,16 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "two_factor_secret" character varying(64) NOT NULL DEFAULT '', ADD COLUMN "two_factor_enabled_at" timestamptz NULL, ADD COLUMN "two_factor_last_step" bigint NOT NULL DEFAULT 0;
+-- create "two_factor_recovery_codes" table
+CREATE TABLE "two_factor_recovery_codes" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "code_hash" text NOT NULL,
+  "used_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_two_factor_recovery_codes_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_two_factor_recovery_codes_code_hash" to table: "two_factor_recovery_codes"
+CREATE UNIQUE INDEX "idx_two_factor_recovery_codes_code_hash" ON "two_factor_recovery_codes" ("code_hash");
+-- create index "idx_two_factor_recovery_codes_user_id" to table: "two_factor_recovery_codes"
+CREATE INDEX "idx_two_factor_recovery_codes_user_id" ON "two_factor_recovery_codes" ("user_id");
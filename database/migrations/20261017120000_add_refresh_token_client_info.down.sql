@@ -0,0 +1,2 @@
+-- reverse: modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" DROP COLUMN "last_used_at", DROP COLUMN "ip_address", DROP COLUMN "user_agent";
@@ -0,0 +1,6 @@
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "user_agent" character varying(512) NOT NULL DEFAULT '', ADD COLUMN "ip_address" character varying(45) NOT NULL DEFAULT '', ADD COLUMN "last_used_at" timestamptz NULL;
+-- existing sessions were last used no earlier than their last rotation
+UPDATE "refresh_tokens" SET "last_used_at" = "updated_at";
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ALTER COLUMN "last_used_at" SET NOT NULL;
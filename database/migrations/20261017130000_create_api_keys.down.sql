@@ -0,0 +1,6 @@
+-- reverse: create index "idx_api_keys_user_id" to table: "api_keys"
+DROP INDEX "idx_api_keys_user_id";
+-- reverse: create index "idx_api_keys_key_hash" to table: "api_keys"
+DROP INDEX "idx_api_keys_key_hash";
+-- reverse: create "api_keys" table
+DROP TABLE "api_keys";
@@ -0,0 +1,20 @@
+-- create "api_keys" table
+CREATE TABLE "api_keys" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "prefix" character varying(16) NOT NULL,
+  "key_hash" text NOT NULL,
+  "permissions" text NOT NULL,
+  "expires_at" timestamptz NULL,
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_api_keys_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_api_keys_key_hash" to table: "api_keys"
+CREATE UNIQUE INDEX "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+-- create index "idx_api_keys_user_id" to table: "api_keys"
+CREATE INDEX "idx_api_keys_user_id" ON "api_keys" ("user_id");
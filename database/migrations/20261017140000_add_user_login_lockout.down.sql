@@ -0,0 +1,2 @@
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "locked_until", DROP COLUMN "failed_login_attempts";
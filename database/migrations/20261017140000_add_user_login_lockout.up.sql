@@ -0,0 +1,2 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "failed_login_attempts" bigint NOT NULL DEFAULT 0, ADD COLUMN "locked_until" timestamptz NULL;
@@ -0,0 +1,8 @@
+-- reverse: create index "idx_refresh_tokens_impersonator_id" to table: "refresh_tokens"
+DROP INDEX "idx_refresh_tokens_impersonator_id";
+-- reverse: modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" DROP COLUMN "impersonator_id";
+-- reverse: create index "idx_logs_impersonator_id" to table: "logs"
+DROP INDEX "idx_logs_impersonator_id";
+-- reverse: modify "logs" table
+ALTER TABLE "logs" DROP COLUMN "impersonator_id";
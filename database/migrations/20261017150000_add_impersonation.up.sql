@@ -0,0 +1,8 @@
+-- modify "logs" table
+ALTER TABLE "logs" ADD COLUMN "impersonator_id" bigint NULL;
+-- create index "idx_logs_impersonator_id" to table: "logs"
+CREATE INDEX "idx_logs_impersonator_id" ON "logs" ("impersonator_id");
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "impersonator_id" bigint NULL;
+-- create index "idx_refresh_tokens_impersonator_id" to table: "refresh_tokens"
+CREATE INDEX "idx_refresh_tokens_impersonator_id" ON "refresh_tokens" ("impersonator_id");
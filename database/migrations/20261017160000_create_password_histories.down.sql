@@ -0,0 +1,4 @@
+-- reverse: create index "idx_password_histories_user_id" to table: "password_histories"
+DROP INDEX "idx_password_histories_user_id";
+-- reverse: create "password_histories" table
+DROP TABLE "password_histories";
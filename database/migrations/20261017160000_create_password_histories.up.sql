@@ -0,0 +1,11 @@
+-- create "password_histories" table
+CREATE TABLE "password_histories" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "password_hash" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_password_histories_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_password_histories_user_id" to table: "password_histories"
+CREATE INDEX "idx_password_histories_user_id" ON "password_histories" ("user_id");
@@ -0,0 +1,6 @@
+-- reverse: create index "idx_security_events_user_id" to table: "security_events"
+DROP INDEX "idx_security_events_user_id";
+-- reverse: create index "idx_security_events_created_at" to table: "security_events"
+DROP INDEX "idx_security_events_created_at";
+-- reverse: create "security_events" table
+DROP TABLE "security_events";
@@ -0,0 +1,17 @@
+-- create "security_events" table
+CREATE TABLE "security_events" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "type" character varying(50) NOT NULL,
+  "outcome" character varying(20) NOT NULL,
+  "ip_address" character varying(45) NOT NULL DEFAULT '',
+  "user_agent" character varying(512) NOT NULL DEFAULT '',
+  "session_id" uuid NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_security_events_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_security_events_created_at" to table: "security_events"
+CREATE INDEX "idx_security_events_created_at" ON "security_events" ("created_at");
+-- create index "idx_security_events_user_id" to table: "security_events"
+CREATE INDEX "idx_security_events_user_id" ON "security_events" ("user_id");
@@ -0,0 +1,2 @@
+-- reverse: modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" DROP COLUMN "authenticated_at";
@@ -0,0 +1,2 @@
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "authenticated_at" timestamptz NULL;
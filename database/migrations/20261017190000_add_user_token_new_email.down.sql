@@ -0,0 +1,2 @@
+-- reverse: modify "user_tokens" table
+ALTER TABLE "user_tokens" DROP COLUMN "new_email";
@@ -0,0 +1,2 @@
+-- modify "user_tokens" table
+ALTER TABLE "user_tokens" ADD COLUMN "new_email" character varying(255) NOT NULL DEFAULT '';
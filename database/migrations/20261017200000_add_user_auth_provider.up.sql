@@ -0,0 +1,2 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "auth_provider" character varying(20) NOT NULL DEFAULT 'local';
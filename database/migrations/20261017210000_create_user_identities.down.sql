@@ -0,0 +1,6 @@
+-- reverse: create index "idx_user_identities_user_provider" to table: "user_identities"
+DROP INDEX "idx_user_identities_user_provider";
+-- reverse: create index "idx_user_identities_provider_subject" to table: "user_identities"
+DROP INDEX "idx_user_identities_provider_subject";
+-- reverse: create "user_identities" table
+DROP TABLE "user_identities";
@@ -0,0 +1,17 @@
+-- create "user_identities" table
+CREATE TABLE "user_identities" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "provider" character varying(50) NOT NULL,
+  "subject" character varying(255) NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "last_login_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_user_identities_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_user_identities_provider_subject" to table: "user_identities"
+CREATE UNIQUE INDEX "idx_user_identities_provider_subject" ON "user_identities" ("provider", "subject");
+-- create index "idx_user_identities_user_provider" to table: "user_identities"
+CREATE UNIQUE INDEX "idx_user_identities_user_provider" ON "user_identities" ("user_id", "provider");
@@ -0,0 +1,6 @@
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ADD COLUMN "logged_in_at" timestamptz NULL, ADD COLUMN "remember_me" boolean NOT NULL DEFAULT false;
+-- rotation never touched created_at, so it is when each existing session logged in
+UPDATE "refresh_tokens" SET "logged_in_at" = "created_at";
+-- modify "refresh_tokens" table
+ALTER TABLE "refresh_tokens" ALTER COLUMN "logged_in_at" SET NOT NULL;
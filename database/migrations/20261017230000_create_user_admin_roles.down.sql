@@ -0,0 +1,10 @@
+-- reverse: modify "users" table
+ALTER TABLE "users" ADD COLUMN "admin_role_id" bigint NULL, ADD CONSTRAINT "fk_users_admin_role" FOREIGN KEY ("admin_role_id") REFERENCES "admin_roles" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- the old column holds a single role, so keep the lowest assigned one
+UPDATE "users" SET "admin_role_id" = (SELECT MIN("admin_role_id") FROM "user_admin_roles" WHERE "user_admin_roles"."user_id" = "users"."id");
+-- create index "idx_users_admin_role_id" to table: "users"
+CREATE INDEX "idx_users_admin_role_id" ON "users" ("admin_role_id");
+-- reverse: create index "idx_user_admin_roles_admin_role_id" to table: "user_admin_roles"
+DROP INDEX "idx_user_admin_roles_admin_role_id";
+-- reverse: create "user_admin_roles" table
+DROP TABLE "user_admin_roles";
@@ -0,0 +1,15 @@
+-- create "user_admin_roles" table
+CREATE TABLE "user_admin_roles" (
+  "user_id" bigint NOT NULL,
+  "admin_role_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("user_id", "admin_role_id"),
+  CONSTRAINT "fk_user_admin_roles_admin_role" FOREIGN KEY ("admin_role_id") REFERENCES "admin_roles" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_user_admin_roles_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_user_admin_roles_admin_role_id" to table: "user_admin_roles"
+CREATE INDEX "idx_user_admin_roles_admin_role_id" ON "user_admin_roles" ("admin_role_id");
+-- carry every existing single role assignment over to the join table
+INSERT INTO "user_admin_roles" ("user_id", "admin_role_id", "created_at") SELECT "id", "admin_role_id", now() FROM "users" WHERE "admin_role_id" IS NOT NULL;
+-- modify "users" table
+ALTER TABLE "users" DROP CONSTRAINT "fk_users_admin_role", DROP COLUMN "admin_role_id";
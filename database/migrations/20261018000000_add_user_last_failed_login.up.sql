@@ -0,0 +1,2 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "last_failed_login_at" timestamptz NULL;
@@ -0,0 +1,4 @@
+-- reverse: create index "idx_personal_data_histories_user_value" to table: "personal_data_histories"
+DROP INDEX "idx_personal_data_histories_user_value";
+-- reverse: create "personal_data_histories" table
+DROP TABLE "personal_data_histories";
@@ -0,0 +1,17 @@
+-- create "personal_data_histories" table
+CREATE TABLE "personal_data_histories" (
+  "id" bigserial NOT NULL,
+  "user_id" bigint NOT NULL,
+  "value" character varying(255) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_personal_data_histories_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- create index "idx_personal_data_histories_user_value" to table: "personal_data_histories"
+CREATE UNIQUE INDEX "idx_personal_data_histories_user_value" ON "personal_data_histories" ("user_id", "value");
+-- record the values accounts hold today, so a later change keeps the old one
+INSERT INTO "personal_data_histories" ("user_id", "value", "created_at")
+SELECT "users"."id", "personal"."value", now()
+FROM "users", LATERAL (VALUES ("users"."name"), ("users"."username"), ("users"."email"), ("users"."business_name"), ("users"."phone")) AS "personal" ("value")
+WHERE "users"."deleted_at" IS NULL AND "personal"."value" IS NOT NULL AND "personal"."value" <> ''
+ON CONFLICT DO NOTHING;
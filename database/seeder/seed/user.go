@@ -6,14 +6,11 @@ import (
 
 	"github.com/PhantomX7/athleton/internal/models"
 	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/password"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
-// BcryptCost is the bcrypt work factor used when hashing seeded passwords.
-const BcryptCost = 12
-
 // SeedUsers inserts the default root and admin users when they do not already exist.
 //
 //nolint:revive // SeedUsers is kept for consistency with the seeder entrypoint naming.
@@ -35,14 +32,13 @@ func SeedUsers(db *gorm.DB, cfg *config.Config) error {
 		},
 	}
 
-	var password []byte
-	password, err := bcrypt.GenerateFromPassword([]byte(cfg.Admin.DefaultPassword), BcryptCost)
+	hashed, err := password.Hash(cfg.Admin.DefaultPassword, cfg.Auth.PasswordHashParams())
 	if err != nil {
 		return err
 	}
 
 	for _, user := range users {
-		user.Password = string(password)
+		user.Password = hashed
 
 		err := db.First(&models.User{}, models.User{
 			Username: user.Username,
@@ -77,13 +77,18 @@ type Entry struct {
 }
 
 // UserName returns the user name from ctx, falling back to "Unknown" when
-// absent, so audit-log phrasing stays consistent across services.
+// absent, so audit-log phrasing stays consistent across services. During
+// impersonation it names the real actor too ("Bob (impersonated by Root)"),
+// so every message built from it reads correctly on its own.
 func UserName(ctx context.Context) string {
-	name, _ := utils.GetUserNameFromContext(ctx)
-	if name == "" {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil || values.UserName == "" {
 		return "Unknown"
 	}
-	return name
+	if values.ImpersonatorID != 0 {
+		return fmt.Sprintf("%s (impersonated by %s)", values.UserName, values.ImpersonatorName)
+	}
+	return values.UserName
 }
 
 // actionVerbs maps standard actions to their past-tense message verb. Actions
@@ -92,6 +97,11 @@ var actionVerbs = map[models.LogAction]string{
 	models.LogActionCreate: "created",
 	models.LogActionUpdate: "updated",
 	models.LogActionDelete: "deleted",
+
+	models.LogActionActivate:   "activated",
+	models.LogActionDeactivate: "deactivated",
+
+	models.LogActionUnlockAccount: "unlocked",
 }
 
 // RecordAction writes a standard "<user> <verbed> <noun>: <name>" audit entry
@@ -122,22 +132,23 @@ func RecordAction(ctx context.Context, repo LogWriter, action models.LogAction,
 }
 
 // Record writes an audit entry in the background, attributed to the user in
-// ctx. Cancellation is detached (context.WithoutCancel) so the write can
-// complete after the originating request returns, but request-scoped logging
-// fields are preserved so a failed write stays correlated to its request.
+// ctx and, during impersonation, to the real actor as well. Cancellation is
+// detached (context.WithoutCancel) so the write can complete after the
+// originating request returns, but request-scoped logging fields are
+// preserved so a failed write stays correlated to its request.
 func Record(ctx context.Context, repo LogWriter, entry Entry) {
-	var userID *uint
-	if id, ok := utils.GetUserIDFromContext(ctx); ok {
-		userID = &id
-	}
-
 	log := &models.Log{
-		UserID:     userID,
 		Action:     entry.Action,
 		EntityType: entry.EntityType,
 		EntityID:   entry.EntityID,
 		Message:    entry.Message,
 	}
+	if values, err := utils.ValuesFromContext(ctx); err == nil {
+		log.UserID = &values.UserID
+		if values.ImpersonatorID != 0 {
+			log.ImpersonatorID = &values.ImpersonatorID
+		}
+	}
 
 	// Detach cancellation so the write can outlive the request, and strip any
 	// transaction the caller's context carries: context.WithoutCancel preserves
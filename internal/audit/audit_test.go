@@ -83,6 +83,26 @@ func TestRecordAttributesUserFromContext(t *testing.T) {
 	require.Equal(t, "kenichi created config", got.Message)
 }
 
+func TestRecordAttributesImpersonatedActionToBothUsers(t *testing.T) {
+	setupLogger(t)
+
+	repo, created := newMockLogRepository(nil)
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID:           42,
+		UserName:         "kenichi",
+		ImpersonatorID:   1,
+		ImpersonatorName: "Root",
+	})
+
+	audit.RecordAction(ctx, repo, models.LogActionUpdate, models.LogEntityTypeConfig, 7, "config", "site_name")
+
+	got := waitForCreate(t, created)
+	require.Equal(t, uint(42), *got.UserID)
+	require.NotNil(t, got.ImpersonatorID)
+	require.Equal(t, uint(1), *got.ImpersonatorID)
+	require.Equal(t, "kenichi (impersonated by Root) updated config: site_name", got.Message)
+}
+
 func TestRecordWithoutUserLeavesUserIDNil(t *testing.T) {
 	setupLogger(t)
 
@@ -98,6 +118,7 @@ func TestRecordWithoutUserLeavesUserIDNil(t *testing.T) {
 	got := waitForCreate(t, created)
 	require.NotNil(t, got)
 	require.Nil(t, got.UserID)
+	require.Nil(t, got.ImpersonatorID)
 	require.Equal(t, models.LogActionDelete, got.Action)
 	require.Equal(t, models.LogEntityTypeUser, got.EntityType)
 	require.Equal(t, uint(9), got.EntityID)
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SecurityEventWriter is the narrow persistence dependency RecordSecurityEvent
+// needs. The security_event module's repository satisfies it structurally,
+// just as the log repository satisfies LogWriter.
+type SecurityEventWriter interface {
+	Create(ctx context.Context, event *models.SecurityEvent) error
+}
+
+// RecordSecurityEvent appends an entry to userID's security timeline in the
+// background. The client's IP and user agent are read from ctx (set by the
+// ClientInfo middleware). sessionID may be uuid.Nil for events that have no
+// session, such as a failed login.
+//
+// The event is always attributed to userID rather than to the caller in ctx:
+// most of these events (login, refresh) happen before there is an
+// authenticated caller, and an admin changing a user's password is an event
+// on the user's account, not the admin's.
+func RecordSecurityEvent(
+	ctx context.Context,
+	repo SecurityEventWriter,
+	userID uint,
+	eventType models.SecurityEventType,
+	outcome models.SecurityEventOutcome,
+	sessionID uuid.UUID,
+) {
+	client := utils.GetClientInfoFromContext(ctx)
+	event := &models.SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		Outcome:   outcome,
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+		// Stamped now, not at insert: background writes may land out of
+		// order, and the timeline is sorted by this column.
+		CreatedAt: time.Now(),
+	}
+	if sessionID != uuid.Nil {
+		event.SessionID = &sessionID
+	}
+
+	// Same detachment as Record: outlive the request, never the transaction.
+	bgCtx := utils.StripTx(context.WithoutCancel(ctx))
+	Go(func() {
+		if err := repo.Create(bgCtx, event); err != nil {
+			logger.Ctx(bgCtx).Error("Failed to create security event",
+				zap.Uint("user_id", userID),
+				zap.String("type", string(eventType)),
+				zap.String("outcome", string(outcome)),
+				zap.Error(err),
+			)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/models"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func TestRecordSecurityEventCapturesClientAndSession(t *testing.T) {
+	setupLogger(t)
+
+	created := make(chan *models.SecurityEvent, 1)
+	repo := &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(_ context.Context, entity *models.SecurityEvent) error {
+			created <- entity
+			return nil
+		},
+	}
+	// The caller in ctx is deliberately someone else: the event belongs to
+	// the account it happened to.
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1})
+	ctx = utils.SetClientInfoToContext(ctx, utils.ClientInfo{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+	sessionID := uuid.New()
+
+	audit.RecordSecurityEvent(ctx, repo, 42, models.SecurityEventLogin, models.SecurityEventOutcomeSuccess, sessionID)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	got := <-created
+	require.Equal(t, uint(42), got.UserID)
+	require.Equal(t, models.SecurityEventLogin, got.Type)
+	require.Equal(t, models.SecurityEventOutcomeSuccess, got.Outcome)
+	require.Equal(t, "203.0.113.7", got.IPAddress)
+	require.Equal(t, "curl/8.0", got.UserAgent)
+	require.NotNil(t, got.SessionID)
+	require.Equal(t, sessionID, *got.SessionID)
+}
+
+func TestRecordSecurityEventWithoutSessionLeavesSessionIDNil(t *testing.T) {
+	setupLogger(t)
+
+	created := make(chan *models.SecurityEvent, 1)
+	repo := &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(_ context.Context, entity *models.SecurityEvent) error {
+			created <- entity
+			return nil
+		},
+	}
+
+	audit.RecordSecurityEvent(context.Background(), repo, 42, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	got := <-created
+	require.Equal(t, models.SecurityEventOutcomeFailure, got.Outcome)
+	require.Nil(t, got.SessionID)
+	require.Empty(t, got.IPAddress)
+}
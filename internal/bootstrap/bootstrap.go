@@ -73,8 +73,18 @@ func RegisterLoggerLifecycle(lc fx.Lifecycle) {
 	})
 }
 
+// NewMetricsRegistry builds the Prometheus registry served at /metrics.
+// Instruments live on a per-server registry, not the process global: the test
+// harness builds many servers per process, and re-registering on the global
+// registry panics.
+func NewMetricsRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector())
+	return reg
+}
+
 // SetupServer configures and returns the Gin engine.
-func SetupServer(cfg *config.Config, m *middlewares.Middleware, cv cvalidator.CustomValidator, db *gorm.DB) *gin.Engine {
+func SetupServer(cfg *config.Config, m *middlewares.Middleware, cv cvalidator.CustomValidator, db *gorm.DB, metricsRegistry *prometheus.Registry) *gin.Engine {
 	logger.Info("Setting up HTTP server")
 
 	// gin.New() (not gin.Default()) so the only request logger in play is our
@@ -102,23 +112,20 @@ func SetupServer(cfg *config.Config, m *middlewares.Middleware, cv cvalidator.Cu
 
 	registerValidators(validators)
 
-	// Prometheus instruments live on a per-server registry, not the process
-	// global: the test harness builds many servers per process, and
-	// re-registering on the global registry panics.
-	metricsRegistry := prometheus.NewRegistry()
-	metricsRegistry.MustRegister(collectors.NewGoCollector())
 	httpMetrics := middlewares.NewHTTPMetrics(metricsRegistry)
 
 	// Apply middleware in order (ORDER IS IMPORTANT!)
 	server.Use(
 		m.RequestID(),                            // 1. Generate/extract request ID (MUST be before logger)
-		httpMetrics.Handler(),                    // 2. Request counter + latency histogram (outermost timing)
-		m.CORS(),                                 // 3. CORS handling
-		m.BodySizeLimit(cfg.Server.MaxBodyBytes), // 4. Reject oversized payloads
-		m.TimeoutMiddleware(cfg.Server.RequestTimeout), // 5. Request deadline via context
-		m.Logger(),       // 6. Request logging (outer, so it sees recovered panics)
-		m.Recovery(),     // 7. Panic recovery → JSON envelope (inner, so Logger still logs the 500)
-		m.ErrorHandler(), // 8. Error handling (MUST be last)
+		m.ClientInfo(),                           // 2. User agent + client IP onto the request context
+		httpMetrics.Handler(),                    // 3. Request counter + latency histogram (outermost timing)
+		m.CORS(),                                 // 4. CORS handling
+		m.CSRF(),                                 // 5. Double-submit check for cookie auth (after CORS preflights)
+		m.BodySizeLimit(cfg.Server.MaxBodyBytes), // 6. Reject oversized payloads
+		m.TimeoutMiddleware(cfg.Server.RequestTimeout), // 7. Request deadline via context
+		m.Logger(),       // 8. Request logging (outer, so it sees recovered panics)
+		m.Recovery(),     // 9. Panic recovery → JSON envelope (inner, so Logger still logs the 500)
+		m.ErrorHandler(), // 10. Error handling (MUST be last)
 	)
 
 	// Prometheus scrape surface. Like the health probes it is unauthenticated;
@@ -3,11 +3,15 @@ package dto
 
 import "time"
 
-// CreateAdminRoleRequest is the payload for creating an admin role.
+// CreateAdminRoleRequest is the payload for creating an admin role. A role
+// with a parent inherits the parent's permissions and may add none of its own.
+// Denials are deny rules, which win over any grant the role holds.
 type CreateAdminRoleRequest struct {
-	Name        string   `json:"name" form:"name" binding:"required,min=2,max=100,unique=admin_roles.name"`
-	Description string   `json:"description" form:"description" binding:"max=255"`
-	Permissions []string `json:"permissions" form:"permissions[]" binding:"required,min=1,dive,required"`
+	Name         string   `json:"name" form:"name" binding:"required,min=2,max=100,unique=admin_roles.name"`
+	Description  string   `json:"description" form:"description" binding:"max=255"`
+	ParentRoleID *uint    `json:"parent_role_id" form:"parent_role_id" binding:"omitempty,min=1"`
+	Permissions  []string `json:"permissions" form:"permissions[]" binding:"required_without=ParentRoleID,dive,required"`
+	Denials      []string `json:"denials" form:"denials[]" binding:"omitempty,dive,required"`
 }
 
 // UpdateAdminRoleRequest is the payload for updating an admin role.
@@ -15,22 +19,42 @@ type CreateAdminRoleRequest struct {
 // ID is set from the path param by the controller, never from the request body
 // (json/form "-"), and drives the unique self-exclusion so re-sending the role's
 // own unchanged name does not conflict with itself.
+//
+// ParentRoleID leaves the parent unchanged when omitted and removes it when 0.
+// Permissions and Denials are each left unchanged when omitted and replaced
+// when sent; an empty Denials list removes every deny rule.
 type UpdateAdminRoleRequest struct {
-	ID          uint     `json:"-" form:"-"`
-	Name        *string  `json:"name" form:"name" binding:"omitempty,min=2,max=100,unique=admin_roles.name.id.ID"`
-	Description *string  `json:"description" form:"description" binding:"omitempty,max=255"`
-	Permissions []string `json:"permissions" form:"permissions[]" binding:"omitempty,dive,required"`
+	ID           uint     `json:"-" form:"-"`
+	Name         *string  `json:"name" form:"name" binding:"omitempty,min=2,max=100,unique=admin_roles.name.id.ID"`
+	Description  *string  `json:"description" form:"description" binding:"omitempty,max=255"`
+	ParentRoleID *uint    `json:"parent_role_id" form:"parent_role_id"`
+	Permissions  []string `json:"permissions" form:"permissions[]" binding:"omitempty,dive,required"`
+	Denials      []string `json:"denials" form:"denials[]" binding:"omitempty,dive,required"`
 }
 
-// AdminRoleResponse is the API response shape for an admin role.
+// AdminRoleResponse is the API response shape for an admin role. Permissions
+// are the role's own grants (what an update replaces); EffectivePermissions
+// adds those inherited from its ancestors, leaving out any a deny rule wholly
+// covers. Denials and EffectiveDenials do the same for deny rules.
 type AdminRoleResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IsActive    bool      `json:"is_active"`
-	Permissions []string  `json:"permissions"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   uint                          `json:"id"`
+	Name                 string                        `json:"name"`
+	Description          string                        `json:"description"`
+	IsActive             bool                          `json:"is_active"`
+	ParentRoleID         *uint                         `json:"parent_role_id"`
+	Permissions          []string                      `json:"permissions"`
+	EffectivePermissions []EffectivePermissionResponse `json:"effective_permissions"`
+	Denials              []string                      `json:"denials"`
+	EffectiveDenials     []EffectivePermissionResponse `json:"effective_denials"`
+	CreatedAt            time.Time                     `json:"created_at"`
+	UpdatedAt            time.Time                     `json:"updated_at"`
+}
+
+// EffectivePermissionResponse is one permission a role holds, flagged when it
+// comes from an ancestor rather than the role's own grants.
+type EffectivePermissionResponse struct {
+	Permission string `json:"permission"`
+	Inherited  bool   `json:"inherited"`
 }
 
 // PermissionResponse is a single available permission, as returned (grouped by
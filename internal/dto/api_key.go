@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// APIKeyCreateRequest issues a new API key. Permissions must be registered
+// permissions the caller currently holds; ExpiresAt is optional and, when
+// set, must be in the future.
+type APIKeyCreateRequest struct {
+	Name        string     `json:"name" form:"name" binding:"required,max=100" maxLength:"100"`
+	Permissions []string   `json:"permissions" form:"permissions" binding:"required,min=1,dive,required"`
+	ExpiresAt   *time.Time `json:"expires_at" form:"expires_at"`
+}
+
+// APIKeyResponse describes an API key without its secret.
+type APIKeyResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// APIKeyCreateResponse is returned once, when the key is issued. Key is the
+// secret to send as "Authorization: ApiKey <key>"; the server keeps only its
+// hash, so it cannot be shown again.
+type APIKeyCreateResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
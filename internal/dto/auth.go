@@ -1,14 +1,18 @@
 package dto
 
-// Password fields are capped at max=72 everywhere: bcrypt rejects passwords
-// longer than 72 bytes, so validating up front returns a 400 instead of
-// surfacing a 500 from the hasher (and no valid stored password can exceed 72
-// bytes anyway, so the cap can never lock a user out).
+import "time"
+
+// Password fields are capped at max=128 everywhere (security.MaxPasswordBytes),
+// so an oversized body is refused with a 400 before it reaches the hasher.
+// Passwords hashed with bcrypt were capped at 72 bytes, so the cap can never
+// lock a user out.
 
 // LoginRequest is the payload for authenticating a user.
 type LoginRequest struct {
 	Username string `json:"username" form:"username" binding:"required"`
-	Password string `json:"password" form:"password" binding:"required,min=8,max=72" minLength:"8" maxLength:"72"`
+	Password string `json:"password" form:"password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
+	// RememberMe opens the session under the longer AUTH_REMEMBER_ME_* limits.
+	RememberMe bool `json:"remember_me" form:"remember_me"`
 }
 
 // RegisterRequest is the payload for registering a new user account.
@@ -17,16 +21,97 @@ type RegisterRequest struct {
 	BusinessName string `json:"business_name" form:"business_name" binding:"required"`
 	Email        string `json:"email" form:"email" binding:"required,unique=users.email"`
 	Phone        string `json:"phone" form:"phone" binding:"required"`
-	Password     string `json:"password" form:"password" binding:"required,min=8,max=72" minLength:"8" maxLength:"72"`
+	Password     string `json:"password" form:"password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
 }
 
 // ChangePasswordRequest is the payload for rotating the authenticated user's password.
 type ChangePasswordRequest struct {
-	OldPassword string `json:"old_password" form:"old_password" binding:"required,max=72" maxLength:"72"`
-	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=8,max=72" minLength:"8" maxLength:"72"`
+	OldPassword string `json:"old_password" form:"old_password" binding:"required,max=128" maxLength:"128"`
+	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
 	ExceptToken string `json:"except_token" form:"except_token" binding:"required"` // excepted refresh token
 }
 
+// ForgotPasswordRequest is the payload for requesting a password-reset email.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" form:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest is the payload for redeeming a password-reset token.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" form:"token" binding:"required"`
+	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
+}
+
+// VerifyEmailRequest is the payload for redeeming an email-verification token.
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// MagicLinkRequest is the payload for requesting a passwordless login link.
+type MagicLinkRequest struct {
+	Email string `json:"email" form:"email" binding:"required,email"`
+}
+
+// ConsumeMagicLinkRequest is the payload for redeeming a login link.
+type ConsumeMagicLinkRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// ResendVerificationRequest is the payload for requesting a fresh
+// email-verification link.
+type ResendVerificationRequest struct {
+	Email string `json:"email" form:"email" binding:"required,email"`
+}
+
+// UpdateProfileRequest is the payload for the authenticated user editing their
+// own profile. Omitted fields are left unchanged; the email address has its
+// own confirmed flow (ChangeEmailRequest).
+type UpdateProfileRequest struct {
+	Name         *string `json:"name" form:"name" binding:"omitempty,min=1,max=255"`
+	BusinessName *string `json:"business_name" form:"business_name" binding:"omitempty,max=255"`
+	Phone        *string `json:"phone" form:"phone" binding:"omitempty,min=1,max=255"`
+}
+
+// ChangeEmailRequest is the payload for asking to move the authenticated
+// user's account to a new address. Nothing changes until the link emailed to
+// that address is redeemed via ConfirmEmailChangeRequest.
+type ChangeEmailRequest struct {
+	Email string `json:"email" form:"email" binding:"required,email,max=255,unique=users.email"`
+}
+
+// ConfirmEmailChangeRequest is the payload for redeeming an email-change token.
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// DataExportResponse carries the time-limited token that downloads an
+// archive of the authenticated user's data.
+type DataExportResponse struct {
+	// Token is POSTed to DownloadURL in the request body; it never goes in a
+	// URL, where access logs and Referer headers would record it.
+	Token       string    `json:"token"`
+	DownloadURL string    `json:"download_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// DownloadDataExportRequest carries a data-export token.
+type DownloadDataExportRequest struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+// DataExportArchive is the ZIP archive of a user's data, ready to be served
+// as a download.
+type DataExportArchive struct {
+	FileName string
+	Content  []byte
+}
+
+// DeleteAccountRequest confirms the authenticated user's request to erase
+// their own account with its password.
+type DeleteAccountRequest struct {
+	Password string `json:"password" form:"password" binding:"required,max=128" maxLength:"128"`
+}
+
 // RefreshRequest is the payload for rotating an access token via a refresh token.
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
@@ -39,13 +124,24 @@ type LogoutRequest struct {
 
 // AuthResponse is the token payload returned after successful authentication.
 type AuthResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
+	// The token fields are empty when EmailVerificationRequired or
+	// TwoFactorRequired is set.
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
 	// MustChangePassword is true when the account (admin/root) still uses the
 	// seeded default password and is blocked from /admin until it rotates it.
 	// A hint only — the RequirePasswordChanged middleware is what enforces it.
 	MustChangePassword bool `json:"must_change_password"`
+	// EmailVerificationRequired is true when registration succeeded but no
+	// tokens were issued because AUTH_REQUIRE_EMAIL_VERIFICATION is on: the
+	// user must follow the emailed link and then log in.
+	EmailVerificationRequired bool `json:"email_verification_required"`
+	// TwoFactorRequired is true when the password was accepted but the
+	// account has 2FA enabled: ChallengeToken must be exchanged, together with
+	// a TOTP or recovery code, at /auth/2fa/verify to obtain the tokens.
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
 }
 
 // MeResponse is the profile payload returned for the authenticated user.
@@ -53,4 +149,39 @@ type MeResponse struct {
 	UserResponse
 	// MustChangePassword mirrors the flag on AuthResponse; see it for details.
 	MustChangePassword bool `json:"must_change_password"`
+	// EmailVerified reports whether the user has confirmed their address, so
+	// the client can prompt unverified users to do so.
+	EmailVerified bool `json:"email_verified"`
+	// TwoFactorEnabled reports whether TOTP 2FA is active on the account.
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	// MustEnableTwoFactor is true when 2FA is mandatory for this account and
+	// it has not enrolled yet; /admin is blocked until it does.
+	MustEnableTwoFactor bool `json:"must_enable_two_factor"`
+	// ImpersonatorID is set when the request was made with an impersonation
+	// token, so the client can show who is really acting.
+	ImpersonatorID *uint `json:"impersonator_id,omitempty"`
+}
+
+// ImpersonationResponse carries the access token that lets a support user act
+// as another user. It comes without a refresh token: the impersonation ends
+// when ExpiresAt passes or /auth/impersonation/end is called.
+type ImpersonationResponse struct {
+	AccessToken string        `json:"access_token"`
+	TokenType   string        `json:"token_type"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	User        *UserResponse `json:"user"`
+}
+
+// ReauthenticateRequest proves the caller's identity again to open the
+// step-up window on the current session: either the account password or,
+// for a 2FA account, a TOTP or recovery code.
+type ReauthenticateRequest struct {
+	Password string `json:"password" form:"password" binding:"required_without=Code,max=128" maxLength:"128"`
+	Code     string `json:"code" form:"code" binding:"required_without=Password,max=32" maxLength:"32"`
+}
+
+// ReauthenticateResponse reports until when the current session may perform
+// operations that require recent authentication.
+type ReauthenticateResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
 }
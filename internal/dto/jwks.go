@@ -0,0 +1,21 @@
+package dto
+
+// JWK is the public half of one access-token signing key, in RFC 7517 form.
+// RSA keys fill N and E; Ed25519 (OKP) keys fill Crv and X.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSResponse is the document served at /.well-known/jwks.json. It is
+// returned bare, without the usual response envelope, because JWT libraries
+// fetch it directly.
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
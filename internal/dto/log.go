@@ -4,13 +4,16 @@ import "time"
 
 // LogResponse is the API response shape for a single audit log entry.
 type LogResponse struct {
-	ID         uint      `json:"id"`
-	UserID     *uint     `json:"user_id"`
-	Action     string    `json:"action"`
-	EntityType string    `json:"entity_type"`
-	EntityID   uint      `json:"entity_id"`
-	Message    string    `json:"message"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID     uint  `json:"id"`
+	UserID *uint `json:"user_id"`
+	// ImpersonatorID is the real actor when the action was taken while
+	// impersonating UserID.
+	ImpersonatorID *uint     `json:"impersonator_id"`
+	Action         string    `json:"action"`
+	EntityType     string    `json:"entity_type"`
+	EntityID       uint      `json:"entity_id"`
+	Message        string    `json:"message"`
+	CreatedAt      time.Time `json:"created_at"`
 
 	// Relationships
 	User *UserResponse `json:"user,omitempty"`
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// OIDCLoginState is what a login started at /auth/oidc/:provider/start must
+// present at the callback. It travels in an HttpOnly cookie, so it is bound
+// to the browser that started the login.
+type OIDCLoginState struct {
+	Provider string `json:"p"`
+	// State is echoed by the provider in the callback; a callback whose state
+	// does not match was not started by this browser.
+	State string `json:"s"`
+	// Nonce must come back inside the ID token.
+	Nonce string `json:"n"`
+	// Verifier is the PKCE code verifier; the provider only saw its S256
+	// challenge.
+	Verifier  string    `json:"v"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+// OIDCAuthorization is a started login: the provider URL to send the user to
+// and the state to keep until they return.
+type OIDCAuthorization struct {
+	URL   string
+	State OIDCLoginState
+}
+
+// OIDCCallbackRequest is the query string the provider redirects back with:
+// a code and the state on success, an error code otherwise.
+type OIDCCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+// UserIdentityResponse describes an OpenID Connect identity linked to the
+// user's account.
+type UserIdentityResponse struct {
+	ID          uint       `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
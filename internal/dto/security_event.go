@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// SecurityEventResponse is one entry of a user's security timeline. SessionID
+// is empty for events not tied to a session, such as a failed login.
+type SecurityEventResponse struct {
+	ID        uint      `json:"id"`
+	Type      string    `json:"type"`
+	Outcome   string    `json:"outcome"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	SessionID string    `json:"session_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
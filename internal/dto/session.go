@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// SessionResponse describes one of the authenticated user's active
+// refresh-token sessions. ID is what DELETE /auth/sessions/{id} takes.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// RememberMe is true when the login asked to be remembered, which gives
+	// the session the longer lifetime and idle timeout.
+	RememberMe bool `json:"remember_me"`
+	// RevokedAt is only ever set in a data export; the session list shows
+	// active sessions alone.
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// Current is true for the session the request was made with.
+	Current bool `json:"current"`
+}
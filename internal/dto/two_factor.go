@@ -0,0 +1,44 @@
+package dto
+
+// TwoFactorVerifyRequest completes a password login for an account with 2FA
+// enabled. Code is either the current six-digit TOTP code or one of the
+// account's unused recovery codes.
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token" binding:"required"`
+	Code           string `json:"code" form:"code" binding:"required,max=32" maxLength:"32"`
+	// RememberMe repeats the choice made at the password step, which opened
+	// no session yet.
+	RememberMe bool `json:"remember_me" form:"remember_me"`
+}
+
+// TwoFactorEnableRequest confirms enrolment with a code from the freshly
+// configured authenticator app.
+type TwoFactorEnableRequest struct {
+	Code string `json:"code" form:"code" binding:"required,len=6" minLength:"6" maxLength:"6"`
+}
+
+// TwoFactorDisableRequest turns 2FA off. Both factors are required so a
+// hijacked session alone cannot strip the second one.
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" form:"password" binding:"required,max=128" maxLength:"128"`
+	Code     string `json:"code" form:"code" binding:"required,max=32" maxLength:"32"`
+}
+
+// TwoFactorRecoveryCodesRequest regenerates the recovery codes; Code is a
+// current TOTP or recovery code.
+type TwoFactorRecoveryCodesRequest struct {
+	Code string `json:"code" form:"code" binding:"required,max=32" maxLength:"32"`
+}
+
+// TwoFactorSetupResponse carries the pending TOTP secret. OTPAuthURI is the
+// otpauth:// URI authenticator apps accept as a QR code.
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TwoFactorRecoveryCodesResponse lists freshly generated recovery codes. They
+// are only ever shown once; the server keeps nothing but their hashes.
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
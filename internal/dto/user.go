@@ -7,10 +7,11 @@ import (
 // UserUpdateRequest defines the structure for updating a user.
 //
 // Role deliberately accepts only "user": promotion to "admin" must go through
-// the dedicated AssignAdminRole endpoint so Role and AdminRoleID always change
-// together (Casbin ignores AdminRoleID unless Role == "admin"), and "root" is
-// never assignable through the API. The only role transition Update supports
-// is demoting an admin back to a plain user, which also clears AdminRoleID.
+// the dedicated AssignAdminRole endpoint so Role and the admin roles always
+// change together (Casbin ignores admin roles unless Role == "admin"), and
+// "root" is never assignable through the API. The only role transition Update
+// supports is demoting an admin back to a plain user, which also removes all
+// of their admin roles.
 // ("reseller" was removed — it is not a role this application defines.)
 type UserUpdateRequest struct {
 	Role *string `json:"role" form:"role" binding:"omitempty,oneof=user" enums:"user"`
@@ -25,37 +26,56 @@ type UserUpdateRequest struct {
 // by the creator, so the service leaves PasswordChangedAt nil and the
 // must-change-default-password gate forces a rotation on first login.
 type AdminUserCreateRequest struct {
-	Username    string `json:"username" form:"username" binding:"required,min=3,max=255,unique=users.username"`
-	Name        string `json:"name" form:"name" binding:"required,max=255"`
-	Email       string `json:"email" form:"email" binding:"required,email,max=255,unique=users.email"`
-	Phone       string `json:"phone" form:"phone" binding:"required,max=255"`
-	Password    string `json:"password" form:"password" binding:"required,min=8,max=72" minLength:"8" maxLength:"72"`
-	AdminRoleID uint   `json:"admin_role_id" form:"admin_role_id" binding:"required,exist=admin_roles.id"`
+	Username     string `json:"username" form:"username" binding:"required,min=3,max=255,unique=users.username"`
+	Name         string `json:"name" form:"name" binding:"required,max=255"`
+	Email        string `json:"email" form:"email" binding:"required,email,max=255,unique=users.email"`
+	Phone        string `json:"phone" form:"phone" binding:"required,max=255"`
+	Password     string `json:"password" form:"password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
+	AdminRoleIDs []uint `json:"admin_role_ids" form:"admin_role_ids" binding:"required,min=1,dive,exist=admin_roles.id"`
 }
 
-// UserAssignAdminRoleRequest defines the structure for assigning admin role
+// Admin role assignment modes of UserAssignAdminRoleRequest.
+const (
+	AdminRoleAssignAdd    = "add"
+	AdminRoleAssignRemove = "remove"
+	AdminRoleAssignSet    = "set"
+)
+
+// UserAssignAdminRoleRequest changes a user's admin roles. Mode "add" grants
+// the listed roles on top of the current ones, "remove" takes them away and
+// "set" replaces the whole set with them.
 type UserAssignAdminRoleRequest struct {
-	AdminRoleID uint `json:"admin_role_id" form:"admin_role_id" binding:"required,exist=admin_roles.id"`
+	Mode         string `json:"mode" form:"mode" binding:"required,oneof=add remove set" enums:"add,remove,set"`
+	AdminRoleIDs []uint `json:"admin_role_ids" form:"admin_role_ids" binding:"required,min=1,dive,exist=admin_roles.id"`
 }
 
 // ChangeAdminPasswordRequest defines the structure for root changing an admin's password.
-// max=72: bcrypt rejects passwords longer than 72 bytes, so validate up front
-// instead of surfacing a 500 from the hasher.
+// max=128 matches security.MaxPasswordBytes, like every password field.
 type ChangeAdminPasswordRequest struct {
-	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=8,max=72" minLength:"8" maxLength:"72"`
+	NewPassword string `json:"new_password" form:"new_password" binding:"required,min=8,max=128" minLength:"8" maxLength:"128"`
 }
 
 // UserResponse defines the structure for user response
 type UserResponse struct {
-	ID           uint               `json:"id"`
-	Username     string             `json:"username"`
-	Name         string             `json:"name"`
-	BusinessName string             `json:"business_name"`
-	Email        string             `json:"email"`
-	Phone        string             `json:"phone"`
-	IsActive     bool               `json:"is_active"`
-	AdminRoleID  *uint              `json:"admin_role_id"`
-	Role         string             `json:"role" enums:"user,admin,root"`
-	CreatedAt    time.Time          `json:"created_at"`
-	AdminRole    *AdminRoleResponse `json:"admin_role,omitempty"`
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	BusinessName string `json:"business_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	IsActive     bool   `json:"is_active"`
+	Role         string `json:"role" enums:"user,admin,root"`
+	// AuthProvider is "ldap" for accounts provisioned from the directory,
+	// whose password cannot be changed here, and "local" otherwise.
+	AuthProvider string `json:"auth_provider" enums:"local,ldap"`
+	// EmailVerifiedAt is nil until the user confirms their address.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// LockedUntil is set while the account is locked out after repeated
+	// failed logins; it may lie in the past until the next login clears it.
+	LockedUntil *time.Time `json:"locked_until"`
+	CreatedAt   time.Time  `json:"created_at"`
+	// AdminRoleIDs and AdminRoles list every admin role the user holds; the
+	// user is granted the union of their permissions.
+	AdminRoleIDs []uint               `json:"admin_role_ids"`
+	AdminRoles   []*AdminRoleResponse `json:"admin_roles"`
 }
@@ -8,17 +8,33 @@ import (
 )
 
 var AdminRole = struct {
-	ID          field.Number[uint]
-	Name        field.String
-	Description field.String
-	IsActive    field.Bool
-	Permissions field.Slice[string]
-	Logs        field.Slice[models.Log]
+	ID                   field.Number[uint]
+	Name                 field.String
+	Description          field.String
+	IsActive             field.Bool
+	Permissions          field.Slice[string]
+	ParentRoleID         field.Number[uint]
+	EffectivePermissions field.Slice[models.AdminRolePermission]
+	Denials              field.Slice[string]
+	EffectiveDenials     field.Slice[models.AdminRolePermission]
+	Logs                 field.Slice[models.Log]
 }{
-	ID:          field.Number[uint]{}.WithColumn("id"),
-	Name:        field.String{}.WithColumn("name"),
-	Description: field.String{}.WithColumn("description"),
-	IsActive:    field.Bool{}.WithColumn("is_active"),
-	Permissions: field.Slice[string]{}.WithName("Permissions"),
-	Logs:        field.Slice[models.Log]{}.WithName("Logs"),
+	ID:                   field.Number[uint]{}.WithColumn("id"),
+	Name:                 field.String{}.WithColumn("name"),
+	Description:          field.String{}.WithColumn("description"),
+	IsActive:             field.Bool{}.WithColumn("is_active"),
+	Permissions:          field.Slice[string]{}.WithName("Permissions"),
+	ParentRoleID:         field.Number[uint]{}.WithColumn("parent_role_id"),
+	EffectivePermissions: field.Slice[models.AdminRolePermission]{}.WithName("EffectivePermissions"),
+	Denials:              field.Slice[string]{}.WithName("Denials"),
+	EffectiveDenials:     field.Slice[models.AdminRolePermission]{}.WithName("EffectiveDenials"),
+	Logs:                 field.Slice[models.Log]{}.WithName("Logs"),
+}
+
+var AdminRolePermission = struct {
+	Permission field.String
+	Inherited  field.Bool
+}{
+	Permission: field.String{}.WithColumn("permission"),
+	Inherited:  field.Bool{}.WithColumn("inherited"),
 }
@@ -0,0 +1,36 @@
+// Code generated by 'gorm.io/cli/gorm'. DO NOT EDIT.
+
+package generated
+
+import (
+	"github.com/PhantomX7/athleton/internal/models"
+	"gorm.io/cli/gorm/field"
+)
+
+var APIKey = struct {
+	ID          field.Number[uint]
+	UserID      field.Number[uint]
+	Name        field.String
+	Prefix      field.String
+	KeyHash     field.String
+	Permissions field.Slice[string]
+	ExpiresAt   field.Time
+	LastUsedAt  field.Time
+	RevokedAt   field.Time
+	CreatedAt   field.Time
+	UpdatedAt   field.Time
+	User        field.Struct[models.User]
+}{
+	ID:          field.Number[uint]{}.WithColumn("id"),
+	UserID:      field.Number[uint]{}.WithColumn("user_id"),
+	Name:        field.String{}.WithColumn("name"),
+	Prefix:      field.String{}.WithColumn("prefix"),
+	KeyHash:     field.String{}.WithColumn("key_hash"),
+	Permissions: field.Slice[string]{}.WithName("Permissions"),
+	ExpiresAt:   field.Time{}.WithColumn("expires_at"),
+	LastUsedAt:  field.Time{}.WithColumn("last_used_at"),
+	RevokedAt:   field.Time{}.WithColumn("revoked_at"),
+	CreatedAt:   field.Time{}.WithColumn("created_at"),
+	UpdatedAt:   field.Time{}.WithColumn("updated_at"),
+	User:        field.Struct[models.User]{}.WithName("User"),
+}
@@ -8,19 +8,21 @@ import (
 )
 
 var Log = struct {
-	ID         field.Number[uint]
-	UserID     field.Number[uint]
-	Action     field.Struct[models.LogAction]
-	EntityType field.String
-	EntityID   field.Number[uint]
-	Message    field.String
-	User       field.Struct[models.User]
+	ID             field.Number[uint]
+	UserID         field.Number[uint]
+	ImpersonatorID field.Number[uint]
+	Action         field.Struct[models.LogAction]
+	EntityType     field.String
+	EntityID       field.Number[uint]
+	Message        field.String
+	User           field.Struct[models.User]
 }{
-	ID:         field.Number[uint]{}.WithColumn("id"),
-	UserID:     field.Number[uint]{}.WithColumn("user_id"),
-	Action:     field.Struct[models.LogAction]{}.WithName("Action"),
-	EntityType: field.String{}.WithColumn("entity_type"),
-	EntityID:   field.Number[uint]{}.WithColumn("entity_id"),
-	Message:    field.String{}.WithColumn("message"),
-	User:       field.Struct[models.User]{}.WithName("User"),
+	ID:             field.Number[uint]{}.WithColumn("id"),
+	UserID:         field.Number[uint]{}.WithColumn("user_id"),
+	ImpersonatorID: field.Number[uint]{}.WithColumn("impersonator_id"),
+	Action:         field.Struct[models.LogAction]{}.WithName("Action"),
+	EntityType:     field.String{}.WithColumn("entity_type"),
+	EntityID:       field.Number[uint]{}.WithColumn("entity_id"),
+	Message:        field.String{}.WithColumn("message"),
+	User:           field.Struct[models.User]{}.WithName("User"),
 }
@@ -0,0 +1,22 @@
+// Code generated by 'gorm.io/cli/gorm'. DO NOT EDIT.
+
+package generated
+
+import (
+	"github.com/PhantomX7/athleton/internal/models"
+	"gorm.io/cli/gorm/field"
+)
+
+var PasswordHistory = struct {
+	ID           field.Number[uint]
+	UserID       field.Number[uint]
+	PasswordHash field.String
+	CreatedAt    field.Time
+	User         field.Struct[models.User]
+}{
+	ID:           field.Number[uint]{}.WithColumn("id"),
+	UserID:       field.Number[uint]{}.WithColumn("user_id"),
+	PasswordHash: field.String{}.WithColumn("password_hash"),
+	CreatedAt:    field.Time{}.WithColumn("created_at"),
+	User:         field.Struct[models.User]{}.WithName("User"),
+}
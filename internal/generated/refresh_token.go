@@ -14,9 +14,16 @@ var RefreshToken = struct {
 	Token             field.String
 	PreviousTokenHash field.String
 	ExpiresAt         field.Time
+	UserAgent         field.String
+	IPAddress         field.String
+	LastUsedAt        field.Time
+	LoggedInAt        field.Time
+	RememberMe        field.Bool
 	CreatedAt         field.Time
 	UpdatedAt         field.Time
 	RevokedAt         field.Time
+	AuthenticatedAt   field.Time
+	ImpersonatorID    field.Number[uint]
 	User              field.Struct[models.User]
 }{
 	ID:                field.Field[uuid.UUID]{}.WithColumn("id"),
@@ -24,8 +31,15 @@ var RefreshToken = struct {
 	Token:             field.String{}.WithColumn("token"),
 	PreviousTokenHash: field.String{}.WithColumn("previous_token_hash"),
 	ExpiresAt:         field.Time{}.WithColumn("expires_at"),
+	UserAgent:         field.String{}.WithColumn("user_agent"),
+	IPAddress:         field.String{}.WithColumn("ip_address"),
+	LastUsedAt:        field.Time{}.WithColumn("last_used_at"),
+	LoggedInAt:        field.Time{}.WithColumn("logged_in_at"),
+	RememberMe:        field.Bool{}.WithColumn("remember_me"),
 	CreatedAt:         field.Time{}.WithColumn("created_at"),
 	UpdatedAt:         field.Time{}.WithColumn("updated_at"),
 	RevokedAt:         field.Time{}.WithColumn("revoked_at"),
+	AuthenticatedAt:   field.Time{}.WithColumn("authenticated_at"),
+	ImpersonatorID:    field.Number[uint]{}.WithColumn("impersonator_id"),
 	User:              field.Struct[models.User]{}.WithName("User"),
 }
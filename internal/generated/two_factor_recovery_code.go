@@ -0,0 +1,24 @@
+// Code generated by 'gorm.io/cli/gorm'. DO NOT EDIT.
+
+package generated
+
+import (
+	"github.com/PhantomX7/athleton/internal/models"
+	"gorm.io/cli/gorm/field"
+)
+
+var TwoFactorRecoveryCode = struct {
+	ID        field.Number[uint]
+	UserID    field.Number[uint]
+	CodeHash  field.String
+	UsedAt    field.Time
+	CreatedAt field.Time
+	User      field.Struct[models.User]
+}{
+	ID:        field.Number[uint]{}.WithColumn("id"),
+	UserID:    field.Number[uint]{}.WithColumn("user_id"),
+	CodeHash:  field.String{}.WithColumn("code_hash"),
+	UsedAt:    field.Time{}.WithColumn("used_at"),
+	CreatedAt: field.Time{}.WithColumn("created_at"),
+	User:      field.Struct[models.User]{}.WithName("User"),
+}
@@ -8,31 +8,45 @@ import (
 )
 
 var User = struct {
-	ID                field.Number[uint]
-	Username          field.String
-	Name              field.String
-	BusinessName      field.String
-	Email             field.String
-	Phone             field.String
-	IsActive          field.Bool
-	Role              field.Struct[models.UserRole]
-	AdminRoleID       field.Number[uint]
-	Password          field.String
-	PasswordChangedAt field.Time
-	AdminRole         field.Struct[models.AdminRole]
-	Logs              field.Slice[models.Log]
+	ID                  field.Number[uint]
+	Username            field.String
+	Name                field.String
+	BusinessName        field.String
+	Email               field.String
+	Phone               field.String
+	IsActive            field.Bool
+	Role                field.Struct[models.UserRole]
+	Password            field.String
+	AuthProvider        field.Struct[models.UserAuthProvider]
+	PasswordChangedAt   field.Time
+	EmailVerifiedAt     field.Time
+	TwoFactorSecret     field.String
+	TwoFactorEnabledAt  field.Time
+	TwoFactorLastStep   field.Number[int64]
+	FailedLoginAttempts field.Number[int]
+	LastFailedLoginAt   field.Time
+	LockedUntil         field.Time
+	AdminRoles          field.Slice[models.AdminRole]
+	Logs                field.Slice[models.Log]
 }{
-	ID:                field.Number[uint]{}.WithColumn("id"),
-	Username:          field.String{}.WithColumn("username"),
-	Name:              field.String{}.WithColumn("name"),
-	BusinessName:      field.String{}.WithColumn("business_name"),
-	Email:             field.String{}.WithColumn("email"),
-	Phone:             field.String{}.WithColumn("phone"),
-	IsActive:          field.Bool{}.WithColumn("is_active"),
-	Role:              field.Struct[models.UserRole]{}.WithName("Role"),
-	AdminRoleID:       field.Number[uint]{}.WithColumn("admin_role_id"),
-	Password:          field.String{}.WithColumn("password"),
-	PasswordChangedAt: field.Time{}.WithColumn("password_changed_at"),
-	AdminRole:         field.Struct[models.AdminRole]{}.WithName("AdminRole"),
-	Logs:              field.Slice[models.Log]{}.WithName("Logs"),
+	ID:                  field.Number[uint]{}.WithColumn("id"),
+	Username:            field.String{}.WithColumn("username"),
+	Name:                field.String{}.WithColumn("name"),
+	BusinessName:        field.String{}.WithColumn("business_name"),
+	Email:               field.String{}.WithColumn("email"),
+	Phone:               field.String{}.WithColumn("phone"),
+	IsActive:            field.Bool{}.WithColumn("is_active"),
+	Role:                field.Struct[models.UserRole]{}.WithName("Role"),
+	Password:            field.String{}.WithColumn("password"),
+	AuthProvider:        field.Struct[models.UserAuthProvider]{}.WithName("AuthProvider"),
+	PasswordChangedAt:   field.Time{}.WithColumn("password_changed_at"),
+	EmailVerifiedAt:     field.Time{}.WithColumn("email_verified_at"),
+	TwoFactorSecret:     field.String{}.WithColumn("two_factor_secret"),
+	TwoFactorEnabledAt:  field.Time{}.WithColumn("two_factor_enabled_at"),
+	TwoFactorLastStep:   field.Number[int64]{}.WithColumn("two_factor_last_step"),
+	FailedLoginAttempts: field.Number[int]{}.WithColumn("failed_login_attempts"),
+	LastFailedLoginAt:   field.Time{}.WithColumn("last_failed_login_at"),
+	LockedUntil:         field.Time{}.WithColumn("locked_until"),
+	AdminRoles:          field.Slice[models.AdminRole]{}.WithName("AdminRoles"),
+	Logs:                field.Slice[models.Log]{}.WithName("Logs"),
 }
@@ -0,0 +1,30 @@
+// Code generated by 'gorm.io/cli/gorm'. DO NOT EDIT.
+
+package generated
+
+import (
+	"github.com/PhantomX7/athleton/internal/models"
+	"gorm.io/cli/gorm/field"
+)
+
+var UserIdentity = struct {
+	ID          field.Number[uint]
+	UserID      field.Number[uint]
+	Provider    field.String
+	Subject     field.String
+	Email       field.String
+	LastLoginAt field.Time
+	CreatedAt   field.Time
+	UpdatedAt   field.Time
+	User        field.Struct[models.User]
+}{
+	ID:          field.Number[uint]{}.WithColumn("id"),
+	UserID:      field.Number[uint]{}.WithColumn("user_id"),
+	Provider:    field.String{}.WithColumn("provider"),
+	Subject:     field.String{}.WithColumn("subject"),
+	Email:       field.String{}.WithColumn("email"),
+	LastLoginAt: field.Time{}.WithColumn("last_login_at"),
+	CreatedAt:   field.Time{}.WithColumn("created_at"),
+	UpdatedAt:   field.Time{}.WithColumn("updated_at"),
+	User:        field.Struct[models.User]{}.WithName("User"),
+}
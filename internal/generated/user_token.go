@@ -0,0 +1,32 @@
+// Code generated by 'gorm.io/cli/gorm'. DO NOT EDIT.
+
+package generated
+
+import (
+	"github.com/PhantomX7/athleton/internal/models"
+	"gorm.io/cli/gorm/field"
+)
+
+var UserToken = struct {
+	ID         field.Number[uint]
+	UserID     field.Number[uint]
+	Purpose    field.Struct[models.UserTokenPurpose]
+	TokenHash  field.String
+	ExpiresAt  field.Time
+	NewEmail   field.String
+	ConsumedAt field.Time
+	CreatedAt  field.Time
+	UpdatedAt  field.Time
+	User       field.Struct[models.User]
+}{
+	ID:         field.Number[uint]{}.WithColumn("id"),
+	UserID:     field.Number[uint]{}.WithColumn("user_id"),
+	Purpose:    field.Struct[models.UserTokenPurpose]{}.WithName("Purpose"),
+	TokenHash:  field.String{}.WithColumn("token_hash"),
+	ExpiresAt:  field.Time{}.WithColumn("expires_at"),
+	NewEmail:   field.String{}.WithColumn("new_email"),
+	ConsumedAt: field.Time{}.WithColumn("consumed_at"),
+	CreatedAt:  field.Time{}.WithColumn("created_at"),
+	UpdatedAt:  field.Time{}.WithColumn("updated_at"),
+	User:       field.Struct[models.User]{}.WithName("User"),
+}
@@ -0,0 +1,110 @@
+package adminrole_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+type deniedRolePayload struct {
+	ID               uint     `json:"id"`
+	Permissions      []string `json:"permissions"`
+	Denials          []string `json:"denials"`
+	EffectiveDenials []struct {
+		Permission string `json:"permission"`
+		Inherited  bool   `json:"inherited"`
+	} `json:"effective_denials"`
+}
+
+// TestAdminRoleDenyRules builds a "Support Lead" who can do everything on
+// users and admins except delete admins, checks the deny rule wins over the
+// wildcard grant on live requests, and that a holder cannot lift the rule
+// from their own role while they may still tighten it.
+func TestAdminRoleDenyRules(t *testing.T) {
+	app := harness.New(t)
+	rootTokens := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Support Lead",
+		"permissions": []string{"user:*", "admin_user:*"},
+		"denials":     []string{permissions.AdminUserDelete.String()},
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var lead deniedRolePayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &lead)
+	require.Equal(t, []string{"user:*", "admin_user:*"}, lead.Permissions)
+	require.Equal(t, []string{permissions.AdminUserDelete.String()}, lead.Denials)
+	require.Len(t, lead.EffectiveDenials, 1)
+
+	// The fixture admin holds Support Lead and the fixture role, which lets
+	// them edit admin roles.
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{
+		permissions.AdminRoleRead.String(),
+		permissions.AdminRoleUpdate.String(),
+	}))
+	app.SetAdminRoles(t, app.AdminUser.ID, app.AdminRole.ID, lead.ID)
+
+	other := models.User{
+		Username: "other-admin",
+		Name:     "Other Admin",
+		Email:    "other-admin@test.local",
+		Phone:    "+620000000009",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: app.RootUser.Password,
+	}
+	require.NoError(t, app.DB.Create(&other).Error)
+
+	tokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec = app.Request(t, http.MethodPatch, "/api/v1/admin/user/"+harness.Itoa(other.ID), map[string]string{
+		"name": "Renamed Admin",
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(other.ID), nil, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID), nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// /auth/me reports the deny rule.
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me struct {
+		AdminRoles []deniedRolePayload `json:"admin_roles"`
+	}
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	require.Len(t, me.AdminRoles, 2)
+	for _, role := range me.AdminRoles {
+		if role.ID == lead.ID {
+			require.Equal(t, []string{permissions.AdminUserDelete.String()}, role.Denials)
+		}
+	}
+
+	// Lifting the deny rule would grant admin_user:delete, which the caller
+	// is denied themselves.
+	path := fmt.Sprintf("/api/v1/admin/admin-role/%d", lead.ID)
+	rec = app.Request(t, http.MethodPatch, path, map[string]any{"denials": []string{}}, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Equal(t, []string{permissions.AdminUserDelete.String()}, app.Casbin.GetDirectRoleDenials(lead.ID))
+
+	// Tightening it needs no authority.
+	rec = app.Request(t, http.MethodPatch, path, map[string]any{
+		"denials": []string{permissions.AdminUserDelete.String(), permissions.AdminUserImpersonate.String()},
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &lead)
+	require.Equal(t, []string{permissions.AdminUserDelete.String(), permissions.AdminUserImpersonate.String()}, lead.Denials)
+
+	// Root may lift it.
+	rec = app.Request(t, http.MethodPatch, path, map[string]any{"denials": []string{}}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &lead)
+	require.Empty(t, lead.Denials)
+}
@@ -0,0 +1,149 @@
+package adminrole_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+type effectivePermissionPayload struct {
+	Permission string `json:"permission"`
+	Inherited  bool   `json:"inherited"`
+}
+
+type roleHierarchyPayload struct {
+	ID                   uint                         `json:"id"`
+	ParentRoleID         *uint                        `json:"parent_role_id"`
+	Permissions          []string                     `json:"permissions"`
+	EffectivePermissions []effectivePermissionPayload `json:"effective_permissions"`
+}
+
+// TestAdminRoleInheritsParentPermissions builds "Support" <- "Senior Support"
+// over HTTP, moves the fixture admin onto the child, and checks that the
+// parent's grant is both enforced and reported as inherited, that a cycle is
+// refused, and that a parent cannot be deleted while a role inherits from it.
+func TestAdminRoleInheritsParentPermissions(t *testing.T) {
+	app := harness.New(t)
+	rootTokens := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Support",
+		"permissions": []string{permissions.LogRead.String()},
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var support roleHierarchyPayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &support)
+
+	// A child needs no permissions of its own.
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":           "Senior Support",
+		"parent_role_id": support.ID,
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var senior roleHierarchyPayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &senior)
+	require.Equal(t, &support.ID, senior.ParentRoleID)
+	require.Empty(t, senior.Permissions)
+	require.Equal(t, []effectivePermissionPayload{{Permission: permissions.LogRead.String(), Inherited: true}}, senior.EffectivePermissions)
+
+	// An admin holding the child reaches the parent's endpoint.
+	app.SetAdminRoles(t, app.AdminUser.ID, senior.ID)
+	adminTokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me struct {
+		AdminRoles []roleHierarchyPayload `json:"admin_roles"`
+	}
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	require.Len(t, me.AdminRoles, 1)
+	require.Equal(t, senior.EffectivePermissions, me.AdminRoles[0].EffectivePermissions)
+
+	// Making the parent inherit from its own child is a cycle.
+	rec = app.Request(t, http.MethodPatch, fmt.Sprintf("/api/v1/admin/admin-role/%d", support.ID), map[string]any{
+		"parent_role_id": senior.ID,
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	require.Nil(t, app.Casbin.GetRoleParent(support.ID))
+
+	rec = app.Request(t, http.MethodDelete, fmt.Sprintf("/api/v1/admin/admin-role/%d", support.ID), nil, rootTokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// Detaching the child (parent_role_id 0) revokes the inherited grant.
+	rec = app.Request(t, http.MethodPatch, fmt.Sprintf("/api/v1/admin/admin-role/%d", senior.ID), map[string]any{
+		"parent_role_id": 0,
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
+
+// TestAdminRoleParentDeactivationStopsInheritance deactivates the parent of
+// the role an admin holds: the admin keeps the child's own grant but loses
+// the inherited one at once, /auth/me stops listing it as inherited, and
+// reactivating the parent hands it back.
+func TestAdminRoleParentDeactivationStopsInheritance(t *testing.T) {
+	app := harness.New(t)
+	rootTokens := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Billing",
+		"permissions": []string{permissions.LogRead.String()},
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var billing roleHierarchyPayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &billing)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":           "Billing Lead",
+		"parent_role_id": billing.ID,
+		"permissions":    []string{permissions.UserRead.String()},
+	}, rootTokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var lead roleHierarchyPayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &lead)
+
+	app.SetAdminRoles(t, app.AdminUser.ID, lead.ID)
+	adminTokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	me := func() []effectivePermissionPayload {
+		t.Helper()
+		rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, adminTokens.AccessToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body struct {
+			AdminRoles []roleHierarchyPayload `json:"admin_roles"`
+		}
+		harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &body)
+		require.Len(t, body.AdminRoles, 1)
+		return body.AdminRoles[0].EffectivePermissions
+	}
+	own := effectivePermissionPayload{Permission: permissions.UserRead.String()}
+	inherited := effectivePermissionPayload{Permission: permissions.LogRead.String(), Inherited: true}
+	require.Equal(t, []effectivePermissionPayload{own, inherited}, me())
+
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/deactivate", billing.ID), nil, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/user", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, "the child's own grant is untouched: %s", rec.Body.String())
+	require.Equal(t, []effectivePermissionPayload{own}, me())
+
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/activate", billing.ID), nil, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, []effectivePermissionPayload{own, inherited}, me())
+}
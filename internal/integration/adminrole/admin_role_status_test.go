@@ -0,0 +1,97 @@
+package adminrole_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+type meRolePayload struct {
+	AdminRoles []struct {
+		ID          uint     `json:"id"`
+		IsActive    bool     `json:"is_active"`
+		Permissions []string `json:"permissions"`
+	} `json:"admin_roles"`
+}
+
+// TestAdminRoleDeactivationDeniesHoldersImmediately deactivates the role an
+// admin holds and checks that the admin's live token loses every permission
+// at once, that /auth/me reports the role as inactive, and that activating
+// the role restores the untouched grants. Both transitions are audited.
+func TestAdminRoleDeactivationDeniesHoldersImmediately(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.LogRead.String()}))
+
+	rootTokens := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	adminTokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/deactivate", app.AdminRole.ID), nil, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var role adminRolePayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &role)
+	require.Equal(t, []string{permissions.LogRead.String()}, role.Permissions, "deactivation keeps the grants")
+	app.WaitForAuditLog(t, models.LogActionDeactivate, app.AdminRole.ID)
+
+	// The same access token is denied without re-login.
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Equal(t, "insufficient permissions", harness.DecodeEnvelope(t, rec).Message)
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me meRolePayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	require.Len(t, me.AdminRoles, 1)
+	require.False(t, me.AdminRoles[0].IsActive)
+	require.Empty(t, me.AdminRoles[0].Permissions)
+
+	var stored models.AdminRole
+	require.NoError(t, app.DB.First(&stored, app.AdminRole.ID).Error)
+	require.False(t, stored.IsActive)
+
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/activate", app.AdminRole.ID), nil, rootTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionActivate, app.AdminRole.ID)
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/log", nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+// TestAdminRoleDeactivationGuards covers the refusals: an admin cannot
+// deactivate the role they hold, and cannot reactivate a role carrying
+// permissions they do not hold themselves.
+func TestAdminRoleDeactivationGuards(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{
+		permissions.AdminRoleUpdate.String(),
+	}))
+
+	adminTokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/deactivate", app.AdminRole.ID), nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Equal(t, "cannot deactivate your own admin role", harness.DecodeEnvelope(t, rec).Message)
+
+	broader := models.AdminRole{Name: "Auditor", IsActive: true}
+	require.NoError(t, app.DB.Create(&broader).Error)
+	require.NoError(t, app.Casbin.AddRolePermissions(broader.ID, []string{permissions.LogRead.String()}))
+
+	// Deactivating another role needs no matching grants...
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/deactivate", broader.ID), nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// ...but reactivating it hands log:read back out, which this admin lacks.
+	rec = app.Request(t, http.MethodPost, fmt.Sprintf("/api/v1/admin/admin-role/%d/activate", broader.ID), nil, adminTokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.False(t, app.Casbin.IsRoleActive(broader.ID))
+}
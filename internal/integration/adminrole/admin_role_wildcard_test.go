@@ -0,0 +1,91 @@
+package adminrole_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+// TestAdminRoleWildcardGrants gives the fixture admin "*:read" and checks
+// that it reads every resource but writes none, that it may hand "*:read"
+// on to a new role, and that it may not grant "user:*" or "*:*".
+func TestAdminRoleWildcardGrants(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{
+		"*:read",
+		permissions.AdminRoleCreate.String(),
+	}))
+	tokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	for _, path := range []string{"/api/v1/admin/log", "/api/v1/admin/config", "/api/v1/admin/admin-role"} {
+		rec := app.Request(t, http.MethodGet, path, nil, tokens.AccessToken)
+		require.Equal(t, http.StatusOK, rec.Code, "%s: %s", path, rec.Body.String())
+	}
+	rec := app.Request(t, http.MethodPatch, "/api/v1/admin/config/1", map[string]any{"value": "x"}, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	// The catalog offers the wildcard grants.
+	rec = app.Request(t, http.MethodGet, "/api/v1/admin/admin-role/permissions", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var catalog map[string][]map[string]string
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &catalog)
+	require.Contains(t, catalog, permissions.Wildcard)
+	require.Contains(t, catalog[permissions.ResourceUser], map[string]string{
+		"permission":  "user:*",
+		"action":      permissions.Wildcard,
+		"description": "All user permissions",
+	})
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Auditor",
+		"permissions": []string{"*:read"},
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created adminRolePayload
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &created)
+	require.Equal(t, []string{"*:read"}, created.Permissions)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Support",
+		"permissions": []string{"user:*"},
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Everything",
+		"permissions": []string{"*:*"},
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+// TestAdminRoleExplicitGrantsCannotMintWildcard gives the fixture admin every
+// user permission one by one. They may pass those on, but not as "user:*" or
+// "user:manage": the stored pattern would also grant user permissions added
+// later, which they do not hold.
+func TestAdminRoleExplicitGrantsCannotMintWildcard(t *testing.T) {
+	app := harness.New(t)
+	userPerms := permissions.ExpandGrant("user:*")
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID,
+		append([]string{permissions.AdminRoleCreate.String()}, userPerms...)))
+	tokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	for _, grant := range []string{"user:*", "user:manage"} {
+		rec := app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+			"name":        "Support " + grant,
+			"permissions": []string{grant},
+		}, tokens.AccessToken)
+		require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		require.Equal(t, "cannot grant permissions you do not hold: "+grant, harness.DecodeEnvelope(t, rec).Message)
+	}
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/admin-role", map[string]any{
+		"name":        "Support",
+		"permissions": userPerms,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,176 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+func createAPIKey(t *testing.T, app *harness.App, accessToken string, perms ...string) dto.APIKeyCreateResponse {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/api-keys", map[string]any{
+		"name":        "ci-exporter",
+		"permissions": perms,
+	}, accessToken)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var created dto.APIKeyCreateResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &created)
+	require.NotEmpty(t, created.Key)
+	return created
+}
+
+func withAPIKey(t *testing.T, app *harness.App, method, path, key string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, rec := harness.NewRequestWithHeader(t, method, path, "Authorization", "ApiKey "+key)
+	app.Engine.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestAPIKeyIsScopedToItsPermissions — a key only reaches the endpoints its
+// scope names, even when its owner could do more, and each use is audited.
+func TestAPIKeyIsScopedToItsPermissions(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{
+		permissions.UserRead.String(),
+		permissions.LogRead.String(),
+	}))
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	created := createAPIKey(t, app, admin.AccessToken, permissions.UserRead.String())
+
+	rec := withAPIKey(t, app, http.MethodGet, "/api/v1/admin/user", created.Key)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	// The owner holds log:read, but the key was not granted it.
+	rec = withAPIKey(t, app, http.MethodGet, "/api/v1/admin/log", created.Key)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	used := app.WaitForAuditLog(t, models.LogActionUseAPIKey, created.ID)
+	require.Equal(t, app.AdminUser.ID, *used.UserID)
+	require.Contains(t, used.Message, "/api/v1/admin/user")
+
+	// Account self-service never accepts a key.
+	rec = withAPIKey(t, app, http.MethodGet, "/api/v1/auth/me", created.Key)
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	rec = withAPIKey(t, app, http.MethodGet, "/api/v1/auth/api-keys", created.Key)
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+}
+
+// TestAPIKeyFollowsOwnerRole — taking a permission away from the owner's role
+// takes it away from the key too.
+func TestAPIKeyFollowsOwnerRole(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.UserRead.String()}))
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	created := createAPIKey(t, app, admin.AccessToken, permissions.UserRead.String())
+
+	require.NoError(t, app.Casbin.RemoveRolePermissions(app.AdminRole.ID, []string{permissions.UserRead.String()}))
+
+	rec := withAPIKey(t, app, http.MethodGet, "/api/v1/admin/user", created.Key)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
+
+// TestAPIKeyCreationCannotExceedOwner — a key cannot be granted a permission
+// its owner does not hold.
+func TestAPIKeyCreationCannotExceedOwner(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.UserRead.String()}))
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/api-keys", map[string]any{
+		"name":        "too-broad",
+		"permissions": []string{permissions.UserRead.String(), permissions.UserDelete.String()},
+	}, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/api-keys", map[string]any{
+		"name":        "member",
+		"permissions": []string{permissions.UserRead.String()},
+	}, member.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
+
+// TestAPIKeyListAndRevoke — keys are listed without their secret and a
+// revoked key stops working at once.
+func TestAPIKeyListAndRevoke(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.UserRead.String()}))
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	created := createAPIKey(t, app, admin.AccessToken, permissions.UserRead.String())
+	require.Equal(t, http.StatusOK, withAPIKey(t, app, http.MethodGet, "/api/v1/admin/user", created.Key).Code)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/api-keys", nil, admin.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotContains(t, rec.Body.String(), created.Key)
+	var keys []dto.APIKeyResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &keys)
+	require.Len(t, keys, 1)
+	require.Equal(t, created.Prefix, keys[0].Prefix)
+
+	// Another user cannot revoke it.
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	path := "/api/v1/auth/api-keys/" + harness.Itoa(created.ID)
+	require.Equal(t, http.StatusNotFound, app.Request(t, http.MethodDelete, path, nil, root.AccessToken).Code)
+
+	rec = app.Request(t, http.MethodDelete, path, nil, admin.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionRevokeAPIKey, created.ID)
+
+	rec = withAPIKey(t, app, http.MethodGet, "/api/v1/admin/user", created.Key)
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	require.Equal(t, http.StatusNotFound, app.Request(t, http.MethodDelete, path, nil, admin.AccessToken).Code)
+}
+
+// TestAPIKeyScopeAppliesToAdminAccountChecks — the admin_user grant the user
+// service checks for admin targets is also held to the key's scope: a key
+// scoped to user:read and user:delete neither lists nor deletes admin
+// accounts, even though its owner holds admin_user:*.
+func TestAPIKeyScopeAppliesToAdminAccountChecks(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{
+		permissions.UserRead.String(),
+		permissions.UserDelete.String(),
+		"admin_user:*",
+	}))
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	created := createAPIKey(t, app, admin.AccessToken, permissions.UserRead.String(), permissions.UserDelete.String())
+
+	other := models.User{
+		Username: "other-admin",
+		Name:     "Other Admin",
+		Email:    "other-admin@test.local",
+		Phone:    "+620000000009",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: app.RootUser.Password,
+	}
+	require.NoError(t, app.DB.Create(&other).Error)
+
+	rec := withAPIKey(t, app, http.MethodGet, "/api/v1/admin/user", created.Key)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var listed []dto.UserResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &listed)
+	require.NotEmpty(t, listed)
+	for _, u := range listed {
+		require.Equal(t, models.UserRoleUser.ToString(), u.Role, "key listed %s", u.Username)
+	}
+
+	rec = withAPIKey(t, app, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(other.ID), created.Key)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = withAPIKey(t, app, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID), created.Key)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// The owner's own session still reaches the admin account.
+	rec = app.Request(t, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(other.ID), nil, admin.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
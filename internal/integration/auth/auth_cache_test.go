@@ -0,0 +1,36 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+)
+
+// TestAuthorizerCacheIsInvalidatedOnDeletion — the harness runs with the
+// authorizer cache on, so a member whose user and session rows are cached
+// must still be locked out the moment an admin deletes the account, and the
+// cache traffic shows up in the metrics exposition.
+func TestAuthorizerCacheIsInvalidatedOnDeletion(t *testing.T) {
+	app := harness.New(t)
+
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	for range 2 {
+		rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, member.AccessToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	rec := app.Request(t, http.MethodDelete, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID), nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, member.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	scrape := app.Request(t, http.MethodGet, "/metrics", nil, "")
+	require.Equal(t, http.StatusOK, scrape.Code)
+	require.Contains(t, scrape.Body.String(), `auth_cache_lookups_total{cache="user",result="hit"}`)
+	require.Contains(t, scrape.Body.String(), `auth_cache_lookups_total{cache="session",result="miss"}`)
+}
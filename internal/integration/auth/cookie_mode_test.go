@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+const webAdminOrigin = "https://admin.example.com"
+
+// browser keeps the cookie jar of a single browser tab.
+type browser struct {
+	app     *harness.App
+	cookies map[string]string
+}
+
+// do sends a request carrying the jar's cookies (and, when csrf is set, the
+// CSRF header a well-behaved frontend adds) and folds Set-Cookie back in.
+func (b *browser) do(t *testing.T, method, path string, body any, csrf bool) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(buf)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequestWithContext(context.Background(), method, path, reader)
+	req.Header.Set("Origin", webAdminOrigin)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range b.cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	if csrf {
+		req.Header.Set(authjwt.CSRFHeader, b.cookies[authjwt.CSRFCookie])
+	}
+
+	rec := httptest.NewRecorder()
+	b.app.Engine.ServeHTTP(rec, req)
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			delete(b.cookies, cookie.Name)
+			continue
+		}
+		b.cookies[cookie.Name] = cookie.Value
+	}
+	return rec
+}
+
+// TestCookieModeSessionLifecycle — the web admin logs in, works, refreshes
+// and logs out without ever seeing a token, and every write needs the CSRF
+// header.
+func TestCookieModeSessionLifecycle(t *testing.T) {
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.Auth.Mode = config.AuthModeCookie
+		cfg.Server.CORSAllowedOrigins = []string{webAdminOrigin}
+	})
+	b := &browser{app: app, cookies: map[string]string{}}
+
+	rec := b.do(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": harness.AdminUsername,
+		"password": harness.TestPassword,
+	}, false)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	require.Empty(t, tokens.AccessToken, "tokens travel only in HttpOnly cookies")
+	require.Empty(t, tokens.RefreshToken)
+	require.NotEmpty(t, b.cookies[authjwt.AccessTokenCookie])
+	require.NotEmpty(t, b.cookies[authjwt.RefreshTokenCookie])
+	require.NotEmpty(t, b.cookies[authjwt.CSRFCookie])
+
+	rec = b.do(t, http.MethodGet, "/api/v1/auth/me", nil, false)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = b.do(t, http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil, false)
+	require.Equal(t, http.StatusForbidden, rec.Code, "a forged cross-site write has no CSRF header")
+	rec = b.do(t, http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil, true)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	oldRefresh := b.cookies[authjwt.RefreshTokenCookie]
+	rec = b.do(t, http.MethodPost, "/api/v1/auth/refresh", nil, true)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotEqual(t, oldRefresh, b.cookies[authjwt.RefreshTokenCookie], "refresh rotates the cookie")
+
+	accessCookie := b.cookies[authjwt.AccessTokenCookie]
+	rec = b.do(t, http.MethodPost, "/api/v1/auth/logout", nil, true)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Empty(t, b.cookies, "logout clears every auth cookie")
+
+	b.cookies[authjwt.AccessTokenCookie] = accessCookie
+	rec = b.do(t, http.MethodGet, "/api/v1/auth/me", nil, false)
+	require.Equal(t, http.StatusForbidden, rec.Code, "the session behind a replayed cookie is revoked")
+}
+
+// TestCookieModeStillAcceptsBearerClients — machine and mobile clients keep
+// using the Authorization header, with no CSRF token, in cookie mode.
+func TestCookieModeStillAcceptsBearerClients(t *testing.T) {
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.Auth.Mode = config.AuthModeCookie
+	})
+	b := &browser{app: app, cookies: map[string]string{}}
+	rec := b.do(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": harness.MemberUsername,
+		"password": harness.TestPassword,
+	}, false)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil, b.cookies[authjwt.AccessTokenCookie])
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// TestDataExport — a user asks for their data, and the returned link serves
+// a ZIP archive without a session until it expires.
+func TestDataExport(t *testing.T) {
+	app := harness.New(t)
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPatch, "/api/v1/auth/me", map[string]string{"name": "Exported Member"}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionUpdate, app.MemberUser.ID)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/me/export", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var export dto.DataExportResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &export)
+	require.Equal(t, "/api/v1/auth/me/export/download", export.DownloadURL)
+	require.NotEmpty(t, export.Token)
+	require.False(t, export.ExpiresAt.IsZero())
+
+	// The token works without a session, and more than once.
+	download := map[string]string{"token": export.Token}
+	for range 2 {
+		rec = app.Request(t, http.MethodPost, export.DownloadURL, download, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	require.Contains(t, files["profile.json"], app.MemberUser.Email)
+	require.Contains(t, files["sessions.json"], `"id"`)
+	require.Contains(t, files["audit_logs.json"], "updated their profile")
+	require.Contains(t, files, "security_events.json")
+
+	rec = app.Request(t, http.MethodPost, export.DownloadURL, map[string]string{"token": "forged"}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	// The token is never read from the query string.
+	rec = app.Request(t, http.MethodPost, export.DownloadURL+"?token="+export.Token, map[string]string{}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodGet, export.DownloadURL+"?token="+export.Token, nil, "")
+	require.NotEqual(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// A new token supersedes the previous one.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/me/export", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, export.DownloadURL, download, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+// TestDeleteMe — a user erases their account with their password: the
+// session stops working, the login is gone, the row is anonymised and the
+// audit trail survives without their personal data.
+func TestDeleteMe(t *testing.T) {
+	app := harness.New(t)
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPatch, "/api/v1/auth/me", map[string]string{"phone": "+620000000077"}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionUpdate, app.MemberUser.ID)
+
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/me", map[string]string{"password": "wrong-password-1"}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/me", map[string]string{"password": harness.TestPassword}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": tokens.RefreshToken}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": harness.MemberUsername,
+		"password": harness.TestPassword,
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+
+	var stored models.User
+	require.NoError(t, app.DB.Unscoped().First(&stored, app.MemberUser.ID).Error)
+	require.True(t, stored.DeletedAt.Valid)
+	require.Equal(t, "Deleted User", stored.Name)
+	require.NotEqual(t, app.MemberUser.Email, stored.Email)
+	require.Empty(t, stored.Phone)
+
+	entry := app.WaitForAuditLog(t, models.LogActionDelete, app.MemberUser.ID)
+	require.NotContains(t, entry.Message, app.MemberUser.Name)
+
+	var update models.Log
+	require.NoError(t, app.DB.Where("action = ? AND entity_id = ?", models.LogActionUpdate, app.MemberUser.ID).First(&update).Error)
+	require.NotContains(t, update.Message, app.MemberUser.Name, "the entry is kept but detached from the name")
+}
+
+// TestDeleteMeRedactsEarlierEmails — deleting an account after an email
+// change leaves neither the old nor the new address in the audit trail.
+func TestDeleteMeRedactsEarlierEmails(t *testing.T) {
+	app := harness.New(t)
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	oldAddress := app.MemberUser.Email
+	const newAddress = "erased@test.local"
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/change-email", map[string]string{"email": newAddress}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	token := harness.TokenFromMail(t, app.LastMailTo(t, newAddress))
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/confirm-email-change", map[string]string{"token": token}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	entry := app.WaitForAuditLog(t, models.LogActionChangeEmail, app.MemberUser.ID)
+	require.Contains(t, entry.Message, oldAddress)
+
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/me", map[string]string{"password": harness.TestPassword}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionDelete, app.MemberUser.ID)
+
+	var logs []models.Log
+	require.NoError(t, app.DB.Where("entity_id = ?", app.MemberUser.ID).Find(&logs).Error)
+	require.NotEmpty(t, logs)
+	for _, entry := range logs {
+		require.NotContains(t, entry.Message, oldAddress, entry.Action)
+		require.NotContains(t, entry.Message, newAddress, entry.Action)
+	}
+
+	var history int64
+	require.NoError(t, app.DB.Model(&models.PersonalDataHistory{}).Where("user_id = ?", app.MemberUser.ID).Count(&history).Error)
+	require.Zero(t, history, "the record of earlier values goes with the account")
+}
+
+// TestDeleteMeIsRefusedForRoot — the root account cannot erase itself.
+func TestDeleteMeIsRefusedForRoot(t *testing.T) {
+	app := harness.New(t)
+	tokens := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodDelete, "/api/v1/auth/me", map[string]string{"password": harness.TestPassword}, tokens.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,124 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+const verifyEmailAddress = "verify.me@test.local"
+
+// register signs up verifyEmailAddress and returns the decoded auth payload.
+func register(t *testing.T, app *harness.App) harness.TokenPair {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/register", map[string]string{
+		"name":          "Verify Me",
+		"business_name": "Verify Business",
+		"email":         verifyEmailAddress,
+		"phone":         "+620000000077",
+		"password":      "register-pass-1",
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	return tokens
+}
+
+func verifyEmail(t *testing.T, app *harness.App, token string) int {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/verify-email", map[string]string{
+		"token": token,
+	}, "")
+	return rec.Code
+}
+
+func getMe(t *testing.T, app *harness.App, accessToken string) dto.MeResponse {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, accessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me dto.MeResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	return me
+}
+
+// TestEmailVerificationFlow — with verification optional, registration still
+// logs the user in, emails a link, and /auth/me reports the address as
+// unverified until that link is redeemed (once).
+func TestEmailVerificationFlow(t *testing.T) {
+	app := harness.New(t)
+
+	tokens := register(t, app)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.False(t, tokens.EmailVerificationRequired)
+
+	me := getMe(t, app, tokens.AccessToken)
+	require.False(t, me.EmailVerified)
+	require.Nil(t, me.EmailVerifiedAt)
+
+	mail := app.LastMailTo(t, verifyEmailAddress)
+	require.Contains(t, mail, "http://frontend.test/verify-email?token=")
+	token := harness.TokenFromMail(t, mail)
+
+	require.Equal(t, http.StatusOK, verifyEmail(t, app, token))
+
+	me = getMe(t, app, tokens.AccessToken)
+	require.True(t, me.EmailVerified)
+	require.NotNil(t, me.EmailVerifiedAt)
+
+	// Single use.
+	require.Equal(t, http.StatusBadRequest, verifyEmail(t, app, token))
+}
+
+// TestRequireEmailVerificationGatesLogin — with AUTH_REQUIRE_EMAIL_VERIFICATION
+// on, registration issues no tokens and login is refused with an explicit
+// message until the newest emailed link is redeemed. Operator-provisioned
+// accounts are unaffected.
+func TestRequireEmailVerificationGatesLogin(t *testing.T) {
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.Auth.RequireEmailVerification = true
+	})
+
+	tokens := register(t, app)
+	require.True(t, tokens.EmailVerificationRequired)
+	require.Empty(t, tokens.AccessToken)
+	require.Empty(t, tokens.RefreshToken)
+	firstToken := harness.TokenFromMail(t, app.LastMailTo(t, verifyEmailAddress))
+
+	login := map[string]string{"username": verifyEmailAddress, "password": "register-pass-1"}
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/login", login, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	require.Equal(t, "email address is not verified", harness.DecodeEnvelope(t, rec).Message)
+
+	// A resend supersedes the first link.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/resend-verification", map[string]string{
+		"email": verifyEmailAddress,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	secondToken := harness.TokenFromMail(t, app.LastMailTo(t, verifyEmailAddress))
+	require.NotEqual(t, firstToken, secondToken)
+
+	require.Equal(t, http.StatusBadRequest, verifyEmail(t, app, firstToken))
+	require.Equal(t, http.StatusOK, verifyEmail(t, app, secondToken))
+
+	session := app.LoginAs(t, verifyEmailAddress, "register-pass-1")
+	require.True(t, getMe(t, app, session.AccessToken).EmailVerified)
+
+	// Nothing more is sent once the address is verified.
+	sent := app.MailCount(t)
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/resend-verification", map[string]string{
+		"email": verifyEmailAddress,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, sent, app.MailCount(t))
+
+	app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+}
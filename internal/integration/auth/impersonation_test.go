@@ -0,0 +1,115 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+func impersonate(t *testing.T, app *harness.App, token string, userID uint) *dto.ImpersonationResponse {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(userID)+"/impersonate", nil, token)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res dto.ImpersonationResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &res)
+	require.NotEmpty(t, res.AccessToken)
+	return &res
+}
+
+// TestRootImpersonationIsAttributedToBothUsers — root acts as the editor,
+// every audited action names both identities, the token cannot widen its own
+// reach, and ending it kills the token without touching root's session.
+func TestRootImpersonationIsAttributedToBothUsers(t *testing.T) {
+	app := harness.New(t)
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.UserUpdate.String()}))
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	res := impersonate(t, app, root.AccessToken, app.AdminUser.ID)
+	require.Equal(t, app.AdminUser.ID, res.User.ID)
+	started := app.WaitForAuditLog(t, models.LogActionImpersonate, app.AdminUser.ID)
+	require.Equal(t, app.RootUser.ID, *started.UserID)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, res.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me dto.MeResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	require.Equal(t, app.AdminUser.ID, me.ID)
+	require.NotNil(t, me.ImpersonatorID)
+	require.Equal(t, app.RootUser.ID, *me.ImpersonatorID)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/unlock", nil, res.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	unlocked := app.WaitForAuditLog(t, models.LogActionUnlockAccount, app.MemberUser.ID)
+	require.Equal(t, app.AdminUser.ID, *unlocked.UserID)
+	require.NotNil(t, unlocked.ImpersonatorID)
+	require.Equal(t, app.RootUser.ID, *unlocked.ImpersonatorID)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/change-password", map[string]string{
+		"old_password": harness.TestPassword,
+		"new_password": "a-brand-new-password-1",
+	}, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/impersonate", nil, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/impersonation/end", nil, res.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	ended := app.WaitForAuditLog(t, models.LogActionEndImpersonation, app.AdminUser.ID)
+	require.Equal(t, app.RootUser.ID, *ended.ImpersonatorID)
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, "an ended impersonation token must stop working")
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, "root's own session is untouched")
+
+	// The impersonation never showed up among the editor's own sessions.
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	require.Len(t, listSessions(t, app, admin.AccessToken), 1)
+}
+
+// TestImpersonationCannotChangeTheAccount — the token may read the target's
+// profile and sessions but not edit the profile or sign the target out.
+func TestImpersonationCannotChangeTheAccount(t *testing.T) {
+	app := harness.New(t)
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	res := impersonate(t, app, root.AccessToken, app.MemberUser.ID)
+
+	sessions := listSessions(t, app, res.AccessToken)
+	require.Len(t, sessions, 1)
+
+	rec := app.Request(t, http.MethodPatch, "/api/v1/auth/me", map[string]string{"name": "Renamed By Support"}, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/sessions/"+sessions[0].ID, nil, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil, res.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	me := getMe(t, app, member.AccessToken)
+	require.Equal(t, app.MemberUser.Name, me.Name, "the member's own session still works and the name is unchanged")
+}
+
+// TestImpersonationRequiresPermission — the editor role holds no
+// user:impersonate grant, and nobody may impersonate root.
+func TestImpersonationRequiresPermission(t *testing.T) {
+	app := harness.New(t)
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/impersonate", nil, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	require.NoError(t, app.Casbin.AddRolePermissions(app.AdminRole.ID, []string{permissions.UserImpersonate.String()}))
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.RootUser.ID)+"/impersonate", nil, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	impersonate(t, app, admin.AccessToken, app.MemberUser.ID)
+}
@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+func writeEd25519Key(t *testing.T) string {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func fetchJWKS(t *testing.T, app *harness.App) dto.JWKSResponse {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	app.Engine.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var doc dto.JWKSResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	return doc
+}
+
+// TestAccessTokensVerifyAgainstJWKS — with an asymmetric key configured, a
+// downstream service holding only the published JWKS can verify our access
+// tokens, and a retired key stays published while it is still accepted.
+func TestAccessTokensVerifyAgainstJWKS(t *testing.T) {
+	oldKey := writeEd25519Key(t)
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.JWT.SigningKeyFile = oldKey
+	})
+
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	require.Equal(t, http.StatusOK, app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken).Code)
+
+	doc := fetchJWKS(t, app)
+	require.Len(t, doc.Keys, 1)
+	jwk := doc.Keys[0]
+	require.Equal(t, "EdDSA", jwk.Alg)
+	require.Equal(t, "sig", jwk.Use)
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	parsed, err := jwt.Parse(tokens.AccessToken, func(token *jwt.Token) (any, error) {
+		require.Equal(t, jwk.Kid, token.Header["kid"])
+		return ed25519.PublicKey(x), nil
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
+	require.NoError(t, err)
+	require.True(t, parsed.Valid)
+
+	// After a rotation the new key is listed first, the old one after it.
+	rotated := harness.New(t, func(cfg *config.Config) {
+		cfg.JWT.SigningKeyFile = writeEd25519Key(t)
+		cfg.JWT.VerificationKeyFiles = []string{oldKey}
+	})
+	doc = fetchJWKS(t, rotated)
+	require.Len(t, doc.Keys, 2)
+	require.NotEqual(t, jwk.Kid, doc.Keys[0].Kid)
+	require.Equal(t, jwk.Kid, doc.Keys[1].Kid)
+}
+
+// TestJWKSIsEmptyForSharedSecret — the HS256 secret is never published.
+func TestJWKSIsEmptyForSharedSecret(t *testing.T) {
+	app := harness.New(t)
+
+	require.Empty(t, fetchJWKS(t, app).Keys)
+}
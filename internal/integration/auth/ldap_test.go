@@ -0,0 +1,193 @@
+package auth_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/libs/ldap/ldaptest"
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+const (
+	directoryPassword = "directory-pass-1"
+	editorsGroupDN    = "cn=editors,ou=groups,dc=example,dc=com"
+)
+
+// newDirectoryApp starts an in-process directory holding one editor, "carol",
+// and one staff member outside every mapped group, and points the app at it.
+func newDirectoryApp(t *testing.T) (*harness.App, *ldaptest.Server) {
+	t.Helper()
+
+	server := ldaptest.NewServer(t,
+		ldaptest.Entry{DN: "cn=service,dc=example,dc=com", Password: "service-pass"},
+		ldaptest.Entry{
+			DN:       "uid=carol,ou=people,dc=example,dc=com",
+			Password: directoryPassword,
+			Attributes: map[string][]string{
+				"uid":      {"carol"},
+				"mail":     {"carol@corp.example"},
+				"cn":       {"Carol Directory"},
+				"memberOf": {"cn=staff,ou=groups,dc=example,dc=com", editorsGroupDN},
+			},
+		},
+		ldaptest.Entry{
+			DN:       "uid=dave,ou=people,dc=example,dc=com",
+			Password: directoryPassword,
+			Attributes: map[string][]string{
+				"uid":      {"dave"},
+				"mail":     {"dave@corp.example"},
+				"memberOf": {"cn=staff,ou=groups,dc=example,dc=com"},
+			},
+		},
+	)
+
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.LDAP = config.LDAPConfig{
+			Enabled:           true,
+			URL:               server.URL,
+			BindDN:            "cn=service,dc=example,dc=com",
+			BindPassword:      "service-pass",
+			BaseDN:            "dc=example,dc=com",
+			UserFilter:        "(uid=%s)",
+			UsernameAttribute: "uid",
+			EmailAttribute:    "mail",
+			NameAttribute:     "cn",
+			GroupAttribute:    "memberOf",
+			GroupRoles:        editorsGroupDN + "=>Editor",
+			Timeout:           time.Second,
+		}
+	})
+	return app, server
+}
+
+// TestDirectoryLoginProvisionsAdmin — a directory user in a mapped group logs
+// in with their directory password and gets an admin account with the mapped
+// role, which the next login reuses rather than duplicates.
+func TestDirectoryLoginProvisionsAdmin(t *testing.T) {
+	app, _ := newDirectoryApp(t)
+
+	tokens := app.LoginAs(t, "carol", directoryPassword)
+
+	var carol models.User
+	require.NoError(t, app.DB.Preload("AdminRoles").Where("username = ?", "carol").First(&carol).Error)
+	require.Equal(t, models.UserRoleAdmin, carol.Role)
+	require.Equal(t, []uint{app.AdminRole.ID}, carol.AdminRoleIDs())
+	require.Equal(t, models.UserAuthProviderLDAP, carol.AuthProvider)
+	require.Equal(t, "carol@corp.example", carol.Email)
+	require.Equal(t, "Carol Directory", carol.Name)
+	require.Empty(t, carol.Password)
+	app.WaitForAuditLog(t, models.LogActionCreate, carol.ID)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var me struct {
+		AuthProvider       string `json:"auth_provider"`
+		MustChangePassword bool   `json:"must_change_password"`
+	}
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &me)
+	require.Equal(t, "ldap", me.AuthProvider)
+	require.False(t, me.MustChangePassword)
+
+	app.LoginAs(t, "carol", directoryPassword)
+	var count int64
+	require.NoError(t, app.DB.Model(&models.User{}).Where("username = ?", "carol").Count(&count).Error)
+	require.Equal(t, int64(1), count)
+
+	// The password is the directory's: it cannot be changed here, and it is
+	// what step-up authentication checks.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/change-password", map[string]string{
+		"old_password": directoryPassword,
+		"new_password": harness.TestNewPassword,
+		"except_token": tokens.RefreshToken,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), "managed by the directory")
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/reauthenticate", map[string]string{"password": directoryPassword}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+// TestDirectoryLoginRefusals — a wrong directory password, a directory user
+// in no mapped group, and a locally seeded admin the directory does not know
+// all fail like any other bad login.
+func TestDirectoryLoginRefusals(t *testing.T) {
+	app, _ := newDirectoryApp(t)
+
+	for _, creds := range []map[string]string{
+		{"username": "carol", "password": "not-the-directory-pass"},
+		{"username": "dave", "password": directoryPassword},
+		{"username": harness.AdminUsername, "password": harness.TestPassword},
+	} {
+		rec := app.Request(t, http.MethodPost, "/api/v1/auth/login", creds, "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code, "%s: %s", creds["username"], rec.Body.String())
+	}
+
+	var count int64
+	require.NoError(t, app.DB.Model(&models.User{}).Where("username = ?", "dave").Count(&count).Error)
+	require.Zero(t, count, "a refused login provisions nothing")
+}
+
+// TestRootLogsInLocallyWhileDirectoryIsDown covers the break-glass account:
+// root and regular users keep their local passwords and never depend on the
+// directory being reachable.
+func TestRootLogsInLocallyWhileDirectoryIsDown(t *testing.T) {
+	app, server := newDirectoryApp(t)
+	server.Close()
+
+	app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": "carol",
+		"password": directoryPassword,
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+}
+
+// TestDirectoryLoginLocksAfterRepeatedFailures — wrong directory passwords
+// count against a known admin exactly like wrong local ones: they are audited,
+// lock the account once the threshold is reached, and while it is locked even
+// the right directory password is refused.
+func TestDirectoryLoginLocksAfterRepeatedFailures(t *testing.T) {
+	app, _ := newDirectoryApp(t)
+	app.LoginAs(t, "carol", directoryPassword)
+
+	var carol models.User
+	require.NoError(t, app.DB.Where("username = ?", "carol").First(&carol).Error)
+
+	for i := range 5 {
+		rec := loginFromIP(t, app, fmt.Sprintf("198.51.100.%d", i+1), "carol", "not-the-directory-pass")
+		require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+	app.WaitForAuditLog(t, models.LogActionLoginFailed, carol.ID)
+	app.WaitForAuditLog(t, models.LogActionLockAccount, carol.ID)
+
+	require.NoError(t, app.DB.First(&carol, carol.ID).Error)
+	require.Equal(t, 5, carol.FailedLoginAttempts)
+	require.True(t, carol.IsLocked(time.Now()))
+
+	rec := loginFromIP(t, app, "198.51.100.50", "carol", directoryPassword)
+	require.Equal(t, http.StatusUnauthorized, rec.Code, "the right password is refused while locked")
+
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	rec = app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(carol.ID)+"/unlock", nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// One more slip after the unlock is not a lock, and the next good login
+	// clears it.
+	rec = loginFromIP(t, app, "198.51.100.51", "carol", "not-the-directory-pass")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	rec = loginFromIP(t, app, "198.51.100.52", "carol", directoryPassword)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var after models.User
+	require.NoError(t, app.DB.First(&after, carol.ID).Error)
+	require.Zero(t, after.FailedLoginAttempts)
+	require.Nil(t, after.LockedUntil)
+}
@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// loginFromIP posts a login from the given client IP, so a test can spread
+// attempts across addresses the way a distributed attack does and stay clear
+// of the per-IP auth rate limiter.
+func loginFromIP(t *testing.T, app *harness.App, ip, username, password string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"username": username, "password": password})
+	require.NoError(t, err)
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":40000"
+
+	rec := httptest.NewRecorder()
+	app.Engine.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestLoginLocksAccountAfterRepeatedFailures — failures from many IPs still
+// lock the targeted account, the locked account answers exactly like an
+// unknown one, and an admin can lift the lock early.
+func TestLoginLocksAccountAfterRepeatedFailures(t *testing.T) {
+	app := harness.New(t)
+
+	for i := range 5 {
+		rec := loginFromIP(t, app, fmt.Sprintf("198.51.100.%d", i+1), harness.AdminUsername, "not-the-password")
+		require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+	app.WaitForAuditLog(t, models.LogActionLockAccount, app.AdminUser.ID)
+
+	locked := loginFromIP(t, app, "198.51.100.50", harness.AdminUsername, harness.TestPassword)
+	unknown := loginFromIP(t, app, "198.51.100.51", "who-is-this", harness.TestPassword)
+	require.Equal(t, http.StatusUnauthorized, locked.Code, "the right password is refused while locked")
+	require.Equal(t, unknown.Code, locked.Code)
+	require.Equal(t, unknown.Body.String(), locked.Body.String(),
+		"a locked account must be indistinguishable from an unknown username")
+
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.AdminUser.ID)+"/unlock", nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionUnlockAccount, app.AdminUser.ID)
+
+	rec = loginFromIP(t, app, "198.51.100.52", harness.AdminUsername, harness.TestPassword)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var admin models.User
+	require.NoError(t, app.DB.First(&admin, app.AdminUser.ID).Error)
+	require.Zero(t, admin.FailedLoginAttempts)
+	require.Nil(t, admin.LockedUntil)
+}
+
+// TestUnlockRequiresPermission — the editor role holds no user:update grant.
+func TestUnlockRequiresPermission(t *testing.T) {
+	app := harness.New(t)
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/unlock", nil, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
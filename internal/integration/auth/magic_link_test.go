@@ -0,0 +1,72 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+// withMagicLinkFor enables passwordless login for roles.
+func withMagicLinkFor(roles ...string) func(*config.Config) {
+	return func(cfg *config.Config) {
+		cfg.Auth.MagicLinkRoles = roles
+	}
+}
+
+func requestMagicLink(t *testing.T, app *harness.App, email string) {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/magic-link", map[string]string{"email": email}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+// TestMagicLinkLoginFlow walks request -> emailed link -> consume: the link
+// opens a working session, cannot be replayed, and both steps land on the
+// security timeline.
+func TestMagicLinkLoginFlow(t *testing.T) {
+	app := harness.New(t, withMagicLinkFor("user"))
+
+	requestMagicLink(t, app, app.MemberUser.Email)
+	mail := app.LastMailTo(t, app.MemberUser.Email)
+	require.Contains(t, mail, "http://frontend.test/magic-link?token=")
+	token := harness.TokenFromMail(t, mail)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/magic-link/consume", map[string]string{"token": token}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), `"email_verified":true`)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/magic-link/consume", map[string]string{"token": token}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	events := listSecurityEvents(t, app, "/api/v1/auth/security-events", tokens.AccessToken)
+	require.Len(t, events, 2)
+	require.Equal(t, "magic_link_login", events[0].Type)
+	require.Equal(t, "success", events[0].Outcome)
+	require.Equal(t, "magic_link_sent", events[1].Type)
+}
+
+// TestMagicLinkIsOffForUnlistedRoles — with the default config nobody gets a
+// link, and enabling it for users does not extend it to admins. The response
+// never reveals which case applied.
+func TestMagicLinkIsOffForUnlistedRoles(t *testing.T) {
+	app := harness.New(t)
+	requestMagicLink(t, app, app.MemberUser.Email)
+	require.Zero(t, app.MailCount(t))
+
+	app = harness.New(t, withMagicLinkFor("user"))
+	requestMagicLink(t, app, app.AdminUser.Email)
+	requestMagicLink(t, app, app.RootUser.Email)
+	requestMagicLink(t, app, "ghost@example.com")
+	require.Zero(t, app.MailCount(t))
+}
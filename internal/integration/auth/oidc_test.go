@@ -0,0 +1,193 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+	oidccontroller "github.com/PhantomX7/athleton/internal/modules/oidc/controller"
+	"github.com/PhantomX7/athleton/libs/oidc/oidctest"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+// newOIDCApp starts a mock identity provider, "corp", that allows regular
+// users and logs user in, and points the app at it.
+func newOIDCApp(t *testing.T, user oidctest.User) (*harness.App, *oidctest.Issuer) {
+	t.Helper()
+
+	issuer := oidctest.NewIssuer(t, "athleton", "client-secret", user)
+	providers, err := json.Marshal([]config.OIDCProvider{{
+		Name:         "corp",
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+		AllowedRoles: []string{"user"},
+	}})
+	require.NoError(t, err)
+
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.OIDC = config.OIDCConfig{
+			Providers:       string(providers),
+			RedirectBaseURL: "http://localhost/api/v1/auth/oidc",
+			StateTTL:        10 * time.Minute,
+		}
+	})
+	return app, issuer
+}
+
+// startOIDCLogin plays the browser through the provider: it starts a login,
+// lets the provider authorize it, and returns the callback to follow with the
+// login cookie set at start.
+func startOIDCLogin(t *testing.T, app *harness.App, issuer *oidctest.Issuer) (string, *http.Cookie) {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/auth/oidc/corp/start", nil)
+	rec := httptest.NewRecorder()
+	app.Engine.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+
+	var login *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == oidccontroller.LoginStateCookie {
+			login = c
+		}
+	}
+	require.NotNil(t, login, "start sets the login cookie")
+
+	callback := issuer.Authorize(t, rec.Header().Get("Location"))
+	require.Equal(t, "/api/v1/auth/oidc/corp/callback", callback.Path)
+	return callback.RequestURI(), login
+}
+
+// finishOIDCLogin follows the provider's redirect back to the app.
+func finishOIDCLogin(t *testing.T, app *harness.App, callback string, login *http.Cookie) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, callback, nil)
+	if login != nil {
+		req.AddCookie(login)
+	}
+	rec := httptest.NewRecorder()
+	app.Engine.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestOIDCLoginProvisionsUser — an unknown provider account with a verified
+// email gets a new regular account, which later logins reuse and which lists
+// the provider among its linked identities.
+func TestOIDCLoginProvisionsUser(t *testing.T) {
+	app, issuer := newOIDCApp(t, oidctest.User{Subject: "carol-sub", Email: "Carol@Corp.example", EmailVerified: true, Name: "Carol"})
+
+	for range 2 {
+		callback, login := startOIDCLogin(t, app, issuer)
+		rec := finishOIDCLogin(t, app, callback, login)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var carol models.User
+	require.NoError(t, app.DB.Where("email = ?", "carol@corp.example").First(&carol).Error)
+	require.Equal(t, models.UserRoleUser, carol.Role)
+	require.Equal(t, "Carol", carol.Name)
+	require.True(t, carol.IsEmailVerified())
+	require.Empty(t, carol.Password)
+
+	var identities []models.UserIdentity
+	require.NoError(t, app.DB.Where("user_id = ?", carol.ID).Find(&identities).Error)
+	require.Len(t, identities, 1)
+	require.Equal(t, "carol-sub", identities[0].Subject)
+	require.NotNil(t, identities[0].LastLoginAt)
+
+	callback, login := startOIDCLogin(t, app, issuer)
+	rec := finishOIDCLogin(t, app, callback, login)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me/identities", nil, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var listed []struct {
+		Provider string `json:"provider"`
+		Email    string `json:"email"`
+		Subject  string `json:"subject"`
+	}
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &listed)
+	require.Len(t, listed, 1)
+	require.Equal(t, "corp", listed[0].Provider)
+	require.Equal(t, "carol@corp.example", listed[0].Email)
+	require.Empty(t, listed[0].Subject, "the provider subject is not exposed")
+}
+
+// TestOIDCLoginLinksVerifiedAccount — a provider account whose verified email
+// matches an existing account with a verified email logs into that account;
+// an account whose email was never verified is not linked.
+func TestOIDCLoginLinksVerifiedAccount(t *testing.T) {
+	app, issuer := newOIDCApp(t, oidctest.User{Subject: "member-sub", Email: "member@test.local", EmailVerified: true})
+
+	callback, login := startOIDCLogin(t, app, issuer)
+	rec := finishOIDCLogin(t, app, callback, login)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	verifiedAt := time.Now()
+	require.NoError(t, app.DB.Model(&app.MemberUser).Update("email_verified_at", &verifiedAt).Error)
+
+	callback, login = startOIDCLogin(t, app, issuer)
+	rec = finishOIDCLogin(t, app, callback, login)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var identity models.UserIdentity
+	require.NoError(t, app.DB.Where("provider = ? AND subject = ?", "corp", "member-sub").First(&identity).Error)
+	require.Equal(t, app.MemberUser.ID, identity.UserID)
+
+	var count int64
+	require.NoError(t, app.DB.Model(&models.User{}).Where("email = ?", "member@test.local").Count(&count).Error)
+	require.Equal(t, int64(1), count)
+}
+
+// TestOIDCLoginRefusals — a callback without the login cookie, with a forged
+// state, replayed, or for an admin account the provider does not allow is
+// refused.
+func TestOIDCLoginRefusals(t *testing.T) {
+	app, issuer := newOIDCApp(t, oidctest.User{Subject: "admin-sub", Email: "admin@test.local", EmailVerified: true})
+
+	callback, _ := startOIDCLogin(t, app, issuer)
+	rec := finishOIDCLogin(t, app, callback, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	_, login := startOIDCLogin(t, app, issuer)
+	rec = finishOIDCLogin(t, app, "/api/v1/auth/oidc/corp/callback?code=forged&state=forged", login)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// The admin's email is verified, but the provider only allows regular
+	// users.
+	verifiedAt := time.Now()
+	require.NoError(t, app.DB.Model(&app.AdminUser).Omit(clause.Associations).Update("email_verified_at", &verifiedAt).Error)
+	callback, login = startOIDCLogin(t, app, issuer)
+	rec = finishOIDCLogin(t, app, callback, login)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	// The code was spent by the refused login.
+	rec = finishOIDCLogin(t, app, callback, login)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/oidc/google/start", nil, "")
+	require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, audit.Drain(ctx))
+	var events int64
+	require.NoError(t, app.DB.Model(&models.SecurityEvent{}).
+		Where("user_id = ? AND type = ? AND outcome = ?", app.AdminUser.ID, models.SecurityEventOIDCLogin, models.SecurityEventOutcomeFailure).
+		Count(&events).Error)
+	require.Equal(t, int64(1), events, fmt.Sprintf("refused login for user %d is recorded", app.AdminUser.ID))
+}
@@ -25,16 +25,16 @@ func TestSeededAdminMustChangeDefaultPassword(t *testing.T) {
 	// password, PasswordChangedAt left nil. The role carries log:read so that
 	// once the gate clears, /admin/log answers 200 instead of a permission 403.
 	seededAdmin := models.User{
-		Username:    "seeded-admin",
-		Name:        "Seeded Admin",
-		Email:       "seeded.admin@test.local",
-		Phone:       "+620000000004",
-		IsActive:    true,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: &app.AdminRole.ID,
-		Password:    harness.PasswordHash(),
+		Username: "seeded-admin",
+		Name:     "Seeded Admin",
+		Email:    "seeded.admin@test.local",
+		Phone:    "+620000000004",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: harness.PasswordHash(),
 	}
 	require.NoError(t, app.DB.Create(&seededAdmin).Error)
+	app.SetAdminRoles(t, seededAdmin.ID, app.AdminRole.ID)
 	require.NoError(t, app.Casbin.AddRolePermissions(
 		app.AdminRole.ID, []string{permissions.LogRead.String()},
 	))
@@ -0,0 +1,82 @@
+package auth_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// TestLoginUpgradesLegacyBcryptHash covers the transparent migration to
+// Argon2id: an account still holding a bcrypt hash logs in as before, its
+// stored hash is replaced by an Argon2id one under the configured parameters,
+// and the upgraded hash keeps working.
+func TestLoginUpgradesLegacyBcryptHash(t *testing.T) {
+	app := harness.New(t)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte(harness.TestPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := models.User{
+		Username: "legacy",
+		Name:     "Legacy User",
+		Email:    "legacy@test.local",
+		Phone:    "+620000000031",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: string(legacy),
+	}
+	require.NoError(t, app.DB.Create(&user).Error)
+
+	app.LoginAs(t, "legacy", harness.TestPassword)
+
+	var stored models.User
+	require.NoError(t, app.DB.First(&stored, user.ID).Error)
+	require.True(t, strings.HasPrefix(stored.Password, "$argon2id$v=19$m=64,t=1,p=1$"), stored.Password)
+	require.Nil(t, stored.PasswordChangedAt, "an upgraded hash is not a password change")
+
+	// The new hash verifies, and is current, so it is not rewritten again.
+	app.LoginAs(t, "legacy", harness.TestPassword)
+	var again models.User
+	require.NoError(t, app.DB.First(&again, user.ID).Error)
+	require.Equal(t, stored.Password, again.Password)
+}
+
+// TestPasswordsLongerThanBcryptLimitAreAccepted checks the lifted length cap:
+// a password past bcrypt's old 72-byte limit can be chosen, and every byte of
+// it counts.
+func TestPasswordsLongerThanBcryptLimitAreAccepted(t *testing.T) {
+	app := harness.New(t)
+
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	long := strings.Repeat("long-passphrase-", 6) + "end" // 99 bytes
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/change-password", map[string]string{
+		"old_password": harness.TestPassword,
+		"new_password": long,
+		"except_token": tokens.RefreshToken,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	app.LoginAs(t, harness.MemberUsername, long)
+
+	// Bytes past the old 72-byte limit count: a different tail fails.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": harness.MemberUsername,
+		"password": strings.Repeat("long-passphrase-", 6) + "END",
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+
+	tokens = app.LoginAs(t, harness.MemberUsername, long)
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/change-password", map[string]string{
+		"old_password": long,
+		"new_password": strings.Repeat("a", 129),
+		"except_token": tokens.RefreshToken,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// requestReset asks for a reset link for email and returns the token carried
+// by the emailed link.
+func requestReset(t *testing.T, app *harness.App, email string) string {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/forgot-password", map[string]string{
+		"email": email,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	return harness.TokenFromMail(t, app.LastMailTo(t, email))
+}
+
+// TestPasswordResetFlow walks forgot -> emailed link -> reset: the new
+// password works, the old one does not, every pre-existing session is
+// revoked, and the token cannot be replayed.
+func TestPasswordResetFlow(t *testing.T) {
+	app := harness.New(t)
+
+	session := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	token := requestReset(t, app, app.MemberUser.Email)
+	require.Contains(t, app.LastMailTo(t, app.MemberUser.Email), "http://frontend.test/reset-password?token=")
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{
+		"token":        token,
+		"new_password": harness.TestNewPassword,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Existing sessions die: both the access token (authorizer denial, 403)
+	// and the refresh token.
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, session.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/refresh", map[string]string{
+		"refresh_token": session.RefreshToken,
+	}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// Old password out, new password in.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": harness.MemberUsername,
+		"password": harness.TestPassword,
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	app.LoginAs(t, harness.MemberUsername, harness.TestNewPassword)
+
+	// Single use: the same token cannot be redeemed twice.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{
+		"token":        token,
+		"new_password": "another-pass-3",
+	}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	logRow := app.WaitForAuditLog(t, models.LogActionResetPassword, app.MemberUser.ID)
+	require.NotNil(t, logRow.UserID)
+	require.Equal(t, app.MemberUser.ID, *logRow.UserID)
+	require.Equal(t, models.LogEntityTypeUser, logRow.EntityType)
+}
+
+// TestForgotPasswordDoesNotEnumerateAccounts — an unknown address gets the
+// same response as a registered one, and no email is sent.
+func TestForgotPasswordDoesNotEnumerateAccounts(t *testing.T) {
+	app := harness.New(t)
+
+	known := app.Request(t, http.MethodPost, "/api/v1/auth/forgot-password", map[string]string{
+		"email": app.MemberUser.Email,
+	}, "")
+	unknown := app.Request(t, http.MethodPost, "/api/v1/auth/forgot-password", map[string]string{
+		"email": "nobody@test.local",
+	}, "")
+
+	require.Equal(t, http.StatusOK, known.Code, known.Body.String())
+	require.Equal(t, known.Code, unknown.Code)
+	require.JSONEq(t, known.Body.String(), unknown.Body.String())
+	require.Equal(t, 1, app.MailCount(t))
+}
+
+// TestPasswordResetOnlyNewestLinkWorks — requesting a second link supersedes
+// the first.
+func TestPasswordResetOnlyNewestLinkWorks(t *testing.T) {
+	app := harness.New(t)
+
+	first := requestReset(t, app, app.MemberUser.Email)
+	second := requestReset(t, app, app.MemberUser.Email)
+	require.NotEqual(t, first, second)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{
+		"token":        first,
+		"new_password": harness.TestNewPassword,
+	}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{
+		"token":        second,
+		"new_password": harness.TestNewPassword,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+// TestPasswordResetTokenExpires — a token past its expiry is rejected.
+func TestPasswordResetTokenExpires(t *testing.T) {
+	app := harness.New(t)
+
+	token := requestReset(t, app, app.MemberUser.Email)
+	require.NoError(t, app.DB.Model(&models.UserToken{}).
+		Where("user_id = ?", app.MemberUser.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{
+		"token":        token,
+		"new_password": harness.TestNewPassword,
+	}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
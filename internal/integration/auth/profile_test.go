@@ -0,0 +1,103 @@
+package auth_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// TestUpdateProfile — a user edits their own name and phone, the change is
+// visible on /auth/me straight away and lands in the audit log.
+func TestUpdateProfile(t *testing.T) {
+	app := harness.New(t)
+	tokens := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPatch, "/api/v1/auth/me", map[string]string{
+		"name":  "Renamed Member",
+		"phone": "+620000000099",
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var updated dto.MeResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &updated)
+	require.Equal(t, "Renamed Member", updated.Name)
+
+	me := getMe(t, app, tokens.AccessToken)
+	require.Equal(t, "Renamed Member", me.Name)
+	require.Equal(t, "+620000000099", me.Phone)
+	require.Equal(t, app.MemberUser.BusinessName, me.BusinessName, "omitted fields are unchanged")
+
+	entry := app.WaitForAuditLog(t, models.LogActionUpdate, app.MemberUser.ID)
+	require.Equal(t, models.LogEntityTypeUser, entry.EntityType)
+	require.Contains(t, entry.Message, "updated their profile: name, phone")
+
+	rec = app.Request(t, http.MethodPatch, "/api/v1/auth/me", map[string]string{"name": ""}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+// TestEmailChangeFlow — the new address only takes effect once confirmed from
+// it, the old address is told about the request, and a self-registered user
+// then logs in with the new address.
+func TestEmailChangeFlow(t *testing.T) {
+	app := harness.New(t)
+	tokens := register(t, app)
+	const newAddress = "moved@test.local"
+
+	// An address another account uses is refused up front.
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/change-email", map[string]string{
+		"email": app.MemberUser.Email,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/change-email", map[string]string{
+		"email": newAddress,
+	}, tokens.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	require.Equal(t, verifyEmailAddress, getMe(t, app, tokens.AccessToken).Email, "nothing changes before confirmation")
+	notice := app.LastMailTo(t, verifyEmailAddress)
+	require.Contains(t, notice, newAddress)
+	token := harness.TokenFromMail(t, app.LastMailTo(t, newAddress))
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/confirm-email-change", map[string]string{"token": token}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/confirm-email-change", map[string]string{"token": token}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, "the link is single-use")
+
+	me := getMe(t, app, tokens.AccessToken)
+	require.Equal(t, newAddress, me.Email)
+	require.True(t, me.EmailVerified)
+
+	app.LoginAs(t, newAddress, "register-pass-1")
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": verifyEmailAddress,
+		"password": "register-pass-1",
+	}, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+
+	entry := app.WaitForAuditLog(t, models.LogActionChangeEmail, me.ID)
+	require.True(t, strings.HasSuffix(entry.Message, "changed email from "+verifyEmailAddress+" to "+newAddress), entry.Message)
+}
+
+// TestEmailChangeIsRefusedDuringImpersonation — moving the address is an
+// account-takeover-grade change, which an impersonating support user may not
+// make on the target's behalf.
+func TestEmailChangeIsRefusedDuringImpersonation(t *testing.T) {
+	app := harness.New(t)
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/impersonate", nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var impersonation dto.ImpersonationResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &impersonation)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/change-email", map[string]string{
+		"email": "hijacked@test.local",
+	}, impersonation.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,84 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/middlewares"
+	"github.com/PhantomX7/athleton/internal/models"
+)
+
+// expireStepUp pushes every session of userID out of the reauthentication
+// window, as if the login happened long ago.
+func expireStepUp(t *testing.T, app *harness.App, userID uint) {
+	t.Helper()
+	require.NoError(t, app.DB.Model(&models.RefreshToken{}).
+		Where("user_id = ?", userID).
+		Update("authenticated_at", time.Now().Add(-time.Hour)).Error)
+}
+
+// TestDestructiveRoutesRequireRecentAuthentication — a stale session is told
+// to reauthenticate with a distinct error code, a wrong password does not
+// open the window, and the right one lets the retried request through.
+func TestDestructiveRoutesRequireRecentAuthentication(t *testing.T) {
+	app := harness.New(t)
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	expireStepUp(t, app, app.RootUser.ID)
+
+	deletePath := "/api/v1/admin/user/" + harness.Itoa(app.MemberUser.ID)
+	rec := app.Request(t, http.MethodDelete, deletePath, nil, root.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.JSONEq(t, `{"code":"`+middlewares.ReauthenticationRequiredCode+`"}`, string(harness.DecodeEnvelope(t, rec).Error))
+
+	// Reads are not gated.
+	rec = app.Request(t, http.MethodGet, deletePath, nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/reauthenticate", map[string]string{"password": "not-the-password"}, root.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodDelete, deletePath, nil, root.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/reauthenticate", map[string]string{"password": harness.TestPassword}, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res dto.ReauthenticateResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &res)
+	require.True(t, res.ExpiresAt.After(time.Now()))
+
+	rec = app.Request(t, http.MethodDelete, deletePath, nil, root.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	events := listSecurityEvents(t, app, "/api/v1/auth/security-events?type=reauthenticate", root.AccessToken)
+	require.Len(t, events, 2)
+	require.Equal(t, "success", events[0].Outcome)
+	require.Equal(t, "failure", events[1].Outcome)
+}
+
+// TestAdminRoleWritesRequireRecentAuthentication — role management is gated
+// the same way.
+func TestAdminRoleWritesRequireRecentAuthentication(t *testing.T) {
+	app := harness.New(t)
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	expireStepUp(t, app, app.RootUser.ID)
+
+	rec := app.Request(t, http.MethodDelete, "/api/v1/admin/admin-role/"+harness.Itoa(app.AdminRole.ID), nil, root.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), middlewares.ReauthenticationRequiredCode)
+}
+
+// TestImpersonationCannotReauthenticate — an impersonation session never
+// counts as recently authenticated and cannot open the window itself, so
+// root must act as themselves for destructive operations.
+func TestImpersonationCannotReauthenticate(t *testing.T) {
+	app := harness.New(t)
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	session := impersonate(t, app, root.AccessToken, app.AdminUser.ID)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/reauthenticate", map[string]string{"password": harness.TestPassword}, session.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
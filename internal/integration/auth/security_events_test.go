@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+)
+
+// listSecurityEvents fetches a security timeline and waits for in-flight
+// background writes first, so the listing reflects every prior request.
+func listSecurityEvents(t *testing.T, app *harness.App, path, token string) []dto.SecurityEventResponse {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, audit.Drain(ctx))
+
+	rec := app.Request(t, http.MethodGet, path, nil, token)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var events []dto.SecurityEventResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &events)
+	return events
+}
+
+// TestSecurityTimelineRecordsLoginsAndLogout — a failed login, a successful
+// one and a logout land on the member's own timeline, newest first, and the
+// same timeline is visible to an admin holding user:read.
+func TestSecurityTimelineRecordsLoginsAndLogout(t *testing.T) {
+	app := harness.New(t)
+
+	rec := loginFromIP(t, app, "198.51.100.10", harness.MemberUsername, "not-the-password")
+	require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+
+	events := listSecurityEvents(t, app, "/api/v1/auth/security-events", member.AccessToken)
+	require.Len(t, events, 2)
+	require.Equal(t, "login", events[0].Type)
+	require.Equal(t, "success", events[0].Outcome)
+	require.NotEmpty(t, events[0].SessionID)
+	require.Equal(t, "login", events[1].Type)
+	require.Equal(t, "failure", events[1].Outcome)
+	require.Equal(t, "198.51.100.10", events[1].IPAddress)
+	require.Empty(t, events[1].SessionID)
+
+	failures := listSecurityEvents(t, app, "/api/v1/auth/security-events?outcome=failure", member.AccessToken)
+	require.Len(t, failures, 1)
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/logout", map[string]string{"refresh_token": member.RefreshToken}, member.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	root := app.LoginAs(t, harness.RootUsername, harness.TestPassword)
+	events = listSecurityEvents(t, app, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/security-events", root.AccessToken)
+	require.Len(t, events, 3, "the root login must not leak into the member's timeline")
+	require.Equal(t, "logout", events[0].Type)
+	require.Equal(t, events[1].SessionID, events[0].SessionID)
+}
+
+// TestSecurityTimelineAdminEndpointRequiresPermission — the editor role holds
+// no user:read grant.
+func TestSecurityTimelineAdminEndpointRequiresPermission(t *testing.T) {
+	app := harness.New(t)
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/admin/user/"+harness.Itoa(app.MemberUser.ID)+"/security-events", nil, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+func loginRemembered(t *testing.T, app *harness.App, username string, rememberMe bool) harness.TokenPair {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]any{
+		"username":    username,
+		"password":    harness.TestPassword,
+		"remember_me": rememberMe,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	return tokens
+}
+
+func refresh(t *testing.T, app *harness.App, refreshToken string) (harness.TokenPair, int) {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/refresh", map[string]string{
+		"refresh_token": refreshToken,
+	}, "")
+	var tokens harness.TokenPair
+	if rec.Code == http.StatusOK {
+		harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	}
+	return tokens, rec.Code
+}
+
+// ageSession moves the member's single session back in time: its login by
+// loggedIn, its last refresh by idle.
+func ageSession(t *testing.T, app *harness.App, userID uint, loggedIn, idle time.Duration) {
+	t.Helper()
+
+	now := time.Now()
+	res := app.DB.Model(&models.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]any{"logged_in_at": now.Add(-loggedIn), "last_used_at": now.Add(-idle)})
+	require.NoError(t, res.Error)
+	require.Equal(t, int64(1), res.RowsAffected)
+}
+
+// TestSessionPolicyRememberMe — a remembered login gets the longer lifetime,
+// keeps its login time across refreshes, and survives an idle stretch that
+// ends a standard session.
+func TestSessionPolicyRememberMe(t *testing.T) {
+	app := harness.New(t)
+
+	tokens := loginRemembered(t, app, harness.MemberUsername, true)
+	var session models.RefreshToken
+	require.NoError(t, app.DB.Where("user_id = ?", app.MemberUser.ID).First(&session).Error)
+	require.True(t, session.RememberMe)
+	require.WithinDuration(t, session.LoggedInAt.Add(30*24*time.Hour), session.ExpiresAt, time.Second)
+
+	ageSession(t, app, app.MemberUser.ID, 48*time.Hour, 36*time.Hour)
+	rotated, code := refresh(t, app, tokens.RefreshToken)
+	require.Equal(t, http.StatusOK, code)
+
+	var after models.RefreshToken
+	require.NoError(t, app.DB.Where("id = ?", session.ID).First(&after).Error)
+	require.WithinDuration(t, time.Now().Add(-48*time.Hour), after.LoggedInAt, time.Minute, "rotation keeps the login time")
+	require.WithinDuration(t, time.Now(), after.LastUsedAt, time.Minute)
+
+	// Even a remembered session ends at its max age.
+	ageSession(t, app, app.MemberUser.ID, 31*24*time.Hour, time.Minute)
+	_, code = refresh(t, app, rotated.RefreshToken)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+// TestSessionPolicyEndsIdleAndOldSessions — a standard session is revoked once
+// idle past AUTH_SESSION_IDLE_TIMEOUT, and an admin session under the
+// stricter admin limit, taking its access tokens with it.
+func TestSessionPolicyEndsIdleAndOldSessions(t *testing.T) {
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.Auth.AdminSessionIdleTimeout = time.Hour
+	})
+
+	member := loginRemembered(t, app, harness.MemberUsername, false)
+	ageSession(t, app, app.MemberUser.ID, 25*time.Hour, 25*time.Hour)
+	_, code := refresh(t, app, member.RefreshToken)
+	require.Equal(t, http.StatusBadRequest, code)
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, member.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, "an ended session's access tokens stop working")
+
+	admin := loginRemembered(t, app, harness.AdminUsername, false)
+	ageSession(t, app, app.AdminUser.ID, 2*time.Hour, 2*time.Hour)
+	_, code = refresh(t, app, admin.RefreshToken)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, audit.Drain(ctx))
+	var failures int64
+	require.NoError(t, app.DB.Model(&models.SecurityEvent{}).
+		Where("user_id = ? AND type = ? AND outcome = ?", app.AdminUser.ID, models.SecurityEventRefresh, models.SecurityEventOutcomeFailure).
+		Count(&failures).Error)
+	require.Equal(t, int64(1), failures)
+}
+
+// TestSessionPolicyRememberMeThroughTwoFactor — a 2FA login opens its session
+// at /auth/2fa/verify, which takes the remember_me choice.
+func TestSessionPolicyRememberMeThroughTwoFactor(t *testing.T) {
+	app := harness.New(t)
+
+	initial := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	secret, _ := enableTwoFactor(t, app, initial.AccessToken)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/2fa/verify", map[string]any{
+		"challenge_token": loginChallenge(t, app, harness.MemberUsername),
+		"code":            totpCode(t, secret, 1),
+		"remember_me":     true,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var sessions []models.RefreshToken
+	require.NoError(t, app.DB.Where("user_id = ?", app.MemberUser.ID).Order("created_at").Find(&sessions).Error)
+	require.Len(t, sessions, 2)
+	require.False(t, sessions[0].RememberMe)
+	require.True(t, sessions[1].RememberMe)
+}
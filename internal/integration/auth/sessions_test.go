@@ -0,0 +1,142 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+)
+
+// loginFrom logs in with the given User-Agent, standing in for a distinct
+// device.
+func loginFrom(t *testing.T, app *harness.App, username, userAgent string) harness.TokenPair {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{
+		"username": username,
+		"password": harness.TestPassword,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	rec := httptest.NewRecorder()
+	app.Engine.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var tokens harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	require.NotEmpty(t, tokens.AccessToken)
+	return tokens
+}
+
+func listSessions(t *testing.T, app *harness.App, accessToken string) []dto.SessionResponse {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/auth/sessions", nil, accessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var sessions []dto.SessionResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &sessions)
+	return sessions
+}
+
+// TestSessionListAndRevoke — each login is listed with the device it came
+// from, the caller's own session is flagged, and revoking another session
+// kills both of its tokens.
+func TestSessionListAndRevoke(t *testing.T) {
+	app := harness.New(t)
+
+	laptop := loginFrom(t, app, harness.MemberUsername, "laptop-browser/1.0")
+	phone := loginFrom(t, app, harness.MemberUsername, "phone-app/2.0")
+
+	sessions := listSessions(t, app, laptop.AccessToken)
+	require.Len(t, sessions, 2)
+	var phoneID string
+	for _, s := range sessions {
+		require.NotEmpty(t, s.IPAddress)
+		require.False(t, s.LastUsedAt.IsZero())
+		switch s.UserAgent {
+		case "laptop-browser/1.0":
+			require.True(t, s.Current)
+		case "phone-app/2.0":
+			require.False(t, s.Current)
+			phoneID = s.ID
+		default:
+			t.Fatalf("unexpected session user agent %q", s.UserAgent)
+		}
+	}
+	require.NotEmpty(t, phoneID)
+
+	rec := app.Request(t, http.MethodDelete, "/api/v1/auth/sessions/"+phoneID, nil, laptop.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, phone.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/refresh", map[string]string{
+		"refresh_token": phone.RefreshToken,
+	}, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	sessions = listSessions(t, app, laptop.AccessToken)
+	require.Len(t, sessions, 1)
+	require.True(t, sessions[0].Current)
+
+	// Revoking twice, or with a malformed id, is rejected.
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/sessions/"+phoneID, nil, laptop.AccessToken)
+	require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	rec = app.Request(t, http.MethodDelete, "/api/v1/auth/sessions/not-a-uuid", nil, laptop.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+// TestSessionRevokeIsScopedToOwner — another user's session id behaves like a
+// missing one and leaves that session alive.
+func TestSessionRevokeIsScopedToOwner(t *testing.T) {
+	app := harness.New(t)
+
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	adminSessions := listSessions(t, app, admin.AccessToken)
+	require.Len(t, adminSessions, 1)
+
+	rec := app.Request(t, http.MethodDelete, "/api/v1/auth/sessions/"+adminSessions[0].ID, nil, member.AccessToken)
+	require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	require.Len(t, listSessions(t, app, admin.AccessToken), 1)
+}
+
+// TestSessionRevokeOthers — signing out everywhere else keeps only the
+// caller's session, and the refresh updates the session's client details.
+func TestSessionRevokeOthers(t *testing.T) {
+	app := harness.New(t)
+
+	first := loginFrom(t, app, harness.MemberUsername, "device-one")
+	second := loginFrom(t, app, harness.MemberUsername, "device-two")
+	third := loginFrom(t, app, harness.MemberUsername, "device-three")
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/sessions/revoke-others", nil, second.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	require.Equal(t, http.StatusForbidden, app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, first.AccessToken).Code)
+	require.Equal(t, http.StatusForbidden, app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, third.AccessToken).Code)
+
+	// Refreshing from a new client moves the session onto it.
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/refresh", map[string]string{
+		"refresh_token": second.RefreshToken,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var refreshed harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &refreshed)
+
+	sessions := listSessions(t, app, refreshed.AccessToken)
+	require.Len(t, sessions, 1)
+	require.True(t, sessions[0].Current)
+	// harness.Request sends no User-Agent.
+	require.Empty(t, sessions[0].UserAgent)
+}
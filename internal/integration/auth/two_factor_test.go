@@ -0,0 +1,183 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/integration/harness"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/totp"
+)
+
+// totpCode computes the code an authenticator app would show offset steps
+// from now.
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := totp.CodeAt(secret, totp.Step(time.Now())+offset)
+	require.NoError(t, err)
+	return code
+}
+
+// enableTwoFactor runs setup + enable for the holder of accessToken and
+// returns the TOTP secret and the issued recovery codes.
+func enableTwoFactor(t *testing.T, app *harness.App, accessToken string) (string, []string) {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/2fa/setup", nil, accessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var setup dto.TwoFactorSetupResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &setup)
+	require.NotEmpty(t, setup.Secret)
+	require.Contains(t, setup.OTPAuthURI, "otpauth://totp/")
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/2fa/enable", map[string]string{
+		"code": totpCode(t, setup.Secret, 0),
+	}, accessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var codes dto.TwoFactorRecoveryCodesResponse
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &codes)
+	require.Len(t, codes.RecoveryCodes, 10)
+
+	return setup.Secret, codes.RecoveryCodes
+}
+
+// loginChallenge logs in with a password and returns the 2FA challenge token.
+func loginChallenge(t *testing.T, app *harness.App, username string) string {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"username": username,
+		"password": harness.TestPassword,
+	}, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res harness.TokenPair
+	harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &res)
+	require.True(t, res.TwoFactorRequired)
+	require.Empty(t, res.AccessToken)
+	require.Empty(t, res.RefreshToken)
+	require.NotEmpty(t, res.ChallengeToken)
+	return res.ChallengeToken
+}
+
+func verifyTwoFactor(t *testing.T, app *harness.App, challenge, code string) (int, harness.TokenPair) {
+	t.Helper()
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/2fa/verify", map[string]string{
+		"challenge_token": challenge,
+		"code":            code,
+	}, "")
+	var tokens harness.TokenPair
+	if rec.Code == http.StatusOK {
+		harness.DecodeData(t, harness.DecodeEnvelope(t, rec), &tokens)
+	}
+	return rec.Code, tokens
+}
+
+// TestTwoFactorLoginFlow — once enrolled, a password alone only yields a
+// challenge; the challenge plus a TOTP or recovery code yields a session, and
+// neither kind of code can be used twice.
+func TestTwoFactorLoginFlow(t *testing.T) {
+	app := harness.New(t)
+
+	initial := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	secret, recoveryCodes := enableTwoFactor(t, app, initial.AccessToken)
+	app.WaitForAuditLog(t, models.LogActionEnableTwoFactor, app.AdminUser.ID)
+	require.True(t, getMe(t, app, initial.AccessToken).TwoFactorEnabled)
+
+	// The enrolment code burned the current step, so use the next one.
+	nextCode := totpCode(t, secret, 1)
+	status, tokens := verifyTwoFactor(t, app, loginChallenge(t, app, harness.AdminUsername), nextCode)
+	require.Equal(t, http.StatusOK, status)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+	require.Equal(t, "Bearer", tokens.TokenType)
+	require.Equal(t, http.StatusOK, app.Request(t, http.MethodGet, "/api/v1/admin/__probe", nil, tokens.AccessToken).Code)
+
+	// Replaying the same TOTP code against a fresh challenge fails.
+	status, _ = verifyTwoFactor(t, app, loginChallenge(t, app, harness.AdminUsername), nextCode)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	// Recovery codes work once, with or without the cosmetic dash.
+	status, _ = verifyTwoFactor(t, app, loginChallenge(t, app, harness.AdminUsername), recoveryCodes[0])
+	require.Equal(t, http.StatusOK, status)
+	status, _ = verifyTwoFactor(t, app, loginChallenge(t, app, harness.AdminUsername), recoveryCodes[0])
+	require.Equal(t, http.StatusBadRequest, status)
+}
+
+// TestTwoFactorChallengeIsSingleUse — a wrong code burns the challenge, so a
+// guesser has to re-enter the password for every attempt.
+func TestTwoFactorChallengeIsSingleUse(t *testing.T) {
+	app := harness.New(t)
+
+	initial := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	secret, _ := enableTwoFactor(t, app, initial.AccessToken)
+
+	challenge := loginChallenge(t, app, harness.MemberUsername)
+	status, _ := verifyTwoFactor(t, app, challenge, "not-a-valid-code")
+	require.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = verifyTwoFactor(t, app, challenge, totpCode(t, secret, 1))
+	require.Equal(t, http.StatusBadRequest, status)
+
+	// The challenge token is not a usable access token either.
+	require.Equal(t, http.StatusUnauthorized, app.Request(t, http.MethodGet, "/api/v1/auth/me", nil, challenge).Code)
+}
+
+// TestTwoFactorDisable — disabling needs the password and a code; afterwards
+// the password alone logs in again.
+func TestTwoFactorDisable(t *testing.T) {
+	app := harness.New(t)
+
+	initial := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	_, recoveryCodes := enableTwoFactor(t, app, initial.AccessToken)
+
+	rec := app.Request(t, http.MethodPost, "/api/v1/auth/2fa/disable", map[string]string{
+		"password": "wrong-password-1",
+		"code":     recoveryCodes[0],
+	}, initial.AccessToken)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = app.Request(t, http.MethodPost, "/api/v1/auth/2fa/disable", map[string]string{
+		"password": harness.TestPassword,
+		"code":     recoveryCodes[0],
+	}, initial.AccessToken)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	app.WaitForAuditLog(t, models.LogActionDisableTwoFactor, app.AdminUser.ID)
+
+	tokens := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	require.False(t, getMe(t, app, tokens.AccessToken).TwoFactorEnabled)
+
+	var remaining int64
+	require.NoError(t, app.DB.Model(&models.TwoFactorRecoveryCode{}).Where("user_id = ?", app.AdminUser.ID).Count(&remaining).Error)
+	require.Zero(t, remaining)
+}
+
+// TestTwoFactorRequiredForAdmins — with 2FA mandatory, an admin without it is
+// shut out of /admin (but not /auth/2fa) until it enrols; plain users are not
+// affected.
+func TestTwoFactorRequiredForAdmins(t *testing.T) {
+	app := harness.New(t, func(cfg *config.Config) {
+		cfg.Auth.TwoFactorRequiredForAdmins = true
+	})
+
+	admin := app.LoginAs(t, harness.AdminUsername, harness.TestPassword)
+	require.True(t, getMe(t, app, admin.AccessToken).MustEnableTwoFactor)
+
+	rec := app.Request(t, http.MethodGet, "/api/v1/admin/__probe", nil, admin.AccessToken)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "two-factor authentication required")
+
+	enableTwoFactor(t, app, admin.AccessToken)
+
+	require.False(t, getMe(t, app, admin.AccessToken).MustEnableTwoFactor)
+	require.Equal(t, http.StatusOK, app.Request(t, http.MethodGet, "/api/v1/admin/__probe", nil, admin.AccessToken).Code)
+
+	member := app.LoginAs(t, harness.MemberUsername, harness.TestPassword)
+	require.False(t, getMe(t, app, member.AccessToken).MustEnableTwoFactor)
+}
@@ -11,6 +11,11 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,10 +26,10 @@ import (
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
+	"github.com/PhantomX7/athleton/internal/audit"
 	"github.com/PhantomX7/athleton/internal/bootstrap"
 	"github.com/PhantomX7/athleton/internal/middlewares"
 	"github.com/PhantomX7/athleton/internal/models"
@@ -32,6 +37,10 @@ import (
 	adminrolecontroller "github.com/PhantomX7/athleton/internal/modules/admin_role/controller"
 	adminrolerepository "github.com/PhantomX7/athleton/internal/modules/admin_role/repository"
 	adminroleservice "github.com/PhantomX7/athleton/internal/modules/admin_role/service"
+	apikeymodule "github.com/PhantomX7/athleton/internal/modules/api_key"
+	apikeycontroller "github.com/PhantomX7/athleton/internal/modules/api_key/controller"
+	apikeyrepository "github.com/PhantomX7/athleton/internal/modules/api_key/repository"
+	apikeyservice "github.com/PhantomX7/athleton/internal/modules/api_key/service"
 	authmodule "github.com/PhantomX7/athleton/internal/modules/auth"
 	authcontroller "github.com/PhantomX7/athleton/internal/modules/auth/controller"
 	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
@@ -44,16 +53,35 @@ import (
 	logcontroller "github.com/PhantomX7/athleton/internal/modules/log/controller"
 	logrepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
 	logservice "github.com/PhantomX7/athleton/internal/modules/log/service"
+	oidcmodule "github.com/PhantomX7/athleton/internal/modules/oidc"
+	oidccontroller "github.com/PhantomX7/athleton/internal/modules/oidc/controller"
+	oidcrepository "github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	oidcservice "github.com/PhantomX7/athleton/internal/modules/oidc/service"
+	passwordpolicyrepository "github.com/PhantomX7/athleton/internal/modules/password_policy/repository"
+	passwordpolicyservice "github.com/PhantomX7/athleton/internal/modules/password_policy/service"
 	rtokenrepository "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
+	securityeventmodule "github.com/PhantomX7/athleton/internal/modules/security_event"
+	securityeventcontroller "github.com/PhantomX7/athleton/internal/modules/security_event/controller"
+	securityeventrepository "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	securityeventservice "github.com/PhantomX7/athleton/internal/modules/security_event/service"
+	twofactormodule "github.com/PhantomX7/athleton/internal/modules/two_factor"
+	twofactorcontroller "github.com/PhantomX7/athleton/internal/modules/two_factor/controller"
+	twofactorrepository "github.com/PhantomX7/athleton/internal/modules/two_factor/repository"
+	twofactorservice "github.com/PhantomX7/athleton/internal/modules/two_factor/service"
 	usermodule "github.com/PhantomX7/athleton/internal/modules/user"
 	usercontroller "github.com/PhantomX7/athleton/internal/modules/user/controller"
 	userrepository "github.com/PhantomX7/athleton/internal/modules/user/repository"
 	userservice "github.com/PhantomX7/athleton/internal/modules/user/service"
+	usertokenrepository "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
 	"github.com/PhantomX7/athleton/internal/routes"
 	"github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/libs/ldap"
+	"github.com/PhantomX7/athleton/libs/mailer"
+	"github.com/PhantomX7/athleton/libs/oidc"
 	"github.com/PhantomX7/athleton/libs/transaction_manager"
 	"github.com/PhantomX7/athleton/pkg/config"
 	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/password"
 	pkgvalidator "github.com/PhantomX7/athleton/pkg/validator"
 )
 
@@ -74,18 +102,22 @@ const (
 	TestMaxBodyBytes = int64(10 << 20)
 )
 
-// passwordHash is computed once per process. bcrypt.MinCost keeps seeding
-// fast; login still exercises the real bcrypt comparison path.
+// testHashParams are the cheapest Argon2id parameters, keeping seeding and
+// login fast while still exercising the real hashing path.
+var testHashParams = password.Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+// passwordHash is computed once per process, under testHashParams so logging
+// in with it never triggers a rehash.
 var passwordHash = sync.OnceValue(func() string {
-	hash, err := bcrypt.GenerateFromPassword([]byte(TestPassword), bcrypt.MinCost)
+	hash, err := password.Hash(TestPassword, testHashParams)
 	if err != nil {
 		panic(err)
 	}
-	return string(hash)
+	return hash
 })
 
-// PasswordHash returns the shared bcrypt hash of TestPassword for tests that
-// seed additional users.
+// PasswordHash returns the shared Argon2id hash of TestPassword for tests
+// that seed additional users.
 func PasswordHash() string {
 	return passwordHash()
 }
@@ -135,6 +167,41 @@ func testConfig() *config.Config {
 			Compress:   false,
 			Console:    false,
 		},
+		Auth: config.AuthConfig{
+			PasswordResetTTL:       30 * time.Minute,
+			PasswordResetURL:       "http://frontend.test/reset-password",
+			EmailVerificationTTL:   24 * time.Hour,
+			EmailVerificationURL:   "http://frontend.test/verify-email",
+			EmailChangeTTL:         time.Hour,
+			EmailChangeURL:         "http://frontend.test/confirm-email-change",
+			DataExportTTL:          15 * time.Minute,
+			MagicLinkTTL:           15 * time.Minute,
+			MagicLinkURL:           "http://frontend.test/magic-link",
+			TwoFactorChallengeTTL:  5 * time.Minute,
+			LockoutThreshold:       5,
+			LockoutDuration:        time.Minute,
+			LockoutMaxDuration:     time.Hour,
+			LockoutWindow:          15 * time.Minute,
+			ImpersonationTTL:       15 * time.Minute,
+			ReauthenticationWindow: 5 * time.Minute,
+			// Long enough that any test relying on a stale entry fails, so
+			// every revocation path is checked for invalidating the cache.
+			CacheTTL:              time.Minute,
+			Mode:                  config.AuthModeBearer,
+			CookieSameSite:        "strict",
+			PasswordMinLength:     8,
+			PasswordHistory:       5,
+			Argon2Memory:          int(testHashParams.Memory),
+			Argon2Iterations:      int(testHashParams.Iterations),
+			Argon2Parallelism:     int(testHashParams.Parallelism),
+			SessionIdleTimeout:    24 * time.Hour,
+			RememberMeMaxAge:      30 * 24 * time.Hour,
+			RememberMeIdleTimeout: 7 * 24 * time.Hour,
+		},
+		Mail: config.MailConfig{
+			Driver: "file",
+			From:   "no-reply@test.local",
+		},
 	}
 }
 
@@ -143,6 +210,9 @@ type App struct {
 	Engine *gin.Engine
 	DB     *gorm.DB
 	Casbin casbin.Client
+	// MailDir is where the file mailer writes every outbound email; read it
+	// back with LastMailTo.
+	MailDir string
 
 	RootUser   models.User
 	AdminUser  models.User
@@ -153,13 +223,17 @@ type App struct {
 // New hand-wires the real application stack (no fx): in-memory SQLite,
 // repositories -> services -> controllers -> route registrars, the production
 // middleware bundle, and the engine built by bootstrap.SetupServer. The
-// /api/v1 groups are created exactly like routes.RegisterRoutes does.
-func New(t *testing.T) *App {
+// /api/v1 groups are created exactly like routes.RegisterRoutes does. Each
+// opt adjusts the test config before anything is built from it.
+func New(t *testing.T, opts ...func(*config.Config)) *App {
 	t.Helper()
 
 	gin.SetMode(gin.TestMode)
 	logger.Log = zap.NewNop()
 	cfg := testConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
 		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
@@ -180,6 +254,14 @@ func New(t *testing.T) *App {
 		&models.RefreshToken{},
 		&models.Log{},
 		&models.Config{},
+		&models.UserToken{},
+		&models.TwoFactorRecoveryCode{},
+		&models.APIKey{},
+		&models.PasswordHistory{},
+		&models.PersonalDataHistory{},
+		&models.SecurityEvent{},
+		&models.UserIdentity{},
+		&models.UserAdminRole{},
 	))
 
 	userRepo := userrepository.NewUserRepository(db)
@@ -187,36 +269,61 @@ func New(t *testing.T) *App {
 	logRepo := logrepository.NewLogRepository(db)
 	adminRoleRepo := adminrolerepository.NewAdminRoleRepository(db)
 	configRepo := configrepository.NewConfigRepository(db)
+	userTokenRepo := usertokenrepository.NewUserTokenRepository(db)
+	recoveryCodeRepo := twofactorrepository.NewRecoveryCodeRepository(db)
+	apiKeyRepo := apikeyrepository.NewAPIKeyRepository(db)
+	passwordHistoryRepo := passwordpolicyrepository.NewPasswordHistoryRepository(db)
+	securityEventRepo := securityeventrepository.NewSecurityEventRepository(db)
+	identityRepo := oidcrepository.NewUserIdentityRepository(db)
 
 	txManager := transaction_manager.NewTransactionManager(db)
 
-	authJWT, err := authjwt.NewAuthJWT(cfg, userRepo, refreshTokenRepo, logRepo, txManager)
+	metricsRegistry := bootstrap.NewMetricsRegistry()
+	authCache := authjwt.NewAuthCache(cfg, metricsRegistry)
+	directory := authjwt.NewDirectoryAuthenticator(cfg, ldap.New(cfg), userRepo, adminRoleRepo, logRepo, securityEventRepo, txManager, authCache)
+	authJWT, err := authjwt.NewAuthJWT(cfg, userRepo, refreshTokenRepo, userTokenRepo, apiKeyRepo, logRepo, securityEventRepo, txManager, authCache, directory)
 	require.NoError(t, err)
 
 	casbinClient, err := casbin.New(db)
 	require.NoError(t, err)
 
+	cfg.Mail.FileDir = t.TempDir()
+	mail, err := mailer.NewFileMailer(cfg.Mail.From, cfg.Mail.FileDir)
+	require.NoError(t, err)
+
+	passwordPolicy, err := passwordpolicyservice.NewPasswordPolicy(cfg, passwordHistoryRepo)
+	require.NoError(t, err)
+
 	mw := middlewares.NewMiddleware(cfg, authJWT, casbinClient)
-	engine := bootstrap.SetupServer(cfg, mw, pkgvalidator.New(db), db)
+	engine := bootstrap.SetupServer(cfg, mw, pkgvalidator.New(db), db, metricsRegistry)
 
-	authService := authservice.NewAuthService(userRepo, logRepo, authJWT, casbinClient, txManager)
+	apiKeyService := apikeyservice.NewAPIKeyService(apiKeyRepo, logRepo, casbinClient)
+	twoFactorService := twofactorservice.NewTwoFactorService(cfg, userRepo, recoveryCodeRepo, userTokenRepo, logRepo, securityEventRepo, authJWT, txManager)
+	authService := authservice.NewAuthService(cfg, userRepo, refreshTokenRepo, userTokenRepo, logRepo, securityEventRepo, identityRepo, passwordPolicy, authJWT, twoFactorService, casbinClient, mail, txManager)
 	adminRoleService := adminroleservice.NewAdminRoleService(adminRoleRepo, logRepo, casbinClient, txManager)
 	configService := configservice.NewConfigService(configRepo, logRepo)
 	logService := logservice.NewLogService(logRepo)
-	userService := userservice.NewUserService(userRepo, adminRoleRepo, refreshTokenRepo, logRepo, casbinClient, txManager, zap.NewNop())
+	securityEventService := securityeventservice.NewSecurityEventService(securityEventRepo, userRepo, casbinClient)
+	oidcClient, err := oidc.New(cfg)
+	require.NoError(t, err)
+	oidcService := oidcservice.NewOIDCService(cfg, oidcClient, identityRepo, userRepo, logRepo, securityEventRepo, authJWT, txManager)
+	userService := userservice.NewUserService(userRepo, adminRoleRepo, refreshTokenRepo, logRepo, securityEventRepo, passwordPolicy, casbinClient, authCache, txManager, zap.NewNop())
 
 	// Mirror routes.RegisterRoutes: shared /api/v1 groups with the same
 	// middleware stack (rate limiting before auth on /admin, the admin role
-	// boundary, then the must-change-default-password gate).
+	// boundary, then the must-change-default-password and mandatory-2FA
+	// gates).
 	root := engine.Group("/api/v1")
 	routeCtx := &routes.Context{
-		Root:   root,
-		Public: root.Group("/public"),
+		Root:      root,
+		Public:    root.Group("/public"),
+		WellKnown: engine.Group("/.well-known"),
 		Admin: root.Group("/admin",
 			mw.AdminRateLimiter(),
 			mw.RequireAuth(),
 			mw.RequireRole(models.UserRoleAdmin.ToString(), models.UserRoleRoot.ToString()),
 			mw.RequirePasswordChanged(),
+			mw.RequireTwoFactor(),
 		),
 		MW: mw,
 	}
@@ -224,18 +331,23 @@ func New(t *testing.T) *App {
 	// rejects plain users even when a route carries no permission guard.
 	routeCtx.Admin.GET("/__probe", func(c *gin.Context) { c.Status(http.StatusOK) })
 
-	authmodule.NewRoutes(authcontroller.NewAuthController(authService)).RegisterRoutes(routeCtx)
-	usermodule.NewRoutes(usercontroller.NewUserController(userService)).RegisterRoutes(routeCtx)
-	adminrolemodule.NewRoutes(adminrolecontroller.NewAdminRoleController(adminRoleService)).RegisterRoutes(routeCtx)
+	authmodule.NewRoutes(authcontroller.NewAuthController(authService, authJWT), cfg).RegisterRoutes(routeCtx)
+	apikeymodule.NewRoutes(apikeycontroller.NewAPIKeyController(apiKeyService)).RegisterRoutes(routeCtx)
+	twofactormodule.NewRoutes(twofactorcontroller.NewTwoFactorController(twoFactorService, authJWT)).RegisterRoutes(routeCtx)
+	usermodule.NewRoutes(usercontroller.NewUserController(userService), cfg).RegisterRoutes(routeCtx)
+	adminrolemodule.NewRoutes(adminrolecontroller.NewAdminRoleController(adminRoleService), cfg).RegisterRoutes(routeCtx)
 	configController := configcontroller.NewConfigController(configService)
 	configmodule.NewAdminRoutes(configController).RegisterRoutes(routeCtx)
 	configmodule.NewPublicRoutes(configController).RegisterRoutes(routeCtx)
 	logmodule.NewRoutes(logcontroller.NewLogController(logService)).RegisterRoutes(routeCtx)
+	securityeventmodule.NewRoutes(securityeventcontroller.NewSecurityEventController(securityEventService)).RegisterRoutes(routeCtx)
+	oidcmodule.NewRoutes(oidccontroller.NewOIDCController(oidcService, authJWT)).RegisterRoutes(routeCtx)
 
 	app := &App{
-		Engine: engine,
-		DB:     db,
-		Casbin: casbinClient,
+		Engine:  engine,
+		DB:      db,
+		Casbin:  casbinClient,
+		MailDir: cfg.Mail.FileDir,
 	}
 	app.seed(t)
 	return app
@@ -275,7 +387,6 @@ func (a *App) seed(t *testing.T) {
 		Phone:             "+620000000002",
 		IsActive:          true,
 		Role:              models.UserRoleAdmin,
-		AdminRoleID:       &a.AdminRole.ID,
 		Password:          hash,
 		PasswordChangedAt: &passwordChangedAt,
 	}
@@ -291,6 +402,8 @@ func (a *App) seed(t *testing.T) {
 	require.NoError(t, a.DB.Create(&a.RootUser).Error)
 	require.NoError(t, a.DB.Create(&a.AdminUser).Error)
 	require.NoError(t, a.DB.Create(&a.MemberUser).Error)
+	require.NoError(t, a.DB.Create(&models.UserAdminRole{UserID: a.AdminUser.ID, AdminRoleID: a.AdminRole.ID}).Error)
+	a.AdminUser.AdminRoles = []models.AdminRole{a.AdminRole}
 }
 
 // EnvelopeMeta is the pagination metadata inside a list response.
@@ -315,6 +428,11 @@ type TokenPair struct {
 	RefreshToken       string `json:"refresh_token"`
 	TokenType          string `json:"token_type"`
 	MustChangePassword bool   `json:"must_change_password"`
+
+	EmailVerificationRequired bool `json:"email_verification_required"`
+
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
 }
 
 // Request performs an HTTP request against the assembled engine. body may be
@@ -395,6 +513,18 @@ func NewRequestWithHeader(t *testing.T, method, path, header, value string) (*ht
 	return req, httptest.NewRecorder()
 }
 
+// SetAdminRoles replaces the user's admin roles directly in the database, for
+// tests that need an admin holding particular roles. It does not touch the
+// authorizer's user cache, so call it before the user's first request.
+func (a *App) SetAdminRoles(t *testing.T, userID uint, roleIDs ...uint) {
+	t.Helper()
+
+	require.NoError(t, a.DB.Where("user_id = ?", userID).Delete(&models.UserAdminRole{}).Error)
+	for _, roleID := range roleIDs {
+		require.NoError(t, a.DB.Create(&models.UserAdminRole{UserID: userID, AdminRoleID: roleID}).Error)
+	}
+}
+
 // WaitForAuditLog polls (with a deadline, no fixed sleeps for correctness)
 // until an audit log row matching action and entityID appears.
 func (a *App) WaitForAuditLog(t *testing.T, action models.LogAction, entityID uint) models.Log {
@@ -416,6 +546,64 @@ func (a *App) WaitForAuditLog(t *testing.T, action models.LogAction, entityID ui
 	}
 }
 
+// LastMailTo returns the most recent email the file mailer wrote for the
+// given recipient, failing the test when there is none. It first waits for
+// background work, which is where the "email me a link" endpoints send.
+func (a *App) LastMailTo(t *testing.T, to string) string {
+	t.Helper()
+	require.NoError(t, audit.Drain(context.Background()))
+
+	entries, err := os.ReadDir(a.MailDir)
+	require.NoError(t, err)
+
+	// File names start with a zero-padded timestamp + sequence, so reverse
+	// lexical order is newest first.
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	slices.Sort(names)
+	slices.Reverse(names)
+
+	for _, name := range names {
+		body, err := os.ReadFile(filepath.Join(a.MailDir, name))
+		require.NoError(t, err)
+		if strings.Contains(string(body), "\r\nTo: "+to+"\r\n") {
+			return string(body)
+		}
+	}
+	require.Failf(t, "no email found", "no email was sent to %s", to)
+	return ""
+}
+
+// MailCount returns how many emails the file mailer has written so far,
+// once background work has finished.
+func (a *App) MailCount(t *testing.T) int {
+	t.Helper()
+	require.NoError(t, audit.Drain(context.Background()))
+
+	entries, err := os.ReadDir(a.MailDir)
+	require.NoError(t, err)
+	return len(entries)
+}
+
+// linkPattern matches the first absolute URL in an email body.
+var linkPattern = regexp.MustCompile(`https?://\S+`)
+
+// TokenFromMail extracts the "token" query parameter from the first link in
+// an email body, i.e. the value a user would submit after clicking it.
+func TokenFromMail(t *testing.T, body string) string {
+	t.Helper()
+
+	link := linkPattern.FindString(body)
+	require.NotEmpty(t, link, "email contains no link: %s", body)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	token := u.Query().Get("token")
+	require.NotEmpty(t, token, "link carries no token: %s", link)
+	return token
+}
+
 // Itoa formats a uint for building URL paths.
 func Itoa(v uint) string {
 	return strconv.FormatUint(uint64(v), 10)
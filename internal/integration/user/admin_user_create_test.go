@@ -27,20 +27,20 @@ func TestAdminCanCreateAdminUserEndToEnd(t *testing.T) {
 	// Root creates the admin account. A smuggled "role":"root" field must be
 	// ignored — the DTO does not bind a role at all.
 	rec := app.Request(t, http.MethodPost, "/api/v1/admin/user", map[string]any{
-		"username":      "second-admin",
-		"name":          "Second Admin",
-		"email":         "second.admin@test.local",
-		"phone":         "+620000000008",
-		"password":      "initial-pass-123",
-		"admin_role_id": app.AdminRole.ID,
-		"role":          "root",
+		"username":       "second-admin",
+		"name":           "Second Admin",
+		"email":          "second.admin@test.local",
+		"phone":          "+620000000008",
+		"password":       "initial-pass-123",
+		"admin_role_ids": []uint{app.AdminRole.ID},
+		"role":           "root",
 	}, rootTokens.AccessToken)
 	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
 
 	var created models.User
-	require.NoError(t, app.DB.Where("username = ?", "second-admin").First(&created).Error)
+	require.NoError(t, app.DB.Preload("AdminRoles").Where("username = ?", "second-admin").First(&created).Error)
 	require.Equal(t, models.UserRoleAdmin, created.Role, "created account must be admin, never root")
-	require.Equal(t, &app.AdminRole.ID, created.AdminRoleID)
+	require.Equal(t, []uint{app.AdminRole.ID}, created.AdminRoleIDs())
 	require.Nil(t, created.PasswordChangedAt, "creator-chosen password must count as unrotated")
 
 	// The new admin can log in but is gated until rotating the password.
@@ -74,7 +74,8 @@ func TestRootAccountsCannotBeModifiedOverHTTP(t *testing.T) {
 	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
 
 	rec = app.Request(t, http.MethodPost, "/api/v1/admin/user/"+rootID+"/admin-role", map[string]any{
-		"admin_role_id": app.AdminRole.ID,
+		"mode":           "add",
+		"admin_role_ids": []uint{app.AdminRole.ID},
 	}, rootTokens.AccessToken)
 	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
 
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"slices"
 
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/libs/casbin"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
 	"github.com/PhantomX7/athleton/pkg/logger"
 	"github.com/PhantomX7/athleton/pkg/response"
@@ -14,8 +16,25 @@ import (
 	"go.uber.org/zap"
 )
 
-// RequireAuth returns the standard authentication middleware
+// RequireAuth authenticates either a Bearer access token or an
+// "Authorization: ApiKey" key. API-key requests carry the key's permission
+// scope in the context values, which the permission guards below enforce.
 func (m *Middleware) RequireAuth() gin.HandlerFunc {
+	session := m.authJWT.Middleware.MiddlewareFunc()
+	return func(c *gin.Context) {
+		if _, ok := authjwt.APIKeyFromRequest(c); ok {
+			m.authJWT.AuthenticateAPIKey(c)
+			return
+		}
+		session(c)
+	}
+}
+
+// RequireSessionAuth accepts only a Bearer access token. Account
+// self-service — password, sessions, 2FA, issuing API keys — sits behind it
+// so a leaked key cannot be used to take over its owner's account or mint a
+// broader key.
+func (m *Middleware) RequireSessionAuth() gin.HandlerFunc {
 	return m.authJWT.Middleware.MiddlewareFunc()
 }
 
@@ -41,9 +60,9 @@ func (m *Middleware) RequireRole(allowedRoles ...string) gin.HandlerFunc {
 
 // RequirePermission validates that the authenticated user holds permission.
 // The authorization rule itself — root bypasses, non-admin is denied, admin is
-// checked against its admin_role via Casbin — lives in
-// casbin.CheckPermissionWithRoot so every guard (here and the Any/All variants)
-// shares one decision instead of re-deriving it. Denials return a generic 403
+// checked against its admin_role via Casbin, an API key is further limited to
+// its scope — lives in checkPermission so every guard (here and the Any/All
+// variants) shares one decision instead of re-deriving it. Denials return a generic 403
 // rather than naming the reason, to avoid leaking authorization internals.
 func (m *Middleware) RequirePermission(permission permissions.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -56,7 +75,7 @@ func (m *Middleware) RequirePermission(permission permissions.Permission) gin.Ha
 			return
 		}
 
-		allowed, err := m.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleID, permission.String())
+		allowed, err := m.checkPermission(values, permission)
 		if err != nil {
 			logger.Ctx(ctx).Error("Failed to verify permission",
 				zap.String("permission", permission.String()), zap.Error(err))
@@ -94,7 +113,7 @@ func (m *Middleware) RequireAnyPermission(perms ...permissions.Permission) gin.H
 		// masquerade as an authorization denial.
 		checkFailed := false
 		for _, perm := range perms {
-			allowed, err := m.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleID, perm.String())
+			allowed, err := m.checkPermission(values, perm)
 			if err != nil {
 				logger.Ctx(ctx).Error("Failed to verify permission",
 					zap.String("permission", perm.String()), zap.Error(err))
@@ -141,7 +160,7 @@ func (m *Middleware) RequireAllPermissions(perms ...permissions.Permission) gin.
 		}
 
 		for _, perm := range perms {
-			allowed, err := m.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleID, perm.String())
+			allowed, err := m.checkPermission(values, perm)
 			if err != nil {
 				logger.Ctx(ctx).Error("Failed to verify permission",
 					zap.String("permission", perm.String()), zap.Error(err))
@@ -161,7 +180,13 @@ func (m *Middleware) RequireAllPermissions(perms ...permissions.Permission) gin.
 	}
 }
 
-// LoginHandler returns gin-jwt's login handler
+// checkPermission reports whether the caller holds perm, within the scope of
+// the API key the request used, if any (see casbin.CheckCaller).
+func (m *Middleware) checkPermission(values *utils.ContextValues, perm permissions.Permission) (bool, error) {
+	return casbin.CheckCaller(m.casbinClient, values, perm.String())
+}
+
+// LoginHandler returns the password-login handler
 func (m *Middleware) LoginHandler() gin.HandlerFunc {
-	return m.authJWT.Middleware.LoginHandler
+	return m.authJWT.LoginHandler
 }
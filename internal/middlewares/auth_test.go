@@ -38,10 +38,10 @@ func serve(r *gin.Engine) *httptest.ResponseRecorder {
 
 func adminValues(roleID uint) utils.ContextValues {
 	return utils.ContextValues{
-		UserID:      7,
-		UserName:    "alice",
-		Role:        models.UserRoleAdmin.ToString(),
-		AdminRoleID: &roleID,
+		UserID:       7,
+		UserName:     "alice",
+		Role:         models.UserRoleAdmin.ToString(),
+		AdminRoleIDs: []uint{roleID},
 	}
 }
 
@@ -287,3 +287,76 @@ func TestRequireAllPermissionsFailsClosedOnCasbinError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rec.Code)
 }
+
+// apiKeyValues is an admin authenticated by an API key scoped to scope.
+func apiKeyValues(roleID uint, scope ...permissions.Permission) utils.ContextValues {
+	values := adminValues(roleID)
+	values.APIKeyID = 11
+	for _, perm := range scope {
+		values.APIKeyPermissions = append(values.APIKeyPermissions, perm.String())
+	}
+	return values
+}
+
+func TestRequirePermissionLimitsAPIKeyToItsScope(t *testing.T) {
+	setupLogger(t)
+	casbinClient := newCasbinClient(func(uint, string) (bool, error) {
+		return true, nil // the owner's role holds everything
+	},
+	)
+	m := newMiddleware(casbinClient)
+
+	rec := serve(newAuthRouter(casbinClient, withContextValues(apiKeyValues(3, permissions.UserRead)),
+		m.RequirePermission(permissions.UserDelete)))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serve(newAuthRouter(casbinClient, withContextValues(apiKeyValues(3, permissions.UserRead)),
+		m.RequirePermission(permissions.UserRead)))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequirePermissionAPIKeyScopeDoesNotWidenOwnerRole(t *testing.T) {
+	setupLogger(t)
+	casbinClient := newCasbinClient(func(uint, string) (bool, error) {
+		return false, nil // the owner's role has since lost the permission
+	},
+	)
+	m := newMiddleware(casbinClient)
+
+	rec := serve(newAuthRouter(casbinClient, withContextValues(apiKeyValues(3, permissions.UserRead)),
+		m.RequirePermission(permissions.UserRead)))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRequireAnyAndAllPermissionsApplyAPIKeyScope(t *testing.T) {
+	setupLogger(t)
+	casbinClient := newCasbinClient(func(uint, string) (bool, error) {
+		return true, nil
+	},
+	)
+	m := newMiddleware(casbinClient)
+	identity := withContextValues(apiKeyValues(3, permissions.UserRead))
+
+	rec := serve(newAuthRouter(casbinClient, identity, m.RequireAnyPermission(permissions.UserDelete, permissions.UserRead)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(newAuthRouter(casbinClient, identity, m.RequireAllPermissions(permissions.UserRead, permissions.UserDelete)))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRequirePermissionLimitsRootAPIKeyToItsScope(t *testing.T) {
+	setupLogger(t)
+	casbinClient := newCasbinClient(nil)
+	m := newMiddleware(casbinClient)
+	identity := withContextValues(utils.ContextValues{
+		UserID:            1,
+		Role:              models.UserRoleRoot.ToString(),
+		APIKeyID:          11,
+		APIKeyPermissions: []string{permissions.LogRead.String()},
+	})
+
+	rec := serve(newAuthRouter(casbinClient, identity, m.RequirePermission(permissions.UserDelete)))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
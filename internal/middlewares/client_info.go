@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxUserAgentLength caps the stored user agent; it matches the
+// refresh_tokens.user_agent column width.
+const maxUserAgentLength = 512
+
+// ClientInfo records the caller's user agent and IP on the request context so
+// services (session bookkeeping in particular) can read them without a
+// *gin.Context. The IP is gin's ClientIP, which only honors forwarding headers
+// from SERVER_TRUSTED_PROXIES.
+func (m *Middleware) ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := utils.SetClientInfoToContext(c.Request.Context(), utils.ClientInfo{
+			UserAgent: truncateUserAgent(c.Request.UserAgent()),
+			IP:        c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// truncateUserAgent trims ua to maxUserAgentLength bytes without splitting a
+// multi-byte rune.
+func truncateUserAgent(ua string) string {
+	ua = strings.TrimSpace(ua)
+	if len(ua) <= maxUserAgentLength {
+		return ua
+	}
+	ua = ua[:maxUserAgentLength]
+	for !utf8.ValidString(ua) {
+		ua = ua[:len(ua)-1]
+	}
+	return ua
+}
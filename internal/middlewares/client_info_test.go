@@ -0,0 +1,50 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func serveClientInfo(t *testing.T, userAgent string) utils.ClientInfo {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var got utils.ClientInfo
+	r := gin.New()
+	r.Use(newMiddleware(nil).ClientInfo())
+	r.GET("/test", func(c *gin.Context) {
+		got = utils.GetClientInfoFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/test", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", userAgent)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	return got
+}
+
+func TestClientInfoStoresUserAgentAndIP(t *testing.T) {
+	got := serveClientInfo(t, "Mozilla/5.0 (X11; Linux x86_64)")
+
+	require.Equal(t, "Mozilla/5.0 (X11; Linux x86_64)", got.UserAgent)
+	require.Equal(t, "203.0.113.7", got.IP)
+}
+
+func TestClientInfoTruncatesLongUserAgentOnRuneBoundary(t *testing.T) {
+	got := serveClientInfo(t, strings.Repeat("é", 400))
+
+	require.LessOrEqual(t, len(got.UserAgent), 512)
+	require.True(t, utf8.ValidString(got.UserAgent))
+}
@@ -5,12 +5,14 @@ import "github.com/gin-gonic/gin"
 // CORS middleware adds Cross-Origin Resource Sharing headers.
 //
 // The allowed origins come from SERVER_CORS_ALLOWED_ORIGINS. When the list is
-// empty every origin is allowed via the wildcard "*" — acceptable only while
-// auth is a Bearer token (browsers reject Allow-Credentials together with a
-// wildcard origin, and cookies are never used here, so credentials are never
-// needed). When the list is set, the request Origin is echoed back only if it
-// matches the allowlist exactly, and "Vary: Origin" is emitted so caches never
-// serve one origin's Allow-Origin header to another.
+// empty every origin is allowed via the wildcard "*" — acceptable only for
+// Bearer-token clients (browsers reject Allow-Credentials together with a
+// wildcard origin, so no cross-origin page can ride on auth cookies). When the
+// list is set, the request Origin is echoed back only if it matches the
+// allowlist exactly, and "Vary: Origin" is emitted so caches never serve one
+// origin's Allow-Origin header to another. In AUTH_MODE=cookie an allowlisted
+// origin is also told it may send credentials, which the web admin needs for
+// its cookies to travel.
 func (m *Middleware) CORS() gin.HandlerFunc {
 	// Build the lookup set once at construction, not per request.
 	var allowed map[string]struct{}
@@ -21,6 +23,8 @@ func (m *Middleware) CORS() gin.HandlerFunc {
 		}
 	}
 
+	credentials := m.cfg != nil && m.cfg.Auth.CookieMode()
+
 	return func(c *gin.Context) {
 		if allowed == nil {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
@@ -32,6 +36,9 @@ func (m *Middleware) CORS() gin.HandlerFunc {
 			if origin := c.GetHeader("Origin"); origin != "" {
 				if _, ok := allowed[origin]; ok {
 					c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+					if credentials {
+						c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+					}
 				}
 			}
 		}
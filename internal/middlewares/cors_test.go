@@ -92,3 +92,24 @@ func TestCORSOmitsAllowOriginForDisallowedOrigin(t *testing.T) {
 		})
 	}
 }
+
+func TestCORSAllowsCredentialsForAllowlistedOriginInCookieMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Server.CORSAllowedOrigins = []string{"https://admin.example.com"}
+	cfg.Auth.Mode = config.AuthModeCookie
+	r := gin.New()
+	r.Use(newMiddlewareWithConfig(cfg, nil).CORS())
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	rec := performCORSRequest(r, http.MethodGet, "https://admin.example.com")
+	require.Equal(t, "https://admin.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	rec = performCORSRequest(r, http.MethodGet, "https://evil.example.com")
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Bearer mode never needs credentials, even for a listed origin.
+	rec = performCORSRequest(newCORSRouter("https://admin.example.com"), http.MethodGet, "https://admin.example.com")
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
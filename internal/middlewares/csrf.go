@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csrfFailedMessage is returned when a cookie-authenticated unsafe request
+// does not echo the CSRF cookie in the X-CSRF-Token header.
+const csrfFailedMessage = "invalid csrf token"
+
+// CSRF enforces the double-submit token in AUTH_MODE=cookie: an unsafe
+// request that carries an auth cookie must repeat the csrf_token cookie in
+// the X-CSRF-Token header. A cross-site page can make the browser send the
+// cookies but can neither read the token nor set the header without passing
+// CORS. Requests with an Authorization header are exempt — that header is
+// never attached by the browser on its own — as are requests with no auth
+// cookie, such as login. In bearer mode the middleware does nothing.
+func (m *Middleware) CSRF() gin.HandlerFunc {
+	if m.cfg == nil || !m.cfg.Auth.CookieMode() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) || c.GetHeader("Authorization") != "" || !hasAuthCookie(c) {
+			c.Next()
+			return
+		}
+
+		cookie, err := c.Cookie(authjwt.CSRFCookie)
+		header := c.GetHeader(authjwt.CSRFHeader)
+		if err != nil || cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1 {
+			c.JSON(http.StatusForbidden, response.BuildResponseFailed(csrfFailedMessage))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func hasAuthCookie(c *gin.Context) bool {
+	for _, name := range []string{authjwt.AccessTokenCookie, authjwt.RefreshTokenCookie} {
+		if v, err := c.Cookie(name); err == nil && v != "" {
+			return true
+		}
+	}
+	return false
+}
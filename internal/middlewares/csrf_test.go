@@ -0,0 +1,71 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+func newCSRFRouter(mode string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	cfg.Auth.Mode = mode
+	r := gin.New()
+	r.Use(newMiddlewareWithConfig(cfg, nil).CSRF())
+	r.Any("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func performCSRFRequest(r *gin.Engine, method string, cookies map[string]string, headers map[string]string) int {
+	req := httptest.NewRequestWithContext(context.Background(), method, "/test", nil)
+	for name, value := range cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestCSRFRequiresMatchingHeaderForCookieAuthenticatedWrites(t *testing.T) {
+	r := newCSRFRouter(config.AuthModeCookie)
+	session := map[string]string{authjwt.AccessTokenCookie: "jwt", authjwt.CSRFCookie: "token"}
+
+	cases := map[string]struct {
+		method  string
+		cookies map[string]string
+		headers map[string]string
+		want    int
+	}{
+		"matching header":       {http.MethodPost, session, map[string]string{authjwt.CSRFHeader: "token"}, http.StatusOK},
+		"missing header":        {http.MethodPost, session, nil, http.StatusForbidden},
+		"wrong header":          {http.MethodDelete, session, map[string]string{authjwt.CSRFHeader: "guess"}, http.StatusForbidden},
+		"missing csrf cookie":   {http.MethodPost, map[string]string{authjwt.RefreshTokenCookie: "rt"}, map[string]string{authjwt.CSRFHeader: ""}, http.StatusForbidden},
+		"safe method":           {http.MethodGet, session, nil, http.StatusOK},
+		"bearer header":         {http.MethodPost, session, map[string]string{"Authorization": "Bearer jwt"}, http.StatusOK},
+		"no auth cookie":        {http.MethodPost, nil, nil, http.StatusOK},
+		"only unrelated cookie": {http.MethodPost, map[string]string{"theme": "dark"}, nil, http.StatusOK},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, performCSRFRequest(r, tc.method, tc.cookies, tc.headers))
+		})
+	}
+}
+
+func TestCSRFIsInertInBearerMode(t *testing.T) {
+	r := newCSRFRouter(config.AuthModeBearer)
+
+	code := performCSRFRequest(r, http.MethodPost, map[string]string{authjwt.AccessTokenCookie: "jwt"}, nil)
+	require.Equal(t, http.StatusOK, code)
+}
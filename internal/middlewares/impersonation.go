@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/PhantomX7/athleton/pkg/response"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// impersonationForbiddenMessage is returned when an impersonation token hits a
+// route that only the account owner may use.
+const impersonationForbiddenMessage = "not allowed while impersonating"
+
+// ForbidImpersonation refuses requests made with an impersonation token. It
+// guards the routes that would let support staff outlast or widen the
+// impersonation: changing credentials, issuing API keys, managing 2FA, and
+// starting another impersonation. It must run AFTER the auth middleware; a
+// request without auth values is let through for that middleware to reject.
+func (m *Middleware) ForbidImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		values, err := utils.ValuesFromContext(c.Request.Context())
+		if err == nil && values.ImpersonatorID != 0 {
+			c.JSON(http.StatusForbidden, response.BuildResponseFailed(impersonationForbiddenMessage))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,35 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func TestForbidImpersonationRejectsImpersonationToken(t *testing.T) {
+	m := newMiddleware(nil)
+	identity := withAuthenticatedUser(utils.ContextValues{
+		UserID:           7,
+		Role:             models.UserRoleUser.ToString(),
+		ImpersonatorID:   1,
+		ImpersonatorName: "Root",
+	}, nil)
+
+	rec := serve(newAuthRouter(nil, identity, m.ForbidImpersonation()))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "not allowed while impersonating")
+}
+
+func TestForbidImpersonationAllowsOwnSession(t *testing.T) {
+	m := newMiddleware(nil)
+	identity := withAuthenticatedUser(utils.ContextValues{UserID: 7, Role: models.UserRoleUser.ToString()}, nil)
+
+	rec := serve(newAuthRouter(nil, identity, m.ForbidImpersonation()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -33,20 +33,25 @@ func newCasbinClient(checkPermissionFn func(uint, string) (bool, error)) *casbin
 	mock := &casbinmocks.ClientMock{
 		CheckPermissionFunc: checkPermissionFn,
 	}
-	mock.CheckPermissionWithRootFunc = func(userRole string, adminRoleID *uint, permission string) (bool, error) {
+	mock.CheckPermissionWithRootFunc = func(userRole string, adminRoleIDs []uint, permission string) (bool, error) {
 		if userRole == "root" {
 			return true, nil
 		}
-		if userRole != "admin" || adminRoleID == nil {
+		if userRole != "admin" {
 			return false, nil
 		}
-		return mock.CheckPermission(*adminRoleID, permission)
+		for _, roleID := range adminRoleIDs {
+			if allowed, err := mock.CheckPermission(roleID, permission); err != nil || allowed {
+				return allowed, err
+			}
+		}
+		return false, nil
 	}
 	return mock
 }
 
 // newMiddleware builds the middleware bundle without a JWT dependency; tests
-// here never exercise RequireAuth/LoginHandler, which are gin-jwt passthroughs.
+// here never exercise RequireAuth/LoginHandler, which delegate to AuthJWT.
 // The zero-value config leaves CORS in wildcard mode; tests that need an
 // origin allowlist use newMiddlewareWithConfig instead.
 func newMiddleware(casbinClient casbin.Client) *middlewares.Middleware {
@@ -3,6 +3,7 @@ package middlewares
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/PhantomX7/athleton/internal/models"
 	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
@@ -15,12 +16,14 @@ import (
 )
 
 // passwordChangeRequiredMessage is returned when an admin/root account still
-// uses a password it did not choose itself (the seeder's default).
+// uses a password it did not choose itself (the seeder's default) or one
+// older than AUTH_PASSWORD_MAX_AGE.
 const passwordChangeRequiredMessage = "password change required"
 
 // RequirePasswordChanged blocks admin/root accounts that have never changed
 // their password (User.PasswordChangedAt == nil) from operating with the
-// seeder's default password. It must run AFTER RequireAuth, whose authorizer
+// seeder's default password, and, when AUTH_PASSWORD_MAX_AGE is set, those
+// whose password has outlived it. It must run AFTER RequireAuth, whose authorizer
 // loads the user record and stores it in the gin context under
 // authjwt.AuthUserKey. Regular (non-admin) users pass through: they chose
 // their own password at registration and are never seeded with a default.
@@ -57,7 +60,7 @@ func (m *Middleware) RequirePasswordChanged() gin.HandlerFunc {
 			return
 		}
 
-		if user.PasswordChangedAt == nil {
+		if user.MustChangePassword(m.cfg.Auth.PasswordMaxAge, time.Now()) {
 			c.JSON(http.StatusForbidden, response.BuildResponseFailed(passwordChangeRequiredMessage))
 			c.Abort()
 			return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/PhantomX7/athleton/internal/models"
 	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/config"
 	"github.com/PhantomX7/athleton/pkg/utils"
 )
 
@@ -123,3 +124,32 @@ func TestRequirePasswordChangedFailsClosedWhenUserNotLoaded(t *testing.T) {
 	require.Equal(t, http.StatusForbidden, rec.Code)
 	require.Contains(t, rec.Body.String(), "password change required")
 }
+
+func TestRequirePasswordChangedRejectsAdminPastMaxAge(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(&config.Config{Auth: config.AuthConfig{PasswordMaxAge: time.Hour}}, nil)
+	stale := time.Now().Add(-2 * time.Hour)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleAdmin.ToString()},
+		&models.User{ID: 7, Role: models.UserRoleAdmin, PasswordChangedAt: &stale},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequirePasswordChanged()))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "password change required")
+}
+
+func TestRequirePasswordChangedAllowsAdminWithinMaxAge(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(&config.Config{Auth: config.AuthConfig{PasswordMaxAge: time.Hour}}, nil)
+	fresh := time.Now().Add(-time.Minute)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleAdmin.ToString()},
+		&models.User{ID: 7, Role: models.UserRoleAdmin, PasswordChangedAt: &fresh},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequirePasswordChanged()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -63,9 +63,9 @@ func rateLimitHandler(limiter *ipRateLimiter) gin.HandlerFunc {
 }
 
 // AuthRateLimiter returns a per-client-IP token-bucket limiter sized for
-// credential-bearing auth endpoints (login/register). Refills at 1 req/sec
-// with a burst of 5 — tight enough to blunt credential stuffing, loose enough
-// for legitimate retries. Each call returns an INDEPENDENT limiter instance,
+// credential-bearing auth endpoints (login/register/password reset/email
+// verification). Refills at 1 req/sec with a burst of 5 — tight enough to
+// blunt credential stuffing, loose enough for legitimate retries. Each call returns an INDEPENDENT limiter instance,
 // so give every route its own: sharing one across endpoints lets traffic on
 // one endpoint exhaust the budget of another.
 func (m *Middleware) AuthRateLimiter() gin.HandlerFunc {
@@ -0,0 +1,77 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/response"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// ReauthenticationRequiredCode is the error code of the 403 returned by
+	// RequireRecentAuth. A client seeing it should ask the user for their
+	// password (or a TOTP code), call POST /auth/reauthenticate and retry.
+	ReauthenticationRequiredCode = "reauthentication_required"
+
+	reauthenticationRequiredMessage = "recent authentication required"
+)
+
+// RequireRecentAuth guards sensitive operations with step-up authentication:
+// the session must have logged in or called POST /auth/reauthenticate within
+// maxAge, so a stale token left in an unattended browser cannot delete users
+// or hand out admin permissions. It must run AFTER RequireAuth, whose
+// authorizer stores the session in the gin context.
+//
+// API-key requests pass: a key is not a browser session and cannot
+// reauthenticate, and the permissions it may exercise were chosen explicitly
+// when it was issued. Impersonation sessions are never recent, since
+// /auth/reauthenticate refuses them.
+func (m *Middleware) RequireRecentAuth(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		values, err := utils.ValuesFromContext(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, response.BuildResponseFailed("unauthorized"))
+			c.Abort()
+			return
+		}
+
+		if values.APIKeyID != 0 {
+			c.Next()
+			return
+		}
+
+		session, ok := sessionFromContext(c)
+		if !ok {
+			// Without the session we cannot prove the authentication is
+			// recent — fail closed.
+			logger.Warn("RequireRecentAuth ran without a loaded session; check middleware ordering",
+				zap.String("request_id", utils.GetRequestIDFromContext(c.Request.Context())),
+				zap.Uint("user_id", values.UserID),
+			)
+		}
+		if !ok || session.AuthenticatedAt == nil || time.Since(*session.AuthenticatedAt) > maxAge {
+			c.JSON(http.StatusForbidden, response.BuildResponseFailedWithCode(reauthenticationRequiredMessage, ReauthenticationRequiredCode))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// sessionFromContext reads the session stored by the JWT authorizer.
+func sessionFromContext(c *gin.Context) (*models.RefreshToken, bool) {
+	value, exists := c.Get(authjwt.AuthSessionKey)
+	if !exists {
+		return nil, false
+	}
+	session, ok := value.(*models.RefreshToken)
+	return session, ok && session != nil
+}
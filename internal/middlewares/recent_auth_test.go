@@ -0,0 +1,82 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/middlewares"
+	"github.com/PhantomX7/athleton/internal/models"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+// withSession stands in for the JWT authorizer: it injects the context
+// values and stores session in the gin context. session may be nil to
+// simulate middleware misordering.
+func withSession(values utils.ContextValues, session *models.RefreshToken) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(utils.NewContextWithValues(c.Request.Context(), values))
+		if session != nil {
+			c.Set(authjwt.AuthSessionKey, session)
+		}
+		c.Next()
+	}
+}
+
+func authenticatedAgo(d time.Duration) *models.RefreshToken {
+	at := time.Now().Add(-d)
+	return &models.RefreshToken{ID: uuid.New(), UserID: 7, AuthenticatedAt: &at}
+}
+
+func TestRequireRecentAuthAllowsFreshSession(t *testing.T) {
+	m := newMiddleware(nil)
+	identity := withSession(adminValues(3), authenticatedAgo(time.Minute))
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireRecentAuth(5*time.Minute)))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireRecentAuthRejectsStaleSessionWithCode(t *testing.T) {
+	setupLogger(t)
+	m := newMiddleware(nil)
+
+	for name, session := range map[string]*models.RefreshToken{
+		"stale":               authenticatedAgo(10 * time.Minute),
+		"never authenticated": {ID: uuid.New(), UserID: 7},
+		"missing session":     nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			identity := withSession(adminValues(3), session)
+
+			rec := serve(newAuthRouter(nil, identity, m.RequireRecentAuth(5*time.Minute)))
+
+			require.Equal(t, http.StatusForbidden, rec.Code)
+			require.Contains(t, rec.Body.String(), `"code":"`+middlewares.ReauthenticationRequiredCode+`"`)
+		})
+	}
+}
+
+func TestRequireRecentAuthLetsAPIKeysThrough(t *testing.T) {
+	m := newMiddleware(nil)
+	values := adminValues(3)
+	values.APIKeyID = 11
+	identity := withSession(values, nil)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireRecentAuth(5*time.Minute)))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireRecentAuthRejectsMissingContextValues(t *testing.T) {
+	m := newMiddleware(nil)
+
+	rec := serve(newAuthRouter(nil, nil, m.RequireRecentAuth(5*time.Minute)))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
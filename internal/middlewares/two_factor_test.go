@@ -0,0 +1,83 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func twoFactorRequiredConfig() *config.Config {
+	return &config.Config{Auth: config.AuthConfig{TwoFactorRequiredForAdmins: true}}
+}
+
+func TestRequireTwoFactorIsNoOpWhenNotRequired(t *testing.T) {
+	setupLogger(t)
+	m := newMiddleware(nil)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleAdmin.ToString()},
+		&models.User{ID: 7, Role: models.UserRoleAdmin},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireTwoFactor()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireTwoFactorAllowsRegularUserWithoutTwoFactor(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(twoFactorRequiredConfig(), nil)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleUser.ToString()},
+		&models.User{ID: 7, Role: models.UserRoleUser},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireTwoFactor()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireTwoFactorRejectsAdminWithoutTwoFactor(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(twoFactorRequiredConfig(), nil)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleAdmin.ToString()},
+		&models.User{ID: 7, Role: models.UserRoleAdmin},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireTwoFactor()))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "two-factor authentication required")
+}
+
+func TestRequireTwoFactorAllowsRootWithTwoFactor(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(twoFactorRequiredConfig(), nil)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 1, Role: models.UserRoleRoot.ToString()},
+		&models.User{ID: 1, Role: models.UserRoleRoot, TwoFactorEnabledAt: passwordChangedAt()},
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireTwoFactor()))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireTwoFactorFailsClosedWhenUserNotLoaded(t *testing.T) {
+	setupLogger(t)
+	m := newMiddlewareWithConfig(twoFactorRequiredConfig(), nil)
+	identity := withAuthenticatedUser(
+		utils.ContextValues{UserID: 7, Role: models.UserRoleAdmin.ToString()},
+		nil,
+	)
+
+	rec := serve(newAuthRouter(nil, identity, m.RequireTwoFactor()))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "two-factor authentication required")
+}
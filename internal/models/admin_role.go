@@ -14,6 +14,14 @@ type AdminRole struct {
 	Description string   `json:"description" gorm:"type:varchar(255);null"`
 	IsActive    bool     `json:"is_active" gorm:"not null;default:true"`
 	Permissions []string `json:"permissions" gorm:"-"`
+	// ParentRoleID and EffectivePermissions are owned by Casbin, like
+	// Permissions: the role inherits every permission of its parent.
+	ParentRoleID         *uint                 `json:"parent_role_id" gorm:"-"`
+	EffectivePermissions []AdminRolePermission `json:"effective_permissions" gorm:"-"`
+	// Denials are the role's own deny rules, which win over any grant;
+	// EffectiveDenials adds those inherited from its ancestors.
+	Denials          []string              `json:"denials" gorm:"-"`
+	EffectiveDenials []AdminRolePermission `json:"effective_denials" gorm:"-"`
 	Timestamp
 
 	// Polymorphic Logs. polymorphicValue must equal LogEntityTypeAdminRole
@@ -21,15 +29,69 @@ type AdminRole struct {
 	Logs []Log `json:"-" gorm:"polymorphic:Entity;polymorphicValue:admin_role"`
 }
 
+// AdminRolePermission is one of a role's effective permissions or deny rules.
+type AdminRolePermission struct {
+	Permission string `json:"permission"`
+	// Inherited is true when the role holds the permission (or deny rule)
+	// only through an ancestor, not by its own grant.
+	Inherited bool `json:"inherited"`
+}
+
+// SetPermissions fills Permissions with the role's own grants and
+// EffectivePermissions with everything it holds, marking what is inherited.
+func (a *AdminRole) SetPermissions(direct, effective []string) {
+	a.Permissions = direct
+	a.EffectivePermissions = markInherited(direct, effective)
+}
+
+// SetDenials fills Denials with the role's own deny rules and
+// EffectiveDenials with every deny rule that applies to it, marking what is
+// inherited.
+func (a *AdminRole) SetDenials(direct, effective []string) {
+	a.Denials = direct
+	a.EffectiveDenials = markInherited(direct, effective)
+}
+
+// markInherited flags each effective entry not among the direct ones.
+func markInherited(direct, effective []string) []AdminRolePermission {
+	own := make(map[string]struct{}, len(direct))
+	for _, perm := range direct {
+		own[perm] = struct{}{}
+	}
+	result := make([]AdminRolePermission, 0, len(effective))
+	for _, perm := range effective {
+		_, isOwn := own[perm]
+		result = append(result, AdminRolePermission{Permission: perm, Inherited: !isOwn})
+	}
+	return result
+}
+
 // ToResponse converts an AdminRole into its response DTO.
 func (a *AdminRole) ToResponse() *dto.AdminRoleResponse {
+	denials := a.Denials
+	if denials == nil {
+		denials = []string{}
+	}
+
 	return &dto.AdminRoleResponse{
-		ID:          a.ID,
-		Name:        a.Name,
-		Description: a.Description,
-		IsActive:    a.IsActive,
-		Permissions: a.Permissions,
-		CreatedAt:   a.CreatedAt,
-		UpdatedAt:   a.UpdatedAt,
+		ID:                   a.ID,
+		Name:                 a.Name,
+		Description:          a.Description,
+		IsActive:             a.IsActive,
+		ParentRoleID:         a.ParentRoleID,
+		Permissions:          a.Permissions,
+		EffectivePermissions: effectiveResponses(a.EffectivePermissions),
+		Denials:              denials,
+		EffectiveDenials:     effectiveResponses(a.EffectiveDenials),
+		CreatedAt:            a.CreatedAt,
+		UpdatedAt:            a.UpdatedAt,
+	}
+}
+
+func effectiveResponses(entries []AdminRolePermission) []dto.EffectivePermissionResponse {
+	result := make([]dto.EffectivePermissionResponse, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, dto.EffectivePermissionResponse{Permission: entry.Permission, Inherited: entry.Inherited})
 	}
+	return result
 }
@@ -0,0 +1,42 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+)
+
+// APIKey is a personal access token a user issues for a machine client. Like
+// refresh tokens only the SHA-256 hash of the secret is stored; Prefix keeps
+// the first few characters in the clear so the owner can tell keys apart.
+// Permissions is the explicit scope the key was granted — requests made with
+// it are authorized against both this list and the owner's current role.
+type APIKey struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id" gorm:"type:bigint;not null;index"`
+	Name        string     `json:"name" gorm:"type:varchar(100);not null"`
+	Prefix      string     `json:"prefix" gorm:"type:varchar(16);not null"`
+	KeyHash     string     `json:"-" gorm:"not null;uniqueIndex"`
+	Permissions []string   `json:"permissions" gorm:"type:text;not null;serializer:json"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" gorm:"null;default:null"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" gorm:"null;default:null"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" gorm:"null;default:null"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// ToResponse converts an APIKey into its API response shape.
+func (k APIKey) ToResponse() dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		Permissions: k.Permissions,
+		ExpiresAt:   k.ExpiresAt,
+		LastUsedAt:  k.LastUsedAt,
+		CreatedAt:   k.CreatedAt,
+	}
+}
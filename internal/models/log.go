@@ -10,19 +10,33 @@ type LogAction string
 
 // Audit-log action values.
 const (
-	LogActionCreate         LogAction = "create"
-	LogActionUpdate         LogAction = "update"
-	LogActionDelete         LogAction = "delete"
-	LogActionLogin          LogAction = "login"
-	LogActionLogout         LogAction = "logout"
-	LogActionImport         LogAction = "import"
-	LogActionExport         LogAction = "export"
-	LogActionChangePassword LogAction = "change_password"
+	LogActionCreate           LogAction = "create"
+	LogActionUpdate           LogAction = "update"
+	LogActionDelete           LogAction = "delete"
+	LogActionLogin            LogAction = "login"
+	LogActionLogout           LogAction = "logout"
+	LogActionImport           LogAction = "import"
+	LogActionExport           LogAction = "export"
+	LogActionChangePassword   LogAction = "change_password"
+	LogActionResetPassword    LogAction = "reset_password"
+	LogActionEnableTwoFactor  LogAction = "enable_two_factor"
+	LogActionDisableTwoFactor LogAction = "disable_two_factor"
+	LogActionRevokeAPIKey     LogAction = "revoke_api_key"
+	LogActionUseAPIKey        LogAction = "use_api_key"
+	LogActionLoginFailed      LogAction = "login_failed"
+	LogActionLockAccount      LogAction = "lock_account"
+	LogActionUnlockAccount    LogAction = "unlock_account"
+	LogActionImpersonate      LogAction = "impersonate"
+	LogActionEndImpersonation LogAction = "end_impersonation"
+	LogActionChangeEmail      LogAction = "change_email"
+	LogActionActivate         LogAction = "activate"
+	LogActionDeactivate       LogAction = "deactivate"
 )
 
 // Audit-log entity-type values.
 const (
 	LogEntityTypeAdminRole = "admin_role"
+	LogEntityTypeAPIKey    = "api_key"
 	LogEntityTypeConfig    = "config"
 	LogEntityTypeUser      = "user"
 )
@@ -34,11 +48,13 @@ func (l LogAction) ToString() string {
 
 // Log stores a single audit-log event.
 type Log struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	UserID     *uint     `json:"user_id" gorm:"index"`
-	Action     LogAction `json:"action" gorm:"type:varchar(50);not null"`
-	EntityType string    `json:"entity_type" gorm:"type:varchar(50);index"`
-	EntityID   uint      `json:"entity_id" gorm:"index"`
+	ID     uint  `json:"id" gorm:"primaryKey"`
+	UserID *uint `json:"user_id" gorm:"index"`
+	// ImpersonatorID is the real actor when UserID was being impersonated.
+	ImpersonatorID *uint     `json:"impersonator_id" gorm:"index"`
+	Action         LogAction `json:"action" gorm:"type:varchar(50);not null"`
+	EntityType     string    `json:"entity_type" gorm:"type:varchar(50);index"`
+	EntityID       uint      `json:"entity_id" gorm:"index"`
 
 	Message string `json:"message" gorm:"type:text"`
 	// IPAddress string `json:"ip_address" gorm:"type:varchar(50)"`
@@ -53,13 +69,14 @@ type Log struct {
 // ToResponse converts a Log into its API response shape.
 func (l Log) ToResponse() dto.LogResponse {
 	response := dto.LogResponse{
-		ID:         l.ID,
-		UserID:     l.UserID,
-		Action:     l.Action.ToString(),
-		EntityType: l.EntityType,
-		EntityID:   l.EntityID,
-		Message:    l.Message,
-		CreatedAt:  l.CreatedAt,
+		ID:             l.ID,
+		UserID:         l.UserID,
+		ImpersonatorID: l.ImpersonatorID,
+		Action:         l.Action.ToString(),
+		EntityType:     l.EntityType,
+		EntityID:       l.EntityID,
+		Message:        l.Message,
+		CreatedAt:      l.CreatedAt,
 	}
 
 	if l.User != nil {
@@ -9,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
+	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/models"
 )
 
@@ -23,25 +24,32 @@ func TestUserRoleToString(t *testing.T) {
 }
 
 func TestUserMustChangePassword(t *testing.T) {
-	changed := time.Now()
+	now := time.Now()
+	changed := now
+	stale := now.Add(-48 * time.Hour)
 
 	cases := []struct {
-		name string
-		user models.User
-		want bool
+		name   string
+		user   models.User
+		maxAge time.Duration
+		want   bool
 	}{
-		{"admin with default password", models.User{Role: models.UserRoleAdmin}, true},
-		{"root with default password", models.User{Role: models.UserRoleRoot}, true},
-		{"admin who rotated", models.User{Role: models.UserRoleAdmin, PasswordChangedAt: &changed}, false},
-		{"root who rotated", models.User{Role: models.UserRoleRoot, PasswordChangedAt: &changed}, false},
+		{"admin with default password", models.User{Role: models.UserRoleAdmin}, 0, true},
+		{"root with default password", models.User{Role: models.UserRoleRoot}, 0, true},
+		{"admin who rotated", models.User{Role: models.UserRoleAdmin, PasswordChangedAt: &changed}, 0, false},
+		{"root who rotated", models.User{Role: models.UserRoleRoot, PasswordChangedAt: &changed}, 0, false},
 		// Regular users are never seeded with a default, so the gate never applies.
-		{"regular user, never changed", models.User{Role: models.UserRoleUser}, false},
-		{"regular user who rotated", models.User{Role: models.UserRoleUser, PasswordChangedAt: &changed}, false},
+		{"regular user, never changed", models.User{Role: models.UserRoleUser}, 0, false},
+		{"regular user who rotated", models.User{Role: models.UserRoleUser, PasswordChangedAt: &changed}, 0, false},
+		{"admin within max age", models.User{Role: models.UserRoleAdmin, PasswordChangedAt: &changed}, 24 * time.Hour, false},
+		{"admin past max age", models.User{Role: models.UserRoleAdmin, PasswordChangedAt: &stale}, 24 * time.Hour, true},
+		{"old password without max age", models.User{Role: models.UserRoleAdmin, PasswordChangedAt: &stale}, 0, false},
+		{"regular user past max age", models.User{Role: models.UserRoleUser, PasswordChangedAt: &stale}, 24 * time.Hour, false},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			require.Equal(t, tc.want, tc.user.MustChangePassword())
+			require.Equal(t, tc.want, tc.user.MustChangePassword(tc.maxAge, now))
 		})
 	}
 }
@@ -64,7 +72,7 @@ func TestUserToResponseMapsAllFields(t *testing.T) {
 		Phone:        "08123456789",
 		IsActive:     true,
 		Role:         models.UserRoleAdmin,
-		AdminRoleID:  uintPtr(3),
+		AdminRoles:   []models.AdminRole{{ID: 3}},
 		Password:     "secret-hash",
 		Timestamp:    models.Timestamp{CreatedAt: createdAt},
 	}
@@ -80,10 +88,9 @@ func TestUserToResponseMapsAllFields(t *testing.T) {
 	require.Equal(t, "08123456789", got.Phone)
 	require.True(t, got.IsActive)
 	require.Equal(t, "admin", got.Role)
-	require.NotNil(t, got.AdminRoleID)
-	require.Equal(t, uint(3), *got.AdminRoleID)
+	require.Equal(t, []uint{3}, got.AdminRoleIDs)
 	require.Equal(t, createdAt, got.CreatedAt)
-	require.Nil(t, got.AdminRole)
+	require.Len(t, got.AdminRoles, 1)
 }
 
 func TestUserToResponseNilAdminRoleFields(t *testing.T) {
@@ -92,30 +99,30 @@ func TestUserToResponseNilAdminRoleFields(t *testing.T) {
 	got := user.ToResponse()
 
 	require.NotNil(t, got)
-	require.Nil(t, got.AdminRoleID)
-	require.Nil(t, got.AdminRole)
+	require.Empty(t, got.AdminRoleIDs)
+	require.Empty(t, got.AdminRoles)
 	require.Equal(t, "user", got.Role)
 	require.False(t, got.IsActive)
 }
 
-func TestUserToResponseIncludesAdminRole(t *testing.T) {
+func TestUserToResponseIncludesAllAdminRoles(t *testing.T) {
 	user := models.User{
-		ID:          2,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: uintPtr(9),
-		AdminRole: &models.AdminRole{
-			ID:          9,
-			Name:        "editor",
-			Permissions: []string{"post:read", "post:write"},
+		ID:   2,
+		Role: models.UserRoleAdmin,
+		AdminRoles: []models.AdminRole{
+			{ID: 9, Name: "editor", Permissions: []string{"post:read", "post:write"}},
+			{ID: 11, Name: "finance", Permissions: []string{"report:read"}},
 		},
 	}
 
 	got := user.ToResponse()
 
-	require.NotNil(t, got.AdminRole)
-	require.Equal(t, uint(9), got.AdminRole.ID)
-	require.Equal(t, "editor", got.AdminRole.Name)
-	require.Equal(t, []string{"post:read", "post:write"}, got.AdminRole.Permissions)
+	require.Equal(t, []uint{9, 11}, got.AdminRoleIDs)
+	require.Len(t, got.AdminRoles, 2)
+	require.Equal(t, "editor", got.AdminRoles[0].Name)
+	require.Equal(t, []string{"post:read", "post:write"}, got.AdminRoles[0].Permissions)
+	require.Equal(t, "finance", got.AdminRoles[1].Name)
+	require.Equal(t, []string{"report:read"}, got.AdminRoles[1].Permissions)
 }
 
 func TestAdminRoleToResponseMapsAllFields(t *testing.T) {
@@ -152,6 +159,23 @@ func TestAdminRoleToResponseNilPermissions(t *testing.T) {
 	require.False(t, got.IsActive)
 }
 
+func TestAdminRoleToResponseIncludesDenials(t *testing.T) {
+	role := models.AdminRole{ID: 13, Name: "support lead"}
+	role.SetDenials([]string{"admin_user:delete"}, []string{"admin_user:delete", "config:update"})
+
+	got := role.ToResponse()
+
+	require.Equal(t, []string{"admin_user:delete"}, got.Denials)
+	require.Equal(t, []dto.EffectivePermissionResponse{
+		{Permission: "admin_user:delete", Inherited: false},
+		{Permission: "config:update", Inherited: true},
+	}, got.EffectiveDenials)
+
+	// A role without deny rules still lists an empty set.
+	empty := models.AdminRole{ID: 14}
+	require.NotNil(t, empty.ToResponse().Denials)
+}
+
 // TestAutoMigrateUniqueIndexes verifies the model index tags migrate cleanly
 // and that unique indexes on soft-deleting tables are partial: a soft-deleted
 // row must not block reuse of its value, while an active duplicate must fail.
@@ -167,6 +191,12 @@ func TestAutoMigrateUniqueIndexes(t *testing.T) {
 		&models.Config{},
 		&models.Log{},
 		&models.AdminRole{},
+		&models.UserToken{},
+		&models.TwoFactorRecoveryCode{},
+		&models.APIKey{},
+		&models.PasswordHistory{},
+		&models.PersonalDataHistory{},
+		&models.SecurityEvent{},
 	))
 
 	newUser := func(username, email string) *models.User {
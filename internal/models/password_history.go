@@ -0,0 +1,18 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+)
+
+// PasswordHistory is a password an account used before its current one. Only
+// the bcrypt hash is kept, exactly as it sat in users.password, so a reuse
+// check costs one bcrypt comparison per row.
+type PasswordHistory struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	UserID       uint      `json:"user_id" gorm:"type:bigint;not null;index"`
+	PasswordHash string    `json:"-" gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
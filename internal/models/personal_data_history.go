@@ -0,0 +1,19 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+)
+
+// PersonalDataHistory is a personal value, such as a name or an email
+// address, that an account has held. Audit messages quote these values as
+// they were when the entry was written, so erasing an account redacts every
+// value it ever held, not just its current ones.
+type PersonalDataHistory struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"type:bigint;not null;uniqueIndex:idx_personal_data_histories_user_value"`
+	Value     string    `json:"-" gorm:"type:varchar(255);not null;uniqueIndex:idx_personal_data_histories_user_value"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
@@ -4,6 +4,8 @@ package models
 import (
 	"time"
 
+	"github.com/PhantomX7/athleton/internal/dto"
+
 	"github.com/google/uuid"
 )
 
@@ -19,11 +21,49 @@ type RefreshToken struct {
 	// request failing with a generic invalid-token error. Nullable — a freshly
 	// minted token has no predecessor — and only the immediate predecessor is
 	// retained, so reuse detection covers a one-step replay, not the full chain.
-	PreviousTokenHash *string    `json:"-" gorm:"index;default:null"`
-	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
-	CreatedAt         time.Time  `json:"created_at" gorm:"not null"`
-	UpdatedAt         time.Time  `json:"updated_at" gorm:"not null"`
-	RevokedAt         *time.Time `json:"revoked_at,omitempty" gorm:"null;default:null"`
+	PreviousTokenHash *string   `json:"-" gorm:"index;default:null"`
+	ExpiresAt         time.Time `json:"expires_at" gorm:"not null"`
+	// UserAgent and IPAddress describe the client that last logged in or
+	// refreshed on this session, and LastUsedAt when it did; they exist so a
+	// user can recognize (and revoke) their own sessions.
+	UserAgent  string    `json:"user_agent" gorm:"type:varchar(512);not null;default:''"`
+	IPAddress  string    `json:"ip_address" gorm:"type:varchar(45);not null;default:''"`
+	LastUsedAt time.Time `json:"last_used_at" gorm:"not null"`
+	// LoggedInAt is when the login that opened the session happened. Rotation
+	// keeps it, so it dates the whole session family: the session ends once it
+	// is older than its policy's max age, however often it is refreshed.
+	LoggedInAt time.Time `json:"logged_in_at" gorm:"not null"`
+	// RememberMe records that the login asked to be remembered, which selects
+	// the longer AUTH_REMEMBER_ME_* session policy.
+	RememberMe bool       `json:"remember_me" gorm:"not null;default:false"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"null;default:null"`
+	// AuthenticatedAt is when the user last proved their identity on this
+	// session: at login, and again at each POST /auth/reauthenticate. Sensitive
+	// routes (RequireRecentAuth) require it to be recent. Nil for
+	// impersonation sessions, which can never reauthenticate as the target.
+	AuthenticatedAt *time.Time `json:"-" gorm:"null;default:null"`
+	// ImpersonatorID is set on a session opened by another user impersonating
+	// UserID. Such a session hands out no refresh token, is not one of the
+	// user's own devices, and does not count toward their session cap.
+	ImpersonatorID *uint `json:"impersonator_id,omitempty" gorm:"type:bigint;null;index"`
 
 	User User `json:"user" gorm:"foreignKey:UserID"`
 }
+
+// ToSessionResponse converts a RefreshToken into its session-list shape,
+// flagging it as current when its ID is currentSessionID.
+func (r RefreshToken) ToSessionResponse(currentSessionID uuid.UUID) dto.SessionResponse {
+	return dto.SessionResponse{
+		ID:         r.ID.String(),
+		UserAgent:  r.UserAgent,
+		IPAddress:  r.IPAddress,
+		CreatedAt:  r.CreatedAt,
+		LastUsedAt: r.LastUsedAt,
+		ExpiresAt:  r.ExpiresAt,
+		RememberMe: r.RememberMe,
+		RevokedAt:  r.RevokedAt,
+		Current:    r.ID == currentSessionID,
+	}
+}
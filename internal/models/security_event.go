@@ -0,0 +1,75 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType is the kind of account event a SecurityEvent records.
+type SecurityEventType string
+
+// Security-event type values.
+const (
+	SecurityEventLogin          SecurityEventType = "login"
+	SecurityEventRefresh        SecurityEventType = "refresh"
+	SecurityEventRefreshReuse   SecurityEventType = "refresh_reuse"
+	SecurityEventLogout         SecurityEventType = "logout"
+	SecurityEventPasswordChange SecurityEventType = "password_change"
+	SecurityEventPasswordReset  SecurityEventType = "password_reset"
+	SecurityEventReauthenticate SecurityEventType = "reauthenticate"
+	SecurityEventMagicLinkSent  SecurityEventType = "magic_link_sent"
+	SecurityEventMagicLinkLogin SecurityEventType = "magic_link_login"
+	SecurityEventEmailChange    SecurityEventType = "email_change"
+	SecurityEventDataExport     SecurityEventType = "data_export"
+	SecurityEventOIDCLogin      SecurityEventType = "oidc_login"
+)
+
+// SecurityEventOutcome says whether the recorded attempt succeeded.
+type SecurityEventOutcome string
+
+// Security-event outcome values.
+const (
+	SecurityEventOutcomeSuccess SecurityEventOutcome = "success"
+	SecurityEventOutcomeFailure SecurityEventOutcome = "failure"
+)
+
+// SecurityEvent is one entry in a user's security timeline: a login, a
+// refresh, a logout or a password change, successful or not. Unlike the
+// audit Log it is kept for every role and carries the client details, so a
+// user can spot activity that was not theirs.
+type SecurityEvent struct {
+	ID      uint                 `json:"id" gorm:"primaryKey"`
+	UserID  uint                 `json:"user_id" gorm:"type:bigint;not null;index"`
+	Type    SecurityEventType    `json:"type" gorm:"type:varchar(50);not null"`
+	Outcome SecurityEventOutcome `json:"outcome" gorm:"type:varchar(20);not null"`
+	// IPAddress and UserAgent come from the ClientInfo middleware and match
+	// the refresh_tokens columns of the same name.
+	IPAddress string `json:"ip_address" gorm:"type:varchar(45);not null;default:''"`
+	UserAgent string `json:"user_agent" gorm:"type:varchar(512);not null;default:''"`
+	// SessionID is the refresh-token session the event concerns, when there
+	// is one (a failed login has none).
+	SessionID *uuid.UUID `json:"session_id" gorm:"type:uuid;null;default:null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;index"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// ToResponse converts a SecurityEvent into its API response shape.
+func (e SecurityEvent) ToResponse() dto.SecurityEventResponse {
+	response := dto.SecurityEventResponse{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		Outcome:   string(e.Outcome),
+		IPAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.SessionID != nil {
+		response.SessionID = e.SessionID.String()
+	}
+	return response
+}
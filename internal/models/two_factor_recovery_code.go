@@ -0,0 +1,19 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+)
+
+// TwoFactorRecoveryCode is a single-use fallback for a lost authenticator.
+// Codes are issued in a batch when 2FA is enabled (or regenerated) and, like
+// every other token in the system, only their SHA-256 hash is stored.
+type TwoFactorRecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"type:bigint;not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	UsedAt    *time.Time `json:"used_at,omitempty" gorm:"null;default:null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
@@ -17,6 +17,19 @@ const (
 	UserRoleRoot  UserRole = "root"
 )
 
+// UserAuthProvider identifies where an account's password is checked.
+type UserAuthProvider string
+
+// Supported auth-provider values.
+const (
+	// UserAuthProviderLocal accounts log in with the password hash stored on
+	// the row.
+	UserAuthProviderLocal UserAuthProvider = "local"
+	// UserAuthProviderLDAP accounts were provisioned from the directory and
+	// log in with their directory password; the row holds none.
+	UserAuthProviderLDAP UserAuthProvider = "ldap"
+)
+
 // Note: Removed UserRoleWriter - writers are now admins with specific permissions
 
 // ToString converts a UserRole to its raw string representation.
@@ -41,16 +54,40 @@ type User struct {
 	Phone        string   `json:"phone" gorm:"type:varchar(255);not null"`
 	IsActive     bool     `json:"is_active" gorm:"not null;default:true"`
 	Role         UserRole `json:"role" gorm:"type:user_role;not null"`
-	AdminRoleID  *uint    `json:"admin_role_id" gorm:"type:bigint;null;index"`
 	Password     string   `json:"-" gorm:"type:varchar(255);not null"`
+	// AuthProvider says where Password is checked. Directory accounts have
+	// an empty Password and cannot change it here.
+	AuthProvider UserAuthProvider `json:"auth_provider" gorm:"type:varchar(20);not null;default:'local'"`
 	// PasswordChangedAt is nil while the account still uses a password it did
 	// not choose itself (e.g. the seeder's default). Admin/root accounts with a
 	// nil value are blocked from /admin routes until they change it.
 	PasswordChangedAt *time.Time `json:"-" gorm:"null;default:null"`
+	// EmailVerifiedAt is stamped when the user redeems an emailed
+	// verification link; nil means the address is unconfirmed.
+	EmailVerifiedAt *time.Time `json:"email_verified_at" gorm:"null;default:null"`
+	// TwoFactorSecret is the base32 TOTP shared secret. It is set by 2FA
+	// setup before enrolment is confirmed, so a non-empty value alone does not
+	// mean 2FA is on — TwoFactorEnabledAt does.
+	TwoFactorSecret    string     `json:"-" gorm:"type:varchar(64);not null;default:''"`
+	TwoFactorEnabledAt *time.Time `json:"-" gorm:"null;default:null"`
+	// TwoFactorLastStep is the TOTP time step of the last accepted code; a
+	// code from the same or an earlier step is refused as a replay.
+	TwoFactorLastStep int64 `json:"-" gorm:"not null;default:0"`
+	// FailedLoginAttempts counts consecutive wrong passwords since the last
+	// successful login (or manual unlock); it drives the lockout backoff.
+	// The count starts over once AUTH_LOCKOUT_WINDOW has passed since
+	// LastFailedLoginAt and any lockout, so sporadic typos never add up.
+	FailedLoginAttempts int        `json:"-" gorm:"not null;default:0"`
+	LastFailedLoginAt   *time.Time `json:"-" gorm:"null;default:null"`
+	// LockedUntil refuses password logins until it passes. It is cleared by
+	// the next successful login or an admin unlock, not when it lapses.
+	LockedUntil *time.Time `json:"locked_until" gorm:"null;default:null"`
 	Timestamp
 
 	// Relationships
-	AdminRole *AdminRole `json:"admin_role,omitempty" gorm:"foreignKey:AdminRoleID"`
+	// AdminRoles are the roles assigned through user_admin_roles; an admin is
+	// granted the union of their permissions.
+	AdminRoles []AdminRole `json:"admin_roles,omitempty" gorm:"many2many:user_admin_roles"`
 
 	// Polymorphic Logs. polymorphicValue must equal LogEntityTypeUser — it is
 	// the discriminator the audit writers store; a mismatch makes this preload
@@ -59,31 +96,97 @@ type User struct {
 }
 
 // MustChangePassword reports whether this account still uses a password it did
-// not choose itself — an admin/root seeded with the default. It mirrors the
-// RequirePasswordChanged middleware gate (which enforces it) and drives the API
-// hint of the same name, so the frontend can route to the change-password
-// screen proactively instead of waiting for a 403 on the first /admin call.
-func (u User) MustChangePassword() bool {
-	return u.Role.IsAdminType() && u.PasswordChangedAt == nil
+// not choose itself — an admin/root seeded with the default — or, with a
+// non-zero maxAge (AUTH_PASSWORD_MAX_AGE), one that has been in use for longer
+// than maxAge at now. It mirrors the RequirePasswordChanged middleware gate
+// (which enforces it) and drives the API hint of the same name, so the
+// frontend can route to the change-password screen proactively instead of
+// waiting for a 403 on the first /admin call.
+func (u User) MustChangePassword(maxAge time.Duration, now time.Time) bool {
+	if !u.Role.IsAdminType() || u.IsDirectoryAccount() {
+		return false
+	}
+	if u.PasswordChangedAt == nil {
+		return true
+	}
+	return maxAge > 0 && now.Sub(*u.PasswordChangedAt) >= maxAge
+}
+
+// IsDirectoryAccount reports whether the account's password lives in the
+// directory rather than on the row. The directory owns its rotation, so
+// MustChangePassword never holds for it.
+func (u User) IsDirectoryAccount() bool {
+	return u.AuthProvider == UserAuthProviderLDAP
+}
+
+// IsEmailVerified reports whether the user has confirmed their email address.
+func (u User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsTwoFactorEnabled reports whether the user has completed TOTP enrolment.
+func (u User) IsTwoFactorEnabled() bool {
+	return u.TwoFactorEnabledAt != nil
+}
+
+// MustEnableTwoFactor reports whether this account is blocked from /admin for
+// lacking 2FA while AUTH_TWO_FACTOR_REQUIRED_FOR_ADMINS is on. Like
+// MustChangePassword it mirrors a middleware gate (RequireTwoFactor) so the
+// frontend can route to enrolment up front.
+func (u User) MustEnableTwoFactor(required bool) bool {
+	return required && u.Role.IsAdminType() && !u.IsTwoFactorEnabled()
+}
+
+// AdminRoleIDs returns the IDs of the loaded AdminRoles, in the order they
+// were loaded. It is empty unless the association was loaded.
+func (u User) AdminRoleIDs() []uint {
+	ids := make([]uint, 0, len(u.AdminRoles))
+	for _, role := range u.AdminRoles {
+		ids = append(ids, role.ID)
+	}
+	return ids
+}
+
+// PersonalData returns the account's personal values that audit messages may
+// quote: its name, username, email, business name and phone, skipping empty
+// ones.
+func (u User) PersonalData() []string {
+	values := make([]string, 0, 5)
+	for _, v := range []string{u.Name, u.Username, u.Email, u.BusinessName, u.Phone} {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// IsLocked reports whether password logins are refused at now because of
+// repeated failures.
+func (u User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 // ToResponse converts a User into its response DTO.
 func (u User) ToResponse() *dto.UserResponse {
 	response := dto.UserResponse{
-		ID:           u.ID,
-		Name:         u.Name,
-		BusinessName: u.BusinessName,
-		Username:     u.Username,
-		Email:        u.Email,
-		Phone:        u.Phone,
-		IsActive:     u.IsActive,
-		Role:         u.Role.ToString(),
-		AdminRoleID:  u.AdminRoleID,
-		CreatedAt:    u.CreatedAt,
+		ID:              u.ID,
+		Name:            u.Name,
+		BusinessName:    u.BusinessName,
+		Username:        u.Username,
+		Email:           u.Email,
+		Phone:           u.Phone,
+		IsActive:        u.IsActive,
+		Role:            u.Role.ToString(),
+		AdminRoleIDs:    u.AdminRoleIDs(),
+		AuthProvider:    string(u.AuthProvider),
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		LockedUntil:     u.LockedUntil,
+		CreatedAt:       u.CreatedAt,
 	}
 
-	if u.AdminRole != nil {
-		response.AdminRole = u.AdminRole.ToResponse()
+	response.AdminRoles = make([]*dto.AdminRoleResponse, 0, len(u.AdminRoles))
+	for _, role := range u.AdminRoles {
+		response.AdminRoles = append(response.AdminRoles, role.ToResponse())
 	}
 
 	return &response
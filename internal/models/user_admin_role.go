@@ -0,0 +1,17 @@
+// Package models defines the application's persistence models.
+package models
+
+import "time"
+
+// UserAdminRole assigns one admin role to a user. A user may hold several
+// roles and is granted the union of their permissions. Rows are written only
+// through the user repository, never through the User.AdminRoles
+// association, so the CreatedAt column is always set.
+type UserAdminRole struct {
+	UserID      uint      `json:"user_id" gorm:"type:bigint;primaryKey;autoIncrement:false"`
+	AdminRoleID uint      `json:"admin_role_id" gorm:"type:bigint;primaryKey;autoIncrement:false;index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null"`
+
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+	AdminRole AdminRole `json:"-" gorm:"foreignKey:AdminRoleID"`
+}
@@ -0,0 +1,37 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+)
+
+// UserIdentity links a user to their account at an OpenID Connect provider,
+// so one user can log in through several providers. Subject is the
+// provider's stable identifier for the account; Email is the address it
+// reported at the last login, kept for display only. A provider account links
+// to one user, and a user links at most one account per provider.
+type UserIdentity struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id" gorm:"type:bigint;not null;uniqueIndex:idx_user_identities_user_provider"`
+	Provider    string     `json:"provider" gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject;uniqueIndex:idx_user_identities_user_provider"`
+	Subject     string     `json:"-" gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email       string     `json:"email" gorm:"type:varchar(255);not null"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" gorm:"null;default:null"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// ToResponse converts a UserIdentity into its API response shape.
+func (i UserIdentity) ToResponse() dto.UserIdentityResponse {
+	return dto.UserIdentityResponse{
+		ID:          i.ID,
+		Provider:    i.Provider,
+		Email:       i.Email,
+		LastLoginAt: i.LastLoginAt,
+		CreatedAt:   i.CreatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+// Package models defines the application's persistence models.
+package models
+
+import (
+	"time"
+)
+
+// UserTokenPurpose scopes a one-time user token to the flow that issued it, so
+// a token minted for one flow can never be redeemed by another.
+type UserTokenPurpose string
+
+// Supported user-token purposes.
+const (
+	UserTokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPurposeMagicLink         UserTokenPurpose = "magic_link"
+	UserTokenPurposeEmailChange       UserTokenPurpose = "email_change"
+	// UserTokenPurposeDataExport is not consumed on use: the download link
+	// works until it expires.
+	UserTokenPurposeDataExport UserTokenPurpose = "data_export"
+	// UserTokenPurposeTwoFactorChallenge is never emailed: it is handed back
+	// by a password login and exchanged, with a TOTP code, for a session.
+	UserTokenPurposeTwoFactorChallenge UserTokenPurpose = "two_factor_challenge"
+)
+
+// UserToken stores a single-use, time-limited token issued to a user (e.g. a
+// password-reset link or a pending 2FA login). Only the SHA-256 hash of the
+// token is persisted, the same way refresh tokens are stored.
+type UserToken struct {
+	ID      uint             `json:"id" gorm:"primaryKey"`
+	UserID  uint             `json:"user_id" gorm:"type:bigint;not null;index"`
+	Purpose UserTokenPurpose `json:"purpose" gorm:"type:varchar(50);not null"`
+	// user_tokens never soft-deletes, so a plain unique index is correct here.
+	TokenHash string    `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	// NewEmail is the address an email-change token switches the account to
+	// once redeemed; it is empty for every other purpose.
+	NewEmail string `json:"-" gorm:"type:varchar(255);not null;default:''"`
+	// ConsumedAt is stamped when the token is redeemed or superseded; a
+	// non-null value makes the token unusable.
+	ConsumedAt *time.Time `json:"consumed_at,omitempty" gorm:"null;default:null"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"not null"`
+
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
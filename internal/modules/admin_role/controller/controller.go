@@ -20,6 +20,8 @@ type AdminRoleController interface {
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Activate(ctx *gin.Context)
+	Deactivate(ctx *gin.Context)
 	FindByID(ctx *gin.Context)
 	GetAllPermissions(ctx *gin.Context)
 }
@@ -91,7 +93,7 @@ func (c *adminRoleController) Index(ctx *gin.Context) {
 // Create handles the creation of a new admin role
 //
 //	@Summary		Create admin role
-//	@Description	Create a new admin role with permissions
+//	@Description	Create a new admin role with permissions and optional deny rules, which win over any grant
 //	@Tags			admin-role
 //	@Accept			json
 //	@Produce		json
@@ -120,7 +122,7 @@ func (c *adminRoleController) Create(ctx *gin.Context) {
 // Update handles the update of an existing admin role
 //
 //	@Summary		Update admin role
-//	@Description	Update an admin role's details and permissions
+//	@Description	Update an admin role's details, permissions and deny rules; lifting a deny rule requires holding what it denied
 //	@Tags			admin-role
 //	@Accept			json
 //	@Produce		json
@@ -184,6 +186,64 @@ func (c *adminRoleController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("Admin role deleted successfully", nil))
 }
 
+// Activate handles reactivating an admin role
+//
+//	@Summary		Activate admin role
+//	@Description	Reactivate an admin role so its holders regain its permissions
+//	@Tags			admin-role
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		uint	true	"Admin Role ID"
+//	@Success		200	{object}	response.Response{data=dto.AdminRoleResponse}
+//	@Failure		403	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/admin/admin-role/{id}/activate [post]
+func (c *adminRoleController) Activate(ctx *gin.Context) {
+	roleID, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	adminRole, err := c.adminRoleService.Activate(ctx.Request.Context(), roleID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("Admin role activated successfully", adminRole.ToResponse()))
+}
+
+// Deactivate handles deactivating an admin role
+//
+//	@Summary		Deactivate admin role
+//	@Description	Deactivate an admin role; its holders are denied every permission until it is reactivated
+//	@Tags			admin-role
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		uint	true	"Admin Role ID"
+//	@Success		200	{object}	response.Response{data=dto.AdminRoleResponse}
+//	@Failure		403	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/admin/admin-role/{id}/deactivate [post]
+func (c *adminRoleController) Deactivate(ctx *gin.Context) {
+	roleID, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	adminRole, err := c.adminRoleService.Deactivate(ctx.Request.Context(), roleID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("Admin role deactivated successfully", adminRole.ToResponse()))
+}
+
 // FindByID handles fetching a single admin role by ID
 //
 //	@Summary		Get admin role by ID
@@ -218,7 +278,7 @@ func (c *adminRoleController) FindByID(ctx *gin.Context) {
 // GetAllPermissions returns all available permissions
 //
 //	@Summary		Get all permissions
-//	@Description	Get all available permissions grouped by resource
+//	@Description	Get all grantable permissions grouped by resource, including each resource's "resource:*" and "resource:manage" grants; the "*" group lists the "*:action" grants
 //	@Tags			admin-role
 //	@Accept			json
 //	@Produce		json
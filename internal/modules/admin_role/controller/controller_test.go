@@ -123,7 +123,7 @@ func TestAdminRoleControllerFindByIDReturnsSuccessResponse(t *testing.T) {
 	data, ok := body["data"].(map[string]any)
 	require.True(t, ok)
 	require.ElementsMatch(t,
-		[]string{"id", "name", "description", "is_active", "permissions", "created_at", "updated_at"},
+		[]string{"id", "name", "description", "is_active", "parent_role_id", "permissions", "effective_permissions", "denials", "effective_denials", "created_at", "updated_at"},
 		slices.Collect(maps.Keys(data)),
 	)
 	require.Equal(t, float64(5), data["id"])
@@ -312,3 +312,52 @@ func TestAdminRoleControllerIndexPropagatesServiceError(t *testing.T) {
 	require.Len(t, ctx.Errors, 1)
 	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
 }
+
+func TestAdminRoleControllerDeactivateReturnsSuccessResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &adminroleservicemocks.AdminRoleServiceMock{
+		DeactivateFunc: func(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+			require.Equal(t, uint(5), roleID)
+			return &models.AdminRole{ID: 5, Name: "Support", IsActive: false}, nil
+		},
+	}
+
+	ctrl := controller.NewAdminRoleController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/admin-role/5/deactivate", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "5"}}
+
+	ctrl.Deactivate(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "Admin role deactivated successfully", body["message"])
+	data, ok := body["data"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, false, data["is_active"])
+}
+
+func TestAdminRoleControllerActivateRejectsInvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &adminroleservicemocks.AdminRoleServiceMock{
+		ActivateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			t.Fatal("Activate should not be called for invalid ids")
+			return nil, nil
+		},
+	}
+
+	ctrl := controller.NewAdminRoleController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/admin-role/abc/activate", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	ctrl.Activate(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, cerrors.ErrInvalidInput)
+}
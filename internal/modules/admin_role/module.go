@@ -2,6 +2,8 @@
 package admin_role
 
 import (
+	"context"
+
 	"github.com/PhantomX7/athleton/internal/modules/admin_role/controller"
 	"github.com/PhantomX7/athleton/internal/modules/admin_role/repository"
 	"github.com/PhantomX7/athleton/internal/modules/admin_role/service"
@@ -22,4 +24,16 @@ var Module = fx.Options(
 			fx.ResultTags(`group:"routes"`),
 		),
 	),
+	fx.Invoke(RegisterRoleStatusLoader),
 )
+
+// RegisterRoleStatusLoader loads which roles are deactivated into Casbin on
+// startup. It runs before the HTTP server starts listening, so no request is
+// ever authorized against a deactivated role.
+func RegisterRoleStatusLoader(lc fx.Lifecycle, adminRoleService service.AdminRoleService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return adminRoleService.LoadRoleStatus(ctx)
+		},
+	})
+}
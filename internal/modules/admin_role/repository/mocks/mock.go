@@ -47,6 +47,9 @@ var _ adminrolerepository.AdminRoleRepository = &AdminRoleRepositoryMock{}
 //			FindByNameFunc: func(ctx context.Context, name string) (*models.AdminRole, error) {
 //				panic("mock out the FindByName method")
 //			},
+//			FindInactiveIDsFunc: func(ctx context.Context) ([]uint, error) {
+//				panic("mock out the FindInactiveIDs method")
+//			},
 //			UpdateFunc: func(ctx context.Context, entity *models.AdminRole) error {
 //				panic("mock out the Update method")
 //			},
@@ -81,6 +84,9 @@ type AdminRoleRepositoryMock struct {
 	// FindByNameFunc mocks the FindByName method.
 	FindByNameFunc func(ctx context.Context, name string) (*models.AdminRole, error)
 
+	// FindInactiveIDsFunc mocks the FindInactiveIDs method.
+	FindInactiveIDsFunc func(ctx context.Context) ([]uint, error)
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, entity *models.AdminRole) error
 
@@ -144,6 +150,11 @@ type AdminRoleRepositoryMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// FindInactiveIDs holds details about calls to the FindInactiveIDs method.
+		FindInactiveIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -160,6 +171,7 @@ type AdminRoleRepositoryMock struct {
 	lockFindByID           sync.RWMutex
 	lockFindByIDForUpdate  sync.RWMutex
 	lockFindByName         sync.RWMutex
+	lockFindInactiveIDs    sync.RWMutex
 	lockUpdate             sync.RWMutex
 }
 
@@ -455,6 +467,38 @@ func (mock *AdminRoleRepositoryMock) FindByNameCalls() []struct {
 	return calls
 }
 
+// FindInactiveIDs calls FindInactiveIDsFunc.
+func (mock *AdminRoleRepositoryMock) FindInactiveIDs(ctx context.Context) ([]uint, error) {
+	if mock.FindInactiveIDsFunc == nil {
+		panic("AdminRoleRepositoryMock.FindInactiveIDsFunc: method is nil but AdminRoleRepository.FindInactiveIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockFindInactiveIDs.Lock()
+	mock.calls.FindInactiveIDs = append(mock.calls.FindInactiveIDs, callInfo)
+	mock.lockFindInactiveIDs.Unlock()
+	return mock.FindInactiveIDsFunc(ctx)
+}
+
+// FindInactiveIDsCalls gets all the calls that were made to FindInactiveIDs.
+// Check the length with:
+//
+//	len(mockedAdminRoleRepository.FindInactiveIDsCalls())
+func (mock *AdminRoleRepositoryMock) FindInactiveIDsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockFindInactiveIDs.RLock()
+	calls = mock.calls.FindInactiveIDs
+	mock.lockFindInactiveIDs.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *AdminRoleRepositoryMock) Update(ctx context.Context, entity *models.AdminRole) error {
 	if mock.UpdateFunc == nil {
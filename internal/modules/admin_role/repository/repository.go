@@ -26,6 +26,7 @@ type AdminRoleRepository interface {
 	FindByName(ctx context.Context, name string) (*models.AdminRole, error)
 	FindByIDForUpdate(ctx context.Context, id uint) (*models.AdminRole, error)
 	CountUsersWithRole(ctx context.Context, roleID uint) (int64, error)
+	FindInactiveIDs(ctx context.Context) ([]uint, error)
 }
 
 type adminRoleRepository struct {
@@ -93,7 +94,7 @@ func (r *adminRoleRepository) CountUsersWithRole(ctx context.Context, roleID uin
 	start := time.Now()
 
 	count, err := gorm.G[models.User](r.GetDB(ctx)).
-		Where(generated.User.AdminRoleID.Eq(roleID)).
+		Where("EXISTS (SELECT 1 FROM user_admin_roles WHERE user_admin_roles.user_id = users.id AND user_admin_roles.admin_role_id = ?)", roleID).
 		Count(ctx, "*")
 
 	r.LogSlowRead(ctx, "CountUsersWithRole", time.Since(start))
@@ -109,3 +110,26 @@ func (r *adminRoleRepository) CountUsersWithRole(ctx context.Context, roleID uin
 
 	return count, nil
 }
+
+// FindInactiveIDs returns the IDs of every non-deleted role that has been
+// deactivated, so their status can be loaded into Casbin at startup.
+func (r *adminRoleRepository) FindInactiveIDs(ctx context.Context) ([]uint, error) {
+	start := time.Now()
+
+	roles, err := gorm.G[models.AdminRole](r.GetDB(ctx)).
+		Select(generated.AdminRole.ID.Column().Name).
+		Where(generated.AdminRole.IsActive.Eq(false)).
+		Find(ctx)
+
+	r.LogSlowRead(ctx, "FindInactiveIDs", time.Since(start))
+
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to find inactive admin roles", err)
+	}
+
+	ids := make([]uint, 0, len(roles))
+	for _, role := range roles {
+		ids = append(ids, role.ID)
+	}
+	return ids, nil
+}
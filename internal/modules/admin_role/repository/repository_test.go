@@ -23,7 +23,7 @@ func setupDB(t *testing.T) *gorm.DB {
 		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
 	})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}))
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.UserAdminRole{}))
 
 	return db
 }
@@ -95,41 +95,44 @@ func TestAdminRoleRepositoryCountUsersWithRoleCountsActiveUsersOnly(t *testing.T
 	require.NoError(t, db.Create(role).Error)
 
 	active := &models.User{
-		Username:    "alice",
-		Name:        "Alice",
-		Email:       "alice@example.com",
-		Phone:       "08123456789",
-		IsActive:    true,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: &role.ID,
-		Password:    "secret",
+		Username: "alice",
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
 	}
 	deleted := &models.User{
-		Username:    "bob",
-		Name:        "Bob",
-		Email:       "bob@example.com",
-		Phone:       "08123456780",
-		IsActive:    true,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: &role.ID,
-		Password:    "secret",
+		Username: "bob",
+		Name:     "Bob",
+		Email:    "bob@example.com",
+		Phone:    "08123456780",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
 	}
 	otherRole := &models.AdminRole{Name: "Writer", Description: "Writer team", IsActive: true}
 	require.NoError(t, db.Create(otherRole).Error)
 	other := &models.User{
-		Username:    "charlie",
-		Name:        "Charlie",
-		Email:       "charlie@example.com",
-		Phone:       "08123456781",
-		IsActive:    true,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: &otherRole.ID,
-		Password:    "secret",
+		Username: "charlie",
+		Name:     "Charlie",
+		Email:    "charlie@example.com",
+		Phone:    "08123456781",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
 	}
 
 	require.NoError(t, db.Create(active).Error)
 	require.NoError(t, db.Create(deleted).Error)
 	require.NoError(t, db.Create(other).Error)
+	require.NoError(t, db.Create([]models.UserAdminRole{
+		{UserID: active.ID, AdminRoleID: role.ID},
+		{UserID: active.ID, AdminRoleID: otherRole.ID},
+		{UserID: deleted.ID, AdminRoleID: role.ID},
+		{UserID: other.ID, AdminRoleID: otherRole.ID},
+	}).Error)
 	require.NoError(t, db.Delete(deleted).Error)
 
 	count, err := repo.CountUsersWithRole(context.Background(), role.ID)
@@ -137,3 +140,25 @@ func TestAdminRoleRepositoryCountUsersWithRoleCountsActiveUsersOnly(t *testing.T
 	require.NoError(t, err)
 	require.EqualValues(t, 1, count)
 }
+
+func TestAdminRoleRepositoryFindInactiveIDsSkipsActiveAndDeletedRoles(t *testing.T) {
+	db := setupDB(t)
+	repo := adminrolerepository.NewAdminRoleRepository(db)
+
+	active := &models.AdminRole{Name: "Support", IsActive: true}
+	inactive := &models.AdminRole{Name: "Writer", IsActive: true}
+	deleted := &models.AdminRole{Name: "Auditor", IsActive: true}
+	require.NoError(t, db.Create(active).Error)
+	require.NoError(t, db.Create(inactive).Error)
+	require.NoError(t, db.Create(deleted).Error)
+	// The column defaults to true, so deactivate after the insert.
+	require.NoError(t, db.Model(&models.AdminRole{}).
+		Where("id IN ?", []uint{inactive.ID, deleted.ID}).
+		Update("is_active", false).Error)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	ids, err := repo.FindInactiveIDs(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, []uint{inactive.ID}, ids)
+}
@@ -4,25 +4,33 @@ package admin_role
 import (
 	"github.com/PhantomX7/athleton/internal/modules/admin_role/controller"
 	"github.com/PhantomX7/athleton/internal/routes"
+	"github.com/PhantomX7/athleton/pkg/config"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
 )
 
 type routeRegistrar struct {
 	controller controller.AdminRoleController
+	cfg        *config.Config
 }
 
 // NewRoutes constructs the admin-role route registrar.
-func NewRoutes(controller controller.AdminRoleController) routes.Registrar {
-	return &routeRegistrar{controller: controller}
+func NewRoutes(controller controller.AdminRoleController, cfg *config.Config) routes.Registrar {
+	return &routeRegistrar{controller: controller, cfg: cfg}
 }
 
 // RegisterRoutes mounts the admin-role endpoints.
 func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	// Changing roles changes what admins may do, so writes demand step-up
+	// authentication.
+	recentAuth := ctx.MW.RequireRecentAuth(r.cfg.Auth.ReauthenticationWindow)
+
 	adminRoleRoute := ctx.Admin.Group("/admin-role")
 	adminRoleRoute.GET("", ctx.MW.RequirePermission(permissions.AdminRoleRead), r.controller.Index)
 	adminRoleRoute.GET("/permissions", ctx.MW.RequirePermission(permissions.AdminRoleRead), r.controller.GetAllPermissions)
 	adminRoleRoute.GET("/:id", ctx.MW.RequirePermission(permissions.AdminRoleRead), r.controller.FindByID)
-	adminRoleRoute.POST("", ctx.MW.RequirePermission(permissions.AdminRoleCreate), r.controller.Create)
-	adminRoleRoute.PATCH("/:id", ctx.MW.RequirePermission(permissions.AdminRoleUpdate), r.controller.Update)
-	adminRoleRoute.DELETE("/:id", ctx.MW.RequirePermission(permissions.AdminRoleDelete), r.controller.Delete)
+	adminRoleRoute.POST("", ctx.MW.RequirePermission(permissions.AdminRoleCreate), recentAuth, r.controller.Create)
+	adminRoleRoute.PATCH("/:id", ctx.MW.RequirePermission(permissions.AdminRoleUpdate), recentAuth, r.controller.Update)
+	adminRoleRoute.POST("/:id/activate", ctx.MW.RequirePermission(permissions.AdminRoleUpdate), recentAuth, r.controller.Activate)
+	adminRoleRoute.POST("/:id/deactivate", ctx.MW.RequirePermission(permissions.AdminRoleUpdate), recentAuth, r.controller.Deactivate)
+	adminRoleRoute.DELETE("/:id", ctx.MW.RequirePermission(permissions.AdminRoleDelete), recentAuth, r.controller.Delete)
 }
@@ -24,9 +24,15 @@ var _ service.AdminRoleService = &AdminRoleServiceMock{}
 //
 //		// make and configure a mocked service.AdminRoleService
 //		mockedAdminRoleService := &AdminRoleServiceMock{
+//			ActivateFunc: func(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+//				panic("mock out the Activate method")
+//			},
 //			CreateFunc: func(ctx context.Context, req *dto.CreateAdminRoleRequest) (*models.AdminRole, error) {
 //				panic("mock out the Create method")
 //			},
+//			DeactivateFunc: func(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+//				panic("mock out the Deactivate method")
+//			},
 //			DeleteFunc: func(ctx context.Context, roleID uint) error {
 //				panic("mock out the Delete method")
 //			},
@@ -39,6 +45,9 @@ var _ service.AdminRoleService = &AdminRoleServiceMock{}
 //			IndexFunc: func(ctx context.Context, req *pagination.Pagination) ([]*models.AdminRole, response.Meta, error) {
 //				panic("mock out the Index method")
 //			},
+//			LoadRoleStatusFunc: func(ctx context.Context) error {
+//				panic("mock out the LoadRoleStatus method")
+//			},
 //			UpdateFunc: func(ctx context.Context, roleID uint, req *dto.UpdateAdminRoleRequest) (*models.AdminRole, error) {
 //				panic("mock out the Update method")
 //			},
@@ -49,9 +58,15 @@ var _ service.AdminRoleService = &AdminRoleServiceMock{}
 //
 //	}
 type AdminRoleServiceMock struct {
+	// ActivateFunc mocks the Activate method.
+	ActivateFunc func(ctx context.Context, roleID uint) (*models.AdminRole, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, req *dto.CreateAdminRoleRequest) (*models.AdminRole, error)
 
+	// DeactivateFunc mocks the Deactivate method.
+	DeactivateFunc func(ctx context.Context, roleID uint) (*models.AdminRole, error)
+
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, roleID uint) error
 
@@ -64,11 +79,21 @@ type AdminRoleServiceMock struct {
 	// IndexFunc mocks the Index method.
 	IndexFunc func(ctx context.Context, req *pagination.Pagination) ([]*models.AdminRole, response.Meta, error)
 
+	// LoadRoleStatusFunc mocks the LoadRoleStatus method.
+	LoadRoleStatusFunc func(ctx context.Context) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, roleID uint, req *dto.UpdateAdminRoleRequest) (*models.AdminRole, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Activate holds details about calls to the Activate method.
+		Activate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -76,6 +101,13 @@ type AdminRoleServiceMock struct {
 			// Req is the req argument value.
 			Req *dto.CreateAdminRoleRequest
 		}
+		// Deactivate holds details about calls to the Deactivate method.
+		Deactivate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
 		// Delete holds details about calls to the Delete method.
 		Delete []struct {
 			// Ctx is the ctx argument value.
@@ -102,6 +134,11 @@ type AdminRoleServiceMock struct {
 			// Req is the req argument value.
 			Req *pagination.Pagination
 		}
+		// LoadRoleStatus holds details about calls to the LoadRoleStatus method.
+		LoadRoleStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -112,14 +149,53 @@ type AdminRoleServiceMock struct {
 			Req *dto.UpdateAdminRoleRequest
 		}
 	}
+	lockActivate          sync.RWMutex
 	lockCreate            sync.RWMutex
+	lockDeactivate        sync.RWMutex
 	lockDelete            sync.RWMutex
 	lockFindByID          sync.RWMutex
 	lockGetAllPermissions sync.RWMutex
 	lockIndex             sync.RWMutex
+	lockLoadRoleStatus    sync.RWMutex
 	lockUpdate            sync.RWMutex
 }
 
+// Activate calls ActivateFunc.
+func (mock *AdminRoleServiceMock) Activate(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+	if mock.ActivateFunc == nil {
+		panic("AdminRoleServiceMock.ActivateFunc: method is nil but AdminRoleService.Activate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		RoleID uint
+	}{
+		Ctx:    ctx,
+		RoleID: roleID,
+	}
+	mock.lockActivate.Lock()
+	mock.calls.Activate = append(mock.calls.Activate, callInfo)
+	mock.lockActivate.Unlock()
+	return mock.ActivateFunc(ctx, roleID)
+}
+
+// ActivateCalls gets all the calls that were made to Activate.
+// Check the length with:
+//
+//	len(mockedAdminRoleService.ActivateCalls())
+func (mock *AdminRoleServiceMock) ActivateCalls() []struct {
+	Ctx    context.Context
+	RoleID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		RoleID uint
+	}
+	mock.lockActivate.RLock()
+	calls = mock.calls.Activate
+	mock.lockActivate.RUnlock()
+	return calls
+}
+
 // Create calls CreateFunc.
 func (mock *AdminRoleServiceMock) Create(ctx context.Context, req *dto.CreateAdminRoleRequest) (*models.AdminRole, error) {
 	if mock.CreateFunc == nil {
@@ -156,6 +232,42 @@ func (mock *AdminRoleServiceMock) CreateCalls() []struct {
 	return calls
 }
 
+// Deactivate calls DeactivateFunc.
+func (mock *AdminRoleServiceMock) Deactivate(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+	if mock.DeactivateFunc == nil {
+		panic("AdminRoleServiceMock.DeactivateFunc: method is nil but AdminRoleService.Deactivate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		RoleID uint
+	}{
+		Ctx:    ctx,
+		RoleID: roleID,
+	}
+	mock.lockDeactivate.Lock()
+	mock.calls.Deactivate = append(mock.calls.Deactivate, callInfo)
+	mock.lockDeactivate.Unlock()
+	return mock.DeactivateFunc(ctx, roleID)
+}
+
+// DeactivateCalls gets all the calls that were made to Deactivate.
+// Check the length with:
+//
+//	len(mockedAdminRoleService.DeactivateCalls())
+func (mock *AdminRoleServiceMock) DeactivateCalls() []struct {
+	Ctx    context.Context
+	RoleID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		RoleID uint
+	}
+	mock.lockDeactivate.RLock()
+	calls = mock.calls.Deactivate
+	mock.lockDeactivate.RUnlock()
+	return calls
+}
+
 // Delete calls DeleteFunc.
 func (mock *AdminRoleServiceMock) Delete(ctx context.Context, roleID uint) error {
 	if mock.DeleteFunc == nil {
@@ -296,6 +408,38 @@ func (mock *AdminRoleServiceMock) IndexCalls() []struct {
 	return calls
 }
 
+// LoadRoleStatus calls LoadRoleStatusFunc.
+func (mock *AdminRoleServiceMock) LoadRoleStatus(ctx context.Context) error {
+	if mock.LoadRoleStatusFunc == nil {
+		panic("AdminRoleServiceMock.LoadRoleStatusFunc: method is nil but AdminRoleService.LoadRoleStatus was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLoadRoleStatus.Lock()
+	mock.calls.LoadRoleStatus = append(mock.calls.LoadRoleStatus, callInfo)
+	mock.lockLoadRoleStatus.Unlock()
+	return mock.LoadRoleStatusFunc(ctx)
+}
+
+// LoadRoleStatusCalls gets all the calls that were made to LoadRoleStatus.
+// Check the length with:
+//
+//	len(mockedAdminRoleService.LoadRoleStatusCalls())
+func (mock *AdminRoleServiceMock) LoadRoleStatusCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLoadRoleStatus.RLock()
+	calls = mock.calls.LoadRoleStatus
+	mock.lockLoadRoleStatus.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *AdminRoleServiceMock) Update(ctx context.Context, roleID uint, req *dto.UpdateAdminRoleRequest) (*models.AdminRole, error) {
 	if mock.UpdateFunc == nil {
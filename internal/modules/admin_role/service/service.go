@@ -3,6 +3,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
 
 	"github.com/PhantomX7/athleton/internal/audit"
@@ -30,6 +32,9 @@ type AdminRoleService interface {
 	Create(ctx context.Context, req *dto.CreateAdminRoleRequest) (*models.AdminRole, error)
 	Update(ctx context.Context, roleID uint, req *dto.UpdateAdminRoleRequest) (*models.AdminRole, error)
 	Delete(ctx context.Context, roleID uint) error
+	Activate(ctx context.Context, roleID uint) (*models.AdminRole, error)
+	Deactivate(ctx context.Context, roleID uint) (*models.AdminRole, error)
+	LoadRoleStatus(ctx context.Context) error
 	FindByID(ctx context.Context, roleID uint) (*models.AdminRole, error)
 	GetAllPermissions(ctx context.Context) map[string][]map[string]string
 }
@@ -69,7 +74,7 @@ func (s *adminRoleService) Index(ctx context.Context, pg *pagination.Pagination)
 	}
 
 	for _, role := range roles {
-		role.Permissions = s.casbinClient.GetRolePermissions(role.ID)
+		s.loadPermissions(role)
 	}
 
 	return roles, response.Meta{
@@ -81,15 +86,29 @@ func (s *adminRoleService) Index(ctx context.Context, pg *pagination.Pagination)
 
 // Create implements AdminRoleService.
 func (s *adminRoleService) Create(ctx context.Context, req *dto.CreateAdminRoleRequest) (*models.AdminRole, error) {
-	// Validate permissions
+	// Validate permissions and deny rules
 	invalidPerms := s.validatePermissions(req.Permissions)
 	if len(invalidPerms) > 0 {
 		return nil, cerrors.NewBadRequestError("invalid permissions: " + strings.Join(invalidPerms, ", "))
 	}
+	if invalidDenials := s.validatePermissions(req.Denials); len(invalidDenials) > 0 {
+		return nil, cerrors.NewBadRequestError("invalid denials: " + strings.Join(invalidDenials, ", "))
+	}
+	if len(req.Permissions) == 0 && req.ParentRoleID == nil {
+		return nil, cerrors.NewBadRequestError("a role needs permissions or a parent role")
+	}
+	if req.ParentRoleID != nil {
+		if err := s.validateParent(ctx, *req.ParentRoleID); err != nil {
+			return nil, err
+		}
+	}
 
-	// A new role has no current permissions, so every requested permission is a
-	// grant the caller must hold.
-	if err := s.authorizeGrant(ctx, req.Permissions, func() []string { return nil }); err != nil {
+	// A new role has no current permissions, so every permission it will hold,
+	// its own or inherited and not denied, is a grant the caller must hold.
+	err := s.authorizeGrant(ctx, func() []string {
+		return s.roleGrants(req.Permissions, req.Denials, req.ParentRoleID)
+	}, func() []string { return nil })
+	if err != nil {
 		return nil, err
 	}
 
@@ -115,21 +134,40 @@ func (s *adminRoleService) Create(ctx context.Context, req *dto.CreateAdminRoleR
 	// Casbin sync fails we attempt a compensating delete of the role; if that
 	// delete also fails, a role without permissions is left behind and must be
 	// repaired manually (it grants no access, so it fails closed).
-	if err := s.casbinClient.AddRolePermissions(adminRole.ID, req.Permissions); err != nil {
-		// Compensating action: delete the created role
+	syncErr := s.casbinClient.AddRolePermissions(adminRole.ID, req.Permissions)
+	if syncErr != nil {
+		syncErr = cerrors.NewInternalServerError("failed to set role permissions", syncErr)
+	} else if len(req.Denials) > 0 {
+		if err := s.casbinClient.SetRoleDenials(adminRole.ID, req.Denials); err != nil {
+			syncErr = cerrors.NewInternalServerError("failed to set role denials", err)
+		}
+	}
+	if syncErr == nil && req.ParentRoleID != nil {
+		// The new role has no descendants, so this can only fail on the
+		// parent's own chain already being at the depth limit.
+		if err := s.casbinClient.SetRoleParent(adminRole.ID, req.ParentRoleID); err != nil {
+			syncErr = parentError(err)
+		}
+	}
+	if syncErr != nil {
+		// Compensating action: drop any policies written and delete the
+		// created role
+		if delErr := s.casbinClient.DeleteRole(adminRole.ID); delErr != nil {
+			logger.Ctx(ctx, zap.Uint("role_id", adminRole.ID)).Error(
+				"failed to clean up casbin policies after admin role creation failed",
+				zap.Error(delErr),
+			)
+		}
 		if delErr := s.adminRoleRepo.Delete(ctx, adminRole); delErr != nil {
 			logger.Ctx(ctx, zap.Uint("role_id", adminRole.ID)).Error(
 				"CRITICAL: failed to roll back admin role after casbin sync failure; role exists without permissions",
 				zap.Error(delErr),
 			)
 		}
-		return nil, cerrors.NewInternalServerError("failed to set role permissions", err)
+		return nil, syncErr
 	}
 
-	// Get permissions for response
-	rolePermissions := s.casbinClient.GetRolePermissions(adminRole.ID)
-
-	adminRole.Permissions = rolePermissions
+	s.loadPermissions(adminRole)
 
 	// Create audit log
 	s.createLog(ctx, models.LogActionCreate, adminRole.ID, adminRole.Name)
@@ -147,18 +185,57 @@ func (s *adminRoleService) Update(ctx context.Context, roleID uint, req *dto.Upd
 		if len(invalidPerms) > 0 {
 			return nil, cerrors.NewBadRequestError("invalid permissions: " + strings.Join(invalidPerms, ", "))
 		}
+	}
+	if invalidDenials := s.validatePermissions(req.Denials); len(invalidDenials) > 0 {
+		return nil, cerrors.NewBadRequestError("invalid denials: " + strings.Join(invalidDenials, ", "))
+	}
+
+	// A parent_role_id of 0 removes the parent.
+	parentChanged := req.ParentRoleID != nil
+	var newParentID *uint
+	if parentChanged && *req.ParentRoleID != 0 {
+		newParentID = req.ParentRoleID
+		if err := s.validateParent(ctx, *newParentID); err != nil {
+			return nil, err
+		}
+	}
 
-		// Only permissions being ADDED to the role require the caller to hold
-		// them; the role's current permissions are resolved lazily so root
-		// callers skip the Casbin read entirely.
-		err := s.authorizeGrant(ctx, req.Permissions, func() []string {
-			return s.casbinClient.GetRolePermissions(roleID)
+	if req.Permissions != nil || req.Denials != nil || parentChanged {
+		// Only permissions the role would newly hold require the caller to
+		// hold them: new grants, grants from a new parent, and grants freed
+		// by a removed deny rule. Both sides are resolved lazily so root
+		// callers skip the Casbin reads entirely.
+		err := s.authorizeGrant(ctx, func() []string {
+			allows, denials, parentID := req.Permissions, req.Denials, newParentID
+			if allows == nil {
+				allows = s.casbinClient.GetDirectRolePermissions(roleID)
+			}
+			if denials == nil {
+				denials = s.casbinClient.GetDirectRoleDenials(roleID)
+			}
+			if !parentChanged {
+				parentID = s.casbinClient.GetRoleParent(roleID)
+			}
+			return s.roleGrants(allows, denials, parentID)
+		}, func() []string {
+			return grantedPermissions(s.casbinClient.GetRolePermissions(roleID), s.casbinClient.GetRoleDenials(roleID))
 		})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Re-parent before the DB transaction: Casbin is what rejects a cycle or
+	// an over-deep chain, and those must fail the request before anything is
+	// written. If the transaction then fails, the old parent is restored.
+	var oldParentID *uint
+	if parentChanged {
+		oldParentID = s.casbinClient.GetRoleParent(roleID)
+		if err := s.casbinClient.SetRoleParent(roleID, newParentID); err != nil {
+			return nil, parentError(err)
+		}
+	}
+
 	// Run the find→modify→update sequence inside a single transaction so a
 	// failure at any step rolls the role row back and concurrent writers cannot
 	// interleave between the read and the write.
@@ -185,6 +262,14 @@ func (s *adminRoleService) Update(ctx context.Context, roleID uint, req *dto.Upd
 		return s.adminRoleRepo.Update(txCtx, adminRole)
 	})
 	if err != nil {
+		if parentChanged {
+			if restoreErr := s.casbinClient.SetRoleParent(roleID, oldParentID); restoreErr != nil {
+				logger.Ctx(ctx, zap.Uint("role_id", roleID)).Error(
+					"CRITICAL: admin role update failed but its casbin parent could not be restored",
+					zap.Error(restoreErr),
+				)
+			}
+		}
 		return nil, err
 	}
 
@@ -206,11 +291,18 @@ func (s *adminRoleService) Update(ctx context.Context, roleID uint, req *dto.Upd
 			return nil, cerrors.NewInternalServerError("failed to update role permissions", err)
 		}
 	}
+	if req.Denials != nil {
+		if err := s.casbinClient.SetRoleDenials(adminRole.ID, req.Denials); err != nil {
+			logger.Ctx(ctx, zap.Uint("role_id", roleID)).Error(
+				"CRITICAL: admin role updated in DB but casbin denial sync failed; denials are stale",
+				zap.Strings("requested_denials", req.Denials),
+				zap.Error(err),
+			)
+			return nil, cerrors.NewInternalServerError("failed to update role denials", err)
+		}
+	}
 
-	// Get permissions for response
-	rolePermissions := s.casbinClient.GetRolePermissions(adminRole.ID)
-
-	adminRole.Permissions = rolePermissions
+	s.loadPermissions(adminRole)
 
 	// Create audit log
 	s.createLog(ctx, models.LogActionUpdate, adminRole.ID, adminRole.Name)
@@ -220,6 +312,12 @@ func (s *adminRoleService) Update(ctx context.Context, roleID uint, req *dto.Upd
 
 // Delete implements AdminRoleService.
 func (s *adminRoleService) Delete(ctx context.Context, roleID uint) error {
+	// Deleting a parent would silently strip its grants from every role
+	// inheriting them, so those must be re-parented first.
+	if len(s.casbinClient.GetChildRoles(roleID)) > 0 {
+		return cerrors.NewBadRequestError("cannot delete role that other roles inherit from")
+	}
+
 	// Run the find→guard→delete sequence inside a single transaction so a
 	// failure at any step rolls everything back and the assigned-users check
 	// cannot race with the delete.
@@ -277,6 +375,89 @@ func (s *adminRoleService) Delete(ctx context.Context, roleID uint) error {
 	return nil
 }
 
+// Activate implements AdminRoleService. Reactivating a role hands its grants
+// back to every holder, so the caller must hold all of them, as if granting
+// the role afresh.
+func (s *adminRoleService) Activate(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+	err := s.authorizeGrant(ctx, func() []string {
+		return grantedPermissions(s.casbinClient.GetRolePermissions(roleID), s.casbinClient.GetRoleDenials(roleID))
+	}, func() []string { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setActive(ctx, roleID, true)
+}
+
+// Deactivate implements AdminRoleService. The role keeps its permissions, but
+// every holder is denied all of them until the role is activated again. Its
+// deny rules stay in force, so deactivating only ever takes permissions away
+// and needs no grant check.
+func (s *adminRoleService) Deactivate(ctx context.Context, roleID uint) (*models.AdminRole, error) {
+	// Deactivating one of your own roles could lock you out of the endpoint
+	// that reverses it.
+	if slices.Contains(utils.GetAdminRoleIDsFromContext(ctx), roleID) {
+		return nil, cerrors.NewForbiddenError("cannot deactivate your own admin role")
+	}
+
+	return s.setActive(ctx, roleID, false)
+}
+
+// setActive flips the role's status in the DB and then in Casbin, which is
+// where permission checks read it. Asking for the current status is a no-op
+// and writes no audit entry.
+func (s *adminRoleService) setActive(ctx context.Context, roleID uint, active bool) (*models.AdminRole, error) {
+	var (
+		adminRole *models.AdminRole
+		changed   bool
+	)
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		adminRole, err = s.adminRoleRepo.FindByIDForUpdate(txCtx, roleID)
+		if err != nil {
+			return err
+		}
+		if adminRole.IsActive == active {
+			return nil
+		}
+
+		adminRole.IsActive = active
+		changed = true
+		return s.adminRoleRepo.Update(txCtx, adminRole)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Casbin's status is in memory and cannot fail, so syncing it after the
+	// commit leaves no window where the two disagree.
+	s.casbinClient.SetRoleActive(adminRole.ID, adminRole.IsActive)
+	s.loadPermissions(adminRole)
+
+	if changed {
+		action := models.LogActionDeactivate
+		if active {
+			action = models.LogActionActivate
+		}
+		s.createLog(ctx, action, adminRole.ID, adminRole.Name)
+	}
+
+	return adminRole, nil
+}
+
+// LoadRoleStatus implements AdminRoleService. Casbin keeps role status in
+// memory only, so it must be loaded from the DB before requests are served.
+func (s *adminRoleService) LoadRoleStatus(ctx context.Context) error {
+	ids, err := s.adminRoleRepo.FindInactiveIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s.casbinClient.SetRoleActive(id, false)
+	}
+	return nil
+}
+
 // FindByID implements AdminRoleService.
 func (s *adminRoleService) FindByID(ctx context.Context, roleID uint) (*models.AdminRole, error) {
 	adminRole, err := s.adminRoleRepo.FindByID(ctx, roleID)
@@ -285,9 +466,7 @@ func (s *adminRoleService) FindByID(ctx context.Context, roleID uint) (*models.A
 	}
 
 	// Get permissions from Casbin
-	rolePermissions := s.casbinClient.GetRolePermissions(adminRole.ID)
-
-	adminRole.Permissions = rolePermissions
+	s.loadPermissions(adminRole)
 
 	return adminRole, nil
 }
@@ -301,47 +480,146 @@ func (s *adminRoleService) GetAllPermissions(_ context.Context) map[string][]map
 func (s *adminRoleService) validatePermissions(perms []string) []string {
 	var invalidPerms []string
 	for _, perm := range perms {
-		if !permissions.IsValidPermission(perm) {
+		if !permissions.IsValidGrant(perm) {
 			invalidPerms = append(invalidPerms, perm)
 		}
 	}
 	return invalidPerms
 }
 
+// loadPermissions fills the Casbin-owned fields of role: its parent, and its
+// own and effective permissions and deny rules.
+func (s *adminRoleService) loadPermissions(role *models.AdminRole) {
+	role.ParentRoleID = s.casbinClient.GetRoleParent(role.ID)
+	role.SetPermissions(s.casbinClient.GetDirectRolePermissions(role.ID), s.casbinClient.GetRolePermissions(role.ID))
+	role.SetDenials(s.casbinClient.GetDirectRoleDenials(role.ID), s.casbinClient.GetRoleDenials(role.ID))
+}
+
+// roleGrants returns the permissions a role would hold with the given own
+// grants, deny rules and parent. An inactive parent passes no grants down
+// until it is reactivated, which is authorized on its own, but its deny rules
+// apply regardless.
+func (s *adminRoleService) roleGrants(allows, denials []string, parentID *uint) []string {
+	if parentID != nil {
+		if s.casbinClient.IsRoleActive(*parentID) {
+			allows = append(slices.Clone(allows), s.casbinClient.GetRolePermissions(*parentID)...)
+		}
+		denials = append(slices.Clone(denials), s.casbinClient.GetRoleDenials(*parentID)...)
+	}
+	return grantedPermissions(allows, denials)
+}
+
+// grantedPermissions expands the grants and drops every permission one of
+// the deny rules covers. A wildcard grant is also listed as itself, unless a
+// deny rule covers it whole: Casbin stores the pattern, which will match
+// permissions registered later too, so granting it takes holding the pattern
+// and not just each permission it covers today.
+func grantedPermissions(allows, denials []string) []string {
+	denied := make(map[string]struct{})
+	for _, denial := range denials {
+		for _, perm := range expandGrant(denial) {
+			denied[perm] = struct{}{}
+		}
+	}
+
+	var granted []string
+	for _, grant := range allows {
+		expanded := expandGrant(grant)
+		for _, perm := range expanded {
+			if _, skip := denied[perm]; skip {
+				continue
+			}
+			// Seen once, whether granted or not
+			denied[perm] = struct{}{}
+			granted = append(granted, perm)
+		}
+		if _, seen := denied[grant]; seen || slices.Equal(expanded, []string{grant}) {
+			continue
+		}
+		if !slices.ContainsFunc(denials, func(denial string) bool { return patternCovers(denial, grant) }) {
+			denied[grant] = struct{}{}
+			granted = append(granted, grant)
+		}
+	}
+	return granted
+}
+
+// patternCovers reports whether the rule pattern matches everything grant
+// does, now and for permissions registered later.
+func patternCovers(pattern, grant string) bool {
+	patternResource, patternAction, _ := strings.Cut(pattern, ":")
+	grantResource, grantAction, _ := strings.Cut(grant, ":")
+	return (patternResource == permissions.Wildcard || patternResource == grantResource) &&
+		(patternAction == permissions.Wildcard || patternAction == permissions.ActionManage || patternAction == grantAction)
+}
+
+// validateParent checks that a requested parent role exists.
+func (s *adminRoleService) validateParent(ctx context.Context, parentID uint) error {
+	if _, err := s.adminRoleRepo.FindByID(ctx, parentID); err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return cerrors.NewBadRequestError("parent role not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// parentError maps a failed SetRoleParent to the error returned to the client.
+func parentError(err error) error {
+	switch {
+	case errors.Is(err, casbin.ErrRoleCycle):
+		return cerrors.NewBadRequestError("parent role would make the role inherit from itself")
+	case errors.Is(err, casbin.ErrRoleHierarchyTooDeep):
+		return cerrors.NewBadRequestError(err.Error())
+	default:
+		return cerrors.NewInternalServerError("failed to set parent role", err)
+	}
+}
+
 // authorizeGrant rejects a role change unless the caller holds every
 // permission being newly granted to the target role. Only additions relative
 // to the role's current permissions require authority: keeping or removing
 // existing grants stays allowed, so a limited admin can still maintain a role
 // broader than their own. Without this check, anyone with admin_role:create /
 // admin_role:update could mint roles carrying permissions they were never
-// granted and escalate within the admin tier. Root bypasses the check
-// (mirroring CheckPermissionWithRoot); a missing caller identity fails closed.
-// current is resolved lazily so root callers skip the Casbin read.
-func (s *adminRoleService) authorizeGrant(ctx context.Context, requested []string, current func() []string) error {
-	if len(requested) == 0 {
+// granted and escalate within the admin tier. next and current list the
+// role's permissions after and before the change, with wildcard grants
+// expanded and everything a deny rule covers removed, plus each wildcard
+// pattern itself (see grantedPermissions): the caller must hold every
+// permission in next but not in current, so adding a grant and lifting a deny
+// rule need the same authority, while adding a deny rule needs none. Holding
+// every action of "user" one by one is therefore not enough to grant
+// "user:*", which would also hand out the actions added later.
+// Root bypasses the check (mirroring CheckPermissionWithRoot) unless it is
+// calling with an API key, whose scope still applies; a missing caller
+// identity fails closed. Both lists are resolved lazily so root session
+// callers skip the Casbin reads.
+func (s *adminRoleService) authorizeGrant(ctx context.Context, next, current func() []string) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err == nil && values.Role == models.UserRoleRoot.ToString() && values.APIKeyID == 0 {
 		return nil
 	}
 
-	role, ok := utils.GetRoleFromContext(ctx)
-	if !ok {
-		return cerrors.NewForbiddenError("cannot grant permissions without an authenticated caller")
-	}
-	if role == models.UserRoleRoot.ToString() {
+	requested := next()
+	if len(requested) == 0 {
 		return nil
 	}
-
-	currentSet := make(map[string]struct{})
-	for _, perm := range current() {
-		currentSet[perm] = struct{}{}
+	if err != nil {
+		return cerrors.NewForbiddenError("cannot grant permissions without an authenticated caller")
 	}
 
-	adminRoleID := utils.GetAdminRoleIDFromContext(ctx)
+	currentPerms := current()
+
 	var denied []string
 	for _, perm := range requested {
-		if _, held := currentSet[perm]; held {
+		// A wildcard the role already holds covers an equivalent one, such
+		// as "user:manage" in place of "user:*". A single permission must be
+		// listed itself, since current leaves out what a deny rule covers.
+		if slices.Contains(currentPerms, perm) || !permissions.IsValidPermission(perm) &&
+			slices.ContainsFunc(currentPerms, func(held string) bool { return patternCovers(held, perm) }) {
 			continue
 		}
-		allowed, err := s.casbinClient.CheckPermissionWithRoot(role, adminRoleID, perm)
+		allowed, err := casbin.CheckCaller(s.casbinClient, values, perm)
 		if err != nil {
 			return cerrors.NewInternalServerError("failed to verify caller permissions", err)
 		}
@@ -355,6 +633,15 @@ func (s *adminRoleService) authorizeGrant(ctx context.Context, requested []strin
 	return nil
 }
 
+// expandGrant returns the permissions a grant covers. A stored grant the
+// registry no longer recognises stands for itself.
+func expandGrant(grant string) []string {
+	if expanded := permissions.ExpandGrant(grant); len(expanded) > 0 {
+		return expanded
+	}
+	return []string{grant}
+}
+
 // createLog creates an audit log entry for admin role operations
 func (s *adminRoleService) createLog(ctx context.Context, action models.LogAction, entityID uint, entityName string) {
 	audit.RecordAction(ctx, s.logRepository, action, models.LogEntityTypeAdminRole, entityID, "admin role", entityName)
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	adminrolemocks "github.com/PhantomX7/athleton/internal/modules/admin_role/repository/mocks"
 	"github.com/PhantomX7/athleton/internal/modules/admin_role/service"
 	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	libcasbin "github.com/PhantomX7/athleton/libs/casbin"
 	casbinmocks "github.com/PhantomX7/athleton/libs/casbin/mocks"
 	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
@@ -65,12 +67,21 @@ func TestAdminRoleServiceIndexReturnsRolesWithPermissions(t *testing.T) {
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			if roleID == 1 {
 				return []string{permissions.LogRead.String()}
 			}
 			return []string{permissions.UserRead.String()}
 		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			if roleID == 1 {
+				return []string{permissions.LogRead.String()}
+			}
+			return []string{permissions.UserRead.String()}
+		},
 	}
 
 	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
@@ -106,9 +117,9 @@ func TestAdminRoleServiceCreateRejectsPermissionsCallerDoesNotHold(t *testing.T)
 
 	callerRoleID := uint(5)
 	casbinClient := &casbinmocks.ClientMock{
-		CheckPermissionWithRootFunc: func(userRole string, adminRoleID *uint, permission string) (bool, error) {
+		CheckPermissionWithRootFunc: func(userRole string, adminRoleIDs []uint, permission string) (bool, error) {
 			require.Equal(t, "admin", userRole)
-			require.Equal(t, callerRoleID, *adminRoleID)
+			require.Equal(t, []uint{callerRoleID}, adminRoleIDs)
 			// The caller only holds log:read.
 			return permission == permissions.LogRead.String(), nil
 		},
@@ -122,7 +133,7 @@ func TestAdminRoleServiceCreateRejectsPermissionsCallerDoesNotHold(t *testing.T)
 
 	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
-		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleID: &callerRoleID,
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
 	})
 
 	role, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{
@@ -135,6 +146,123 @@ func TestAdminRoleServiceCreateRejectsPermissionsCallerDoesNotHold(t *testing.T)
 	require.ErrorIs(t, err, cerrors.ErrForbidden)
 }
 
+// TestAdminRoleServiceCreateHoldsAPIKeyCallersToTheKeyScope — a key can only
+// hand on what is in its own scope, even when its owner (root included)
+// holds more.
+func TestAdminRoleServiceCreateHoldsAPIKeyCallersToTheKeyScope(t *testing.T) {
+	setupLogger(t)
+
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(string, []uint, string) (bool, error) { return true, nil },
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		CreateFunc: func(context.Context, *models.AdminRole) error {
+			t.Fatal("Create must not be called when the key lacks a requested permission")
+			return nil
+		},
+	}
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
+
+	for _, role := range []string{"admin", "root"} {
+		ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+			UserID: 11, UserName: "Alice", Role: role, AdminRoleIDs: []uint{5},
+			APIKeyID: 3, APIKeyPermissions: []string{permissions.AdminRoleCreate.String(), permissions.LogRead.String()},
+		})
+
+		created, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{
+			Name:        "Manager",
+			Permissions: []string{permissions.LogRead.String(), permissions.UserRead.String()},
+		})
+
+		require.Nil(t, created, role)
+		require.ErrorIs(t, err, cerrors.ErrForbidden, role)
+	}
+}
+
+// TestAdminRoleServiceCreateExpandsWildcardGrantsBeforeCheckingCaller — a
+// wildcard grant is only as strong as the permissions it covers, so granting
+// "*:read" requires holding every read permission.
+func TestAdminRoleServiceCreateExpandsWildcardGrantsBeforeCheckingCaller(t *testing.T) {
+	setupLogger(t)
+
+	var checked []string
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			checked = append(checked, permission)
+			// The caller holds every read except log:read.
+			return permission != permissions.LogRead.String(), nil
+		},
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		CreateFunc: func(context.Context, *models.AdminRole) error {
+			t.Fatal("Create must not be called when the caller lacks a covered permission")
+			return nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{5},
+	})
+
+	role, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{
+		Name:        "Auditor",
+		Permissions: []string{"*:read", permissions.UserRead.String()},
+	})
+
+	require.Nil(t, role)
+	require.ErrorIs(t, err, cerrors.ErrForbidden)
+	require.Equal(t, append(permissions.ExpandGrant("*:read"), "*:read"), checked,
+		"each covered permission is checked once, then the wildcard itself")
+}
+
+// TestAdminRoleServiceCreateRequiresHoldingTheWildcardItself — holding every
+// user permission one by one does not let an admin grant "user:*", which
+// would also cover the user permissions registered later.
+func TestAdminRoleServiceCreateRequiresHoldingTheWildcardItself(t *testing.T) {
+	setupLogger(t)
+
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			return permissions.IsValidPermission(permission), nil
+		},
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		CreateFunc: func(context.Context, *models.AdminRole) error {
+			t.Fatal("Create must not be called when the caller lacks the wildcard")
+			return nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{5},
+	})
+
+	role, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{Name: "Support", Permissions: []string{"user:*"}})
+
+	require.Nil(t, role)
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "cannot grant permissions you do not hold: user:*", appErr.Message)
+}
+
+func TestAdminRoleServiceCreateRejectsUngrantableWildcards(t *testing.T) {
+	setupLogger(t)
+
+	svc := service.NewAdminRoleService(&adminrolemocks.AdminRoleRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+
+	for _, grant := range []string{"*:*", "nope:*", "*:nope"} {
+		role, err := svc.Create(context.Background(), &dto.CreateAdminRoleRequest{
+			Name:        "Everything",
+			Permissions: []string{grant},
+		})
+
+		require.Nil(t, role)
+		require.ErrorIs(t, err, cerrors.ErrInvalidInput, grant)
+	}
+}
+
 func TestAdminRoleServiceCreateFailsClosedWithoutCallerIdentity(t *testing.T) {
 	setupLogger(t)
 
@@ -164,11 +292,18 @@ func TestAdminRoleServiceUpdateRejectsAddedPermissionsCallerDoesNotHold(t *testi
 	callerRoleID := uint(5)
 	casbinClient := &casbinmocks.ClientMock{
 		// Target role currently holds only log:read.
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			require.Equal(t, uint(8), roleID)
 			return []string{permissions.LogRead.String()}
 		},
-		CheckPermissionWithRootFunc: func(userRole string, adminRoleID *uint, permission string) (bool, error) {
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, uint(8), roleID)
+			return []string{permissions.LogRead.String()}
+		},
+		CheckPermissionWithRootFunc: func(string, []uint, string) (bool, error) {
 			// The caller holds nothing beyond role management.
 			return false, nil
 		},
@@ -182,7 +317,7 @@ func TestAdminRoleServiceUpdateRejectsAddedPermissionsCallerDoesNotHold(t *testi
 
 	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, passthroughTxManager())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
-		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleID: &callerRoleID,
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
 	})
 
 	role, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{
@@ -204,11 +339,18 @@ func TestAdminRoleServiceUpdateAllowsKeepingPermissionsCallerDoesNotHold(t *test
 	// can still maintain (rename etc.) a role broader than their own.
 	currentPerms := []string{permissions.LogRead.String(), permissions.UserRead.String()}
 	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			require.Equal(t, uint(8), roleID)
 			return currentPerms
 		},
-		CheckPermissionWithRootFunc: func(string, *uint, string) (bool, error) {
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, uint(8), roleID)
+			return currentPerms
+		},
+		CheckPermissionWithRootFunc: func(string, []uint, string) (bool, error) {
 			t.Fatal("no permission check should run when no permission is added")
 			return false, nil
 		},
@@ -235,7 +377,7 @@ func TestAdminRoleServiceUpdateAllowsKeepingPermissionsCallerDoesNotHold(t *test
 
 	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
-		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleID: &callerRoleID,
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
 	})
 
 	name := "Supervisor"
@@ -253,6 +395,48 @@ func TestAdminRoleServiceUpdateAllowsKeepingPermissionsCallerDoesNotHold(t *test
 	}
 }
 
+// TestAdminRoleServiceUpdateTreatsPermissionsCoveredByCurrentWildcardAsHeld —
+// narrowing "user:*" to some of the permissions it covers grants nothing new.
+func TestAdminRoleServiceUpdateTreatsPermissionsCoveredByCurrentWildcardAsHeld(t *testing.T) {
+	setupLogger(t)
+
+	requested := []string{permissions.UserRead.String(), "user:manage"}
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(uint) []string { return []string{"user:*"} },
+		GetDirectRoleDenialsFunc:     func(uint) []string { return nil },
+		GetRoleDenialsFunc:           func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(uint) []string { return []string{"user:*"} },
+		CheckPermissionWithRootFunc: func(string, []uint, string) (bool, error) {
+			t.Fatal("no permission check should run when the current wildcard covers every request")
+			return false, nil
+		},
+		SetRolePermissionsFunc: func(roleID uint, perms []string) error {
+			require.Equal(t, requested, perms)
+			return nil
+		},
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			return &models.AdminRole{ID: 8, Name: "Support"}, nil
+		},
+		UpdateFunc: func(context.Context, *models.AdminRole) error { return nil },
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+
+	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{5},
+	})
+
+	role, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{Permissions: requested})
+
+	require.NoError(t, err)
+	require.NotNil(t, role)
+}
+
 func TestAdminRoleServiceCreateAllowsRootToGrantAnyPermission(t *testing.T) {
 	setupLogger(t)
 
@@ -264,14 +448,20 @@ func TestAdminRoleServiceCreateAllowsRootToGrantAnyPermission(t *testing.T) {
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
-		CheckPermissionWithRootFunc: func(string, *uint, string) (bool, error) {
+		CheckPermissionWithRootFunc: func(string, []uint, string) (bool, error) {
 			t.Fatal("root must bypass the grant check entirely")
 			return false, nil
 		},
 		AddRolePermissionsFunc: func(uint, []string) error { return nil },
+		GetRoleParentFunc:      func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(uint) []string {
 			return []string{permissions.UserRead.String()}
 		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(uint) []string {
+			return []string{permissions.UserRead.String()}
+		},
 	}
 	logRepo := &logmocks.LogRepositoryMock{
 		CreateFunc: func(ctx context.Context, entry *models.Log) error {
@@ -318,10 +508,17 @@ func TestAdminRoleServiceCreateReturnsRoleAndAuditLog(t *testing.T) {
 			require.Equal(t, []string{permissions.LogRead.String()}, perms)
 			return nil
 		},
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			require.Equal(t, uint(8), roleID)
 			return []string{permissions.LogRead.String()}
 		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, uint(8), roleID)
+			return []string{permissions.LogRead.String()}
+		},
 	}
 	logRepo := &logmocks.LogRepositoryMock{
 		CreateFunc: func(ctx context.Context, entry *models.Log) error {
@@ -387,10 +584,17 @@ func TestAdminRoleServiceUpdateUpdatesRoleInTransactionAndSyncsCasbinAfterCommit
 			require.Equal(t, []string{permissions.LogRead.String()}, perms)
 			return nil
 		},
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			require.Equal(t, uint(8), roleID)
 			return []string{permissions.LogRead.String()}
 		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, uint(8), roleID)
+			return []string{permissions.LogRead.String()}
+		},
 	}
 	logRepo := &logmocks.LogRepositoryMock{
 		CreateFunc: func(ctx context.Context, entry *models.Log) error {
@@ -492,6 +696,7 @@ func TestAdminRoleServiceDeleteDeletesRoleThenCasbinPolicies(t *testing.T) {
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
+		GetChildRolesFunc: func(uint) []uint { return nil },
 		DeleteRoleFunc: func(roleID uint) error {
 			// Casbin cleanup must run only after the DB delete has committed.
 			require.True(t, dbDeleted, "casbin cleanup must run after the DB delete")
@@ -546,6 +751,7 @@ func TestAdminRoleServiceDeleteSucceedsWhenCasbinCleanupFails(t *testing.T) {
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
+		GetChildRolesFunc: func(uint) []uint { return nil },
 		DeleteRoleFunc: func(uint) error {
 			return errors.New("casbin unavailable")
 		},
@@ -584,7 +790,7 @@ func TestAdminRoleServiceDeleteRejectsAssignedUsers(t *testing.T) {
 		},
 	}
 
-	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager())
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{GetChildRolesFunc: func(uint) []uint { return nil }}, passthroughTxManager())
 
 	err := svc.Delete(context.Background(), 3)
 
@@ -603,10 +809,17 @@ func TestAdminRoleServiceFindByIDReturnsRoleWithPermissions(t *testing.T) {
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc: func(uint) *uint { return nil },
 		GetRolePermissionsFunc: func(roleID uint) []string {
 			require.Equal(t, uint(4), roleID)
 			return []string{permissions.UserRead.String()}
 		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, uint(4), roleID)
+			return []string{permissions.UserRead.String()}
+		},
 	}
 
 	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
@@ -652,3 +865,449 @@ func TestAdminRoleServiceIndexReturnsRepositoryError(t *testing.T) {
 	require.Equal(t, response.Meta{}, meta)
 	require.ErrorIs(t, err, expectedErr)
 }
+
+func TestAdminRoleServiceDeactivateUpdatesRoleThenCasbinAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	logCh := make(chan *models.Log, 1)
+	dbUpdated := false
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(ctx context.Context, id uint) (*models.AdminRole, error) {
+			require.Equal(t, uint(3), id)
+			return &models.AdminRole{ID: 3, Name: "Manager", IsActive: true}, nil
+		},
+		UpdateFunc: func(ctx context.Context, role *models.AdminRole) error {
+			require.False(t, role.IsActive)
+			dbUpdated = true
+			return nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		SetRoleActiveFunc: func(roleID uint, active bool) {
+			// Casbin must only follow a committed DB write.
+			require.True(t, dbUpdated, "casbin status must be synced after the DB update")
+			require.Equal(t, uint(3), roleID)
+			require.False(t, active)
+		},
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(uint) []string { return []string{permissions.LogRead.String()} },
+		GetDirectRoleDenialsFunc:     func(uint) []string { return nil },
+		GetRoleDenialsFunc:           func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(uint) []string { return []string{permissions.LogRead.String()} },
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(ctx context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
+	callerRoleID := uint(5)
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
+	})
+
+	role, err := svc.Deactivate(ctx, 3)
+
+	require.NoError(t, err)
+	require.False(t, role.IsActive)
+	require.Equal(t, []string{permissions.LogRead.String()}, role.Permissions, "deactivation keeps the grants")
+	require.Len(t, casbinClient.SetRoleActiveCalls(), 1)
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionDeactivate, entry.Action)
+		require.Equal(t, "Alice deactivated admin role: Manager", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit log")
+	}
+}
+
+func TestAdminRoleServiceDeactivateRejectsCallersOwnRole(t *testing.T) {
+	setupLogger(t)
+
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			t.Fatal("the role must not be loaded when the caller holds it")
+			return nil, nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager())
+	callerRoleID := uint(3)
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
+	})
+
+	role, err := svc.Deactivate(ctx, 3)
+
+	require.Nil(t, role)
+	require.ErrorIs(t, err, cerrors.ErrForbidden)
+}
+
+func TestAdminRoleServiceActivateRejectsRoleWithPermissionsCallerDoesNotHold(t *testing.T) {
+	setupLogger(t)
+
+	callerRoleID := uint(5)
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc: func(uint) *uint { return nil },
+		GetRolePermissionsFunc: func(uint) []string {
+			return []string{permissions.LogRead.String(), permissions.UserDelete.String()}
+		},
+		GetDirectRoleDenialsFunc: func(uint) []string { return nil },
+		GetRoleDenialsFunc:       func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(uint) []string {
+			return []string{permissions.LogRead.String(), permissions.UserDelete.String()}
+		},
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			return permission == permissions.LogRead.String(), nil
+		},
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			t.Fatal("the role must not be loaded when the caller cannot grant it")
+			return nil, nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
+	})
+
+	role, err := svc.Activate(ctx, 3)
+
+	require.Nil(t, role)
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "cannot grant permissions you do not hold: "+permissions.UserDelete.String(), appErr.Message)
+}
+
+func TestAdminRoleServiceActivateIsNoOpForActiveRole(t *testing.T) {
+	setupLogger(t)
+
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			return &models.AdminRole{ID: 3, Name: "Manager", IsActive: true}, nil
+		},
+		UpdateFunc: func(context.Context, *models.AdminRole) error {
+			t.Fatal("an unchanged status must not be written")
+			return nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(uint) []string { return nil },
+		GetDirectRoleDenialsFunc:     func(uint) []string { return nil },
+		GetRoleDenialsFunc:           func(uint) []string { return nil },
+		GetDirectRolePermissionsFunc: func(uint) []string { return nil },
+		SetRoleActiveFunc:            func(uint, bool) {},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error {
+			t.Fatal("an unchanged status must not be audited")
+			return nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: "root"})
+
+	role, err := svc.Activate(ctx, 3)
+
+	require.NoError(t, err)
+	require.True(t, role.IsActive)
+}
+
+func TestAdminRoleServiceLoadRoleStatusMarksInactiveRoles(t *testing.T) {
+	setupLogger(t)
+
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindInactiveIDsFunc: func(context.Context) ([]uint, error) {
+			return []uint{4, 7}, nil
+		},
+	}
+	inactive := map[uint]bool{}
+	casbinClient := &casbinmocks.ClientMock{
+		SetRoleActiveFunc: func(roleID uint, active bool) {
+			require.False(t, active)
+			inactive[roleID] = true
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
+
+	require.NoError(t, svc.LoadRoleStatus(context.Background()))
+	require.Equal(t, map[uint]bool{4: true, 7: true}, inactive)
+}
+
+func TestAdminRoleServiceCreateRejectsInheritedPermissionsCallerDoesNotHold(t *testing.T) {
+	setupLogger(t)
+
+	callerRoleID := uint(5)
+	parentID := uint(2)
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.AdminRole, error) {
+			require.Equal(t, parentID, id)
+			return &models.AdminRole{ID: parentID, Name: "Support"}, nil
+		},
+		CreateFunc: func(context.Context, *models.AdminRole) error {
+			t.Fatal("Create must not be called when the parent grants more than the caller holds")
+			return nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		IsRoleActiveFunc:   func(uint) bool { return true },
+		GetRoleDenialsFunc: func(uint) []string { return nil },
+		GetRolePermissionsFunc: func(roleID uint) []string {
+			require.Equal(t, parentID, roleID)
+			return []string{permissions.UserDelete.String()}
+		},
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			return permission == permissions.LogRead.String(), nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{callerRoleID},
+	})
+
+	role, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{
+		Name:         "Senior Support",
+		ParentRoleID: &parentID,
+		Permissions:  []string{permissions.LogRead.String()},
+	})
+
+	require.Nil(t, role)
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "cannot grant permissions you do not hold: "+permissions.UserDelete.String(), appErr.Message)
+}
+
+func TestAdminRoleServiceCreateRequiresPermissionsOrParent(t *testing.T) {
+	setupLogger(t)
+
+	svc := service.NewAdminRoleService(&adminrolemocks.AdminRoleRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+
+	role, err := svc.Create(context.Background(), &dto.CreateAdminRoleRequest{Name: "Empty", Permissions: []string{}})
+
+	require.Nil(t, role)
+	require.ErrorIs(t, err, cerrors.ErrInvalidInput)
+}
+
+func TestAdminRoleServiceUpdateRejectsParentCycleBeforeWriting(t *testing.T) {
+	setupLogger(t)
+
+	parentID := uint(9)
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.AdminRole, error) {
+			return &models.AdminRole{ID: parentID, Name: "Lead"}, nil
+		},
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			t.Fatal("the role row must not be touched when the parent is rejected")
+			return nil, nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc:      func(uint) *uint { return nil },
+		GetRoleDenialsFunc:     func(uint) []string { return nil },
+		GetRolePermissionsFunc: func(uint) []string { return nil },
+		SetRoleParentFunc: func(roleID uint, gotParentID *uint) error {
+			require.Equal(t, uint(8), roleID)
+			require.Equal(t, parentID, *gotParentID)
+			return libcasbin.ErrRoleCycle
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: "root"})
+
+	role, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{ParentRoleID: &parentID})
+
+	require.Nil(t, role)
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "parent role would make the role inherit from itself", appErr.Message)
+}
+
+func TestAdminRoleServiceUpdateRestoresParentWhenTransactionFails(t *testing.T) {
+	setupLogger(t)
+
+	oldParentID, newParentID := uint(3), uint(4)
+	dbErr := errors.New("db down")
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.AdminRole, error) {
+			return &models.AdminRole{ID: newParentID}, nil
+		},
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			return nil, dbErr
+		},
+	}
+	var parents []*uint
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc:      func(uint) *uint { return &oldParentID },
+		GetRoleDenialsFunc:     func(uint) []string { return nil },
+		GetRolePermissionsFunc: func(uint) []string { return nil },
+		SetRoleParentFunc: func(_ uint, parentID *uint) error {
+			parents = append(parents, parentID)
+			return nil
+		},
+	}
+
+	svc := service.NewAdminRoleService(repo, &logmocks.LogRepositoryMock{}, casbinClient, passthroughTxManager())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: "root"})
+
+	_, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{ParentRoleID: &newParentID})
+
+	require.ErrorIs(t, err, dbErr)
+	require.Equal(t, []*uint{&newParentID, &oldParentID}, parents)
+}
+
+func TestAdminRoleServiceDeleteRejectsRoleWithChildren(t *testing.T) {
+	setupLogger(t)
+
+	casbinClient := &casbinmocks.ClientMock{
+		GetChildRolesFunc: func(roleID uint) []uint {
+			require.Equal(t, uint(3), roleID)
+			return []uint{4}
+		},
+	}
+
+	svc := service.NewAdminRoleService(&adminrolemocks.AdminRoleRepositoryMock{}, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{})
+
+	err := svc.Delete(context.Background(), 3)
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "cannot delete role that other roles inherit from", appErr.Message)
+}
+
+// TestAdminRoleServiceCreateDeniesWhatTheCallerLacks — deny rules narrow what
+// a new role holds, so an admin holding "user:*" minus some actions may grant
+// "user:*" while denying the user permissions they do not hold themselves.
+func TestAdminRoleServiceCreateDeniesWhatTheCallerLacks(t *testing.T) {
+	setupLogger(t)
+
+	held := []string{permissions.UserRead.String(), permissions.UserUpdate.String(), "user:*"}
+	denials := []string{permissions.UserAssignRole.String(), permissions.UserDelete.String(), permissions.UserImpersonate.String()}
+	var checked []string
+	var storedDenials []string
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			checked = append(checked, permission)
+			return slices.Contains(held, permission), nil
+		},
+		AddRolePermissionsFunc: func(uint, []string) error { return nil },
+		SetRoleDenialsFunc: func(roleID uint, perms []string) error {
+			require.Equal(t, uint(8), roleID)
+			storedDenials = perms
+			return nil
+		},
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(uint) []string { return []string{"user:*"} },
+		GetDirectRolePermissionsFunc: func(uint) []string { return []string{"user:*"} },
+		GetRoleDenialsFunc:           func(uint) []string { return storedDenials },
+		GetDirectRoleDenialsFunc:     func(uint) []string { return storedDenials },
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		CreateFunc: func(_ context.Context, role *models.AdminRole) error {
+			role.ID = 8
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+
+	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, &txmocks.TransactionManagerMock{})
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{5},
+	})
+
+	role, err := svc.Create(ctx, &dto.CreateAdminRoleRequest{
+		Name:        "Support",
+		Permissions: []string{"user:*"},
+		Denials:     denials,
+	})
+
+	require.NoError(t, err)
+	require.ElementsMatch(t, held, checked, "denied permissions need no authority")
+	require.Equal(t, denials, storedDenials)
+	require.Equal(t, denials, role.Denials)
+	require.Len(t, role.EffectiveDenials, len(denials))
+}
+
+func TestAdminRoleServiceCreateRejectsInvalidDenials(t *testing.T) {
+	setupLogger(t)
+
+	svc := service.NewAdminRoleService(&adminrolemocks.AdminRoleRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+
+	role, err := svc.Create(context.Background(), &dto.CreateAdminRoleRequest{
+		Name:        "Support",
+		Permissions: []string{permissions.UserRead.String()},
+		Denials:     []string{"not:valid"},
+	})
+
+	require.Nil(t, role)
+	require.ErrorIs(t, err, cerrors.ErrInvalidInput)
+}
+
+// TestAdminRoleServiceUpdateDenialChangesAreGuarded — lifting a deny rule
+// hands its permissions out, so it needs the same authority as granting them;
+// adding one only takes permissions away and needs none.
+func TestAdminRoleServiceUpdateDenialChangesAreGuarded(t *testing.T) {
+	setupLogger(t)
+
+	newClient := func(denials *[]string) *casbinmocks.ClientMock {
+		return &casbinmocks.ClientMock{
+			CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+				// The caller holds every user permission except delete.
+				return permission != permissions.UserDelete.String(), nil
+			},
+			GetRoleParentFunc:            func(uint) *uint { return nil },
+			GetRolePermissionsFunc:       func(uint) []string { return []string{"user:*"} },
+			GetDirectRolePermissionsFunc: func(uint) []string { return []string{"user:*"} },
+			GetRoleDenialsFunc:           func(uint) []string { return *denials },
+			GetDirectRoleDenialsFunc:     func(uint) []string { return *denials },
+			SetRoleDenialsFunc: func(_ uint, perms []string) error {
+				*denials = perms
+				return nil
+			},
+		}
+	}
+	repo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.AdminRole, error) {
+			return &models.AdminRole{ID: 8, Name: "Support"}, nil
+		},
+		UpdateFunc: func(context.Context, *models.AdminRole) error { return nil },
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 11, UserName: "Alice", Role: "admin", AdminRoleIDs: []uint{5},
+	})
+
+	// Lifting the user:delete deny would grant user:delete.
+	denials := []string{permissions.UserDelete.String()}
+	casbinClient := newClient(&denials)
+	svc := service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
+	_, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{Denials: []string{}})
+	require.ErrorIs(t, err, cerrors.ErrForbidden)
+	require.Empty(t, casbinClient.SetRoleDenialsCalls())
+
+	// Denying more of user:* is always allowed.
+	denials = []string{permissions.UserDelete.String()}
+	casbinClient = newClient(&denials)
+	casbinClient.CheckPermissionWithRootFunc = func(string, []uint, string) (bool, error) {
+		t.Fatal("adding a deny rule grants nothing, so no permission check should run")
+		return false, nil
+	}
+	svc = service.NewAdminRoleService(repo, logRepo, casbinClient, passthroughTxManager())
+	role, err := svc.Update(ctx, 8, &dto.UpdateAdminRoleRequest{
+		Denials: []string{permissions.UserDelete.String(), permissions.UserImpersonate.String()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{permissions.UserDelete.String(), permissions.UserImpersonate.String()}, role.Denials)
+}
@@ -0,0 +1,111 @@
+// Package controller exposes HTTP handlers for API-key management.
+package controller
+
+import (
+	"net/http"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/service"
+	"github.com/PhantomX7/athleton/pkg/ginx"
+	"github.com/PhantomX7/athleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyController defines the interface for API-key controller operations
+type APIKeyController interface {
+	Create(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+}
+
+type apiKeyController struct {
+	apiKeyService service.APIKeyService
+}
+
+// NewAPIKeyController constructs an APIKeyController.
+func NewAPIKeyController(apiKeyService service.APIKeyService) APIKeyController {
+	return &apiKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create issues a new API key for the authenticated user.
+//
+//	@Summary		Create API key
+//	@Description	Issue a personal access token scoped to a subset of the caller's permissions. The key is only shown in this response; send it as "Authorization: ApiKey <key>".
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.APIKeyCreateRequest	true	"Create API Key Request"
+//	@Success		201		{object}	response.Response{data=dto.APIKeyCreateResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		403		{object}	response.Response
+//	@Router			/auth/api-keys [post]
+func (c *apiKeyController) Create(ctx *gin.Context) {
+	var req dto.APIKeyCreateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.apiKeyService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.BuildResponseSuccess("api key created", res))
+}
+
+// List returns the authenticated user's API keys.
+//
+//	@Summary		List API keys
+//	@Description	List the authenticated user's API keys that have not been revoked, newest first. Secrets are never returned.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=[]dto.APIKeyResponse}
+//	@Failure		401	{object}	response.Response
+//	@Router			/auth/api-keys [get]
+func (c *apiKeyController) List(ctx *gin.Context) {
+	res, err := c.apiKeyService.List(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("get api keys success", res))
+}
+
+// Revoke disables one of the authenticated user's API keys.
+//
+//	@Summary		Revoke API key
+//	@Description	Permanently disable one of the authenticated user's API keys
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"API Key ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Router			/auth/api-keys/{id} [delete]
+func (c *apiKeyController) Revoke(ctx *gin.Context) {
+	id, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	err := c.apiKeyService.Revoke(ctx.Request.Context(), id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("api key revoked", nil))
+}
@@ -0,0 +1,87 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/controller"
+	apikeyservicemocks "github.com/PhantomX7/athleton/internal/modules/api_key/service/mocks"
+)
+
+func TestAPIKeyControllerCreateReturnsKeyOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &apikeyservicemocks.APIKeyServiceMock{
+		CreateFunc: func(_ context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error) {
+			require.Equal(t, "exporter", req.Name)
+			require.Equal(t, []string{"user:read"}, req.Permissions)
+			return &dto.APIKeyCreateResponse{
+				APIKeyResponse: dto.APIKeyResponse{ID: 3, Name: req.Name, Prefix: "ath_ABCDEFGH"},
+				Key:            "ath_ABCDEFGHsecret",
+			}, nil
+		},
+	}
+
+	ctrl := controller.NewAPIKeyController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/api-keys",
+		bytes.NewBufferString(`{"name":"exporter","permissions":["user:read"]}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Create(ctx)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var body struct {
+		Data dto.APIKeyCreateResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "ath_ABCDEFGHsecret", body.Data.Key)
+	require.Equal(t, uint(3), body.Data.ID)
+}
+
+func TestAPIKeyControllerCreateRejectsEmptyScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := controller.NewAPIKeyController(&apikeyservicemocks.APIKeyServiceMock{})
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/api-keys",
+		bytes.NewBufferString(`{"name":"exporter","permissions":[]}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Create(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+}
+
+func TestAPIKeyControllerRevokeParsesID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &apikeyservicemocks.APIKeyServiceMock{
+		RevokeFunc: func(_ context.Context, id uint) error {
+			require.Equal(t, uint(12), id)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAPIKeyController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/api-keys/12", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "12"}}
+
+	ctrl.Revoke(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, svc.RevokeCalls(), 1)
+}
@@ -0,0 +1,25 @@
+// Package api_key wires the API-key (personal access token) module.
+package api_key
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/api_key/controller"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/repository"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/service"
+	"github.com/PhantomX7/athleton/internal/routes"
+
+	"go.uber.org/fx"
+)
+
+// Module wires the API-key module dependencies into the Fx container.
+var Module = fx.Options(
+	fx.Provide(
+		controller.NewAPIKeyController,
+		service.NewAPIKeyService,
+		repository.NewAPIKeyRepository,
+		fx.Annotate(
+			NewRoutes,
+			fx.As(new(routes.Registrar)),
+			fx.ResultTags(`group:"routes"`),
+		),
+	),
+)
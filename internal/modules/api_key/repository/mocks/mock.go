@@ -0,0 +1,548 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeyrepository "github.com/PhantomX7/athleton/internal/modules/api_key/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that APIKeyRepositoryMock does implement apikeyrepository.APIKeyRepository.
+// If this is not the case, regenerate this file with moq.
+var _ apikeyrepository.APIKeyRepository = &APIKeyRepositoryMock{}
+
+// APIKeyRepositoryMock is a mock implementation of apikeyrepository.APIKeyRepository.
+//
+//	func TestSomethingThatUsesAPIKeyRepository(t *testing.T) {
+//
+//		// make and configure a mocked apikeyrepository.APIKeyRepository
+//		mockedAPIKeyRepository := &APIKeyRepositoryMock{
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.APIKey) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.APIKey) error {
+//				panic("mock out the Delete method")
+//			},
+//			FindActiveByKeyFunc: func(ctx context.Context, key string) (*models.APIKey, error) {
+//				panic("mock out the FindActiveByKey method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.APIKey, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.APIKey, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			FindUnrevokedByUserIDFunc: func(ctx context.Context, userID uint) ([]models.APIKey, error) {
+//				panic("mock out the FindUnrevokedByUserID method")
+//			},
+//			RevokeByIDForUserFunc: func(ctx context.Context, id uint, userID uint) (bool, error) {
+//				panic("mock out the RevokeByIDForUser method")
+//			},
+//			TouchLastUsedFunc: func(ctx context.Context, id uint) error {
+//				panic("mock out the TouchLastUsed method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.APIKey) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedAPIKeyRepository in code that requires apikeyrepository.APIKeyRepository
+//		// and then make assertions.
+//
+//	}
+type APIKeyRepositoryMock struct {
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.APIKey) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.APIKey) error
+
+	// FindActiveByKeyFunc mocks the FindActiveByKey method.
+	FindActiveByKeyFunc func(ctx context.Context, key string) (*models.APIKey, error)
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.APIKey, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.APIKey, error)
+
+	// FindUnrevokedByUserIDFunc mocks the FindUnrevokedByUserID method.
+	FindUnrevokedByUserIDFunc func(ctx context.Context, userID uint) ([]models.APIKey, error)
+
+	// RevokeByIDForUserFunc mocks the RevokeByIDForUser method.
+	RevokeByIDForUserFunc func(ctx context.Context, id uint, userID uint) (bool, error)
+
+	// TouchLastUsedFunc mocks the TouchLastUsed method.
+	TouchLastUsedFunc func(ctx context.Context, id uint) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.APIKey) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.APIKey
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.APIKey
+		}
+		// FindActiveByKey holds details about calls to the FindActiveByKey method.
+		FindActiveByKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// FindUnrevokedByUserID holds details about calls to the FindUnrevokedByUserID method.
+		FindUnrevokedByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// RevokeByIDForUser holds details about calls to the RevokeByIDForUser method.
+		RevokeByIDForUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// TouchLastUsed holds details about calls to the TouchLastUsed method.
+		TouchLastUsed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.APIKey
+		}
+	}
+	lockCount                 sync.RWMutex
+	lockCreate                sync.RWMutex
+	lockDelete                sync.RWMutex
+	lockFindActiveByKey       sync.RWMutex
+	lockFindAll               sync.RWMutex
+	lockFindByID              sync.RWMutex
+	lockFindUnrevokedByUserID sync.RWMutex
+	lockRevokeByIDForUser     sync.RWMutex
+	lockTouchLastUsed         sync.RWMutex
+	lockUpdate                sync.RWMutex
+}
+
+// Count calls CountFunc.
+func (mock *APIKeyRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("APIKeyRepositoryMock.CountFunc: method is nil but APIKeyRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.CountCalls())
+func (mock *APIKeyRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *APIKeyRepositoryMock) Create(ctx context.Context, entity *models.APIKey) error {
+	if mock.CreateFunc == nil {
+		panic("APIKeyRepositoryMock.CreateFunc: method is nil but APIKeyRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.CreateCalls())
+func (mock *APIKeyRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.APIKey
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *APIKeyRepositoryMock) Delete(ctx context.Context, entity *models.APIKey) error {
+	if mock.DeleteFunc == nil {
+		panic("APIKeyRepositoryMock.DeleteFunc: method is nil but APIKeyRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.DeleteCalls())
+func (mock *APIKeyRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.APIKey
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// FindActiveByKey calls FindActiveByKeyFunc.
+func (mock *APIKeyRepositoryMock) FindActiveByKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if mock.FindActiveByKeyFunc == nil {
+		panic("APIKeyRepositoryMock.FindActiveByKeyFunc: method is nil but APIKeyRepository.FindActiveByKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockFindActiveByKey.Lock()
+	mock.calls.FindActiveByKey = append(mock.calls.FindActiveByKey, callInfo)
+	mock.lockFindActiveByKey.Unlock()
+	return mock.FindActiveByKeyFunc(ctx, key)
+}
+
+// FindActiveByKeyCalls gets all the calls that were made to FindActiveByKey.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.FindActiveByKeyCalls())
+func (mock *APIKeyRepositoryMock) FindActiveByKeyCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockFindActiveByKey.RLock()
+	calls = mock.calls.FindActiveByKey
+	mock.lockFindActiveByKey.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *APIKeyRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.APIKey, error) {
+	if mock.FindAllFunc == nil {
+		panic("APIKeyRepositoryMock.FindAllFunc: method is nil but APIKeyRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.FindAllCalls())
+func (mock *APIKeyRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *APIKeyRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.APIKey, error) {
+	if mock.FindByIDFunc == nil {
+		panic("APIKeyRepositoryMock.FindByIDFunc: method is nil but APIKeyRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.FindByIDCalls())
+func (mock *APIKeyRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// FindUnrevokedByUserID calls FindUnrevokedByUserIDFunc.
+func (mock *APIKeyRepositoryMock) FindUnrevokedByUserID(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	if mock.FindUnrevokedByUserIDFunc == nil {
+		panic("APIKeyRepositoryMock.FindUnrevokedByUserIDFunc: method is nil but APIKeyRepository.FindUnrevokedByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindUnrevokedByUserID.Lock()
+	mock.calls.FindUnrevokedByUserID = append(mock.calls.FindUnrevokedByUserID, callInfo)
+	mock.lockFindUnrevokedByUserID.Unlock()
+	return mock.FindUnrevokedByUserIDFunc(ctx, userID)
+}
+
+// FindUnrevokedByUserIDCalls gets all the calls that were made to FindUnrevokedByUserID.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.FindUnrevokedByUserIDCalls())
+func (mock *APIKeyRepositoryMock) FindUnrevokedByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindUnrevokedByUserID.RLock()
+	calls = mock.calls.FindUnrevokedByUserID
+	mock.lockFindUnrevokedByUserID.RUnlock()
+	return calls
+}
+
+// RevokeByIDForUser calls RevokeByIDForUserFunc.
+func (mock *APIKeyRepositoryMock) RevokeByIDForUser(ctx context.Context, id uint, userID uint) (bool, error) {
+	if mock.RevokeByIDForUserFunc == nil {
+		panic("APIKeyRepositoryMock.RevokeByIDForUserFunc: method is nil but APIKeyRepository.RevokeByIDForUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     uint
+		UserID uint
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		UserID: userID,
+	}
+	mock.lockRevokeByIDForUser.Lock()
+	mock.calls.RevokeByIDForUser = append(mock.calls.RevokeByIDForUser, callInfo)
+	mock.lockRevokeByIDForUser.Unlock()
+	return mock.RevokeByIDForUserFunc(ctx, id, userID)
+}
+
+// RevokeByIDForUserCalls gets all the calls that were made to RevokeByIDForUser.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.RevokeByIDForUserCalls())
+func (mock *APIKeyRepositoryMock) RevokeByIDForUserCalls() []struct {
+	Ctx    context.Context
+	ID     uint
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     uint
+		UserID uint
+	}
+	mock.lockRevokeByIDForUser.RLock()
+	calls = mock.calls.RevokeByIDForUser
+	mock.lockRevokeByIDForUser.RUnlock()
+	return calls
+}
+
+// TouchLastUsed calls TouchLastUsedFunc.
+func (mock *APIKeyRepositoryMock) TouchLastUsed(ctx context.Context, id uint) error {
+	if mock.TouchLastUsedFunc == nil {
+		panic("APIKeyRepositoryMock.TouchLastUsedFunc: method is nil but APIKeyRepository.TouchLastUsed was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uint
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockTouchLastUsed.Lock()
+	mock.calls.TouchLastUsed = append(mock.calls.TouchLastUsed, callInfo)
+	mock.lockTouchLastUsed.Unlock()
+	return mock.TouchLastUsedFunc(ctx, id)
+}
+
+// TouchLastUsedCalls gets all the calls that were made to TouchLastUsed.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.TouchLastUsedCalls())
+func (mock *APIKeyRepositoryMock) TouchLastUsedCalls() []struct {
+	Ctx context.Context
+	ID  uint
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uint
+	}
+	mock.lockTouchLastUsed.RLock()
+	calls = mock.calls.TouchLastUsed
+	mock.lockTouchLastUsed.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *APIKeyRepositoryMock) Update(ctx context.Context, entity *models.APIKey) error {
+	if mock.UpdateFunc == nil {
+		panic("APIKeyRepositoryMock.UpdateFunc: method is nil but APIKeyRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.UpdateCalls())
+func (mock *APIKeyRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.APIKey
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.APIKey
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
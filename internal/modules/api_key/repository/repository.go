@@ -0,0 +1,131 @@
+// Package repository provides API-key persistence primitives.
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HashAPIKey returns the at-rest form of an API-key secret: a SHA-256 hex
+// digest, like refresh and user tokens. Keys are generated random values,
+// never user-chosen, so a fast unsalted hash is enough and keeps the column
+// indexable for the per-request lookup.
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . APIKeyRepository
+
+// APIKeyRepository defines the interface for API-key operations.
+type APIKeyRepository interface {
+	repository.Repository[models.APIKey]
+	FindActiveByKey(ctx context.Context, key string) (*models.APIKey, error)
+	FindUnrevokedByUserID(ctx context.Context, userID uint) ([]models.APIKey, error)
+	RevokeByIDForUser(ctx context.Context, id, userID uint) (bool, error)
+	TouchLastUsed(ctx context.Context, id uint) error
+}
+
+type apiKeyRepository struct {
+	repository.BaseRepository[models.APIKey]
+}
+
+// NewAPIKeyRepository constructs an APIKeyRepository.
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{
+		BaseRepository: repository.NewBaseRepository[models.APIKey](db),
+	}
+}
+
+// Create overrides BaseRepository.Create to hash the secret before persisting.
+// Callers set KeyHash to the plaintext key they are about to hand out; the
+// stored row only ever contains its hash.
+func (r *apiKeyRepository) Create(ctx context.Context, entity *models.APIKey) error {
+	if entity != nil && entity.KeyHash != "" {
+		entity.KeyHash = HashAPIKey(entity.KeyHash)
+	}
+	return r.BaseRepository.Create(ctx, entity)
+}
+
+// activeKeyPredicates matches keys that are neither revoked nor past their
+// expiry. A key without an expiry never lapses on its own.
+func activeKeyPredicates(now time.Time) []clause.Expression {
+	return []clause.Expression{
+		generated.APIKey.RevokedAt.IsNull(),
+		clause.Or(generated.APIKey.ExpiresAt.IsNull(), generated.APIKey.ExpiresAt.Gt(now)),
+	}
+}
+
+// FindActiveByKey returns the active key matching the plaintext secret. An
+// unknown, revoked or expired key is the same NotFound.
+func (r *apiKeyRepository) FindActiveByKey(ctx context.Context, key string) (*models.APIKey, error) {
+	q := gorm.G[models.APIKey](r.GetDB(ctx)).
+		Where(generated.APIKey.KeyHash.Eq(HashAPIKey(key)))
+	for _, p := range activeKeyPredicates(time.Now()) {
+		q = q.Where(p)
+	}
+
+	apiKey, err := q.First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cerrors.NewNotFoundError("invalid api key")
+		}
+		return nil, cerrors.NewInternalServerError("failed to find api key", err)
+	}
+	return &apiKey, nil
+}
+
+// FindUnrevokedByUserID returns the user's keys that have not been revoked,
+// newest first. Expired keys are included so their owner can still see (and
+// tidy up) them.
+func (r *apiKeyRepository) FindUnrevokedByUserID(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	keys, err := gorm.G[models.APIKey](r.GetDB(ctx)).
+		Where(generated.APIKey.UserID.Eq(userID)).
+		Where(generated.APIKey.RevokedAt.IsNull()).
+		Order(generated.APIKey.ID.Desc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find api keys for user id %v", userID), err)
+	}
+	return keys, nil
+}
+
+// RevokeByIDForUser revokes key id only if it belongs to userID and is not
+// already revoked, reporting whether a row was revoked. Scoping the UPDATE by
+// owner means another user's key is indistinguishable from a missing one.
+func (r *apiKeyRepository) RevokeByIDForUser(ctx context.Context, id, userID uint) (bool, error) {
+	rows, err := gorm.G[models.APIKey](r.GetDB(ctx)).
+		Where(generated.APIKey.ID.Eq(id)).
+		Where(generated.APIKey.UserID.Eq(userID)).
+		Where(generated.APIKey.RevokedAt.IsNull()).
+		Set(generated.APIKey.RevokedAt.Set(time.Now())).
+		Update(ctx)
+	if err != nil {
+		return false, cerrors.NewInternalServerError(fmt.Sprintf("failed to revoke api key by id %v", id), err)
+	}
+	return rows > 0, nil
+}
+
+// TouchLastUsed stamps last_used_at on key id with the current time.
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint) error {
+	_, err := gorm.G[models.APIKey](r.GetDB(ctx)).
+		Where(generated.APIKey.ID.Eq(id)).
+		Set(generated.APIKey.LastUsedAt.Set(time.Now())).
+		Update(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to update last use of api key id %v", id), err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeyrepository "github.com/PhantomX7/athleton/internal/modules/api_key/repository"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.APIKey{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func seedKey(t *testing.T, repo apikeyrepository.APIKeyRepository, userID uint, secret string, expiresAt *time.Time) *models.APIKey {
+	t.Helper()
+
+	key := &models.APIKey{
+		UserID:      userID,
+		Name:        secret,
+		Prefix:      secret[:4],
+		KeyHash:     secret,
+		Permissions: []string{"user:read"},
+		ExpiresAt:   expiresAt,
+	}
+	require.NoError(t, repo.Create(context.Background(), key))
+	return key
+}
+
+func TestAPIKeyRepositoryCreateStoresHashAndPermissions(t *testing.T) {
+	db := setupDB(t)
+	repo := apikeyrepository.NewAPIKeyRepository(db)
+	user := seedUser(t, db, "alma")
+
+	seedKey(t, repo, user.ID, "ath_plaintext", nil)
+
+	var row models.APIKey
+	require.NoError(t, db.First(&row).Error)
+	require.Equal(t, apikeyrepository.HashAPIKey("ath_plaintext"), row.KeyHash)
+	require.Equal(t, []string{"user:read"}, row.Permissions)
+}
+
+func TestAPIKeyRepositoryFindActiveByKeySkipsRevokedAndExpired(t *testing.T) {
+	db := setupDB(t)
+	repo := apikeyrepository.NewAPIKeyRepository(db)
+	user := seedUser(t, db, "alma")
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	live := seedKey(t, repo, user.ID, "ath_live", &future)
+	seedKey(t, repo, user.ID, "ath_expired", &past)
+	revoked := seedKey(t, repo, user.ID, "ath_revoked", nil)
+	ok, err := repo.RevokeByIDForUser(context.Background(), revoked.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	found, err := repo.FindActiveByKey(context.Background(), "ath_live")
+	require.NoError(t, err)
+	require.Equal(t, live.ID, found.ID)
+
+	for _, secret := range []string{"ath_expired", "ath_revoked", "ath_unknown"} {
+		_, err := repo.FindActiveByKey(context.Background(), secret)
+		require.Error(t, err, secret)
+	}
+}
+
+func TestAPIKeyRepositoryRevokeIsScopedToOwner(t *testing.T) {
+	db := setupDB(t)
+	repo := apikeyrepository.NewAPIKeyRepository(db)
+	owner := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bert")
+	key := seedKey(t, repo, owner.ID, "ath_owned", nil)
+
+	ok, err := repo.RevokeByIDForUser(context.Background(), key.ID, other.ID)
+	require.NoError(t, err)
+	require.False(t, ok, "another user's key must not be revoked")
+
+	ok, err = repo.RevokeByIDForUser(context.Background(), key.ID, owner.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = repo.RevokeByIDForUser(context.Background(), key.ID, owner.ID)
+	require.NoError(t, err)
+	require.False(t, ok, "revoking twice reports nothing revoked")
+}
+
+func TestAPIKeyRepositoryFindUnrevokedByUserIDAndTouch(t *testing.T) {
+	db := setupDB(t)
+	repo := apikeyrepository.NewAPIKeyRepository(db)
+	user := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bert")
+	past := time.Now().Add(-time.Hour)
+
+	older := seedKey(t, repo, user.ID, "ath_older", &past)
+	newer := seedKey(t, repo, user.ID, "ath_newer", nil)
+	revoked := seedKey(t, repo, user.ID, "ath_revoked", nil)
+	seedKey(t, repo, other.ID, "ath_other", nil)
+	_, err := repo.RevokeByIDForUser(context.Background(), revoked.ID, user.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.TouchLastUsed(context.Background(), newer.ID))
+
+	keys, err := repo.FindUnrevokedByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 2, "expired keys are listed, revoked ones are not")
+	require.Equal(t, newer.ID, keys[0].ID)
+	require.NotNil(t, keys[0].LastUsedAt)
+	require.Equal(t, older.ID, keys[1].ID)
+	require.Nil(t, keys[1].LastUsedAt)
+}
@@ -0,0 +1,26 @@
+// Package api_key wires the API-key (personal access token) module.
+package api_key
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/api_key/controller"
+	"github.com/PhantomX7/athleton/internal/routes"
+)
+
+type routeRegistrar struct {
+	controller controller.APIKeyController
+}
+
+// NewRoutes constructs the API-key route registrar.
+func NewRoutes(controller controller.APIKeyController) routes.Registrar {
+	return &routeRegistrar{controller: controller}
+}
+
+// RegisterRoutes mounts the API-key endpoints under /auth/api-keys. Managing
+// keys takes a session: a key that could issue keys could outlive its own
+// revocation.
+func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	apiKeys := ctx.Root.Group("/auth/api-keys", ctx.MW.RequireSessionAuth(), ctx.MW.ForbidImpersonation())
+	apiKeys.POST("", r.controller.Create)
+	apiKeys.GET("", r.controller.List)
+	apiKeys.DELETE("/:id", r.controller.Revoke)
+}
@@ -0,0 +1,178 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/service"
+)
+
+// Ensure, that APIKeyServiceMock does implement service.APIKeyService.
+// If this is not the case, regenerate this file with moq.
+var _ service.APIKeyService = &APIKeyServiceMock{}
+
+// APIKeyServiceMock is a mock implementation of service.APIKeyService.
+//
+//	func TestSomethingThatUsesAPIKeyService(t *testing.T) {
+//
+//		// make and configure a mocked service.APIKeyService
+//		mockedAPIKeyService := &APIKeyServiceMock{
+//			CreateFunc: func(ctx context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error) {
+//				panic("mock out the Create method")
+//			},
+//			ListFunc: func(ctx context.Context) ([]dto.APIKeyResponse, error) {
+//				panic("mock out the List method")
+//			},
+//			RevokeFunc: func(ctx context.Context, id uint) error {
+//				panic("mock out the Revoke method")
+//			},
+//		}
+//
+//		// use mockedAPIKeyService in code that requires service.APIKeyService
+//		// and then make assertions.
+//
+//	}
+type APIKeyServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]dto.APIKeyResponse, error)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, id uint) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.APIKeyCreateRequest
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+		}
+	}
+	lockCreate sync.RWMutex
+	lockList   sync.RWMutex
+	lockRevoke sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *APIKeyServiceMock) Create(ctx context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error) {
+	if mock.CreateFunc == nil {
+		panic("APIKeyServiceMock.CreateFunc: method is nil but APIKeyService.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.APIKeyCreateRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, req)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedAPIKeyService.CreateCalls())
+func (mock *APIKeyServiceMock) CreateCalls() []struct {
+	Ctx context.Context
+	Req *dto.APIKeyCreateRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.APIKeyCreateRequest
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *APIKeyServiceMock) List(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	if mock.ListFunc == nil {
+		panic("APIKeyServiceMock.ListFunc: method is nil but APIKeyService.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedAPIKeyService.ListCalls())
+func (mock *APIKeyServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *APIKeyServiceMock) Revoke(ctx context.Context, id uint) error {
+	if mock.RevokeFunc == nil {
+		panic("APIKeyServiceMock.RevokeFunc: method is nil but APIKeyService.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uint
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, id)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedAPIKeyService.RevokeCalls())
+func (mock *APIKeyServiceMock) RevokeCalls() []struct {
+	Ctx context.Context
+	ID  uint
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uint
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
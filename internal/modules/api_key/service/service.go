@@ -0,0 +1,162 @@
+// Package service contains the API-key module business logic.
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeyrepo "github.com/PhantomX7/athleton/internal/modules/api_key/repository"
+	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	"github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+const (
+	// keyPrefix marks API keys so they are recognizable in config files and
+	// secret scanners.
+	keyPrefix = "ath_"
+	// displayPrefixLen is how much of a key is kept in the clear to tell
+	// keys apart in listings.
+	displayPrefixLen = len(keyPrefix) + 8
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . APIKeyService
+
+// APIKeyService defines the interface for API-key service operations
+type APIKeyService interface {
+	Create(ctx context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error)
+	List(ctx context.Context) ([]dto.APIKeyResponse, error)
+	Revoke(ctx context.Context, id uint) error
+}
+
+type apiKeyService struct {
+	apiKeyRepo    apikeyrepo.APIKeyRepository
+	logRepository logRepository.LogRepository
+	casbinClient  casbin.Client
+}
+
+// NewAPIKeyService builds the API-key service from its dependencies.
+func NewAPIKeyService(
+	apiKeyRepo apikeyrepo.APIKeyRepository,
+	logRepository logRepository.LogRepository,
+	casbinClient casbin.Client,
+) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:    apiKeyRepo,
+		logRepository: logRepository,
+		casbinClient:  casbinClient,
+	}
+}
+
+// Create issues an API key for the authenticated user, scoped to the
+// requested permissions. Every permission must be registered and currently
+// held by the caller, so a key can never grant more than its owner has. The
+// plaintext key is only returned here.
+func (s *apiKeyService) Create(ctx context.Context, req *dto.APIKeyCreateRequest) (*dto.APIKeyCreateResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, cerrors.NewBadRequestError("expires_at must be in the future")
+	}
+
+	scope := slices.Clone(req.Permissions)
+	slices.Sort(scope)
+	scope = slices.Compact(scope)
+
+	var invalid, denied []string
+	for _, perm := range scope {
+		if !permissions.IsValidPermission(perm) {
+			invalid = append(invalid, perm)
+			continue
+		}
+		allowed, err := s.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleIDs, perm)
+		if err != nil {
+			return nil, cerrors.NewInternalServerError("failed to verify caller permissions", err)
+		}
+		if !allowed {
+			denied = append(denied, perm)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, cerrors.NewBadRequestError("invalid permissions: " + strings.Join(invalid, ", "))
+	}
+	if len(denied) > 0 {
+		return nil, cerrors.NewForbiddenError("cannot grant permissions you do not hold: " + strings.Join(denied, ", "))
+	}
+
+	key := keyPrefix + rand.Text() + rand.Text()
+	apiKey := &models.APIKey{
+		UserID:      values.UserID,
+		Name:        req.Name,
+		Prefix:      key[:displayPrefixLen],
+		KeyHash:     key,
+		Permissions: scope,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	audit.RecordAction(ctx, s.logRepository, models.LogActionCreate, models.LogEntityTypeAPIKey, apiKey.ID, "API key", apiKey.Name)
+
+	return &dto.APIKeyCreateResponse{
+		APIKeyResponse: apiKey.ToResponse(),
+		Key:            key,
+	}, nil
+}
+
+// List returns the authenticated user's keys that have not been revoked.
+func (s *apiKeyService) List(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.FindUnrevokedByUserID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, key.ToResponse())
+	}
+	return res, nil
+}
+
+// Revoke permanently disables one of the authenticated user's keys. Another
+// user's key is reported as not found so key IDs cannot be probed.
+func (s *apiKeyService) Revoke(ctx context.Context, id uint) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.apiKeyRepo.RevokeByIDForUser(ctx, id, values.UserID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return cerrors.NewNotFoundError("api key not found")
+	}
+
+	audit.Record(ctx, s.logRepository, audit.Entry{
+		Action:     models.LogActionRevokeAPIKey,
+		EntityType: models.LogEntityTypeAPIKey,
+		EntityID:   id,
+		Message:    fmt.Sprintf("%s revoked API key #%d", audit.UserName(ctx), id),
+	})
+	return nil
+}
@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeymocks "github.com/PhantomX7/athleton/internal/modules/api_key/repository/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/api_key/service"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	casbinmocks "github.com/PhantomX7/athleton/libs/casbin/mocks"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func setupLogger(t *testing.T) {
+	t.Helper()
+
+	prev := logger.Log
+	logger.Log = zap.NewNop()
+	t.Cleanup(func() {
+		logger.Log = prev
+	})
+}
+
+func adminContext(userID, roleID uint) context.Context {
+	return utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID:       userID,
+		UserName:     "alice",
+		Role:         models.UserRoleAdmin.ToString(),
+		AdminRoleIDs: []uint{roleID},
+	})
+}
+
+func requireAppError(t *testing.T, err error, code int, message string) {
+	t.Helper()
+	var appErr *cerrors.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	require.Equal(t, code, appErr.Code)
+	require.Equal(t, message, appErr.Message)
+}
+
+// casbinHolding grants exactly the listed permissions.
+func casbinHolding(held ...permissions.Permission) *casbinmocks.ClientMock {
+	return &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, permission string) (bool, error) {
+			for _, p := range held {
+				if p.String() == permission {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+func logRepo() *logmocks.LogRepositoryMock {
+	return &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+}
+
+func TestAPIKeyServiceCreateScopesKeyToHeldPermissions(t *testing.T) {
+	setupLogger(t)
+
+	var stored *models.APIKey
+	repo := &apikeymocks.APIKeyRepositoryMock{
+		CreateFunc: func(_ context.Context, key *models.APIKey) error {
+			stored = key
+			key.ID = 9
+			return nil
+		},
+	}
+	logs := logRepo()
+	svc := service.NewAPIKeyService(repo, logs, casbinHolding(permissions.UserRead, permissions.LogRead))
+
+	res, err := svc.Create(adminContext(7, 3), &dto.APIKeyCreateRequest{
+		Name:        "exporter",
+		Permissions: []string{"user:read", "log:read", "user:read"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.True(t, strings.HasPrefix(res.Key, "ath_"))
+	require.Equal(t, res.Key[:len(res.Prefix)], res.Prefix)
+	require.Equal(t, uint(9), res.ID)
+	require.Equal(t, []string{"log:read", "user:read"}, res.Permissions, "scope is de-duplicated")
+
+	require.Equal(t, uint(7), stored.UserID)
+	require.Equal(t, res.Key, stored.KeyHash, "the repository hashes the plaintext on insert")
+
+	require.Len(t, logs.CreateCalls(), 1)
+	require.Equal(t, models.LogEntityTypeAPIKey, logs.CreateCalls()[0].Entity.EntityType)
+}
+
+func TestAPIKeyServiceCreateRejectsUnheldAndUnknownPermissions(t *testing.T) {
+	setupLogger(t)
+
+	repo := &apikeymocks.APIKeyRepositoryMock{}
+	svc := service.NewAPIKeyService(repo, logRepo(), casbinHolding(permissions.UserRead))
+
+	_, err := svc.Create(adminContext(7, 3), &dto.APIKeyCreateRequest{
+		Name:        "too-broad",
+		Permissions: []string{"user:read", "user:delete"},
+	})
+	requireAppError(t, err, http.StatusForbidden, "cannot grant permissions you do not hold: user:delete")
+
+	_, err = svc.Create(adminContext(7, 3), &dto.APIKeyCreateRequest{
+		Name:        "made-up",
+		Permissions: []string{"user:read", "rocket:launch"},
+	})
+	requireAppError(t, err, http.StatusBadRequest, "invalid permissions: rocket:launch")
+
+	require.Empty(t, repo.CreateCalls())
+}
+
+func TestAPIKeyServiceCreateRejectsPastExpiry(t *testing.T) {
+	setupLogger(t)
+
+	past := time.Now().Add(-time.Minute)
+	svc := service.NewAPIKeyService(&apikeymocks.APIKeyRepositoryMock{}, logRepo(), casbinHolding(permissions.UserRead))
+
+	_, err := svc.Create(adminContext(7, 3), &dto.APIKeyCreateRequest{
+		Name:        "stale",
+		Permissions: []string{"user:read"},
+		ExpiresAt:   &past,
+	})
+	requireAppError(t, err, http.StatusBadRequest, "expires_at must be in the future")
+}
+
+func TestAPIKeyServiceRevokeReportsMissingKey(t *testing.T) {
+	setupLogger(t)
+
+	repo := &apikeymocks.APIKeyRepositoryMock{
+		RevokeByIDForUserFunc: func(_ context.Context, id, userID uint) (bool, error) {
+			require.Equal(t, uint(5), id)
+			require.Equal(t, uint(7), userID)
+			return false, nil
+		},
+	}
+	svc := service.NewAPIKeyService(repo, logRepo(), casbinHolding())
+
+	err := svc.Revoke(adminContext(7, 3), 5)
+	requireAppError(t, err, http.StatusNotFound, "api key not found")
+}
+
+func TestAPIKeyServiceListRequiresAuthenticatedUser(t *testing.T) {
+	setupLogger(t)
+
+	svc := service.NewAPIKeyService(&apikeymocks.APIKeyRepositoryMock{}, logRepo(), casbinHolding())
+
+	_, err := svc.List(context.Background())
+	require.Error(t, err)
+}
@@ -2,13 +2,17 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/PhantomX7/athleton/internal/dto"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
 	"github.com/PhantomX7/athleton/internal/modules/auth/service"
+	"github.com/PhantomX7/athleton/pkg/ginx"
 	"github.com/PhantomX7/athleton/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // AuthController defines the interface for auth controller operations
@@ -16,26 +20,47 @@ import (
 type AuthController interface {
 	Register(ctx *gin.Context)
 	GetMe(ctx *gin.Context)
+	UpdateMe(ctx *gin.Context)
+	ChangeEmail(ctx *gin.Context)
+	ConfirmEmailChange(ctx *gin.Context)
+	RequestDataExport(ctx *gin.Context)
+	DownloadDataExport(ctx *gin.Context)
+	DeleteMe(ctx *gin.Context)
 	Refresh(ctx *gin.Context)
 	ChangePassword(ctx *gin.Context)
+	Reauthenticate(ctx *gin.Context)
 	Logout(ctx *gin.Context)
+	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+	VerifyEmail(ctx *gin.Context)
+	ResendVerification(ctx *gin.Context)
+	RequestMagicLink(ctx *gin.Context)
+	ConsumeMagicLink(ctx *gin.Context)
+	ListSessions(ctx *gin.Context)
+	RevokeSession(ctx *gin.Context)
+	RevokeOtherSessions(ctx *gin.Context)
+	JWKS(ctx *gin.Context)
+	Impersonate(ctx *gin.Context)
+	EndImpersonation(ctx *gin.Context)
 }
 
 type authController struct {
 	authService service.AuthService
+	cookies     authjwt.SessionCookies
 }
 
 // NewAuthController constructs an AuthController.
-func NewAuthController(authService service.AuthService) AuthController {
+func NewAuthController(authService service.AuthService, cookies authjwt.SessionCookies) AuthController {
 	return &authController{
 		authService: authService,
+		cookies:     cookies,
 	}
 }
 
 // Register handles new account registration.
 //
 //	@Summary		Register
-//	@Description	Register a new user account and return auth tokens
+//	@Description	Register a new user account, email a verification link, and return auth tokens (no tokens when email verification is required)
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -57,6 +82,7 @@ func (c *authController) Register(ctx *gin.Context) {
 		return
 	}
 
+	c.cookies.SetSessionCookies(ctx, res)
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("register success", res))
 }
 
@@ -82,21 +108,202 @@ func (c *authController) GetMe(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("get me success", res))
 }
 
+// UpdateMe edits the authenticated user's own profile.
+//
+//	@Summary		Update current user
+//	@Description	Change the authenticated user's name, business name or phone. Omitted fields are left unchanged; the email address is changed via /auth/change-email.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.UpdateProfileRequest	true	"Update Profile Request"
+//	@Success		200		{object}	response.Response{data=dto.MeResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Router			/auth/me [patch]
+func (c *authController) UpdateMe(ctx *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.authService.UpdateMe(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("profile updated successfully", res))
+}
+
+// ChangeEmail starts moving the authenticated user's account to a new address.
+//
+//	@Summary		Change email
+//	@Description	Email a confirmation link to the new address and a notice to the current one. The address only changes once the link is redeemed at /auth/confirm-email-change. Requires a recent authentication (see /auth/reauthenticate).
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ChangeEmailRequest	true	"Change Email Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		403		{object}	response.Response
+//	@Router			/auth/change-email [post]
+func (c *authController) ChangeEmail(ctx *gin.Context) {
+	var req dto.ChangeEmailRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.ChangeEmail(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("a confirmation link has been sent to the new address", nil))
+}
+
+// ConfirmEmailChange applies a pending email change using an emailed token.
+//
+//	@Summary		Confirm email change
+//	@Description	Redeem an email-change token, switching the account to the new address and marking it verified
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.ConfirmEmailChangeRequest	true	"Confirm Email Change Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/confirm-email-change [post]
+func (c *authController) ConfirmEmailChange(ctx *gin.Context) {
+	var req dto.ConfirmEmailChangeRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.ConfirmEmailChange(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("email changed successfully", nil))
+}
+
+// RequestDataExport issues a time-limited link to an archive of the
+// authenticated user's data.
+//
+//	@Summary		Request data export
+//	@Description	Issue a token that downloads a ZIP archive of the authenticated user's profile, sessions, security events and audit entries until expires_at, by POSTing it to download_url. Requesting another token invalidates the previous one. Requires a recent authentication (see /auth/reauthenticate).
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=dto.DataExportResponse}
+//	@Failure		401	{object}	response.Response
+//	@Failure		403	{object}	response.Response
+//	@Router			/auth/me/export [post]
+func (c *authController) RequestDataExport(ctx *gin.Context) {
+	res, err := c.authService.RequestDataExport(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	res.DownloadURL = ctx.Request.URL.Path + "/download"
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("data export is ready for download", res))
+}
+
+// DownloadDataExport serves the archive a data-export token unlocks.
+//
+//	@Summary		Download data export
+//	@Description	Download the ZIP archive a data-export token unlocks. The token is read from the JSON or form-encoded body, never the query string, so it stays out of access logs and Referer headers. It keeps working until it expires.
+//	@Tags			auth
+//	@Accept			json
+//	@Accept			x-www-form-urlencoded
+//	@Produce		application/zip
+//	@Param			body	body		dto.DownloadDataExportRequest	true	"Download Data Export Request"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/me/export/download [post]
+func (c *authController) DownloadDataExport(ctx *gin.Context) {
+	// binding.Form would also read the query string, which is exactly where
+	// the token must not travel.
+	var b binding.Binding = binding.JSON
+	if ctx.ContentType() == binding.MIMEPOSTForm {
+		b = binding.FormPost
+	}
+	var req dto.DownloadDataExportRequest
+	if err := ctx.ShouldBindWith(&req, b); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	archive, err := c.authService.DownloadDataExport(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.FileName))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/zip", archive.Content)
+}
+
+// DeleteMe erases the authenticated user's own account.
+//
+//	@Summary		Delete current user
+//	@Description	Erase the authenticated user's account after checking its password: personal data is anonymised, every session is revoked, and audit entries are kept without the personal data. Root accounts cannot be deleted.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.DeleteAccountRequest	true	"Delete Account Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		403		{object}	response.Response
+//	@Router			/auth/me [delete]
+func (c *authController) DeleteMe(ctx *gin.Context) {
+	var req dto.DeleteAccountRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.DeleteMe(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.cookies.ClearSessionCookies(ctx)
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("account deleted successfully", nil))
+}
+
 // Refresh rotates an access token using a refresh token.
 //
 //	@Summary		Refresh token
-//	@Description	Exchange a refresh token for a new access token
+//	@Description	Exchange a refresh token for a new access token. In cookie auth mode the refresh token cookie is used and the new tokens are set as cookies; the body may then be empty.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		dto.RefreshRequest	true	"Refresh Request"
+//	@Param			body	body		dto.RefreshRequest	false	"Refresh Request"
 //	@Success		200		{object}	response.Response{data=dto.AuthResponse}
 //	@Failure		400		{object}	response.Response
 //	@Failure		401		{object}	response.Response
 //	@Router			/auth/refresh [post]
 func (c *authController) Refresh(ctx *gin.Context) {
 	var req dto.RefreshRequest
-	if err := ctx.ShouldBind(&req); err != nil {
+	if token, ok := c.cookies.RefreshTokenFromCookie(ctx); ok {
+		req.RefreshToken = token
+	} else if err := ctx.ShouldBind(&req); err != nil {
 		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
@@ -107,6 +314,7 @@ func (c *authController) Refresh(ctx *gin.Context) {
 		return
 	}
 
+	c.cookies.SetSessionCookies(ctx, res)
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("refresh success", res))
 }
 
@@ -139,22 +347,54 @@ func (c *authController) ChangePassword(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("password changed successfully", nil))
 }
 
+// Reauthenticate opens the step-up window on the current session.
+//
+//	@Summary		Reauthenticate
+//	@Description	Confirm the password, or for a 2FA account a TOTP or recovery code, to allow the current session to perform sensitive operations (deleting users, changing another admin's password, managing admin roles) for AUTH_REAUTHENTICATION_WINDOW. Those operations answer 403 with error code "reauthentication_required" until this is done.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.ReauthenticateRequest	true	"Reauthenticate Request"
+//	@Success		200		{object}	response.Response{data=dto.ReauthenticateResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		403		{object}	response.Response
+//	@Router			/auth/reauthenticate [post]
+func (c *authController) Reauthenticate(ctx *gin.Context) {
+	var req dto.ReauthenticateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.authService.Reauthenticate(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("reauthenticated successfully", res))
+}
+
 // Logout revokes the supplied refresh token.
 //
 //	@Summary		Logout
-//	@Description	Revoke the supplied refresh token
+//	@Description	Revoke the supplied refresh token. In cookie auth mode the refresh token cookie is used, the body may be empty, and the auth cookies are cleared.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			body	body		dto.LogoutRequest	true	"Logout Request"
+//	@Param			body	body		dto.LogoutRequest	false	"Logout Request"
 //	@Success		200		{object}	response.Response
 //	@Failure		400		{object}	response.Response
 //	@Failure		401		{object}	response.Response
 //	@Router			/auth/logout [post]
 func (c *authController) Logout(ctx *gin.Context) {
 	var req dto.LogoutRequest
-	if err := ctx.ShouldBind(&req); err != nil {
+	if token, ok := c.cookies.RefreshTokenFromCookie(ctx); ok {
+		req.RefreshToken = token
+	} else if err := ctx.ShouldBind(&req); err != nil {
 		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
@@ -165,5 +405,306 @@ func (c *authController) Logout(ctx *gin.Context) {
 		return
 	}
 
+	c.cookies.ClearSessionCookies(ctx)
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("logout successful", nil))
 }
+
+// ForgotPassword emails a password-reset link to the given address.
+//
+//	@Summary		Forgot password
+//	@Description	Email a single-use password-reset link. Always succeeds so registered addresses cannot be enumerated.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.ForgotPasswordRequest	true	"Forgot Password Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/forgot-password [post]
+func (c *authController) ForgotPassword(ctx *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.ForgotPassword(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("if the email is registered, a password reset link has been sent", nil))
+}
+
+// ResetPassword sets a new password using an emailed reset token.
+//
+//	@Summary		Reset password
+//	@Description	Redeem a password-reset token, set a new password, and revoke every session
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.ResetPasswordRequest	true	"Reset Password Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/reset-password [post]
+func (c *authController) ResetPassword(ctx *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.ResetPassword(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("password reset successfully", nil))
+}
+
+// VerifyEmail confirms the user's email address using an emailed token.
+//
+//	@Summary		Verify email
+//	@Description	Redeem an email-verification token and mark the address as verified
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.VerifyEmailRequest	true	"Verify Email Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/verify-email [post]
+func (c *authController) VerifyEmail(ctx *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.VerifyEmail(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("email verified successfully", nil))
+}
+
+// ResendVerification emails a fresh verification link to the given address.
+//
+//	@Summary		Resend verification email
+//	@Description	Email a new verification link to an unverified account. Always succeeds so registered addresses cannot be enumerated.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.ResendVerificationRequest	true	"Resend Verification Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/resend-verification [post]
+func (c *authController) ResendVerification(ctx *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.ResendVerification(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("if the email is registered and unverified, a verification link has been sent", nil))
+}
+
+// RequestMagicLink emails a passwordless login link to the given address.
+//
+//	@Summary		Request magic link
+//	@Description	Email a single-use login link when the account's role is listed in AUTH_MAGIC_LINK_ROLES and it has no 2FA. Always succeeds so registered addresses cannot be enumerated.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.MagicLinkRequest	true	"Magic Link Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/magic-link [post]
+func (c *authController) RequestMagicLink(ctx *gin.Context) {
+	var req dto.MagicLinkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.authService.RequestMagicLink(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("if the email can log in by link, a login link has been sent", nil))
+}
+
+// ConsumeMagicLink exchanges an emailed login link for auth tokens.
+//
+//	@Summary		Consume magic link
+//	@Description	Redeem a single-use login link and return auth tokens, like a password login
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.ConsumeMagicLinkRequest	true	"Consume Magic Link Request"
+//	@Success		200		{object}	response.Response{data=dto.AuthResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/magic-link/consume [post]
+func (c *authController) ConsumeMagicLink(ctx *gin.Context) {
+	var req dto.ConsumeMagicLinkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.authService.ConsumeMagicLink(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.cookies.SetSessionCookies(ctx, res)
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("login success", res))
+}
+
+// ListSessions returns the authenticated user's active sessions.
+//
+//	@Summary		List sessions
+//	@Description	List the authenticated user's active sessions (one per signed-in device), most recently used first. The session making the request has current=true.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=[]dto.SessionResponse}
+//	@Failure		401	{object}	response.Response
+//	@Router			/auth/sessions [get]
+func (c *authController) ListSessions(ctx *gin.Context) {
+	res, err := c.authService.ListSessions(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("get sessions success", res))
+}
+
+// RevokeSession signs out one of the authenticated user's sessions.
+//
+//	@Summary		Revoke session
+//	@Description	Sign out one of the authenticated user's sessions; its refresh and access tokens stop working immediately
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Session ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Router			/auth/sessions/{id} [delete]
+func (c *authController) RevokeSession(ctx *gin.Context) {
+	id, ok := ginx.ParseUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	err := c.authService.RevokeSession(ctx.Request.Context(), id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("session revoked", nil))
+}
+
+// RevokeOtherSessions signs out every other session of the authenticated user.
+//
+//	@Summary		Revoke other sessions
+//	@Description	Sign out every session of the authenticated user except the one making the request
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Router			/auth/sessions/revoke-others [post]
+func (c *authController) RevokeOtherSessions(ctx *gin.Context) {
+	err := c.authService.RevokeOtherSessions(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("other sessions revoked", nil))
+}
+
+// Impersonate starts acting as another user.
+//
+//	@Summary		Impersonate user
+//	@Description	Issue a short-lived access token that acts as the given user. Every action taken with it is audited against both users. The token cannot be refreshed, change passwords, edit the profile, revoke sessions or start another impersonation.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"User ID"
+//	@Success		200	{object}	response.Response{data=dto.ImpersonationResponse}
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		403	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Router			/admin/user/{id}/impersonate [post]
+func (c *authController) Impersonate(ctx *gin.Context) {
+	userID, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	res, err := c.authService.Impersonate(ctx.Request.Context(), userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("impersonation started", res))
+}
+
+// EndImpersonation revokes the impersonation token making the request.
+//
+//	@Summary		End impersonation
+//	@Description	Revoke the impersonation session the request is made with
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Router			/auth/impersonation/end [post]
+func (c *authController) EndImpersonation(ctx *gin.Context) {
+	err := c.authService.EndImpersonation(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("impersonation ended", nil))
+}
+
+// JWKS publishes the public keys that verify access tokens, so other services
+// can validate them without the signing secret. The document is served bare
+// (no response envelope), as JWKS clients expect.
+func (c *authController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.authService.GetJWKS(ctx.Request.Context()))
+}
@@ -8,14 +8,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/modules/auth/controller"
+	authjwtmocks "github.com/PhantomX7/athleton/internal/modules/auth/jwt/mocks"
 	authservicemocks "github.com/PhantomX7/athleton/internal/modules/auth/service/mocks"
 )
 
@@ -30,6 +33,16 @@ func init() {
 	}
 }
 
+// bearerCookies stands in for SessionCookies in bearer mode: no cookies are
+// read or written.
+func bearerCookies() *authjwtmocks.SessionCookiesMock {
+	return &authjwtmocks.SessionCookiesMock{
+		SetSessionCookiesFunc:      func(*gin.Context, *dto.AuthResponse) {},
+		ClearSessionCookiesFunc:    func(*gin.Context) {},
+		RefreshTokenFromCookieFunc: func(*gin.Context) (string, bool) { return "", false },
+	}
+}
+
 func TestAuthControllerGetMeReturnsSuccessResponse(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -49,7 +62,7 @@ func TestAuthControllerGetMeReturnsSuccessResponse(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/me", nil)
@@ -77,7 +90,7 @@ func TestAuthControllerRefreshReturnsSuccessResponse(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"refresh-token"}`))
@@ -104,7 +117,7 @@ func TestAuthControllerChangePasswordReturnsSuccessResponse(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/change-password", bytes.NewBufferString(`{"old_password":"old-password","new_password":"new-password","except_token":"keep-token"}`))
@@ -129,7 +142,7 @@ func TestAuthControllerLogoutReturnsSuccessResponse(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/logout", bytes.NewBufferString(`{"refresh_token":"refresh-token"}`))
@@ -153,7 +166,7 @@ func TestAuthControllerRegisterRejectsInvalidPayload(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/register", bytes.NewBufferString(`{}`))
@@ -178,7 +191,7 @@ func TestAuthControllerRegisterReturnsTokensOnSuccess(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	body := `{"name":"Alice","business_name":"Acme","email":"alice@example.com","phone":"081","password":"supersecret"}`
@@ -204,7 +217,7 @@ func TestAuthControllerRefreshPropagatesServiceError(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"t"}`))
@@ -224,7 +237,7 @@ func TestAuthControllerChangePasswordPropagatesServiceError(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	body := `{"old_password":"old-password","new_password":"new-password","except_token":"keep"}`
@@ -245,7 +258,7 @@ func TestAuthControllerLogoutPropagatesServiceError(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/logout", bytes.NewBufferString(`{"refresh_token":"t"}`))
@@ -267,7 +280,7 @@ func TestAuthControllerGetMePropagatesServiceError(t *testing.T) {
 		},
 	}
 
-	ctrl := controller.NewAuthController(svc)
+	ctrl := controller.NewAuthController(svc, bearerCookies())
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/me", nil)
@@ -277,3 +290,499 @@ func TestAuthControllerGetMePropagatesServiceError(t *testing.T) {
 	require.Len(t, ctx.Errors, 1)
 	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
 }
+
+func TestAuthControllerForgotPasswordReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ForgotPasswordFunc: func(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+			require.NotNil(t, ctx)
+			require.Equal(t, "alice@example.com", req.Email)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/forgot-password", bytes.NewBufferString(`{"email":"alice@example.com"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ForgotPassword(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "if the email is registered, a password reset link has been sent", body["message"])
+}
+
+func TestAuthControllerForgotPasswordRejectsInvalidPayload(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ForgotPasswordFunc: func(context.Context, *dto.ForgotPasswordRequest) error {
+			t.Fatal("ForgotPassword should not be called for invalid payloads")
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/forgot-password", bytes.NewBufferString(`{"email":"not-an-email"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ForgotPassword(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.True(t, ctx.Errors[0].IsType(gin.ErrorTypeBind))
+}
+
+func TestAuthControllerResetPasswordReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ResetPasswordFunc: func(ctx context.Context, req *dto.ResetPasswordRequest) error {
+			require.NotNil(t, ctx)
+			require.Equal(t, "reset-token", req.Token)
+			require.Equal(t, "new-password", req.NewPassword)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/reset-password", bytes.NewBufferString(`{"token":"reset-token","new_password":"new-password"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ResetPassword(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "password reset successfully", body["message"])
+}
+
+func TestAuthControllerResetPasswordPropagatesServiceError(t *testing.T) {
+	expectedErr := errors.New("service failed")
+	svc := &authservicemocks.AuthServiceMock{
+		ResetPasswordFunc: func(context.Context, *dto.ResetPasswordRequest) error {
+			return expectedErr
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/reset-password", bytes.NewBufferString(`{"token":"t","new_password":"new-password"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ResetPassword(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
+}
+
+func TestAuthControllerVerifyEmailReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		VerifyEmailFunc: func(ctx context.Context, req *dto.VerifyEmailRequest) error {
+			require.NotNil(t, ctx)
+			require.Equal(t, "verify-token", req.Token)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/verify-email", bytes.NewBufferString(`{"token":"verify-token"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.VerifyEmail(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "email verified successfully", body["message"])
+}
+
+func TestAuthControllerVerifyEmailPropagatesServiceError(t *testing.T) {
+	expectedErr := errors.New("service failed")
+	svc := &authservicemocks.AuthServiceMock{
+		VerifyEmailFunc: func(context.Context, *dto.VerifyEmailRequest) error {
+			return expectedErr
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/verify-email", bytes.NewBufferString(`{"token":"t"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.VerifyEmail(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
+}
+
+func TestAuthControllerResendVerificationReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ResendVerificationFunc: func(ctx context.Context, req *dto.ResendVerificationRequest) error {
+			require.Equal(t, "alice@example.com", req.Email)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/resend-verification", bytes.NewBufferString(`{"email":"alice@example.com"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ResendVerification(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "if the email is registered and unverified, a verification link has been sent", body["message"])
+}
+
+func TestAuthControllerListSessionsReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ListSessionsFunc: func(context.Context) ([]dto.SessionResponse, error) {
+			return []dto.SessionResponse{{ID: uuid.NewString(), UserAgent: "curl/8.0", Current: true}}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions", nil)
+
+	ctrl.ListSessions(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Message string                `json:"message"`
+		Data    []dto.SessionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "get sessions success", body.Message)
+	require.Len(t, body.Data, 1)
+	require.True(t, body.Data[0].Current)
+}
+
+func TestAuthControllerRevokeSessionPassesParsedID(t *testing.T) {
+	sessionID := uuid.New()
+	svc := &authservicemocks.AuthServiceMock{
+		RevokeSessionFunc: func(_ context.Context, id uuid.UUID) error {
+			require.Equal(t, sessionID, id)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/"+sessionID.String(), nil)
+	ctx.Params = gin.Params{{Key: "id", Value: sessionID.String()}}
+
+	ctrl.RevokeSession(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, svc.RevokeSessionCalls(), 1)
+}
+
+func TestAuthControllerRevokeSessionRejectsMalformedID(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/42", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "42"}}
+
+	ctrl.RevokeSession(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Empty(t, svc.RevokeSessionCalls())
+}
+
+func TestAuthControllerRevokeOtherSessionsPropagatesServiceError(t *testing.T) {
+	expectedErr := errors.New("service failed")
+	svc := &authservicemocks.AuthServiceMock{
+		RevokeOtherSessionsFunc: func(context.Context) error {
+			return expectedErr
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/sessions/revoke-others", nil)
+
+	ctrl.RevokeOtherSessions(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
+}
+
+func TestAuthControllerImpersonatePassesParsedID(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ImpersonateFunc: func(_ context.Context, userID uint) (*dto.ImpersonationResponse, error) {
+			require.Equal(t, uint(9), userID)
+			return &dto.ImpersonationResponse{AccessToken: "access", TokenType: "Bearer"}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/user/9/impersonate", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "9"}}
+
+	ctrl.Impersonate(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Message string                    `json:"message"`
+		Data    dto.ImpersonationResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "impersonation started", body.Message)
+	require.Equal(t, "access", body.Data.AccessToken)
+}
+
+func TestAuthControllerEndImpersonationPropagatesServiceError(t *testing.T) {
+	expectedErr := errors.New("service failed")
+	svc := &authservicemocks.AuthServiceMock{
+		EndImpersonationFunc: func(context.Context) error {
+			return expectedErr
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/impersonation/end", nil)
+
+	ctrl.EndImpersonation(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
+}
+
+func TestAuthControllerReauthenticateReturnsWindow(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc := &authservicemocks.AuthServiceMock{
+		ReauthenticateFunc: func(_ context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+			require.Equal(t, "secret-password", req.Password)
+			return &dto.ReauthenticateResponse{ExpiresAt: expiresAt}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/reauthenticate", bytes.NewBufferString(`{"password":"secret-password"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Reauthenticate(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"expires_at":"2026-01-02T03:04:05Z"`)
+}
+
+func TestAuthControllerReauthenticateRequiresPasswordOrCode(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ReauthenticateFunc: func(context.Context, *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+			t.Fatal("Reauthenticate should not be called without a password or code")
+			return nil, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/reauthenticate", bytes.NewBufferString(`{}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Reauthenticate(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.True(t, ctx.Errors[0].IsType(gin.ErrorTypeBind))
+}
+
+func TestAuthControllerConsumeMagicLinkReturnsTokens(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ConsumeMagicLinkFunc: func(_ context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error) {
+			require.Equal(t, "link-token", req.Token)
+			return &dto.AuthResponse{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer"}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/magic-link/consume", bytes.NewBufferString(`{"token":"link-token"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ConsumeMagicLink(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"access_token":"access"`)
+}
+
+func TestAuthControllerUpdateMeLeavesOmittedFieldsNil(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		UpdateMeFunc: func(_ context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error) {
+			require.NotNil(t, req.Name)
+			require.Equal(t, "Alice B", *req.Name)
+			require.Nil(t, req.BusinessName)
+			require.Nil(t, req.Phone)
+			return &dto.MeResponse{UserResponse: dto.UserResponse{ID: 1, Name: "Alice B"}}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPatch, "/auth/me", bytes.NewBufferString(`{"name":"Alice B"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.UpdateMe(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "profile updated successfully", body["message"])
+}
+
+func TestAuthControllerUpdateMeRejectsEmptyName(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPatch, "/auth/me", bytes.NewBufferString(`{"name":""}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.UpdateMe(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+	require.Empty(t, svc.UpdateMeCalls())
+}
+
+func TestAuthControllerChangeEmailRejectsInvalidAddress(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/change-email", bytes.NewBufferString(`{"email":"not-an-address"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ChangeEmail(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+	require.Empty(t, svc.ChangeEmailCalls())
+}
+
+func TestAuthControllerConfirmEmailChangeReturnsSuccessResponse(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		ConfirmEmailChangeFunc: func(_ context.Context, req *dto.ConfirmEmailChangeRequest) error {
+			require.Equal(t, "change-token", req.Token)
+			return nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/confirm-email-change", bytes.NewBufferString(`{"token":"change-token"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.ConfirmEmailChange(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "email changed successfully", body["message"])
+}
+
+func TestAuthControllerRequestDataExportReturnsDownloadURL(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		RequestDataExportFunc: func(context.Context) (*dto.DataExportResponse, error) {
+			return &dto.DataExportResponse{Token: "export token", ExpiresAt: time.Now().Add(time.Minute)}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/auth/me/export", nil)
+
+	ctrl.RequestDataExport(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "/api/v1/auth/me/export/download", body.Data["download_url"])
+	require.Equal(t, "export token", body.Data["token"])
+}
+
+func TestAuthControllerDownloadDataExportServesAttachment(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{
+		DownloadDataExportFunc: func(_ context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error) {
+			require.Equal(t, "export-token", req.Token)
+			return &dto.DataExportArchive{FileName: "data-export-1.zip", Content: []byte("PK")}, nil
+		},
+	}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/me/export/download", bytes.NewBufferString(`{"token":"export-token"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.DownloadDataExport(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="data-export-1.zip"`, rec.Header().Get("Content-Disposition"))
+	require.Equal(t, "PK", rec.Body.String())
+}
+
+func TestAuthControllerDownloadDataExportIgnoresQueryToken(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	for _, contentType := range []string{"application/json", "application/x-www-form-urlencoded"} {
+		rec := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rec)
+		ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/me/export/download?token=export-token", bytes.NewBufferString(""))
+		ctx.Request.Header.Set("Content-Type", contentType)
+
+		ctrl.DownloadDataExport(ctx)
+
+		require.Len(t, ctx.Errors, 1, contentType)
+		require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type, contentType)
+	}
+	require.Empty(t, svc.DownloadDataExportCalls())
+}
+
+func TestAuthControllerDeleteMeRequiresPassword(t *testing.T) {
+	svc := &authservicemocks.AuthServiceMock{}
+
+	ctrl := controller.NewAuthController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/me", bytes.NewBufferString(`{}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.DeleteMe(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+	require.Empty(t, svc.DeleteMeCalls())
+}
@@ -0,0 +1,127 @@
+package authjwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeymocks "github.com/PhantomX7/athleton/internal/modules/api_key/repository/mocks"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func newAPIKeyContext(authorization string) (*gin.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/admin/user", nil)
+	if authorization != "" {
+		c.Request.Header.Set("Authorization", authorization)
+	}
+	return c, rec
+}
+
+func TestAPIKeyFromRequest(t *testing.T) {
+	for header, want := range map[string]string{
+		"ApiKey ath_secret":  "ath_secret",
+		"apikey  ath_secret": "ath_secret",
+		"Bearer ath_secret":  "",
+		"ApiKey ":            "",
+		"":                   "",
+	} {
+		c, _ := newAPIKeyContext(header)
+		key, ok := APIKeyFromRequest(c)
+		require.Equal(t, want, key, header)
+		require.Equal(t, want != "", ok, header)
+	}
+}
+
+func TestAuthenticateAPIKeySetsOwnerAndScope(t *testing.T) {
+	setupLogger(t)
+
+	adminRoleID := uint(4)
+	apiKeyRepo := &apikeymocks.APIKeyRepositoryMock{
+		FindActiveByKeyFunc: func(_ context.Context, key string) (*models.APIKey, error) {
+			require.Equal(t, "ath_secret", key)
+			return &models.APIKey{ID: 11, UserID: 5, Name: "exporter", Permissions: []string{"user:read"}}, nil
+		},
+		TouchLastUsedFunc: func(_ context.Context, id uint) error {
+			require.Equal(t, uint(11), id)
+			return nil
+		},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Name: "Alice", Role: models.UserRoleAdmin, IsActive: true, AdminRoles: []models.AdminRole{{ID: adminRoleID}}}, nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: userRepo, apiKeyRepo: apiKeyRepo, logRepository: logRepo}
+
+	c, _ := newAPIKeyContext("ApiKey ath_secret")
+	a.AuthenticateAPIKey(c)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.False(t, c.IsAborted())
+	values, err := utils.ValuesFromContext(c.Request.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint(5), values.UserID)
+	require.Equal(t, "admin", values.Role)
+	require.Equal(t, []uint{adminRoleID}, values.AdminRoleIDs)
+	require.Equal(t, uint(11), values.APIKeyID)
+	require.Equal(t, []string{"user:read"}, values.APIKeyPermissions)
+	_, loaded := c.Get(AuthUserKey)
+	require.True(t, loaded)
+
+	require.Len(t, apiKeyRepo.TouchLastUsedCalls(), 1)
+	require.Len(t, logRepo.CreateCalls(), 1)
+	entry := logRepo.CreateCalls()[0].Entity
+	require.Equal(t, models.LogActionUseAPIKey, entry.Action)
+	require.Equal(t, uint(11), entry.EntityID)
+	require.Contains(t, entry.Message, "GET /api/v1/admin/user")
+}
+
+func TestAuthenticateAPIKeyRejectsUnknownKeyAndInactiveOwner(t *testing.T) {
+	setupLogger(t)
+
+	unknown := &AuthJWT{cfg: &config.Config{}, apiKeyRepo: &apikeymocks.APIKeyRepositoryMock{
+		FindActiveByKeyFunc: func(context.Context, string) (*models.APIKey, error) {
+			return nil, cerrors.NewNotFoundError("invalid api key")
+		},
+	}}
+	c, rec := newAPIKeyContext("ApiKey ath_unknown")
+	unknown.AuthenticateAPIKey(c)
+	require.True(t, c.IsAborted())
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	disabledOwner := &AuthJWT{
+		cfg: &config.Config{},
+		apiKeyRepo: &apikeymocks.APIKeyRepositoryMock{
+			FindActiveByKeyFunc: func(context.Context, string) (*models.APIKey, error) {
+				return &models.APIKey{ID: 11, UserID: 5}, nil
+			},
+		},
+		userRepo: &usermocks.UserRepositoryMock{
+			FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+				return &models.User{ID: id, Role: models.UserRoleAdmin, IsActive: false}, nil
+			},
+		},
+	}
+	c, rec = newAPIKeyContext("ApiKey ath_secret")
+	disabledOwner.AuthenticateAPIKey(c)
+	require.True(t, c.IsAborted())
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	_, err := utils.ValuesFromContext(c.Request.Context())
+	require.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package authjwt
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxCachedEntries bounds each of the cache's two LRUs. An entry is a user
+// row or a session row, so the ceiling is a few megabytes at most; past it
+// the least recently authorized user or session is simply looked up again.
+const maxCachedEntries = 10000
+
+// AuthCache keeps the rows the authorizer loads on every authenticated
+// request — the user and the session the access token is bound to — for
+// AUTH_CACHE_TTL, so a busy client does not cost two queries per call. With
+// the TTL at zero it is disabled and every lookup goes to the database.
+//
+// Anything that deactivates a user or revokes a session must invalidate it
+// AFTER its transaction commits; until then a concurrent request may read and
+// re-cache the old rows. The TTL bounds how stale any entry can be, which is
+// also the window in which another instance's cache may still accept a
+// revoked session.
+type AuthCache struct {
+	users    *expirable.LRU[uint, models.User]
+	sessions *expirable.LRU[uuid.UUID, models.RefreshToken]
+	lookups  *prometheus.CounterVec
+
+	// mu serialises invalidation with the epoch check in the put helpers.
+	mu sync.Mutex
+	// epoch advances on every invalidation. A lookup remembers the epoch it
+	// started in and only caches its result if none happened meanwhile, so a
+	// row read just before a revocation cannot be cached just after it.
+	epoch atomic.Uint64
+}
+
+// NewAuthCache builds the cache and registers its hit/miss counter on reg.
+func NewAuthCache(cfg *config.Config, reg prometheus.Registerer) *AuthCache {
+	c := &AuthCache{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_cache_lookups_total",
+			Help: "Authorizer cache lookups, by cache (user or session) and result (hit or miss).",
+		}, []string{"cache", "result"}),
+	}
+	reg.MustRegister(c.lookups)
+
+	if ttl := cfg.Auth.CacheTTL; ttl > 0 {
+		c.users = expirable.NewLRU[uint, models.User](maxCachedEntries, nil, ttl)
+		c.sessions = expirable.NewLRU[uuid.UUID, models.RefreshToken](maxCachedEntries, nil, ttl)
+	}
+	return c
+}
+
+// InvalidateUser drops userID and every cached session of theirs. Call it
+// after deactivating, deleting or otherwise changing the account, and after
+// revoking more than one of its sessions.
+func (c *AuthCache) InvalidateUser(userID uint) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch.Add(1)
+
+	c.users.Remove(userID)
+	for _, id := range c.sessions.Keys() {
+		if s, ok := c.sessions.Peek(id); ok && s.UserID == userID {
+			c.sessions.Remove(id)
+		}
+	}
+}
+
+// InvalidateSession drops one session, after it is revoked or reauthenticated.
+func (c *AuthCache) InvalidateSession(id uuid.UUID) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch.Add(1)
+
+	c.sessions.Remove(id)
+}
+
+// enabled reports whether entries are kept at all. A nil cache, as built by
+// tests that construct AuthJWT directly, behaves like a disabled one.
+func (c *AuthCache) enabled() bool {
+	return c != nil && c.users != nil
+}
+
+// user returns a copy of the cached user, so callers may not mutate the entry.
+func (c *AuthCache) user(id uint) (*models.User, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+	u, ok := c.users.Get(id)
+	c.count("user", ok)
+	if !ok {
+		return nil, false
+	}
+	return &u, true
+}
+
+// session returns a copy of the cached session if it has not expired since
+// it was cached.
+func (c *AuthCache) session(id uuid.UUID) (*models.RefreshToken, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+	s, ok := c.sessions.Get(id)
+	if ok && !s.ExpiresAt.After(time.Now()) {
+		c.sessions.Remove(id)
+		ok = false
+	}
+	c.count("session", ok)
+	if !ok {
+		return nil, false
+	}
+	return &s, true
+}
+
+// putUser caches u unless an invalidation happened since epoch.
+func (c *AuthCache) putUser(epoch uint64, u *models.User) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch.Load() == epoch {
+		c.users.Add(u.ID, *u)
+	}
+}
+
+// putSession caches s unless an invalidation happened since epoch.
+func (c *AuthCache) putSession(epoch uint64, s *models.RefreshToken) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch.Load() == epoch {
+		c.sessions.Add(s.ID, *s)
+	}
+}
+
+// currentEpoch is read before a database lookup whose result may be cached.
+func (c *AuthCache) currentEpoch() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.epoch.Load()
+}
+
+func (c *AuthCache) count(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.lookups.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,168 @@
+package authjwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/repository"
+)
+
+func newTestAuthCache(t *testing.T, ttl time.Duration) (*AuthCache, *prometheus.Registry) {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Auth.CacheTTL = ttl
+	reg := prometheus.NewRegistry()
+	return NewAuthCache(cfg, reg), reg
+}
+
+// cacheLookups returns the auth_cache_lookups_total value for one label pair.
+func cacheLookups(t *testing.T, reg *prometheus.Registry, cache, result string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != "auth_cache_lookups_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["cache"] == cache && labels["result"] == result {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestAuthCacheServesRepeatLookupsFromMemory(t *testing.T) {
+	cache, reg := newTestAuthCache(t, time.Minute)
+	sessionID := uuid.New()
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, IsActive: true}, nil
+		},
+	}
+	tokenRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		FindActiveByIDFunc: func(_ context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+			return &models.RefreshToken{ID: id, UserID: 5, ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	a := &AuthJWT{userRepo: userRepo, refreshTokenRepo: tokenRepo, cache: cache}
+
+	for range 3 {
+		user, err := a.findUser(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, uint(5), user.ID)
+
+		session, err := a.findActiveSession(context.Background(), sessionID)
+		require.NoError(t, err)
+		require.Equal(t, sessionID, session.ID)
+	}
+
+	require.Len(t, userRepo.FindByIDCalls(), 1)
+	require.Len(t, tokenRepo.FindActiveByIDCalls(), 1)
+	require.Equal(t, float64(1), cacheLookups(t, reg, "user", "miss"))
+	require.Equal(t, float64(2), cacheLookups(t, reg, "user", "hit"))
+	require.Equal(t, float64(1), cacheLookups(t, reg, "session", "miss"))
+	require.Equal(t, float64(2), cacheLookups(t, reg, "session", "hit"))
+}
+
+func TestAuthCacheReturnsCopies(t *testing.T) {
+	cache, _ := newTestAuthCache(t, time.Minute)
+	cache.putUser(cache.currentEpoch(), &models.User{ID: 5, IsActive: true})
+
+	user, ok := cache.user(5)
+	require.True(t, ok)
+	user.IsActive = false
+
+	again, ok := cache.user(5)
+	require.True(t, ok)
+	require.True(t, again.IsActive)
+}
+
+func TestAuthCacheInvalidateUserDropsTheirSessions(t *testing.T) {
+	cache, _ := newTestAuthCache(t, time.Minute)
+	expires := time.Now().Add(time.Hour)
+	own, other := uuid.New(), uuid.New()
+
+	epoch := cache.currentEpoch()
+	cache.putUser(epoch, &models.User{ID: 5})
+	cache.putSession(epoch, &models.RefreshToken{ID: own, UserID: 5, ExpiresAt: expires})
+	cache.putSession(epoch, &models.RefreshToken{ID: other, UserID: 6, ExpiresAt: expires})
+
+	cache.InvalidateUser(5)
+
+	_, ok := cache.user(5)
+	require.False(t, ok)
+	_, ok = cache.session(own)
+	require.False(t, ok)
+	_, ok = cache.session(other)
+	require.True(t, ok, "another user's session is untouched")
+}
+
+func TestAuthCacheInvalidateSession(t *testing.T) {
+	cache, _ := newTestAuthCache(t, time.Minute)
+	id := uuid.New()
+	cache.putSession(cache.currentEpoch(), &models.RefreshToken{ID: id, UserID: 5, ExpiresAt: time.Now().Add(time.Hour)})
+
+	cache.InvalidateSession(id)
+
+	_, ok := cache.session(id)
+	require.False(t, ok)
+}
+
+func TestAuthCacheDoesNotServeExpiredSession(t *testing.T) {
+	cache, _ := newTestAuthCache(t, time.Minute)
+	id := uuid.New()
+	cache.putSession(cache.currentEpoch(), &models.RefreshToken{ID: id, UserID: 5, ExpiresAt: time.Now().Add(-time.Second)})
+
+	_, ok := cache.session(id)
+	require.False(t, ok)
+}
+
+func TestAuthCacheSkipsPutAfterConcurrentInvalidation(t *testing.T) {
+	cache, _ := newTestAuthCache(t, time.Minute)
+
+	// A lookup starts, a revocation lands while it reads the database, and
+	// the now-stale row must not be cached.
+	epoch := cache.currentEpoch()
+	cache.InvalidateUser(5)
+	cache.putUser(epoch, &models.User{ID: 5, IsActive: true})
+
+	_, ok := cache.user(5)
+	require.False(t, ok)
+}
+
+func TestAuthCacheDisabledWithZeroTTL(t *testing.T) {
+	cache, reg := newTestAuthCache(t, 0)
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, IsActive: true}, nil
+		},
+	}
+	a := &AuthJWT{userRepo: userRepo, cache: cache}
+
+	for range 2 {
+		_, err := a.findUser(context.Background(), 5)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, userRepo.FindByIDCalls(), 2)
+	require.Zero(t, cacheLookups(t, reg, "user", "miss"))
+	cache.InvalidateUser(5) // no-op, must not panic
+}
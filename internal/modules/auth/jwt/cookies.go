@@ -0,0 +1,117 @@
+package authjwt
+
+import (
+	"crypto/rand"
+	"net/http"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . SessionCookies
+
+// Cookie and header names used in AUTH_MODE=cookie.
+const (
+	// AccessTokenCookie holds the access JWT; RequireAuth reads it when no
+	// Authorization header is sent.
+	AccessTokenCookie = "access_token"
+	// RefreshTokenCookie holds the opaque refresh token for /auth/refresh and
+	// /auth/logout.
+	RefreshTokenCookie = "refresh_token"
+	// CSRFCookie holds the double-submit CSRF token. It is the one auth cookie
+	// scripts can read, so the frontend can echo it in CSRFHeader.
+	CSRFCookie = "csrf_token"
+	// CSRFHeader must repeat CSRFCookie on unsafe cookie-authenticated requests.
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// SessionCookies moves session tokens between response bodies and cookies
+// for the handlers that open, rotate or end a session. In bearer mode every
+// method is a no-op, so handlers can call it unconditionally.
+type SessionCookies interface {
+	// SetSessionCookies stores the tokens in resp as cookies, together with a
+	// fresh CSRF token, and blanks them from resp so they never reach
+	// JavaScript.
+	SetSessionCookies(c *gin.Context, resp *dto.AuthResponse)
+	// ClearSessionCookies expires every auth cookie.
+	ClearSessionCookies(c *gin.Context)
+	// RefreshTokenFromCookie returns the refresh token cookie, if any.
+	RefreshTokenFromCookie(c *gin.Context) (string, bool)
+}
+
+// NewSessionCookies exposes a's cookie handling to the controllers.
+func NewSessionCookies(a *AuthJWT) SessionCookies {
+	return a
+}
+
+// tokenLookup is where gin-jwt looks for the access token. Cookie mode still
+// honours the header, so non-browser clients keep working alongside the web
+// admin; the header wins when both are sent.
+func tokenLookup(auth config.AuthConfig) string {
+	if auth.CookieMode() {
+		return "header: Authorization, cookie: " + AccessTokenCookie
+	}
+	return "header: Authorization"
+}
+
+// cookieSameSite maps AUTH_COOKIE_SAME_SITE to its http.SameSite value.
+func cookieSameSite(auth config.AuthConfig) http.SameSite {
+	switch auth.CookieSameSite {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// SetSessionCookies implements SessionCookies.
+func (a *AuthJWT) SetSessionCookies(c *gin.Context, resp *dto.AuthResponse) {
+	if !a.cfg.Auth.CookieMode() || resp == nil || resp.AccessToken == "" {
+		return
+	}
+
+	a.Middleware.SetCookie(c, resp.AccessToken)
+	a.Middleware.SetRefreshTokenCookie(c, resp.RefreshToken)
+	a.setCSRFCookie(c, newCSRFToken(), int(a.cfg.LongestSessionMaxAge().Seconds()))
+
+	resp.AccessToken = ""
+	resp.RefreshToken = ""
+}
+
+// ClearSessionCookies implements SessionCookies.
+func (a *AuthJWT) ClearSessionCookies(c *gin.Context) {
+	if !a.cfg.Auth.CookieMode() {
+		return
+	}
+
+	c.SetSameSite(a.Middleware.CookieSameSite)
+	c.SetCookie(AccessTokenCookie, "", -1, "/", a.cfg.Auth.CookieDomain, true, true)
+	c.SetCookie(RefreshTokenCookie, "", -1, "/", a.cfg.Auth.CookieDomain, true, true)
+	a.setCSRFCookie(c, "", -1)
+}
+
+// RefreshTokenFromCookie implements SessionCookies.
+func (a *AuthJWT) RefreshTokenFromCookie(c *gin.Context) (string, bool) {
+	if !a.cfg.Auth.CookieMode() {
+		return "", false
+	}
+	token, err := c.Cookie(RefreshTokenCookie)
+	return token, err == nil && token != ""
+}
+
+// setCSRFCookie writes the CSRF cookie. Unlike the token cookies it is not
+// HttpOnly: the double-submit check only works because the frontend can read
+// it, while a cross-site page cannot.
+func (a *AuthJWT) setCSRFCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(a.Middleware.CookieSameSite)
+	c.SetCookie(CSRFCookie, value, maxAge, "/", a.cfg.Auth.CookieDomain, true, false)
+}
+
+// newCSRFToken returns a random CSRF token.
+func newCSRFToken() string {
+	return rand.Text()
+}
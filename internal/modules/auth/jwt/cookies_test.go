@@ -0,0 +1,88 @@
+package authjwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+)
+
+func newCookieContext() (*gin.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/refresh", nil)
+	return c, rec
+}
+
+func responseCookies(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range rec.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func TestSetSessionCookiesMovesTokensOutOfBodyInCookieMode(t *testing.T) {
+	t.Setenv("AUTH_MODE", "cookie")
+	t.Setenv("AUTH_COOKIE_SAME_SITE", "lax")
+	a := newAuthJWT(t, &usermocks.UserRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{})
+	c, rec := newCookieContext()
+
+	resp := &dto.AuthResponse{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer"}
+	a.SetSessionCookies(c, resp)
+
+	require.Empty(t, resp.AccessToken, "the body must not carry tokens scripts could read")
+	require.Empty(t, resp.RefreshToken)
+
+	cookies := responseCookies(rec)
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie} {
+		require.Contains(t, cookies, name)
+		require.True(t, cookies[name].HttpOnly, name)
+		require.True(t, cookies[name].Secure, name)
+		require.Equal(t, http.SameSiteLaxMode, cookies[name].SameSite, name)
+	}
+	require.Equal(t, "access", cookies[AccessTokenCookie].Value)
+	require.Equal(t, "refresh", cookies[RefreshTokenCookie].Value)
+
+	require.Contains(t, cookies, CSRFCookie)
+	require.NotEmpty(t, cookies[CSRFCookie].Value)
+	require.False(t, cookies[CSRFCookie].HttpOnly, "the frontend must be able to read the CSRF token")
+}
+
+func TestClearSessionCookiesExpiresEveryAuthCookie(t *testing.T) {
+	t.Setenv("AUTH_MODE", "cookie")
+	a := newAuthJWT(t, &usermocks.UserRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{})
+	c, rec := newCookieContext()
+
+	a.ClearSessionCookies(c)
+
+	cookies := responseCookies(rec)
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie, CSRFCookie} {
+		require.Contains(t, cookies, name)
+		require.Empty(t, cookies[name].Value, name)
+		require.Negative(t, cookies[name].MaxAge, name)
+	}
+}
+
+func TestSessionCookiesAreInertInBearerMode(t *testing.T) {
+	a := newAuthJWT(t, &usermocks.UserRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{})
+	c, rec := newCookieContext()
+	c.Request.AddCookie(&http.Cookie{Name: RefreshTokenCookie, Value: "refresh"})
+
+	resp := &dto.AuthResponse{AccessToken: "access", RefreshToken: "refresh"}
+	a.SetSessionCookies(c, resp)
+	a.ClearSessionCookies(c)
+	_, ok := a.RefreshTokenFromCookie(c)
+
+	require.Equal(t, "access", resp.AccessToken)
+	require.Empty(t, rec.Result().Cookies())
+	require.False(t, ok, "bearer mode never reads tokens from cookies")
+}
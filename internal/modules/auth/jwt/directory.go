@@ -0,0 +1,349 @@
+package authjwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/models"
+	adminrolerepo "github.com/PhantomX7/athleton/internal/modules/admin_role/repository"
+	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	"github.com/PhantomX7/athleton/libs/ldap"
+	"github.com/PhantomX7/athleton/libs/transaction_manager"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CredentialValidator checks a username and password for the login endpoint.
+// validateCredentials asks each configured validator in turn; the first one
+// that does not answer ErrCredentialsNotHandled decides the login, and the
+// local password check runs when none claims it.
+type CredentialValidator interface {
+	ValidateCredentials(ctx context.Context, username, plain string) (*models.User, error)
+}
+
+// ErrCredentialsNotHandled is returned by a CredentialValidator that leaves
+// the login to the next validator.
+var ErrCredentialsNotHandled = errors.New("credentials not handled")
+
+// errNoMappedGroup refuses a directory user who is in none of the groups
+// LDAP_GROUP_ROLES maps to an admin role.
+var errNoMappedGroup = errors.New("directory user is in no mapped group")
+
+// errMappedRoleMissing refuses a directory user whose mapped groups all name
+// admin roles that do not exist.
+var errMappedRoleMissing = errors.New("no admin role named by the user's mapped groups exists")
+
+// DirectoryAuthenticator logs admins in with their directory credentials
+// (LDAP_*). The admin role comes from the user's directory groups, and the
+// models.User row is provisioned on first login and brought in line with the
+// directory on every later one.
+//
+// It leaves root and regular user accounts to the local password check, so
+// root stays available as a break-glass account while the directory is
+// unreachable. Every admin, including one created locally before the
+// directory was enabled, signs in through the directory.
+type DirectoryAuthenticator struct {
+	cfg               *config.Config
+	client            ldap.Client
+	userRepo          userrepo.UserRepository
+	adminRoleRepo     adminrolerepo.AdminRoleRepository
+	logRepository     logRepository.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	txManager         transaction_manager.TransactionManager
+	cache             *AuthCache
+}
+
+// NewDirectoryAuthenticator builds the directory login backend. It is always
+// constructed; with LDAP_ENABLED off it hands every login on.
+func NewDirectoryAuthenticator(
+	cfg *config.Config,
+	client ldap.Client,
+	userRepo userrepo.UserRepository,
+	adminRoleRepo adminrolerepo.AdminRoleRepository,
+	logRepository logRepository.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
+	txManager transaction_manager.TransactionManager,
+	cache *AuthCache,
+) *DirectoryAuthenticator {
+	return &DirectoryAuthenticator{
+		cfg:               cfg,
+		client:            client,
+		userRepo:          userRepo,
+		adminRoleRepo:     adminRoleRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		txManager:         txManager,
+		cache:             cache,
+	}
+}
+
+// ValidateCredentials implements CredentialValidator.
+func (d *DirectoryAuthenticator) ValidateCredentials(ctx context.Context, username, plain string) (*models.User, error) {
+	if !d.cfg.LDAP.Enabled {
+		return nil, ErrCredentialsNotHandled
+	}
+	username = strings.ToLower(strings.TrimSpace(username))
+
+	local, err := d.findLocal(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if local != nil && local.Role != models.UserRoleAdmin {
+		return nil, ErrCredentialsNotHandled
+	}
+
+	// A known admin that is switched off locally is refused without asking
+	// the directory: deactivation and lockout here outrank it.
+	if local != nil && (!local.IsActive || local.IsLocked(time.Now())) {
+		d.recordFailure(ctx, local)
+		return nil, errors.New("inactive account")
+	}
+
+	entry, err := d.client.Authenticate(ctx, username, plain)
+	if err != nil {
+		if !errors.Is(err, ldap.ErrInvalidCredentials) {
+			logger.Error("Directory login failed", zap.String("username", username), zap.Error(err))
+		}
+		d.recordFailure(ctx, local)
+		// A wrong directory password counts towards the lockout like a wrong
+		// local one. An unreachable directory is not the caller's fault.
+		if local != nil && errors.Is(err, ldap.ErrInvalidCredentials) {
+			d.lockout().registerFailure(ctx, local)
+		}
+		return nil, err
+	}
+
+	roles, err := d.adminRolesFor(ctx, entry.Groups)
+	if err != nil {
+		logger.Warn("Directory login refused", zap.String("dn", entry.DN), zap.Error(err))
+		d.recordFailure(ctx, local)
+		return nil, err
+	}
+
+	user, err := d.provision(ctx, entry, roles)
+	if err != nil {
+		logger.Warn("Directory account could not be provisioned", zap.String("dn", entry.DN), zap.Error(err))
+		d.recordFailure(ctx, local)
+		return nil, err
+	}
+	if local != nil {
+		d.lockout().clear(ctx, local)
+	}
+	return user, nil
+}
+
+// lockout returns the failed-login counter shared with the local password
+// check.
+func (d *DirectoryAuthenticator) lockout() lockout {
+	return lockout{cfg: d.cfg, userRepo: d.userRepo, logRepository: d.logRepository}
+}
+
+// findLocal returns the account the typed username or email names, or nil
+// when there is none.
+func (d *DirectoryAuthenticator) findLocal(ctx context.Context, username string) (*models.User, error) {
+	var user *models.User
+	var err error
+	if strings.Contains(username, "@") {
+		user, err = d.userRepo.FindByEmail(ctx, username)
+	} else {
+		user, err = d.userRepo.FindByUsername(ctx, username)
+	}
+	if errors.Is(err, cerrors.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// adminRolesFor returns the admin roles of every LDAP_GROUP_ROLES entry whose
+// group is among groups, in the order they are listed and without
+// duplicates. DNs compare case-insensitively. A mapping naming a role that
+// does not exist, say one renamed since, is logged as a configuration error
+// and skipped.
+func (d *DirectoryAuthenticator) adminRolesFor(ctx context.Context, groups []string) ([]models.AdminRole, error) {
+	mappings, err := d.cfg.LDAP.GroupRoleMappings()
+	if err != nil {
+		return nil, err
+	}
+	var roles []models.AdminRole
+	matched := false
+	for _, m := range mappings {
+		if !slices.ContainsFunc(groups, func(g string) bool { return strings.EqualFold(strings.TrimSpace(g), m.GroupDN) }) {
+			continue
+		}
+		matched = true
+		role, err := d.adminRoleRepo.FindByName(ctx, m.AdminRole)
+		if errors.Is(err, cerrors.ErrNotFound) {
+			logger.Error("LDAP_GROUP_ROLES maps a group to an admin role that does not exist",
+				zap.String("group", m.GroupDN), zap.String("admin_role", m.AdminRole))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(roles, func(r models.AdminRole) bool { return r.ID == role.ID }) {
+			roles = append(roles, *role)
+		}
+	}
+	if !matched {
+		return nil, errNoMappedGroup
+	}
+	if len(roles) == 0 {
+		return nil, errMappedRoleMissing
+	}
+	return roles, nil
+}
+
+// provision creates the admin account for entry on its first login, or
+// brings an existing one in line with the directory: name, email, admin roles
+// and provider. A regular or root account holding the same username or
+// email is never taken over.
+func (d *DirectoryAuthenticator) provision(ctx context.Context, entry *ldap.Entry, roles []models.AdminRole) (*models.User, error) {
+	username := strings.ToLower(entry.Username)
+	email := strings.ToLower(entry.Email)
+	if username == "" || email == "" {
+		return nil, errors.New("directory entry has no username or email")
+	}
+	name := entry.Name
+	if name == "" {
+		name = username
+	}
+
+	roleIDs := make([]uint, len(roles))
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleIDs[i] = role.ID
+		roleNames[i] = role.Name
+	}
+
+	var user *models.User
+	var action models.LogAction
+	err := d.txManager.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		existing, err := d.userRepo.FindByUsername(ctx, username)
+		if err != nil && !errors.Is(err, cerrors.ErrNotFound) {
+			return err
+		}
+
+		if existing == nil {
+			if err := d.checkEmailFree(ctx, email, 0); err != nil {
+				return err
+			}
+			now := time.Now()
+			user = &models.User{
+				Username:        username,
+				Name:            name,
+				Email:           email,
+				IsActive:        true,
+				Role:            models.UserRoleAdmin,
+				AuthProvider:    models.UserAuthProviderLDAP,
+				EmailVerifiedAt: &now,
+			}
+			action = models.LogActionCreate
+			if err := d.userRepo.Create(ctx, user); err != nil {
+				return err
+			}
+			user.AdminRoles = roles
+			return d.userRepo.SetAdminRoles(ctx, user.ID, roleIDs)
+		}
+
+		user, err = d.userRepo.FindByIDForUpdate(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		if user.Role != models.UserRoleAdmin {
+			return fmt.Errorf("username %s belongs to a %s account", username, user.Role)
+		}
+		if !user.IsActive {
+			return errors.New("inactive account")
+		}
+		if user.Email != email {
+			if err := d.checkEmailFree(ctx, email, user.ID); err != nil {
+				return err
+			}
+		}
+
+		// The directory owns the account's roles: it holds exactly the
+		// mapped ones, whatever was assigned here in between.
+		if user.Name == name && user.Email == email && user.IsDirectoryAccount() &&
+			sameRoles(user.AdminRoleIDs(), roleIDs) {
+			return nil
+		}
+		user.Name = name
+		user.Email = email
+		user.AuthProvider = models.UserAuthProviderLDAP
+		user.Password = ""
+		action = models.LogActionUpdate
+		if err := d.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		user.AdminRoles = roles
+		return d.userRepo.SetAdminRoles(ctx, user.ID, roleIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case models.LogActionCreate:
+		d.audit(ctx, user, action, fmt.Sprintf("%s was provisioned from the directory as %s", user.Name, strings.Join(roleNames, ", ")))
+	case models.LogActionUpdate:
+		// The role or name may have changed: drop what the authorizer cached.
+		d.cache.InvalidateUser(user.ID)
+		d.audit(ctx, user, action, fmt.Sprintf("%s was updated from the directory as %s", user.Name, strings.Join(roleNames, ", ")))
+	}
+	return user, nil
+}
+
+// sameRoles reports whether a and b hold the same role IDs, in any order.
+func sameRoles(a, b []uint) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
+
+// checkEmailFree fails when email belongs to an account other than ownerID.
+func (d *DirectoryAuthenticator) checkEmailFree(ctx context.Context, email string, ownerID uint) error {
+	other, err := d.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, cerrors.ErrNotFound) {
+		return err
+	}
+	if other != nil && other.ID != ownerID {
+		return fmt.Errorf("email %s belongs to another account", email)
+	}
+	return nil
+}
+
+// audit records a provisioning change, attributed to the account itself: the
+// login has no authenticated user yet.
+func (d *DirectoryAuthenticator) audit(ctx context.Context, user *models.User, action models.LogAction, message string) {
+	auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+		UserID:       user.ID,
+		UserName:     user.Name,
+		Role:         string(user.Role),
+		AdminRoleIDs: user.AdminRoleIDs(),
+		RequestID:    utils.GetRequestIDFromContext(ctx),
+	})
+	audit.Record(auditCtx, d.logRepository, audit.Entry{
+		Action:     action,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   user.ID,
+		Message:    message,
+	})
+}
+
+// recordFailure puts a refused login on a known account's security timeline.
+func (d *DirectoryAuthenticator) recordFailure(ctx context.Context, user *models.User) {
+	if user == nil {
+		return
+	}
+	audit.RecordSecurityEvent(ctx, d.securityEventRepo, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+}
@@ -0,0 +1,372 @@
+package authjwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/models"
+	adminrolemocks "github.com/PhantomX7/athleton/internal/modules/admin_role/repository/mocks"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	"github.com/PhantomX7/athleton/libs/ldap"
+	ldapmocks "github.com/PhantomX7/athleton/libs/ldap/mocks"
+	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+)
+
+const (
+	testOpsGroup     = "cn=ops,ou=groups,dc=example,dc=com"
+	testEditorsGroup = "cn=editors,ou=groups,dc=example,dc=com"
+)
+
+func directoryConfig() *config.Config {
+	return &config.Config{LDAP: config.LDAPConfig{
+		Enabled:    true,
+		GroupRoles: testOpsGroup + "=>Operations;" + testEditorsGroup + "=>Editor",
+	}}
+}
+
+func aliceEntry(groups ...string) *ldap.Entry {
+	return &ldap.Entry{
+		DN:       "uid=alice,ou=people,dc=example,dc=com",
+		Username: "Alice",
+		Email:    "Alice@Example.com",
+		Name:     "Alice Admin",
+		Groups:   groups,
+	}
+}
+
+func newDirectoryAuthenticator(cfg *config.Config, client ldap.Client, userRepo *usermocks.UserRepositoryMock) *DirectoryAuthenticator {
+	roles := map[string]uint{"Operations": 7, "Editor": 8}
+	adminRoleRepo := &adminrolemocks.AdminRoleRepositoryMock{
+		FindByNameFunc: func(_ context.Context, name string) (*models.AdminRole, error) {
+			id, ok := roles[name]
+			if !ok {
+				return nil, cerrors.NewNotFoundError("admin role not found")
+			}
+			return &models.AdminRole{ID: id, Name: name}, nil
+		},
+	}
+	tx := &txmocks.TransactionManagerMock{
+		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	return NewDirectoryAuthenticator(cfg, client, userRepo, adminRoleRepo, logRepo, stubSecurityEventRepo(), tx, nil)
+}
+
+func notFound(context.Context, string) (*models.User, error) {
+	return nil, cerrors.NewNotFoundError("user not found")
+}
+
+func TestDirectoryAuthenticatorProvisionsAdminOnFirstLogin(t *testing.T) {
+	setupLogger(t)
+
+	client := &ldapmocks.ClientMock{
+		AuthenticateFunc: func(_ context.Context, username, password string) (*ldap.Entry, error) {
+			require.Equal(t, "alice", username)
+			require.Equal(t, "directory-secret", password)
+			// Every mapped group adds its role, in the order they are listed.
+			return aliceEntry(testEditorsGroup, "CN=Ops,OU=Groups,DC=Example,DC=Com"), nil
+		},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: notFound,
+		FindByEmailFunc:    notFound,
+		CreateFunc: func(_ context.Context, u *models.User) error {
+			u.ID = 42
+			return nil
+		},
+		SetAdminRolesFunc: func(context.Context, uint, []uint) error { return nil },
+	}
+	d := newDirectoryAuthenticator(directoryConfig(), client, userRepo)
+
+	user, err := d.ValidateCredentials(context.Background(), " Alice ", "directory-secret")
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, userRepo.CreateCalls(), 1)
+	require.Equal(t, uint(42), user.ID)
+	require.Equal(t, "alice", user.Username)
+	require.Equal(t, "alice@example.com", user.Email)
+	require.Equal(t, "Alice Admin", user.Name)
+	require.Equal(t, models.UserRoleAdmin, user.Role)
+	require.Equal(t, []uint{7, 8}, user.AdminRoleIDs())
+	require.Len(t, userRepo.SetAdminRolesCalls(), 1)
+	require.Equal(t, uint(42), userRepo.SetAdminRolesCalls()[0].ID)
+	require.Equal(t, []uint{7, 8}, userRepo.SetAdminRolesCalls()[0].RoleIDs)
+	require.Equal(t, models.UserAuthProviderLDAP, user.AuthProvider)
+	require.Empty(t, user.Password)
+	require.True(t, user.IsActive)
+	require.True(t, user.IsEmailVerified())
+	require.False(t, user.MustChangePassword(0, time.Now()), "the directory owns the password")
+}
+
+func TestDirectoryAuthenticatorSyncsExistingDirectoryAdmin(t *testing.T) {
+	setupLogger(t)
+
+	oldRole := uint(7)
+	stored := &models.User{
+		ID: 42, Username: "alice", Name: "Alice", Email: "old@example.com",
+		IsActive: true, Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: oldRole}},
+		AuthProvider: models.UserAuthProviderLDAP,
+	}
+	client := &ldapmocks.ClientMock{
+		AuthenticateFunc: func(context.Context, string, string) (*ldap.Entry, error) {
+			return aliceEntry(testEditorsGroup), nil
+		},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			u := *stored
+			return &u, nil
+		},
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) {
+			u := *stored
+			return &u, nil
+		},
+		FindByEmailFunc:   notFound,
+		UpdateFunc:        func(context.Context, *models.User) error { return nil },
+		SetAdminRolesFunc: func(context.Context, uint, []uint) error { return nil },
+	}
+	d := newDirectoryAuthenticator(directoryConfig(), client, userRepo)
+
+	user, err := d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, userRepo.UpdateCalls(), 1)
+	require.Empty(t, userRepo.CreateCalls())
+	require.Equal(t, []uint{8}, user.AdminRoleIDs(), "moved to the role of the user's current group")
+	require.Len(t, userRepo.SetAdminRolesCalls(), 1)
+	require.Equal(t, []uint{8}, userRepo.SetAdminRolesCalls()[0].RoleIDs)
+	require.Equal(t, "alice@example.com", user.Email)
+	require.Equal(t, "Alice Admin", user.Name)
+}
+
+func TestDirectoryAuthenticatorMapsEveryMatchedGroup(t *testing.T) {
+	setupLogger(t)
+
+	const supportGroup = "cn=support,ou=groups,dc=example,dc=com"
+	cfg := directoryConfig()
+	// Two groups mapping to one role give it once.
+	cfg.LDAP.GroupRoles += ";" + supportGroup + "=>Operations"
+
+	cases := map[string]struct {
+		held    []models.AdminRole
+		groups  []string
+		want    []uint
+		updated bool
+	}{
+		"a second group adds its role": {
+			held:    []models.AdminRole{{ID: 7}},
+			groups:  []string{testOpsGroup, testEditorsGroup},
+			want:    []uint{7, 8},
+			updated: true,
+		},
+		"the same roles in another order": {
+			held:   []models.AdminRole{{ID: 8}, {ID: 7}},
+			groups: []string{testEditorsGroup, supportGroup, testOpsGroup},
+			want:   []uint{8, 7},
+		},
+		"a role held beyond the groups is dropped": {
+			held:    []models.AdminRole{{ID: 7}, {ID: 8}},
+			groups:  []string{supportGroup},
+			want:    []uint{7},
+			updated: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stored := &models.User{
+				ID: 42, Username: "alice", Name: "Alice Admin", Email: "alice@example.com",
+				IsActive: true, Role: models.UserRoleAdmin, AdminRoles: tc.held,
+				AuthProvider: models.UserAuthProviderLDAP,
+			}
+			client := &ldapmocks.ClientMock{
+				AuthenticateFunc: func(context.Context, string, string) (*ldap.Entry, error) {
+					return aliceEntry(tc.groups...), nil
+				},
+			}
+			userRepo := &usermocks.UserRepositoryMock{
+				FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+					u := *stored
+					return &u, nil
+				},
+				FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) {
+					u := *stored
+					return &u, nil
+				},
+				UpdateFunc:        func(context.Context, *models.User) error { return nil },
+				SetAdminRolesFunc: func(context.Context, uint, []uint) error { return nil },
+			}
+			d := newDirectoryAuthenticator(cfg, client, userRepo)
+
+			user, err := d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+			require.NoError(t, err)
+			require.NoError(t, audit.Drain(context.Background()))
+
+			require.Equal(t, tc.want, user.AdminRoleIDs())
+			if !tc.updated {
+				require.Empty(t, userRepo.UpdateCalls())
+				require.Empty(t, userRepo.SetAdminRolesCalls())
+				return
+			}
+			require.Len(t, userRepo.SetAdminRolesCalls(), 1)
+			require.Equal(t, tc.want, userRepo.SetAdminRolesCalls()[0].RoleIDs)
+		})
+	}
+}
+
+func TestDirectoryAuthenticatorSkipsMissingMappedRole(t *testing.T) {
+	setupLogger(t)
+
+	cfg := directoryConfig()
+	// "Auditor" was renamed or deleted after LDAP_GROUP_ROLES was written.
+	cfg.LDAP.GroupRoles = "cn=auditors,ou=groups,dc=example,dc=com=>Auditor;" + cfg.LDAP.GroupRoles
+	client := &ldapmocks.ClientMock{
+		AuthenticateFunc: func(context.Context, string, string) (*ldap.Entry, error) {
+			return aliceEntry("cn=auditors,ou=groups,dc=example,dc=com", testEditorsGroup), nil
+		},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: notFound,
+		FindByEmailFunc:    notFound,
+		CreateFunc: func(_ context.Context, u *models.User) error {
+			u.ID = 42
+			return nil
+		},
+		SetAdminRolesFunc: func(context.Context, uint, []uint) error { return nil },
+	}
+	d := newDirectoryAuthenticator(cfg, client, userRepo)
+
+	user, err := d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+	require.Equal(t, []uint{8}, user.AdminRoleIDs(), "the next matching group still applies")
+
+	// With no other matching group the login is refused as a missing role,
+	// not as a user outside the mapped groups.
+	client.AuthenticateFunc = func(context.Context, string, string) (*ldap.Entry, error) {
+		return aliceEntry("cn=auditors,ou=groups,dc=example,dc=com"), nil
+	}
+	_, err = d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+	require.ErrorIs(t, err, errMappedRoleMissing)
+}
+
+func TestDirectoryAuthenticatorLeavesRootAndUsersToLocalPasswords(t *testing.T) {
+	for _, role := range []models.UserRole{models.UserRoleRoot, models.UserRoleUser} {
+		client := &ldapmocks.ClientMock{}
+		userRepo := &usermocks.UserRepositoryMock{
+			FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+				return &models.User{ID: 1, Role: role, IsActive: true}, nil
+			},
+		}
+		d := newDirectoryAuthenticator(directoryConfig(), client, userRepo)
+
+		_, err := d.ValidateCredentials(context.Background(), "root", "local-secret")
+		require.ErrorIs(t, err, ErrCredentialsNotHandled, role)
+		require.Empty(t, client.AuthenticateCalls(), "the directory is not asked")
+	}
+}
+
+func TestDirectoryAuthenticatorHandsOnWhileDisabled(t *testing.T) {
+	d := newDirectoryAuthenticator(&config.Config{}, &ldapmocks.ClientMock{}, &usermocks.UserRepositoryMock{})
+
+	_, err := d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+	require.ErrorIs(t, err, ErrCredentialsNotHandled)
+}
+
+func TestDirectoryAuthenticatorRefusals(t *testing.T) {
+	setupLogger(t)
+
+	cases := map[string]struct {
+		entry     *ldap.Entry
+		authErr   error
+		local     *models.User
+		emailUser *models.User
+	}{
+		"wrong directory password": {authErr: ldap.ErrInvalidCredentials},
+		"no mapped group":          {entry: aliceEntry("cn=staff,ou=groups,dc=example,dc=com")},
+		"email held by a customer": {
+			entry:     aliceEntry(testOpsGroup),
+			emailUser: &models.User{ID: 9, Role: models.UserRoleUser},
+		},
+		"locally deactivated admin": {
+			entry: aliceEntry(testOpsGroup),
+			local: &models.User{ID: 42, Role: models.UserRoleAdmin, IsActive: false},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &ldapmocks.ClientMock{
+				AuthenticateFunc: func(context.Context, string, string) (*ldap.Entry, error) {
+					return tc.entry, tc.authErr
+				},
+			}
+			userRepo := &usermocks.UserRepositoryMock{
+				FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+					if tc.local == nil {
+						return nil, cerrors.NewNotFoundError("user not found")
+					}
+					return tc.local, nil
+				},
+				FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+					if tc.emailUser == nil {
+						return nil, cerrors.NewNotFoundError("user not found")
+					}
+					return tc.emailUser, nil
+				},
+			}
+			d := newDirectoryAuthenticator(directoryConfig(), client, userRepo)
+
+			user, err := d.ValidateCredentials(context.Background(), "alice", "directory-secret")
+			require.Error(t, err)
+			require.NotErrorIs(t, err, ErrCredentialsNotHandled)
+			require.Nil(t, user)
+			require.Empty(t, userRepo.CreateCalls())
+		})
+	}
+}
+
+func TestValidateCredentialsFallsBackToLocalPasswordWhenNotHandled(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 1, Role: models.UserRoleRoot, IsActive: true, Password: string(hashed)}, nil
+		},
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
+	}
+	directory := newDirectoryAuthenticator(directoryConfig(), &ldapmocks.ClientMock{}, userRepo)
+	a := &AuthJWT{cfg: directoryConfig(), userRepo: userRepo, securityEventRepo: stubSecurityEventRepo(), validators: []CredentialValidator{directory}}
+
+	user, err := a.validateCredentials(context.Background(), "root", "secret123")
+	require.NoError(t, err)
+	require.Equal(t, uint(1), user.ID)
+}
+
+func TestValidateLocalCredentialsRefusesDirectoryAccount(t *testing.T) {
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 42, Role: models.UserRoleAdmin, IsActive: true, AuthProvider: models.UserAuthProviderLDAP}, nil
+		},
+	}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: userRepo, securityEventRepo: stubSecurityEventRepo()}
+
+	_, err := a.validateCredentials(context.Background(), "alice", "")
+	require.Error(t, err, "with the directory off, an empty local hash must not admit anyone")
+}
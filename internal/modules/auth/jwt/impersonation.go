@@ -0,0 +1,81 @@
+package authjwt
+
+import (
+	"context"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Impersonate opens a session in which actor acts as target. The session is a
+// refresh-token row tagged with the actor's ID whose token value is never
+// handed out, so it cannot be refreshed: it lives for AUTH_IMPERSONATION_TTL
+// at most and ends early when revoked. It does not count toward the target's
+// session cap and is hidden from the target's session list.
+func (a *AuthJWT) Impersonate(ctx context.Context, actor, target *models.User) (*dto.ImpersonationResponse, error) {
+	sessionID := uuid.New()
+	client := utils.GetClientInfoFromContext(ctx)
+	now := time.Now()
+
+	err := a.refreshTokenRepo.Create(ctx, &models.RefreshToken{
+		ID:             sessionID,
+		UserID:         target.ID,
+		Token:          newRefreshTokenValue(),
+		ExpiresAt:      now.Add(a.cfg.Auth.ImpersonationTTL),
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IP,
+		LastUsedAt:     now,
+		LoggedInAt:     now,
+		ImpersonatorID: &actor.ID,
+	})
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to create impersonation session", err)
+	}
+
+	accessToken, expire, err := a.generateAccessToken(&authSubject{User: target, SessionID: sessionID, Actor: actor})
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to generate access token", err)
+	}
+
+	return &dto.ImpersonationResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   expire,
+		User:        target.ToResponse(),
+	}, nil
+}
+
+// resolveImpersonator checks that the access token's actor claim agrees with
+// the session it points at and, for an impersonation session, records the
+// real actor in values. Either both carry the same actor or neither does: a
+// token minted for an ordinary session can never pick up an actor, and an
+// impersonation token stripped of its claim cannot pass as the target's own.
+// The actor must still be active, so deactivating a support account ends
+// every impersonation it started.
+func (a *AuthJWT) resolveImpersonator(ctx context.Context, session *models.RefreshToken, actor *models.User, values *utils.ContextValues) bool {
+	if session.ImpersonatorID == nil && actor == nil {
+		return true
+	}
+	if session.ImpersonatorID == nil || actor == nil || *session.ImpersonatorID != actor.ID {
+		logger.Warn("Impersonation claim does not match session",
+			zap.String("session_id", session.ID.String()),
+			zap.Uint("user_id", session.UserID))
+		return false
+	}
+
+	dbActor, err := a.findUser(ctx, actor.ID)
+	if err != nil || !dbActor.IsActive {
+		return false
+	}
+
+	values.ImpersonatorID = dbActor.ID
+	values.ImpersonatorName = dbActor.Name
+	return true
+}
@@ -0,0 +1,112 @@
+package authjwt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+// authorizeImpersonation runs the authorizer for member 9 against a session
+// whose impersonator is sessionActor, presenting a token whose act claim
+// names claimActor (0 for none). actorActive controls the actor's account.
+func authorizeImpersonation(t *testing.T, sessionActor, claimActor uint, actorActive bool) (bool, *gin.Context) {
+	t.Helper()
+	setupLogger(t)
+
+	users := map[uint]*models.User{
+		1: {ID: 1, Name: "Root", Role: models.UserRoleRoot, IsActive: actorActive},
+		9: {ID: 9, Name: "Member", Role: models.UserRoleUser, IsActive: true},
+	}
+	repo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return users[id], nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		FindActiveByIDFunc: func(_ context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+			session := &models.RefreshToken{ID: id, UserID: 9}
+			if sessionActor != 0 {
+				session.ImpersonatorID = &sessionActor
+			}
+			return session, nil
+		},
+	}
+
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
+
+	subj := &authSubject{User: &models.User{ID: 9}, SessionID: uuid.New()}
+	if claimActor != 0 {
+		subj.Actor = &models.User{ID: claimActor}
+	}
+	return a.authorizer(c, subj), c
+}
+
+func TestAuthorizerAttributesImpersonationSessionToActor(t *testing.T) {
+	allowed, c := authorizeImpersonation(t, 1, 1, true)
+
+	require.True(t, allowed)
+	values, err := utils.ValuesFromContext(c.Request.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint(9), values.UserID)
+	require.Equal(t, uint(1), values.ImpersonatorID)
+	require.Equal(t, "Root", values.ImpersonatorName)
+}
+
+func TestAuthorizerRejectsMismatchedImpersonation(t *testing.T) {
+	for name, tc := range map[string]struct{ sessionActor, claimActor uint }{
+		"claim without impersonation session": {0, 1},
+		"impersonation session without claim": {1, 0},
+		"claim names another actor":           {1, 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			allowed, _ := authorizeImpersonation(t, tc.sessionActor, tc.claimActor, true)
+			require.False(t, allowed)
+		})
+	}
+}
+
+func TestAuthorizerRejectsImpersonationByDeactivatedActor(t *testing.T) {
+	allowed, _ := authorizeImpersonation(t, 1, 1, false)
+	require.False(t, allowed)
+}
+
+func TestActorClaimSurvivesTokenRoundTrip(t *testing.T) {
+	a := &AuthJWT{cfg: &config.Config{}}
+	claims := a.payloadFunc(&authSubject{
+		User:      &models.User{ID: 9, Role: models.UserRoleUser},
+		SessionID: uuid.New(),
+		Actor:     &models.User{ID: 1},
+	})
+
+	// Decode the claims the way a verified token presents them: numbers come
+	// back as float64 and the actor as a generic map.
+	raw, err := json.Marshal(claims)
+	require.NoError(t, err)
+	var decoded jwt.MapClaims
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("JWT_PAYLOAD", decoded)
+	subj, ok := a.identityHandler(c).(*authSubject)
+
+	require.True(t, ok)
+	require.Equal(t, uint(9), subj.User.ID)
+	require.NotNil(t, subj.Actor)
+	require.Equal(t, uint(1), subj.Actor.ID)
+}
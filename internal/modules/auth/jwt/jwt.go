@@ -3,23 +3,27 @@ package authjwt
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/PhantomX7/athleton/internal/audit"
 	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/models"
+	apikeyrepo "github.com/PhantomX7/athleton/internal/modules/api_key/repository"
 	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
 	rtokenrepo "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
 	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	usertokenrepo "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
 	"github.com/PhantomX7/athleton/libs/transaction_manager"
 	"github.com/PhantomX7/athleton/pkg/config"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/password"
 	"github.com/PhantomX7/athleton/pkg/response"
 	"github.com/PhantomX7/athleton/pkg/utils"
 
@@ -29,7 +33,6 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -37,37 +40,32 @@ const (
 	IdentityKey = "user_id"
 	// RoleKey stores the authenticated role in claims and Gin context.
 	RoleKey = "role"
-	// AdminRoleIDKey stores the authenticated admin-role ID in claims and Gin context.
-	AdminRoleIDKey = "admin_role_id"
+	// AdminRoleIDsKey stores the authenticated user's admin-role IDs in
+	// claims and Gin context.
+	AdminRoleIDsKey = "admin_role_ids"
+	// legacyAdminRoleIDKey is the single admin-role claim of tokens issued
+	// before users could hold several roles. It is still read so those
+	// tokens keep their claim until they expire.
+	legacyAdminRoleIDKey = "admin_role_id"
 	// SessionIDKey stores the refresh-token session identifier in claims.
 	SessionIDKey = "jti"
+	// ActorKey carries the real actor of an impersonation token, as an RFC
+	// 8693 actor claim: {"sub": <actor user ID>}.
+	ActorKey = "act"
 
 	// AuthUserKey is the gin-context key under which the authorizer stores the
 	// freshly loaded *models.User for downstream middleware (e.g. the
 	// must-change-default-password gate) — saving them a second DB lookup.
 	AuthUserKey = "auth_user"
+	// AuthSessionKey is the gin-context key under which the authorizer stores
+	// the *models.RefreshToken session the access token is bound to, for
+	// middleware that inspects it (RequireRecentAuth).
+	AuthSessionKey = "auth_session"
 
 	authRefreshTokenKey = "auth_refresh_token" // #nosec G101 -- identifier name, not a credential
-
-	// dummyBcryptCost matches the production bcrypt cost (see service.BcryptCost)
-	// so the timing-equalization path costs the same as a real comparison.
-	dummyBcryptCost = 12
+	authChallengeKey    = "auth_two_factor_challenge"
 )
 
-// dummyHash is a real bcrypt hash generated lazily on first use. It is fed to
-// bcrypt.CompareHashAndPassword on the username-not-found / inactive-user
-// paths so the response time matches a real wrong-password comparison and
-// account existence is not leaked via timing. The previous string literal was
-// not a valid bcrypt hash, so CompareHashAndPassword returned ErrHashTooShort
-// immediately and did no work.
-var dummyHash = sync.OnceValue(func() []byte {
-	h, err := bcrypt.GenerateFromPassword([]byte("athleton-timing-dummy-password"), dummyBcryptCost)
-	if err != nil {
-		panic(fmt.Sprintf("authjwt: failed to generate dummy bcrypt hash: %v", err))
-	}
-	return h
-})
-
 // authSubject is the value passed between Authenticator → payloadFunc and
 // identityHandler → authorizer. It binds the access JWT to a specific
 // refresh-token session: revoking that refresh token also kills the access
@@ -75,6 +73,8 @@ var dummyHash = sync.OnceValue(func() []byte {
 type authSubject struct {
 	User      *models.User
 	SessionID uuid.UUID
+	// Actor is the user impersonating User, or nil for their own session.
+	Actor *models.User
 }
 
 // errRefreshTokenReuse is an internal sentinel used to unwind the rotation
@@ -83,14 +83,29 @@ type authSubject struct {
 // error instead.
 var errRefreshTokenReuse = errors.New("refresh token reuse detected")
 
+// ErrEmailNotVerified is returned from login when AUTH_REQUIRE_EMAIL_VERIFICATION
+// is on and the account has not confirmed its address. Unlike the generic
+// credential failure it is surfaced verbatim: it is only reached after the
+// password matched, so it tells the caller nothing they did not already know.
+var ErrEmailNotVerified = errors.New("email address is not verified")
+
 // AuthJWT bundles the gin-jwt middleware with the repositories it depends on.
 type AuthJWT struct {
-	Middleware       *ginjwt.GinJWTMiddleware
-	cfg              *config.Config
-	userRepo         userrepo.UserRepository
-	refreshTokenRepo rtokenrepo.RefreshTokenRepository
-	logRepository    logRepository.LogRepository
-	txManager        transaction_manager.TransactionManager
+	Middleware        *ginjwt.GinJWTMiddleware
+	keys              *keySet
+	cfg               *config.Config
+	userRepo          userrepo.UserRepository
+	refreshTokenRepo  rtokenrepo.RefreshTokenRepository
+	userTokenRepo     usertokenrepo.UserTokenRepository
+	apiKeyRepo        apikeyrepo.APIKeyRepository
+	logRepository     logRepository.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	txManager         transaction_manager.TransactionManager
+	cache             *AuthCache
+	// validators are asked before the local password check; see
+	// CredentialValidator.
+	validators []CredentialValidator
+	directory  *DirectoryAuthenticator
 }
 
 // NewAuthJWT constructs the JWT authentication middleware and its helpers.
@@ -98,42 +113,66 @@ func NewAuthJWT(
 	cfg *config.Config,
 	userRepo userrepo.UserRepository,
 	refreshTokenRepo rtokenrepo.RefreshTokenRepository,
+	userTokenRepo usertokenrepo.UserTokenRepository,
+	apiKeyRepo apikeyrepo.APIKeyRepository,
 	logRepository logRepository.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
 	txManager transaction_manager.TransactionManager,
+	cache *AuthCache,
+	directory *DirectoryAuthenticator,
 ) (*AuthJWT, error) {
-	// Force dummy-hash generation now so a bcrypt failure surfaces as a boot
-	// error instead of a panic on the first login attempt.
-	_ = dummyHash()
+	// Generate the dummy hash now so a failure surfaces as a boot error
+	// instead of a panic on the first login attempt.
+	_ = password.Dummy(cfg.Auth.PasswordHashParams())
 
-	a := &AuthJWT{
-		cfg:              cfg,
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		logRepository:    logRepository,
-		txManager:        txManager,
+	keys, err := loadKeySet(cfg.JWT)
+	if err != nil {
+		return nil, err
 	}
 
+	a := &AuthJWT{
+		keys:              keys,
+		cfg:               cfg,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		userTokenRepo:     userTokenRepo,
+		apiKeyRepo:        apiKeyRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		txManager:         txManager,
+		cache:             cache,
+		directory:         directory,
+	}
+	if directory != nil {
+		a.validators = append(a.validators, directory)
+	}
+
+	// gin-jwt only verifies tokens here. It can sign with just one HMAC or
+	// RSA key and never sets a kid header, so minting (generateAccessToken)
+	// and the login endpoint (LoginHandler) are ours; KeyFunc lets it accept
+	// any key in the set.
 	middleware, err := ginjwt.New(&ginjwt.GinJWTMiddleware{
-		Realm:            cfg.App.Name,
-		Key:              []byte(cfg.JWT.Secret),
-		Timeout:          cfg.JWT.Expiration,
-		MaxRefresh:       cfg.JWT.RefreshExpiration,
-		IdentityKey:      IdentityKey,
-		SigningAlgorithm: "HS256",
-		TokenLookup:      "header: Authorization",
-		TokenHeadName:    "Bearer",
-		TimeFunc:         time.Now,
-		SendCookie:       false,
-		SecureCookie:     cfg.IsProduction(),
-		CookieHTTPOnly:   true,
-		CookieSameSite:   http.SameSiteStrictMode,
-
-		PayloadFunc:     a.payloadFunc,
+		Realm:         cfg.App.Name,
+		KeyFunc:       keys.keyFunc,
+		Timeout:       cfg.JWT.Expiration,
+		MaxRefresh:    cfg.JWT.RefreshExpiration,
+		IdentityKey:   IdentityKey,
+		TokenLookup:   tokenLookup(cfg.Auth),
+		TokenHeadName: "Bearer",
+		TimeFunc:      time.Now,
+		// Cookies are only written through SessionCookies, in cookie mode.
+		SendCookie:             cfg.Auth.CookieMode(),
+		SecureCookie:           true,
+		CookieHTTPOnly:         true,
+		CookieSameSite:         cookieSameSite(cfg.Auth),
+		CookieDomain:           cfg.Auth.CookieDomain,
+		CookieName:             AccessTokenCookie,
+		RefreshTokenCookieName: RefreshTokenCookie,
+		RefreshTokenTimeout:    cfg.LongestSessionMaxAge(),
+
 		IdentityHandler: a.identityHandler,
-		Authenticator:   a.authenticator,
 		Authorizer:      a.authorizer,
 		Unauthorized:    a.unauthorized,
-		LoginResponse:   a.loginResponse,
 		LogoutResponse:  a.logoutResponse,
 	})
 
@@ -166,8 +205,12 @@ func (a *AuthJWT) payloadFunc(data any) jwt.MapClaims {
 		"iss":        a.cfg.JWT.Issuer,
 	}
 
-	if user.AdminRoleID != nil {
-		claims[AdminRoleIDKey] = *user.AdminRoleID
+	if roleIDs := user.AdminRoleIDs(); len(roleIDs) > 0 {
+		claims[AdminRoleIDsKey] = roleIDs
+	}
+
+	if subj.Actor != nil {
+		claims[ActorKey] = map[string]any{"sub": subj.Actor.ID}
 	}
 
 	return claims
@@ -178,12 +221,17 @@ func (a *AuthJWT) identityHandler(c *gin.Context) any {
 	userID, _ := claims[IdentityKey].(float64)
 	role, _ := claims[RoleKey].(string)
 
-	var adminRoleID *uint
-	// Comma-ok assertion: JSON numbers decode as float64, but a forged or
+	var adminRoles []models.AdminRole
+	// Comma-ok assertions: JSON numbers decode as float64, but a forged or
 	// malformed claim could carry any type — skip it rather than panic.
-	if val, ok := claims[AdminRoleIDKey].(float64); ok {
-		id := uint(val)
-		adminRoleID = &id
+	if vals, ok := claims[AdminRoleIDsKey].([]any); ok {
+		for _, v := range vals {
+			if val, ok := v.(float64); ok {
+				adminRoles = append(adminRoles, models.AdminRole{ID: uint(val)})
+			}
+		}
+	} else if val, ok := claims[legacyAdminRoleIDKey].(float64); ok {
+		adminRoles = []models.AdminRole{{ID: uint(val)}}
 	}
 
 	var sessionID uuid.UUID
@@ -193,13 +241,21 @@ func (a *AuthJWT) identityHandler(c *gin.Context) any {
 		}
 	}
 
+	var actor *models.User
+	if act, ok := claims[ActorKey].(map[string]any); ok {
+		if sub, ok := act["sub"].(float64); ok {
+			actor = &models.User{ID: uint(sub)}
+		}
+	}
+
 	return &authSubject{
 		User: &models.User{
-			ID:          uint(userID),
-			Role:        models.UserRole(role),
-			AdminRoleID: adminRoleID,
+			ID:         uint(userID),
+			Role:       models.UserRole(role),
+			AdminRoles: adminRoles,
 		},
 		SessionID: sessionID,
+		Actor:     actor,
 	}
 }
 
@@ -210,14 +266,34 @@ func (a *AuthJWT) authenticator(c *gin.Context) (any, error) {
 	}
 
 	user, err := a.validateCredentials(c.Request.Context(), req.Username, req.Password)
+	if errors.Is(err, ErrEmailNotVerified) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, ginjwt.ErrFailedAuthentication
 	}
 
+	// The password alone does not open a session for a 2FA account: hand back
+	// a short-lived challenge instead, redeemed with a TOTP code at
+	// /auth/2fa/verify. gin-jwt still signs a token for the returned subject,
+	// but with no session ID it fails the authorizer, and loginResponse sends
+	// the challenge in its place.
+	if user.IsTwoFactorEnabled() {
+		challenge, err := a.createTwoFactorChallenge(c.Request.Context(), user.ID)
+		if err != nil {
+			logger.Error("Failed to create two-factor challenge at login", zap.Uint("user_id", user.ID), zap.Error(err))
+			return nil, ginjwt.ErrFailedAuthentication
+		}
+		c.Set(AuthUserKey, user)
+		c.Set(authChallengeKey, challenge)
+		logger.Info("Password verified; awaiting second factor", zap.Uint("user_id", user.ID))
+		return &authSubject{User: user}, nil
+	}
+
 	// Pre-create the refresh-token session so the access JWT can carry its
 	// ID as the jti claim. The authorizer will look this session up on every
 	// request, so revoking it kills the matching access tokens too.
-	refreshTokenStr, sessionID, err := a.createRefreshToken(c.Request.Context(), user.ID)
+	refreshTokenStr, sessionID, err := a.createRefreshToken(c.Request.Context(), user, req.RememberMe)
 	if err != nil {
 		logger.Error("Failed to create refresh token at login", zap.Uint("user_id", user.ID), zap.Error(err))
 		return nil, ginjwt.ErrFailedAuthentication
@@ -226,6 +302,7 @@ func (a *AuthJWT) authenticator(c *gin.Context) (any, error) {
 	subj := &authSubject{User: user, SessionID: sessionID}
 	c.Set(AuthUserKey, user)
 	c.Set(authRefreshTokenKey, refreshTokenStr)
+	a.recordSecurityEvent(c.Request.Context(), user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeSuccess, sessionID)
 	logger.Info("Login successful", zap.Uint("user_id", user.ID))
 	return subj, nil
 }
@@ -238,8 +315,8 @@ func (a *AuthJWT) authorizer(c *gin.Context, data any) bool {
 
 	ctx := c.Request.Context()
 
-	dbUser, err := a.userRepo.FindByID(ctx, subj.User.ID)
-	if err != nil || !dbUser.IsActive {
+	dbUser, err := a.findUser(ctx, subj.User.ID)
+	if err != nil || !dbUser.IsActive || a.emailVerificationPending(dbUser) {
 		return false
 	}
 
@@ -247,26 +324,109 @@ func (a *AuthJWT) authorizer(c *gin.Context, data any) bool {
 	// token's jti claim must still be active and belong to this user. Once
 	// that row is revoked (logout, change-password, admin action), every
 	// access token minted for that session stops working immediately.
-	session, err := a.refreshTokenRepo.FindActiveByID(ctx, subj.SessionID)
+	session, err := a.findActiveSession(ctx, subj.SessionID)
 	if err != nil || session.UserID != subj.User.ID {
 		return false
 	}
 
-	a.setContextValues(c, dbUser.ID, dbUser.Name, string(dbUser.Role), dbUser.AdminRoleID)
+	values := utils.ContextValues{
+		UserID:       dbUser.ID,
+		UserName:     dbUser.Name,
+		Role:         string(dbUser.Role),
+		AdminRoleIDs: dbUser.AdminRoleIDs(),
+		SessionID:    session.ID,
+	}
+	if !a.resolveImpersonator(ctx, session, subj.Actor, &values) {
+		return false
+	}
+
+	a.setContextValues(c, values)
 	// Expose the loaded user so later middleware (e.g. RequirePasswordChanged)
 	// can inspect fields like PasswordChangedAt without another DB query.
 	c.Set(AuthUserKey, dbUser)
+	c.Set(AuthSessionKey, session)
 	return true
 }
 
+// findUser loads the user the authorizer checks, through the cache.
+func (a *AuthJWT) findUser(ctx context.Context, id uint) (*models.User, error) {
+	if user, ok := a.cache.user(id); ok {
+		return user, nil
+	}
+	epoch := a.cache.currentEpoch()
+	user, err := a.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a.cache.putUser(epoch, user)
+	return user, nil
+}
+
+// findActiveSession loads the active session an access token is bound to,
+// through the cache.
+func (a *AuthJWT) findActiveSession(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+	if session, ok := a.cache.session(id); ok {
+		return session, nil
+	}
+	epoch := a.cache.currentEpoch()
+	session, err := a.refreshTokenRepo.FindActiveByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a.cache.putSession(epoch, session)
+	return session, nil
+}
+
+// InvalidateUser drops userID and their sessions from the authorizer cache.
+// Call it after the transaction that deactivated the account, changed it or
+// revoked its sessions has committed.
+func (a *AuthJWT) InvalidateUser(userID uint) {
+	a.cache.InvalidateUser(userID)
+}
+
+// InvalidateSession drops one session from the authorizer cache. Call it
+// after the session is revoked or reauthenticated.
+func (a *AuthJWT) InvalidateSession(id uuid.UUID) {
+	a.cache.InvalidateSession(id)
+}
+
 func (a *AuthJWT) unauthorized(c *gin.Context, code int, message string) {
 	c.JSON(code, response.BuildResponseFailed(message))
 }
 
-// loginResponse writes the token payload after gin-jwt authenticates the user.
+// LoginHandler authenticates a password login and answers through
+// loginResponse. It stands in for gin-jwt's LoginHandler, whose built-in
+// token generation cannot use the key set.
+func (a *AuthJWT) LoginHandler(c *gin.Context) {
+	data, err := a.authenticator(c)
+	if err != nil {
+		c.Header("WWW-Authenticate", "Bearer realm=\""+a.cfg.App.Name+"\"")
+		c.Abort()
+		a.unauthorized(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// A 2FA challenge has no session yet, so there is nothing to sign.
+	token := &core.Token{TokenType: "Bearer"}
+	if subj, ok := data.(*authSubject); ok && subj.SessionID != uuid.Nil {
+		accessToken, expire, err := a.generateAccessToken(subj)
+		if err != nil {
+			logger.Error("Failed to sign access token at login", zap.Uint("user_id", subj.User.ID), zap.Error(err))
+			c.Abort()
+			a.unauthorized(c, http.StatusInternalServerError, ginjwt.ErrFailedTokenCreation.Error())
+			return
+		}
+		token.AccessToken = accessToken
+		token.ExpiresAt = expire.Unix()
+	}
+
+	a.loginResponse(c, token)
+}
+
+// loginResponse writes the token payload after LoginHandler authenticates the user.
 //
 //	@Summary		Login
-//	@Description	Authenticate with username and password and return auth tokens
+//	@Description	Authenticate with username and password and return auth tokens. Accounts with 2FA enabled get a challenge_token instead, to be completed at /auth/2fa/verify.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -276,6 +436,14 @@ func (a *AuthJWT) unauthorized(c *gin.Context, code int, message string) {
 //	@Failure		401		{object}	response.Response
 //	@Router			/auth/login [post]
 func (a *AuthJWT) loginResponse(c *gin.Context, token *core.Token) {
+	if challenge, ok := c.Get(authChallengeKey); ok {
+		c.JSON(http.StatusOK, response.BuildResponseSuccess("two-factor authentication required", dto.AuthResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge.(string),
+		}))
+		return
+	}
+
 	userData, _ := c.Get(AuthUserKey)
 	user, ok := userData.(*models.User)
 	if !ok {
@@ -296,12 +464,14 @@ func (a *AuthJWT) loginResponse(c *gin.Context, token *core.Token) {
 		a.createLoginLog(user)
 	}
 
-	c.JSON(http.StatusOK, response.BuildResponseSuccess("login success", dto.AuthResponse{
+	resp := &dto.AuthResponse{
 		AccessToken:        token.AccessToken,
 		RefreshToken:       refreshToken,
 		TokenType:          "Bearer",
-		MustChangePassword: user.MustChangePassword(),
-	}))
+		MustChangePassword: user.MustChangePassword(a.cfg.Auth.PasswordMaxAge, time.Now()),
+	}
+	a.SetSessionCookies(c, resp)
+	c.JSON(http.StatusOK, response.BuildResponseSuccess("login success", resp))
 }
 
 func (a *AuthJWT) logoutResponse(c *gin.Context) {
@@ -310,33 +480,41 @@ func (a *AuthJWT) logoutResponse(c *gin.Context) {
 
 // --- Public Methods ---
 
-// GenerateTokensForUser mints a new access/refresh token pair for user.
+// GenerateTokensForUser mints a new access/refresh token pair for user, under
+// the standard (not remember-me) session policy.
 func (a *AuthJWT) GenerateTokensForUser(ctx context.Context, user *models.User) (*dto.AuthResponse, error) {
-	// Refresh token first so the access token can carry its session ID as jti.
-	refreshTokenStr, sessionID, err := a.createRefreshToken(ctx, user.ID)
+	resp, _, err := a.generateSession(ctx, user, false)
+	return resp, err
+}
+
+// CompleteLogin finishes a login that was held back for a second factor. It
+// mints the session exactly like a password-only login and writes the same
+// privileged-login audit entry and security event, which the password step
+// skipped for the challenge. rememberMe selects the session policy, as it does
+// for a password-only login.
+func (a *AuthJWT) CompleteLogin(ctx context.Context, user *models.User, rememberMe bool) (*dto.AuthResponse, error) {
+	resp, sessionID, err := a.generateSession(ctx, user, rememberMe)
 	if err != nil {
-		return nil, cerrors.NewInternalServerError("failed to generate refresh token", err)
+		return nil, err
 	}
 
-	token, err := a.Middleware.TokenGenerator(ctx, &authSubject{User: user, SessionID: sessionID})
-	if err != nil {
-		return nil, cerrors.NewInternalServerError("failed to generate access token", err)
+	if user.Role.IsAdminType() {
+		a.createLoginLog(user)
 	}
+	a.recordSecurityEvent(ctx, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeSuccess, sessionID)
 
-	return &dto.AuthResponse{
-		AccessToken:        token.AccessToken,
-		RefreshToken:       refreshTokenStr,
-		TokenType:          "Bearer",
-		MustChangePassword: user.MustChangePassword(),
-	}, nil
+	logger.Info("Login successful", zap.Uint("user_id", user.ID))
+	return resp, nil
 }
 
 // ValidateAndRotateRefreshToken rotates oldToken in place and returns a fresh
 // token pair bound to the SAME session. Rotation swaps only the stored token
 // hash on the existing row: the row ID is preserved so access tokens carrying
-// it as their jti claim keep working across a refresh, and expires_at is
-// preserved so the session has an absolute lifetime of JWT_REFRESH_EXPIRATION
-// from login instead of sliding forever one refresh at a time.
+// it as their jti claim keep working across a refresh, and expires_at and
+// logged_in_at are preserved so the session has an absolute lifetime from
+// login instead of sliding forever one refresh at a time. A session past its
+// policy's max age or idle timeout (see sessionPolicyExpired) is revoked
+// instead of rotated.
 func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken string) (*dto.AuthResponse, error) {
 	tokenRecord, err := a.refreshTokenRepo.FindByToken(ctx, oldToken)
 	if err != nil {
@@ -350,10 +528,12 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 		if superseded, ferr := a.refreshTokenRepo.FindByPreviousToken(ctx, oldToken); ferr == nil {
 			logger.Warn("Refresh-token reuse detected (superseded token replayed); revoking all sessions",
 				zap.Uint("user_id", superseded.UserID))
+			a.recordSecurityEvent(ctx, superseded.UserID, models.SecurityEventRefreshReuse, models.SecurityEventOutcomeFailure, superseded.ID)
 			if rerr := a.refreshTokenRepo.RevokeAllByUserID(ctx, superseded.UserID); rerr != nil {
 				logger.Error("Failed to revoke sessions after refresh-token reuse",
 					zap.Uint("user_id", superseded.UserID), zap.Error(rerr))
 			}
+			a.cache.InvalidateUser(superseded.UserID)
 		}
 		return nil, cerrors.NewBadRequestError("invalid or expired refresh token")
 	}
@@ -364,9 +544,26 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 	}
 
 	if !user.IsActive {
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventRefresh, models.SecurityEventOutcomeFailure, tokenRecord.ID)
 		return nil, cerrors.NewBadRequestError("user account is inactive")
 	}
 
+	if a.emailVerificationPending(user) {
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventRefresh, models.SecurityEventOutcomeFailure, tokenRecord.ID)
+		return nil, cerrors.NewBadRequestError(ErrEmailNotVerified.Error())
+	}
+
+	if reason := a.sessionPolicyExpired(user, tokenRecord, time.Now()); reason != "" {
+		logger.Info("Session ended by its session policy",
+			zap.Uint("user_id", user.ID), zap.String("session_id", tokenRecord.ID.String()), zap.String("reason", reason))
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventRefresh, models.SecurityEventOutcomeFailure, tokenRecord.ID)
+		if _, err := a.refreshTokenRepo.RevokeByTokenIfActive(ctx, oldToken); err != nil {
+			logger.Error("Failed to revoke expired session", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		a.cache.InvalidateSession(tokenRecord.ID)
+		return nil, cerrors.NewBadRequestError("session expired, please log in again")
+	}
+
 	newToken := newRefreshTokenValue()
 
 	// Rotate atomically: swapping the hash and minting the access token must
@@ -382,7 +579,7 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 		// rotation would silently degrade to "issue extra tokens", letting an
 		// attacker who captured a refresh token replay it indefinitely while
 		// the store is unhealthy.
-		updated, err := a.refreshTokenRepo.UpdateTokenHashIfActive(txCtx, oldToken, newToken)
+		updated, err := a.refreshTokenRepo.UpdateTokenHashIfActive(txCtx, oldToken, newToken, utils.GetClientInfoFromContext(ctx))
 		if err != nil {
 			logger.Error("Failed to rotate refresh token hash",
 				zap.Uint("user_id", user.ID), zap.Error(err))
@@ -407,16 +604,16 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 		// Mint the access token with the EXISTING session ID as jti so tokens
 		// issued before this refresh stay valid: the authorizer resolves jti to
 		// this same still-active row.
-		token, err := a.Middleware.TokenGenerator(txCtx, &authSubject{User: user, SessionID: tokenRecord.ID})
+		accessToken, _, err := a.generateAccessToken(&authSubject{User: user, SessionID: tokenRecord.ID})
 		if err != nil {
 			return cerrors.NewInternalServerError("failed to generate access token", err)
 		}
 
 		resp = &dto.AuthResponse{
-			AccessToken:        token.AccessToken,
+			AccessToken:        accessToken,
 			RefreshToken:       newToken,
 			TokenType:          "Bearer",
-			MustChangePassword: user.MustChangePassword(),
+			MustChangePassword: user.MustChangePassword(a.cfg.Auth.PasswordMaxAge, time.Now()),
 		}
 		return nil
 	})
@@ -427,10 +624,12 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 	if reuseDetected {
 		logger.Warn("Refresh-token reuse detected during rotation; revoking all sessions",
 			zap.Uint("user_id", user.ID))
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventRefreshReuse, models.SecurityEventOutcomeFailure, tokenRecord.ID)
 		if err := a.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
 			logger.Error("Failed to revoke sessions after refresh-token reuse",
 				zap.Uint("user_id", user.ID), zap.Error(err))
 		}
+		a.cache.InvalidateUser(user.ID)
 		return nil, cerrors.NewBadRequestError("invalid or expired refresh token")
 	}
 
@@ -438,9 +637,15 @@ func (a *AuthJWT) ValidateAndRotateRefreshToken(ctx context.Context, oldToken st
 		return nil, err
 	}
 
+	a.recordSecurityEvent(ctx, user.ID, models.SecurityEventRefresh, models.SecurityEventOutcomeSuccess, tokenRecord.ID)
 	return resp, nil
 }
 
+// JWKS returns the public signing keys for /.well-known/jwks.json.
+func (a *AuthJWT) JWKS() dto.JWKSResponse {
+	return a.keys.jwks()
+}
+
 // RevokeRefreshToken revokes token when it belongs to userID.
 func (a *AuthJWT) RevokeRefreshToken(ctx context.Context, token string, userID uint) error {
 	tokenRecord, err := a.refreshTokenRepo.FindByToken(ctx, token)
@@ -463,7 +668,11 @@ func (a *AuthJWT) RevokeRefreshToken(ctx context.Context, token string, userID u
 		return nil
 	}
 
-	return a.refreshTokenRepo.RevokeByToken(ctx, token)
+	if err := a.refreshTokenRepo.RevokeByToken(ctx, token); err != nil {
+		return err
+	}
+	a.cache.InvalidateSession(tokenRecord.ID)
+	return nil
 }
 
 // RevokeAllUserTokensExcept revokes every active token for userID except exceptToken.
@@ -473,9 +682,30 @@ func (a *AuthJWT) RevokeAllUserTokensExcept(ctx context.Context, userID uint, ex
 
 // --- Private Helpers ---
 
-func (a *AuthJWT) validateCredentials(ctx context.Context, username, password string) (*models.User, error) {
+// validateCredentials runs a login through the CredentialValidator chain,
+// falling back to the local password check.
+func (a *AuthJWT) validateCredentials(ctx context.Context, username, plain string) (*models.User, error) {
+	for _, v := range a.validators {
+		user, err := v.ValidateCredentials(ctx, username, plain)
+		if errors.Is(err, ErrCredentialsNotHandled) {
+			continue
+		}
+		return user, err
+	}
+	return a.validateLocalCredentials(ctx, username, plain)
+}
+
+// validateLocalCredentials checks plain against the password hash stored on
+// the account. It is the last credential check, and the only one for root and
+// regular users.
+func (a *AuthJWT) validateLocalCredentials(ctx context.Context, username, plain string) (*models.User, error) {
 	username = strings.TrimSpace(username)
 
+	// The unknown-user, inactive and locked paths compare against a dummy
+	// hash of the active scheme, so they cost as much as a wrong password and
+	// timing reveals neither which accounts exist nor which are locked.
+	params := a.cfg.Auth.PasswordHashParams()
+
 	var user *models.User
 	var err error
 
@@ -486,22 +716,122 @@ func (a *AuthJWT) validateCredentials(ctx context.Context, username, password st
 	}
 
 	if err != nil {
-		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		_ = password.Compare(password.Dummy(params), plain)
 		return nil, err
 	}
 
-	if !user.IsActive {
-		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+	// From here on the account exists, so every refusal goes on its
+	// security timeline. A directory account has no local password to match;
+	// it is refused here while the directory login is disabled.
+	if !user.IsActive || user.IsDirectoryAccount() {
+		_ = password.Compare(password.Dummy(params), plain)
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
 		return nil, errors.New("inactive account")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	// A locked account refuses even the right password, and pays the same
+	// hashing cost as any other failure.
+	if user.IsLocked(time.Now()) {
+		_ = password.Compare(password.Dummy(params), plain)
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+		return nil, errAccountLocked
+	}
+
+	if err := password.Compare(user.Password, plain); err != nil {
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+		a.lockout().registerFailure(ctx, user)
 		return nil, err
 	}
+	a.lockout().clear(ctx, user)
+	a.upgradePasswordHash(ctx, user, plain, params)
+
+	if a.emailVerificationPending(user) {
+		a.recordSecurityEvent(ctx, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+		return nil, ErrEmailNotVerified
+	}
 
 	return user, nil
 }
 
+// VerifyPassword checks plain against user's password wherever it lives: the
+// directory for a directory account, the stored hash otherwise. It backs the
+// re-entry of a password for sensitive operations (step-up, account
+// deletion) and, unlike login, does no lockout bookkeeping.
+func (a *AuthJWT) VerifyPassword(ctx context.Context, user *models.User, plain string) error {
+	if !user.IsDirectoryAccount() {
+		return password.Compare(user.Password, plain)
+	}
+	if a.directory == nil || !a.cfg.LDAP.Enabled {
+		return errors.New("directory login is disabled")
+	}
+	_, err := a.directory.client.Authenticate(ctx, user.Username, plain)
+	return err
+}
+
+// upgradePasswordHash rehashes plain under params when user's stored hash is
+// a legacy bcrypt hash or uses outdated Argon2id parameters. A login is the
+// only time the plaintext is available, so hashes upgrade as their owners
+// return. It is best-effort: a failure is logged and the login proceeds.
+func (a *AuthJWT) upgradePasswordHash(ctx context.Context, user *models.User, plain string, params password.Params) {
+	if !password.NeedsRehash(user.Password, params) {
+		return
+	}
+	hashed, err := password.Hash(plain, params)
+	if err == nil {
+		err = a.userRepo.UpdatePasswordHash(ctx, user.ID, hashed)
+	}
+	if err != nil {
+		logger.Error("Failed to upgrade password hash", zap.Uint("user_id", user.ID), zap.Error(err))
+		return
+	}
+	user.Password = hashed
+}
+
+// emailVerificationPending reports whether user is locked out until they
+// verify their email. Only self-registered accounts are gated: admin and root
+// accounts are provisioned by an operator rather than by the address owner.
+func (a *AuthJWT) emailVerificationPending(user *models.User) bool {
+	return a.cfg.Auth.RequireEmailVerification && !user.Role.IsAdminType() && !user.IsEmailVerified()
+}
+
+// createTwoFactorChallenge stores a single-use challenge token for userID and
+// returns its wire value. Only its hash is persisted, like every user token.
+func (a *AuthJWT) createTwoFactorChallenge(ctx context.Context, userID uint) (string, error) {
+	challenge := rand.Text()
+	err := a.userTokenRepo.Create(ctx, &models.UserToken{
+		UserID:    userID,
+		Purpose:   models.UserTokenPurposeTwoFactorChallenge,
+		TokenHash: challenge,
+		ExpiresAt: time.Now().Add(a.cfg.Auth.TwoFactorChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+// generateSession mints a new access/refresh token pair for user and returns
+// the session ID they are bound to.
+func (a *AuthJWT) generateSession(ctx context.Context, user *models.User, rememberMe bool) (*dto.AuthResponse, uuid.UUID, error) {
+	// Refresh token first so the access token can carry its session ID as jti.
+	refreshTokenStr, sessionID, err := a.createRefreshToken(ctx, user, rememberMe)
+	if err != nil {
+		return nil, uuid.Nil, cerrors.NewInternalServerError("failed to generate refresh token", err)
+	}
+
+	accessToken, _, err := a.generateAccessToken(&authSubject{User: user, SessionID: sessionID})
+	if err != nil {
+		return nil, uuid.Nil, cerrors.NewInternalServerError("failed to generate access token", err)
+	}
+
+	return &dto.AuthResponse{
+		AccessToken:        accessToken,
+		RefreshToken:       refreshTokenStr,
+		TokenType:          "Bearer",
+		MustChangePassword: user.MustChangePassword(a.cfg.Auth.PasswordMaxAge, time.Now()),
+	}, sessionID, nil
+}
+
 // newRefreshTokenValue returns a fresh opaque refresh-token wire value. Two
 // concatenated UUIDv4s give ~244 bits of entropy — far beyond brute-force
 // reach — and only the SHA-256 hash of this value is ever stored at rest.
@@ -509,22 +839,59 @@ func newRefreshTokenValue() string {
 	return uuid.New().String() + "-" + uuid.New().String()
 }
 
+// generateAccessToken signs an access token for subj with the active key. The
+// exp and orig_iat claims mirror what gin-jwt sets, since its middleware still
+// validates them.
+func (a *AuthJWT) generateAccessToken(subj *authSubject) (string, time.Time, error) {
+	now := time.Now()
+	expire := now.Add(a.cfg.JWT.Expiration)
+	if subj.Actor != nil {
+		if limit := now.Add(a.cfg.Auth.ImpersonationTTL); limit.Before(expire) {
+			expire = limit
+		}
+	}
+
+	claims := a.payloadFunc(subj)
+	claims["exp"] = expire.Unix()
+	claims["orig_iat"] = now.Unix()
+
+	token, err := a.keys.signedString(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expire, nil
+}
+
 // createRefreshToken inserts a new refresh-token row and returns both the
 // opaque token string (handed to the client) and the row's UUID, which the
 // caller embeds in the access JWT as the jti claim to bind it to this session.
-func (a *AuthJWT) createRefreshToken(ctx context.Context, userID uint) (string, uuid.UUID, error) {
-	if err := a.enforceSessionCap(ctx, userID); err != nil {
+// The client details recorded by the ClientInfo middleware are stored on the
+// row so the user can tell their sessions apart. Opening a session is itself
+// an authentication, so the row starts inside the reauthentication window.
+// The row expires at the max age of the session policy for user's role and
+// rememberMe.
+func (a *AuthJWT) createRefreshToken(ctx context.Context, user *models.User, rememberMe bool) (string, uuid.UUID, error) {
+	if err := a.enforceSessionCap(ctx, user.ID); err != nil {
 		return "", uuid.Nil, err
 	}
 
 	sessionID := uuid.New()
 	token := newRefreshTokenValue()
+	client := utils.GetClientInfoFromContext(ctx)
+	now := time.Now()
+	policy := a.cfg.SessionPolicy(user.Role.IsAdminType(), rememberMe)
 
 	err := a.refreshTokenRepo.Create(ctx, &models.RefreshToken{
-		ID:        sessionID,
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: time.Now().Add(a.cfg.JWT.RefreshExpiration),
+		ID:              sessionID,
+		UserID:          user.ID,
+		Token:           token,
+		ExpiresAt:       now.Add(policy.MaxAge),
+		UserAgent:       client.UserAgent,
+		IPAddress:       client.IP,
+		LastUsedAt:      now,
+		LoggedInAt:      now,
+		RememberMe:      rememberMe,
+		AuthenticatedAt: &now,
 	})
 	if err != nil {
 		return "", uuid.Nil, err
@@ -533,6 +900,23 @@ func (a *AuthJWT) createRefreshToken(ctx context.Context, userID uint) (string,
 	return token, sessionID, nil
 }
 
+// sessionPolicyExpired reports why session may no longer be refreshed under
+// the policy for user's current role, or "" when it may. The policy is looked
+// up again rather than trusted from login, so tightening the limits — or
+// promoting the user to an admin role — also ends sessions opened before.
+// Idleness is measured from the last login or refresh; access tokens are
+// short-lived, so an active client refreshes well within any idle timeout.
+func (a *AuthJWT) sessionPolicyExpired(user *models.User, session *models.RefreshToken, now time.Time) string {
+	policy := a.cfg.SessionPolicy(user.Role.IsAdminType(), session.RememberMe)
+	if now.Sub(session.LoggedInAt) >= policy.MaxAge {
+		return "max_age"
+	}
+	if now.Sub(session.LastUsedAt) >= policy.IdleTimeout {
+		return "idle_timeout"
+	}
+	return ""
+}
+
 // enforceSessionCap keeps a user's concurrent active sessions at or below
 // JWT_MAX_ACTIVE_SESSIONS by revoking the oldest active session(s) so the
 // session about to be created fits. A cap of 0 disables the check. Enforcing
@@ -562,32 +946,46 @@ func (a *AuthJWT) enforceSessionCap(ctx context.Context, userID uint) error {
 		zap.Int64("active_sessions", count),
 		zap.Int("max_active_sessions", maxSessions),
 		zap.Int("revoking", overflow))
-	return a.refreshTokenRepo.RevokeOldestActiveByUserID(ctx, userID, overflow)
+	if err := a.refreshTokenRepo.RevokeOldestActiveByUserID(ctx, userID, overflow); err != nil {
+		return err
+	}
+	// This may run inside the login transaction, so a request racing the
+	// commit can re-cache an evicted session; the cache TTL bounds that.
+	a.cache.InvalidateUser(userID)
+	return nil
 }
 
-func (a *AuthJWT) setContextValues(c *gin.Context, userID uint, userName string, role string, adminRoleID *uint) {
-	ctx := utils.NewContextWithValues(c.Request.Context(), utils.ContextValues{
-		UserID:      userID,
-		UserName:    userName,
-		Role:        role,
-		AdminRoleID: adminRoleID,
-		RequestID:   utils.GetRequestIDFromContext(c.Request.Context()),
-	})
+// setContextValues stores the authenticated caller on the request context
+// (alongside its request ID) and mirrors the identity into the gin context.
+func (a *AuthJWT) setContextValues(c *gin.Context, values utils.ContextValues) {
+	values.RequestID = utils.GetRequestIDFromContext(c.Request.Context())
+	ctx := utils.NewContextWithValues(c.Request.Context(), values)
 	c.Request = c.Request.WithContext(ctx)
-	c.Set("user_id", userID)
-	c.Set("role", role)
-	if adminRoleID != nil {
-		c.Set("admin_role_id", *adminRoleID)
+	c.Set("user_id", values.UserID)
+	c.Set("role", values.Role)
+	if len(values.AdminRoleIDs) > 0 {
+		c.Set(AdminRoleIDsKey, values.AdminRoleIDs)
 	}
 }
 
 // createLoginLog creates an audit log entry for admin login
 func (a *AuthJWT) createLoginLog(user *models.User) {
-	message := fmt.Sprintf("%s logged in", user.Name)
+	a.createAuthLog(user, models.LogActionLogin, fmt.Sprintf("%s logged in", user.Name))
+}
 
+// createAuthLog writes an audit entry about user's own account. Login-time
+// events have no authenticated request context, so the entry is attributed to
+// user directly rather than through audit.Record.
+func (a *AuthJWT) createAuthLog(user *models.User, action models.LogAction, message string) {
+	writeAuthLog(a.logRepository, user, action, message)
+}
+
+// writeAuthLog is createAuthLog for code that holds the log repository but
+// no AuthJWT, such as the shared lockout counter.
+func writeAuthLog(logRepo logRepository.LogRepository, user *models.User, action models.LogAction, message string) {
 	log := &models.Log{
 		UserID:     &user.ID,
-		Action:     models.LogActionLogin,
+		Action:     action,
 		EntityType: models.LogEntityTypeUser,
 		EntityID:   user.ID,
 		Message:    message,
@@ -595,13 +993,18 @@ func (a *AuthJWT) createLoginLog(user *models.User) {
 
 	// Tracked by audit.Drain so graceful shutdown waits for the write.
 	audit.Go(func() {
-		if err := a.logRepository.Create(context.Background(), log); err != nil {
-			logger.Error("Failed to create login audit log",
+		if err := logRepo.Create(context.Background(), log); err != nil {
+			logger.Error("Failed to create auth audit log",
 				zap.String("entity_type", models.LogEntityTypeUser),
 				zap.Uint("entity_id", user.ID),
-				zap.String("action", string(models.LogActionLogin)),
+				zap.String("action", string(action)),
 				zap.Error(err),
 			)
 		}
 	})
 }
+
+// recordSecurityEvent adds an event to userID's security timeline.
+func (a *AuthJWT) recordSecurityEvent(ctx context.Context, userID uint, eventType models.SecurityEventType, outcome models.SecurityEventOutcome, sessionID uuid.UUID) {
+	audit.RecordSecurityEvent(ctx, a.securityEventRepo, userID, eventType, outcome, sessionID)
+}
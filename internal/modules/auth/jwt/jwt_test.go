@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,17 +18,22 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/models"
+	apikeymocks "github.com/PhantomX7/athleton/internal/modules/api_key/repository/mocks"
 	logrepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
 	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
 	refreshtokenrepository "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
 	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
 	userrepository "github.com/PhantomX7/athleton/internal/modules/user/repository"
 	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	usertokenmocks "github.com/PhantomX7/athleton/internal/modules/user_token/repository/mocks"
 	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
 	"github.com/PhantomX7/athleton/pkg/config"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/password"
 	"github.com/PhantomX7/athleton/pkg/repository"
 	"github.com/PhantomX7/athleton/pkg/utils"
 )
@@ -63,38 +69,45 @@ func setupLogger(t *testing.T) {
 	})
 }
 
+// stubSecurityEventRepo accepts every security event; tests that assert on
+// the timeline inspect CreateCalls after audit.Drain.
+func stubSecurityEventRepo() *securityeventmocks.SecurityEventRepositoryMock {
+	return &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(context.Context, *models.SecurityEvent) error { return nil },
+	}
+}
+
 func newAuthJWT(t *testing.T, userRepo userrepository.UserRepository, refreshRepo refreshtokenrepository.RefreshTokenRepository, logRepo logrepository.LogRepository) *AuthJWT {
 	t.Helper()
 	cfg := setupConfig(t)
 	setupLogger(t)
 
-	auth, err := NewAuthJWT(cfg, userRepo, refreshRepo, logRepo, &txmocks.TransactionManagerMock{
+	auth, err := NewAuthJWT(cfg, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &apikeymocks.APIKeyRepositoryMock{}, logRepo, stubSecurityEventRepo(), &txmocks.TransactionManagerMock{
 		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
 			return fn(ctx)
 		},
-	})
+	}, nil, nil)
 	require.NoError(t, err)
 	return auth
 }
 
-func TestPayloadFuncIncludesIdentityRoleSessionAndAdminRoleID(t *testing.T) {
+func TestPayloadFuncIncludesIdentityRoleSessionAndAdminRoleIDs(t *testing.T) {
 	cfg := setupConfig(t)
 
-	adminRoleID := uint(9)
 	sessionID := uuid.New()
 	a := &AuthJWT{cfg: cfg}
 	claims := a.payloadFunc(&authSubject{
 		User: &models.User{
-			ID:          5,
-			Role:        models.UserRoleAdmin,
-			AdminRoleID: &adminRoleID,
+			ID:         5,
+			Role:       models.UserRoleAdmin,
+			AdminRoles: []models.AdminRole{{ID: 9}, {ID: 12}},
 		},
 		SessionID: sessionID,
 	})
 
 	require.Equal(t, uint(5), claims[IdentityKey])
 	require.Equal(t, models.UserRoleAdmin.ToString(), claims[RoleKey])
-	require.Equal(t, adminRoleID, claims[AdminRoleIDKey])
+	require.Equal(t, []uint{9, 12}, claims[AdminRoleIDsKey])
 	require.Equal(t, sessionID.String(), claims[SessionIDKey])
 	require.Equal(t, cfg.JWT.Issuer, claims["iss"])
 }
@@ -114,10 +127,10 @@ func TestIdentityHandlerBuildsSubjectFromClaims(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rec)
 	c.Set("JWT_PAYLOAD", jwt.MapClaims{
-		IdentityKey:    float64(7),
-		RoleKey:        "admin",
-		AdminRoleIDKey: float64(3),
-		SessionIDKey:   sessionID.String(),
+		IdentityKey:     float64(7),
+		RoleKey:         "admin",
+		AdminRoleIDsKey: []any{float64(3), float64(4)},
+		SessionIDKey:    sessionID.String(),
 	})
 
 	subj, ok := a.identityHandler(c).(*authSubject)
@@ -126,23 +139,44 @@ func TestIdentityHandlerBuildsSubjectFromClaims(t *testing.T) {
 	require.NotNil(t, subj.User)
 	require.Equal(t, uint(7), subj.User.ID)
 	require.Equal(t, models.UserRoleAdmin, subj.User.Role)
-	require.NotNil(t, subj.User.AdminRoleID)
-	require.Equal(t, uint(3), *subj.User.AdminRoleID)
+	require.Equal(t, []uint{3, 4}, subj.User.AdminRoleIDs())
 	require.Equal(t, sessionID, subj.SessionID)
 }
 
+// Tokens issued before users could hold several roles carry a single
+// admin_role_id claim; it still reads as a one-role set.
+func TestIdentityHandlerReadsLegacySingleAdminRoleClaim(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	a := &AuthJWT{}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Set("JWT_PAYLOAD", jwt.MapClaims{
+		IdentityKey:          float64(7),
+		RoleKey:              "admin",
+		legacyAdminRoleIDKey: float64(3),
+	})
+
+	subj, ok := a.identityHandler(c).(*authSubject)
+
+	require.True(t, ok)
+	require.Equal(t, []uint{3}, subj.User.AdminRoleIDs())
+}
+
 // Regression test for the prior panic: a forged or malformed admin-role claim
 // of the wrong JSON type hit an unchecked val.(float64) assertion. It must be
 // skipped instead — never trusted, never a crash.
-func TestIdentityHandlerSkipsAdminRoleIDWithWrongType(t *testing.T) {
+func TestIdentityHandlerSkipsAdminRoleIDsWithWrongType(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	a := &AuthJWT{}
 
 	cases := map[string]any{
-		"string": "3",
-		"bool":   true,
-		"nil":    nil,
+		"string":         "3",
+		"bool":           true,
+		"nil":            nil,
+		"single number":  float64(3),
+		"list of string": []any{"3"},
 	}
 
 	for name, val := range cases {
@@ -150,9 +184,9 @@ func TestIdentityHandlerSkipsAdminRoleIDWithWrongType(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(rec)
 			c.Set("JWT_PAYLOAD", jwt.MapClaims{
-				IdentityKey:    float64(7),
-				RoleKey:        "admin",
-				AdminRoleIDKey: val,
+				IdentityKey:     float64(7),
+				RoleKey:         "admin",
+				AdminRoleIDsKey: val,
 			})
 
 			var subj *authSubject
@@ -162,7 +196,7 @@ func TestIdentityHandlerSkipsAdminRoleIDWithWrongType(t *testing.T) {
 			})
 
 			require.True(t, ok)
-			require.Nil(t, subj.User.AdminRoleID)
+			require.Empty(t, subj.User.AdminRoleIDs())
 		})
 	}
 }
@@ -198,6 +232,7 @@ func TestValidateCredentialsUsesEmailAndReturnsUser(t *testing.T) {
 	require.NoError(t, err)
 
 	repo := &usermocks.UserRepositoryMock{
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
 		FindByEmailFunc: func(ctx context.Context, email string) (*models.User, error) {
 			require.Equal(t, "alice@example.com", email)
 			return &models.User{
@@ -209,7 +244,7 @@ func TestValidateCredentialsUsesEmailAndReturnsUser(t *testing.T) {
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
 	user, err := a.validateCredentials(context.Background(), " Alice@Example.com ", "secret123")
 
 	require.NoError(t, err)
@@ -234,22 +269,138 @@ func TestValidateCredentialsRejectsInactiveUser(t *testing.T) {
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
 	user, err := a.validateCredentials(context.Background(), "alice", "secret123")
 
 	require.Nil(t, user)
 	require.Error(t, err)
 }
 
-// Regression test for the prior bug where dummyHash was a string literal that
-// bcrypt rejected as ErrHashTooShort, doing zero work and leaking account
-// existence via timing. The replacement must be a real bcrypt hash at the
-// production cost so the timing-equalization path actually pays the cost.
-func TestDummyHashIsRealBcryptHashAtProductionCost(t *testing.T) {
-	cost, err := bcrypt.Cost(dummyHash())
+func TestValidateCredentialsRejectsUnverifiedEmailWhenRequired(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	verifiedAt := time.Now()
+	users := map[string]*models.User{
+		"unverified": {ID: 1, Role: models.UserRoleUser, IsActive: true, Password: string(hashed)},
+		"verified":   {ID: 2, Role: models.UserRoleUser, IsActive: true, Password: string(hashed), EmailVerifiedAt: &verifiedAt},
+		"admin":      {ID: 3, Role: models.UserRoleAdmin, IsActive: true, Password: string(hashed)},
+	}
+	repo := &usermocks.UserRepositoryMock{
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
+		FindByUsernameFunc: func(_ context.Context, username string) (*models.User, error) {
+			return users[username], nil
+		},
+	}
+
+	cfg := &config.Config{}
+	a := &AuthJWT{cfg: cfg, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	// Off by default: an unverified user logs in as before.
+	_, err = a.validateCredentials(context.Background(), "unverified", "secret123")
+	require.NoError(t, err)
+
+	cfg.Auth.RequireEmailVerification = true
+	_, err = a.validateCredentials(context.Background(), "unverified", "secret123")
+	require.ErrorIs(t, err, ErrEmailNotVerified)
+
+	// A wrong password must still fail as a plain credential error.
+	_, err = a.validateCredentials(context.Background(), "unverified", "wrong-password")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrEmailNotVerified)
 
-	require.NoError(t, err, "dummyHash must be parseable by bcrypt")
-	require.Equal(t, dummyBcryptCost, cost, "dummyHash cost must match production")
+	_, err = a.validateCredentials(context.Background(), "verified", "secret123")
+	require.NoError(t, err)
+	_, err = a.validateCredentials(context.Background(), "admin", "secret123")
+	require.NoError(t, err, "operator-provisioned accounts are not gated")
+}
+
+func TestValidateCredentialsUpgradesLegacyBcryptHash(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	var stored string
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 4, IsActive: true, Password: string(hashed)}, nil
+		},
+		UpdatePasswordHashFunc: func(_ context.Context, id uint, hash string) error {
+			require.Equal(t, uint(4), id)
+			stored = hash
+			return nil
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.Argon2Memory, cfg.Auth.Argon2Iterations, cfg.Auth.Argon2Parallelism = 64, 1, 1
+	a := &AuthJWT{cfg: cfg, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	user, err := a.validateCredentials(context.Background(), "alice", "secret123")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(stored, "$argon2id$v=19$m=64,t=1,p=1$"), stored)
+	require.Equal(t, stored, user.Password)
+	require.NoError(t, password.Compare(stored, "secret123"))
+
+	// A wrong password never rewrites the hash.
+	stored = ""
+	_, err = a.validateCredentials(context.Background(), "alice", "wrong-password")
+	require.Error(t, err)
+	require.Empty(t, stored)
+}
+
+func TestValidateCredentialsRehashesOnlyOutdatedArgon2Params(t *testing.T) {
+	setupLogger(t)
+
+	cheap := password.Params{Memory: 64, Iterations: 1, Parallelism: 1}
+	hashed, err := password.Hash("secret123", cheap)
+	require.NoError(t, err)
+
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 5, IsActive: true, Password: hashed}, nil
+		},
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.Argon2Memory, cfg.Auth.Argon2Iterations, cfg.Auth.Argon2Parallelism = 64, 1, 1
+	a := &AuthJWT{cfg: cfg, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	_, err = a.validateCredentials(context.Background(), "alice", "secret123")
+	require.NoError(t, err)
+	require.Empty(t, repo.UpdatePasswordHashCalls(), "a current hash is left alone")
+
+	cfg.Auth.Argon2Iterations = 2
+	user, err := a.validateCredentials(context.Background(), "alice", "secret123")
+	require.NoError(t, err)
+	require.Len(t, repo.UpdatePasswordHashCalls(), 1)
+	require.Contains(t, user.Password, "$m=64,t=2,p=1$")
+}
+
+func TestValidateCredentialsSucceedsWhenHashUpgradeFails(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 6, IsActive: true, Password: string(hashed)}, nil
+		},
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return errors.New("db down") },
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.Argon2Memory, cfg.Auth.Argon2Iterations, cfg.Auth.Argon2Parallelism = 64, 1, 1
+	a := &AuthJWT{cfg: cfg, userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	user, err := a.validateCredentials(context.Background(), "alice", "secret123")
+	require.NoError(t, err)
+	require.Equal(t, string(hashed), user.Password, "the legacy hash is kept for the next attempt")
 }
 
 func TestAuthorizerSetsContextValuesForActiveUserWithBoundSession(t *testing.T) {
@@ -261,11 +412,11 @@ func TestAuthorizerSetsContextValuesForActiveUserWithBoundSession(t *testing.T)
 		FindByIDFunc: func(ctx context.Context, id uint, _ ...repository.Association) (*models.User, error) {
 			require.Equal(t, uint(5), id)
 			return &models.User{
-				ID:          5,
-				Name:        "Alice",
-				Role:        models.UserRoleAdmin,
-				IsActive:    true,
-				AdminRoleID: &adminRoleID,
+				ID:         5,
+				Name:       "Alice",
+				Role:       models.UserRoleAdmin,
+				IsActive:   true,
+				AdminRoles: []models.AdminRole{{ID: adminRoleID}},
 			}, nil
 		},
 	}
@@ -276,7 +427,7 @@ func TestAuthorizerSetsContextValuesForActiveUserWithBoundSession(t *testing.T)
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo, refreshTokenRepo: refreshRepo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
 	rec := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rec)
 	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
@@ -293,8 +444,7 @@ func TestAuthorizerSetsContextValuesForActiveUserWithBoundSession(t *testing.T)
 	require.Equal(t, uint(5), values.UserID)
 	require.Equal(t, "Alice", values.UserName)
 	require.Equal(t, "admin", values.Role)
-	require.NotNil(t, values.AdminRoleID)
-	require.Equal(t, uint(4), *values.AdminRoleID)
+	require.Equal(t, []uint{4}, values.AdminRoleIDs)
 }
 
 func TestAuthorizerRejectsAccessTokenWithoutSessionClaim(t *testing.T) {
@@ -328,7 +478,7 @@ func TestAuthorizerRejectsRevokedSession(t *testing.T) {
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo, refreshTokenRepo: refreshRepo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
 	rec := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rec)
 	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
@@ -361,7 +511,7 @@ func TestAuthorizerRejectsUserDeactivatedAfterLogin(t *testing.T) {
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo, refreshTokenRepo: refreshRepo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
 	rec := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rec)
 	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
@@ -375,6 +525,35 @@ func TestAuthorizerRejectsUserDeactivatedAfterLogin(t *testing.T) {
 	require.False(t, sessionLookedUp, "inactive user must be rejected before the session lookup")
 }
 
+func TestAuthorizerRejectsUnverifiedUserWhenVerificationRequired(t *testing.T) {
+	setupLogger(t)
+
+	repo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: 5, IsActive: true, Role: models.UserRoleUser}, nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		FindActiveByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
+			return &models.RefreshToken{ID: id, UserID: 5}, nil
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.Auth.RequireEmailVerification = true
+	a := &AuthJWT{cfg: cfg, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
+
+	allowed := a.authorizer(c, &authSubject{
+		User:      &models.User{ID: 5},
+		SessionID: uuid.New(),
+	})
+
+	require.False(t, allowed)
+}
+
 func TestAuthorizerRejectsSessionBelongingToDifferentUser(t *testing.T) {
 	setupLogger(t)
 
@@ -390,7 +569,7 @@ func TestAuthorizerRejectsSessionBelongingToDifferentUser(t *testing.T) {
 		},
 	}
 
-	a := &AuthJWT{userRepo: repo, refreshTokenRepo: refreshRepo}
+	a := &AuthJWT{cfg: &config.Config{}, userRepo: repo, refreshTokenRepo: refreshRepo, securityEventRepo: stubSecurityEventRepo()}
 	rec := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(rec)
 	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/protected", nil)
@@ -424,6 +603,43 @@ func TestGenerateTokensForUserCreatesRefreshToken(t *testing.T) {
 	require.Equal(t, "Bearer", res.TokenType)
 }
 
+// A new session records its login and expires at the max age of the policy
+// chosen by the account's role and the remember-me flag.
+func TestGenerateSessionAppliesSessionPolicy(t *testing.T) {
+	cases := map[string]struct {
+		role       models.UserRole
+		rememberMe bool
+		wantMaxAge time.Duration
+	}{
+		"user":                  {role: models.UserRoleUser, wantMaxAge: 72 * time.Hour},
+		"user remembered":       {role: models.UserRoleUser, rememberMe: true, wantMaxAge: 720 * time.Hour},
+		"admin":                 {role: models.UserRoleAdmin, wantMaxAge: 8 * time.Hour},
+		"admin remembered":      {role: models.UserRoleAdmin, rememberMe: true, wantMaxAge: 720 * time.Hour},
+		"root uses admin limit": {role: models.UserRoleRoot, wantMaxAge: 8 * time.Hour},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created *models.RefreshToken
+			refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+				CreateFunc: func(_ context.Context, entity *models.RefreshToken) error {
+					created = entity
+					return nil
+				},
+			}
+			a := newAuthJWT(t, &usermocks.UserRepositoryMock{}, refreshRepo, &logmocks.LogRepositoryMock{})
+			a.cfg.Auth.AdminSessionMaxAge = 8 * time.Hour
+
+			_, _, err := a.generateSession(context.Background(), &models.User{ID: 7, Role: tc.role}, tc.rememberMe)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.rememberMe, created.RememberMe)
+			require.WithinDuration(t, time.Now(), created.LoggedInAt, time.Second)
+			require.WithinDuration(t, created.LoggedInAt.Add(tc.wantMaxAge), created.ExpiresAt, time.Second)
+		})
+	}
+}
+
 // With JWT_MAX_ACTIVE_SESSIONS unset (0) the cap is disabled: token creation
 // must neither count sessions nor revoke anything — the mock panics on any
 // unexpected GetValidCountByUserID / RevokeOldestActiveByUserID call, so the
@@ -530,9 +746,9 @@ func TestValidateAndRotateRefreshTokenRotatesInPlace(t *testing.T) {
 	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
 		FindByTokenFunc: func(ctx context.Context, token string) (*models.RefreshToken, error) {
 			require.Equal(t, "old-token", token)
-			return &models.RefreshToken{ID: sessionID, UserID: 11, Token: token}, nil
+			return &models.RefreshToken{ID: sessionID, UserID: 11, Token: token, LoggedInAt: time.Now(), LastUsedAt: time.Now()}, nil
 		},
-		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string) (bool, error) {
+		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string, _ utils.ClientInfo) (bool, error) {
 			require.Equal(t, "old-token", oldToken)
 			require.NotEmpty(t, newToken)
 			rotatedTo = newToken
@@ -560,6 +776,74 @@ func TestValidateAndRotateRefreshTokenRotatesInPlace(t *testing.T) {
 	require.Equal(t, sessionID.String(), claims[SessionIDKey])
 }
 
+// A session past its policy's max age or idle timeout is revoked instead of
+// rotated, even though its row has not expired yet. The policy follows the
+// account's current role and the session's remember-me flag.
+func TestValidateAndRotateRefreshTokenEndsExpiredSessions(t *testing.T) {
+	now := time.Now()
+	cases := map[string]struct {
+		role                   models.UserRole
+		rememberMe             bool
+		loggedInAgo, idleSince time.Duration
+		wantExpired            bool
+	}{
+		"fresh session":                  {role: models.UserRoleUser, loggedInAgo: time.Hour, idleSince: time.Minute},
+		"past max age":                   {role: models.UserRoleUser, loggedInAgo: 73 * time.Hour, idleSince: time.Minute, wantExpired: true},
+		"idle too long":                  {role: models.UserRoleUser, loggedInAgo: 30 * time.Hour, idleSince: 25 * time.Hour, wantExpired: true},
+		"remembered survives idle night": {role: models.UserRoleUser, rememberMe: true, loggedInAgo: 30 * time.Hour, idleSince: 25 * time.Hour},
+		"remembered outlives max age":    {role: models.UserRoleUser, rememberMe: true, loggedInAgo: 100 * time.Hour, idleSince: time.Hour},
+		"admin idle limit is stricter":   {role: models.UserRoleAdmin, loggedInAgo: 3 * time.Hour, idleSince: 2 * time.Hour, wantExpired: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sessionID := uuid.New()
+			userRepo := &usermocks.UserRepositoryMock{
+				FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+					return &models.User{ID: 11, Role: tc.role, IsActive: true}, nil
+				},
+			}
+			revoked := ""
+			refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+				FindByTokenFunc: func(_ context.Context, token string) (*models.RefreshToken, error) {
+					return &models.RefreshToken{
+						ID:         sessionID,
+						UserID:     11,
+						Token:      token,
+						RememberMe: tc.rememberMe,
+						LoggedInAt: now.Add(-tc.loggedInAgo),
+						LastUsedAt: now.Add(-tc.idleSince),
+					}, nil
+				},
+				RevokeByTokenIfActiveFunc: func(_ context.Context, token string) (bool, error) {
+					revoked = token
+					return true, nil
+				},
+				UpdateTokenHashIfActiveFunc: func(context.Context, string, string, utils.ClientInfo) (bool, error) {
+					return true, nil
+				},
+			}
+
+			a := newAuthJWT(t, userRepo, refreshRepo, &logmocks.LogRepositoryMock{})
+			a.cfg.Auth.AdminSessionIdleTimeout = time.Hour
+			res, err := a.ValidateAndRotateRefreshToken(context.Background(), "old-token")
+
+			if !tc.wantExpired {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.RefreshToken)
+				require.Empty(t, revoked)
+				return
+			}
+			var appErr *cerrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			require.Contains(t, appErr.Message, "session expired")
+			require.Nil(t, res)
+			require.Equal(t, "old-token", revoked, "an expired session must be revoked")
+			require.Empty(t, refreshRepo.UpdateTokenHashIfActiveCalls(), "an expired session must not be rotated")
+		})
+	}
+}
+
 // Regression test for the prior bug where rotation-store errors were ignored
 // and a fresh token pair was minted anyway, leaving the old refresh token
 // reusable. (Mint = TokenGenerator; the mock panics on an unexpected Create.)
@@ -571,9 +855,9 @@ func TestValidateAndRotateRefreshTokenFailsWhenHashSwapFails(t *testing.T) {
 	}
 	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
 		FindByTokenFunc: func(ctx context.Context, token string) (*models.RefreshToken, error) {
-			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token}, nil
+			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token, LoggedInAt: time.Now(), LastUsedAt: time.Now()}, nil
 		},
-		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string) (bool, error) {
+		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string, _ utils.ClientInfo) (bool, error) {
 			return false, cerrors.NewInternalServerError("db boom", errors.New("boom"))
 		},
 	}
@@ -598,9 +882,9 @@ func TestValidateAndRotateRefreshTokenDetectsReuse(t *testing.T) {
 	revokeAllUser := uint(0)
 	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
 		FindByTokenFunc: func(ctx context.Context, token string) (*models.RefreshToken, error) {
-			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token}, nil
+			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token, LoggedInAt: time.Now(), LastUsedAt: time.Now()}, nil
 		},
-		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string) (bool, error) {
+		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string, _ utils.ClientInfo) (bool, error) {
 			return false, nil // lost the race: hash already rotated away
 		},
 		RevokeAllByUserIDFunc: func(ctx context.Context, userID uint) error {
@@ -693,9 +977,9 @@ func TestValidateAndRotateRefreshTokenIsTransactional(t *testing.T) {
 	}
 	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
 		FindByTokenFunc: func(ctx context.Context, token string) (*models.RefreshToken, error) {
-			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token}, nil
+			return &models.RefreshToken{ID: uuid.New(), UserID: 11, Token: token, LoggedInAt: time.Now(), LastUsedAt: time.Now()}, nil
 		},
-		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string) (bool, error) {
+		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string, _ utils.ClientInfo) (bool, error) {
 			return false, errors.New("swap failed") // forces the transaction to unwind
 		},
 	}
@@ -707,7 +991,7 @@ func TestValidateAndRotateRefreshTokenIsTransactional(t *testing.T) {
 			return fn(ctx)
 		},
 	}
-	a, err := NewAuthJWT(cfg, userRepo, refreshRepo, &logmocks.LogRepositoryMock{}, tx)
+	a, err := NewAuthJWT(cfg, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &apikeymocks.APIKeyRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), tx, nil, nil)
 	require.NoError(t, err)
 
 	res, err := a.ValidateAndRotateRefreshToken(context.Background(), "old-token")
@@ -758,7 +1042,7 @@ func TestLoginResponseCreatesAuditLogForPrivilegedRoles(t *testing.T) {
 	for _, role := range []models.UserRole{models.UserRoleAdmin, models.UserRoleRoot} {
 		t.Run(string(role), func(t *testing.T) {
 			created := make(chan *models.Log, 1)
-			a := &AuthJWT{logRepository: &logmocks.LogRepositoryMock{
+			a := &AuthJWT{cfg: &config.Config{}, logRepository: &logmocks.LogRepositoryMock{
 				CreateFunc: func(_ context.Context, entry *models.Log) error {
 					created <- entry
 					return nil
@@ -802,3 +1086,78 @@ func TestLoginResponseReturnsInternalErrorWithoutAuthenticatedUser(t *testing.T)
 	require.Equal(t, false, body["status"])
 	require.Equal(t, "internal error", body["message"])
 }
+
+func TestAuthenticatorIssuesChallengeInsteadOfSessionWhenTwoFactorEnabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	enabledAt := time.Now()
+
+	userRepo := &usermocks.UserRepositoryMock{
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 5, Username: "alice", IsActive: true, Password: string(hashed), TwoFactorEnabledAt: &enabledAt}, nil
+		},
+	}
+	var stored *models.UserToken
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		CreateFunc: func(_ context.Context, token *models.UserToken) error {
+			stored = token
+			return nil
+		},
+	}
+	// No refresh-token mock functions: creating a session here would panic.
+	a := &AuthJWT{
+		cfg:              &config.Config{Auth: config.AuthConfig{TwoFactorChallengeTTL: 5 * time.Minute}},
+		userRepo:         userRepo,
+		refreshTokenRepo: &refreshtokenmocks.RefreshTokenRepositoryMock{},
+		userTokenRepo:    userTokenRepo,
+	}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/login",
+		strings.NewReader(`{"username":"alice","password":"secret123"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	data, err := a.authenticator(c)
+	require.NoError(t, err)
+	subj, ok := data.(*authSubject)
+	require.True(t, ok)
+	require.Equal(t, uuid.Nil, subj.SessionID)
+
+	require.NotNil(t, stored)
+	require.Equal(t, uint(5), stored.UserID)
+	require.Equal(t, models.UserTokenPurposeTwoFactorChallenge, stored.Purpose)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), stored.ExpiresAt, time.Minute)
+
+	challenge, ok := c.Get(authChallengeKey)
+	require.True(t, ok)
+	require.Equal(t, stored.TokenHash, challenge)
+}
+
+func TestLoginResponseReturnsChallengeWithoutTokensOrAuditLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// No log mock function: an audit write here would panic.
+	a := &AuthJWT{cfg: &config.Config{}, logRepository: &logmocks.LogRepositoryMock{}}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/login", nil)
+	c.Set(AuthUserKey, &models.User{ID: 3, Role: models.UserRoleAdmin})
+	c.Set(authChallengeKey, "challenge-token")
+
+	a.loginResponse(c, &core.Token{AccessToken: "discarded"})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Data dto.AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.True(t, body.Data.TwoFactorRequired)
+	require.Equal(t, "challenge-token", body.Data.ChallengeToken)
+	require.Empty(t, body.Data.AccessToken)
+	require.Empty(t, body.Data.RefreshToken)
+}
@@ -0,0 +1,226 @@
+package authjwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	ginjwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits rejects RSA keys too short to be trusted for RS256.
+const minRSAKeyBits = 2048
+
+// errUnknownKeyID rejects a token whose kid header names no key in the set —
+// typically one signed by a key that has since been retired.
+var errUnknownKeyID = errors.New("unknown signing key")
+
+// signingKey is one access-token key. HS256 keys have an empty id (tokens
+// signed with the shared secret carry no kid) and are never published.
+type signingKey struct {
+	id     string
+	method jwt.SigningMethod
+	// sign is nil for verification-only keys.
+	sign   any
+	verify any
+	jwk    *dto.JWK
+}
+
+// keySet holds the key new access tokens are signed with plus every key whose
+// tokens are still accepted, indexed by kid.
+type keySet struct {
+	active *signingKey
+	byID   map[string]*signingKey
+}
+
+// loadKeySet builds the key set from config: the PEM signing key when one is
+// configured, otherwise HS256 with JWT_SECRET, plus any retired keys kept for
+// verification.
+func loadKeySet(cfg config.JWTConfig) (*keySet, error) {
+	var active *signingKey
+	if cfg.SigningKeyFile == "" {
+		secret := []byte(cfg.Secret)
+		active = &signingKey{method: jwt.SigningMethodHS256, sign: secret, verify: secret}
+	} else {
+		key, err := readPEMKey(cfg.SigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if key.sign == nil {
+			return nil, fmt.Errorf("jwt signing key %s: a private key is required", cfg.SigningKeyFile)
+		}
+		active = key
+	}
+
+	ks := &keySet{
+		active: active,
+		byID:   map[string]*signingKey{active.id: active},
+	}
+	for _, path := range cfg.VerificationKeyFiles {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		key, err := readPEMKey(path)
+		if err != nil {
+			return nil, err
+		}
+		// The active key may also be listed here during a rotation; keep the
+		// entry that can sign.
+		if _, exists := ks.byID[key.id]; exists {
+			continue
+		}
+		key.sign = nil
+		ks.byID[key.id] = key
+	}
+	return ks, nil
+}
+
+// signedString signs claims with the active key, naming it in the kid header.
+func (ks *keySet) signedString(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.method, claims)
+	if ks.active.id != "" {
+		token.Header["kid"] = ks.active.id
+	}
+	return token.SignedString(ks.active.sign)
+}
+
+// keyFunc resolves the verification key for a token from its kid header. The
+// token's alg must match the key's: without that check a token could claim
+// HS256 and be "verified" with a public key as the HMAC secret.
+func (ks *keySet) keyFunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.byID[kid]
+	if !ok {
+		return nil, errUnknownKeyID
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, ginjwt.ErrInvalidSigningAlgorithm
+	}
+	return key.verify, nil
+}
+
+// jwks returns the public keys of the set, active key first and the rest in
+// kid order. A shared HS256 secret is never included.
+func (ks *keySet) jwks() dto.JWKSResponse {
+	retired := make([]dto.JWK, 0, len(ks.byID))
+	for _, key := range ks.byID {
+		if key != ks.active && key.jwk != nil {
+			retired = append(retired, *key.jwk)
+		}
+	}
+	sort.Slice(retired, func(i, j int) bool { return retired[i].Kid < retired[j].Kid })
+
+	res := dto.JWKSResponse{Keys: make([]dto.JWK, 0, len(retired)+1)}
+	if ks.active.jwk != nil {
+		res.Keys = append(res.Keys, *ks.active.jwk)
+	}
+	res.Keys = append(res.Keys, retired...)
+	return res
+}
+
+// readPEMKey loads an RSA or Ed25519 key from a PEM file. Private keys yield a
+// signing key; public keys a verification-only one.
+func readPEMKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from operator config
+	if err != nil {
+		return nil, fmt.Errorf("jwt key %s: %w", path, err)
+	}
+	key, err := parsePEMKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("jwt key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+func parsePEMKey(data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var (
+		private any
+		public  any
+		err     error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		public, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		public = &k.PublicKey
+	case ed25519.PrivateKey:
+		public = k.Public()
+	case nil:
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", private)
+	}
+
+	var key *signingKey
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits (got %d)", minRSAKeyBits, pub.N.BitLen())
+		}
+		jwk := &dto.JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+		jwk.Kid = thumbprint(fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N))
+		key = &signingKey{method: jwt.SigningMethodRS256, verify: pub, jwk: jwk}
+	case ed25519.PublicKey:
+		jwk := &dto.JWK{
+			Kty: "OKP",
+			Use: "sig",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}
+		jwk.Kid = thumbprint(fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":%q}`, jwk.X))
+		key = &signingKey{method: jwt.SigningMethodEdDSA, verify: pub, jwk: jwk}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	key.id = key.jwk.Kid
+	key.sign = private
+	return key, nil
+}
+
+// thumbprint derives a kid as the RFC 7638 JWK thumbprint: the base64url
+// SHA-256 of the key's required members in lexicographic order. Deriving it
+// from the key means operators never have to assign (or keep unique) ids.
+func thumbprint(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
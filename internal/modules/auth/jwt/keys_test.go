@@ -0,0 +1,175 @@
+package authjwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+// writePEM stores key in a temp file as PKCS#8 (private) or PKIX (public) PEM.
+func writePEM(t *testing.T, key any) string {
+	t.Helper()
+
+	var block *pem.Block
+	switch k := key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(k)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	return path
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return key
+}
+
+func parseWith(ks *keySet, token string) (*jwt.Token, error) {
+	return jwt.Parse(token, ks.keyFunc)
+}
+
+func TestKeySetHS256FallbackSignsWithoutKid(t *testing.T) {
+	ks, err := loadKeySet(config.JWTConfig{Secret: "test-secret-of-at-least-32-characters"})
+	require.NoError(t, err)
+
+	signed, err := ks.signedString(jwt.MapClaims{"sub": 1})
+	require.NoError(t, err)
+
+	token, err := parseWith(ks, signed)
+	require.NoError(t, err)
+	require.Equal(t, "HS256", token.Method.Alg())
+	require.NotContains(t, token.Header, "kid")
+	require.Empty(t, ks.jwks().Keys, "a shared secret must never be published")
+}
+
+func TestKeySetEdDSASignsWithThumbprintKid(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ks, err := loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, private)})
+	require.NoError(t, err)
+
+	signed, err := ks.signedString(jwt.MapClaims{"sub": 1})
+	require.NoError(t, err)
+	token, err := parseWith(ks, signed)
+	require.NoError(t, err)
+	require.Equal(t, "EdDSA", token.Method.Alg())
+
+	keys := ks.jwks().Keys
+	require.Len(t, keys, 1)
+	require.Equal(t, token.Header["kid"], keys[0].Kid)
+	require.Equal(t, "OKP", keys[0].Kty)
+	require.Equal(t, "Ed25519", keys[0].Crv)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(private.Public().(ed25519.PublicKey)), keys[0].X)
+}
+
+// Rotation: tokens from the retired key keep verifying while it is listed,
+// new tokens use the new key, and dropping the old key retires its tokens.
+func TestKeySetRotationKeepsRetiredKeyForVerification(t *testing.T) {
+	oldKey := newRSAKey(t, 2048)
+	newKey := newRSAKey(t, 2048)
+
+	before, err := loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, oldKey)})
+	require.NoError(t, err)
+	oldToken, err := before.signedString(jwt.MapClaims{"sub": 1})
+	require.NoError(t, err)
+
+	during, err := loadKeySet(config.JWTConfig{
+		SigningKeyFile:       writePEM(t, newKey),
+		VerificationKeyFiles: []string{writePEM(t, &oldKey.PublicKey), " "},
+	})
+	require.NoError(t, err)
+
+	_, err = parseWith(during, oldToken)
+	require.NoError(t, err)
+	newToken, err := during.signedString(jwt.MapClaims{"sub": 1})
+	require.NoError(t, err)
+	parsed, err := parseWith(during, newToken)
+	require.NoError(t, err)
+	require.Equal(t, "RS256", parsed.Method.Alg())
+
+	keys := during.jwks().Keys
+	require.Len(t, keys, 2)
+	require.Equal(t, parsed.Header["kid"], keys[0].Kid, "the active key is listed first")
+	require.Equal(t, before.active.id, keys[1].Kid)
+
+	after, err := loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, newKey)})
+	require.NoError(t, err)
+	_, err = parseWith(after, oldToken)
+	require.ErrorIs(t, err, errUnknownKeyID)
+}
+
+// A token that names an RSA key but claims HS256 must not be verified with the
+// public key bytes as an HMAC secret.
+func TestKeySetRejectsAlgorithmMismatch(t *testing.T) {
+	key := newRSAKey(t, 2048)
+	ks, err := loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, key)})
+	require.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": 1})
+	forged.Header["kid"] = ks.active.id
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	signed, err := forged.SignedString(der)
+	require.NoError(t, err)
+
+	_, err = parseWith(ks, signed)
+	require.Error(t, err)
+
+	// A kid-less token is not silently checked against the asymmetric key.
+	unnamed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": 1}).SignedString(key)
+	require.NoError(t, err)
+	_, err = parseWith(ks, unnamed)
+	require.ErrorIs(t, err, errUnknownKeyID)
+}
+
+func TestLoadKeySetRejectsUnusableKeys(t *testing.T) {
+	_, err := loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, newRSAKey(t, 1024))})
+	require.ErrorContains(t, err, "at least 2048 bits")
+
+	_, err = loadKeySet(config.JWTConfig{SigningKeyFile: writePEM(t, &newRSAKey(t, 2048).PublicKey)})
+	require.ErrorContains(t, err, "a private key is required")
+
+	_, err = loadKeySet(config.JWTConfig{SigningKeyFile: filepath.Join(t.TempDir(), "missing.pem")})
+	require.Error(t, err)
+
+	garbage := filepath.Join(t.TempDir(), "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a key"), 0o600))
+	_, err = loadKeySet(config.JWTConfig{SigningKeyFile: garbage})
+	require.ErrorContains(t, err, "no PEM block")
+}
+
+// The kid is the RFC 7638 thumbprint; this is the worked example from §3.1.
+func TestParsePEMKeyDerivesRFC7638Kid(t *testing.T) {
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	require.NoError(t, err)
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+
+	key, err := parsePEMKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(pub)}))
+	require.NoError(t, err)
+	require.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.id)
+	require.Equal(t, "AQAB", key.jwk.E)
+	require.Nil(t, key.sign)
+}
@@ -0,0 +1,164 @@
+package authjwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/models"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+func lockoutConfig() *config.Config {
+	return &config.Config{Auth: config.AuthConfig{
+		LockoutThreshold:   3,
+		LockoutDuration:    time.Minute,
+		LockoutMaxDuration: 10 * time.Minute,
+		LockoutWindow:      15 * time.Minute,
+	}}
+}
+
+func TestLockoutDurationBacksOffAndCaps(t *testing.T) {
+	l := lockout{cfg: lockoutConfig()}
+
+	for attempts, want := range map[int]time.Duration{
+		1: 0,
+		2: 0,
+		3: time.Minute,
+		4: 2 * time.Minute,
+		5: 4 * time.Minute,
+		6: 8 * time.Minute,
+		7: 10 * time.Minute,
+		9: 10 * time.Minute,
+	} {
+		require.Equal(t, want, l.duration(attempts), attempts)
+	}
+
+	l.cfg.Auth.LockoutThreshold = 0
+	require.Zero(t, l.duration(100), "a zero threshold disables lockout")
+}
+
+func TestValidateCredentialsLocksAdminAfterThresholdAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	attempts := 0
+	var lockedUntil time.Time
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 4, Name: "Alice", Role: models.UserRoleAdmin, IsActive: true, Password: string(hashed)}, nil
+		},
+		RecordFailedLoginFunc: func(_ context.Context, id uint, since time.Time) (int, error) {
+			require.WithinDuration(t, time.Now().Add(-15*time.Minute), since, 5*time.Second)
+			require.Equal(t, uint(4), id)
+			attempts++
+			return attempts, nil
+		},
+		LockUntilFunc: func(_ context.Context, id uint, until time.Time) error {
+			lockedUntil = until
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	a := &AuthJWT{cfg: lockoutConfig(), userRepo: repo, securityEventRepo: stubSecurityEventRepo(), logRepository: logRepo}
+
+	for range 3 {
+		_, err := a.validateCredentials(context.Background(), "alice", "wrong-password")
+		require.Error(t, err)
+	}
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, repo.LockUntilCalls(), 1, "only the failure reaching the threshold locks")
+	require.WithinDuration(t, time.Now().Add(time.Minute), lockedUntil, 5*time.Second)
+
+	actions := map[models.LogAction]int{}
+	for _, call := range logRepo.CreateCalls() {
+		actions[call.Entity.Action]++
+		require.Equal(t, uint(4), *call.Entity.UserID)
+	}
+	require.Equal(t, 3, actions[models.LogActionLoginFailed])
+	require.Equal(t, 1, actions[models.LogActionLockAccount])
+}
+
+func TestValidateCredentialsDoesNotAuditRegularUserFailures(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 8, Role: models.UserRoleUser, IsActive: true, Password: string(hashed)}, nil
+		},
+		RecordFailedLoginFunc: func(context.Context, uint, time.Time) (int, error) { return 3, nil },
+		LockUntilFunc:         func(context.Context, uint, time.Time) error { return nil },
+	}
+	logRepo := &logmocks.LogRepositoryMock{}
+	a := &AuthJWT{cfg: lockoutConfig(), userRepo: repo, securityEventRepo: stubSecurityEventRepo(), logRepository: logRepo}
+
+	_, err = a.validateCredentials(context.Background(), "member", "wrong-password")
+	require.Error(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, repo.LockUntilCalls(), 1, "regular accounts are locked too")
+	require.Empty(t, logRepo.CreateCalls())
+}
+
+func TestValidateCredentialsRefusesLockedAccountEvenWithRightPassword(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Minute)
+	repo := &usermocks.UserRepositoryMock{
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 8, Role: models.UserRoleUser, IsActive: true, Password: string(hashed), FailedLoginAttempts: 3, LockedUntil: &until}, nil
+		},
+	}
+	a := &AuthJWT{cfg: lockoutConfig(), userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	user, err := a.validateCredentials(context.Background(), "member", "secret123")
+	require.Nil(t, user)
+	require.ErrorIs(t, err, errAccountLocked)
+
+	_, err = a.validateCredentials(context.Background(), "member", "wrong-password")
+	require.ErrorIs(t, err, errAccountLocked)
+	require.Empty(t, repo.RecordFailedLoginCalls(), "failures during a lockout do not extend it")
+}
+
+func TestValidateCredentialsClearsFailuresAfterLockoutLapses(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	lapsed := time.Now().Add(-time.Second)
+	repo := &usermocks.UserRepositoryMock{
+		UpdatePasswordHashFunc: func(context.Context, uint, string) error { return nil },
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 8, Role: models.UserRoleUser, IsActive: true, Password: string(hashed), FailedLoginAttempts: 3, LockedUntil: &lapsed}, nil
+		},
+		ClearFailedLoginsFunc: func(_ context.Context, id uint) error {
+			require.Equal(t, uint(8), id)
+			return nil
+		},
+	}
+	a := &AuthJWT{cfg: lockoutConfig(), userRepo: repo, securityEventRepo: stubSecurityEventRepo()}
+
+	user, err := a.validateCredentials(context.Background(), "member", "secret123")
+	require.NoError(t, err)
+	require.Len(t, repo.ClearFailedLoginsCalls(), 1)
+	require.Zero(t, user.FailedLoginAttempts)
+	require.Nil(t, user.LockedUntil)
+}
@@ -0,0 +1,172 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+// Ensure, that SessionCookiesMock does implement authjwt.SessionCookies.
+// If this is not the case, regenerate this file with moq.
+var _ authjwt.SessionCookies = &SessionCookiesMock{}
+
+// SessionCookiesMock is a mock implementation of authjwt.SessionCookies.
+//
+//	func TestSomethingThatUsesSessionCookies(t *testing.T) {
+//
+//		// make and configure a mocked authjwt.SessionCookies
+//		mockedSessionCookies := &SessionCookiesMock{
+//			ClearSessionCookiesFunc: func(c *gin.Context)  {
+//				panic("mock out the ClearSessionCookies method")
+//			},
+//			RefreshTokenFromCookieFunc: func(c *gin.Context) (string, bool) {
+//				panic("mock out the RefreshTokenFromCookie method")
+//			},
+//			SetSessionCookiesFunc: func(c *gin.Context, resp *dto.AuthResponse)  {
+//				panic("mock out the SetSessionCookies method")
+//			},
+//		}
+//
+//		// use mockedSessionCookies in code that requires authjwt.SessionCookies
+//		// and then make assertions.
+//
+//	}
+type SessionCookiesMock struct {
+	// ClearSessionCookiesFunc mocks the ClearSessionCookies method.
+	ClearSessionCookiesFunc func(c *gin.Context)
+
+	// RefreshTokenFromCookieFunc mocks the RefreshTokenFromCookie method.
+	RefreshTokenFromCookieFunc func(c *gin.Context) (string, bool)
+
+	// SetSessionCookiesFunc mocks the SetSessionCookies method.
+	SetSessionCookiesFunc func(c *gin.Context, resp *dto.AuthResponse)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ClearSessionCookies holds details about calls to the ClearSessionCookies method.
+		ClearSessionCookies []struct {
+			// C is the c argument value.
+			C *gin.Context
+		}
+		// RefreshTokenFromCookie holds details about calls to the RefreshTokenFromCookie method.
+		RefreshTokenFromCookie []struct {
+			// C is the c argument value.
+			C *gin.Context
+		}
+		// SetSessionCookies holds details about calls to the SetSessionCookies method.
+		SetSessionCookies []struct {
+			// C is the c argument value.
+			C *gin.Context
+			// Resp is the resp argument value.
+			Resp *dto.AuthResponse
+		}
+	}
+	lockClearSessionCookies    sync.RWMutex
+	lockRefreshTokenFromCookie sync.RWMutex
+	lockSetSessionCookies      sync.RWMutex
+}
+
+// ClearSessionCookies calls ClearSessionCookiesFunc.
+func (mock *SessionCookiesMock) ClearSessionCookies(c *gin.Context) {
+	if mock.ClearSessionCookiesFunc == nil {
+		panic("SessionCookiesMock.ClearSessionCookiesFunc: method is nil but SessionCookies.ClearSessionCookies was just called")
+	}
+	callInfo := struct {
+		C *gin.Context
+	}{
+		C: c,
+	}
+	mock.lockClearSessionCookies.Lock()
+	mock.calls.ClearSessionCookies = append(mock.calls.ClearSessionCookies, callInfo)
+	mock.lockClearSessionCookies.Unlock()
+	mock.ClearSessionCookiesFunc(c)
+}
+
+// ClearSessionCookiesCalls gets all the calls that were made to ClearSessionCookies.
+// Check the length with:
+//
+//	len(mockedSessionCookies.ClearSessionCookiesCalls())
+func (mock *SessionCookiesMock) ClearSessionCookiesCalls() []struct {
+	C *gin.Context
+} {
+	var calls []struct {
+		C *gin.Context
+	}
+	mock.lockClearSessionCookies.RLock()
+	calls = mock.calls.ClearSessionCookies
+	mock.lockClearSessionCookies.RUnlock()
+	return calls
+}
+
+// RefreshTokenFromCookie calls RefreshTokenFromCookieFunc.
+func (mock *SessionCookiesMock) RefreshTokenFromCookie(c *gin.Context) (string, bool) {
+	if mock.RefreshTokenFromCookieFunc == nil {
+		panic("SessionCookiesMock.RefreshTokenFromCookieFunc: method is nil but SessionCookies.RefreshTokenFromCookie was just called")
+	}
+	callInfo := struct {
+		C *gin.Context
+	}{
+		C: c,
+	}
+	mock.lockRefreshTokenFromCookie.Lock()
+	mock.calls.RefreshTokenFromCookie = append(mock.calls.RefreshTokenFromCookie, callInfo)
+	mock.lockRefreshTokenFromCookie.Unlock()
+	return mock.RefreshTokenFromCookieFunc(c)
+}
+
+// RefreshTokenFromCookieCalls gets all the calls that were made to RefreshTokenFromCookie.
+// Check the length with:
+//
+//	len(mockedSessionCookies.RefreshTokenFromCookieCalls())
+func (mock *SessionCookiesMock) RefreshTokenFromCookieCalls() []struct {
+	C *gin.Context
+} {
+	var calls []struct {
+		C *gin.Context
+	}
+	mock.lockRefreshTokenFromCookie.RLock()
+	calls = mock.calls.RefreshTokenFromCookie
+	mock.lockRefreshTokenFromCookie.RUnlock()
+	return calls
+}
+
+// SetSessionCookies calls SetSessionCookiesFunc.
+func (mock *SessionCookiesMock) SetSessionCookies(c *gin.Context, resp *dto.AuthResponse) {
+	if mock.SetSessionCookiesFunc == nil {
+		panic("SessionCookiesMock.SetSessionCookiesFunc: method is nil but SessionCookies.SetSessionCookies was just called")
+	}
+	callInfo := struct {
+		C    *gin.Context
+		Resp *dto.AuthResponse
+	}{
+		C:    c,
+		Resp: resp,
+	}
+	mock.lockSetSessionCookies.Lock()
+	mock.calls.SetSessionCookies = append(mock.calls.SetSessionCookies, callInfo)
+	mock.lockSetSessionCookies.Unlock()
+	mock.SetSessionCookiesFunc(c, resp)
+}
+
+// SetSessionCookiesCalls gets all the calls that were made to SetSessionCookies.
+// Check the length with:
+//
+//	len(mockedSessionCookies.SetSessionCookiesCalls())
+func (mock *SessionCookiesMock) SetSessionCookiesCalls() []struct {
+	C    *gin.Context
+	Resp *dto.AuthResponse
+} {
+	var calls []struct {
+		C    *gin.Context
+		Resp *dto.AuthResponse
+	}
+	mock.lockSetSessionCookies.RLock()
+	calls = mock.calls.SetSessionCookies
+	mock.lockSetSessionCookies.RUnlock()
+	return calls
+}
@@ -16,6 +16,9 @@ var Module = fx.Options(
 		controller.NewAuthController,
 		service.NewAuthService,
 		jwtauth.NewAuthJWT,
+		jwtauth.NewDirectoryAuthenticator,
+		jwtauth.NewAuthCache,
+		jwtauth.NewSessionCookies,
 		fx.Annotate(
 			NewRoutes,
 			fx.As(new(routes.Registrar)),
@@ -4,15 +4,18 @@ package auth
 import (
 	"github.com/PhantomX7/athleton/internal/modules/auth/controller"
 	"github.com/PhantomX7/athleton/internal/routes"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
 )
 
 type routeRegistrar struct {
 	controller controller.AuthController
+	cfg        *config.Config
 }
 
 // NewRoutes constructs the authentication route registrar.
-func NewRoutes(controller controller.AuthController) routes.Registrar {
-	return &routeRegistrar{controller: controller}
+func NewRoutes(controller controller.AuthController, cfg *config.Config) routes.Registrar {
+	return &routeRegistrar{controller: controller, cfg: cfg}
 }
 
 // RegisterRoutes mounts the authentication endpoints.
@@ -27,9 +30,55 @@ func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
 	publicAuth.POST("/register", ctx.MW.AuthRateLimiter(), r.controller.Register)
 	publicAuth.POST("/login", ctx.MW.AuthRateLimiter(), ctx.MW.LoginHandler())
 	publicAuth.POST("/refresh", ctx.MW.RefreshRateLimiter(), r.controller.Refresh)
+	publicAuth.POST("/forgot-password", ctx.MW.AuthRateLimiter(), r.controller.ForgotPassword)
+	publicAuth.POST("/reset-password", ctx.MW.AuthRateLimiter(), r.controller.ResetPassword)
+	publicAuth.POST("/verify-email", ctx.MW.AuthRateLimiter(), r.controller.VerifyEmail)
+	publicAuth.POST("/confirm-email-change", ctx.MW.AuthRateLimiter(), r.controller.ConfirmEmailChange)
+	publicAuth.POST("/resend-verification", ctx.MW.AuthRateLimiter(), r.controller.ResendVerification)
+	publicAuth.POST("/magic-link", ctx.MW.AuthRateLimiter(), r.controller.RequestMagicLink)
+	publicAuth.POST("/magic-link/consume", ctx.MW.AuthRateLimiter(), r.controller.ConsumeMagicLink)
+	// Posted the token POST /auth/me/export returns, so it may arrive without
+	// a session; the token alone grants the download.
+	publicAuth.POST("/me/export/download", ctx.MW.AuthRateLimiter(), r.controller.DownloadDataExport)
 
-	privateAuth := ctx.Root.Group("/auth", ctx.MW.RequireAuth())
+	// Key discovery for services that verify our access tokens.
+	ctx.WellKnown.GET("/jwks.json", r.controller.JWKS)
+
+	// Account self-service takes a session, never an API key.
+	privateAuth := ctx.Root.Group("/auth", ctx.MW.RequireSessionAuth())
 	privateAuth.GET("/me", r.controller.GetMe)
-	privateAuth.POST("/change-password", r.controller.ChangePassword)
+	privateAuth.PATCH("/me", ctx.MW.ForbidImpersonation(), r.controller.UpdateMe)
+	// Erasure is confirmed with the password, a check rate-limited like login.
+	privateAuth.DELETE("/me", ctx.MW.AuthRateLimiter(), ctx.MW.ForbidImpersonation(), r.controller.DeleteMe)
+	privateAuth.POST("/me/export",
+		ctx.MW.ForbidImpersonation(),
+		ctx.MW.RequireRecentAuth(r.cfg.Auth.ReauthenticationWindow),
+		r.controller.RequestDataExport,
+	)
+	privateAuth.POST("/change-password", ctx.MW.ForbidImpersonation(), r.controller.ChangePassword)
+	// Whoever controls the address can reset the password, so moving it
+	// takes the same step-up as other account-takeover-grade operations.
+	privateAuth.POST("/change-email",
+		ctx.MW.ForbidImpersonation(),
+		ctx.MW.RequireRecentAuth(r.cfg.Auth.ReauthenticationWindow),
+		r.controller.ChangeEmail,
+	)
+	// Step-up for RequireRecentAuth routes. Rate-limited like login: it is a
+	// password check that a hijacked session could otherwise hammer.
+	privateAuth.POST("/reauthenticate", ctx.MW.AuthRateLimiter(), ctx.MW.ForbidImpersonation(), r.controller.Reauthenticate)
 	privateAuth.POST("/logout", r.controller.Logout)
+	privateAuth.GET("/sessions", r.controller.ListSessions)
+	// An impersonating support user may look at the target's sessions but
+	// not sign the target out.
+	privateAuth.DELETE("/sessions/:id", ctx.MW.ForbidImpersonation(), r.controller.RevokeSession)
+	privateAuth.POST("/sessions/revoke-others", ctx.MW.ForbidImpersonation(), r.controller.RevokeOtherSessions)
+	privateAuth.POST("/impersonation/end", r.controller.EndImpersonation)
+
+	// Impersonation is started from the admin surface but minted here, where
+	// the token machinery lives. An impersonation token can never start another.
+	ctx.Admin.POST("/user/:id/impersonate",
+		ctx.MW.ForbidImpersonation(),
+		ctx.MW.RequirePermission(permissions.UserImpersonate),
+		r.controller.Impersonate,
+	)
 }
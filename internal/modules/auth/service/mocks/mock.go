@@ -9,6 +9,7 @@ import (
 
 	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/modules/auth/service"
+	"github.com/google/uuid"
 )
 
 // Ensure, that AuthServiceMock does implement service.AuthService.
@@ -21,21 +22,78 @@ var _ service.AuthService = &AuthServiceMock{}
 //
 //		// make and configure a mocked service.AuthService
 //		mockedAuthService := &AuthServiceMock{
+//			ChangeEmailFunc: func(ctx context.Context, req *dto.ChangeEmailRequest) error {
+//				panic("mock out the ChangeEmail method")
+//			},
 //			ChangePasswordFunc: func(ctx context.Context, req *dto.ChangePasswordRequest) error {
 //				panic("mock out the ChangePassword method")
 //			},
+//			ConfirmEmailChangeFunc: func(ctx context.Context, req *dto.ConfirmEmailChangeRequest) error {
+//				panic("mock out the ConfirmEmailChange method")
+//			},
+//			ConsumeMagicLinkFunc: func(ctx context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error) {
+//				panic("mock out the ConsumeMagicLink method")
+//			},
+//			DeleteMeFunc: func(ctx context.Context, req *dto.DeleteAccountRequest) error {
+//				panic("mock out the DeleteMe method")
+//			},
+//			DownloadDataExportFunc: func(ctx context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error) {
+//				panic("mock out the DownloadDataExport method")
+//			},
+//			EndImpersonationFunc: func(ctx context.Context) error {
+//				panic("mock out the EndImpersonation method")
+//			},
+//			ForgotPasswordFunc: func(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+//				panic("mock out the ForgotPassword method")
+//			},
+//			GetJWKSFunc: func(ctx context.Context) dto.JWKSResponse {
+//				panic("mock out the GetJWKS method")
+//			},
 //			GetMeFunc: func(ctx context.Context) (*dto.MeResponse, error) {
 //				panic("mock out the GetMe method")
 //			},
+//			ImpersonateFunc: func(ctx context.Context, userID uint) (*dto.ImpersonationResponse, error) {
+//				panic("mock out the Impersonate method")
+//			},
+//			ListSessionsFunc: func(ctx context.Context) ([]dto.SessionResponse, error) {
+//				panic("mock out the ListSessions method")
+//			},
 //			LogoutFunc: func(ctx context.Context, req *dto.LogoutRequest) error {
 //				panic("mock out the Logout method")
 //			},
+//			ReauthenticateFunc: func(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+//				panic("mock out the Reauthenticate method")
+//			},
 //			RefreshFunc: func(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error) {
 //				panic("mock out the Refresh method")
 //			},
 //			RegisterFunc: func(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
 //				panic("mock out the Register method")
 //			},
+//			RequestDataExportFunc: func(ctx context.Context) (*dto.DataExportResponse, error) {
+//				panic("mock out the RequestDataExport method")
+//			},
+//			RequestMagicLinkFunc: func(ctx context.Context, req *dto.MagicLinkRequest) error {
+//				panic("mock out the RequestMagicLink method")
+//			},
+//			ResendVerificationFunc: func(ctx context.Context, req *dto.ResendVerificationRequest) error {
+//				panic("mock out the ResendVerification method")
+//			},
+//			ResetPasswordFunc: func(ctx context.Context, req *dto.ResetPasswordRequest) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//			RevokeOtherSessionsFunc: func(ctx context.Context) error {
+//				panic("mock out the RevokeOtherSessions method")
+//			},
+//			RevokeSessionFunc: func(ctx context.Context, sessionID uuid.UUID) error {
+//				panic("mock out the RevokeSession method")
+//			},
+//			UpdateMeFunc: func(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error) {
+//				panic("mock out the UpdateMe method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, req *dto.VerifyEmailRequest) error {
+//				panic("mock out the VerifyEmail method")
+//			},
 //		}
 //
 //		// use mockedAuthService in code that requires service.AuthService
@@ -43,23 +101,87 @@ var _ service.AuthService = &AuthServiceMock{}
 //
 //	}
 type AuthServiceMock struct {
+	// ChangeEmailFunc mocks the ChangeEmail method.
+	ChangeEmailFunc func(ctx context.Context, req *dto.ChangeEmailRequest) error
+
 	// ChangePasswordFunc mocks the ChangePassword method.
 	ChangePasswordFunc func(ctx context.Context, req *dto.ChangePasswordRequest) error
 
+	// ConfirmEmailChangeFunc mocks the ConfirmEmailChange method.
+	ConfirmEmailChangeFunc func(ctx context.Context, req *dto.ConfirmEmailChangeRequest) error
+
+	// ConsumeMagicLinkFunc mocks the ConsumeMagicLink method.
+	ConsumeMagicLinkFunc func(ctx context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error)
+
+	// DeleteMeFunc mocks the DeleteMe method.
+	DeleteMeFunc func(ctx context.Context, req *dto.DeleteAccountRequest) error
+
+	// DownloadDataExportFunc mocks the DownloadDataExport method.
+	DownloadDataExportFunc func(ctx context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error)
+
+	// EndImpersonationFunc mocks the EndImpersonation method.
+	EndImpersonationFunc func(ctx context.Context) error
+
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx context.Context, req *dto.ForgotPasswordRequest) error
+
+	// GetJWKSFunc mocks the GetJWKS method.
+	GetJWKSFunc func(ctx context.Context) dto.JWKSResponse
+
 	// GetMeFunc mocks the GetMe method.
 	GetMeFunc func(ctx context.Context) (*dto.MeResponse, error)
 
+	// ImpersonateFunc mocks the Impersonate method.
+	ImpersonateFunc func(ctx context.Context, userID uint) (*dto.ImpersonationResponse, error)
+
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context) ([]dto.SessionResponse, error)
+
 	// LogoutFunc mocks the Logout method.
 	LogoutFunc func(ctx context.Context, req *dto.LogoutRequest) error
 
+	// ReauthenticateFunc mocks the Reauthenticate method.
+	ReauthenticateFunc func(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error)
+
 	// RefreshFunc mocks the Refresh method.
 	RefreshFunc func(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error)
 
 	// RegisterFunc mocks the Register method.
 	RegisterFunc func(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error)
 
+	// RequestDataExportFunc mocks the RequestDataExport method.
+	RequestDataExportFunc func(ctx context.Context) (*dto.DataExportResponse, error)
+
+	// RequestMagicLinkFunc mocks the RequestMagicLink method.
+	RequestMagicLinkFunc func(ctx context.Context, req *dto.MagicLinkRequest) error
+
+	// ResendVerificationFunc mocks the ResendVerification method.
+	ResendVerificationFunc func(ctx context.Context, req *dto.ResendVerificationRequest) error
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, req *dto.ResetPasswordRequest) error
+
+	// RevokeOtherSessionsFunc mocks the RevokeOtherSessions method.
+	RevokeOtherSessionsFunc func(ctx context.Context) error
+
+	// RevokeSessionFunc mocks the RevokeSession method.
+	RevokeSessionFunc func(ctx context.Context, sessionID uuid.UUID) error
+
+	// UpdateMeFunc mocks the UpdateMe method.
+	UpdateMeFunc func(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error)
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx context.Context, req *dto.VerifyEmailRequest) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ChangeEmail holds details about calls to the ChangeEmail method.
+		ChangeEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ChangeEmailRequest
+		}
 		// ChangePassword holds details about calls to the ChangePassword method.
 		ChangePassword []struct {
 			// Ctx is the ctx argument value.
@@ -67,11 +189,68 @@ type AuthServiceMock struct {
 			// Req is the req argument value.
 			Req *dto.ChangePasswordRequest
 		}
+		// ConfirmEmailChange holds details about calls to the ConfirmEmailChange method.
+		ConfirmEmailChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ConfirmEmailChangeRequest
+		}
+		// ConsumeMagicLink holds details about calls to the ConsumeMagicLink method.
+		ConsumeMagicLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ConsumeMagicLinkRequest
+		}
+		// DeleteMe holds details about calls to the DeleteMe method.
+		DeleteMe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.DeleteAccountRequest
+		}
+		// DownloadDataExport holds details about calls to the DownloadDataExport method.
+		DownloadDataExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.DownloadDataExportRequest
+		}
+		// EndImpersonation holds details about calls to the EndImpersonation method.
+		EndImpersonation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ForgotPasswordRequest
+		}
+		// GetJWKS holds details about calls to the GetJWKS method.
+		GetJWKS []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetMe holds details about calls to the GetMe method.
 		GetMe []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// Impersonate holds details about calls to the Impersonate method.
+		Impersonate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Logout holds details about calls to the Logout method.
 		Logout []struct {
 			// Ctx is the ctx argument value.
@@ -79,6 +258,13 @@ type AuthServiceMock struct {
 			// Req is the req argument value.
 			Req *dto.LogoutRequest
 		}
+		// Reauthenticate holds details about calls to the Reauthenticate method.
+		Reauthenticate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ReauthenticateRequest
+		}
 		// Refresh holds details about calls to the Refresh method.
 		Refresh []struct {
 			// Ctx is the ctx argument value.
@@ -93,12 +279,119 @@ type AuthServiceMock struct {
 			// Req is the req argument value.
 			Req *dto.RegisterRequest
 		}
+		// RequestDataExport holds details about calls to the RequestDataExport method.
+		RequestDataExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RequestMagicLink holds details about calls to the RequestMagicLink method.
+		RequestMagicLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.MagicLinkRequest
+		}
+		// ResendVerification holds details about calls to the ResendVerification method.
+		ResendVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ResendVerificationRequest
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.ResetPasswordRequest
+		}
+		// RevokeOtherSessions holds details about calls to the RevokeOtherSessions method.
+		RevokeOtherSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RevokeSession holds details about calls to the RevokeSession method.
+		RevokeSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SessionID is the sessionID argument value.
+			SessionID uuid.UUID
+		}
+		// UpdateMe holds details about calls to the UpdateMe method.
+		UpdateMe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.UpdateProfileRequest
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.VerifyEmailRequest
+		}
 	}
-	lockChangePassword sync.RWMutex
-	lockGetMe          sync.RWMutex
-	lockLogout         sync.RWMutex
-	lockRefresh        sync.RWMutex
-	lockRegister       sync.RWMutex
+	lockChangeEmail         sync.RWMutex
+	lockChangePassword      sync.RWMutex
+	lockConfirmEmailChange  sync.RWMutex
+	lockConsumeMagicLink    sync.RWMutex
+	lockDeleteMe            sync.RWMutex
+	lockDownloadDataExport  sync.RWMutex
+	lockEndImpersonation    sync.RWMutex
+	lockForgotPassword      sync.RWMutex
+	lockGetJWKS             sync.RWMutex
+	lockGetMe               sync.RWMutex
+	lockImpersonate         sync.RWMutex
+	lockListSessions        sync.RWMutex
+	lockLogout              sync.RWMutex
+	lockReauthenticate      sync.RWMutex
+	lockRefresh             sync.RWMutex
+	lockRegister            sync.RWMutex
+	lockRequestDataExport   sync.RWMutex
+	lockRequestMagicLink    sync.RWMutex
+	lockResendVerification  sync.RWMutex
+	lockResetPassword       sync.RWMutex
+	lockRevokeOtherSessions sync.RWMutex
+	lockRevokeSession       sync.RWMutex
+	lockUpdateMe            sync.RWMutex
+	lockVerifyEmail         sync.RWMutex
+}
+
+// ChangeEmail calls ChangeEmailFunc.
+func (mock *AuthServiceMock) ChangeEmail(ctx context.Context, req *dto.ChangeEmailRequest) error {
+	if mock.ChangeEmailFunc == nil {
+		panic("AuthServiceMock.ChangeEmailFunc: method is nil but AuthService.ChangeEmail was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ChangeEmailRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockChangeEmail.Lock()
+	mock.calls.ChangeEmail = append(mock.calls.ChangeEmail, callInfo)
+	mock.lockChangeEmail.Unlock()
+	return mock.ChangeEmailFunc(ctx, req)
+}
+
+// ChangeEmailCalls gets all the calls that were made to ChangeEmail.
+// Check the length with:
+//
+//	len(mockedAuthService.ChangeEmailCalls())
+func (mock *AuthServiceMock) ChangeEmailCalls() []struct {
+	Ctx context.Context
+	Req *dto.ChangeEmailRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ChangeEmailRequest
+	}
+	mock.lockChangeEmail.RLock()
+	calls = mock.calls.ChangeEmail
+	mock.lockChangeEmail.RUnlock()
+	return calls
 }
 
 // ChangePassword calls ChangePasswordFunc.
@@ -137,6 +430,250 @@ func (mock *AuthServiceMock) ChangePasswordCalls() []struct {
 	return calls
 }
 
+// ConfirmEmailChange calls ConfirmEmailChangeFunc.
+func (mock *AuthServiceMock) ConfirmEmailChange(ctx context.Context, req *dto.ConfirmEmailChangeRequest) error {
+	if mock.ConfirmEmailChangeFunc == nil {
+		panic("AuthServiceMock.ConfirmEmailChangeFunc: method is nil but AuthService.ConfirmEmailChange was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ConfirmEmailChangeRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockConfirmEmailChange.Lock()
+	mock.calls.ConfirmEmailChange = append(mock.calls.ConfirmEmailChange, callInfo)
+	mock.lockConfirmEmailChange.Unlock()
+	return mock.ConfirmEmailChangeFunc(ctx, req)
+}
+
+// ConfirmEmailChangeCalls gets all the calls that were made to ConfirmEmailChange.
+// Check the length with:
+//
+//	len(mockedAuthService.ConfirmEmailChangeCalls())
+func (mock *AuthServiceMock) ConfirmEmailChangeCalls() []struct {
+	Ctx context.Context
+	Req *dto.ConfirmEmailChangeRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ConfirmEmailChangeRequest
+	}
+	mock.lockConfirmEmailChange.RLock()
+	calls = mock.calls.ConfirmEmailChange
+	mock.lockConfirmEmailChange.RUnlock()
+	return calls
+}
+
+// ConsumeMagicLink calls ConsumeMagicLinkFunc.
+func (mock *AuthServiceMock) ConsumeMagicLink(ctx context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error) {
+	if mock.ConsumeMagicLinkFunc == nil {
+		panic("AuthServiceMock.ConsumeMagicLinkFunc: method is nil but AuthService.ConsumeMagicLink was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ConsumeMagicLinkRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockConsumeMagicLink.Lock()
+	mock.calls.ConsumeMagicLink = append(mock.calls.ConsumeMagicLink, callInfo)
+	mock.lockConsumeMagicLink.Unlock()
+	return mock.ConsumeMagicLinkFunc(ctx, req)
+}
+
+// ConsumeMagicLinkCalls gets all the calls that were made to ConsumeMagicLink.
+// Check the length with:
+//
+//	len(mockedAuthService.ConsumeMagicLinkCalls())
+func (mock *AuthServiceMock) ConsumeMagicLinkCalls() []struct {
+	Ctx context.Context
+	Req *dto.ConsumeMagicLinkRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ConsumeMagicLinkRequest
+	}
+	mock.lockConsumeMagicLink.RLock()
+	calls = mock.calls.ConsumeMagicLink
+	mock.lockConsumeMagicLink.RUnlock()
+	return calls
+}
+
+// DeleteMe calls DeleteMeFunc.
+func (mock *AuthServiceMock) DeleteMe(ctx context.Context, req *dto.DeleteAccountRequest) error {
+	if mock.DeleteMeFunc == nil {
+		panic("AuthServiceMock.DeleteMeFunc: method is nil but AuthService.DeleteMe was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.DeleteAccountRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDeleteMe.Lock()
+	mock.calls.DeleteMe = append(mock.calls.DeleteMe, callInfo)
+	mock.lockDeleteMe.Unlock()
+	return mock.DeleteMeFunc(ctx, req)
+}
+
+// DeleteMeCalls gets all the calls that were made to DeleteMe.
+// Check the length with:
+//
+//	len(mockedAuthService.DeleteMeCalls())
+func (mock *AuthServiceMock) DeleteMeCalls() []struct {
+	Ctx context.Context
+	Req *dto.DeleteAccountRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.DeleteAccountRequest
+	}
+	mock.lockDeleteMe.RLock()
+	calls = mock.calls.DeleteMe
+	mock.lockDeleteMe.RUnlock()
+	return calls
+}
+
+// DownloadDataExport calls DownloadDataExportFunc.
+func (mock *AuthServiceMock) DownloadDataExport(ctx context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error) {
+	if mock.DownloadDataExportFunc == nil {
+		panic("AuthServiceMock.DownloadDataExportFunc: method is nil but AuthService.DownloadDataExport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.DownloadDataExportRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDownloadDataExport.Lock()
+	mock.calls.DownloadDataExport = append(mock.calls.DownloadDataExport, callInfo)
+	mock.lockDownloadDataExport.Unlock()
+	return mock.DownloadDataExportFunc(ctx, req)
+}
+
+// DownloadDataExportCalls gets all the calls that were made to DownloadDataExport.
+// Check the length with:
+//
+//	len(mockedAuthService.DownloadDataExportCalls())
+func (mock *AuthServiceMock) DownloadDataExportCalls() []struct {
+	Ctx context.Context
+	Req *dto.DownloadDataExportRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.DownloadDataExportRequest
+	}
+	mock.lockDownloadDataExport.RLock()
+	calls = mock.calls.DownloadDataExport
+	mock.lockDownloadDataExport.RUnlock()
+	return calls
+}
+
+// EndImpersonation calls EndImpersonationFunc.
+func (mock *AuthServiceMock) EndImpersonation(ctx context.Context) error {
+	if mock.EndImpersonationFunc == nil {
+		panic("AuthServiceMock.EndImpersonationFunc: method is nil but AuthService.EndImpersonation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockEndImpersonation.Lock()
+	mock.calls.EndImpersonation = append(mock.calls.EndImpersonation, callInfo)
+	mock.lockEndImpersonation.Unlock()
+	return mock.EndImpersonationFunc(ctx)
+}
+
+// EndImpersonationCalls gets all the calls that were made to EndImpersonation.
+// Check the length with:
+//
+//	len(mockedAuthService.EndImpersonationCalls())
+func (mock *AuthServiceMock) EndImpersonationCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockEndImpersonation.RLock()
+	calls = mock.calls.EndImpersonation
+	mock.lockEndImpersonation.RUnlock()
+	return calls
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *AuthServiceMock) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	if mock.ForgotPasswordFunc == nil {
+		panic("AuthServiceMock.ForgotPasswordFunc: method is nil but AuthService.ForgotPassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ForgotPasswordRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	mock.lockForgotPassword.Unlock()
+	return mock.ForgotPasswordFunc(ctx, req)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//
+//	len(mockedAuthService.ForgotPasswordCalls())
+func (mock *AuthServiceMock) ForgotPasswordCalls() []struct {
+	Ctx context.Context
+	Req *dto.ForgotPasswordRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ForgotPasswordRequest
+	}
+	mock.lockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	mock.lockForgotPassword.RUnlock()
+	return calls
+}
+
+// GetJWKS calls GetJWKSFunc.
+func (mock *AuthServiceMock) GetJWKS(ctx context.Context) dto.JWKSResponse {
+	if mock.GetJWKSFunc == nil {
+		panic("AuthServiceMock.GetJWKSFunc: method is nil but AuthService.GetJWKS was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetJWKS.Lock()
+	mock.calls.GetJWKS = append(mock.calls.GetJWKS, callInfo)
+	mock.lockGetJWKS.Unlock()
+	return mock.GetJWKSFunc(ctx)
+}
+
+// GetJWKSCalls gets all the calls that were made to GetJWKS.
+// Check the length with:
+//
+//	len(mockedAuthService.GetJWKSCalls())
+func (mock *AuthServiceMock) GetJWKSCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetJWKS.RLock()
+	calls = mock.calls.GetJWKS
+	mock.lockGetJWKS.RUnlock()
+	return calls
+}
+
 // GetMe calls GetMeFunc.
 func (mock *AuthServiceMock) GetMe(ctx context.Context) (*dto.MeResponse, error) {
 	if mock.GetMeFunc == nil {
@@ -169,6 +706,74 @@ func (mock *AuthServiceMock) GetMeCalls() []struct {
 	return calls
 }
 
+// Impersonate calls ImpersonateFunc.
+func (mock *AuthServiceMock) Impersonate(ctx context.Context, userID uint) (*dto.ImpersonationResponse, error) {
+	if mock.ImpersonateFunc == nil {
+		panic("AuthServiceMock.ImpersonateFunc: method is nil but AuthService.Impersonate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockImpersonate.Lock()
+	mock.calls.Impersonate = append(mock.calls.Impersonate, callInfo)
+	mock.lockImpersonate.Unlock()
+	return mock.ImpersonateFunc(ctx, userID)
+}
+
+// ImpersonateCalls gets all the calls that were made to Impersonate.
+// Check the length with:
+//
+//	len(mockedAuthService.ImpersonateCalls())
+func (mock *AuthServiceMock) ImpersonateCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockImpersonate.RLock()
+	calls = mock.calls.Impersonate
+	mock.lockImpersonate.RUnlock()
+	return calls
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *AuthServiceMock) ListSessions(ctx context.Context) ([]dto.SessionResponse, error) {
+	if mock.ListSessionsFunc == nil {
+		panic("AuthServiceMock.ListSessionsFunc: method is nil but AuthService.ListSessions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	return mock.ListSessionsFunc(ctx)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedAuthService.ListSessionsCalls())
+func (mock *AuthServiceMock) ListSessionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
+}
+
 // Logout calls LogoutFunc.
 func (mock *AuthServiceMock) Logout(ctx context.Context, req *dto.LogoutRequest) error {
 	if mock.LogoutFunc == nil {
@@ -205,6 +810,42 @@ func (mock *AuthServiceMock) LogoutCalls() []struct {
 	return calls
 }
 
+// Reauthenticate calls ReauthenticateFunc.
+func (mock *AuthServiceMock) Reauthenticate(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+	if mock.ReauthenticateFunc == nil {
+		panic("AuthServiceMock.ReauthenticateFunc: method is nil but AuthService.Reauthenticate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ReauthenticateRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockReauthenticate.Lock()
+	mock.calls.Reauthenticate = append(mock.calls.Reauthenticate, callInfo)
+	mock.lockReauthenticate.Unlock()
+	return mock.ReauthenticateFunc(ctx, req)
+}
+
+// ReauthenticateCalls gets all the calls that were made to Reauthenticate.
+// Check the length with:
+//
+//	len(mockedAuthService.ReauthenticateCalls())
+func (mock *AuthServiceMock) ReauthenticateCalls() []struct {
+	Ctx context.Context
+	Req *dto.ReauthenticateRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ReauthenticateRequest
+	}
+	mock.lockReauthenticate.RLock()
+	calls = mock.calls.Reauthenticate
+	mock.lockReauthenticate.RUnlock()
+	return calls
+}
+
 // Refresh calls RefreshFunc.
 func (mock *AuthServiceMock) Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error) {
 	if mock.RefreshFunc == nil {
@@ -276,3 +917,283 @@ func (mock *AuthServiceMock) RegisterCalls() []struct {
 	mock.lockRegister.RUnlock()
 	return calls
 }
+
+// RequestDataExport calls RequestDataExportFunc.
+func (mock *AuthServiceMock) RequestDataExport(ctx context.Context) (*dto.DataExportResponse, error) {
+	if mock.RequestDataExportFunc == nil {
+		panic("AuthServiceMock.RequestDataExportFunc: method is nil but AuthService.RequestDataExport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRequestDataExport.Lock()
+	mock.calls.RequestDataExport = append(mock.calls.RequestDataExport, callInfo)
+	mock.lockRequestDataExport.Unlock()
+	return mock.RequestDataExportFunc(ctx)
+}
+
+// RequestDataExportCalls gets all the calls that were made to RequestDataExport.
+// Check the length with:
+//
+//	len(mockedAuthService.RequestDataExportCalls())
+func (mock *AuthServiceMock) RequestDataExportCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRequestDataExport.RLock()
+	calls = mock.calls.RequestDataExport
+	mock.lockRequestDataExport.RUnlock()
+	return calls
+}
+
+// RequestMagicLink calls RequestMagicLinkFunc.
+func (mock *AuthServiceMock) RequestMagicLink(ctx context.Context, req *dto.MagicLinkRequest) error {
+	if mock.RequestMagicLinkFunc == nil {
+		panic("AuthServiceMock.RequestMagicLinkFunc: method is nil but AuthService.RequestMagicLink was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.MagicLinkRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRequestMagicLink.Lock()
+	mock.calls.RequestMagicLink = append(mock.calls.RequestMagicLink, callInfo)
+	mock.lockRequestMagicLink.Unlock()
+	return mock.RequestMagicLinkFunc(ctx, req)
+}
+
+// RequestMagicLinkCalls gets all the calls that were made to RequestMagicLink.
+// Check the length with:
+//
+//	len(mockedAuthService.RequestMagicLinkCalls())
+func (mock *AuthServiceMock) RequestMagicLinkCalls() []struct {
+	Ctx context.Context
+	Req *dto.MagicLinkRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.MagicLinkRequest
+	}
+	mock.lockRequestMagicLink.RLock()
+	calls = mock.calls.RequestMagicLink
+	mock.lockRequestMagicLink.RUnlock()
+	return calls
+}
+
+// ResendVerification calls ResendVerificationFunc.
+func (mock *AuthServiceMock) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error {
+	if mock.ResendVerificationFunc == nil {
+		panic("AuthServiceMock.ResendVerificationFunc: method is nil but AuthService.ResendVerification was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ResendVerificationRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockResendVerification.Lock()
+	mock.calls.ResendVerification = append(mock.calls.ResendVerification, callInfo)
+	mock.lockResendVerification.Unlock()
+	return mock.ResendVerificationFunc(ctx, req)
+}
+
+// ResendVerificationCalls gets all the calls that were made to ResendVerification.
+// Check the length with:
+//
+//	len(mockedAuthService.ResendVerificationCalls())
+func (mock *AuthServiceMock) ResendVerificationCalls() []struct {
+	Ctx context.Context
+	Req *dto.ResendVerificationRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ResendVerificationRequest
+	}
+	mock.lockResendVerification.RLock()
+	calls = mock.calls.ResendVerification
+	mock.lockResendVerification.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *AuthServiceMock) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("AuthServiceMock.ResetPasswordFunc: method is nil but AuthService.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.ResetPasswordRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, req)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedAuthService.ResetPasswordCalls())
+func (mock *AuthServiceMock) ResetPasswordCalls() []struct {
+	Ctx context.Context
+	Req *dto.ResetPasswordRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.ResetPasswordRequest
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
+// RevokeOtherSessions calls RevokeOtherSessionsFunc.
+func (mock *AuthServiceMock) RevokeOtherSessions(ctx context.Context) error {
+	if mock.RevokeOtherSessionsFunc == nil {
+		panic("AuthServiceMock.RevokeOtherSessionsFunc: method is nil but AuthService.RevokeOtherSessions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRevokeOtherSessions.Lock()
+	mock.calls.RevokeOtherSessions = append(mock.calls.RevokeOtherSessions, callInfo)
+	mock.lockRevokeOtherSessions.Unlock()
+	return mock.RevokeOtherSessionsFunc(ctx)
+}
+
+// RevokeOtherSessionsCalls gets all the calls that were made to RevokeOtherSessions.
+// Check the length with:
+//
+//	len(mockedAuthService.RevokeOtherSessionsCalls())
+func (mock *AuthServiceMock) RevokeOtherSessionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRevokeOtherSessions.RLock()
+	calls = mock.calls.RevokeOtherSessions
+	mock.lockRevokeOtherSessions.RUnlock()
+	return calls
+}
+
+// RevokeSession calls RevokeSessionFunc.
+func (mock *AuthServiceMock) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	if mock.RevokeSessionFunc == nil {
+		panic("AuthServiceMock.RevokeSessionFunc: method is nil but AuthService.RevokeSession was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		SessionID uuid.UUID
+	}{
+		Ctx:       ctx,
+		SessionID: sessionID,
+	}
+	mock.lockRevokeSession.Lock()
+	mock.calls.RevokeSession = append(mock.calls.RevokeSession, callInfo)
+	mock.lockRevokeSession.Unlock()
+	return mock.RevokeSessionFunc(ctx, sessionID)
+}
+
+// RevokeSessionCalls gets all the calls that were made to RevokeSession.
+// Check the length with:
+//
+//	len(mockedAuthService.RevokeSessionCalls())
+func (mock *AuthServiceMock) RevokeSessionCalls() []struct {
+	Ctx       context.Context
+	SessionID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		SessionID uuid.UUID
+	}
+	mock.lockRevokeSession.RLock()
+	calls = mock.calls.RevokeSession
+	mock.lockRevokeSession.RUnlock()
+	return calls
+}
+
+// UpdateMe calls UpdateMeFunc.
+func (mock *AuthServiceMock) UpdateMe(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error) {
+	if mock.UpdateMeFunc == nil {
+		panic("AuthServiceMock.UpdateMeFunc: method is nil but AuthService.UpdateMe was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.UpdateProfileRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUpdateMe.Lock()
+	mock.calls.UpdateMe = append(mock.calls.UpdateMe, callInfo)
+	mock.lockUpdateMe.Unlock()
+	return mock.UpdateMeFunc(ctx, req)
+}
+
+// UpdateMeCalls gets all the calls that were made to UpdateMe.
+// Check the length with:
+//
+//	len(mockedAuthService.UpdateMeCalls())
+func (mock *AuthServiceMock) UpdateMeCalls() []struct {
+	Ctx context.Context
+	Req *dto.UpdateProfileRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.UpdateProfileRequest
+	}
+	mock.lockUpdateMe.RLock()
+	calls = mock.calls.UpdateMe
+	mock.lockUpdateMe.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *AuthServiceMock) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	if mock.VerifyEmailFunc == nil {
+		panic("AuthServiceMock.VerifyEmailFunc: method is nil but AuthService.VerifyEmail was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.VerifyEmailRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	mock.lockVerifyEmail.Unlock()
+	return mock.VerifyEmailFunc(ctx, req)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//
+//	len(mockedAuthService.VerifyEmailCalls())
+func (mock *AuthServiceMock) VerifyEmailCalls() []struct {
+	Ctx context.Context
+	Req *dto.VerifyEmailRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.VerifyEmailRequest
+	}
+	mock.lockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	mock.lockVerifyEmail.RUnlock()
+	return calls
+}
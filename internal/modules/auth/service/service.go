@@ -2,26 +2,42 @@
 package service
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/PhantomX7/athleton/internal/audit"
 	"github.com/PhantomX7/athleton/internal/dto"
-	"github.com/PhantomX7/athleton/internal/generated"
 	"github.com/PhantomX7/athleton/internal/models"
 	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
 	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	oidcrepo "github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	passwordpolicy "github.com/PhantomX7/athleton/internal/modules/password_policy/service"
+	rtokenrepo "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	twofactorservice "github.com/PhantomX7/athleton/internal/modules/two_factor/service"
 	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	usertokenrepo "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
 	"github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/libs/mailer"
 	"github.com/PhantomX7/athleton/libs/transaction_manager"
-	"github.com/PhantomX7/athleton/pkg/constants/security"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/password"
 	"github.com/PhantomX7/athleton/pkg/utils"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . AuthService
@@ -29,34 +45,77 @@ import (
 // AuthService defines the interface for auth service operations
 type AuthService interface {
 	GetMe(ctx context.Context) (*dto.MeResponse, error)
+	UpdateMe(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error)
+	ChangeEmail(ctx context.Context, req *dto.ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, req *dto.ConfirmEmailChangeRequest) error
+	RequestDataExport(ctx context.Context) (*dto.DataExportResponse, error)
+	DownloadDataExport(ctx context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error)
+	DeleteMe(ctx context.Context, req *dto.DeleteAccountRequest) error
 	Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error)
 	Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error)
 	ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) error
+	Reauthenticate(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error)
 	Logout(ctx context.Context, req *dto.LogoutRequest) error
+	ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error
+	RequestMagicLink(ctx context.Context, req *dto.MagicLinkRequest) error
+	ConsumeMagicLink(ctx context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error)
+	ListSessions(ctx context.Context) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context) error
+	GetJWKS(ctx context.Context) dto.JWKSResponse
+	Impersonate(ctx context.Context, userID uint) (*dto.ImpersonationResponse, error)
+	EndImpersonation(ctx context.Context) error
 }
 
 type authService struct {
-	userRepo      userrepo.UserRepository
-	logRepository logRepository.LogRepository
-	authJWT       *authjwt.AuthJWT
-	casbinClient  casbin.Client
-	txManager     transaction_manager.TransactionManager
+	cfg               *config.Config
+	userRepo          userrepo.UserRepository
+	refreshTokenRepo  rtokenrepo.RefreshTokenRepository
+	userTokenRepo     usertokenrepo.UserTokenRepository
+	logRepository     logRepository.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	identityRepo      oidcrepo.UserIdentityRepository
+	passwordPolicy    passwordpolicy.PasswordPolicy
+	authJWT           *authjwt.AuthJWT
+	twoFactorService  twofactorservice.TwoFactorService
+	casbinClient      casbin.Client
+	mailer            mailer.Mailer
+	txManager         transaction_manager.TransactionManager
 }
 
 // NewAuthService builds the auth service from its dependencies.
 func NewAuthService(
+	cfg *config.Config,
 	userRepo userrepo.UserRepository,
+	refreshTokenRepo rtokenrepo.RefreshTokenRepository,
+	userTokenRepo usertokenrepo.UserTokenRepository,
 	logRepository logRepository.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
+	identityRepo oidcrepo.UserIdentityRepository,
+	passwordPolicy passwordpolicy.PasswordPolicy,
 	authJWT *authjwt.AuthJWT,
+	twoFactorService twofactorservice.TwoFactorService,
 	casbinClient casbin.Client,
+	mailer mailer.Mailer,
 	txManager transaction_manager.TransactionManager,
 ) AuthService {
 	return &authService{
-		userRepo:      userRepo,
-		logRepository: logRepository,
-		authJWT:       authJWT,
-		casbinClient:  casbinClient,
-		txManager:     txManager,
+		cfg:               cfg,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		userTokenRepo:     userTokenRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		identityRepo:      identityRepo,
+		passwordPolicy:    passwordPolicy,
+		authJWT:           authJWT,
+		twoFactorService:  twoFactorService,
+		casbinClient:      casbinClient,
+		mailer:            mailer,
+		txManager:         txManager,
 	}
 }
 
@@ -67,7 +126,7 @@ func (s *authService) GetMe(ctx context.Context) (*dto.MeResponse, error) {
 		return nil, err
 	}
 
-	user, err := s.userRepo.FindByID(ctx, values.UserID, generated.User.AdminRole)
+	user, err := s.userRepo.FindByID(ctx, values.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -77,19 +136,517 @@ func (s *authService) GetMe(ctx context.Context) (*dto.MeResponse, error) {
 		return nil, cerrors.NewForbiddenError("user account is inactive")
 	}
 
-	// AdminRole can be nil even when AdminRoleID is set (soft-deleted role,
-	// seed drift); degrade to a role-less profile instead of panicking.
-	if user.AdminRoleID != nil && user.AdminRole != nil {
-		user.AdminRole.Permissions = s.casbinClient.GetRolePermissions(*user.AdminRoleID)
+	// The effective permissions and deny rules of each role include those
+	// inherited from parent roles. An inactive role is reported with is_active
+	// false and no permissions, since its holder is denied all of them, but
+	// with its deny rules, which still apply.
+	for i := range user.AdminRoles {
+		role := &user.AdminRoles[i]
+		role.ParentRoleID = s.casbinClient.GetRoleParent(role.ID)
+		role.SetPermissions([]string{}, []string{})
+		if role.IsActive {
+			role.SetPermissions(s.casbinClient.GetDirectRolePermissions(role.ID), s.casbinClient.GetRolePermissions(role.ID))
+		}
+		role.SetDenials(s.casbinClient.GetDirectRoleDenials(role.ID), s.casbinClient.GetRoleDenials(role.ID))
+	}
+
+	me := &dto.MeResponse{
+		UserResponse:        *user.ToResponse(),
+		MustChangePassword:  user.MustChangePassword(s.cfg.Auth.PasswordMaxAge, time.Now()),
+		EmailVerified:       user.IsEmailVerified(),
+		TwoFactorEnabled:    user.IsTwoFactorEnabled(),
+		MustEnableTwoFactor: user.MustEnableTwoFactor(s.cfg.Auth.TwoFactorRequiredForAdmins),
+	}
+	if values.ImpersonatorID != 0 {
+		me.ImpersonatorID = &values.ImpersonatorID
+	}
+	return me, nil
+}
+
+// UpdateMe applies the authenticated user's edits to their own profile and
+// returns the result. Values are trimmed, and nothing is written or audited
+// when no field actually changes.
+func (s *authService) UpdateMe(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.MeResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, cerrors.NewBadRequestError("name cannot be blank")
+		}
+		if name != user.Name {
+			user.Name = name
+			changed = append(changed, "name")
+		}
+	}
+	if req.BusinessName != nil {
+		if businessName := strings.TrimSpace(*req.BusinessName); businessName != user.BusinessName {
+			user.BusinessName = businessName
+			changed = append(changed, "business name")
+		}
+	}
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if phone == "" {
+			return nil, cerrors.NewBadRequestError("phone cannot be blank")
+		}
+		if phone != user.Phone {
+			user.Phone = phone
+			changed = append(changed, "phone")
+		}
+	}
+
+	if len(changed) > 0 {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+		s.authJWT.InvalidateUser(user.ID)
+
+		audit.Record(ctx, s.logRepository, audit.Entry{
+			Action:     models.LogActionUpdate,
+			EntityType: models.LogEntityTypeUser,
+			EntityID:   user.ID,
+			Message:    fmt.Sprintf("%s updated their profile: %s", audit.UserName(ctx), strings.Join(changed, ", ")),
+		})
+	}
+
+	return s.GetMe(ctx)
+}
+
+// ChangeEmail emails a confirmation link to req.Email and a notice to the
+// account's current address. The address only changes once the link is
+// redeemed (ConfirmEmailChange), so a mistyped address or one the user does
+// not control never takes over where password resets are sent.
+func (s *authService) ChangeEmail(ctx context.Context, req *dto.ChangeEmailRequest) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, values.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The unique tag compares the raw value; stored addresses are normalized.
+	newEmail := strings.ToLower(strings.TrimSpace(req.Email))
+	if newEmail == user.Email {
+		return cerrors.NewBadRequestError("new email must be different from current email")
+	}
+	if err := s.ensureEmailAvailable(ctx, user, newEmail); err != nil {
+		return err
+	}
+
+	var token string
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		token, err = s.storeUserToken(txCtx, &models.UserToken{
+			UserID:    user.ID,
+			Purpose:   models.UserTokenPurposeEmailChange,
+			NewEmail:  newEmail,
+			ExpiresAt: time.Now().Add(s.cfg.Auth.EmailChangeTTL),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Unlike the enumeration-safe flows the caller is signed in and waiting
+	// for this email, so a delivery failure is reported and can be retried.
+	confirm := mailer.Message{
+		To:      newEmail,
+		Subject: fmt.Sprintf("Confirm your new %s email address", s.cfg.App.Name),
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm you want to use this address for your account by opening the link below:\n\n%s\n\n"+
+				"The link expires in %s. Until then your account keeps its current address. If you did not ask for this, you can ignore this email.\n",
+			user.Name, tokenLink(s.cfg.Auth.EmailChangeURL, token), s.cfg.Auth.EmailChangeTTL),
+	}
+	if err := s.mailer.Send(ctx, confirm); err != nil {
+		return cerrors.NewInternalServerError("failed to send confirmation email", err)
+	}
+
+	notice := mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Your %s email address is being changed", s.cfg.App.Name),
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nA request was made to change the email address of your account to %s. "+
+				"It takes effect once confirmed from that address.\n\n"+
+				"If this was not you, change your password and sign out your other sessions now.\n",
+			user.Name, newEmail),
+	}
+	if err := s.mailer.Send(ctx, notice); err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to send email change notice", zap.Error(err))
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Email change requested")
+	return nil
+}
+
+// ConfirmEmailChange redeems an email-change token and moves the account to
+// the address it was issued for, marking that address verified. The address
+// is checked again, since it may have been taken since the link was sent. An
+// account whose username mirrors its email (as self-registration sets it)
+// keeps logging in with its address, so the username follows the change.
+func (s *authService) ConfirmEmailChange(ctx context.Context, req *dto.ConfirmEmailChangeRequest) error {
+	var user *models.User
+	var oldEmail string
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		userToken, err := s.userTokenRepo.ConsumeByToken(txCtx, models.UserTokenPurposeEmailChange, req.Token)
+		if err != nil {
+			if errors.Is(err, cerrors.ErrNotFound) {
+				return cerrors.NewBadRequestError("invalid or expired email change token")
+			}
+			return err
+		}
+
+		user, err = s.userRepo.FindByID(txCtx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive {
+			return cerrors.NewBadRequestError("invalid or expired email change token")
+		}
+		if err := s.ensureEmailAvailable(txCtx, user, userToken.NewEmail); err != nil {
+			return err
+		}
+
+		oldEmail = user.Email
+		if user.Username == user.Email {
+			user.Username = userToken.NewEmail
+		}
+		user.Email = userToken.NewEmail
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+
+		// Links already mailed to the old address must not outlive it.
+		for _, purpose := range []models.UserTokenPurpose{
+			models.UserTokenPurposePasswordReset,
+			models.UserTokenPurposeEmailVerification,
+			models.UserTokenPurposeMagicLink,
+		} {
+			if err := s.userTokenRepo.ConsumeAllByUserID(txCtx, user.ID, purpose); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Email address changed")
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventEmailChange, models.SecurityEventOutcomeSuccess, uuid.Nil)
+
+	// The link may be opened without a session, so attribute the entry to
+	// the account itself, as ResetPassword does.
+	auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+		UserID:       user.ID,
+		UserName:     user.Name,
+		Role:         user.Role.ToString(),
+		AdminRoleIDs: user.AdminRoleIDs(),
+		RequestID:    utils.GetRequestIDFromContext(ctx),
+	})
+	audit.Record(auditCtx, s.logRepository, audit.Entry{
+		Action:     models.LogActionChangeEmail,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   user.ID,
+		Message:    fmt.Sprintf("%s changed email from %s to %s", user.Name, oldEmail, user.Email),
+	})
+
+	return nil
+}
+
+// ensureEmailAvailable refuses email when another account already uses it,
+// as its address or, for a user whose username follows their email, as its
+// username.
+func (s *authService) ensureEmailAvailable(ctx context.Context, user *models.User, email string) error {
+	lookups := []func(context.Context, string) (*models.User, error){s.userRepo.FindByEmail}
+	if user.Username == user.Email {
+		lookups = append(lookups, s.userRepo.FindByUsername)
+	}
+
+	for _, find := range lookups {
+		other, err := find(ctx, email)
+		if err != nil {
+			if errors.Is(err, cerrors.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if other.ID != user.ID {
+			return cerrors.NewBadRequestError("email is already in use")
+		}
+	}
+	return nil
+}
+
+// RequestDataExport issues a token with which the authenticated user can
+// download an archive of their data until AUTH_DATA_EXPORT_TTL passes.
+// Requesting another token invalidates the previous one.
+func (s *authService) RequestDataExport(ctx context.Context) (*dto.DataExportResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.Auth.DataExportTTL)
+	var token string
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		token, err = s.storeUserToken(txCtx, &models.UserToken{
+			UserID:    values.UserID,
+			Purpose:   models.UserTokenPurposeDataExport,
+			ExpiresAt: expiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", values.UserID)).Info("Data export requested")
+	return &dto.DataExportResponse{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// DownloadDataExport builds the archive a data-export token unlocks: the
+// user's profile, every session, their security timeline, the audit entries
+// they acted in or that concern their account, and their linked identities.
+// The token is not consumed, so a download can be retried until it expires.
+func (s *authService) DownloadDataExport(ctx context.Context, req *dto.DownloadDataExportRequest) (*dto.DataExportArchive, error) {
+	userToken, err := s.userTokenRepo.FindActiveByToken(ctx, models.UserTokenPurposeDataExport, req.Token)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return nil, cerrors.NewBadRequestError("invalid or expired export link")
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return nil, cerrors.NewBadRequestError("invalid or expired export link")
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, cerrors.NewBadRequestError("invalid or expired export link")
+	}
+
+	sessions, err := s.refreshTokenRepo.FindAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.securityEventRepo.FindAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.logRepository.FindAllByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.identityRepo.FindAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionResponses := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, session.ToSessionResponse(uuid.Nil))
+	}
+	eventResponses := make([]dto.SecurityEventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, event.ToResponse())
+	}
+	logResponses := make([]dto.LogResponse, 0, len(logs))
+	for _, entry := range logs {
+		logResponses = append(logResponses, entry.ToResponse())
+	}
+	identityResponses := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		identityResponses = append(identityResponses, identity.ToResponse())
 	}
 
-	return &dto.MeResponse{
-		UserResponse:       *user.ToResponse(),
-		MustChangePassword: user.MustChangePassword(),
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range []struct {
+		name string
+		data any
+	}{
+		{"profile.json", user.ToResponse()},
+		{"sessions.json", sessionResponses},
+		{"security_events.json", eventResponses},
+		{"audit_logs.json", logResponses},
+		{"identities.json", identityResponses},
+	} {
+		if err := writeJSONEntry(zw, entry.name, entry.data); err != nil {
+			return nil, cerrors.NewInternalServerError("failed to build data export", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, cerrors.NewInternalServerError("failed to build data export", err)
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Data export downloaded")
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventDataExport, models.SecurityEventOutcomeSuccess, uuid.Nil)
+
+	return &dto.DataExportArchive{
+		FileName: fmt.Sprintf("data-export-%d-%s.zip", user.ID, time.Now().UTC().Format("20060102")),
+		Content:  buf.Bytes(),
 	}, nil
 }
 
-// Register creates a new user account
+// writeJSONEntry adds name to the archive holding data as indented JSON.
+func writeJSONEntry(zw *zip.Writer, name string, data any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+// deletedUserRedaction replaces the personal data of an erased account in
+// the messages of the audit entries it took part in.
+const deletedUserRedaction = "[deleted user]"
+
+// DeleteMe erases the authenticated user's account after checking its
+// password. The row is kept, so audit entries still point at it, but its
+// personal data is overwritten, it is deactivated and soft-deleted, every
+// session is revoked, outstanding emailed links stop working, the security
+// timeline and linked identities are dropped, and the name, username and
+// addresses are redacted from audit messages. Root accounts cannot erase themselves.
+func (s *authService) DeleteMe(ctx context.Context, req *dto.DeleteAccountRequest) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		user, err := s.userRepo.FindByIDForUpdate(txCtx, values.UserID)
+		if err != nil {
+			return err
+		}
+		if user.Role == models.UserRoleRoot {
+			logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Attempted root account self-deletion")
+			return cerrors.NewForbiddenError("root accounts cannot be deleted")
+		}
+		if s.authJWT.VerifyPassword(txCtx, user, req.Password) != nil {
+			logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Account deletion failed - incorrect password")
+			return cerrors.NewBadRequestError("password is incorrect")
+		}
+
+		// Audit messages quote personal data as it was when they were
+		// written, so redact every value the account has held, such as an
+		// address it has since changed, and not just the current ones.
+		// Longer values go first, so an email is not half-replaced through a
+		// username it contains.
+		personal, err := s.userRepo.FindPersonalDataHistory(txCtx, user.ID)
+		if err != nil {
+			return err
+		}
+		personal = append(personal, user.PersonalData()...)
+		slices.Sort(personal)
+		personal = slices.Compact(personal)
+		slices.SortStableFunc(personal, func(a, b string) int { return len(b) - len(a) })
+		if err := s.logRepository.RedactUser(txCtx, user.ID, personal, deletedUserRedaction); err != nil {
+			return err
+		}
+
+		anonymize(user)
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		// After the update, which records the placeholders too.
+		if err := s.userRepo.DeletePersonalDataHistory(txCtx, user.ID); err != nil {
+			return err
+		}
+		if err := s.userRepo.Delete(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.refreshTokenRepo.RevokeAllByUserID(txCtx, user.ID); err != nil {
+			return err
+		}
+		for _, purpose := range []models.UserTokenPurpose{
+			models.UserTokenPurposePasswordReset,
+			models.UserTokenPurposeEmailVerification,
+			models.UserTokenPurposeMagicLink,
+			models.UserTokenPurposeEmailChange,
+			models.UserTokenPurposeTwoFactorChallenge,
+			models.UserTokenPurposeDataExport,
+		} {
+			if err := s.userTokenRepo.ConsumeAllByUserID(txCtx, user.ID, purpose); err != nil {
+				return err
+			}
+		}
+		if err := s.identityRepo.DeleteAllByUserID(txCtx, user.ID); err != nil {
+			return err
+		}
+		return s.securityEventRepo.DeleteAllByUserID(txCtx, user.ID)
+	})
+	if err != nil {
+		return err
+	}
+	s.authJWT.InvalidateUser(values.UserID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", values.UserID)).Info("Account deleted by its owner")
+
+	// The entry must not carry the personal data just erased.
+	auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+		UserID:       values.UserID,
+		UserName:     deletedUserName,
+		Role:         values.Role,
+		AdminRoleIDs: values.AdminRoleIDs,
+		RequestID:    utils.GetRequestIDFromContext(ctx),
+	})
+	audit.Record(auditCtx, s.logRepository, audit.Entry{
+		Action:     models.LogActionDelete,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   values.UserID,
+		Message:    fmt.Sprintf("user #%d deleted their own account", values.UserID),
+	})
+
+	return nil
+}
+
+// deletedUserName is the name an erased account is left with.
+const deletedUserName = "Deleted User"
+
+// anonymize overwrites the personal data and credentials on user. The
+// placeholders keep the username and email unique, and the .invalid domain
+// can never receive mail.
+func anonymize(user *models.User) {
+	user.Name = deletedUserName
+	user.BusinessName = ""
+	user.Phone = ""
+	user.Username = fmt.Sprintf("deleted-user-%d", user.ID)
+	user.Email = fmt.Sprintf("deleted-user-%d@deleted.invalid", user.ID)
+	user.Password = ""
+	user.IsActive = false
+	user.EmailVerifiedAt = nil
+	user.PasswordChangedAt = nil
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = nil
+	user.TwoFactorLastStep = 0
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+}
+
+// Register creates a new user account and emails it a verification link.
+// When AUTH_REQUIRE_EMAIL_VERIFICATION is on no tokens are issued: the
+// account cannot log in until the link is followed.
 func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
 	// Normalize inputs
 	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
@@ -107,24 +664,37 @@ func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		IsActive:     true,
 	}
 
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), security.BcryptCost)
+	hashedPassword, err := s.passwordPolicy.Hash(ctx, nil, req.Password)
 	if err != nil {
-		return nil, cerrors.NewInternalServerError("failed to process password", err)
+		return nil, err
 	}
-	user.Password = string(hashedPassword)
+	user.Password = hashedPassword
 	// A self-chosen password at registration counts as changed, so the
 	// must-change-default-password gate never fires for self-registered users.
 	now := time.Now()
 	user.PasswordChangedAt = &now
 
-	// Create user and tokens in transaction
+	requireVerification := s.cfg.Auth.RequireEmailVerification
+
+	// Create user, verification token and (unless verification gates login)
+	// session tokens in one transaction
 	var authResponse *dto.AuthResponse
+	var verificationToken string
 	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
 		if err := s.userRepo.Create(txCtx, user); err != nil {
 			return err
 		}
 
+		verificationToken, err = s.issueUserToken(txCtx, user.ID, models.UserTokenPurposeEmailVerification, s.cfg.Auth.EmailVerificationTTL)
+		if err != nil {
+			return err
+		}
+
+		if requireVerification {
+			authResponse = &dto.AuthResponse{EmailVerificationRequired: true}
+			return nil
+		}
+
 		// Generate tokens using AuthJWT
 		authResponse, err = s.authJWT.GenerateTokensForUser(txCtx, user)
 		return err
@@ -134,6 +704,7 @@ func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 	}
 
 	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("User registered successfully")
+	s.sendVerificationEmail(ctx, user, verificationToken)
 	return authResponse, nil
 }
 
@@ -154,9 +725,14 @@ func (s *authService) ChangePassword(ctx context.Context, req *dto.ChangePasswor
 		return err
 	}
 
+	if user.IsDirectoryAccount() {
+		return cerrors.NewBadRequestError("password is managed by the directory")
+	}
+
 	// Verify old password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword)); err != nil {
+	if err := password.Compare(user.Password, req.OldPassword); err != nil {
 		logger.Ctx(ctx, zap.Uint("user_id", values.UserID)).Warn("Password change failed - incorrect current password")
+		audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventPasswordChange, models.SecurityEventOutcomeFailure, values.SessionID)
 		return cerrors.NewBadRequestError("current password is incorrect")
 	}
 
@@ -165,15 +741,17 @@ func (s *authService) ChangePassword(ctx context.Context, req *dto.ChangePasswor
 		return cerrors.NewBadRequestError("new password must be different from current password")
 	}
 
-	// Hash new password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), security.BcryptCost)
+	hashedPassword, err := s.passwordPolicy.Hash(ctx, user, req.NewPassword)
 	if err != nil {
-		return cerrors.NewInternalServerError("failed to process new password", err)
+		return err
 	}
 
 	// Update password and revoke tokens in transaction
 	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
-		user.Password = string(hashedPassword)
+		if err := s.passwordPolicy.Retire(txCtx, user); err != nil {
+			return err
+		}
+		user.Password = hashedPassword
 		// Clears the must-change-default-password gate for seeded accounts.
 		now := time.Now()
 		user.PasswordChangedAt = &now
@@ -185,6 +763,9 @@ func (s *authService) ChangePassword(ctx context.Context, req *dto.ChangePasswor
 	if err != nil {
 		return err
 	}
+	s.authJWT.InvalidateUser(user.ID)
+
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventPasswordChange, models.SecurityEventOutcomeSuccess, values.SessionID)
 
 	// Audit every privileged password rotation — root included.
 	if user.Role.IsAdminType() {
@@ -194,6 +775,51 @@ func (s *authService) ChangePassword(ctx context.Context, req *dto.ChangePasswor
 	return nil
 }
 
+// Reauthenticate checks the caller's identity again and opens the step-up
+// window on the current session, which RequireRecentAuth demands before
+// sensitive operations. A code is checked as a second factor (TOTP or
+// recovery code); otherwise the password is.
+func (s *authService) Reauthenticate(ctx context.Context, req *dto.ReauthenticateRequest) (*dto.ReauthenticateResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if values.SessionID == uuid.Nil {
+		return nil, cerrors.NewUnauthorizedError("current session is unknown")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != "" {
+		err = s.twoFactorService.VerifyCode(ctx, user, req.Code)
+	} else if s.authJWT.VerifyPassword(ctx, user, req.Password) != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Reauthentication failed - incorrect password")
+		err = cerrors.NewBadRequestError("password is incorrect")
+	}
+	if err != nil {
+		audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventReauthenticate, models.SecurityEventOutcomeFailure, values.SessionID)
+		return nil, err
+	}
+
+	now := time.Now()
+	marked, err := s.refreshTokenRepo.MarkAuthenticated(ctx, values.SessionID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		// The session was revoked or expired while the request was in flight.
+		return nil, cerrors.NewUnauthorizedError("current session is unknown")
+	}
+	// RequireRecentAuth reads AuthenticatedAt from the cached session.
+	s.authJWT.InvalidateSession(values.SessionID)
+
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventReauthenticate, models.SecurityEventOutcomeSuccess, values.SessionID)
+	return &dto.ReauthenticateResponse{ExpiresAt: now.Add(s.cfg.Auth.ReauthenticationWindow)}, nil
+}
+
 // Logout revokes a specific refresh token
 func (s *authService) Logout(ctx context.Context, req *dto.LogoutRequest) error {
 	values, err := utils.ValuesFromContext(ctx)
@@ -201,7 +827,597 @@ func (s *authService) Logout(ctx context.Context, req *dto.LogoutRequest) error
 		return err
 	}
 
-	return s.authJWT.RevokeRefreshToken(ctx, req.RefreshToken, values.UserID)
+	if err := s.authJWT.RevokeRefreshToken(ctx, req.RefreshToken, values.UserID); err != nil {
+		return err
+	}
+
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, values.UserID, models.SecurityEventLogout, models.SecurityEventOutcomeSuccess, values.SessionID)
+	return nil
+}
+
+// ListSessions returns the authenticated user's active sessions, most
+// recently used first, marking the one the request was made with.
+func (s *authService) ListSessions(ctx context.Context) ([]dto.SessionResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.refreshTokenRepo.FindActiveByUserID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, session.ToSessionResponse(values.SessionID))
+	}
+	return res, nil
+}
+
+// RevokeSession signs out one of the authenticated user's sessions. Another
+// user's session is reported as not found rather than forbidden so session
+// IDs cannot be probed. Revoking the current session is allowed and behaves
+// like a logout.
+func (s *authService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.refreshTokenRepo.RevokeByIDForUser(ctx, sessionID, values.UserID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return cerrors.NewNotFoundError("session not found")
+	}
+	s.authJWT.InvalidateSession(sessionID)
+
+	// Signing a device out is a logout of that session.
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, values.UserID, models.SecurityEventLogout, models.SecurityEventOutcomeSuccess, sessionID)
+	return nil
+}
+
+// RevokeOtherSessions signs out every session of the authenticated user
+// except the one the request was made with.
+func (s *authService) RevokeOtherSessions(ctx context.Context) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Without a current session the exception would match nothing and this
+	// would silently become "revoke all".
+	if values.SessionID == uuid.Nil {
+		return cerrors.NewUnauthorizedError("current session is unknown")
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllByUserIDExceptID(ctx, values.UserID, values.SessionID); err != nil {
+		return err
+	}
+	s.authJWT.InvalidateUser(values.UserID)
+	return nil
+}
+
+// Impersonate lets the authenticated user act as userID for a short while.
+// Only a user's own session may start one: API keys and impersonation tokens
+// are refused, so impersonation never chains. Root accounts cannot be
+// impersonated, and admin-type targets additionally need the
+// admin_user:impersonate grant.
+func (s *authService) Impersonate(ctx context.Context, userID uint) (*dto.ImpersonationResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if values.ImpersonatorID != 0 {
+		return nil, cerrors.NewForbiddenError("cannot impersonate while impersonating")
+	}
+	if values.APIKeyID != 0 {
+		return nil, cerrors.NewForbiddenError("impersonation requires an interactive session")
+	}
+	if values.UserID == userID {
+		return nil, cerrors.NewBadRequestError("cannot impersonate yourself")
+	}
+
+	actor, err := s.userRepo.FindByID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == models.UserRoleRoot {
+		return nil, cerrors.NewForbiddenError("cannot impersonate root user")
+	}
+	if !target.IsActive {
+		return nil, cerrors.NewBadRequestError("cannot impersonate an inactive user")
+	}
+	if target.Role.IsAdminType() {
+		allowed, err := s.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleIDs, permissions.AdminUserImpersonate.String())
+		if err != nil {
+			return nil, cerrors.NewInternalServerError("failed to verify permissions", err)
+		}
+		if !allowed {
+			return nil, cerrors.NewForbiddenError("insufficient permissions to impersonate admin accounts")
+		}
+	}
+
+	resp, err := s.authJWT.Impersonate(ctx, actor, target)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", actor.ID), zap.Uint("target_user_id", target.ID)).Warn("Impersonation started")
+	audit.Record(ctx, s.logRepository, audit.Entry{
+		Action:     models.LogActionImpersonate,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   target.ID,
+		Message:    fmt.Sprintf("%s started impersonating %s", actor.Name, target.Name),
+	})
+	return resp, nil
+}
+
+// EndImpersonation revokes the impersonation session the request was made
+// with. The token stops working immediately; the actor's own session is
+// untouched.
+func (s *authService) EndImpersonation(ctx context.Context) error {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if values.ImpersonatorID == 0 {
+		return cerrors.NewBadRequestError("not impersonating")
+	}
+
+	if _, err := s.refreshTokenRepo.RevokeByIDForUser(ctx, values.SessionID, values.UserID); err != nil {
+		return err
+	}
+	s.authJWT.InvalidateSession(values.SessionID)
+
+	audit.Record(ctx, s.logRepository, audit.Entry{
+		Action:     models.LogActionEndImpersonation,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   values.UserID,
+		Message:    fmt.Sprintf("%s stopped impersonating %s", values.ImpersonatorName, values.UserName),
+	})
+	return nil
+}
+
+// GetJWKS returns the public keys that verify access tokens.
+func (s *authService) GetJWKS(_ context.Context) dto.JWKSResponse {
+	return s.authJWT.JWKS()
+}
+
+// ForgotPassword emails a single-use password-reset link to the account
+// registered under req.Email. The lookup, the token and the email all happen
+// in the background (see inBackground), so the response is the same, and
+// takes the same time, whether or not such an account exists or is active:
+// the endpoint cannot be used to enumerate registered addresses.
+func (s *authService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	s.inBackground(ctx, func(ctx context.Context) {
+		s.sendPasswordReset(ctx, email)
+	})
+	return nil
+}
+
+// sendPasswordReset is the background half of ForgotPassword. Every failure
+// is logged, since there is no caller left to report it to.
+func (s *authService) sendPasswordReset(ctx context.Context, email string) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			logger.Ctx(ctx).Info("Password reset requested for unknown email")
+			return
+		}
+		logger.Ctx(ctx).Error("Failed to look up password reset email", zap.Error(err))
+		return
+	}
+
+	if !user.IsActive {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Password reset requested for inactive user")
+		return
+	}
+	if user.IsDirectoryAccount() {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Password reset requested for directory account")
+		return
+	}
+
+	var token string
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		token, err = s.issueUserToken(txCtx, user.ID, models.UserTokenPurposePasswordReset, s.cfg.Auth.PasswordResetTTL)
+		return err
+	})
+	if err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to issue password reset token", zap.Error(err))
+		return
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Reset your %s password", s.cfg.App.Name),
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Use the link below to choose a new one:\n\n%s\n\n"+
+				"The link expires in %s and can only be used once. If you did not request this, you can ignore this email.\n",
+			user.Name, tokenLink(s.cfg.Auth.PasswordResetURL, token), s.cfg.Auth.PasswordResetTTL),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to send password reset email", zap.Error(err))
+		return
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Password reset email sent")
+}
+
+// ResetPassword redeems a password-reset token and sets the new password. The
+// token is consumed in the same transaction that writes the password, so a
+// failed update leaves it redeemable, and every session the user has is
+// revoked: whoever prompted the reset may have been locked out by an attacker
+// holding a live refresh token.
+func (s *authService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	var user *models.User
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		userToken, err := s.userTokenRepo.ConsumeByToken(txCtx, models.UserTokenPurposePasswordReset, req.Token)
+		if err != nil {
+			if errors.Is(err, cerrors.ErrNotFound) {
+				return cerrors.NewBadRequestError("invalid or expired reset token")
+			}
+			return err
+		}
+
+		user, err = s.userRepo.FindByID(txCtx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive {
+			return cerrors.NewBadRequestError("invalid or expired reset token")
+		}
+
+		// The reuse rule needs the account, which only the token identifies,
+		// so the policy runs here; a rejected password rolls the redemption
+		// back and the link stays usable for another attempt.
+		hashedPassword, err := s.passwordPolicy.Hash(txCtx, user, req.NewPassword)
+		if err != nil {
+			return err
+		}
+		if err := s.passwordPolicy.Retire(txCtx, user); err != nil {
+			return err
+		}
+		user.Password = hashedPassword
+		// The user chose this password, so it clears the
+		// must-change-default-password gate like a self-service change does.
+		now := time.Now()
+		user.PasswordChangedAt = &now
+		// Redeeming the emailed link proves ownership, so it also lifts a
+		// lockout that guessing at the old password may have triggered.
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+
+		if err := s.userTokenRepo.ConsumeAllByUserID(txCtx, user.ID, models.UserTokenPurposePasswordReset); err != nil {
+			return err
+		}
+		return s.refreshTokenRepo.RevokeAllByUserID(txCtx, user.ID)
+	})
+	if err != nil {
+		return err
+	}
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Password reset via emailed token")
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventPasswordReset, models.SecurityEventOutcomeSuccess, uuid.Nil)
+
+	// The request is unauthenticated, so attribute the entry to the account
+	// whose token was redeemed rather than to "Unknown".
+	auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+		UserID:       user.ID,
+		UserName:     user.Name,
+		Role:         user.Role.ToString(),
+		AdminRoleIDs: user.AdminRoleIDs(),
+		RequestID:    utils.GetRequestIDFromContext(ctx),
+	})
+	audit.Record(auditCtx, s.logRepository, audit.Entry{
+		Action:     models.LogActionResetPassword,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   user.ID,
+		Message:    fmt.Sprintf("%s reset password via emailed link", user.Name),
+	})
+
+	return nil
+}
+
+// VerifyEmail redeems an email-verification token and marks the owning
+// account's address as verified. Redeeming a link for an address that is
+// already verified succeeds without changing the original timestamp.
+func (s *authService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	var user *models.User
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		userToken, err := s.userTokenRepo.ConsumeByToken(txCtx, models.UserTokenPurposeEmailVerification, req.Token)
+		if err != nil {
+			if errors.Is(err, cerrors.ErrNotFound) {
+				return cerrors.NewBadRequestError("invalid or expired verification token")
+			}
+			return err
+		}
+
+		user, err = s.userRepo.FindByID(txCtx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive {
+			return cerrors.NewBadRequestError("invalid or expired verification token")
+		}
+
+		if !user.IsEmailVerified() {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := s.userRepo.Update(txCtx, user); err != nil {
+				return err
+			}
+		}
+
+		// Any other outstanding links for this address are now pointless.
+		return s.userTokenRepo.ConsumeAllByUserID(txCtx, user.ID, models.UserTokenPurposeEmailVerification)
+	})
+	if err != nil {
+		return err
+	}
+	// A session refused while the address was unverified may still be cached.
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Email address verified")
+	return nil
+}
+
+// ResendVerification emails a fresh verification link to the unverified
+// account registered under req.Email, invalidating earlier links. Like
+// ForgotPassword it does the work in the background and always reports
+// success, so it cannot be used to probe which addresses are registered or
+// verified.
+func (s *authService) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	s.inBackground(ctx, func(ctx context.Context) {
+		s.resendVerification(ctx, email)
+	})
+	return nil
+}
+
+// resendVerification is the background half of ResendVerification.
+func (s *authService) resendVerification(ctx context.Context, email string) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			logger.Ctx(ctx).Info("Verification resend requested for unknown email")
+			return
+		}
+		logger.Ctx(ctx).Error("Failed to look up verification resend email", zap.Error(err))
+		return
+	}
+
+	if !user.IsActive || user.IsEmailVerified() {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Verification resend skipped: account inactive or already verified")
+		return
+	}
+
+	var token string
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		token, err = s.issueUserToken(txCtx, user.ID, models.UserTokenPurposeEmailVerification, s.cfg.Auth.EmailVerificationTTL)
+		return err
+	})
+	if err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to issue verification token", zap.Error(err))
+		return
+	}
+
+	s.sendVerificationEmail(ctx, user, token)
+}
+
+// RequestMagicLink emails a single-use login link to the account registered
+// under req.Email when its role may log in without a password. Like
+// ForgotPassword it does the work in the background and always reports
+// success, so it cannot be used to probe which addresses are registered or
+// which roles they hold. Accounts with 2FA are skipped: the link stands in
+// for the password, never the second factor.
+func (s *authService) RequestMagicLink(ctx context.Context, req *dto.MagicLinkRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	s.inBackground(ctx, func(ctx context.Context) {
+		s.sendMagicLink(ctx, email)
+	})
+	return nil
+}
+
+// sendMagicLink is the background half of RequestMagicLink.
+func (s *authService) sendMagicLink(ctx context.Context, email string) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			logger.Ctx(ctx).Info("Magic link requested for unknown email")
+			return
+		}
+		logger.Ctx(ctx).Error("Failed to look up magic link email", zap.Error(err))
+		return
+	}
+
+	if !s.magicLinkAllowed(user) {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Magic link skipped: account inactive, role not enabled or 2FA on")
+		return
+	}
+
+	var token string
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		token, err = s.issueUserToken(txCtx, user.ID, models.UserTokenPurposeMagicLink, s.cfg.Auth.MagicLinkTTL)
+		return err
+	})
+	if err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to issue magic link token", zap.Error(err))
+		return
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Your %s login link", s.cfg.App.Name),
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to log in:\n\n%s\n\n"+
+				"The link expires in %s and can only be used once. If you did not request it, you can ignore this email.\n",
+			user.Name, tokenLink(s.cfg.Auth.MagicLinkURL, token), s.cfg.Auth.MagicLinkTTL),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to send magic link email", zap.Error(err))
+		return
+	}
+
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventMagicLinkSent, models.SecurityEventOutcomeSuccess, uuid.Nil)
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Magic link email sent")
+}
+
+// ConsumeMagicLink redeems a login link for a new session. The account is
+// re-checked at redemption, since its role, status or 2FA enrolment may have
+// changed after the link was sent. The token is consumed in the transaction
+// that opens the session, so a failure leaves the link usable. Redeeming the
+// link proves the user controls the address, so it also marks it verified.
+func (s *authService) ConsumeMagicLink(ctx context.Context, req *dto.ConsumeMagicLinkRequest) (*dto.AuthResponse, error) {
+	var user *models.User
+	var authResponse *dto.AuthResponse
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		userToken, err := s.userTokenRepo.ConsumeByToken(txCtx, models.UserTokenPurposeMagicLink, req.Token)
+		if err != nil {
+			if errors.Is(err, cerrors.ErrNotFound) {
+				return cerrors.NewBadRequestError("invalid or expired login link")
+			}
+			return err
+		}
+
+		user, err = s.userRepo.FindByID(txCtx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+		if !s.magicLinkAllowed(user) {
+			logger.Ctx(txCtx, zap.Uint("user_id", user.ID)).Warn("Magic link refused for account")
+			audit.RecordSecurityEvent(txCtx, s.securityEventRepo, user.ID, models.SecurityEventMagicLinkLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+			return cerrors.NewBadRequestError("invalid or expired login link")
+		}
+
+		if !user.IsEmailVerified() {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := s.userRepo.Update(txCtx, user); err != nil {
+				return err
+			}
+		}
+
+		authResponse, err = s.authJWT.GenerateTokensForUser(txCtx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The address may have just been verified.
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Login via magic link")
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventMagicLinkLogin, models.SecurityEventOutcomeSuccess, uuid.Nil)
+
+	// Privileged logins are audited like password logins; the request is
+	// unauthenticated, so the entry is attributed to the account itself.
+	if user.Role.IsAdminType() {
+		auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+			UserID:       user.ID,
+			UserName:     user.Name,
+			Role:         user.Role.ToString(),
+			AdminRoleIDs: user.AdminRoleIDs(),
+			RequestID:    utils.GetRequestIDFromContext(ctx),
+		})
+		audit.Record(auditCtx, s.logRepository, audit.Entry{
+			Action:     models.LogActionLogin,
+			EntityType: models.LogEntityTypeUser,
+			EntityID:   user.ID,
+			Message:    fmt.Sprintf("%s logged in via magic link", user.Name),
+		})
+	}
+
+	return authResponse, nil
+}
+
+// magicLinkAllowed reports whether user may log in by emailed link.
+func (s *authService) magicLinkAllowed(user *models.User) bool {
+	return user.IsActive && s.cfg.Auth.MagicLinkEnabledFor(user.Role.ToString()) && !user.IsTwoFactorEnabled()
+}
+
+// issueUserToken mints a single-use token for purpose and stores its hash,
+// first consuming any still-valid token of the same purpose so only the most
+// recently emailed link works. It must run inside a transaction.
+func (s *authService) issueUserToken(ctx context.Context, userID uint, purpose models.UserTokenPurpose, ttl time.Duration) (string, error) {
+	return s.storeUserToken(ctx, &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+}
+
+// storeUserToken is issueUserToken for tokens that carry more than a
+// purpose and a lifetime: it supersedes earlier tokens of userToken's
+// purpose, fills in a fresh token value and returns it.
+func (s *authService) storeUserToken(ctx context.Context, userToken *models.UserToken) (string, error) {
+	if err := s.userTokenRepo.ConsumeAllByUserID(ctx, userToken.UserID, userToken.Purpose); err != nil {
+		return "", err
+	}
+
+	token := rand.Text()
+	userToken.TokenHash = token
+	if err := s.userTokenRepo.Create(ctx, userToken); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// sendVerificationEmail delivers the verification link for token. Failures are
+// logged rather than returned: the account already exists, and the user can
+// ask for another link via ResendVerification.
+func (s *authService) sendVerificationEmail(ctx context.Context, user *models.User, token string) {
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Verify your %s email address", s.cfg.App.Name),
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm this is your email address by opening the link below:\n\n%s\n\n"+
+				"The link expires in %s. If you did not create an account, you can ignore this email.\n",
+			user.Name, tokenLink(s.cfg.Auth.EmailVerificationURL, token), s.cfg.Auth.EmailVerificationTTL),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Error("Failed to send verification email", zap.Error(err))
+		return
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Verification email sent")
+}
+
+// inBackground runs fn off the request path for the unauthenticated
+// "email me a link" endpoints. Doing the account lookup there too means
+// every address costs the caller the same: no database round trip, token
+// write or mail delivery that only a registered address would pay for. fn
+// gets a context detached from the request's cancellation, and the
+// goroutine is tracked by audit.Drain so shutdown waits for the email.
+func (s *authService) inBackground(ctx context.Context, fn func(ctx context.Context)) {
+	bgCtx := utils.StripTx(context.WithoutCancel(ctx))
+	audit.Go(func() { fn(bgCtx) })
+}
+
+// tokenLink appends token to the frontend page at rawURL, preserving any query
+// string the URL already carries.
+func tokenLink(rawURL, token string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// unreachable: validateAuth requires absolute URLs at startup
+		return rawURL + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
 // createLog creates an audit log entry for auth operations (admin only).
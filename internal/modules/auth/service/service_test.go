@@ -1,7 +1,12 @@
 package service_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"net/http"
 	"testing"
 	"time"
 
@@ -10,20 +15,30 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/PhantomX7/athleton/internal/audit"
 	"github.com/PhantomX7/athleton/internal/dto"
 	"github.com/PhantomX7/athleton/internal/models"
+	apikeymocks "github.com/PhantomX7/athleton/internal/modules/api_key/repository/mocks"
 	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
 	"github.com/PhantomX7/athleton/internal/modules/auth/service"
 	logrepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
 	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	oidcmocks "github.com/PhantomX7/athleton/internal/modules/oidc/repository/mocks"
+	passwordpolicymocks "github.com/PhantomX7/athleton/internal/modules/password_policy/service/mocks"
 	refreshtokenrepository "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
 	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
+	twofactormocks "github.com/PhantomX7/athleton/internal/modules/two_factor/service/mocks"
 	userrepository "github.com/PhantomX7/athleton/internal/modules/user/repository"
 	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	usertokenmocks "github.com/PhantomX7/athleton/internal/modules/user_token/repository/mocks"
 	casbinmocks "github.com/PhantomX7/athleton/libs/casbin/mocks"
+	"github.com/PhantomX7/athleton/libs/mailer"
+	mailermocks "github.com/PhantomX7/athleton/libs/mailer/mocks"
 	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
 	"github.com/PhantomX7/athleton/pkg/config"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/logger"
 	"github.com/PhantomX7/athleton/pkg/repository"
 	"github.com/PhantomX7/athleton/pkg/utils"
@@ -57,16 +72,39 @@ func setupLogger(t *testing.T) {
 	})
 }
 
+// stubSecurityEventRepo accepts every security event so the service tests
+// need not care which timeline entries a flow records.
+func stubSecurityEventRepo() *securityeventmocks.SecurityEventRepositoryMock {
+	return &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(context.Context, *models.SecurityEvent) error { return nil },
+	}
+}
+
+// stubPasswordPolicy returns a password policy mock that accepts every
+// candidate and hashes it at bcrypt's minimum cost, keeping the service tests
+// independent of the policy rules (covered in the password_policy module).
+func stubPasswordPolicy() *passwordpolicymocks.PasswordPolicyMock {
+	return &passwordpolicymocks.PasswordPolicyMock{
+		HashFunc: func(ctx context.Context, user *models.User, candidate string) (string, error) {
+			hashed, err := bcrypt.GenerateFromPassword([]byte(candidate), bcrypt.MinCost)
+			return string(hashed), err
+		},
+		RetireFunc: func(ctx context.Context, user *models.User) error {
+			return nil
+		},
+	}
+}
+
 func newAuthJWT(t *testing.T, userRepo userrepository.UserRepository, refreshRepo refreshtokenrepository.RefreshTokenRepository, logRepo logrepository.LogRepository) *authjwt.AuthJWT {
 	t.Helper()
 	cfg := setupConfig(t)
 	setupLogger(t)
 
-	auth, err := authjwt.NewAuthJWT(cfg, userRepo, refreshRepo, logRepo, &txmocks.TransactionManagerMock{
+	auth, err := authjwt.NewAuthJWT(cfg, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &apikeymocks.APIKeyRepositoryMock{}, logRepo, stubSecurityEventRepo(), &txmocks.TransactionManagerMock{
 		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
 			return fn(ctx)
 		},
-	})
+	}, nil, nil)
 	require.NoError(t, err)
 	return auth
 }
@@ -74,69 +112,121 @@ func newAuthJWT(t *testing.T, userRepo userrepository.UserRepository, refreshRep
 func TestAuthServiceGetMeReturnsUserWithPermissions(t *testing.T) {
 	setupLogger(t)
 
-	adminRoleID := uint(7)
+	grants := map[uint][]string{
+		7: {permissions.UserRead.String()},
+		9: {permissions.LogRead.String()},
+	}
+	denials := map[uint][]string{
+		7: {permissions.UserDelete.String()},
+	}
 	userRepo := &usermocks.UserRepositoryMock{
-		FindByIDFunc: func(ctx context.Context, id uint, preloads ...repository.Association) (*models.User, error) {
+		FindByIDFunc: func(ctx context.Context, id uint, _ ...repository.Association) (*models.User, error) {
 			require.Equal(t, uint(5), id)
-			require.NotEmpty(t, preloads)
 			return &models.User{
-				ID:          5,
-				Username:    "admin",
-				Name:        "Admin User",
-				Email:       "admin@example.com",
-				Phone:       "081",
-				IsActive:    true,
-				Role:        models.UserRoleAdmin,
-				AdminRoleID: &adminRoleID,
-				AdminRole:   &models.AdminRole{ID: adminRoleID, Name: "Manager"},
+				ID:       5,
+				Username: "admin",
+				Name:     "Admin User",
+				Email:    "admin@example.com",
+				Phone:    "081",
+				IsActive: true,
+				Role:     models.UserRoleAdmin,
+				AdminRoles: []models.AdminRole{
+					{ID: 7, Name: "Manager", IsActive: true},
+					{ID: 9, Name: "Auditor", IsActive: true},
+				},
 			}, nil
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
-		GetRolePermissionsFunc: func(roleID uint) []string {
-			require.Equal(t, adminRoleID, roleID)
-			return []string{permissions.UserRead.String()}
-		},
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(roleID uint) []string { return grants[roleID] },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string { return grants[roleID] },
+		GetRoleDenialsFunc:           func(roleID uint) []string { return denials[roleID] },
+		GetDirectRoleDenialsFunc:     func(roleID uint) []string { return denials[roleID] },
 	}
 
-	svc := service.NewAuthService(userRepo, &logmocks.LogRepositoryMock{}, nil, casbinClient, &txmocks.TransactionManagerMock{})
+	svc := service.NewAuthService(&config.Config{}, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, casbinClient, &mailermocks.MailerMock{}, &txmocks.TransactionManagerMock{})
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 5})
 
 	me, err := svc.GetMe(ctx)
 
 	require.NoError(t, err)
 	require.Equal(t, uint(5), me.ID)
-	require.NotNil(t, me.AdminRole)
-	require.Equal(t, []string{permissions.UserRead.String()}, me.AdminRole.Permissions)
+	require.Equal(t, []uint{7, 9}, me.AdminRoleIDs)
+	require.Len(t, me.AdminRoles, 2)
+	require.Equal(t, []string{permissions.UserRead.String()}, me.AdminRoles[0].Permissions)
+	require.Equal(t, []string{permissions.LogRead.String()}, me.AdminRoles[1].Permissions)
+	require.Equal(t, []string{permissions.UserDelete.String()}, me.AdminRoles[0].Denials)
+	require.Empty(t, me.AdminRoles[1].Denials)
+	require.False(t, me.EmailVerified)
 }
 
-func TestAuthServiceGetMeToleratesMissingPreloadedRole(t *testing.T) {
+func TestAuthServiceGetMeReportsInactiveRoleWithoutPermissions(t *testing.T) {
 	setupLogger(t)
 
-	// AdminRoleID can point at a role whose row failed to preload (soft-deleted
-	// role, seed drift). GetMe must degrade gracefully instead of panicking.
 	adminRoleID := uint(7)
 	userRepo := &usermocks.UserRepositoryMock{
 		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
 			return &models.User{
-				ID:          5,
-				Username:    "admin",
-				IsActive:    true,
-				Role:        models.UserRoleAdmin,
-				AdminRoleID: &adminRoleID,
-				AdminRole:   nil,
+				ID:         5,
+				IsActive:   true,
+				Role:       models.UserRoleAdmin,
+				AdminRoles: []models.AdminRole{{ID: adminRoleID, Name: "Manager", IsActive: false}},
+			}, nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		GetRoleParentFunc: func(uint) *uint { return nil },
+		GetRolePermissionsFunc: func(uint) []string {
+			t.Fatal("an inactive role grants nothing, so its permissions must not be reported")
+			return nil
+		},
+		GetDirectRolePermissionsFunc: func(uint) []string {
+			t.Fatal("an inactive role grants nothing, so its permissions must not be reported")
+			return nil
+		},
+		// Its deny rules still apply, so they are.
+		GetDirectRoleDenialsFunc: func(uint) []string { return []string{"admin_user:delete"} },
+		GetRoleDenialsFunc:       func(uint) []string { return []string{"admin_user:delete"} },
+	}
+
+	svc := service.NewAuthService(&config.Config{}, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, casbinClient, &mailermocks.MailerMock{}, &txmocks.TransactionManagerMock{})
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 5})
+
+	me, err := svc.GetMe(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, me.AdminRoles, 1)
+	require.False(t, me.AdminRoles[0].IsActive)
+	require.Empty(t, me.AdminRoles[0].Permissions)
+	require.Equal(t, []string{"admin_user:delete"}, me.AdminRoles[0].Denials)
+}
+
+func TestAuthServiceGetMeReportsNoRolesForRolelessAdmin(t *testing.T) {
+	setupLogger(t)
+
+	// A soft-deleted role is not preloaded, so an admin can come back with no
+	// roles. GetMe must report an empty list rather than fail.
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+			return &models.User{
+				ID:       5,
+				Username: "admin",
+				IsActive: true,
+				Role:     models.UserRoleAdmin,
 			}, nil
 		},
 	}
 
-	svc := service.NewAuthService(userRepo, &logmocks.LogRepositoryMock{}, nil, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+	svc := service.NewAuthService(&config.Config{}, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, &txmocks.TransactionManagerMock{})
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 5})
 
 	me, err := svc.GetMe(ctx)
 
 	require.NoError(t, err)
 	require.Equal(t, uint(5), me.ID)
-	require.Nil(t, me.AdminRole)
+	require.Empty(t, me.AdminRoleIDs)
+	require.Empty(t, me.AdminRoles)
 }
 
 func TestAuthServiceRegisterCreatesUserAndTokens(t *testing.T) {
@@ -175,7 +265,25 @@ func TestAuthServiceRegisterCreatesUserAndTokens(t *testing.T) {
 		},
 	}
 
-	svc := service.NewAuthService(userRepo, logRepo, auth, &casbinmocks.ClientMock{}, txManager)
+	var issued *models.UserToken
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(context.Context, uint, models.UserTokenPurpose) error { return nil },
+		CreateFunc: func(ctx context.Context, token *models.UserToken) error {
+			copied := *token
+			issued = &copied
+			return nil
+		},
+	}
+	var sent []mailer.Message
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(ctx context.Context, msg mailer.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+	cfg := setupConfig(t)
+
+	svc := service.NewAuthService(cfg, userRepo, refreshRepo, userTokenRepo, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, mail, txManager)
 	ctx := utils.SetRequestIDToContext(context.Background(), "req-1")
 
 	res, err := svc.Register(ctx, &dto.RegisterRequest{
@@ -190,6 +298,51 @@ func TestAuthServiceRegisterCreatesUserAndTokens(t *testing.T) {
 	require.NotEmpty(t, res.AccessToken)
 	require.NotEmpty(t, res.RefreshToken)
 	require.Equal(t, "Bearer", res.TokenType)
+	require.False(t, res.EmailVerificationRequired)
+
+	require.NotNil(t, issued)
+	require.Equal(t, uint(9), issued.UserID)
+	require.Equal(t, models.UserTokenPurposeEmailVerification, issued.Purpose)
+	require.Len(t, sent, 1)
+	require.Equal(t, "user@example.com", sent[0].To)
+	require.Contains(t, sent[0].Body, cfg.Auth.EmailVerificationURL+"?token="+issued.TokenHash)
+}
+
+func TestAuthServiceRegisterWithholdsTokensWhenVerificationRequired(t *testing.T) {
+	cfg := setupConfig(t)
+	cfg.Auth.RequireEmailVerification = true
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, user *models.User) error {
+			require.Nil(t, user.EmailVerifiedAt)
+			user.ID = 9
+			return nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(context.Context, uint, models.UserTokenPurpose) error { return nil },
+		CreateFunc:             func(context.Context, *models.UserToken) error { return nil },
+	}
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(context.Context, mailer.Message) error { return nil },
+	}
+
+	// A nil AuthJWT and an empty refresh-token mock: minting tokens would panic.
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	res, err := svc.Register(context.Background(), &dto.RegisterRequest{
+		Name:     "User",
+		Email:    "user@example.com",
+		Phone:    "08123",
+		Password: "secret123",
+	})
+
+	require.NoError(t, err)
+	require.True(t, res.EmailVerificationRequired)
+	require.Empty(t, res.AccessToken)
+	require.Empty(t, res.RefreshToken)
+	require.Len(t, mail.SendCalls(), 1)
 }
 
 func TestAuthServiceRefreshRotatesToken(t *testing.T) {
@@ -202,10 +355,10 @@ func TestAuthServiceRefreshRotatesToken(t *testing.T) {
 	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
 		FindByTokenFunc: func(ctx context.Context, token string) (*models.RefreshToken, error) {
 			require.Equal(t, "old-token", token)
-			return &models.RefreshToken{ID: uuid.New(), UserID: 3, Token: token}, nil
+			return &models.RefreshToken{ID: uuid.New(), UserID: 3, Token: token, LoggedInAt: time.Now(), LastUsedAt: time.Now()}, nil
 		},
 		// Rotation swaps the stored hash in place on the existing session row.
-		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string) (bool, error) {
+		UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken, newToken string, _ utils.ClientInfo) (bool, error) {
 			require.Equal(t, "old-token", oldToken)
 			require.NotEmpty(t, newToken)
 			require.NotEqual(t, oldToken, newToken)
@@ -214,7 +367,7 @@ func TestAuthServiceRefreshRotatesToken(t *testing.T) {
 	}
 	auth := newAuthJWT(t, userRepo, refreshRepo, &logmocks.LogRepositoryMock{})
 
-	svc := service.NewAuthService(userRepo, &logmocks.LogRepositoryMock{}, auth, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, &txmocks.TransactionManagerMock{})
 
 	res, err := svc.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: "old-token"})
 
@@ -266,7 +419,7 @@ func TestAuthServiceChangePasswordUpdatesHashRevokesTokensAndLogsAdmin(t *testin
 		},
 	}
 
-	svc := service.NewAuthService(userRepo, logRepo, auth, &casbinmocks.ClientMock{}, txManager)
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, txManager)
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 4, UserName: "Root"})
 
 	err = svc.ChangePassword(ctx, &dto.ChangePasswordRequest{
@@ -320,7 +473,7 @@ func TestAuthServiceChangePasswordAuditsRootUsers(t *testing.T) {
 		},
 	}
 
-	svc := service.NewAuthService(userRepo, logRepo, auth, &casbinmocks.ClientMock{}, txManager)
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, txManager)
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 4, UserName: "Root User"})
 
 	err = svc.ChangePassword(ctx, &dto.ChangePasswordRequest{
@@ -352,10 +505,1096 @@ func TestAuthServiceLogoutRevokesRefreshToken(t *testing.T) {
 	}
 	auth := newAuthJWT(t, userRepo, refreshRepo, &logmocks.LogRepositoryMock{})
 
-	svc := service.NewAuthService(userRepo, &logmocks.LogRepositoryMock{}, auth, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{})
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, &txmocks.TransactionManagerMock{})
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6})
 
 	err := svc.Logout(ctx, &dto.LogoutRequest{RefreshToken: "refresh-token"})
 
 	require.NoError(t, err)
 }
+
+func passthroughTx() *txmocks.TransactionManagerMock {
+	return &txmocks.TransactionManagerMock{
+		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
+func TestAuthServiceForgotPasswordIssuesTokenAndEmailsLink(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*models.User, error) {
+			require.Equal(t, "user@example.com", email)
+			return &models.User{ID: 8, Name: "User", Email: "user@example.com", IsActive: true}, nil
+		},
+	}
+	var issued *models.UserToken
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error {
+			require.Nil(t, issued, "earlier links must be superseded before the new one is stored")
+			require.Equal(t, uint(8), userID)
+			require.Equal(t, models.UserTokenPurposePasswordReset, purpose)
+			return nil
+		},
+		CreateFunc: func(ctx context.Context, token *models.UserToken) error {
+			copied := *token
+			issued = &copied
+			return nil
+		},
+	}
+	var sent []mailer.Message
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(ctx context.Context, msg mailer.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	err := svc.ForgotPassword(context.Background(), &dto.ForgotPasswordRequest{Email: " User@Example.com "})
+
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+	require.NotNil(t, issued)
+	require.Equal(t, uint(8), issued.UserID)
+	require.Equal(t, models.UserTokenPurposePasswordReset, issued.Purpose)
+	require.WithinDuration(t, time.Now().Add(cfg.Auth.PasswordResetTTL), issued.ExpiresAt, 5*time.Second)
+	require.Len(t, sent, 1)
+	require.Equal(t, "user@example.com", sent[0].To)
+	// The repository hashes on Create, so the value handed to it is the wire
+	// value that the emailed link must carry.
+	require.Contains(t, sent[0].Body, cfg.Auth.PasswordResetURL+"?token="+issued.TokenHash)
+}
+
+func TestAuthServiceForgotPasswordDoesNotRevealUnknownOrInactiveAccounts(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	users := map[string]*models.User{
+		"inactive@example.com": {ID: 2, Email: "inactive@example.com", IsActive: false},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(_ context.Context, email string) (*models.User, error) {
+			if u, ok := users[email]; ok {
+				return u, nil
+			}
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(context.Context, uint, models.UserTokenPurpose) error { return nil },
+		CreateFunc:             func(context.Context, *models.UserToken) error { return nil },
+	}
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(context.Context, mailer.Message) error { return nil },
+	}
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	require.NoError(t, svc.ForgotPassword(context.Background(), &dto.ForgotPasswordRequest{Email: "ghost@example.com"}))
+	require.NoError(t, svc.ForgotPassword(context.Background(), &dto.ForgotPasswordRequest{Email: "inactive@example.com"}))
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, userRepo.FindByEmailCalls(), 2)
+	require.Empty(t, userTokenRepo.CreateCalls())
+	require.Empty(t, mail.SendCalls())
+}
+
+// TestAuthServiceForgotPasswordAnswersBeforeTheWorkIsDone holds the mailer
+// and the token write open: a registered address must not keep the caller
+// waiting for either, and a failed token write must not surface as an error.
+func TestAuthServiceForgotPasswordAnswersBeforeTheWorkIsDone(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 8, Email: "user@example.com", IsActive: true}, nil
+		},
+	}
+	release := make(chan struct{})
+	tx := &txmocks.TransactionManagerMock{
+		ExecuteInTransactionFunc: func(context.Context, func(context.Context) error) error {
+			<-release
+			return errors.New("connection reset")
+		},
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, tx)
+
+	require.NoError(t, svc.ForgotPassword(context.Background(), &dto.ForgotPasswordRequest{Email: "user@example.com"}))
+	close(release)
+	require.NoError(t, audit.Drain(context.Background()))
+	require.Len(t, tx.ExecuteInTransactionCalls(), 1)
+}
+
+func TestAuthServiceForgotPasswordSwallowsMailerFailure(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 8, Email: "user@example.com", IsActive: true}, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(context.Context, uint, models.UserTokenPurpose) error { return nil },
+		CreateFunc:             func(context.Context, *models.UserToken) error { return nil },
+	}
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(context.Context, mailer.Message) error { return errors.New("smtp down") },
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	// A delivery failure must look exactly like success to the caller.
+	require.NoError(t, svc.ForgotPassword(context.Background(), &dto.ForgotPasswordRequest{Email: "user@example.com"}))
+	require.NoError(t, audit.Drain(context.Background()))
+	require.Len(t, mail.SendCalls(), 1)
+}
+
+func TestAuthServiceResetPasswordUpdatesHashRevokesSessionsAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	logCh := make(chan *models.Log, 1)
+	lockedUntil := time.Now().Add(time.Hour)
+	user := &models.User{ID: 8, Name: "Member", Role: models.UserRoleUser, IsActive: true, Password: "old-hash", FailedLoginAttempts: 6, LockedUntil: &lockedUntil}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			require.Equal(t, uint(8), id)
+			return user, nil
+		},
+		UpdateFunc: func(_ context.Context, entity *models.User) error {
+			require.NoError(t, bcrypt.CompareHashAndPassword([]byte(entity.Password), []byte("brand-new-pass")))
+			require.NotNil(t, entity.PasswordChangedAt)
+			require.Zero(t, entity.FailedLoginAttempts, "a reset lifts the lockout")
+			require.Nil(t, entity.LockedUntil)
+			return nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposePasswordReset, purpose)
+			require.Equal(t, "emailed-token", token)
+			return &models.UserToken{ID: 1, UserID: 8}, nil
+		},
+		ConsumeAllByUserIDFunc: func(_ context.Context, userID uint, _ models.UserTokenPurpose) error {
+			require.Equal(t, uint(8), userID)
+			return nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		RevokeAllByUserIDFunc: func(_ context.Context, userID uint) error {
+			require.Equal(t, uint(8), userID)
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(_ context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, userTokenRepo, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	err := svc.ResetPassword(context.Background(), &dto.ResetPasswordRequest{Token: "emailed-token", NewPassword: "brand-new-pass"})
+
+	require.NoError(t, err)
+	require.Len(t, refreshRepo.RevokeAllByUserIDCalls(), 1)
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionResetPassword, entry.Action)
+		require.Equal(t, models.LogEntityTypeUser, entry.EntityType)
+		require.Equal(t, uint(8), entry.EntityID)
+		// Unauthenticated request: attributed to the account, not "Unknown".
+		require.NotNil(t, entry.UserID)
+		require.Equal(t, uint(8), *entry.UserID)
+		require.Equal(t, "Member reset password via emailed link", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit log")
+	}
+}
+
+func TestAuthServiceResetPasswordRejectsInvalidToken(t *testing.T) {
+	setupLogger(t)
+
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(context.Context, models.UserTokenPurpose, string) (*models.UserToken, error) {
+			return nil, cerrors.NewNotFoundError("invalid or expired token")
+		},
+	}
+
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	err := svc.ResetPassword(context.Background(), &dto.ResetPasswordRequest{Token: "bogus", NewPassword: "brand-new-pass"})
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Equal(t, "invalid or expired reset token", appErr.Message)
+}
+
+func TestAuthServiceVerifyEmailMarksAddressVerified(t *testing.T) {
+	setupLogger(t)
+
+	user := &models.User{ID: 8, Email: "user@example.com", IsActive: true}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			require.Equal(t, uint(8), id)
+			return user, nil
+		},
+		UpdateFunc: func(_ context.Context, u *models.User) error {
+			require.NotNil(t, u.EmailVerifiedAt)
+			return nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposeEmailVerification, purpose)
+			require.Equal(t, "verify-token", token)
+			return &models.UserToken{UserID: 8, Purpose: purpose}, nil
+		},
+		ConsumeAllByUserIDFunc: func(_ context.Context, userID uint, purpose models.UserTokenPurpose) error {
+			require.Equal(t, models.UserTokenPurposeEmailVerification, purpose)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	require.NoError(t, svc.VerifyEmail(context.Background(), &dto.VerifyEmailRequest{Token: "verify-token"}))
+	require.True(t, user.IsEmailVerified())
+	require.Len(t, userRepo.UpdateCalls(), 1)
+
+	// A second link for an already-verified address keeps the first timestamp.
+	verifiedAt := *user.EmailVerifiedAt
+	require.NoError(t, svc.VerifyEmail(context.Background(), &dto.VerifyEmailRequest{Token: "verify-token"}))
+	require.Equal(t, verifiedAt, *user.EmailVerifiedAt)
+	require.Len(t, userRepo.UpdateCalls(), 1)
+}
+
+func TestAuthServiceVerifyEmailRejectsInvalidToken(t *testing.T) {
+	setupLogger(t)
+
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(context.Context, models.UserTokenPurpose, string) (*models.UserToken, error) {
+			return nil, cerrors.NewNotFoundError("invalid or expired token")
+		},
+	}
+
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	err := svc.VerifyEmail(context.Background(), &dto.VerifyEmailRequest{Token: "bogus"})
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Equal(t, "invalid or expired verification token", appErr.Message)
+}
+
+func TestAuthServiceResendVerificationOnlyEmailsUnverifiedAccounts(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	verifiedAt := time.Now()
+	users := map[string]*models.User{
+		"pending@example.com":  {ID: 1, Email: "pending@example.com", IsActive: true},
+		"verified@example.com": {ID: 2, Email: "verified@example.com", IsActive: true, EmailVerifiedAt: &verifiedAt},
+		"inactive@example.com": {ID: 3, Email: "inactive@example.com", IsActive: false},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(_ context.Context, email string) (*models.User, error) {
+			if u, ok := users[email]; ok {
+				return u, nil
+			}
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(_ context.Context, userID uint, purpose models.UserTokenPurpose) error {
+			require.Equal(t, uint(1), userID)
+			require.Equal(t, models.UserTokenPurposeEmailVerification, purpose)
+			return nil
+		},
+		CreateFunc: func(context.Context, *models.UserToken) error { return nil },
+	}
+	var sent []mailer.Message
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(_ context.Context, msg mailer.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	for _, email := range []string{"ghost@example.com", "verified@example.com", "inactive@example.com", " Pending@Example.com "} {
+		require.NoError(t, svc.ResendVerification(context.Background(), &dto.ResendVerificationRequest{Email: email}))
+	}
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, sent, 1)
+	require.Equal(t, "pending@example.com", sent[0].To)
+	require.Contains(t, sent[0].Body, cfg.Auth.EmailVerificationURL+"?token=")
+}
+
+func TestAuthServiceListSessionsMarksCurrentSession(t *testing.T) {
+	current, other := uuid.New(), uuid.New()
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		FindActiveByUserIDFunc: func(_ context.Context, userID uint) ([]models.RefreshToken, error) {
+			require.Equal(t, uint(6), userID)
+			return []models.RefreshToken{
+				{ID: other, UserID: 6, UserAgent: "phone"},
+				{ID: current, UserID: 6, UserAgent: "laptop"},
+			}, nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6, SessionID: current})
+
+	sessions, err := svc.ListSessions(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, other.String(), sessions[0].ID)
+	require.False(t, sessions[0].Current)
+	require.Equal(t, "laptop", sessions[1].UserAgent)
+	require.True(t, sessions[1].Current)
+}
+
+func TestAuthServiceRevokeSessionReportsForeignSessionAsNotFound(t *testing.T) {
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		RevokeByIDForUserFunc: func(_ context.Context, _ uuid.UUID, userID uint) (bool, error) {
+			require.Equal(t, uint(6), userID)
+			return false, nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6})
+
+	err := svc.RevokeSession(ctx, uuid.New())
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusNotFound, appErr.Code)
+	require.Equal(t, "session not found", appErr.Message)
+}
+
+func TestAuthServiceRevokeOtherSessionsKeepsCurrentSession(t *testing.T) {
+	current := uuid.New()
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		RevokeAllByUserIDExceptIDFunc: func(_ context.Context, userID uint, exceptID uuid.UUID) error {
+			require.Equal(t, uint(6), userID)
+			require.Equal(t, current, exceptID)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	require.NoError(t, svc.RevokeOtherSessions(utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6, SessionID: current})))
+	require.Len(t, refreshRepo.RevokeAllByUserIDExceptIDCalls(), 1)
+
+	// Without a known current session nothing is revoked.
+	err := svc.RevokeOtherSessions(utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6}))
+	require.Error(t, err)
+	require.Len(t, refreshRepo.RevokeAllByUserIDExceptIDCalls(), 1)
+}
+
+func TestAuthServiceImpersonateIssuesActorBoundTokenAndAudits(t *testing.T) {
+	users := map[uint]*models.User{
+		1: {ID: 1, Name: "Root", Role: models.UserRoleRoot, IsActive: true},
+		9: {ID: 9, Name: "Member", Role: models.UserRoleUser, IsActive: true},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return users[id], nil
+		},
+	}
+	var session *models.RefreshToken
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		CreateFunc: func(_ context.Context, token *models.RefreshToken) error {
+			session = token
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	auth := newAuthJWT(t, userRepo, refreshRepo, logRepo)
+
+	svc := service.NewAuthService(setupConfig(t), userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), auth, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: string(models.UserRoleRoot)})
+
+	res, err := svc.Impersonate(ctx, 9)
+	require.NoError(t, err)
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.NotEmpty(t, res.AccessToken)
+	require.Equal(t, uint(9), res.User.ID)
+	require.WithinDuration(t, time.Now().Add(10*time.Minute), res.ExpiresAt, 5*time.Second,
+		"the token never outlives the regular access-token lifetime")
+
+	require.NotNil(t, session)
+	require.Equal(t, uint(9), session.UserID)
+	require.Equal(t, uint(1), *session.ImpersonatorID)
+	require.WithinDuration(t, time.Now().Add(15*time.Minute), session.ExpiresAt, 5*time.Second)
+
+	require.Len(t, logRepo.CreateCalls(), 1)
+	entry := logRepo.CreateCalls()[0].Entity
+	require.Equal(t, models.LogActionImpersonate, entry.Action)
+	require.Equal(t, uint(9), entry.EntityID)
+	require.Equal(t, uint(1), *entry.UserID)
+	require.Equal(t, "Root started impersonating Member", entry.Message)
+}
+
+func TestAuthServiceImpersonateRejectsForbiddenTargets(t *testing.T) {
+	users := map[uint]*models.User{
+		1: {ID: 1, Name: "Root", Role: models.UserRoleRoot, IsActive: true},
+		2: {ID: 2, Name: "Support", Role: models.UserRoleAdmin, IsActive: true},
+		3: {ID: 3, Name: "Other Root", Role: models.UserRoleRoot, IsActive: true},
+		4: {ID: 4, Name: "Editor", Role: models.UserRoleAdmin, IsActive: true},
+		5: {ID: 5, Name: "Gone", Role: models.UserRoleUser, IsActive: false},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return users[id], nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(userRole string, _ []uint, permission string) (bool, error) {
+			require.Equal(t, permissions.AdminUserImpersonate.String(), permission)
+			return userRole == string(models.UserRoleRoot), nil
+		},
+	}
+	svc := service.NewAuthService(nil, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, casbinClient, &mailermocks.MailerMock{}, passthroughTx())
+
+	root := utils.ContextValues{UserID: 1, Role: string(models.UserRoleRoot)}
+	support := utils.ContextValues{UserID: 2, Role: string(models.UserRoleAdmin)}
+	impersonating := utils.ContextValues{UserID: 9, ImpersonatorID: 1}
+	apiKey := utils.ContextValues{UserID: 1, Role: string(models.UserRoleRoot), APIKeyID: 7}
+
+	for name, tc := range map[string]struct {
+		values utils.ContextValues
+		target uint
+		code   int
+	}{
+		"chained impersonation": {impersonating, 5, http.StatusForbidden},
+		"api key":               {apiKey, 5, http.StatusForbidden},
+		"self":                  {root, 1, http.StatusBadRequest},
+		"root target":           {root, 3, http.StatusForbidden},
+		"inactive target":       {root, 5, http.StatusBadRequest},
+		"admin without grant":   {support, 4, http.StatusForbidden},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.Impersonate(utils.NewContextWithValues(context.Background(), tc.values), tc.target)
+
+			var appErr *cerrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			require.Equal(t, tc.code, appErr.Code)
+		})
+	}
+}
+
+func TestAuthServiceEndImpersonationRevokesSessionAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	session := uuid.New()
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		RevokeByIDForUserFunc: func(_ context.Context, id uuid.UUID, userID uint) (bool, error) {
+			require.Equal(t, session, id)
+			require.Equal(t, uint(9), userID)
+			return true, nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	svc := service.NewAuthService(nil, &usermocks.UserRepositoryMock{}, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	err := svc.EndImpersonation(utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 9}))
+	require.ErrorIs(t, err, cerrors.ErrInvalidInput)
+	require.Empty(t, refreshRepo.RevokeByIDForUserCalls())
+
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID: 9, UserName: "Member", SessionID: session, ImpersonatorID: 1, ImpersonatorName: "Root",
+	})
+	require.NoError(t, svc.EndImpersonation(ctx))
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, refreshRepo.RevokeByIDForUserCalls(), 1)
+	require.Len(t, logRepo.CreateCalls(), 1)
+	entry := logRepo.CreateCalls()[0].Entity
+	require.Equal(t, models.LogActionEndImpersonation, entry.Action)
+	require.Equal(t, uint(1), *entry.ImpersonatorID)
+	require.Equal(t, "Root stopped impersonating Member", entry.Message)
+}
+
+func TestAuthServiceReauthenticateWithPasswordMarksSession(t *testing.T) {
+	setupLogger(t)
+
+	session := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Password: string(hash)}, nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		MarkAuthenticatedFunc: func(_ context.Context, id uuid.UUID, _ time.Time) (bool, error) {
+			require.Equal(t, session, id)
+			return true, nil
+		},
+	}
+	securityEventRepo := stubSecurityEventRepo()
+	cfg := &config.Config{Auth: config.AuthConfig{ReauthenticationWindow: 5 * time.Minute}}
+	svc := service.NewAuthService(cfg, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, securityEventRepo, nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6, SessionID: session})
+
+	_, err = svc.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "wrong-password"})
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Empty(t, refreshRepo.MarkAuthenticatedCalls())
+
+	res, err := svc.Reauthenticate(ctx, &dto.ReauthenticateRequest{Password: "secret-password"})
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), res.ExpiresAt, time.Minute)
+	require.Len(t, refreshRepo.MarkAuthenticatedCalls(), 1)
+
+	require.NoError(t, audit.Drain(context.Background()))
+	calls := securityEventRepo.CreateCalls()
+	require.Len(t, calls, 2)
+	require.Equal(t, models.SecurityEventReauthenticate, calls[0].Entity.Type)
+	outcomes := []models.SecurityEventOutcome{calls[0].Entity.Outcome, calls[1].Entity.Outcome}
+	require.ElementsMatch(t, []models.SecurityEventOutcome{models.SecurityEventOutcomeFailure, models.SecurityEventOutcomeSuccess}, outcomes)
+}
+
+func TestAuthServiceReauthenticateWithCodeUsesSecondFactor(t *testing.T) {
+	setupLogger(t)
+
+	session := uuid.New()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		MarkAuthenticatedFunc: func(context.Context, uuid.UUID, time.Time) (bool, error) { return true, nil },
+	}
+	twoFactor := &twofactormocks.TwoFactorServiceMock{
+		VerifyCodeFunc: func(_ context.Context, user *models.User, code string) error {
+			require.Equal(t, uint(6), user.ID)
+			if code != "123456" {
+				return cerrors.NewBadRequestError("invalid two-factor code")
+			}
+			return nil
+		},
+	}
+	cfg := &config.Config{Auth: config.AuthConfig{ReauthenticationWindow: 5 * time.Minute}}
+	svc := service.NewAuthService(cfg, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, twoFactor, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6, SessionID: session})
+
+	_, err := svc.Reauthenticate(ctx, &dto.ReauthenticateRequest{Code: "000000"})
+	require.Error(t, err)
+	require.Empty(t, refreshRepo.MarkAuthenticatedCalls())
+
+	_, err = svc.Reauthenticate(ctx, &dto.ReauthenticateRequest{Code: "123456"})
+	require.NoError(t, err)
+	require.Len(t, refreshRepo.MarkAuthenticatedCalls(), 1)
+	require.NoError(t, audit.Drain(context.Background()))
+}
+
+func TestAuthServiceReauthenticateRejectsUnknownOrRevokedSession(t *testing.T) {
+	setupLogger(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Password: string(hash)}, nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		MarkAuthenticatedFunc: func(context.Context, uuid.UUID, time.Time) (bool, error) { return false, nil },
+	}
+	svc := service.NewAuthService(&config.Config{}, userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	req := &dto.ReauthenticateRequest{Password: "secret-password"}
+
+	for name, ctx := range map[string]context.Context{
+		"no session": utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6}),
+		"revoked":    utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 6, SessionID: uuid.New()}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.Reauthenticate(ctx, req)
+			var appErr *cerrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			require.Equal(t, http.StatusUnauthorized, appErr.Code)
+		})
+	}
+	require.NoError(t, audit.Drain(context.Background()))
+}
+
+func TestAuthServiceRequestMagicLinkOnlyEmailsEnabledRoles(t *testing.T) {
+	cfg := setupConfig(t)
+	cfg.Auth.MagicLinkRoles = []string{models.UserRoleUser.ToString()}
+	setupLogger(t)
+
+	enabledAt := time.Now()
+	users := map[string]*models.User{
+		"user@example.com":     {ID: 8, Name: "User", Email: "user@example.com", Role: models.UserRoleUser, IsActive: true},
+		"admin@example.com":    {ID: 9, Email: "admin@example.com", Role: models.UserRoleAdmin, IsActive: true},
+		"inactive@example.com": {ID: 10, Email: "inactive@example.com", Role: models.UserRoleUser},
+		"2fa@example.com":      {ID: 11, Email: "2fa@example.com", Role: models.UserRoleUser, IsActive: true, TwoFactorEnabledAt: &enabledAt},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByEmailFunc: func(_ context.Context, email string) (*models.User, error) {
+			if u, ok := users[email]; ok {
+				return u, nil
+			}
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+	}
+	var issued []models.UserToken
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(context.Context, uint, models.UserTokenPurpose) error { return nil },
+		CreateFunc: func(_ context.Context, token *models.UserToken) error {
+			issued = append(issued, *token)
+			return nil
+		},
+	}
+	var sent []mailer.Message
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(_ context.Context, msg mailer.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+
+	for email := range users {
+		require.NoError(t, svc.RequestMagicLink(context.Background(), &dto.MagicLinkRequest{Email: email}))
+	}
+	require.NoError(t, svc.RequestMagicLink(context.Background(), &dto.MagicLinkRequest{Email: "ghost@example.com"}))
+	require.NoError(t, audit.Drain(context.Background()))
+
+	require.Len(t, issued, 1)
+	require.Equal(t, uint(8), issued[0].UserID)
+	require.Equal(t, models.UserTokenPurposeMagicLink, issued[0].Purpose)
+	require.WithinDuration(t, time.Now().Add(cfg.Auth.MagicLinkTTL), issued[0].ExpiresAt, 5*time.Second)
+	require.Len(t, sent, 1)
+	require.Contains(t, sent[0].Body, cfg.Auth.MagicLinkURL+"?token="+issued[0].TokenHash)
+}
+
+func TestAuthServiceConsumeMagicLinkRefusesAccountsNoLongerEnabled(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Role: models.UserRoleUser, IsActive: true}, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposeMagicLink, purpose)
+			return &models.UserToken{UserID: 8, Purpose: purpose}, nil
+		},
+	}
+	securityEventRepo := stubSecurityEventRepo()
+	// The role was dropped from AUTH_MAGIC_LINK_ROLES after the link was sent.
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, securityEventRepo, nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	_, err := svc.ConsumeMagicLink(context.Background(), &dto.ConsumeMagicLinkRequest{Token: "link-token"})
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.NoError(t, audit.Drain(context.Background()))
+	require.Len(t, securityEventRepo.CreateCalls(), 1)
+	require.Equal(t, models.SecurityEventOutcomeFailure, securityEventRepo.CreateCalls()[0].Entity.Outcome)
+}
+
+func TestAuthServiceUpdateMeAppliesTrimmedChangesAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	logCh := make(chan *models.Log, 1)
+	user := &models.User{ID: 8, Name: "Member", BusinessName: "Old Co", Phone: "081", Role: models.UserRoleUser, IsActive: true}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			require.Equal(t, uint(8), id)
+			copied := *user
+			return &copied, nil
+		},
+		UpdateFunc: func(_ context.Context, u *models.User) error {
+			*user = *u
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(_ context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(setupConfig(t), userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 8, UserName: "Member"})
+
+	name, businessName, phone := "  Member Two ", "Old Co", "0812"
+	res, err := svc.UpdateMe(ctx, &dto.UpdateProfileRequest{Name: &name, BusinessName: &businessName, Phone: &phone})
+
+	require.NoError(t, err)
+	require.Equal(t, "Member Two", res.Name)
+	require.Equal(t, "0812", res.Phone)
+	require.Len(t, userRepo.UpdateCalls(), 1)
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionUpdate, entry.Action)
+		require.Equal(t, models.LogEntityTypeUser, entry.EntityType)
+		require.Equal(t, uint(8), entry.EntityID)
+		require.Equal(t, "Member updated their profile: name, phone", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit log")
+	}
+
+	// Re-sending the current values writes nothing.
+	_, err = svc.UpdateMe(ctx, &dto.UpdateProfileRequest{Name: &res.Name})
+	require.NoError(t, err)
+	require.Len(t, userRepo.UpdateCalls(), 1)
+
+	blank := "   "
+	_, err = svc.UpdateMe(ctx, &dto.UpdateProfileRequest{Phone: &blank})
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAuthServiceChangeEmailMailsLinkToNewAddressAndNoticeToOld(t *testing.T) {
+	cfg := setupConfig(t)
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+			return &models.User{ID: 8, Name: "Member", Username: "member", Email: "old@example.com", IsActive: true}, nil
+		},
+		FindByEmailFunc: func(_ context.Context, email string) (*models.User, error) {
+			require.Equal(t, "new@example.com", email)
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+	}
+	var issued *models.UserToken
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(_ context.Context, _ uint, purpose models.UserTokenPurpose) error {
+			require.Equal(t, models.UserTokenPurposeEmailChange, purpose)
+			return nil
+		},
+		CreateFunc: func(_ context.Context, token *models.UserToken) error {
+			copied := *token
+			issued = &copied
+			return nil
+		},
+	}
+	var sent []mailer.Message
+	mail := &mailermocks.MailerMock{
+		SendFunc: func(_ context.Context, msg mailer.Message) error {
+			sent = append(sent, msg)
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(cfg, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, mail, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 8})
+
+	require.NoError(t, svc.ChangeEmail(ctx, &dto.ChangeEmailRequest{Email: " New@Example.com "}))
+
+	require.NotNil(t, issued)
+	require.Equal(t, models.UserTokenPurposeEmailChange, issued.Purpose)
+	require.Equal(t, "new@example.com", issued.NewEmail)
+	require.WithinDuration(t, time.Now().Add(cfg.Auth.EmailChangeTTL), issued.ExpiresAt, 5*time.Second)
+	require.Empty(t, userRepo.UpdateCalls(), "nothing changes before confirmation")
+	require.Len(t, sent, 2)
+	require.Equal(t, "new@example.com", sent[0].To)
+	require.Contains(t, sent[0].Body, cfg.Auth.EmailChangeURL+"?token="+issued.TokenHash)
+	require.Equal(t, "old@example.com", sent[1].To)
+	require.Contains(t, sent[1].Body, "new@example.com")
+	require.NotContains(t, sent[1].Body, issued.TokenHash)
+
+	err := svc.ChangeEmail(ctx, &dto.ChangeEmailRequest{Email: "OLD@example.com"})
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAuthServiceConfirmEmailChangeMovesAddressAndAudits(t *testing.T) {
+	setupLogger(t)
+
+	logCh := make(chan *models.Log, 1)
+	user := &models.User{ID: 8, Name: "Member", Username: "old@example.com", Email: "old@example.com", Role: models.UserRoleUser, IsActive: true}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+			return user, nil
+		},
+		FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+		FindByUsernameFunc: func(context.Context, string) (*models.User, error) {
+			return nil, cerrors.NewNotFoundError("user not found")
+		},
+		UpdateFunc: func(context.Context, *models.User) error { return nil },
+	}
+	var consumed []models.UserTokenPurpose
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposeEmailChange, purpose)
+			require.Equal(t, "change-token", token)
+			return &models.UserToken{UserID: 8, Purpose: purpose, NewEmail: "new@example.com"}, nil
+		},
+		ConsumeAllByUserIDFunc: func(_ context.Context, _ uint, purpose models.UserTokenPurpose) error {
+			consumed = append(consumed, purpose)
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(_ context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, logRepo, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	require.NoError(t, svc.ConfirmEmailChange(context.Background(), &dto.ConfirmEmailChangeRequest{Token: "change-token"}))
+
+	require.Equal(t, "new@example.com", user.Email)
+	require.Equal(t, "new@example.com", user.Username, "a username mirroring the email follows it")
+	require.True(t, user.IsEmailVerified())
+	require.ElementsMatch(t, []models.UserTokenPurpose{
+		models.UserTokenPurposePasswordReset,
+		models.UserTokenPurposeEmailVerification,
+		models.UserTokenPurposeMagicLink,
+	}, consumed)
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionChangeEmail, entry.Action)
+		require.Equal(t, models.LogEntityTypeUser, entry.EntityType)
+		require.NotNil(t, entry.UserID)
+		require.Equal(t, uint(8), *entry.UserID)
+		require.Equal(t, "Member changed email from old@example.com to new@example.com", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit log")
+	}
+}
+
+func TestAuthServiceConfirmEmailChangeRejectsAddressTakenSinceRequest(t *testing.T) {
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+			return &models.User{ID: 8, Username: "member", Email: "old@example.com", IsActive: true}, nil
+		},
+		FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+			return &models.User{ID: 9, Email: "new@example.com"}, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, _ string) (*models.UserToken, error) {
+			return &models.UserToken{UserID: 8, Purpose: purpose, NewEmail: "new@example.com"}, nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	err := svc.ConfirmEmailChange(context.Background(), &dto.ConfirmEmailChangeRequest{Token: "change-token"})
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Equal(t, "email is already in use", appErr.Message)
+	require.Empty(t, userRepo.UpdateCalls())
+}
+
+func TestAuthServiceDownloadDataExportArchivesTheUsersData(t *testing.T) {
+	setupLogger(t)
+
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		FindActiveByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposeDataExport, purpose)
+			if token != "export-token" {
+				return nil, cerrors.NewNotFoundError("invalid or expired token")
+			}
+			return &models.UserToken{UserID: 8, Purpose: purpose}, nil
+		},
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
+			return &models.User{ID: 8, Name: "Member", Email: "member@example.com", IsActive: true}, nil
+		},
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		FindAllByUserIDFunc: func(context.Context, uint) ([]models.RefreshToken, error) {
+			return []models.RefreshToken{{ID: uuid.New(), UserID: 8, IPAddress: "203.0.113.7"}}, nil
+		},
+	}
+	securityEventRepo := stubSecurityEventRepo()
+	securityEventRepo.FindAllByUserIDFunc = func(context.Context, uint) ([]models.SecurityEvent, error) {
+		return []models.SecurityEvent{{ID: 1, UserID: 8, Type: models.SecurityEventLogin}}, nil
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		FindAllByUserFunc: func(context.Context, uint) ([]models.Log, error) {
+			return []models.Log{{ID: 3, Action: models.LogActionUpdate, Message: "Member updated their profile: name"}}, nil
+		},
+	}
+	identityRepo := &oidcmocks.UserIdentityRepositoryMock{
+		FindAllByUserIDFunc: func(context.Context, uint) ([]models.UserIdentity, error) {
+			return []models.UserIdentity{{ID: 4, UserID: 8, Provider: "google", Subject: "g-8", Email: "member@gmail.example"}}, nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, userTokenRepo, logRepo, securityEventRepo, identityRepo, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+
+	archive, err := svc.DownloadDataExport(context.Background(), &dto.DownloadDataExportRequest{Token: "export-token"})
+	require.NoError(t, err)
+	require.Contains(t, archive.FileName, "data-export-8-")
+
+	zr, err := zip.NewReader(bytes.NewReader(archive.Content), int64(len(archive.Content)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	require.Len(t, files, 5)
+	require.Contains(t, files["profile.json"], "member@example.com")
+	require.Contains(t, files["sessions.json"], "203.0.113.7")
+	require.Contains(t, files["security_events.json"], `"login"`)
+	require.Contains(t, files["audit_logs.json"], "updated their profile")
+	require.Contains(t, files["identities.json"], "member@gmail.example")
+	require.NotContains(t, files["identities.json"], "g-8", "the provider subject is not exported")
+
+	_, err = svc.DownloadDataExport(context.Background(), &dto.DownloadDataExportRequest{Token: "forged"})
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestAuthServiceDeleteMeAnonymisesAndDetachesAuditTrail(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("member-pass-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	now := time.Now()
+	user := &models.User{
+		ID: 8, Name: "Member", Username: "member", Email: "member@example.com", Phone: "0812", BusinessName: "Member Co",
+		Role: models.UserRoleUser, IsActive: true, Password: string(hashed), EmailVerifiedAt: &now, TwoFactorSecret: "SECRET", TwoFactorEnabledAt: &now,
+	}
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDForUpdateFunc: func(_ context.Context, id uint) (*models.User, error) {
+			require.Equal(t, uint(8), id)
+			return user, nil
+		},
+		UpdateFunc: func(context.Context, *models.User) error { return nil },
+		DeleteFunc: func(context.Context, *models.User) error { return nil },
+		// Values the account held before, and one it still holds.
+		FindPersonalDataHistoryFunc: func(context.Context, uint) ([]string, error) {
+			return []string{"Old Name", "old@example.com", "Member"}, nil
+		},
+		DeletePersonalDataHistoryFunc: func(context.Context, uint) error { return nil },
+	}
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		RevokeAllByUserIDFunc: func(context.Context, uint) error { return nil },
+	}
+	var consumed []models.UserTokenPurpose
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeAllByUserIDFunc: func(_ context.Context, _ uint, purpose models.UserTokenPurpose) error {
+			consumed = append(consumed, purpose)
+			return nil
+		},
+	}
+	securityEventRepo := stubSecurityEventRepo()
+	securityEventRepo.DeleteAllByUserIDFunc = func(context.Context, uint) error { return nil }
+	identityRepo := &oidcmocks.UserIdentityRepositoryMock{
+		DeleteAllByUserIDFunc: func(context.Context, uint) error { return nil },
+	}
+	logCh := make(chan *models.Log, 1)
+	logRepo := &logmocks.LogRepositoryMock{
+		RedactUserFunc: func(context.Context, uint, []string, string) error { return nil },
+		CreateFunc: func(_ context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, refreshRepo, userTokenRepo, logRepo, securityEventRepo, identityRepo, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 8, UserName: "Member", Role: "user"})
+
+	require.NoError(t, svc.DeleteMe(ctx, &dto.DeleteAccountRequest{Password: "member-pass-1"}))
+
+	require.Equal(t, "Deleted User", user.Name)
+	require.Equal(t, "deleted-user-8", user.Username)
+	require.Equal(t, "deleted-user-8@deleted.invalid", user.Email)
+	require.Empty(t, user.Phone)
+	require.Empty(t, user.BusinessName)
+	require.Empty(t, user.Password)
+	require.Empty(t, user.TwoFactorSecret)
+	require.False(t, user.IsActive)
+	require.Nil(t, user.EmailVerifiedAt)
+	require.Len(t, userRepo.DeleteCalls(), 1)
+	require.Len(t, refreshRepo.RevokeAllByUserIDCalls(), 1)
+	require.Len(t, securityEventRepo.DeleteAllByUserIDCalls(), 1)
+	require.Len(t, identityRepo.DeleteAllByUserIDCalls(), 1)
+	require.Contains(t, consumed, models.UserTokenPurposeDataExport)
+
+	redact := logRepo.RedactUserCalls()
+	require.Len(t, redact, 1)
+	require.Equal(t, []string{"member@example.com", "old@example.com", "Member Co", "Old Name", "Member", "member", "0812"}, redact[0].Values)
+	require.Len(t, userRepo.DeletePersonalDataHistoryCalls(), 1)
+
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionDelete, entry.Action)
+		require.Equal(t, uint(8), entry.EntityID)
+		require.Equal(t, "user #8 deleted their own account", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit log")
+	}
+}
+
+func TestAuthServiceDeleteMeRefusesRootAndWrongPassword(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("right-pass-1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	role := models.UserRoleRoot
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) {
+			return &models.User{ID: 8, Role: role, IsActive: true, Password: string(hashed)}, nil
+		},
+	}
+
+	svc := service.NewAuthService(nil, userRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &usertokenmocks.UserTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), nil, stubPasswordPolicy(), &authjwt.AuthJWT{}, nil, &casbinmocks.ClientMock{}, &mailermocks.MailerMock{}, passthroughTx())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 8})
+
+	err = svc.DeleteMe(ctx, &dto.DeleteAccountRequest{Password: "right-pass-1"})
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusForbidden, appErr.Code)
+
+	role = models.UserRoleAdmin
+	err = svc.DeleteMe(ctx, &dto.DeleteAccountRequest{Password: "wrong-pass-1"})
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Empty(t, userRepo.UpdateCalls())
+}
@@ -23,6 +23,9 @@ var _ service.CronService = &CronServiceMock{}
 //			ClearRefreshTokenFunc: func(ctx context.Context) error {
 //				panic("mock out the ClearRefreshToken method")
 //			},
+//			ClearUserTokenFunc: func(ctx context.Context) error {
+//				panic("mock out the ClearUserToken method")
+//			},
 //			RunAllCleanupJobsFunc: func(ctx context.Context) error {
 //				panic("mock out the RunAllCleanupJobs method")
 //			},
@@ -36,6 +39,9 @@ type CronServiceMock struct {
 	// ClearRefreshTokenFunc mocks the ClearRefreshToken method.
 	ClearRefreshTokenFunc func(ctx context.Context) error
 
+	// ClearUserTokenFunc mocks the ClearUserToken method.
+	ClearUserTokenFunc func(ctx context.Context) error
+
 	// RunAllCleanupJobsFunc mocks the RunAllCleanupJobs method.
 	RunAllCleanupJobsFunc func(ctx context.Context) error
 
@@ -46,6 +52,11 @@ type CronServiceMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// ClearUserToken holds details about calls to the ClearUserToken method.
+		ClearUserToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// RunAllCleanupJobs holds details about calls to the RunAllCleanupJobs method.
 		RunAllCleanupJobs []struct {
 			// Ctx is the ctx argument value.
@@ -53,6 +64,7 @@ type CronServiceMock struct {
 		}
 	}
 	lockClearRefreshToken sync.RWMutex
+	lockClearUserToken    sync.RWMutex
 	lockRunAllCleanupJobs sync.RWMutex
 }
 
@@ -88,6 +100,38 @@ func (mock *CronServiceMock) ClearRefreshTokenCalls() []struct {
 	return calls
 }
 
+// ClearUserToken calls ClearUserTokenFunc.
+func (mock *CronServiceMock) ClearUserToken(ctx context.Context) error {
+	if mock.ClearUserTokenFunc == nil {
+		panic("CronServiceMock.ClearUserTokenFunc: method is nil but CronService.ClearUserToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockClearUserToken.Lock()
+	mock.calls.ClearUserToken = append(mock.calls.ClearUserToken, callInfo)
+	mock.lockClearUserToken.Unlock()
+	return mock.ClearUserTokenFunc(ctx)
+}
+
+// ClearUserTokenCalls gets all the calls that were made to ClearUserToken.
+// Check the length with:
+//
+//	len(mockedCronService.ClearUserTokenCalls())
+func (mock *CronServiceMock) ClearUserTokenCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockClearUserToken.RLock()
+	calls = mock.calls.ClearUserToken
+	mock.lockClearUserToken.RUnlock()
+	return calls
+}
+
 // RunAllCleanupJobs calls RunAllCleanupJobsFunc.
 func (mock *CronServiceMock) RunAllCleanupJobs(ctx context.Context) error {
 	if mock.RunAllCleanupJobsFunc == nil {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
+	usertokenrepo "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
 	"github.com/PhantomX7/athleton/pkg/logger"
 
 	"go.uber.org/zap"
@@ -17,19 +18,23 @@ import (
 // CronService exposes the background cleanup jobs run by the scheduler.
 type CronService interface {
 	ClearRefreshToken(ctx context.Context) error
+	ClearUserToken(ctx context.Context) error
 	RunAllCleanupJobs(ctx context.Context) error
 }
 
 type cronService struct {
 	refreshTokenRepo repository.RefreshTokenRepository
+	userTokenRepo    usertokenrepo.UserTokenRepository
 }
 
 // NewCronService builds a CronService from its dependencies.
 func NewCronService(
 	refreshTokenRepo repository.RefreshTokenRepository,
+	userTokenRepo usertokenrepo.UserTokenRepository,
 ) CronService {
 	return &cronService{
 		refreshTokenRepo: refreshTokenRepo,
+		userTokenRepo:    userTokenRepo,
 	}
 }
 
@@ -54,6 +59,27 @@ func (s *cronService) ClearRefreshToken(ctx context.Context) error {
 	return nil
 }
 
+// ClearUserToken removes expired and consumed one-time user tokens
+func (s *cronService) ClearUserToken(ctx context.Context) error {
+	startTime := time.Now()
+	logger.Info("Starting user token cleanup job")
+
+	err := s.userTokenRepo.DeleteInvalidToken(ctx)
+	if err != nil {
+		logger.Error("Failed to clear invalid user tokens",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(startTime)),
+		)
+		return err
+	}
+
+	logger.Info("User token cleanup job completed successfully",
+		zap.Duration("duration", time.Since(startTime)),
+	)
+
+	return nil
+}
+
 // RunAllCleanupJobs runs all cleanup jobs in sequence. A failing job does not
 // stop the remaining jobs, but every failure is joined into the returned
 // error so the scheduler observes the run's real outcome.
@@ -70,6 +96,11 @@ func (s *cronService) RunAllCleanupJobs(ctx context.Context) error {
 		errs = append(errs, err)
 	}
 
+	if err := s.ClearUserToken(ctx); err != nil {
+		logger.Error("User token cleanup failed", zap.Error(err))
+		errs = append(errs, err)
+	}
+
 	logger.Info("All cleanup jobs completed",
 		zap.Duration("total_duration", time.Since(startTime)),
 	)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/PhantomX7/athleton/internal/modules/cron/service"
 	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	usertokenmocks "github.com/PhantomX7/athleton/internal/modules/user_token/repository/mocks"
 	"github.com/PhantomX7/athleton/pkg/logger"
 )
 
@@ -35,7 +36,9 @@ func TestCronServiceClearRefreshTokenDeletesInvalidTokens(t *testing.T) {
 		},
 	}
 
-	svc := service.NewCronService(repo)
+	svc := service.NewCronService(repo, &usertokenmocks.UserTokenRepositoryMock{
+		DeleteInvalidTokenFunc: func(context.Context) error { return nil },
+	})
 
 	err := svc.ClearRefreshToken(context.Background())
 
@@ -53,7 +56,9 @@ func TestCronServiceClearRefreshTokenReturnsRepositoryError(t *testing.T) {
 		},
 	}
 
-	svc := service.NewCronService(repo)
+	svc := service.NewCronService(repo, &usertokenmocks.UserTokenRepositoryMock{
+		DeleteInvalidTokenFunc: func(context.Context) error { return nil },
+	})
 
 	err := svc.ClearRefreshToken(context.Background())
 
@@ -75,7 +80,9 @@ func TestCronServiceRunAllCleanupJobsReportsJobFailures(t *testing.T) {
 		},
 	}
 
-	svc := service.NewCronService(repo)
+	svc := service.NewCronService(repo, &usertokenmocks.UserTokenRepositoryMock{
+		DeleteInvalidTokenFunc: func(context.Context) error { return nil },
+	})
 
 	err := svc.RunAllCleanupJobs(context.Background())
 
@@ -90,7 +97,46 @@ func TestCronServiceRunAllCleanupJobsSucceedsWhenJobsSucceed(t *testing.T) {
 		DeleteInvalidTokenFunc: func(context.Context) error { return nil },
 	}
 
-	svc := service.NewCronService(repo)
+	svc := service.NewCronService(repo, &usertokenmocks.UserTokenRepositoryMock{
+		DeleteInvalidTokenFunc: func(context.Context) error { return nil },
+	})
 
 	require.NoError(t, svc.RunAllCleanupJobs(context.Background()))
 }
+
+func TestCronServiceClearUserTokenDeletesInvalidTokens(t *testing.T) {
+	setupLogger(t)
+
+	called := false
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		DeleteInvalidTokenFunc: func(context.Context) error {
+			called = true
+			return nil
+		},
+	}
+
+	svc := service.NewCronService(&refreshtokenmocks.RefreshTokenRepositoryMock{}, userTokenRepo)
+
+	require.NoError(t, svc.ClearUserToken(context.Background()))
+	require.True(t, called)
+}
+
+func TestCronServiceRunAllCleanupJobsRunsEveryJobDespiteFailures(t *testing.T) {
+	setupLogger(t)
+
+	refreshErr := errors.New("refresh cleanup failed")
+	userTokenErr := errors.New("user token cleanup failed")
+	svc := service.NewCronService(
+		&refreshtokenmocks.RefreshTokenRepositoryMock{
+			DeleteInvalidTokenFunc: func(context.Context) error { return refreshErr },
+		},
+		&usertokenmocks.UserTokenRepositoryMock{
+			DeleteInvalidTokenFunc: func(context.Context) error { return userTokenErr },
+		},
+	)
+
+	err := svc.RunAllCleanupJobs(context.Background())
+
+	require.ErrorIs(t, err, refreshErr)
+	require.ErrorIs(t, err, userTokenErr)
+}
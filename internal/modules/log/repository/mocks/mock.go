@@ -35,9 +35,15 @@ var _ logrepository.LogRepository = &LogRepositoryMock{}
 //			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.Log, error) {
 //				panic("mock out the FindAll method")
 //			},
+//			FindAllByUserFunc: func(ctx context.Context, userID uint) ([]models.Log, error) {
+//				panic("mock out the FindAllByUser method")
+//			},
 //			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.Log, error) {
 //				panic("mock out the FindByID method")
 //			},
+//			RedactUserFunc: func(ctx context.Context, userID uint, values []string, replacement string) error {
+//				panic("mock out the RedactUser method")
+//			},
 //			UpdateFunc: func(ctx context.Context, entity *models.Log) error {
 //				panic("mock out the Update method")
 //			},
@@ -60,9 +66,15 @@ type LogRepositoryMock struct {
 	// FindAllFunc mocks the FindAll method.
 	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.Log, error)
 
+	// FindAllByUserFunc mocks the FindAllByUser method.
+	FindAllByUserFunc func(ctx context.Context, userID uint) ([]models.Log, error)
+
 	// FindByIDFunc mocks the FindByID method.
 	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.Log, error)
 
+	// RedactUserFunc mocks the RedactUser method.
+	RedactUserFunc func(ctx context.Context, userID uint, values []string, replacement string) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, entity *models.Log) error
 
@@ -96,6 +108,13 @@ type LogRepositoryMock struct {
 			// Pg is the pg argument value.
 			Pg *pagination.Pagination
 		}
+		// FindAllByUser holds details about calls to the FindAllByUser method.
+		FindAllByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
 		// FindByID holds details about calls to the FindByID method.
 		FindByID []struct {
 			// Ctx is the ctx argument value.
@@ -105,6 +124,17 @@ type LogRepositoryMock struct {
 			// Preloads is the preloads argument value.
 			Preloads []pkgrepository.Association
 		}
+		// RedactUser holds details about calls to the RedactUser method.
+		RedactUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Values is the values argument value.
+			Values []string
+			// Replacement is the replacement argument value.
+			Replacement string
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -113,12 +143,14 @@ type LogRepositoryMock struct {
 			Entity *models.Log
 		}
 	}
-	lockCount    sync.RWMutex
-	lockCreate   sync.RWMutex
-	lockDelete   sync.RWMutex
-	lockFindAll  sync.RWMutex
-	lockFindByID sync.RWMutex
-	lockUpdate   sync.RWMutex
+	lockCount         sync.RWMutex
+	lockCreate        sync.RWMutex
+	lockDelete        sync.RWMutex
+	lockFindAll       sync.RWMutex
+	lockFindAllByUser sync.RWMutex
+	lockFindByID      sync.RWMutex
+	lockRedactUser    sync.RWMutex
+	lockUpdate        sync.RWMutex
 }
 
 // Count calls CountFunc.
@@ -265,6 +297,42 @@ func (mock *LogRepositoryMock) FindAllCalls() []struct {
 	return calls
 }
 
+// FindAllByUser calls FindAllByUserFunc.
+func (mock *LogRepositoryMock) FindAllByUser(ctx context.Context, userID uint) ([]models.Log, error) {
+	if mock.FindAllByUserFunc == nil {
+		panic("LogRepositoryMock.FindAllByUserFunc: method is nil but LogRepository.FindAllByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindAllByUser.Lock()
+	mock.calls.FindAllByUser = append(mock.calls.FindAllByUser, callInfo)
+	mock.lockFindAllByUser.Unlock()
+	return mock.FindAllByUserFunc(ctx, userID)
+}
+
+// FindAllByUserCalls gets all the calls that were made to FindAllByUser.
+// Check the length with:
+//
+//	len(mockedLogRepository.FindAllByUserCalls())
+func (mock *LogRepositoryMock) FindAllByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindAllByUser.RLock()
+	calls = mock.calls.FindAllByUser
+	mock.lockFindAllByUser.RUnlock()
+	return calls
+}
+
 // FindByID calls FindByIDFunc.
 func (mock *LogRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.Log, error) {
 	if mock.FindByIDFunc == nil {
@@ -305,6 +373,50 @@ func (mock *LogRepositoryMock) FindByIDCalls() []struct {
 	return calls
 }
 
+// RedactUser calls RedactUserFunc.
+func (mock *LogRepositoryMock) RedactUser(ctx context.Context, userID uint, values []string, replacement string) error {
+	if mock.RedactUserFunc == nil {
+		panic("LogRepositoryMock.RedactUserFunc: method is nil but LogRepository.RedactUser was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      uint
+		Values      []string
+		Replacement string
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		Values:      values,
+		Replacement: replacement,
+	}
+	mock.lockRedactUser.Lock()
+	mock.calls.RedactUser = append(mock.calls.RedactUser, callInfo)
+	mock.lockRedactUser.Unlock()
+	return mock.RedactUserFunc(ctx, userID, values, replacement)
+}
+
+// RedactUserCalls gets all the calls that were made to RedactUser.
+// Check the length with:
+//
+//	len(mockedLogRepository.RedactUserCalls())
+func (mock *LogRepositoryMock) RedactUserCalls() []struct {
+	Ctx         context.Context
+	UserID      uint
+	Values      []string
+	Replacement string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      uint
+		Values      []string
+		Replacement string
+	}
+	mock.lockRedactUser.RLock()
+	calls = mock.calls.RedactUser
+	mock.lockRedactUser.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *LogRepositoryMock) Update(ctx context.Context, entity *models.Log) error {
 	if mock.UpdateFunc == nil {
@@ -2,10 +2,20 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PhantomX7/athleton/internal/generated"
 	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . LogRepository
@@ -13,6 +23,8 @@ import (
 // LogRepository defines the interface for log repository operations
 type LogRepository interface {
 	repository.Repository[models.Log]
+	FindAllByUser(ctx context.Context, userID uint) ([]models.Log, error)
+	RedactUser(ctx context.Context, userID uint, values []string, replacement string) error
 }
 
 // logRepository implements the LogRepository interface
@@ -26,3 +38,104 @@ func NewLogRepository(db *gorm.DB) LogRepository {
 		BaseRepository: repository.NewBaseRepository[models.Log](db),
 	}
 }
+
+// userLogs matches the entries a user took part in: those they acted in,
+// directly or while impersonating someone, and those about their account.
+func userLogs(userID uint) clause.Expression {
+	return clause.Or(
+		generated.Log.UserID.Eq(userID),
+		generated.Log.ImpersonatorID.Eq(userID),
+		clause.And(
+			generated.Log.EntityType.Eq(models.LogEntityTypeUser),
+			generated.Log.EntityID.Eq(userID),
+		),
+	)
+}
+
+// FindAllByUser returns every entry the user took part in, oldest first.
+func (r *logRepository) FindAllByUser(ctx context.Context, userID uint) ([]models.Log, error) {
+	logs, err := gorm.G[models.Log](r.GetDB(ctx)).
+		Where(userLogs(userID)).
+		Order(generated.Log.ID.Asc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find logs for user id %v", userID), err)
+	}
+	return logs, nil
+}
+
+// RedactUser replaces each of values with replacement in the messages of the
+// entries the user took part in. Only whole tokens are replaced: a value
+// bordered by a letter or digit is part of a longer word, such as "Al" in
+// another admin's "Alice", and is left alone. Where several values start at
+// the same place the longest wins, and replaced text is never matched again.
+// No other column, updated_at included, is touched, so the entries keep their
+// place in the audit trail. Empty values are skipped.
+func (r *logRepository) RedactUser(ctx context.Context, userID uint, values []string, replacement string) error {
+	values = slices.DeleteFunc(slices.Clone(values), func(v string) bool { return v == "" })
+	if len(values) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(values, func(a, b string) int { return len(b) - len(a) })
+
+	logs, err := r.FindAllByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, log := range logs {
+		message := redactTokens(log.Message, values, replacement)
+		if message == log.Message {
+			continue
+		}
+		err := r.GetDB(ctx).Model(&models.Log{}).
+			Where(generated.Log.ID.Eq(log.ID)).
+			UpdateColumn(generated.Log.Message.Column().Name, message).Error
+		if err != nil {
+			return cerrors.NewInternalServerError(fmt.Sprintf("failed to redact logs for user id %v", userID), err)
+		}
+	}
+	return nil
+}
+
+// redactTokens scans message once, replacing each whole-token occurrence of
+// one of values (sorted longest first) with replacement.
+func redactTokens(message string, values []string, replacement string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); {
+		matched := ""
+		for _, v := range values {
+			if strings.HasPrefix(message[i:], v) && !extendsWord(message, i, i+len(v)) {
+				matched = v
+				break
+			}
+		}
+		if matched == "" {
+			_, size := utf8.DecodeRuneInString(message[i:])
+			b.WriteString(message[i : i+size])
+			i += size
+			continue
+		}
+		b.WriteString(replacement)
+		i += len(matched)
+	}
+	return b.String()
+}
+
+// extendsWord reports whether message[start:end] is glued to a longer word:
+// it starts with a letter or digit that follows another, or ends with one
+// that precedes another. An edge that is punctuation, like the "+" of a
+// phone number, needs no border.
+func extendsWord(message string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(message[start:end])
+	before, n := utf8.DecodeLastRuneInString(message[:start])
+	if n > 0 && isWordRune(first) && isWordRune(before) {
+		return true
+	}
+	last, _ := utf8.DecodeLastRuneInString(message[start:end])
+	after, n := utf8.DecodeRuneInString(message[end:])
+	return n > 0 && isWordRune(last) && isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/require"
@@ -231,3 +232,84 @@ func TestLogRepositoryFindAllReturnsEmptySlice(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, count)
 }
+
+func TestLogRepositoryFindAllByUserMatchesActorImpersonatorAndSubject(t *testing.T) {
+	db := setupDB(t)
+	repo := logrepository.NewLogRepository(db)
+
+	user := seedUser(t, db)
+	other := user.ID + 100
+	seeds := []*models.Log{
+		{UserID: &user.ID, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeConfig, EntityID: 1, Message: "acted"},
+		{UserID: &other, ImpersonatorID: &user.ID, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeConfig, EntityID: 2, Message: "impersonated"},
+		{UserID: &other, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeUser, EntityID: user.ID, Message: "subject"},
+		{UserID: &other, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeConfig, EntityID: user.ID, Message: "config with the same id"},
+		{UserID: &other, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeUser, EntityID: other, Message: "someone else"},
+	}
+	for _, seed := range seeds {
+		require.NoError(t, db.Create(seed).Error)
+	}
+
+	logs, err := repo.FindAllByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	require.Equal(t, "acted", logs[0].Message)
+	require.Equal(t, "impersonated", logs[1].Message)
+	require.Equal(t, "subject", logs[2].Message)
+}
+
+func TestLogRepositoryRedactUserRewritesOnlyTheirMessages(t *testing.T) {
+	db := setupDB(t)
+	repo := logrepository.NewLogRepository(db)
+
+	user := seedUser(t, db)
+	other := user.ID + 100
+	own := &models.Log{UserID: &user.ID, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeUser, EntityID: user.ID, Message: "Actor changed email from actor@example.com to new@example.com"}
+	foreign := &models.Log{UserID: &other, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeConfig, EntityID: 1, Message: "Actor is also someone else's name"}
+	require.NoError(t, db.Create(own).Error)
+	require.NoError(t, db.Create(foreign).Error)
+	updatedAt := own.UpdatedAt
+
+	err := repo.RedactUser(context.Background(), user.ID, []string{"actor@example.com", "new@example.com", "Actor", ""}, "[x]")
+	require.NoError(t, err)
+
+	var stored models.Log
+	require.NoError(t, db.First(&stored, own.ID).Error)
+	require.Equal(t, "[x] changed email from [x] to [x]", stored.Message)
+	require.Equal(t, models.LogActionUpdate, stored.Action)
+	require.WithinDuration(t, updatedAt, stored.UpdatedAt, time.Millisecond)
+
+	var untouched models.Log
+	require.NoError(t, db.First(&untouched, foreign.ID).Error)
+	require.Equal(t, "Actor is also someone else's name", untouched.Message)
+}
+
+func TestLogRepositoryRedactUserOnlyReplacesWholeTokens(t *testing.T) {
+	db := setupDB(t)
+	repo := logrepository.NewLogRepository(db)
+
+	user := seedUser(t, db)
+	other := user.ID + 100
+	logs := []*models.Log{
+		{UserID: &other, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeUser, EntityID: user.ID, Message: "Alice updated user: Al"},
+		{UserID: &user.ID, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeUser, EntityID: other, Message: "Al updated user: Alice (al@example.com, +6281234)"},
+		{UserID: &user.ID, Action: models.LogActionUpdate, EntityType: models.LogEntityTypeConfig, EntityID: 1, Message: "Al renamed Ann's config to Annabel, Al's"},
+	}
+	for _, log := range logs {
+		require.NoError(t, db.Create(log).Error)
+	}
+
+	err := repo.RedactUser(context.Background(), user.ID, []string{"Al", "al", "al@example.com", "Ann", "+6281234"}, "[x]")
+	require.NoError(t, err)
+
+	want := []string{
+		"Alice updated user: [x]",
+		"[x] updated user: Alice ([x], [x])",
+		"[x] renamed [x]'s config to Annabel, [x]'s",
+	}
+	for i, log := range logs {
+		var stored models.Log
+		require.NoError(t, db.First(&stored, log.ID).Error)
+		require.Equal(t, want[i], stored.Message)
+	}
+}
@@ -3,12 +3,18 @@ package modules
 
 import (
 	"github.com/PhantomX7/athleton/internal/modules/admin_role"
+	"github.com/PhantomX7/athleton/internal/modules/api_key"
 	"github.com/PhantomX7/athleton/internal/modules/auth"
 	"github.com/PhantomX7/athleton/internal/modules/config"
 	"github.com/PhantomX7/athleton/internal/modules/cron"
 	"github.com/PhantomX7/athleton/internal/modules/log"
+	"github.com/PhantomX7/athleton/internal/modules/oidc"
+	"github.com/PhantomX7/athleton/internal/modules/password_policy"
 	"github.com/PhantomX7/athleton/internal/modules/refresh_token"
+	"github.com/PhantomX7/athleton/internal/modules/security_event"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor"
 	"github.com/PhantomX7/athleton/internal/modules/user"
+	"github.com/PhantomX7/athleton/internal/modules/user_token"
 
 	"go.uber.org/fx"
 )
@@ -16,10 +22,16 @@ import (
 // Module groups all application modules behind a single Fx option.
 var Module = fx.Options(
 	admin_role.Module,
+	api_key.Module,
 	auth.Module,
 	config.Module,
 	cron.Module,
 	log.Module,
+	oidc.Module,
+	password_policy.Module,
 	refresh_token.Module,
+	security_event.Module,
+	two_factor.Module,
 	user.Module,
+	user_token.Module,
 )
@@ -0,0 +1,156 @@
+// Package controller exposes HTTP handlers for OpenID Connect login.
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/service"
+	"github.com/PhantomX7/athleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginStateCookie holds the OIDC login in progress between the start and
+// callback requests.
+const LoginStateCookie = "oidc_login"
+
+// OIDCController defines the interface for OpenID Connect login controller operations
+type OIDCController interface {
+	Start(ctx *gin.Context)
+	Callback(ctx *gin.Context)
+	ListIdentities(ctx *gin.Context)
+}
+
+type oidcController struct {
+	oidcService service.OIDCService
+	cookies     authjwt.SessionCookies
+}
+
+// NewOIDCController constructs an OIDCController.
+func NewOIDCController(oidcService service.OIDCService, cookies authjwt.SessionCookies) OIDCController {
+	return &oidcController{
+		oidcService: oidcService,
+		cookies:     cookies,
+	}
+}
+
+// Start sends the browser to the identity provider to log in.
+//
+//	@Summary		Start OIDC login
+//	@Description	Redirect to the identity provider's login page (authorization code flow with PKCE). The login in progress is kept in an HttpOnly cookie that the callback checks.
+//	@Tags			auth
+//	@Param			provider	path	string	true	"Provider name from OIDC_PROVIDERS"
+//	@Success		302
+//	@Failure		404	{object}	response.Response
+//	@Failure		429	{object}	response.Response
+//	@Failure		502	{object}	response.Response
+//	@Router			/auth/oidc/{provider}/start [get]
+func (c *oidcController) Start(ctx *gin.Context) {
+	res, err := c.oidcService.Start(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	value, err := json.Marshal(res.State)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	// Lax, not Strict: the callback is a top-level navigation from the
+	// provider's site, which Strict would strip the cookie from.
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(LoginStateCookie, base64.RawURLEncoding.EncodeToString(value),
+		int(time.Until(res.State.ExpiresAt).Seconds()), loginStatePath(ctx), "", true, true)
+	ctx.Redirect(http.StatusFound, res.URL)
+}
+
+// Callback completes a login the identity provider redirected back from.
+//
+//	@Summary		OIDC login callback
+//	@Description	Redeem the authorization code the identity provider redirected back with and return auth tokens, like a password login. The identity is linked to the account holding its verified email, or to a new account when the provider allows regular users.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			provider			path		string	true	"Provider name from OIDC_PROVIDERS"
+//	@Param			code				query		string	false	"Authorization code"
+//	@Param			state				query		string	false	"State echoed by the provider"
+//	@Param			error				query		string	false	"Error code from the provider"
+//	@Param			error_description	query		string	false	"Error description from the provider"
+//	@Success		200					{object}	response.Response{data=dto.AuthResponse}
+//	@Failure		400					{object}	response.Response
+//	@Failure		403					{object}	response.Response
+//	@Failure		404					{object}	response.Response
+//	@Failure		429					{object}	response.Response
+//	@Router			/auth/oidc/{provider}/callback [get]
+func (c *oidcController) Callback(ctx *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	login := loginStateFromCookie(ctx)
+	// The login is single-use whatever the outcome.
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(LoginStateCookie, "", -1, loginStatePath(ctx), "", true, true)
+
+	res, err := c.oidcService.Callback(ctx.Request.Context(), ctx.Param("provider"), &req, login)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.cookies.SetSessionCookies(ctx, res)
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("login success", res))
+}
+
+// ListIdentities lists the identity-provider accounts linked to the caller.
+//
+//	@Summary		List linked identities
+//	@Description	List the identity-provider accounts linked to the authenticated user
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=[]dto.UserIdentityResponse}
+//	@Failure		401	{object}	response.Response
+//	@Router			/auth/me/identities [get]
+func (c *oidcController) ListIdentities(ctx *gin.Context) {
+	res, err := c.oidcService.ListIdentities(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("identities retrieved", res))
+}
+
+// loginStatePath scopes the login cookie to the provider's routes, so it is
+// only sent back to its callback.
+func loginStatePath(ctx *gin.Context) string {
+	path := ctx.Request.URL.Path
+	return path[:strings.LastIndex(path, "/")]
+}
+
+// loginStateFromCookie decodes the login kept by Start, or returns nil when
+// there is none.
+func loginStateFromCookie(ctx *gin.Context) *dto.OIDCLoginState {
+	value, err := ctx.Cookie(LoginStateCookie)
+	if err != nil {
+		return nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil
+	}
+	var login dto.OIDCLoginState
+	if err := json.Unmarshal(raw, &login); err != nil {
+		return nil
+	}
+	return &login
+}
@@ -0,0 +1,127 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	authjwtmocks "github.com/PhantomX7/athleton/internal/modules/auth/jwt/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/controller"
+	oidcservicemocks "github.com/PhantomX7/athleton/internal/modules/oidc/service/mocks"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newRouter(ctrl controller.OIDCController) *gin.Engine {
+	router := gin.New()
+	router.GET("/auth/oidc/:provider/start", ctrl.Start)
+	router.GET("/auth/oidc/:provider/callback", ctrl.Callback)
+	return router
+}
+
+func TestOIDCControllerStartRedirectsWithLoginCookie(t *testing.T) {
+	login := dto.OIDCLoginState{
+		Provider:  "corp",
+		State:     "the-state",
+		Nonce:     "the-nonce",
+		Verifier:  "the-verifier",
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}
+	svc := &oidcservicemocks.OIDCServiceMock{
+		StartFunc: func(_ context.Context, provider string) (*dto.OIDCAuthorization, error) {
+			require.Equal(t, "corp", provider)
+			return &dto.OIDCAuthorization{URL: "https://sso.example.com/authorize?state=the-state", State: login}, nil
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	newRouter(controller.NewOIDCController(svc, &authjwtmocks.SessionCookiesMock{})).ServeHTTP(rec,
+		httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/corp/start", nil))
+
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "https://sso.example.com/authorize?state=the-state", rec.Header().Get("Location"))
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	require.Equal(t, controller.LoginStateCookie, cookie.Name)
+	require.Equal(t, "/auth/oidc/corp", cookie.Path)
+	require.True(t, cookie.HttpOnly)
+	require.True(t, cookie.Secure)
+	require.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	require.Positive(t, cookie.MaxAge)
+}
+
+func TestOIDCControllerCallbackHandsLoginToServiceAndClearsCookie(t *testing.T) {
+	start := &oidcservicemocks.OIDCServiceMock{
+		StartFunc: func(context.Context, string) (*dto.OIDCAuthorization, error) {
+			return &dto.OIDCAuthorization{URL: "https://sso.example.com/authorize", State: dto.OIDCLoginState{
+				Provider: "corp", State: "the-state", Nonce: "the-nonce", Verifier: "the-verifier",
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			}}, nil
+		},
+	}
+	rec := httptest.NewRecorder()
+	newRouter(controller.NewOIDCController(start, &authjwtmocks.SessionCookiesMock{})).ServeHTTP(rec,
+		httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/corp/start", nil))
+	loginCookie := rec.Result().Cookies()[0]
+
+	var sessionSet bool
+	cookies := &authjwtmocks.SessionCookiesMock{
+		SetSessionCookiesFunc: func(*gin.Context, *dto.AuthResponse) { sessionSet = true },
+	}
+	svc := &oidcservicemocks.OIDCServiceMock{
+		CallbackFunc: func(_ context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error) {
+			require.Equal(t, "corp", provider)
+			require.Equal(t, "the-code", req.Code)
+			require.Equal(t, "the-state", req.State)
+			require.NotNil(t, login)
+			require.Equal(t, "the-verifier", login.Verifier)
+			require.Equal(t, "the-nonce", login.Nonce)
+			return &dto.AuthResponse{AccessToken: "access", RefreshToken: "refresh"}, nil
+		},
+	}
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/corp/callback?code=the-code&state=the-state", nil)
+	req.AddCookie(loginCookie)
+	rec = httptest.NewRecorder()
+	newRouter(controller.NewOIDCController(svc, cookies)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, sessionSet)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "login success", body["message"])
+
+	cleared := rec.Result().Cookies()
+	require.Len(t, cleared, 1)
+	require.Equal(t, controller.LoginStateCookie, cleared[0].Name)
+	require.Negative(t, cleared[0].MaxAge)
+}
+
+func TestOIDCControllerCallbackWithoutCookiePassesNoLogin(t *testing.T) {
+	svc := &oidcservicemocks.OIDCServiceMock{
+		CallbackFunc: func(_ context.Context, _ string, _ *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error) {
+			require.Nil(t, login)
+			return &dto.AuthResponse{}, nil
+		},
+	}
+	cookies := &authjwtmocks.SessionCookiesMock{
+		SetSessionCookiesFunc: func(*gin.Context, *dto.AuthResponse) {},
+	}
+
+	rec := httptest.NewRecorder()
+	newRouter(controller.NewOIDCController(svc, cookies)).ServeHTTP(rec,
+		httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/corp/callback?code=c&state=s", nil))
+
+	require.Len(t, svc.CallbackCalls(), 1)
+}
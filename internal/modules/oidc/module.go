@@ -0,0 +1,25 @@
+// Package oidc wires the OpenID Connect login module.
+package oidc
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/oidc/controller"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/service"
+	"github.com/PhantomX7/athleton/internal/routes"
+
+	"go.uber.org/fx"
+)
+
+// Module wires the OpenID Connect login module dependencies into the Fx container.
+var Module = fx.Options(
+	fx.Provide(
+		controller.NewOIDCController,
+		service.NewOIDCService,
+		repository.NewUserIdentityRepository,
+		fx.Annotate(
+			NewRoutes,
+			fx.As(new(routes.Registrar)),
+			fx.ResultTags(`group:"routes"`),
+		),
+	),
+)
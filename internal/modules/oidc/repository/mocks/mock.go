@@ -0,0 +1,610 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	oidcrepository "github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that UserIdentityRepositoryMock does implement oidcrepository.UserIdentityRepository.
+// If this is not the case, regenerate this file with moq.
+var _ oidcrepository.UserIdentityRepository = &UserIdentityRepositoryMock{}
+
+// UserIdentityRepositoryMock is a mock implementation of oidcrepository.UserIdentityRepository.
+//
+//	func TestSomethingThatUsesUserIdentityRepository(t *testing.T) {
+//
+//		// make and configure a mocked oidcrepository.UserIdentityRepository
+//		mockedUserIdentityRepository := &UserIdentityRepositoryMock{
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.UserIdentity) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.UserIdentity) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteAllByUserIDFunc: func(ctx context.Context, userID uint) error {
+//				panic("mock out the DeleteAllByUserID method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.UserIdentity, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindAllByUserIDFunc: func(ctx context.Context, userID uint) ([]models.UserIdentity, error) {
+//				panic("mock out the FindAllByUserID method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserIdentity, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			FindByProviderSubjectFunc: func(ctx context.Context, provider string, subject string) (*models.UserIdentity, error) {
+//				panic("mock out the FindByProviderSubject method")
+//			},
+//			FindByUserIDAndProviderFunc: func(ctx context.Context, userID uint, provider string) (*models.UserIdentity, error) {
+//				panic("mock out the FindByUserIDAndProvider method")
+//			},
+//			TouchLoginFunc: func(ctx context.Context, id uint, email string) error {
+//				panic("mock out the TouchLogin method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.UserIdentity) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedUserIdentityRepository in code that requires oidcrepository.UserIdentityRepository
+//		// and then make assertions.
+//
+//	}
+type UserIdentityRepositoryMock struct {
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.UserIdentity) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.UserIdentity) error
+
+	// DeleteAllByUserIDFunc mocks the DeleteAllByUserID method.
+	DeleteAllByUserIDFunc func(ctx context.Context, userID uint) error
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.UserIdentity, error)
+
+	// FindAllByUserIDFunc mocks the FindAllByUserID method.
+	FindAllByUserIDFunc func(ctx context.Context, userID uint) ([]models.UserIdentity, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserIdentity, error)
+
+	// FindByProviderSubjectFunc mocks the FindByProviderSubject method.
+	FindByProviderSubjectFunc func(ctx context.Context, provider string, subject string) (*models.UserIdentity, error)
+
+	// FindByUserIDAndProviderFunc mocks the FindByUserIDAndProvider method.
+	FindByUserIDAndProviderFunc func(ctx context.Context, userID uint, provider string) (*models.UserIdentity, error)
+
+	// TouchLoginFunc mocks the TouchLogin method.
+	TouchLoginFunc func(ctx context.Context, id uint, email string) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.UserIdentity) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserIdentity
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserIdentity
+		}
+		// DeleteAllByUserID holds details about calls to the DeleteAllByUserID method.
+		DeleteAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindAllByUserID holds details about calls to the FindAllByUserID method.
+		FindAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// FindByProviderSubject holds details about calls to the FindByProviderSubject method.
+		FindByProviderSubject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// FindByUserIDAndProvider holds details about calls to the FindByUserIDAndProvider method.
+		FindByUserIDAndProvider []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Provider is the provider argument value.
+			Provider string
+		}
+		// TouchLogin holds details about calls to the TouchLogin method.
+		TouchLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Email is the email argument value.
+			Email string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserIdentity
+		}
+	}
+	lockCount                   sync.RWMutex
+	lockCreate                  sync.RWMutex
+	lockDelete                  sync.RWMutex
+	lockDeleteAllByUserID       sync.RWMutex
+	lockFindAll                 sync.RWMutex
+	lockFindAllByUserID         sync.RWMutex
+	lockFindByID                sync.RWMutex
+	lockFindByProviderSubject   sync.RWMutex
+	lockFindByUserIDAndProvider sync.RWMutex
+	lockTouchLogin              sync.RWMutex
+	lockUpdate                  sync.RWMutex
+}
+
+// Count calls CountFunc.
+func (mock *UserIdentityRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("UserIdentityRepositoryMock.CountFunc: method is nil but UserIdentityRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.CountCalls())
+func (mock *UserIdentityRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *UserIdentityRepositoryMock) Create(ctx context.Context, entity *models.UserIdentity) error {
+	if mock.CreateFunc == nil {
+		panic("UserIdentityRepositoryMock.CreateFunc: method is nil but UserIdentityRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.CreateCalls())
+func (mock *UserIdentityRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserIdentity
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *UserIdentityRepositoryMock) Delete(ctx context.Context, entity *models.UserIdentity) error {
+	if mock.DeleteFunc == nil {
+		panic("UserIdentityRepositoryMock.DeleteFunc: method is nil but UserIdentityRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.DeleteCalls())
+func (mock *UserIdentityRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserIdentity
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteAllByUserID calls DeleteAllByUserIDFunc.
+func (mock *UserIdentityRepositoryMock) DeleteAllByUserID(ctx context.Context, userID uint) error {
+	if mock.DeleteAllByUserIDFunc == nil {
+		panic("UserIdentityRepositoryMock.DeleteAllByUserIDFunc: method is nil but UserIdentityRepository.DeleteAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAllByUserID.Lock()
+	mock.calls.DeleteAllByUserID = append(mock.calls.DeleteAllByUserID, callInfo)
+	mock.lockDeleteAllByUserID.Unlock()
+	return mock.DeleteAllByUserIDFunc(ctx, userID)
+}
+
+// DeleteAllByUserIDCalls gets all the calls that were made to DeleteAllByUserID.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.DeleteAllByUserIDCalls())
+func (mock *UserIdentityRepositoryMock) DeleteAllByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockDeleteAllByUserID.RLock()
+	calls = mock.calls.DeleteAllByUserID
+	mock.lockDeleteAllByUserID.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *UserIdentityRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.UserIdentity, error) {
+	if mock.FindAllFunc == nil {
+		panic("UserIdentityRepositoryMock.FindAllFunc: method is nil but UserIdentityRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.FindAllCalls())
+func (mock *UserIdentityRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindAllByUserID calls FindAllByUserIDFunc.
+func (mock *UserIdentityRepositoryMock) FindAllByUserID(ctx context.Context, userID uint) ([]models.UserIdentity, error) {
+	if mock.FindAllByUserIDFunc == nil {
+		panic("UserIdentityRepositoryMock.FindAllByUserIDFunc: method is nil but UserIdentityRepository.FindAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindAllByUserID.Lock()
+	mock.calls.FindAllByUserID = append(mock.calls.FindAllByUserID, callInfo)
+	mock.lockFindAllByUserID.Unlock()
+	return mock.FindAllByUserIDFunc(ctx, userID)
+}
+
+// FindAllByUserIDCalls gets all the calls that were made to FindAllByUserID.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.FindAllByUserIDCalls())
+func (mock *UserIdentityRepositoryMock) FindAllByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindAllByUserID.RLock()
+	calls = mock.calls.FindAllByUserID
+	mock.lockFindAllByUserID.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *UserIdentityRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserIdentity, error) {
+	if mock.FindByIDFunc == nil {
+		panic("UserIdentityRepositoryMock.FindByIDFunc: method is nil but UserIdentityRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.FindByIDCalls())
+func (mock *UserIdentityRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// FindByProviderSubject calls FindByProviderSubjectFunc.
+func (mock *UserIdentityRepositoryMock) FindByProviderSubject(ctx context.Context, provider string, subject string) (*models.UserIdentity, error) {
+	if mock.FindByProviderSubjectFunc == nil {
+		panic("UserIdentityRepositoryMock.FindByProviderSubjectFunc: method is nil but UserIdentityRepository.FindByProviderSubject was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Subject:  subject,
+	}
+	mock.lockFindByProviderSubject.Lock()
+	mock.calls.FindByProviderSubject = append(mock.calls.FindByProviderSubject, callInfo)
+	mock.lockFindByProviderSubject.Unlock()
+	return mock.FindByProviderSubjectFunc(ctx, provider, subject)
+}
+
+// FindByProviderSubjectCalls gets all the calls that were made to FindByProviderSubject.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.FindByProviderSubjectCalls())
+func (mock *UserIdentityRepositoryMock) FindByProviderSubjectCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Subject  string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}
+	mock.lockFindByProviderSubject.RLock()
+	calls = mock.calls.FindByProviderSubject
+	mock.lockFindByProviderSubject.RUnlock()
+	return calls
+}
+
+// FindByUserIDAndProvider calls FindByUserIDAndProviderFunc.
+func (mock *UserIdentityRepositoryMock) FindByUserIDAndProvider(ctx context.Context, userID uint, provider string) (*models.UserIdentity, error) {
+	if mock.FindByUserIDAndProviderFunc == nil {
+		panic("UserIdentityRepositoryMock.FindByUserIDAndProviderFunc: method is nil but UserIdentityRepository.FindByUserIDAndProvider was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uint
+		Provider string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Provider: provider,
+	}
+	mock.lockFindByUserIDAndProvider.Lock()
+	mock.calls.FindByUserIDAndProvider = append(mock.calls.FindByUserIDAndProvider, callInfo)
+	mock.lockFindByUserIDAndProvider.Unlock()
+	return mock.FindByUserIDAndProviderFunc(ctx, userID, provider)
+}
+
+// FindByUserIDAndProviderCalls gets all the calls that were made to FindByUserIDAndProvider.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.FindByUserIDAndProviderCalls())
+func (mock *UserIdentityRepositoryMock) FindByUserIDAndProviderCalls() []struct {
+	Ctx      context.Context
+	UserID   uint
+	Provider string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uint
+		Provider string
+	}
+	mock.lockFindByUserIDAndProvider.RLock()
+	calls = mock.calls.FindByUserIDAndProvider
+	mock.lockFindByUserIDAndProvider.RUnlock()
+	return calls
+}
+
+// TouchLogin calls TouchLoginFunc.
+func (mock *UserIdentityRepositoryMock) TouchLogin(ctx context.Context, id uint, email string) error {
+	if mock.TouchLoginFunc == nil {
+		panic("UserIdentityRepositoryMock.TouchLoginFunc: method is nil but UserIdentityRepository.TouchLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    uint
+		Email string
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Email: email,
+	}
+	mock.lockTouchLogin.Lock()
+	mock.calls.TouchLogin = append(mock.calls.TouchLogin, callInfo)
+	mock.lockTouchLogin.Unlock()
+	return mock.TouchLoginFunc(ctx, id, email)
+}
+
+// TouchLoginCalls gets all the calls that were made to TouchLogin.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.TouchLoginCalls())
+func (mock *UserIdentityRepositoryMock) TouchLoginCalls() []struct {
+	Ctx   context.Context
+	ID    uint
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    uint
+		Email string
+	}
+	mock.lockTouchLogin.RLock()
+	calls = mock.calls.TouchLogin
+	mock.lockTouchLogin.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *UserIdentityRepositoryMock) Update(ctx context.Context, entity *models.UserIdentity) error {
+	if mock.UpdateFunc == nil {
+		panic("UserIdentityRepositoryMock.UpdateFunc: method is nil but UserIdentityRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedUserIdentityRepository.UpdateCalls())
+func (mock *UserIdentityRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserIdentity
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserIdentity
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
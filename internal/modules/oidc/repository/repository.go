@@ -0,0 +1,111 @@
+// Package repository provides linked-identity persistence primitives.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . UserIdentityRepository
+
+// UserIdentityRepository defines the interface for linked-identity operations.
+type UserIdentityRepository interface {
+	repository.Repository[models.UserIdentity]
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	FindByUserIDAndProvider(ctx context.Context, userID uint, provider string) (*models.UserIdentity, error)
+	FindAllByUserID(ctx context.Context, userID uint) ([]models.UserIdentity, error)
+	TouchLogin(ctx context.Context, id uint, email string) error
+	DeleteAllByUserID(ctx context.Context, userID uint) error
+}
+
+type userIdentityRepository struct {
+	repository.BaseRepository[models.UserIdentity]
+}
+
+// NewUserIdentityRepository constructs a UserIdentityRepository.
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{
+		BaseRepository: repository.NewBaseRepository[models.UserIdentity](db),
+	}
+}
+
+// FindByProviderSubject returns the identity linking provider's account
+// subject to a user.
+func (r *userIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	identity, err := gorm.G[models.UserIdentity](r.GetDB(ctx)).
+		Where(generated.UserIdentity.Provider.Eq(provider)).
+		Where(generated.UserIdentity.Subject.Eq(subject)).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cerrors.NewNotFoundError("identity not found")
+		}
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find %s identity", provider), err)
+	}
+	return &identity, nil
+}
+
+// FindByUserIDAndProvider returns the identity the user has linked at
+// provider.
+func (r *userIdentityRepository) FindByUserIDAndProvider(ctx context.Context, userID uint, provider string) (*models.UserIdentity, error) {
+	identity, err := gorm.G[models.UserIdentity](r.GetDB(ctx)).
+		Where(generated.UserIdentity.UserID.Eq(userID)).
+		Where(generated.UserIdentity.Provider.Eq(provider)).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cerrors.NewNotFoundError("identity not found")
+		}
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find %s identity for user id %v", provider, userID), err)
+	}
+	return &identity, nil
+}
+
+// FindAllByUserID returns every identity linked to the user, oldest first.
+func (r *userIdentityRepository) FindAllByUserID(ctx context.Context, userID uint) ([]models.UserIdentity, error) {
+	identities, err := gorm.G[models.UserIdentity](r.GetDB(ctx)).
+		Where(generated.UserIdentity.UserID.Eq(userID)).
+		Order(generated.UserIdentity.ID.Asc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find identities for user id %v", userID), err)
+	}
+	return identities, nil
+}
+
+// TouchLogin stamps last_login_at on identity id with the current time and
+// records the email the provider reported for it.
+func (r *userIdentityRepository) TouchLogin(ctx context.Context, id uint, email string) error {
+	_, err := gorm.G[models.UserIdentity](r.GetDB(ctx)).
+		Where(generated.UserIdentity.ID.Eq(id)).
+		Set(
+			generated.UserIdentity.LastLoginAt.Set(time.Now()),
+			generated.UserIdentity.Email.Set(email),
+		).
+		Update(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to update last login of identity id %v", id), err)
+	}
+	return nil
+}
+
+// DeleteAllByUserID hard-deletes every identity linked to the user, so none
+// of their provider accounts can log in to it again.
+func (r *userIdentityRepository) DeleteAllByUserID(ctx context.Context, userID uint) error {
+	_, err := gorm.G[models.UserIdentity](r.GetDB(ctx)).
+		Where(generated.UserIdentity.UserID.Eq(userID)).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to delete identities for user id %v", userID), err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	oidcrepository "github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         gormlogger.Default.LogMode(gormlogger.Silent),
+		TranslateError: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.UserIdentity{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func TestUserIdentityRepositoryLookups(t *testing.T) {
+	db := setupDB(t)
+	repo := oidcrepository.NewUserIdentityRepository(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+
+	for _, identity := range []*models.UserIdentity{
+		{UserID: alice.ID, Provider: "google", Subject: "g-1", Email: "alice@example.com"},
+		{UserID: alice.ID, Provider: "corp", Subject: "c-1", Email: "alice@corp.example"},
+		{UserID: bob.ID, Provider: "google", Subject: "g-2", Email: "bob@example.com"},
+	} {
+		require.NoError(t, repo.Create(ctx, identity))
+	}
+
+	found, err := repo.FindByProviderSubject(ctx, "google", "g-1")
+	require.NoError(t, err)
+	require.Equal(t, alice.ID, found.UserID)
+
+	// Subjects are only unique within their provider.
+	_, err = repo.FindByProviderSubject(ctx, "corp", "g-1")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	found, err = repo.FindByUserIDAndProvider(ctx, bob.ID, "google")
+	require.NoError(t, err)
+	require.Equal(t, "g-2", found.Subject)
+	_, err = repo.FindByUserIDAndProvider(ctx, bob.ID, "corp")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	identities, err := repo.FindAllByUserID(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	require.Equal(t, "google", identities[0].Provider)
+	require.Equal(t, "corp", identities[1].Provider)
+}
+
+func TestUserIdentityRepositoryEnforcesOneLinkPerAccount(t *testing.T) {
+	db := setupDB(t)
+	repo := oidcrepository.NewUserIdentityRepository(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+
+	require.NoError(t, repo.Create(ctx, &models.UserIdentity{UserID: alice.ID, Provider: "google", Subject: "g-1", Email: "alice@example.com"}))
+
+	// The same provider account cannot link to a second user...
+	err := repo.Create(ctx, &models.UserIdentity{UserID: bob.ID, Provider: "google", Subject: "g-1", Email: "alice@example.com"})
+	require.ErrorIs(t, err, cerrors.ErrConflict)
+	// ...nor a user link a second account at the same provider.
+	err = repo.Create(ctx, &models.UserIdentity{UserID: alice.ID, Provider: "google", Subject: "g-9", Email: "other@example.com"})
+	require.ErrorIs(t, err, cerrors.ErrConflict)
+}
+
+func TestUserIdentityRepositoryDeleteAllByUserID(t *testing.T) {
+	db := setupDB(t)
+	repo := oidcrepository.NewUserIdentityRepository(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+
+	require.NoError(t, repo.Create(ctx, &models.UserIdentity{UserID: alice.ID, Provider: "google", Subject: "g-1", Email: "alice@example.com"}))
+	require.NoError(t, repo.Create(ctx, &models.UserIdentity{UserID: alice.ID, Provider: "corp", Subject: "c-1", Email: "alice@corp.example"}))
+	require.NoError(t, repo.Create(ctx, &models.UserIdentity{UserID: bob.ID, Provider: "google", Subject: "g-2", Email: "bob@example.com"}))
+
+	require.NoError(t, repo.DeleteAllByUserID(ctx, alice.ID))
+
+	identities, err := repo.FindAllByUserID(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Empty(t, identities)
+	identities, err = repo.FindAllByUserID(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+}
+
+func TestUserIdentityRepositoryTouchLogin(t *testing.T) {
+	db := setupDB(t)
+	repo := oidcrepository.NewUserIdentityRepository(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+
+	identity := &models.UserIdentity{UserID: alice.ID, Provider: "google", Subject: "g-1", Email: "alice@example.com"}
+	require.NoError(t, repo.Create(ctx, identity))
+
+	require.NoError(t, repo.TouchLogin(ctx, identity.ID, "alice@new.example"))
+
+	found, err := repo.FindByProviderSubject(ctx, "google", "g-1")
+	require.NoError(t, err)
+	require.Equal(t, "alice@new.example", found.Email)
+	require.NotNil(t, found.LastLoginAt)
+}
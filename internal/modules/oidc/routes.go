@@ -0,0 +1,27 @@
+// Package oidc wires the OpenID Connect login module.
+package oidc
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/oidc/controller"
+	"github.com/PhantomX7/athleton/internal/routes"
+)
+
+type routeRegistrar struct {
+	controller controller.OIDCController
+}
+
+// NewRoutes constructs the OpenID Connect route registrar.
+func NewRoutes(controller controller.OIDCController) routes.Registrar {
+	return &routeRegistrar{controller: controller}
+}
+
+// RegisterRoutes mounts the OpenID Connect login endpoints under /auth/oidc,
+// rate-limited like the other unauthenticated login entry points, and the
+// linked-identity listing under the caller's own account.
+func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	oidc := ctx.Root.Group("/auth/oidc/:provider")
+	oidc.GET("/start", ctx.MW.AuthRateLimiter(), r.controller.Start)
+	oidc.GET("/callback", ctx.MW.AuthRateLimiter(), r.controller.Callback)
+
+	ctx.Root.GET("/auth/me/identities", ctx.MW.RequireSessionAuth(), r.controller.ListIdentities)
+}
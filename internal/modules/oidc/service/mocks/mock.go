@@ -0,0 +1,190 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/service"
+)
+
+// Ensure, that OIDCServiceMock does implement service.OIDCService.
+// If this is not the case, regenerate this file with moq.
+var _ service.OIDCService = &OIDCServiceMock{}
+
+// OIDCServiceMock is a mock implementation of service.OIDCService.
+//
+//	func TestSomethingThatUsesOIDCService(t *testing.T) {
+//
+//		// make and configure a mocked service.OIDCService
+//		mockedOIDCService := &OIDCServiceMock{
+//			CallbackFunc: func(ctx context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error) {
+//				panic("mock out the Callback method")
+//			},
+//			ListIdentitiesFunc: func(ctx context.Context) ([]dto.UserIdentityResponse, error) {
+//				panic("mock out the ListIdentities method")
+//			},
+//			StartFunc: func(ctx context.Context, provider string) (*dto.OIDCAuthorization, error) {
+//				panic("mock out the Start method")
+//			},
+//		}
+//
+//		// use mockedOIDCService in code that requires service.OIDCService
+//		// and then make assertions.
+//
+//	}
+type OIDCServiceMock struct {
+	// CallbackFunc mocks the Callback method.
+	CallbackFunc func(ctx context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error)
+
+	// ListIdentitiesFunc mocks the ListIdentities method.
+	ListIdentitiesFunc func(ctx context.Context) ([]dto.UserIdentityResponse, error)
+
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, provider string) (*dto.OIDCAuthorization, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Callback holds details about calls to the Callback method.
+		Callback []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Req is the req argument value.
+			Req *dto.OIDCCallbackRequest
+			// Login is the login argument value.
+			Login *dto.OIDCLoginState
+		}
+		// ListIdentities holds details about calls to the ListIdentities method.
+		ListIdentities []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+		}
+	}
+	lockCallback       sync.RWMutex
+	lockListIdentities sync.RWMutex
+	lockStart          sync.RWMutex
+}
+
+// Callback calls CallbackFunc.
+func (mock *OIDCServiceMock) Callback(ctx context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error) {
+	if mock.CallbackFunc == nil {
+		panic("OIDCServiceMock.CallbackFunc: method is nil but OIDCService.Callback was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Req      *dto.OIDCCallbackRequest
+		Login    *dto.OIDCLoginState
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Req:      req,
+		Login:    login,
+	}
+	mock.lockCallback.Lock()
+	mock.calls.Callback = append(mock.calls.Callback, callInfo)
+	mock.lockCallback.Unlock()
+	return mock.CallbackFunc(ctx, provider, req, login)
+}
+
+// CallbackCalls gets all the calls that were made to Callback.
+// Check the length with:
+//
+//	len(mockedOIDCService.CallbackCalls())
+func (mock *OIDCServiceMock) CallbackCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Req      *dto.OIDCCallbackRequest
+	Login    *dto.OIDCLoginState
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Req      *dto.OIDCCallbackRequest
+		Login    *dto.OIDCLoginState
+	}
+	mock.lockCallback.RLock()
+	calls = mock.calls.Callback
+	mock.lockCallback.RUnlock()
+	return calls
+}
+
+// ListIdentities calls ListIdentitiesFunc.
+func (mock *OIDCServiceMock) ListIdentities(ctx context.Context) ([]dto.UserIdentityResponse, error) {
+	if mock.ListIdentitiesFunc == nil {
+		panic("OIDCServiceMock.ListIdentitiesFunc: method is nil but OIDCService.ListIdentities was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListIdentities.Lock()
+	mock.calls.ListIdentities = append(mock.calls.ListIdentities, callInfo)
+	mock.lockListIdentities.Unlock()
+	return mock.ListIdentitiesFunc(ctx)
+}
+
+// ListIdentitiesCalls gets all the calls that were made to ListIdentities.
+// Check the length with:
+//
+//	len(mockedOIDCService.ListIdentitiesCalls())
+func (mock *OIDCServiceMock) ListIdentitiesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListIdentities.RLock()
+	calls = mock.calls.ListIdentities
+	mock.lockListIdentities.RUnlock()
+	return calls
+}
+
+// Start calls StartFunc.
+func (mock *OIDCServiceMock) Start(ctx context.Context, provider string) (*dto.OIDCAuthorization, error) {
+	if mock.StartFunc == nil {
+		panic("OIDCServiceMock.StartFunc: method is nil but OIDCService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	return mock.StartFunc(ctx, provider)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedOIDCService.StartCalls())
+func (mock *OIDCServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Provider string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
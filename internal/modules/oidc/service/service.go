@@ -0,0 +1,326 @@
+// Package service contains the OpenID Connect login business logic.
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	oidcrepo "github.com/PhantomX7/athleton/internal/modules/oidc/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	"github.com/PhantomX7/athleton/libs/oidc"
+	"github.com/PhantomX7/athleton/libs/transaction_manager"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . OIDCService
+
+// OIDCService defines the interface for OpenID Connect login operations
+type OIDCService interface {
+	Start(ctx context.Context, provider string) (*dto.OIDCAuthorization, error)
+	Callback(ctx context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error)
+	ListIdentities(ctx context.Context) ([]dto.UserIdentityResponse, error)
+}
+
+type oidcService struct {
+	cfg               *config.Config
+	client            oidc.Client
+	identityRepo      oidcrepo.UserIdentityRepository
+	userRepo          userrepo.UserRepository
+	logRepository     logRepository.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	authJWT           *authjwt.AuthJWT
+	txManager         transaction_manager.TransactionManager
+}
+
+// NewOIDCService builds the OpenID Connect login service from its dependencies.
+func NewOIDCService(
+	cfg *config.Config,
+	client oidc.Client,
+	identityRepo oidcrepo.UserIdentityRepository,
+	userRepo userrepo.UserRepository,
+	logRepository logRepository.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
+	authJWT *authjwt.AuthJWT,
+	txManager transaction_manager.TransactionManager,
+) OIDCService {
+	return &oidcService{
+		cfg:               cfg,
+		client:            client,
+		identityRepo:      identityRepo,
+		userRepo:          userRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		authJWT:           authJWT,
+		txManager:         txManager,
+	}
+}
+
+// errInvalidLogin answers a callback that does not belong to a login this
+// browser started, or whose login has expired.
+var errInvalidLogin = cerrors.NewBadRequestError("invalid or expired login, please start again")
+
+// Start begins a login at provider: it draws the state, nonce and PKCE
+// verifier the callback will be checked against and builds the provider URL.
+func (s *oidcService) Start(ctx context.Context, provider string) (*dto.OIDCAuthorization, error) {
+	if _, ok := s.client.Provider(provider); !ok {
+		return nil, cerrors.NewNotFoundError("unknown identity provider")
+	}
+
+	login := dto.OIDCLoginState{
+		Provider:  provider,
+		State:     rand.Text(),
+		Nonce:     rand.Text(),
+		Verifier:  oauth2.GenerateVerifier(),
+		ExpiresAt: time.Now().Add(s.cfg.OIDC.StateTTL),
+	}
+	authURL, err := s.client.AuthCodeURL(ctx, provider, login.State, login.Nonce, login.Verifier)
+	if err != nil {
+		logger.Ctx(ctx, zap.String("provider", provider)).Error("Identity provider discovery failed", zap.Error(err))
+		return nil, cerrors.NewAppError(http.StatusBadGateway, "identity provider is unavailable", err)
+	}
+	return &dto.OIDCAuthorization{URL: authURL, State: login}, nil
+}
+
+// Callback finishes a login started by Start. The code is redeemed only when
+// the callback's state matches the login kept by the browser; the returned ID
+// token must carry the login's nonce. The account is the one the provider
+// identity is linked to; an unlinked identity is linked to the account holding
+// its verified email, or, when the provider allows regular users, to a new
+// one. The account's role must be one the provider allows, and, like magic
+// links, 2FA accounts must log in with their password.
+func (s *oidcService) Callback(ctx context.Context, provider string, req *dto.OIDCCallbackRequest, login *dto.OIDCLoginState) (*dto.AuthResponse, error) {
+	p, ok := s.client.Provider(provider)
+	if !ok {
+		return nil, cerrors.NewNotFoundError("unknown identity provider")
+	}
+	if login == nil || login.State == "" || login.Provider != provider || time.Now().After(login.ExpiresAt) ||
+		subtle.ConstantTimeCompare([]byte(login.State), []byte(req.State)) != 1 {
+		return nil, errInvalidLogin
+	}
+	if req.Error != "" {
+		logger.Ctx(ctx, zap.String("provider", provider)).Info("Identity provider refused the login",
+			zap.String("error", req.Error), zap.String("error_description", req.ErrorDescription))
+		return nil, cerrors.NewBadRequestError("the identity provider did not complete the login")
+	}
+	if req.Code == "" {
+		return nil, errInvalidLogin
+	}
+
+	identity, err := s.client.Exchange(ctx, provider, req.Code, login.Verifier, login.Nonce)
+	if err != nil {
+		logger.Ctx(ctx, zap.String("provider", provider)).Warn("Identity provider login could not be verified", zap.Error(err))
+		return nil, cerrors.NewBadRequestError("the identity provider login could not be verified")
+	}
+	if identity.Subject == "" {
+		return nil, cerrors.NewBadRequestError("the identity provider login could not be verified")
+	}
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+
+	var user, refused *models.User
+	var linked bool
+	var authResponse *dto.AuthResponse
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		user, linked, err = s.resolveUser(txCtx, p, identity)
+		if err != nil {
+			return err
+		}
+		if err := s.checkAllowed(p, user); err != nil {
+			refused = user
+			return err
+		}
+
+		authResponse, err = s.authJWT.GenerateTokensForUser(txCtx, user)
+		return err
+	})
+	if err != nil {
+		if refused != nil {
+			logger.Ctx(ctx, zap.Uint("user_id", refused.ID), zap.String("provider", provider)).Warn("Identity provider login refused for account")
+			audit.RecordSecurityEvent(ctx, s.securityEventRepo, refused.ID, models.SecurityEventOIDCLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+		}
+		return nil, err
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID), zap.String("provider", provider), zap.Bool("linked", linked)).Info("Login via identity provider")
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventOIDCLogin, models.SecurityEventOutcomeSuccess, uuid.Nil)
+
+	// Privileged logins are audited like password logins; the request is
+	// unauthenticated, so the entry is attributed to the account itself.
+	if user.Role.IsAdminType() {
+		auditCtx := utils.NewContextWithValues(ctx, utils.ContextValues{
+			UserID:       user.ID,
+			UserName:     user.Name,
+			Role:         user.Role.ToString(),
+			AdminRoleIDs: user.AdminRoleIDs(),
+			RequestID:    utils.GetRequestIDFromContext(ctx),
+		})
+		audit.Record(auditCtx, s.logRepository, audit.Entry{
+			Action:     models.LogActionLogin,
+			EntityType: models.LogEntityTypeUser,
+			EntityID:   user.ID,
+			Message:    fmt.Sprintf("%s logged in via %s", user.Name, provider),
+		})
+	}
+
+	return authResponse, nil
+}
+
+// resolveUser returns the account identity logs in to, linking the identity
+// first when it is new, and reports whether it did. It must run inside a
+// transaction.
+func (s *oidcService) resolveUser(ctx context.Context, p config.OIDCProvider, identity *oidc.Identity) (*models.User, bool, error) {
+	existing, err := s.identityRepo.FindByProviderSubject(ctx, p.Name, identity.Subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(ctx, existing.UserID)
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return nil, false, cerrors.NewForbiddenError("the account linked to this identity is no longer available")
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		email := existing.Email
+		if identity.Email != "" {
+			email = identity.Email
+		}
+		if err := s.identityRepo.TouchLogin(ctx, existing.ID, email); err != nil {
+			return nil, false, err
+		}
+		return user, false, nil
+	}
+	if !errors.Is(err, cerrors.ErrNotFound) {
+		return nil, false, err
+	}
+
+	// An unlinked identity is matched by email, which only means something
+	// when the provider vouches for it.
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, false, cerrors.NewForbiddenError("the identity provider did not report a verified email")
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		if err := s.checkLinkable(ctx, p, user); err != nil {
+			return nil, false, err
+		}
+	case errors.Is(err, cerrors.ErrNotFound):
+		if !slices.Contains(p.AllowedRoles, models.UserRoleUser.ToString()) {
+			return nil, false, cerrors.NewForbiddenError("no account uses this email")
+		}
+		user, err = s.provision(ctx, identity)
+		if err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, err
+	}
+
+	now := time.Now()
+	err = s.identityRepo.Create(ctx, &models.UserIdentity{
+		UserID:      user.ID,
+		Provider:    p.Name,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// checkLinkable refuses to link a provider identity to an existing account
+// whose address was never verified: anyone could have registered it, and
+// linking would hand the registrant's password a session on the real owner's
+// login. It also keeps an account to one identity per provider.
+func (s *oidcService) checkLinkable(ctx context.Context, p config.OIDCProvider, user *models.User) error {
+	if !user.IsEmailVerified() {
+		return cerrors.NewForbiddenError("verify the email address of your account before logging in with " + p.Name)
+	}
+	_, err := s.identityRepo.FindByUserIDAndProvider(ctx, user.ID, p.Name)
+	if err == nil {
+		return cerrors.NewForbiddenError("another " + p.Name + " account is already linked to this account")
+	}
+	if !errors.Is(err, cerrors.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// provision creates a regular user account for identity. It has no
+// password: the user logs in through the provider, or sets one with the
+// forgot-password flow.
+func (s *oidcService) provision(ctx context.Context, identity *oidc.Identity) (*models.User, error) {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = identity.Email
+	}
+	now := time.Now()
+	user := &models.User{
+		Username:        identity.Email,
+		Name:            name,
+		Email:           identity.Email,
+		Role:            models.UserRoleUser,
+		IsActive:        true,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("User registered via identity provider")
+	return user, nil
+}
+
+// checkAllowed reports whether user may log in through p.
+func (s *oidcService) checkAllowed(p config.OIDCProvider, user *models.User) error {
+	switch {
+	case !user.IsActive || user.IsLocked(time.Now()):
+		return cerrors.NewForbiddenError("account is inactive")
+	case !slices.Contains(p.AllowedRoles, user.Role.ToString()):
+		return cerrors.NewForbiddenError("this account cannot log in with " + p.Name)
+	case user.IsDirectoryAccount():
+		return cerrors.NewForbiddenError("this account logs in with its directory credentials")
+	case user.IsTwoFactorEnabled():
+		return cerrors.NewForbiddenError("accounts with two-factor authentication must log in with their password")
+	}
+	return nil
+}
+
+// ListIdentities returns the provider identities linked to the authenticated
+// user.
+func (s *oidcService) ListIdentities(ctx context.Context) ([]dto.UserIdentityResponse, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := s.identityRepo.FindAllByUserID(ctx, values.UserID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		responses = append(responses, identity.ToResponse())
+	}
+	return responses, nil
+}
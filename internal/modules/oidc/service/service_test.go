@@ -0,0 +1,381 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	apikeymocks "github.com/PhantomX7/athleton/internal/modules/api_key/repository/mocks"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	oidcmocks "github.com/PhantomX7/athleton/internal/modules/oidc/repository/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/oidc/service"
+	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	usertokenmocks "github.com/PhantomX7/athleton/internal/modules/user_token/repository/mocks"
+	"github.com/PhantomX7/athleton/libs/oidc"
+	oidcclientmocks "github.com/PhantomX7/athleton/libs/oidc/mocks"
+	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func setupLogger(t *testing.T) {
+	t.Helper()
+
+	prev := logger.Log
+	logger.Log = zap.NewNop()
+	t.Cleanup(func() {
+		logger.Log = prev
+	})
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		JWT: config.JWTConfig{
+			Secret:            "test-secret-of-at-least-32-characters",
+			Expiration:        10 * time.Minute,
+			RefreshExpiration: 72 * time.Hour,
+			Issuer:            "athleton-test",
+		},
+		App:  config.AppConfig{Name: "Athleton Test", Environment: "development"},
+		OIDC: config.OIDCConfig{StateTTL: 10 * time.Minute},
+	}
+}
+
+func passthroughTx() *txmocks.TransactionManagerMock {
+	return &txmocks.TransactionManagerMock{
+		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
+func stubSecurityEventRepo() *securityeventmocks.SecurityEventRepositoryMock {
+	return &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(context.Context, *models.SecurityEvent) error { return nil },
+	}
+}
+
+// fixture is a service wired to mocks, with the provider "corp" allowing
+// allowedRoles and answering every code with identity.
+type fixture struct {
+	svc          service.OIDCService
+	client       *oidcclientmocks.ClientMock
+	identityRepo *oidcmocks.UserIdentityRepositoryMock
+	userRepo     *usermocks.UserRepositoryMock
+	events       *securityeventmocks.SecurityEventRepositoryMock
+}
+
+func newFixture(t *testing.T, identity *oidc.Identity, allowedRoles ...string) *fixture {
+	t.Helper()
+	setupLogger(t)
+
+	cfg := testConfig()
+	if len(allowedRoles) == 0 {
+		allowedRoles = []string{"user"}
+	}
+	client := &oidcclientmocks.ClientMock{
+		ProviderFunc: func(name string) (config.OIDCProvider, bool) {
+			if name != "corp" {
+				return config.OIDCProvider{}, false
+			}
+			return config.OIDCProvider{Name: "corp", AllowedRoles: allowedRoles}, true
+		},
+		AuthCodeURLFunc: func(_ context.Context, _, state, _, _ string) (string, error) {
+			return "https://sso.example.com/authorize?state=" + state, nil
+		},
+		ExchangeFunc: func(_ context.Context, _, code, verifier, nonce string) (*oidc.Identity, error) {
+			require.Equal(t, "the-code", code)
+			require.Equal(t, "the-verifier", verifier)
+			require.Equal(t, "the-nonce", nonce)
+			copied := *identity
+			return &copied, nil
+		},
+	}
+	f := &fixture{
+		client: client,
+		identityRepo: &oidcmocks.UserIdentityRepositoryMock{
+			FindByProviderSubjectFunc: func(context.Context, string, string) (*models.UserIdentity, error) {
+				return nil, cerrors.NewNotFoundError("identity not found")
+			},
+			FindByUserIDAndProviderFunc: func(context.Context, uint, string) (*models.UserIdentity, error) {
+				return nil, cerrors.NewNotFoundError("identity not found")
+			},
+			CreateFunc:     func(context.Context, *models.UserIdentity) error { return nil },
+			TouchLoginFunc: func(context.Context, uint, string) error { return nil },
+		},
+		userRepo: &usermocks.UserRepositoryMock{
+			FindByEmailFunc: func(context.Context, string) (*models.User, error) {
+				return nil, cerrors.NewNotFoundError("user not found")
+			},
+			CreateFunc: func(_ context.Context, u *models.User) error {
+				u.ID = 99
+				return nil
+			},
+		},
+		events: stubSecurityEventRepo(),
+	}
+
+	refreshRepo := &refreshtokenmocks.RefreshTokenRepositoryMock{
+		CreateFunc: func(context.Context, *models.RefreshToken) error { return nil },
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(context.Context, *models.Log) error { return nil },
+	}
+	authJWT, err := authjwt.NewAuthJWT(cfg, f.userRepo, refreshRepo, &usertokenmocks.UserTokenRepositoryMock{}, &apikeymocks.APIKeyRepositoryMock{}, logRepo, f.events, passthroughTx(), nil, nil)
+	require.NoError(t, err)
+
+	f.svc = service.NewOIDCService(cfg, client, f.identityRepo, f.userRepo, logRepo, f.events, authJWT, passthroughTx())
+	return f
+}
+
+func validLogin() *dto.OIDCLoginState {
+	return &dto.OIDCLoginState{
+		Provider:  "corp",
+		State:     "the-state",
+		Nonce:     "the-nonce",
+		Verifier:  "the-verifier",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+}
+
+func callbackRequest() *dto.OIDCCallbackRequest {
+	return &dto.OIDCCallbackRequest{Code: "the-code", State: "the-state"}
+}
+
+var carol = &oidc.Identity{Subject: "carol-sub", Email: "Carol@Example.com", EmailVerified: true, Name: "Carol"}
+
+func requireAppError(t *testing.T, err error, code int) {
+	t.Helper()
+
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, code, appErr.Code, appErr.Message)
+}
+
+func TestOIDCServiceStartDrawsAFreshLogin(t *testing.T) {
+	f := newFixture(t, carol)
+
+	first, err := f.svc.Start(context.Background(), "corp")
+	require.NoError(t, err)
+	second, err := f.svc.Start(context.Background(), "corp")
+	require.NoError(t, err)
+
+	require.Equal(t, "corp", first.State.Provider)
+	require.NotEmpty(t, first.State.Nonce)
+	require.GreaterOrEqual(t, len(first.State.Verifier), 43, "RFC 7636 minimum verifier length")
+	require.WithinDuration(t, time.Now().Add(10*time.Minute), first.State.ExpiresAt, 5*time.Second)
+	require.Equal(t, "https://sso.example.com/authorize?state="+first.State.State, first.URL)
+	require.NotEqual(t, first.State.State, second.State.State)
+	require.NotEqual(t, first.State.Verifier, second.State.Verifier)
+
+	_, err = f.svc.Start(context.Background(), "google")
+	requireAppError(t, err, http.StatusNotFound)
+}
+
+func TestOIDCServiceCallbackRejectsForeignOrStaleLogins(t *testing.T) {
+	for name, mutate := range map[string]func(*dto.OIDCLoginState, *dto.OIDCCallbackRequest) *dto.OIDCLoginState{
+		"no login cookie": func(*dto.OIDCLoginState, *dto.OIDCCallbackRequest) *dto.OIDCLoginState { return nil },
+		"state mismatch": func(l *dto.OIDCLoginState, r *dto.OIDCCallbackRequest) *dto.OIDCLoginState {
+			r.State = "forged"
+			return l
+		},
+		"expired login": func(l *dto.OIDCLoginState, _ *dto.OIDCCallbackRequest) *dto.OIDCLoginState {
+			l.ExpiresAt = time.Now().Add(-time.Second)
+			return l
+		},
+		"other provider": func(l *dto.OIDCLoginState, _ *dto.OIDCCallbackRequest) *dto.OIDCLoginState {
+			l.Provider = "google"
+			return l
+		},
+		"provider error": func(l *dto.OIDCLoginState, r *dto.OIDCCallbackRequest) *dto.OIDCLoginState {
+			r.Code, r.Error = "", "access_denied"
+			return l
+		},
+		"missing code": func(l *dto.OIDCLoginState, r *dto.OIDCCallbackRequest) *dto.OIDCLoginState { r.Code = ""; return l },
+		"empty state match": func(l *dto.OIDCLoginState, r *dto.OIDCCallbackRequest) *dto.OIDCLoginState {
+			l.State, r.State = "", ""
+			return l
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t, carol)
+			req := callbackRequest()
+			login := mutate(validLogin(), req)
+
+			res, err := f.svc.Callback(context.Background(), "corp", req, login)
+			requireAppError(t, err, http.StatusBadRequest)
+			require.Nil(t, res)
+			require.Empty(t, f.client.ExchangeCalls(), "the code is never redeemed")
+		})
+	}
+}
+
+func TestOIDCServiceCallbackLogsInLinkedIdentity(t *testing.T) {
+	f := newFixture(t, carol)
+	f.identityRepo.FindByProviderSubjectFunc = func(_ context.Context, provider, subject string) (*models.UserIdentity, error) {
+		require.Equal(t, "corp", provider)
+		require.Equal(t, "carol-sub", subject)
+		return &models.UserIdentity{ID: 5, UserID: 12, Provider: provider, Subject: subject, Email: "old@example.com"}, nil
+	}
+	f.userRepo.FindByIDFunc = func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+		require.Equal(t, uint(12), id)
+		return &models.User{ID: 12, Name: "Carol", Role: models.UserRoleUser, IsActive: true}, nil
+	}
+
+	res, err := f.svc.Callback(context.Background(), "corp", callbackRequest(), validLogin())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.AccessToken)
+	require.NotEmpty(t, res.RefreshToken)
+
+	touched := f.identityRepo.TouchLoginCalls()
+	require.Len(t, touched, 1)
+	require.Equal(t, uint(5), touched[0].ID)
+	require.Equal(t, "carol@example.com", touched[0].Email)
+	require.Empty(t, f.identityRepo.CreateCalls())
+	require.Empty(t, f.userRepo.CreateCalls())
+}
+
+func TestOIDCServiceCallbackLinksAccountByVerifiedEmail(t *testing.T) {
+	f := newFixture(t, carol)
+	verified := time.Now()
+	f.userRepo.FindByEmailFunc = func(_ context.Context, email string) (*models.User, error) {
+		require.Equal(t, "carol@example.com", email)
+		return &models.User{ID: 12, Name: "Carol", Role: models.UserRoleUser, IsActive: true, EmailVerifiedAt: &verified}, nil
+	}
+
+	res, err := f.svc.Callback(context.Background(), "corp", callbackRequest(), validLogin())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.AccessToken)
+
+	created := f.identityRepo.CreateCalls()
+	require.Len(t, created, 1)
+	require.Equal(t, uint(12), created[0].Entity.UserID)
+	require.Equal(t, "corp", created[0].Entity.Provider)
+	require.Equal(t, "carol-sub", created[0].Entity.Subject)
+	require.Empty(t, f.userRepo.CreateCalls())
+}
+
+func TestOIDCServiceCallbackProvisionsNewUser(t *testing.T) {
+	f := newFixture(t, carol)
+
+	res, err := f.svc.Callback(context.Background(), "corp", callbackRequest(), validLogin())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.AccessToken)
+
+	users := f.userRepo.CreateCalls()
+	require.Len(t, users, 1)
+	user := users[0].Entity
+	require.Equal(t, "carol@example.com", user.Username)
+	require.Equal(t, "carol@example.com", user.Email)
+	require.Equal(t, "Carol", user.Name)
+	require.Equal(t, models.UserRoleUser, user.Role)
+	require.True(t, user.IsActive)
+	require.True(t, user.IsEmailVerified())
+	require.Empty(t, user.Password)
+
+	identities := f.identityRepo.CreateCalls()
+	require.Len(t, identities, 1)
+	require.Equal(t, uint(99), identities[0].Entity.UserID)
+}
+
+func TestOIDCServiceCallbackRefusals(t *testing.T) {
+	verified := time.Now()
+	cases := map[string]struct {
+		identity     *oidc.Identity
+		allowedRoles []string
+		emailUser    *models.User
+		linkedAlso   bool
+		status       int
+	}{
+		"unverified provider email": {
+			identity: &oidc.Identity{Subject: "carol-sub", Email: "carol@example.com"},
+			status:   http.StatusForbidden,
+		},
+		"no provider email": {
+			identity: &oidc.Identity{Subject: "carol-sub", EmailVerified: true},
+			status:   http.StatusForbidden,
+		},
+		"provider does not provision users": {
+			identity:     carol,
+			allowedRoles: []string{"admin"},
+			status:       http.StatusForbidden,
+		},
+		"account address never verified": {
+			identity:  carol,
+			emailUser: &models.User{ID: 12, Role: models.UserRoleUser, IsActive: true},
+			status:    http.StatusForbidden,
+		},
+		"account already linked at the provider": {
+			identity:   carol,
+			emailUser:  &models.User{ID: 12, Role: models.UserRoleUser, IsActive: true, EmailVerifiedAt: &verified},
+			linkedAlso: true,
+			status:     http.StatusForbidden,
+		},
+		"role not allowed": {
+			identity:  carol,
+			emailUser: &models.User{ID: 12, Role: models.UserRoleAdmin, IsActive: true, EmailVerifiedAt: &verified},
+			status:    http.StatusForbidden,
+		},
+		"inactive account": {
+			identity:  carol,
+			emailUser: &models.User{ID: 12, Role: models.UserRoleUser, IsActive: false, EmailVerifiedAt: &verified},
+			status:    http.StatusForbidden,
+		},
+		"two-factor account": {
+			identity:  carol,
+			emailUser: &models.User{ID: 12, Role: models.UserRoleUser, IsActive: true, EmailVerifiedAt: &verified, TwoFactorEnabledAt: &verified},
+			status:    http.StatusForbidden,
+		},
+		"directory account": {
+			identity:     carol,
+			allowedRoles: []string{"user", "admin"},
+			emailUser:    &models.User{ID: 12, Role: models.UserRoleAdmin, IsActive: true, EmailVerifiedAt: &verified, AuthProvider: models.UserAuthProviderLDAP},
+			status:       http.StatusForbidden,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t, tc.identity, tc.allowedRoles...)
+			if tc.emailUser != nil {
+				f.userRepo.FindByEmailFunc = func(context.Context, string) (*models.User, error) {
+					return tc.emailUser, nil
+				}
+			}
+			if tc.linkedAlso {
+				f.identityRepo.FindByUserIDAndProviderFunc = func(context.Context, uint, string) (*models.UserIdentity, error) {
+					return &models.UserIdentity{ID: 3, UserID: 12, Provider: "corp", Subject: "someone-else"}, nil
+				}
+			}
+
+			res, err := f.svc.Callback(context.Background(), "corp", callbackRequest(), validLogin())
+			requireAppError(t, err, tc.status)
+			require.Nil(t, res)
+			require.Empty(t, f.userRepo.CreateCalls())
+		})
+	}
+}
+
+func TestOIDCServiceListIdentities(t *testing.T) {
+	f := newFixture(t, carol)
+	f.identityRepo.FindAllByUserIDFunc = func(_ context.Context, userID uint) ([]models.UserIdentity, error) {
+		require.Equal(t, uint(12), userID)
+		return []models.UserIdentity{{ID: 5, UserID: 12, Provider: "corp", Subject: "carol-sub", Email: "carol@example.com"}}, nil
+	}
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 12})
+
+	res, err := f.svc.ListIdentities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []dto.UserIdentityResponse{{ID: 5, Provider: "corp", Email: "carol@example.com"}}, res)
+}
@@ -0,0 +1,18 @@
+// Package password_policy wires the password policy (composition rules,
+// breached-password list and reuse history) into the application container.
+package password_policy
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/password_policy/repository"
+	"github.com/PhantomX7/athleton/internal/modules/password_policy/service"
+
+	"go.uber.org/fx"
+)
+
+// Module registers the password-policy module dependencies.
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewPasswordHistoryRepository,
+		service.NewPasswordPolicy,
+	),
+)
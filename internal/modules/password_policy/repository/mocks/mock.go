@@ -0,0 +1,454 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	passwordpolicyrepository "github.com/PhantomX7/athleton/internal/modules/password_policy/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that PasswordHistoryRepositoryMock does implement passwordpolicyrepository.PasswordHistoryRepository.
+// If this is not the case, regenerate this file with moq.
+var _ passwordpolicyrepository.PasswordHistoryRepository = &PasswordHistoryRepositoryMock{}
+
+// PasswordHistoryRepositoryMock is a mock implementation of passwordpolicyrepository.PasswordHistoryRepository.
+//
+//	func TestSomethingThatUsesPasswordHistoryRepository(t *testing.T) {
+//
+//		// make and configure a mocked passwordpolicyrepository.PasswordHistoryRepository
+//		mockedPasswordHistoryRepository := &PasswordHistoryRepositoryMock{
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.PasswordHistory) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.PasswordHistory) error {
+//				panic("mock out the Delete method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.PasswordHistory, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.PasswordHistory, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			FindRecentByUserIDFunc: func(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+//				panic("mock out the FindRecentByUserID method")
+//			},
+//			PruneByUserIDFunc: func(ctx context.Context, userID uint, keep int) error {
+//				panic("mock out the PruneByUserID method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.PasswordHistory) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedPasswordHistoryRepository in code that requires passwordpolicyrepository.PasswordHistoryRepository
+//		// and then make assertions.
+//
+//	}
+type PasswordHistoryRepositoryMock struct {
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.PasswordHistory) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.PasswordHistory) error
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.PasswordHistory, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.PasswordHistory, error)
+
+	// FindRecentByUserIDFunc mocks the FindRecentByUserID method.
+	FindRecentByUserIDFunc func(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error)
+
+	// PruneByUserIDFunc mocks the PruneByUserID method.
+	PruneByUserIDFunc func(ctx context.Context, userID uint, keep int) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.PasswordHistory) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.PasswordHistory
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.PasswordHistory
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// FindRecentByUserID holds details about calls to the FindRecentByUserID method.
+		FindRecentByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// PruneByUserID holds details about calls to the PruneByUserID method.
+		PruneByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Keep is the keep argument value.
+			Keep int
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.PasswordHistory
+		}
+	}
+	lockCount              sync.RWMutex
+	lockCreate             sync.RWMutex
+	lockDelete             sync.RWMutex
+	lockFindAll            sync.RWMutex
+	lockFindByID           sync.RWMutex
+	lockFindRecentByUserID sync.RWMutex
+	lockPruneByUserID      sync.RWMutex
+	lockUpdate             sync.RWMutex
+}
+
+// Count calls CountFunc.
+func (mock *PasswordHistoryRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("PasswordHistoryRepositoryMock.CountFunc: method is nil but PasswordHistoryRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.CountCalls())
+func (mock *PasswordHistoryRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *PasswordHistoryRepositoryMock) Create(ctx context.Context, entity *models.PasswordHistory) error {
+	if mock.CreateFunc == nil {
+		panic("PasswordHistoryRepositoryMock.CreateFunc: method is nil but PasswordHistoryRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.CreateCalls())
+func (mock *PasswordHistoryRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.PasswordHistory
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *PasswordHistoryRepositoryMock) Delete(ctx context.Context, entity *models.PasswordHistory) error {
+	if mock.DeleteFunc == nil {
+		panic("PasswordHistoryRepositoryMock.DeleteFunc: method is nil but PasswordHistoryRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.DeleteCalls())
+func (mock *PasswordHistoryRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.PasswordHistory
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *PasswordHistoryRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.PasswordHistory, error) {
+	if mock.FindAllFunc == nil {
+		panic("PasswordHistoryRepositoryMock.FindAllFunc: method is nil but PasswordHistoryRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.FindAllCalls())
+func (mock *PasswordHistoryRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *PasswordHistoryRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.PasswordHistory, error) {
+	if mock.FindByIDFunc == nil {
+		panic("PasswordHistoryRepositoryMock.FindByIDFunc: method is nil but PasswordHistoryRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.FindByIDCalls())
+func (mock *PasswordHistoryRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// FindRecentByUserID calls FindRecentByUserIDFunc.
+func (mock *PasswordHistoryRepositoryMock) FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+	if mock.FindRecentByUserIDFunc == nil {
+		panic("PasswordHistoryRepositoryMock.FindRecentByUserIDFunc: method is nil but PasswordHistoryRepository.FindRecentByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Limit:  limit,
+	}
+	mock.lockFindRecentByUserID.Lock()
+	mock.calls.FindRecentByUserID = append(mock.calls.FindRecentByUserID, callInfo)
+	mock.lockFindRecentByUserID.Unlock()
+	return mock.FindRecentByUserIDFunc(ctx, userID, limit)
+}
+
+// FindRecentByUserIDCalls gets all the calls that were made to FindRecentByUserID.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.FindRecentByUserIDCalls())
+func (mock *PasswordHistoryRepositoryMock) FindRecentByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+		Limit  int
+	}
+	mock.lockFindRecentByUserID.RLock()
+	calls = mock.calls.FindRecentByUserID
+	mock.lockFindRecentByUserID.RUnlock()
+	return calls
+}
+
+// PruneByUserID calls PruneByUserIDFunc.
+func (mock *PasswordHistoryRepositoryMock) PruneByUserID(ctx context.Context, userID uint, keep int) error {
+	if mock.PruneByUserIDFunc == nil {
+		panic("PasswordHistoryRepositoryMock.PruneByUserIDFunc: method is nil but PasswordHistoryRepository.PruneByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+		Keep   int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Keep:   keep,
+	}
+	mock.lockPruneByUserID.Lock()
+	mock.calls.PruneByUserID = append(mock.calls.PruneByUserID, callInfo)
+	mock.lockPruneByUserID.Unlock()
+	return mock.PruneByUserIDFunc(ctx, userID, keep)
+}
+
+// PruneByUserIDCalls gets all the calls that were made to PruneByUserID.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.PruneByUserIDCalls())
+func (mock *PasswordHistoryRepositoryMock) PruneByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+	Keep   int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+		Keep   int
+	}
+	mock.lockPruneByUserID.RLock()
+	calls = mock.calls.PruneByUserID
+	mock.lockPruneByUserID.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *PasswordHistoryRepositoryMock) Update(ctx context.Context, entity *models.PasswordHistory) error {
+	if mock.UpdateFunc == nil {
+		panic("PasswordHistoryRepositoryMock.UpdateFunc: method is nil but PasswordHistoryRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedPasswordHistoryRepository.UpdateCalls())
+func (mock *PasswordHistoryRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.PasswordHistory
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.PasswordHistory
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
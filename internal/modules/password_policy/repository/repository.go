@@ -0,0 +1,77 @@
+// Package repository provides password-history persistence primitives.
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . PasswordHistoryRepository
+
+// PasswordHistoryRepository defines the interface for password-history operations.
+type PasswordHistoryRepository interface {
+	repository.Repository[models.PasswordHistory]
+	FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error)
+	PruneByUserID(ctx context.Context, userID uint, keep int) error
+}
+
+type passwordHistoryRepository struct {
+	repository.BaseRepository[models.PasswordHistory]
+}
+
+// NewPasswordHistoryRepository constructs a PasswordHistoryRepository.
+func NewPasswordHistoryRepository(db *gorm.DB) PasswordHistoryRepository {
+	return &passwordHistoryRepository{
+		BaseRepository: repository.NewBaseRepository[models.PasswordHistory](db),
+	}
+}
+
+// FindRecentByUserID returns up to limit of the user's retired password
+// hashes, most recently retired first.
+func (r *passwordHistoryRepository) FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := gorm.G[models.PasswordHistory](r.GetDB(ctx)).
+		Where(generated.PasswordHistory.UserID.Eq(userID)).
+		Order(generated.PasswordHistory.ID.Desc()).
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find password history for user id %v", userID), err)
+	}
+	return rows, nil
+}
+
+// PruneByUserID deletes all but the user's keep most recent history rows, so
+// the table never grows past what the reuse check reads. Like
+// RevokeOldestActiveByUserID it looks the boundary up first instead of
+// relying on DELETE ... ORDER BY ... OFFSET, which is not portable.
+func (r *passwordHistoryRepository) PruneByUserID(ctx context.Context, userID uint, keep int) error {
+	q := gorm.G[models.PasswordHistory](r.GetDB(ctx)).
+		Where(generated.PasswordHistory.UserID.Eq(userID))
+
+	if keep > 0 {
+		kept, err := r.FindRecentByUserID(ctx, userID, keep)
+		if err != nil {
+			return err
+		}
+		if len(kept) < keep {
+			return nil
+		}
+		q = q.Where(generated.PasswordHistory.ID.Lt(kept[len(kept)-1].ID))
+	}
+
+	if _, err := q.Delete(ctx); err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to prune password history for user id %v", userID), err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	historyrepository "github.com/PhantomX7/athleton/internal/modules/password_policy/repository"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.PasswordHistory{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func seedHistory(t *testing.T, repo historyrepository.PasswordHistoryRepository, userID uint, n int) {
+	t.Helper()
+
+	for i := 1; i <= n; i++ {
+		require.NoError(t, repo.Create(context.Background(), &models.PasswordHistory{
+			UserID:       userID,
+			PasswordHash: fmt.Sprintf("hash-%d", i),
+		}))
+	}
+}
+
+func TestPasswordHistoryRepositoryFindRecentByUserIDNewestFirst(t *testing.T) {
+	db := setupDB(t)
+	repo := historyrepository.NewPasswordHistoryRepository(db)
+	user := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bima")
+	seedHistory(t, repo, user.ID, 3)
+	seedHistory(t, repo, other.ID, 2)
+
+	rows, err := repo.FindRecentByUserID(context.Background(), user.ID, 2)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "hash-3", rows[0].PasswordHash)
+	require.Equal(t, "hash-2", rows[1].PasswordHash)
+
+	rows, err = repo.FindRecentByUserID(context.Background(), user.ID, 0)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
+
+func TestPasswordHistoryRepositoryPruneByUserIDKeepsNewest(t *testing.T) {
+	db := setupDB(t)
+	repo := historyrepository.NewPasswordHistoryRepository(db)
+	user := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bima")
+	seedHistory(t, repo, user.ID, 4)
+	seedHistory(t, repo, other.ID, 2)
+
+	require.NoError(t, repo.PruneByUserID(context.Background(), user.ID, 2))
+
+	rows, err := repo.FindRecentByUserID(context.Background(), user.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "hash-4", rows[0].PasswordHash)
+	require.Equal(t, "hash-3", rows[1].PasswordHash)
+
+	// Other accounts are untouched.
+	rows, err = repo.FindRecentByUserID(context.Background(), other.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.NoError(t, repo.PruneByUserID(context.Background(), user.ID, 0))
+	rows, err = repo.FindRecentByUserID(context.Background(), user.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
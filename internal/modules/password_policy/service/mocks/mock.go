@@ -0,0 +1,140 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/password_policy/service"
+)
+
+// Ensure, that PasswordPolicyMock does implement service.PasswordPolicy.
+// If this is not the case, regenerate this file with moq.
+var _ service.PasswordPolicy = &PasswordPolicyMock{}
+
+// PasswordPolicyMock is a mock implementation of service.PasswordPolicy.
+//
+//	func TestSomethingThatUsesPasswordPolicy(t *testing.T) {
+//
+//		// make and configure a mocked service.PasswordPolicy
+//		mockedPasswordPolicy := &PasswordPolicyMock{
+//			HashFunc: func(ctx context.Context, user *models.User, candidate string) (string, error) {
+//				panic("mock out the Hash method")
+//			},
+//			RetireFunc: func(ctx context.Context, user *models.User) error {
+//				panic("mock out the Retire method")
+//			},
+//		}
+//
+//		// use mockedPasswordPolicy in code that requires service.PasswordPolicy
+//		// and then make assertions.
+//
+//	}
+type PasswordPolicyMock struct {
+	// HashFunc mocks the Hash method.
+	HashFunc func(ctx context.Context, user *models.User, candidate string) (string, error)
+
+	// RetireFunc mocks the Retire method.
+	RetireFunc func(ctx context.Context, user *models.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Hash holds details about calls to the Hash method.
+		Hash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *models.User
+			// Candidate is the candidate argument value.
+			Candidate string
+		}
+		// Retire holds details about calls to the Retire method.
+		Retire []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *models.User
+		}
+	}
+	lockHash   sync.RWMutex
+	lockRetire sync.RWMutex
+}
+
+// Hash calls HashFunc.
+func (mock *PasswordPolicyMock) Hash(ctx context.Context, user *models.User, candidate string) (string, error) {
+	if mock.HashFunc == nil {
+		panic("PasswordPolicyMock.HashFunc: method is nil but PasswordPolicy.Hash was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		User      *models.User
+		Candidate string
+	}{
+		Ctx:       ctx,
+		User:      user,
+		Candidate: candidate,
+	}
+	mock.lockHash.Lock()
+	mock.calls.Hash = append(mock.calls.Hash, callInfo)
+	mock.lockHash.Unlock()
+	return mock.HashFunc(ctx, user, candidate)
+}
+
+// HashCalls gets all the calls that were made to Hash.
+// Check the length with:
+//
+//	len(mockedPasswordPolicy.HashCalls())
+func (mock *PasswordPolicyMock) HashCalls() []struct {
+	Ctx       context.Context
+	User      *models.User
+	Candidate string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		User      *models.User
+		Candidate string
+	}
+	mock.lockHash.RLock()
+	calls = mock.calls.Hash
+	mock.lockHash.RUnlock()
+	return calls
+}
+
+// Retire calls RetireFunc.
+func (mock *PasswordPolicyMock) Retire(ctx context.Context, user *models.User) error {
+	if mock.RetireFunc == nil {
+		panic("PasswordPolicyMock.RetireFunc: method is nil but PasswordPolicy.Retire was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		User *models.User
+	}{
+		Ctx:  ctx,
+		User: user,
+	}
+	mock.lockRetire.Lock()
+	mock.calls.Retire = append(mock.calls.Retire, callInfo)
+	mock.lockRetire.Unlock()
+	return mock.RetireFunc(ctx, user)
+}
+
+// RetireCalls gets all the calls that were made to Retire.
+// Check the length with:
+//
+//	len(mockedPasswordPolicy.RetireCalls())
+func (mock *PasswordPolicyMock) RetireCalls() []struct {
+	Ctx  context.Context
+	User *models.User
+} {
+	var calls []struct {
+		Ctx  context.Context
+		User *models.User
+	}
+	mock.lockRetire.RLock()
+	calls = mock.calls.Retire
+	mock.lockRetire.RUnlock()
+	return calls
+}
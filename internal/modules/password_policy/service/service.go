@@ -0,0 +1,213 @@
+// Package service contains the password policy every flow that sets a
+// password goes through.
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1" // #nosec G505 -- breached-password lists are keyed by SHA-1; nothing is stored with it
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/password_policy/repository"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/constants/security"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/password"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . PasswordPolicy
+
+// PasswordPolicy decides which passwords an account may choose and keeps the
+// history that backs the reuse rule.
+type PasswordPolicy interface {
+	// Hash checks candidate against the policy and returns its Argon2id hash.
+	// user is the account the password is for, or nil for one that does not
+	// exist yet; only an existing account has a history to check.
+	Hash(ctx context.Context, user *models.User, candidate string) (string, error)
+	// Retire moves user's current password hash into the history. Call it in
+	// the transaction that overwrites user.Password.
+	Retire(ctx context.Context, user *models.User) error
+}
+
+type passwordPolicy struct {
+	cfg         config.AuthConfig
+	historyRepo repository.PasswordHistoryRepository
+	// breached holds the SHA-1 digests of AUTH_PASSWORD_BREACHED_LIST_FILE,
+	// sorted for binary search.
+	breached [][sha1.Size]byte
+}
+
+// NewPasswordPolicy builds the policy from config, loading the breached
+// password list up front so a bad path fails startup rather than the first
+// password change.
+func NewPasswordPolicy(cfg *config.Config, historyRepo repository.PasswordHistoryRepository) (PasswordPolicy, error) {
+	p := &passwordPolicy{cfg: cfg.Auth, historyRepo: historyRepo}
+	if cfg.Auth.PasswordBreachedListFile != "" {
+		breached, err := loadBreachedList(cfg.Auth.PasswordBreachedListFile)
+		if err != nil {
+			return nil, err
+		}
+		p.breached = breached
+	}
+	return p, nil
+}
+
+// Hash implements PasswordPolicy. The cheap rules run first; the reuse check
+// costs a hash comparison per remembered password, so it runs last.
+func (p *passwordPolicy) Hash(ctx context.Context, user *models.User, candidate string) (string, error) {
+	if n := utf8.RuneCountInString(candidate); n < p.cfg.PasswordMinLength {
+		return "", cerrors.NewBadRequestError(fmt.Sprintf("password must be at least %d characters long", p.cfg.PasswordMinLength))
+	}
+	if len(candidate) > security.MaxPasswordBytes {
+		return "", cerrors.NewBadRequestError(fmt.Sprintf("password must be at most %d bytes long", security.MaxPasswordBytes))
+	}
+	if characterClasses(candidate) < p.cfg.PasswordMinCharacterClasses {
+		return "", cerrors.NewBadRequestError(fmt.Sprintf(
+			"password must mix at least %d of lowercase letters, uppercase letters, digits and symbols",
+			p.cfg.PasswordMinCharacterClasses))
+	}
+	if security.IsCommonPassword(candidate) || p.isBreached(candidate) {
+		return "", cerrors.NewBadRequestError("password is too common or has appeared in a data breach")
+	}
+	if user != nil {
+		reused, err := p.isRecentlyUsed(ctx, user, candidate)
+		if err != nil {
+			return "", err
+		}
+		if reused {
+			return "", cerrors.NewBadRequestError("password was used recently; choose a different one")
+		}
+	}
+
+	hashed, err := password.Hash(candidate, p.cfg.PasswordHashParams())
+	if err != nil {
+		return "", cerrors.NewInternalServerError("failed to process password", err)
+	}
+	return hashed, nil
+}
+
+// Retire implements PasswordPolicy. The history keeps PasswordHistory-1 rows:
+// together with the current password that is the window the reuse check
+// reads.
+func (p *passwordPolicy) Retire(ctx context.Context, user *models.User) error {
+	if p.cfg.PasswordHistory <= 1 || user.Password == "" {
+		return nil
+	}
+
+	if err := p.historyRepo.Create(ctx, &models.PasswordHistory{
+		UserID:       user.ID,
+		PasswordHash: user.Password,
+	}); err != nil {
+		return err
+	}
+	return p.historyRepo.PruneByUserID(ctx, user.ID, p.cfg.PasswordHistory-1)
+}
+
+// isRecentlyUsed compares candidate with the account's current password and
+// its retired ones, newest first.
+func (p *passwordPolicy) isRecentlyUsed(ctx context.Context, user *models.User, candidate string) (bool, error) {
+	if p.cfg.PasswordHistory <= 0 {
+		return false, nil
+	}
+
+	hashes := []string{user.Password}
+	history, err := p.historyRepo.FindRecentByUserID(ctx, user.ID, p.cfg.PasswordHistory-1)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range history {
+		hashes = append(hashes, h.PasswordHash)
+	}
+
+	for _, h := range hashes {
+		if h != "" && password.Compare(h, candidate) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *passwordPolicy) isBreached(candidate string) bool {
+	if len(p.breached) == 0 {
+		return false
+	}
+	sum := sha1.Sum([]byte(candidate)) // #nosec G401 -- lookup key, not a password hash
+	_, found := slices.BinarySearchFunc(p.breached, sum, compareDigests)
+	return found
+}
+
+// characterClasses counts how many of lowercase, uppercase, digit and symbol
+// (anything else, spaces included) occur in s.
+func characterClasses(s string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	n := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			n++
+		}
+	}
+	return n
+}
+
+// loadBreachedList reads one hex SHA-1 digest per line, ignoring blank lines
+// and any ":count" suffix, and returns the digests sorted and de-duplicated.
+// The file need not be sorted already.
+func loadBreachedList(path string) ([][sha1.Size]byte, error) {
+	f, err := os.Open(path) // #nosec G304 -- path comes from operator config
+	if err != nil {
+		return nil, fmt.Errorf("breached password list %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var digests [][sha1.Size]byte
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		entry, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if entry == "" {
+			continue
+		}
+
+		var digest [sha1.Size]byte
+		if len(entry) != hex.EncodedLen(sha1.Size) {
+			return nil, fmt.Errorf("breached password list %s: line %d is not a SHA-1 hex digest", path, line)
+		}
+		if _, err := hex.Decode(digest[:], []byte(entry)); err != nil {
+			return nil, fmt.Errorf("breached password list %s: line %d: %w", path, line, err)
+		}
+		digests = append(digests, digest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("breached password list %s: %w", path, err)
+	}
+	if len(digests) == 0 {
+		return nil, fmt.Errorf("breached password list %s is empty", path)
+	}
+
+	slices.SortFunc(digests, compareDigests)
+	return slices.CompactFunc(digests, func(a, b [sha1.Size]byte) bool { return a == b }), nil
+}
+
+func compareDigests(a, b [sha1.Size]byte) int {
+	return bytes.Compare(a[:], b[:])
+}
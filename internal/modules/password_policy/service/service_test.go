@@ -0,0 +1,193 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- mirrors the breached-list format under test
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	historymocks "github.com/PhantomX7/athleton/internal/modules/password_policy/repository/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/password_policy/service"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/password"
+)
+
+func newConfig() *config.Config {
+	return &config.Config{Auth: config.AuthConfig{
+		PasswordMinLength: 8,
+		PasswordHistory:   3,
+		// Cheap Argon2id parameters keep the tests fast.
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}}
+}
+
+func mustHash(t *testing.T, plain string) string {
+	t.Helper()
+	hashed, err := password.Hash(plain, newConfig().Auth.PasswordHashParams())
+	require.NoError(t, err)
+	return hashed
+}
+
+// mustLegacyHash hashes plain with bcrypt, as passwords were before Argon2id.
+func mustLegacyHash(t *testing.T, plain string) string {
+	t.Helper()
+	hashed, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hashed)
+}
+
+func requireBadRequest(t *testing.T, err error, message string) {
+	t.Helper()
+	var appErr *cerrors.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	require.Equal(t, http.StatusBadRequest, appErr.Code)
+	require.Contains(t, appErr.Message, message)
+}
+
+// writeBreachedList writes passwords as an unsorted SHA-1 list with
+// HIBP-style ":count" suffixes.
+func writeBreachedList(t *testing.T, passwords ...string) string {
+	t.Helper()
+	var b strings.Builder
+	for i := len(passwords) - 1; i >= 0; i-- {
+		sum := sha1.Sum([]byte(passwords[i])) // #nosec G401 -- test fixture
+		b.WriteString(strings.ToUpper(hex.EncodeToString(sum[:])) + ":42\n")
+	}
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0o600))
+	return path
+}
+
+func TestPasswordPolicyHashAcceptsValidPassword(t *testing.T) {
+	policy, err := service.NewPasswordPolicy(newConfig(), &historymocks.PasswordHistoryRepositoryMock{})
+	require.NoError(t, err)
+
+	hashed, err := policy.Hash(context.Background(), nil, "correct horse battery")
+
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=64,t=1,p=1$"), hashed)
+	require.NoError(t, password.Compare(hashed, "correct horse battery"))
+}
+
+func TestPasswordPolicyHashEnforcesLengthAndClasses(t *testing.T) {
+	cfg := newConfig()
+	cfg.Auth.PasswordMinLength = 10
+	cfg.Auth.PasswordMinCharacterClasses = 3
+	policy, err := service.NewPasswordPolicy(cfg, &historymocks.PasswordHistoryRepositoryMock{})
+	require.NoError(t, err)
+
+	_, err = policy.Hash(context.Background(), nil, "Short1!")
+	requireBadRequest(t, err, "at least 10 characters")
+
+	_, err = policy.Hash(context.Background(), nil, strings.Repeat("a", 129))
+	requireBadRequest(t, err, "at most 128 bytes")
+
+	_, err = policy.Hash(context.Background(), nil, "alllowercaseletters")
+	requireBadRequest(t, err, "mix at least 3")
+
+	_, err = policy.Hash(context.Background(), nil, "Mixed-case words")
+	require.NoError(t, err)
+}
+
+func TestPasswordPolicyHashRejectsCommonAndBreachedPasswords(t *testing.T) {
+	cfg := newConfig()
+	cfg.Auth.PasswordBreachedListFile = writeBreachedList(t, "hunter22hunter", "trustno1trustno1")
+	policy, err := service.NewPasswordPolicy(cfg, &historymocks.PasswordHistoryRepositoryMock{})
+	require.NoError(t, err)
+
+	_, err = policy.Hash(context.Background(), nil, "Password")
+	requireBadRequest(t, err, "too common")
+
+	_, err = policy.Hash(context.Background(), nil, "trustno1trustno1")
+	requireBadRequest(t, err, "data breach")
+
+	_, err = policy.Hash(context.Background(), nil, "not-in-the-list")
+	require.NoError(t, err)
+}
+
+func TestNewPasswordPolicyRejectsBadBreachedList(t *testing.T) {
+	cfg := newConfig()
+	cfg.Auth.PasswordBreachedListFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err := service.NewPasswordPolicy(cfg, &historymocks.PasswordHistoryRepositoryMock{})
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "bad.txt")
+	require.NoError(t, os.WriteFile(path, []byte("not-a-digest\n"), 0o600))
+	cfg.Auth.PasswordBreachedListFile = path
+	_, err = service.NewPasswordPolicy(cfg, &historymocks.PasswordHistoryRepositoryMock{})
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestPasswordPolicyHashRejectsRecentPasswords(t *testing.T) {
+	user := &models.User{ID: 9, Password: mustHash(t, "current-password")}
+	repo := &historymocks.PasswordHistoryRepositoryMock{
+		FindRecentByUserIDFunc: func(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+			require.Equal(t, uint(9), userID)
+			// PasswordHistory counts the current password.
+			require.Equal(t, 2, limit)
+			return []models.PasswordHistory{
+				{PasswordHash: mustHash(t, "previous-password")},
+				{PasswordHash: mustLegacyHash(t, "legacy-password")},
+			}, nil
+		},
+	}
+	policy, err := service.NewPasswordPolicy(newConfig(), repo)
+	require.NoError(t, err)
+
+	_, err = policy.Hash(context.Background(), user, "current-password")
+	requireBadRequest(t, err, "used recently")
+
+	_, err = policy.Hash(context.Background(), user, "previous-password")
+	requireBadRequest(t, err, "used recently")
+
+	_, err = policy.Hash(context.Background(), user, "legacy-password")
+	requireBadRequest(t, err, "used recently")
+
+	_, err = policy.Hash(context.Background(), user, "a-fresh-password")
+	require.NoError(t, err)
+}
+
+func TestPasswordPolicyRetireStoresAndPrunesHistory(t *testing.T) {
+	user := &models.User{ID: 9, Password: "old-hash"}
+	repo := &historymocks.PasswordHistoryRepositoryMock{
+		CreateFunc: func(ctx context.Context, entity *models.PasswordHistory) error {
+			require.Equal(t, uint(9), entity.UserID)
+			require.Equal(t, "old-hash", entity.PasswordHash)
+			return nil
+		},
+		PruneByUserIDFunc: func(ctx context.Context, userID uint, keep int) error {
+			require.Equal(t, uint(9), userID)
+			require.Equal(t, 2, keep)
+			return nil
+		},
+	}
+	policy, err := service.NewPasswordPolicy(newConfig(), repo)
+	require.NoError(t, err)
+
+	require.NoError(t, policy.Retire(context.Background(), user))
+	require.Len(t, repo.CreateCalls(), 1)
+	require.Len(t, repo.PruneByUserIDCalls(), 1)
+}
+
+func TestPasswordPolicyRetireIsNoopWithoutHistory(t *testing.T) {
+	cfg := newConfig()
+	cfg.Auth.PasswordHistory = 1
+	repo := &historymocks.PasswordHistoryRepositoryMock{}
+	policy, err := service.NewPasswordPolicy(cfg, repo)
+	require.NoError(t, err)
+
+	require.NoError(t, policy.Retire(context.Background(), &models.User{ID: 9, Password: "old-hash"}))
+	require.Empty(t, repo.CreateCalls())
+}
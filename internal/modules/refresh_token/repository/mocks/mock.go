@@ -6,11 +6,13 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/PhantomX7/athleton/internal/models"
 	refreshtokenrepository "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
 	"github.com/PhantomX7/athleton/pkg/pagination"
 	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
 	"github.com/google/uuid"
 )
 
@@ -39,9 +41,15 @@ var _ refreshtokenrepository.RefreshTokenRepository = &RefreshTokenRepositoryMoc
 //			FindActiveByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error) {
 //				panic("mock out the FindActiveByID method")
 //			},
+//			FindActiveByUserIDFunc: func(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+//				panic("mock out the FindActiveByUserID method")
+//			},
 //			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.RefreshToken, error) {
 //				panic("mock out the FindAll method")
 //			},
+//			FindAllByUserIDFunc: func(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+//				panic("mock out the FindAllByUserID method")
+//			},
 //			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.RefreshToken, error) {
 //				panic("mock out the FindByID method")
 //			},
@@ -54,12 +62,21 @@ var _ refreshtokenrepository.RefreshTokenRepository = &RefreshTokenRepositoryMoc
 //			GetValidCountByUserIDFunc: func(ctx context.Context, userID uint) (int64, error) {
 //				panic("mock out the GetValidCountByUserID method")
 //			},
+//			MarkAuthenticatedFunc: func(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+//				panic("mock out the MarkAuthenticated method")
+//			},
 //			RevokeAllByUserIDFunc: func(ctx context.Context, userID uint) error {
 //				panic("mock out the RevokeAllByUserID method")
 //			},
 //			RevokeAllByUserIDExceptFunc: func(ctx context.Context, userID uint, exceptToken string) error {
 //				panic("mock out the RevokeAllByUserIDExcept method")
 //			},
+//			RevokeAllByUserIDExceptIDFunc: func(ctx context.Context, userID uint, exceptID uuid.UUID) error {
+//				panic("mock out the RevokeAllByUserIDExceptID method")
+//			},
+//			RevokeByIDForUserFunc: func(ctx context.Context, id uuid.UUID, userID uint) (bool, error) {
+//				panic("mock out the RevokeByIDForUser method")
+//			},
 //			RevokeByTokenFunc: func(ctx context.Context, token string) error {
 //				panic("mock out the RevokeByToken method")
 //			},
@@ -72,7 +89,7 @@ var _ refreshtokenrepository.RefreshTokenRepository = &RefreshTokenRepositoryMoc
 //			UpdateFunc: func(ctx context.Context, entity *models.RefreshToken) error {
 //				panic("mock out the Update method")
 //			},
-//			UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken string, newToken string) (bool, error) {
+//			UpdateTokenHashIfActiveFunc: func(ctx context.Context, oldToken string, newToken string, client utils.ClientInfo) (bool, error) {
 //				panic("mock out the UpdateTokenHashIfActive method")
 //			},
 //		}
@@ -97,9 +114,15 @@ type RefreshTokenRepositoryMock struct {
 	// FindActiveByIDFunc mocks the FindActiveByID method.
 	FindActiveByIDFunc func(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error)
 
+	// FindActiveByUserIDFunc mocks the FindActiveByUserID method.
+	FindActiveByUserIDFunc func(ctx context.Context, userID uint) ([]models.RefreshToken, error)
+
 	// FindAllFunc mocks the FindAll method.
 	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.RefreshToken, error)
 
+	// FindAllByUserIDFunc mocks the FindAllByUserID method.
+	FindAllByUserIDFunc func(ctx context.Context, userID uint) ([]models.RefreshToken, error)
+
 	// FindByIDFunc mocks the FindByID method.
 	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.RefreshToken, error)
 
@@ -112,12 +135,21 @@ type RefreshTokenRepositoryMock struct {
 	// GetValidCountByUserIDFunc mocks the GetValidCountByUserID method.
 	GetValidCountByUserIDFunc func(ctx context.Context, userID uint) (int64, error)
 
+	// MarkAuthenticatedFunc mocks the MarkAuthenticated method.
+	MarkAuthenticatedFunc func(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+
 	// RevokeAllByUserIDFunc mocks the RevokeAllByUserID method.
 	RevokeAllByUserIDFunc func(ctx context.Context, userID uint) error
 
 	// RevokeAllByUserIDExceptFunc mocks the RevokeAllByUserIDExcept method.
 	RevokeAllByUserIDExceptFunc func(ctx context.Context, userID uint, exceptToken string) error
 
+	// RevokeAllByUserIDExceptIDFunc mocks the RevokeAllByUserIDExceptID method.
+	RevokeAllByUserIDExceptIDFunc func(ctx context.Context, userID uint, exceptID uuid.UUID) error
+
+	// RevokeByIDForUserFunc mocks the RevokeByIDForUser method.
+	RevokeByIDForUserFunc func(ctx context.Context, id uuid.UUID, userID uint) (bool, error)
+
 	// RevokeByTokenFunc mocks the RevokeByToken method.
 	RevokeByTokenFunc func(ctx context.Context, token string) error
 
@@ -131,7 +163,7 @@ type RefreshTokenRepositoryMock struct {
 	UpdateFunc func(ctx context.Context, entity *models.RefreshToken) error
 
 	// UpdateTokenHashIfActiveFunc mocks the UpdateTokenHashIfActive method.
-	UpdateTokenHashIfActiveFunc func(ctx context.Context, oldToken string, newToken string) (bool, error)
+	UpdateTokenHashIfActiveFunc func(ctx context.Context, oldToken string, newToken string, client utils.ClientInfo) (bool, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -168,6 +200,13 @@ type RefreshTokenRepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// FindActiveByUserID holds details about calls to the FindActiveByUserID method.
+		FindActiveByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
 		// FindAll holds details about calls to the FindAll method.
 		FindAll []struct {
 			// Ctx is the ctx argument value.
@@ -175,6 +214,13 @@ type RefreshTokenRepositoryMock struct {
 			// Pg is the pg argument value.
 			Pg *pagination.Pagination
 		}
+		// FindAllByUserID holds details about calls to the FindAllByUserID method.
+		FindAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
 		// FindByID holds details about calls to the FindByID method.
 		FindByID []struct {
 			// Ctx is the ctx argument value.
@@ -205,6 +251,15 @@ type RefreshTokenRepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID uint
 		}
+		// MarkAuthenticated holds details about calls to the MarkAuthenticated method.
+		MarkAuthenticated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// At is the at argument value.
+			At time.Time
+		}
 		// RevokeAllByUserID holds details about calls to the RevokeAllByUserID method.
 		RevokeAllByUserID []struct {
 			// Ctx is the ctx argument value.
@@ -221,6 +276,24 @@ type RefreshTokenRepositoryMock struct {
 			// ExceptToken is the exceptToken argument value.
 			ExceptToken string
 		}
+		// RevokeAllByUserIDExceptID holds details about calls to the RevokeAllByUserIDExceptID method.
+		RevokeAllByUserIDExceptID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// ExceptID is the exceptID argument value.
+			ExceptID uuid.UUID
+		}
+		// RevokeByIDForUser holds details about calls to the RevokeByIDForUser method.
+		RevokeByIDForUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uint
+		}
 		// RevokeByToken holds details about calls to the RevokeByToken method.
 		RevokeByToken []struct {
 			// Ctx is the ctx argument value.
@@ -259,6 +332,8 @@ type RefreshTokenRepositoryMock struct {
 			OldToken string
 			// NewToken is the newToken argument value.
 			NewToken string
+			// Client is the client argument value.
+			Client utils.ClientInfo
 		}
 	}
 	lockCount                      sync.RWMutex
@@ -266,13 +341,18 @@ type RefreshTokenRepositoryMock struct {
 	lockDelete                     sync.RWMutex
 	lockDeleteInvalidToken         sync.RWMutex
 	lockFindActiveByID             sync.RWMutex
+	lockFindActiveByUserID         sync.RWMutex
 	lockFindAll                    sync.RWMutex
+	lockFindAllByUserID            sync.RWMutex
 	lockFindByID                   sync.RWMutex
 	lockFindByPreviousToken        sync.RWMutex
 	lockFindByToken                sync.RWMutex
 	lockGetValidCountByUserID      sync.RWMutex
+	lockMarkAuthenticated          sync.RWMutex
 	lockRevokeAllByUserID          sync.RWMutex
 	lockRevokeAllByUserIDExcept    sync.RWMutex
+	lockRevokeAllByUserIDExceptID  sync.RWMutex
+	lockRevokeByIDForUser          sync.RWMutex
 	lockRevokeByToken              sync.RWMutex
 	lockRevokeByTokenIfActive      sync.RWMutex
 	lockRevokeOldestActiveByUserID sync.RWMutex
@@ -456,6 +536,42 @@ func (mock *RefreshTokenRepositoryMock) FindActiveByIDCalls() []struct {
 	return calls
 }
 
+// FindActiveByUserID calls FindActiveByUserIDFunc.
+func (mock *RefreshTokenRepositoryMock) FindActiveByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+	if mock.FindActiveByUserIDFunc == nil {
+		panic("RefreshTokenRepositoryMock.FindActiveByUserIDFunc: method is nil but RefreshTokenRepository.FindActiveByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindActiveByUserID.Lock()
+	mock.calls.FindActiveByUserID = append(mock.calls.FindActiveByUserID, callInfo)
+	mock.lockFindActiveByUserID.Unlock()
+	return mock.FindActiveByUserIDFunc(ctx, userID)
+}
+
+// FindActiveByUserIDCalls gets all the calls that were made to FindActiveByUserID.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.FindActiveByUserIDCalls())
+func (mock *RefreshTokenRepositoryMock) FindActiveByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindActiveByUserID.RLock()
+	calls = mock.calls.FindActiveByUserID
+	mock.lockFindActiveByUserID.RUnlock()
+	return calls
+}
+
 // FindAll calls FindAllFunc.
 func (mock *RefreshTokenRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.RefreshToken, error) {
 	if mock.FindAllFunc == nil {
@@ -492,6 +608,42 @@ func (mock *RefreshTokenRepositoryMock) FindAllCalls() []struct {
 	return calls
 }
 
+// FindAllByUserID calls FindAllByUserIDFunc.
+func (mock *RefreshTokenRepositoryMock) FindAllByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+	if mock.FindAllByUserIDFunc == nil {
+		panic("RefreshTokenRepositoryMock.FindAllByUserIDFunc: method is nil but RefreshTokenRepository.FindAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindAllByUserID.Lock()
+	mock.calls.FindAllByUserID = append(mock.calls.FindAllByUserID, callInfo)
+	mock.lockFindAllByUserID.Unlock()
+	return mock.FindAllByUserIDFunc(ctx, userID)
+}
+
+// FindAllByUserIDCalls gets all the calls that were made to FindAllByUserID.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.FindAllByUserIDCalls())
+func (mock *RefreshTokenRepositoryMock) FindAllByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindAllByUserID.RLock()
+	calls = mock.calls.FindAllByUserID
+	mock.lockFindAllByUserID.RUnlock()
+	return calls
+}
+
 // FindByID calls FindByIDFunc.
 func (mock *RefreshTokenRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.RefreshToken, error) {
 	if mock.FindByIDFunc == nil {
@@ -640,6 +792,46 @@ func (mock *RefreshTokenRepositoryMock) GetValidCountByUserIDCalls() []struct {
 	return calls
 }
 
+// MarkAuthenticated calls MarkAuthenticatedFunc.
+func (mock *RefreshTokenRepositoryMock) MarkAuthenticated(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	if mock.MarkAuthenticatedFunc == nil {
+		panic("RefreshTokenRepositoryMock.MarkAuthenticatedFunc: method is nil but RefreshTokenRepository.MarkAuthenticated was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		At  time.Time
+	}{
+		Ctx: ctx,
+		ID:  id,
+		At:  at,
+	}
+	mock.lockMarkAuthenticated.Lock()
+	mock.calls.MarkAuthenticated = append(mock.calls.MarkAuthenticated, callInfo)
+	mock.lockMarkAuthenticated.Unlock()
+	return mock.MarkAuthenticatedFunc(ctx, id, at)
+}
+
+// MarkAuthenticatedCalls gets all the calls that were made to MarkAuthenticated.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.MarkAuthenticatedCalls())
+func (mock *RefreshTokenRepositoryMock) MarkAuthenticatedCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	At  time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		At  time.Time
+	}
+	mock.lockMarkAuthenticated.RLock()
+	calls = mock.calls.MarkAuthenticated
+	mock.lockMarkAuthenticated.RUnlock()
+	return calls
+}
+
 // RevokeAllByUserID calls RevokeAllByUserIDFunc.
 func (mock *RefreshTokenRepositoryMock) RevokeAllByUserID(ctx context.Context, userID uint) error {
 	if mock.RevokeAllByUserIDFunc == nil {
@@ -716,6 +908,86 @@ func (mock *RefreshTokenRepositoryMock) RevokeAllByUserIDExceptCalls() []struct
 	return calls
 }
 
+// RevokeAllByUserIDExceptID calls RevokeAllByUserIDExceptIDFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeAllByUserIDExceptID(ctx context.Context, userID uint, exceptID uuid.UUID) error {
+	if mock.RevokeAllByUserIDExceptIDFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeAllByUserIDExceptIDFunc: method is nil but RefreshTokenRepository.RevokeAllByUserIDExceptID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uint
+		ExceptID uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		ExceptID: exceptID,
+	}
+	mock.lockRevokeAllByUserIDExceptID.Lock()
+	mock.calls.RevokeAllByUserIDExceptID = append(mock.calls.RevokeAllByUserIDExceptID, callInfo)
+	mock.lockRevokeAllByUserIDExceptID.Unlock()
+	return mock.RevokeAllByUserIDExceptIDFunc(ctx, userID, exceptID)
+}
+
+// RevokeAllByUserIDExceptIDCalls gets all the calls that were made to RevokeAllByUserIDExceptID.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeAllByUserIDExceptIDCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeAllByUserIDExceptIDCalls() []struct {
+	Ctx      context.Context
+	UserID   uint
+	ExceptID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uint
+		ExceptID uuid.UUID
+	}
+	mock.lockRevokeAllByUserIDExceptID.RLock()
+	calls = mock.calls.RevokeAllByUserIDExceptID
+	mock.lockRevokeAllByUserIDExceptID.RUnlock()
+	return calls
+}
+
+// RevokeByIDForUser calls RevokeByIDForUserFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeByIDForUser(ctx context.Context, id uuid.UUID, userID uint) (bool, error) {
+	if mock.RevokeByIDForUserFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeByIDForUserFunc: method is nil but RefreshTokenRepository.RevokeByIDForUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     uuid.UUID
+		UserID uint
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		UserID: userID,
+	}
+	mock.lockRevokeByIDForUser.Lock()
+	mock.calls.RevokeByIDForUser = append(mock.calls.RevokeByIDForUser, callInfo)
+	mock.lockRevokeByIDForUser.Unlock()
+	return mock.RevokeByIDForUserFunc(ctx, id, userID)
+}
+
+// RevokeByIDForUserCalls gets all the calls that were made to RevokeByIDForUser.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeByIDForUserCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeByIDForUserCalls() []struct {
+	Ctx    context.Context
+	ID     uuid.UUID
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     uuid.UUID
+		UserID uint
+	}
+	mock.lockRevokeByIDForUser.RLock()
+	calls = mock.calls.RevokeByIDForUser
+	mock.lockRevokeByIDForUser.RUnlock()
+	return calls
+}
+
 // RevokeByToken calls RevokeByTokenFunc.
 func (mock *RefreshTokenRepositoryMock) RevokeByToken(ctx context.Context, token string) error {
 	if mock.RevokeByTokenFunc == nil {
@@ -865,7 +1137,7 @@ func (mock *RefreshTokenRepositoryMock) UpdateCalls() []struct {
 }
 
 // UpdateTokenHashIfActive calls UpdateTokenHashIfActiveFunc.
-func (mock *RefreshTokenRepositoryMock) UpdateTokenHashIfActive(ctx context.Context, oldToken string, newToken string) (bool, error) {
+func (mock *RefreshTokenRepositoryMock) UpdateTokenHashIfActive(ctx context.Context, oldToken string, newToken string, client utils.ClientInfo) (bool, error) {
 	if mock.UpdateTokenHashIfActiveFunc == nil {
 		panic("RefreshTokenRepositoryMock.UpdateTokenHashIfActiveFunc: method is nil but RefreshTokenRepository.UpdateTokenHashIfActive was just called")
 	}
@@ -873,15 +1145,17 @@ func (mock *RefreshTokenRepositoryMock) UpdateTokenHashIfActive(ctx context.Cont
 		Ctx      context.Context
 		OldToken string
 		NewToken string
+		Client   utils.ClientInfo
 	}{
 		Ctx:      ctx,
 		OldToken: oldToken,
 		NewToken: newToken,
+		Client:   client,
 	}
 	mock.lockUpdateTokenHashIfActive.Lock()
 	mock.calls.UpdateTokenHashIfActive = append(mock.calls.UpdateTokenHashIfActive, callInfo)
 	mock.lockUpdateTokenHashIfActive.Unlock()
-	return mock.UpdateTokenHashIfActiveFunc(ctx, oldToken, newToken)
+	return mock.UpdateTokenHashIfActiveFunc(ctx, oldToken, newToken, client)
 }
 
 // UpdateTokenHashIfActiveCalls gets all the calls that were made to UpdateTokenHashIfActive.
@@ -892,11 +1166,13 @@ func (mock *RefreshTokenRepositoryMock) UpdateTokenHashIfActiveCalls() []struct
 	Ctx      context.Context
 	OldToken string
 	NewToken string
+	Client   utils.ClientInfo
 } {
 	var calls []struct {
 		Ctx      context.Context
 		OldToken string
 		NewToken string
+		Client   utils.ClientInfo
 	}
 	mock.lockUpdateTokenHashIfActive.RLock()
 	calls = mock.calls.UpdateTokenHashIfActive
@@ -13,6 +13,7 @@ import (
 	"github.com/PhantomX7/athleton/internal/models"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -38,14 +39,19 @@ type RefreshTokenRepository interface {
 	FindByToken(ctx context.Context, token string) (*models.RefreshToken, error)
 	FindByPreviousToken(ctx context.Context, token string) (*models.RefreshToken, error)
 	FindActiveByID(ctx context.Context, id uuid.UUID) (*models.RefreshToken, error)
+	FindActiveByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error)
+	FindAllByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error)
 	GetValidCountByUserID(ctx context.Context, userID uint) (int64, error)
 	DeleteInvalidToken(ctx context.Context) error
+	MarkAuthenticated(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
 	RevokeAllByUserID(ctx context.Context, userID uint) error
 	RevokeAllByUserIDExcept(ctx context.Context, userID uint, exceptToken string) error
+	RevokeAllByUserIDExceptID(ctx context.Context, userID uint, exceptID uuid.UUID) error
+	RevokeByIDForUser(ctx context.Context, id uuid.UUID, userID uint) (bool, error)
 	RevokeByToken(ctx context.Context, token string) error
 	RevokeByTokenIfActive(ctx context.Context, token string) (bool, error)
 	RevokeOldestActiveByUserID(ctx context.Context, userID uint, n int) error
-	UpdateTokenHashIfActive(ctx context.Context, oldToken, newToken string) (bool, error)
+	UpdateTokenHashIfActive(ctx context.Context, oldToken, newToken string, client utils.ClientInfo) (bool, error)
 }
 
 type refreshTokenRepository struct {
@@ -145,10 +151,42 @@ func (r *refreshTokenRepository) FindActiveByID(ctx context.Context, id uuid.UUI
 	return &rt, nil
 }
 
-// GetValidCountByUserID counts active tokens for a user.
+// FindActiveByUserID returns the user's active sessions, most recently used
+// first. Impersonation sessions are not the user's own and are left out.
+func (r *refreshTokenRepository) FindActiveByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
+		Where(generated.RefreshToken.UserID.Eq(userID)).
+		Where(generated.RefreshToken.ImpersonatorID.IsNull())
+	for _, p := range activeTokenPredicates(time.Now()) {
+		q = q.Where(p)
+	}
+
+	tokens, err := q.Order(generated.RefreshToken.LastUsedAt.Desc()).Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find active refresh tokens for user id %v", userID), err)
+	}
+	return tokens, nil
+}
+
+// FindAllByUserID returns every session recorded for the user, revoked and
+// expired ones included, newest first.
+func (r *refreshTokenRepository) FindAllByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+	tokens, err := gorm.G[models.RefreshToken](r.GetDB(ctx)).
+		Where(generated.RefreshToken.UserID.Eq(userID)).
+		Order(generated.RefreshToken.CreatedAt.Desc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find refresh tokens for user id %v", userID), err)
+	}
+	return tokens, nil
+}
+
+// GetValidCountByUserID counts a user's own active sessions, i.e. those the
+// session cap applies to; impersonation sessions are not counted.
 func (r *refreshTokenRepository) GetValidCountByUserID(ctx context.Context, userID uint) (int64, error) {
 	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
-		Where(generated.RefreshToken.UserID.Eq(userID))
+		Where(generated.RefreshToken.UserID.Eq(userID)).
+		Where(generated.RefreshToken.ImpersonatorID.IsNull())
 	for _, p := range activeTokenPredicates(time.Now()) {
 		q = q.Where(p)
 	}
@@ -208,6 +246,60 @@ func (r *refreshTokenRepository) RevokeAllByUserIDExcept(ctx context.Context, us
 	return nil
 }
 
+// RevokeAllByUserIDExceptID is RevokeAllByUserIDExcept keyed on the session
+// ID rather than the token value, for callers that only hold the access
+// token's jti. A uuid.Nil exceptID matches no row and revokes everything.
+func (r *refreshTokenRepository) RevokeAllByUserIDExceptID(ctx context.Context, userID uint, exceptID uuid.UUID) error {
+	now := time.Now()
+	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
+		Where(generated.RefreshToken.UserID.Eq(userID)).
+		Where(generated.RefreshToken.ID.Neq(exceptID))
+	for _, p := range activeTokenPredicates(now) {
+		q = q.Where(p)
+	}
+
+	if _, err := q.Set(generated.RefreshToken.RevokedAt.Set(now)).Update(ctx); err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to revoke refresh tokens for user id %v", userID), err)
+	}
+	return nil
+}
+
+// MarkAuthenticated stamps the active session id as freshly authenticated at
+// at, reporting whether a row was updated. A revoked or expired session is
+// left alone, so a reauthentication racing a logout cannot revive it.
+func (r *refreshTokenRepository) MarkAuthenticated(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
+		Where(generated.RefreshToken.ID.Eq(id))
+	for _, p := range activeTokenPredicates(at) {
+		q = q.Where(p)
+	}
+
+	rows, err := q.Set(generated.RefreshToken.AuthenticatedAt.Set(at)).Update(ctx)
+	if err != nil {
+		return false, cerrors.NewInternalServerError(fmt.Sprintf("failed to mark refresh token %v authenticated", id), err)
+	}
+	return rows > 0, nil
+}
+
+// RevokeByIDForUser revokes the active session id only if it belongs to
+// userID, reporting whether a row was revoked. Scoping the UPDATE by owner
+// means a caller cannot tell another user's session apart from a missing one.
+func (r *refreshTokenRepository) RevokeByIDForUser(ctx context.Context, id uuid.UUID, userID uint) (bool, error) {
+	now := time.Now()
+	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
+		Where(generated.RefreshToken.ID.Eq(id)).
+		Where(generated.RefreshToken.UserID.Eq(userID))
+	for _, p := range activeTokenPredicates(now) {
+		q = q.Where(p)
+	}
+
+	rows, err := q.Set(generated.RefreshToken.RevokedAt.Set(now)).Update(ctx)
+	if err != nil {
+		return false, cerrors.NewInternalServerError(fmt.Sprintf("failed to revoke refresh token by id %v", id), err)
+	}
+	return rows > 0, nil
+}
+
 // RevokeByToken revokes one specific refresh token by its plaintext value.
 // The plaintext is hashed before matching since that is what is stored. A
 // 0-row update (token already revoked or missing) is treated as success — this
@@ -254,7 +346,8 @@ func (r *refreshTokenRepository) RevokeOldestActiveByUserID(ctx context.Context,
 
 	now := time.Now()
 	q := gorm.G[models.RefreshToken](r.GetDB(ctx)).
-		Where(generated.RefreshToken.UserID.Eq(userID))
+		Where(generated.RefreshToken.UserID.Eq(userID)).
+		Where(generated.RefreshToken.ImpersonatorID.IsNull())
 	for _, p := range activeTokenPredicates(now) {
 		q = q.Where(p)
 	}
@@ -297,7 +390,10 @@ func (r *refreshTokenRepository) RevokeOldestActiveByUserID(ctx context.Context,
 // matches after the winner rewrites it. A false return is therefore the reuse
 // signal — the presented token was already rotated away or revoked — and the
 // caller must refuse and tear down the session family.
-func (r *refreshTokenRepository) UpdateTokenHashIfActive(ctx context.Context, oldToken, newToken string) (bool, error) {
+//
+// The same statement refreshes the session's client details and last_used_at
+// so the session list reflects the device that most recently refreshed it.
+func (r *refreshTokenRepository) UpdateTokenHashIfActive(ctx context.Context, oldToken, newToken string, client utils.ClientInfo) (bool, error) {
 	oldHash := HashRefreshToken(oldToken)
 	newHash := HashRefreshToken(newToken)
 	rows, err := gorm.G[models.RefreshToken](r.GetDB(ctx)).
@@ -309,6 +405,9 @@ func (r *refreshTokenRepository) UpdateTokenHashIfActive(ctx context.Context, ol
 		Set(
 			generated.RefreshToken.Token.Set(newHash),
 			generated.RefreshToken.PreviousTokenHash.Set(oldHash),
+			generated.RefreshToken.UserAgent.Set(client.UserAgent),
+			generated.RefreshToken.IPAddress.Set(client.IP),
+			generated.RefreshToken.LastUsedAt.Set(time.Now()),
 		).
 		Update(ctx)
 	if err != nil {
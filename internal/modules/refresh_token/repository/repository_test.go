@@ -15,6 +15,7 @@ import (
 	"github.com/PhantomX7/athleton/internal/models"
 	refreshtokenrepository "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/utils"
 )
 
 func setupDB(t *testing.T) *gorm.DB {
@@ -284,7 +285,10 @@ func TestRefreshTokenRepositoryUpdateTokenHashIfActiveRotatesInPlace(t *testing.
 	expiresAt := time.Now().Add(time.Hour)
 	seed := seedToken(t, db, user.ID, "before-rotation", expiresAt, nil)
 
-	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "before-rotation", "after-rotation")
+	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "before-rotation", "after-rotation", utils.ClientInfo{
+		UserAgent: "curl/8.0",
+		IP:        "203.0.113.7",
+	})
 	require.NoError(t, err)
 	require.True(t, updated)
 
@@ -294,6 +298,9 @@ func TestRefreshTokenRepositoryUpdateTokenHashIfActiveRotatesInPlace(t *testing.
 	require.WithinDuration(t, expiresAt, stored.ExpiresAt, time.Second,
 		"rotation must NOT extend the session: expires_at is the absolute lifetime set at login")
 	require.Nil(t, stored.RevokedAt)
+	require.Equal(t, "curl/8.0", stored.UserAgent)
+	require.Equal(t, "203.0.113.7", stored.IPAddress)
+	require.WithinDuration(t, time.Now(), stored.LastUsedAt, 5*time.Second)
 
 	// The new plaintext resolves to the same session row; the old one is gone.
 	got, err := repo.FindByToken(context.Background(), "after-rotation")
@@ -313,7 +320,7 @@ func TestRefreshTokenRepositoryRotationRecordsPreviousHashForReuseDetection(t *t
 	user := seedUser(t, db, "mona")
 	seed := seedToken(t, db, user.ID, "gen-1", time.Now().Add(time.Hour), nil)
 
-	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "gen-1", "gen-2")
+	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "gen-1", "gen-2", utils.ClientInfo{})
 	require.NoError(t, err)
 	require.True(t, updated)
 
@@ -344,24 +351,24 @@ func TestRefreshTokenRepositoryUpdateTokenHashIfActiveReportsReuse(t *testing.T)
 	seedToken(t, db, user.ID, "already-revoked", now.Add(time.Hour), &now)
 
 	// First rotation wins.
-	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "rotate-me", "fresh-1")
+	updated, err := repo.UpdateTokenHashIfActive(context.Background(), "rotate-me", "fresh-1", utils.ClientInfo{})
 	require.NoError(t, err)
 	require.True(t, updated)
 
 	// Replaying the pre-rotation value matches no row: the reuse signal. The
 	// concurrent-rotation race collapses to this same case — the loser's WHERE
 	// token = old-hash no longer matches after the winner rewrote it.
-	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "rotate-me", "fresh-2")
+	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "rotate-me", "fresh-2", utils.ClientInfo{})
 	require.NoError(t, err)
 	require.False(t, updated)
 
 	// A revoked row must never be rotated back to life.
-	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "already-revoked", "fresh-3")
+	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "already-revoked", "fresh-3", utils.ClientInfo{})
 	require.NoError(t, err)
 	require.False(t, updated)
 
 	// Nonexistent tokens report false rather than erroring.
-	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "never-existed", "fresh-4")
+	updated, err = repo.UpdateTokenHashIfActive(context.Background(), "never-existed", "fresh-4", utils.ClientInfo{})
 	require.NoError(t, err)
 	require.False(t, updated)
 }
@@ -425,3 +432,147 @@ func TestRefreshTokenRepositoryRevokeOldestActiveByUserIDNoOpCases(t *testing.T)
 	require.NoError(t, db.First(&got, "id = ?", active.ID).Error)
 	require.Nil(t, got.RevokedAt)
 }
+
+func TestRefreshTokenRepositoryFindActiveByUserIDOrdersByLastUse(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	user := seedUser(t, db, "nora")
+	other := seedUser(t, db, "omar")
+	revokedAt := time.Now()
+
+	older := seedToken(t, db, user.ID, "older", time.Now().Add(time.Hour), nil)
+	newer := seedToken(t, db, user.ID, "newer", time.Now().Add(time.Hour), nil)
+	require.NoError(t, db.Model(older).Update("last_used_at", time.Now().Add(-time.Hour)).Error)
+	require.NoError(t, db.Model(newer).Update("last_used_at", time.Now()).Error)
+	seedToken(t, db, user.ID, "revoked", time.Now().Add(time.Hour), &revokedAt)
+	seedToken(t, db, user.ID, "expired", time.Now().Add(-time.Hour), nil)
+	seedToken(t, db, other.ID, "someone-else", time.Now().Add(time.Hour), nil)
+
+	sessions, err := repo.FindActiveByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, newer.ID, sessions[0].ID)
+	require.Equal(t, older.ID, sessions[1].ID)
+}
+
+func TestRefreshTokenRepositoryFindAllByUserIDIncludesEndedSessions(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	user := seedUser(t, db, "nora")
+	other := seedUser(t, db, "omar")
+	revokedAt := time.Now()
+
+	seedToken(t, db, user.ID, "active", time.Now().Add(time.Hour), nil)
+	seedToken(t, db, user.ID, "revoked", time.Now().Add(time.Hour), &revokedAt)
+	seedToken(t, db, user.ID, "expired", time.Now().Add(-time.Hour), nil)
+	seedToken(t, db, other.ID, "someone-else", time.Now().Add(time.Hour), nil)
+
+	sessions, err := repo.FindAllByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	for _, session := range sessions {
+		require.Equal(t, user.ID, session.UserID)
+	}
+}
+
+// RevokeByIDForUser must only touch the caller's own active session.
+func TestRefreshTokenRepositoryRevokeByIDForUserChecksOwner(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	owner := seedUser(t, db, "pia")
+	intruder := seedUser(t, db, "quin")
+	session := seedToken(t, db, owner.ID, "pia-session", time.Now().Add(time.Hour), nil)
+
+	revoked, err := repo.RevokeByIDForUser(context.Background(), session.ID, intruder.ID)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	revoked, err = repo.RevokeByIDForUser(context.Background(), session.ID, owner.ID)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// Already revoked: nothing left to revoke.
+	revoked, err = repo.RevokeByIDForUser(context.Background(), session.ID, owner.ID)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestRefreshTokenRepositoryRevokeAllByUserIDExceptIDKeepsCurrentSession(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	user := seedUser(t, db, "rosa")
+	other := seedUser(t, db, "sam")
+	current := seedToken(t, db, user.ID, "current", time.Now().Add(time.Hour), nil)
+	stale := seedToken(t, db, user.ID, "stale", time.Now().Add(time.Hour), nil)
+	untouched := seedToken(t, db, other.ID, "untouched", time.Now().Add(time.Hour), nil)
+
+	require.NoError(t, repo.RevokeAllByUserIDExceptID(context.Background(), user.ID, current.ID))
+
+	revokedAt := func(id uuid.UUID) *time.Time {
+		var stored models.RefreshToken
+		require.NoError(t, db.First(&stored, "id = ?", id).Error)
+		return stored.RevokedAt
+	}
+	require.Nil(t, revokedAt(current.ID))
+	require.NotNil(t, revokedAt(stale.ID))
+	require.Nil(t, revokedAt(untouched.ID))
+}
+
+// Impersonation sessions belong to the target user but are not theirs to see
+// or lose: listing, counting and the session cap all skip them.
+func TestRefreshTokenRepositorySessionQueriesSkipImpersonationSessions(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	user := seedUser(t, db, "pia")
+	support := seedUser(t, db, "quinn")
+	now := time.Now()
+
+	own := seedToken(t, db, user.ID, "own", now.Add(time.Hour), nil)
+	impersonation := seedToken(t, db, user.ID, "impersonation", now.Add(time.Hour), nil)
+	require.NoError(t, db.Model(impersonation).Update("impersonator_id", support.ID).Error)
+	setCreatedAt(t, db, impersonation.ID, now.Add(-2*time.Hour))
+	setCreatedAt(t, db, own.ID, now.Add(-time.Hour))
+
+	sessions, err := repo.FindActiveByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, own.ID, sessions[0].ID)
+
+	count, err := repo.GetValidCountByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	require.NoError(t, repo.RevokeOldestActiveByUserID(context.Background(), user.ID, 1))
+	var gotOwn, gotImpersonation models.RefreshToken
+	require.NoError(t, db.First(&gotOwn, "id = ?", own.ID).Error)
+	require.NoError(t, db.First(&gotImpersonation, "id = ?", impersonation.ID).Error)
+	require.NotNil(t, gotOwn.RevokedAt)
+	require.Nil(t, gotImpersonation.RevokedAt, "the cap never evicts an impersonation session")
+}
+
+func TestRefreshTokenRepositoryMarkAuthenticatedUpdatesOnlyActiveSessions(t *testing.T) {
+	db := setupDB(t)
+	repo := refreshtokenrepository.NewRefreshTokenRepository(db)
+	user := seedUser(t, db, "kate")
+	now := time.Now()
+
+	active := seedToken(t, db, user.ID, "active", now.Add(time.Hour), nil)
+	revoked := seedToken(t, db, user.ID, "revoked", now.Add(time.Hour), &now)
+
+	marked, err := repo.MarkAuthenticated(context.Background(), active.ID, now)
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	var got models.RefreshToken
+	require.NoError(t, db.First(&got, "id = ?", active.ID).Error)
+	require.NotNil(t, got.AuthenticatedAt)
+	require.WithinDuration(t, now, *got.AuthenticatedAt, time.Second)
+
+	marked, err = repo.MarkAuthenticated(context.Background(), revoked.ID, now)
+	require.NoError(t, err)
+	require.False(t, marked)
+
+	marked, err = repo.MarkAuthenticated(context.Background(), uuid.New(), now)
+	require.NoError(t, err)
+	require.False(t, marked)
+}
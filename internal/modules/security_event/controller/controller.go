@@ -0,0 +1,151 @@
+// Package controller exposes HTTP handlers for security-timeline endpoints.
+package controller
+
+import (
+	"net/http"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/service"
+	"github.com/PhantomX7/athleton/pkg/ginx"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	"github.com/PhantomX7/athleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityEventController exposes the security-timeline HTTP handlers.
+type SecurityEventController interface {
+	IndexOwn(ctx *gin.Context)
+	IndexByUser(ctx *gin.Context)
+}
+
+type securityEventController struct {
+	securityEventService service.SecurityEventService
+}
+
+// NewSecurityEventController builds a SecurityEventController from the
+// security-event service.
+func NewSecurityEventController(securityEventService service.SecurityEventService) SecurityEventController {
+	return &securityEventController{
+		securityEventService: securityEventService,
+	}
+}
+
+// newSecurityEventPagination creates a new pagination instance for security
+// events. There is deliberately no user_id filter: the user is fixed by the
+// route.
+func newSecurityEventPagination(conditions map[string][]string) *pagination.Pagination {
+	filterDefinition := pagination.NewFilterDefinition().
+		AddFilter("type", pagination.FilterConfig{
+			Field:     "type", // enum column is models.SecurityEventType, not a scalar field helper — stay on the string path
+			TableName: "security_events",
+			Type:      pagination.FilterTypeEnum,
+			EnumValues: []string{
+				string(models.SecurityEventLogin),
+				string(models.SecurityEventRefresh),
+				string(models.SecurityEventRefreshReuse),
+				string(models.SecurityEventLogout),
+				string(models.SecurityEventPasswordChange),
+				string(models.SecurityEventPasswordReset),
+				string(models.SecurityEventReauthenticate),
+				string(models.SecurityEventMagicLinkSent),
+				string(models.SecurityEventMagicLinkLogin),
+			},
+		}).
+		AddFilter("outcome", pagination.FilterConfig{
+			Field:     "outcome",
+			TableName: "security_events",
+			Type:      pagination.FilterTypeEnum,
+			EnumValues: []string{
+				string(models.SecurityEventOutcomeSuccess),
+				string(models.SecurityEventOutcomeFailure),
+			},
+		}).
+		AddFilter("ip_address", pagination.FilterConfig{
+			Column:    generated.SecurityEvent.IPAddress,
+			TableName: "security_events",
+			Type:      pagination.FilterTypeString,
+		}).
+		AddFilter("created_at", pagination.FilterConfig{
+			Column:    generated.SecurityEvent.CreatedAt,
+			TableName: "security_events",
+			Type:      pagination.FilterTypeDate,
+		}).
+		AddSort("id", pagination.SortConfig{Column: generated.SecurityEvent.ID, Allowed: true}).
+		AddSort("created_at", pagination.SortConfig{Column: generated.SecurityEvent.CreatedAt, Allowed: true})
+
+	return pagination.NewPagination(conditions, filterDefinition, pagination.PaginationOptions{
+		DefaultLimit: 20,
+		MaxLimit:     100,
+		DefaultOrder: "id desc",
+	})
+}
+
+// IndexOwn lists the authenticated user's security events.
+//
+//	@Summary		List own security events
+//	@Description	Get a paginated timeline of the authenticated user's logins, failed logins, refreshes, refresh-token reuse detections, logouts and password changes, newest first
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit		query		int		false	"Limit"
+//	@Param			offset		query		int		false	"Offset"
+//	@Param			sort		query		string	false	"Sort"
+//	@Param			type		query		string	false	"Filter by event type"
+//	@Param			outcome		query		string	false	"Filter by outcome (success, failure)"
+//	@Param			ip_address	query		string	false	"Filter by IP address"
+//	@Success		200			{object}	response.Response{data=[]dto.SecurityEventResponse,meta=response.Meta}
+//	@Failure		400			{object}	response.Response
+//	@Failure		401			{object}	response.Response
+//	@Router			/auth/security-events [get]
+func (c *securityEventController) IndexOwn(ctx *gin.Context) {
+	events, meta, err := c.securityEventService.IndexOwn(
+		ctx.Request.Context(),
+		newSecurityEventPagination(ctx.Request.URL.Query()),
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response.BuildPaginationResponse(events, meta))
+}
+
+// IndexByUser lists a user's security events for an admin.
+//
+//	@Summary		List a user's security events
+//	@Description	Get a paginated security timeline for the user with the provided ID. Admin and root accounts also require admin_user:read.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		uint	true	"User ID"
+//	@Param			limit		query		int		false	"Limit"
+//	@Param			offset		query		int		false	"Offset"
+//	@Param			sort		query		string	false	"Sort"
+//	@Param			type		query		string	false	"Filter by event type"
+//	@Param			outcome		query		string	false	"Filter by outcome (success, failure)"
+//	@Param			ip_address	query		string	false	"Filter by IP address"
+//	@Success		200			{object}	response.Response{data=[]dto.SecurityEventResponse,meta=response.Meta}
+//	@Failure		400			{object}	response.Response
+//	@Failure		403			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Router			/admin/user/{id}/security-events [get]
+func (c *securityEventController) IndexByUser(ctx *gin.Context) {
+	userID, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	events, meta, err := c.securityEventService.IndexByUserID(
+		ctx.Request.Context(),
+		userID,
+		newSecurityEventPagination(ctx.Request.URL.Query()),
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, response.BuildPaginationResponse(events, meta))
+}
@@ -0,0 +1,105 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/controller"
+	securityeventservicemocks "github.com/PhantomX7/athleton/internal/modules/security_event/service/mocks"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	"github.com/PhantomX7/athleton/pkg/response"
+)
+
+func TestSecurityEventControllerIndexOwnReturnsPaginatedResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sessionID := uuid.New()
+	svc := &securityeventservicemocks.SecurityEventServiceMock{
+		IndexOwnFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+			require.Equal(t, 5, pg.Limit)
+			return []*models.SecurityEvent{
+				{
+					ID:        3,
+					UserID:    9,
+					Type:      models.SecurityEventLogin,
+					Outcome:   models.SecurityEventOutcomeSuccess,
+					IPAddress: "203.0.113.7",
+					UserAgent: "curl/8.0",
+					SessionID: &sessionID,
+				},
+			}, response.Meta{Total: 1, Limit: 5}, nil
+		},
+	}
+
+	ctrl := controller.NewSecurityEventController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/security-events?limit=5&outcome=success", nil)
+
+	ctrl.IndexOwn(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	data, ok := body["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, data, 1)
+
+	item, ok := data[0].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "login", item["type"])
+	require.Equal(t, "success", item["outcome"])
+	require.Equal(t, "203.0.113.7", item["ip_address"])
+	require.Equal(t, sessionID.String(), item["session_id"])
+	require.NotContains(t, item, "user_id")
+}
+
+func TestSecurityEventControllerIndexByUserPassesUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &securityeventservicemocks.SecurityEventServiceMock{
+		IndexByUserIDFunc: func(_ context.Context, userID uint, _ *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+			require.Equal(t, uint(7), userID)
+			return []*models.SecurityEvent{}, response.Meta{}, nil
+		},
+	}
+
+	ctrl := controller.NewSecurityEventController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/user/7/security-events", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "7"}}
+
+	ctrl.IndexByUser(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, svc.IndexByUserIDCalls(), 1)
+}
+
+func TestSecurityEventControllerIndexByUserRejectsInvalidParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &securityeventservicemocks.SecurityEventServiceMock{}
+
+	ctrl := controller.NewSecurityEventController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/user/abc/security-events", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	ctrl.IndexByUser(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.ErrorIs(t, ctx.Errors[0].Err, cerrors.ErrInvalidInput)
+	require.Empty(t, svc.IndexByUserIDCalls())
+}
@@ -0,0 +1,25 @@
+// Package security_event wires the security-timeline module.
+package security_event
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/security_event/controller"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/service"
+	"github.com/PhantomX7/athleton/internal/routes"
+
+	"go.uber.org/fx"
+)
+
+// Module wires the security-event module dependencies into the Fx container.
+var Module = fx.Options(
+	fx.Provide(
+		controller.NewSecurityEventController,
+		service.NewSecurityEventService,
+		repository.NewSecurityEventRepository,
+		fx.Annotate(
+			NewRoutes,
+			fx.As(new(routes.Registrar)),
+			fx.ResultTags(`group:"routes"`),
+		),
+	),
+)
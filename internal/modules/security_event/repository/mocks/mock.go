@@ -0,0 +1,442 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	securityeventrepository "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that SecurityEventRepositoryMock does implement securityeventrepository.SecurityEventRepository.
+// If this is not the case, regenerate this file with moq.
+var _ securityeventrepository.SecurityEventRepository = &SecurityEventRepositoryMock{}
+
+// SecurityEventRepositoryMock is a mock implementation of securityeventrepository.SecurityEventRepository.
+//
+//	func TestSomethingThatUsesSecurityEventRepository(t *testing.T) {
+//
+//		// make and configure a mocked securityeventrepository.SecurityEventRepository
+//		mockedSecurityEventRepository := &SecurityEventRepositoryMock{
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.SecurityEvent) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.SecurityEvent) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteAllByUserIDFunc: func(ctx context.Context, userID uint) error {
+//				panic("mock out the DeleteAllByUserID method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindAllByUserIDFunc: func(ctx context.Context, userID uint) ([]models.SecurityEvent, error) {
+//				panic("mock out the FindAllByUserID method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.SecurityEvent, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.SecurityEvent) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedSecurityEventRepository in code that requires securityeventrepository.SecurityEventRepository
+//		// and then make assertions.
+//
+//	}
+type SecurityEventRepositoryMock struct {
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.SecurityEvent) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.SecurityEvent) error
+
+	// DeleteAllByUserIDFunc mocks the DeleteAllByUserID method.
+	DeleteAllByUserIDFunc func(ctx context.Context, userID uint) error
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, error)
+
+	// FindAllByUserIDFunc mocks the FindAllByUserID method.
+	FindAllByUserIDFunc func(ctx context.Context, userID uint) ([]models.SecurityEvent, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.SecurityEvent, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.SecurityEvent) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.SecurityEvent
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.SecurityEvent
+		}
+		// DeleteAllByUserID holds details about calls to the DeleteAllByUserID method.
+		DeleteAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindAllByUserID holds details about calls to the FindAllByUserID method.
+		FindAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.SecurityEvent
+		}
+	}
+	lockCount             sync.RWMutex
+	lockCreate            sync.RWMutex
+	lockDelete            sync.RWMutex
+	lockDeleteAllByUserID sync.RWMutex
+	lockFindAll           sync.RWMutex
+	lockFindAllByUserID   sync.RWMutex
+	lockFindByID          sync.RWMutex
+	lockUpdate            sync.RWMutex
+}
+
+// Count calls CountFunc.
+func (mock *SecurityEventRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("SecurityEventRepositoryMock.CountFunc: method is nil but SecurityEventRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.CountCalls())
+func (mock *SecurityEventRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *SecurityEventRepositoryMock) Create(ctx context.Context, entity *models.SecurityEvent) error {
+	if mock.CreateFunc == nil {
+		panic("SecurityEventRepositoryMock.CreateFunc: method is nil but SecurityEventRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.CreateCalls())
+func (mock *SecurityEventRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.SecurityEvent
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *SecurityEventRepositoryMock) Delete(ctx context.Context, entity *models.SecurityEvent) error {
+	if mock.DeleteFunc == nil {
+		panic("SecurityEventRepositoryMock.DeleteFunc: method is nil but SecurityEventRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.DeleteCalls())
+func (mock *SecurityEventRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.SecurityEvent
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteAllByUserID calls DeleteAllByUserIDFunc.
+func (mock *SecurityEventRepositoryMock) DeleteAllByUserID(ctx context.Context, userID uint) error {
+	if mock.DeleteAllByUserIDFunc == nil {
+		panic("SecurityEventRepositoryMock.DeleteAllByUserIDFunc: method is nil but SecurityEventRepository.DeleteAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAllByUserID.Lock()
+	mock.calls.DeleteAllByUserID = append(mock.calls.DeleteAllByUserID, callInfo)
+	mock.lockDeleteAllByUserID.Unlock()
+	return mock.DeleteAllByUserIDFunc(ctx, userID)
+}
+
+// DeleteAllByUserIDCalls gets all the calls that were made to DeleteAllByUserID.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.DeleteAllByUserIDCalls())
+func (mock *SecurityEventRepositoryMock) DeleteAllByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockDeleteAllByUserID.RLock()
+	calls = mock.calls.DeleteAllByUserID
+	mock.lockDeleteAllByUserID.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *SecurityEventRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, error) {
+	if mock.FindAllFunc == nil {
+		panic("SecurityEventRepositoryMock.FindAllFunc: method is nil but SecurityEventRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.FindAllCalls())
+func (mock *SecurityEventRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindAllByUserID calls FindAllByUserIDFunc.
+func (mock *SecurityEventRepositoryMock) FindAllByUserID(ctx context.Context, userID uint) ([]models.SecurityEvent, error) {
+	if mock.FindAllByUserIDFunc == nil {
+		panic("SecurityEventRepositoryMock.FindAllByUserIDFunc: method is nil but SecurityEventRepository.FindAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockFindAllByUserID.Lock()
+	mock.calls.FindAllByUserID = append(mock.calls.FindAllByUserID, callInfo)
+	mock.lockFindAllByUserID.Unlock()
+	return mock.FindAllByUserIDFunc(ctx, userID)
+}
+
+// FindAllByUserIDCalls gets all the calls that were made to FindAllByUserID.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.FindAllByUserIDCalls())
+func (mock *SecurityEventRepositoryMock) FindAllByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockFindAllByUserID.RLock()
+	calls = mock.calls.FindAllByUserID
+	mock.lockFindAllByUserID.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *SecurityEventRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.SecurityEvent, error) {
+	if mock.FindByIDFunc == nil {
+		panic("SecurityEventRepositoryMock.FindByIDFunc: method is nil but SecurityEventRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.FindByIDCalls())
+func (mock *SecurityEventRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *SecurityEventRepositoryMock) Update(ctx context.Context, entity *models.SecurityEvent) error {
+	if mock.UpdateFunc == nil {
+		panic("SecurityEventRepositoryMock.UpdateFunc: method is nil but SecurityEventRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedSecurityEventRepository.UpdateCalls())
+func (mock *SecurityEventRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.SecurityEvent
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.SecurityEvent
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -0,0 +1,60 @@
+// Package repository provides security-event persistence primitives.
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . SecurityEventRepository
+
+// SecurityEventRepository defines the interface for security-event operations.
+type SecurityEventRepository interface {
+	repository.Repository[models.SecurityEvent]
+	FindAllByUserID(ctx context.Context, userID uint) ([]models.SecurityEvent, error)
+	DeleteAllByUserID(ctx context.Context, userID uint) error
+}
+
+type securityEventRepository struct {
+	repository.BaseRepository[models.SecurityEvent]
+}
+
+// NewSecurityEventRepository constructs a SecurityEventRepository.
+func NewSecurityEventRepository(db *gorm.DB) SecurityEventRepository {
+	return &securityEventRepository{
+		BaseRepository: repository.NewBaseRepository[models.SecurityEvent](db),
+	}
+}
+
+// FindAllByUserID returns the user's whole security timeline, newest first.
+func (r *securityEventRepository) FindAllByUserID(ctx context.Context, userID uint) ([]models.SecurityEvent, error) {
+	events, err := gorm.G[models.SecurityEvent](r.GetDB(ctx)).
+		Where(generated.SecurityEvent.UserID.Eq(userID)).
+		Order(generated.SecurityEvent.CreatedAt.Desc()).
+		Order(generated.SecurityEvent.ID.Desc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find security events for user id %v", userID), err)
+	}
+	return events, nil
+}
+
+// DeleteAllByUserID hard-deletes the user's security timeline. Its client IP
+// addresses and user agents are personal data with no use once the account
+// is gone.
+func (r *securityEventRepository) DeleteAllByUserID(ctx context.Context, userID uint) error {
+	_, err := gorm.G[models.SecurityEvent](r.GetDB(ctx)).
+		Where(generated.SecurityEvent.UserID.Eq(userID)).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to delete security events for user id %v", userID), err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	securityeventrepository "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.SecurityEvent{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func seedEvent(t *testing.T, db *gorm.DB, userID uint, eventType models.SecurityEventType, createdAt time.Time) *models.SecurityEvent {
+	t.Helper()
+
+	event := &models.SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		Outcome:   models.SecurityEventOutcomeSuccess,
+		IPAddress: "203.0.113.7",
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, db.Create(event).Error)
+	return event
+}
+
+func TestSecurityEventRepositoryFindAllByUserIDNewestFirst(t *testing.T) {
+	db := setupDB(t)
+	repo := securityeventrepository.NewSecurityEventRepository(db)
+	user := seedUser(t, db, "nora")
+	other := seedUser(t, db, "omar")
+
+	older := seedEvent(t, db, user.ID, models.SecurityEventLogin, time.Now().Add(-time.Hour))
+	newer := seedEvent(t, db, user.ID, models.SecurityEventLogout, time.Now())
+	seedEvent(t, db, other.ID, models.SecurityEventLogin, time.Now())
+
+	events, err := repo.FindAllByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, newer.ID, events[0].ID)
+	require.Equal(t, older.ID, events[1].ID)
+}
+
+func TestSecurityEventRepositoryDeleteAllByUserIDKeepsOtherUsers(t *testing.T) {
+	db := setupDB(t)
+	repo := securityeventrepository.NewSecurityEventRepository(db)
+	user := seedUser(t, db, "nora")
+	other := seedUser(t, db, "omar")
+
+	seedEvent(t, db, user.ID, models.SecurityEventLogin, time.Now())
+	seedEvent(t, db, user.ID, models.SecurityEventLogout, time.Now())
+	kept := seedEvent(t, db, other.ID, models.SecurityEventLogin, time.Now())
+
+	require.NoError(t, repo.DeleteAllByUserID(context.Background(), user.ID))
+
+	var remaining []models.SecurityEvent
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	require.Equal(t, kept.ID, remaining[0].ID)
+}
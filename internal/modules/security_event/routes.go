@@ -0,0 +1,24 @@
+// Package security_event wires the security-timeline module.
+package security_event
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/security_event/controller"
+	"github.com/PhantomX7/athleton/internal/routes"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+)
+
+type routeRegistrar struct {
+	controller controller.SecurityEventController
+}
+
+// NewRoutes constructs the security-event route registrar.
+func NewRoutes(controller controller.SecurityEventController) routes.Registrar {
+	return &routeRegistrar{controller: controller}
+}
+
+// RegisterRoutes mounts the security-timeline endpoints: the user's own under
+// /auth (a session, like the session list) and any user's under /admin/user.
+func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	ctx.Root.GET("/auth/security-events", ctx.MW.RequireSessionAuth(), r.controller.IndexOwn)
+	ctx.Admin.GET("/user/:id/security-events", ctx.MW.RequirePermission(permissions.UserRead), r.controller.IndexByUser)
+}
@@ -0,0 +1,142 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/service"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	"github.com/PhantomX7/athleton/pkg/response"
+)
+
+// Ensure, that SecurityEventServiceMock does implement service.SecurityEventService.
+// If this is not the case, regenerate this file with moq.
+var _ service.SecurityEventService = &SecurityEventServiceMock{}
+
+// SecurityEventServiceMock is a mock implementation of service.SecurityEventService.
+//
+//	func TestSomethingThatUsesSecurityEventService(t *testing.T) {
+//
+//		// make and configure a mocked service.SecurityEventService
+//		mockedSecurityEventService := &SecurityEventServiceMock{
+//			IndexByUserIDFunc: func(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+//				panic("mock out the IndexByUserID method")
+//			},
+//			IndexOwnFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+//				panic("mock out the IndexOwn method")
+//			},
+//		}
+//
+//		// use mockedSecurityEventService in code that requires service.SecurityEventService
+//		// and then make assertions.
+//
+//	}
+type SecurityEventServiceMock struct {
+	// IndexByUserIDFunc mocks the IndexByUserID method.
+	IndexByUserIDFunc func(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error)
+
+	// IndexOwnFunc mocks the IndexOwn method.
+	IndexOwnFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IndexByUserID holds details about calls to the IndexByUserID method.
+		IndexByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// IndexOwn holds details about calls to the IndexOwn method.
+		IndexOwn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+	}
+	lockIndexByUserID sync.RWMutex
+	lockIndexOwn      sync.RWMutex
+}
+
+// IndexByUserID calls IndexByUserIDFunc.
+func (mock *SecurityEventServiceMock) IndexByUserID(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+	if mock.IndexByUserIDFunc == nil {
+		panic("SecurityEventServiceMock.IndexByUserIDFunc: method is nil but SecurityEventService.IndexByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+		Pg     *pagination.Pagination
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Pg:     pg,
+	}
+	mock.lockIndexByUserID.Lock()
+	mock.calls.IndexByUserID = append(mock.calls.IndexByUserID, callInfo)
+	mock.lockIndexByUserID.Unlock()
+	return mock.IndexByUserIDFunc(ctx, userID, pg)
+}
+
+// IndexByUserIDCalls gets all the calls that were made to IndexByUserID.
+// Check the length with:
+//
+//	len(mockedSecurityEventService.IndexByUserIDCalls())
+func (mock *SecurityEventServiceMock) IndexByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+	Pg     *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+		Pg     *pagination.Pagination
+	}
+	mock.lockIndexByUserID.RLock()
+	calls = mock.calls.IndexByUserID
+	mock.lockIndexByUserID.RUnlock()
+	return calls
+}
+
+// IndexOwn calls IndexOwnFunc.
+func (mock *SecurityEventServiceMock) IndexOwn(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+	if mock.IndexOwnFunc == nil {
+		panic("SecurityEventServiceMock.IndexOwnFunc: method is nil but SecurityEventService.IndexOwn was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockIndexOwn.Lock()
+	mock.calls.IndexOwn = append(mock.calls.IndexOwn, callInfo)
+	mock.lockIndexOwn.Unlock()
+	return mock.IndexOwnFunc(ctx, pg)
+}
+
+// IndexOwnCalls gets all the calls that were made to IndexOwn.
+// Check the length with:
+//
+//	len(mockedSecurityEventService.IndexOwnCalls())
+func (mock *SecurityEventServiceMock) IndexOwnCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockIndexOwn.RLock()
+	calls = mock.calls.IndexOwn
+	mock.lockIndexOwn.RUnlock()
+	return calls
+}
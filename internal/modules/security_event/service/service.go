@@ -0,0 +1,108 @@
+// Package service contains the business logic for reading security timelines.
+// Events are written by the auth flows through audit.RecordSecurityEvent.
+package service
+
+import (
+	"context"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	"github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	"github.com/PhantomX7/athleton/pkg/response"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . SecurityEventService
+
+// SecurityEventService exposes read-only security-timeline operations.
+type SecurityEventService interface {
+	// IndexOwn lists the authenticated user's own events.
+	IndexOwn(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error)
+	// IndexByUserID lists userID's events for an admin.
+	IndexByUserID(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error)
+}
+
+type securityEventService struct {
+	securityEventRepo repository.SecurityEventRepository
+	userRepo          userrepo.UserRepository
+	casbinClient      casbin.Client
+}
+
+// NewSecurityEventService builds a SecurityEventService from its dependencies.
+func NewSecurityEventService(
+	securityEventRepo repository.SecurityEventRepository,
+	userRepo userrepo.UserRepository,
+	casbinClient casbin.Client,
+) SecurityEventService {
+	return &securityEventService{
+		securityEventRepo: securityEventRepo,
+		userRepo:          userRepo,
+		casbinClient:      casbinClient,
+	}
+}
+
+// IndexOwn implements SecurityEventService.
+func (s *securityEventService) IndexOwn(ctx context.Context, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, response.Meta{}, err
+	}
+	return s.index(ctx, values.UserID, pg)
+}
+
+// IndexByUserID implements SecurityEventService. Like the rest of the admin
+// user surface, an admin or root account's timeline needs admin_user:read on
+// top of the route's user:read.
+func (s *securityEventService) IndexByUserID(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, response.Meta{}, err
+	}
+
+	if user.Role.IsAdminType() {
+		values, err := utils.ValuesFromContext(ctx)
+		if err != nil {
+			return nil, response.Meta{}, err
+		}
+		allowed, err := s.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleIDs, permissions.AdminUserRead.String())
+		if err != nil {
+			return nil, response.Meta{}, cerrors.NewInternalServerError("failed to verify permissions", err)
+		}
+		if !allowed {
+			return nil, response.Meta{}, cerrors.NewForbiddenError("insufficient permissions to manage admin accounts")
+		}
+	}
+
+	return s.index(ctx, user.ID, pg)
+}
+
+// index lists userID's events; the user scope is added here, never taken
+// from the query string.
+func (s *securityEventService) index(ctx context.Context, userID uint, pg *pagination.Pagination) ([]*models.SecurityEvent, response.Meta, error) {
+	pg.AddCustomScope(func(db *gorm.DB) *gorm.DB {
+		return db.Where(generated.SecurityEvent.UserID.Eq(userID))
+	})
+
+	events, err := s.securityEventRepo.FindAll(ctx, pg)
+	if err != nil {
+		return nil, response.Meta{}, err
+	}
+
+	count, err := s.securityEventRepo.Count(ctx, pg)
+	if err != nil {
+		return nil, response.Meta{}, err
+	}
+
+	return events, response.Meta{
+		Total:  count,
+		Offset: pg.Offset,
+		Limit:  pg.Limit,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	securityeventrepository "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/security_event/service"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	casbinmocks "github.com/PhantomX7/athleton/libs/casbin/mocks"
+	"github.com/PhantomX7/athleton/pkg/constants/permissions"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+func newPagination() *pagination.Pagination {
+	return pagination.NewPagination(nil, nil,
+		pagination.PaginationOptions{DefaultLimit: 20, MaxLimit: 100, DefaultOrder: "id asc"})
+}
+
+// TestSecurityEventServiceIndexOwnScopesToCaller drives IndexOwn through a
+// real sqlite-backed repository: the user scope is a pagination scope, which
+// a mock cannot observe.
+func TestSecurityEventServiceIndexOwnScopesToCaller(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.SecurityEvent{}))
+
+	var users []*models.User
+	for _, name := range []string{"alma", "bima"} {
+		user := &models.User{
+			Username: name,
+			Name:     name,
+			Email:    name + "@example.com",
+			Phone:    "0812345",
+			IsActive: true,
+			Role:     models.UserRoleUser,
+			Password: "hashed",
+		}
+		require.NoError(t, db.Create(user).Error)
+		users = append(users, user)
+	}
+	for _, event := range []*models.SecurityEvent{
+		{UserID: users[0].ID, Type: models.SecurityEventLogin, Outcome: models.SecurityEventOutcomeFailure},
+		{UserID: users[0].ID, Type: models.SecurityEventLogin, Outcome: models.SecurityEventOutcomeSuccess},
+		{UserID: users[1].ID, Type: models.SecurityEventLogin, Outcome: models.SecurityEventOutcomeSuccess},
+	} {
+		require.NoError(t, db.Create(event).Error)
+	}
+
+	svc := service.NewSecurityEventService(securityeventrepository.NewSecurityEventRepository(db), &usermocks.UserRepositoryMock{}, &casbinmocks.ClientMock{})
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: users[0].ID})
+
+	events, meta, err := svc.IndexOwn(ctx, newPagination())
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), meta.Total)
+	require.Len(t, events, 2)
+	for _, event := range events {
+		require.Equal(t, users[0].ID, event.UserID)
+	}
+	require.Equal(t, models.SecurityEventOutcomeFailure, events[0].Outcome)
+}
+
+func TestSecurityEventServiceIndexByUserIDSkipsAdminCheckForRegularUsers(t *testing.T) {
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Role: models.UserRoleUser}, nil
+		},
+	}
+	eventRepo := &securityeventmocks.SecurityEventRepositoryMock{
+		FindAllFunc: func(context.Context, *pagination.Pagination) ([]*models.SecurityEvent, error) {
+			return []*models.SecurityEvent{{ID: 1, UserID: 5}}, nil
+		},
+		CountFunc: func(context.Context, *pagination.Pagination) (int64, error) {
+			return 1, nil
+		},
+	}
+	svc := service.NewSecurityEventService(eventRepo, userRepo, &casbinmocks.ClientMock{})
+
+	events, meta, err := svc.IndexByUserID(context.Background(), 5, newPagination())
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(1), meta.Total)
+}
+
+func TestSecurityEventServiceIndexByUserIDRequiresAdminUserReadForAdmins(t *testing.T) {
+	adminRoleID := uint(3)
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Role: models.UserRoleAdmin}, nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(role string, gotRoleIDs []uint, perm string) (bool, error) {
+			require.Equal(t, models.UserRoleAdmin.ToString(), role)
+			require.Equal(t, []uint{adminRoleID}, gotRoleIDs)
+			require.Equal(t, permissions.AdminUserRead.String(), perm)
+			return false, nil
+		},
+	}
+	eventRepo := &securityeventmocks.SecurityEventRepositoryMock{}
+	svc := service.NewSecurityEventService(eventRepo, userRepo, casbinClient)
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
+		UserID:       1,
+		Role:         models.UserRoleAdmin.ToString(),
+		AdminRoleIDs: []uint{adminRoleID},
+	})
+
+	events, _, err := svc.IndexByUserID(ctx, 7, newPagination())
+
+	var appErr *cerrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	require.Equal(t, http.StatusForbidden, appErr.Code)
+	require.Nil(t, events)
+	require.Empty(t, eventRepo.FindAllCalls())
+}
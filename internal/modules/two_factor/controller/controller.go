@@ -0,0 +1,174 @@
+// Package controller exposes HTTP handlers for two-factor authentication.
+package controller
+
+import (
+	"net/http"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/service"
+	"github.com/PhantomX7/athleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorController defines the interface for two-factor controller operations
+type TwoFactorController interface {
+	Setup(ctx *gin.Context)
+	Enable(ctx *gin.Context)
+	Disable(ctx *gin.Context)
+	RegenerateRecoveryCodes(ctx *gin.Context)
+	Verify(ctx *gin.Context)
+}
+
+type twoFactorController struct {
+	twoFactorService service.TwoFactorService
+	cookies          authjwt.SessionCookies
+}
+
+// NewTwoFactorController constructs a TwoFactorController.
+func NewTwoFactorController(twoFactorService service.TwoFactorService, cookies authjwt.SessionCookies) TwoFactorController {
+	return &twoFactorController{
+		twoFactorService: twoFactorService,
+		cookies:          cookies,
+	}
+}
+
+// Setup starts 2FA enrolment for the authenticated user.
+//
+//	@Summary		Start 2FA setup
+//	@Description	Generate a pending TOTP secret and its otpauth:// URI. 2FA is not active until confirmed via /auth/2fa/enable.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.Response{data=dto.TwoFactorSetupResponse}
+//	@Failure		401	{object}	response.Response
+//	@Failure		409	{object}	response.Response
+//	@Router			/auth/2fa/setup [post]
+func (c *twoFactorController) Setup(ctx *gin.Context) {
+	res, err := c.twoFactorService.Setup(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("two-factor setup started", res))
+}
+
+// Enable confirms 2FA enrolment with a code from the authenticator app.
+//
+//	@Summary		Enable 2FA
+//	@Description	Confirm the pending TOTP secret with a current code, turn 2FA on, and return one-time recovery codes
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.TwoFactorEnableRequest	true	"Enable 2FA Request"
+//	@Success		200		{object}	response.Response{data=dto.TwoFactorRecoveryCodesResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		409		{object}	response.Response
+//	@Router			/auth/2fa/enable [post]
+func (c *twoFactorController) Enable(ctx *gin.Context) {
+	var req dto.TwoFactorEnableRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.twoFactorService.Enable(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("two-factor authentication enabled", res))
+}
+
+// Disable turns 2FA off for the authenticated user.
+//
+//	@Summary		Disable 2FA
+//	@Description	Turn 2FA off; requires the current password and a TOTP or recovery code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.TwoFactorDisableRequest	true	"Disable 2FA Request"
+//	@Success		200		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Router			/auth/2fa/disable [post]
+func (c *twoFactorController) Disable(ctx *gin.Context) {
+	var req dto.TwoFactorDisableRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	err := c.twoFactorService.Disable(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("two-factor authentication disabled", nil))
+}
+
+// RegenerateRecoveryCodes replaces the authenticated user's recovery codes.
+//
+//	@Summary		Regenerate 2FA recovery codes
+//	@Description	Invalidate every existing recovery code and return a fresh set; requires a TOTP or recovery code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		dto.TwoFactorRecoveryCodesRequest	true	"Regenerate Recovery Codes Request"
+//	@Success		200		{object}	response.Response{data=dto.TwoFactorRecoveryCodesResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Router			/auth/2fa/recovery-codes [post]
+func (c *twoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var req dto.TwoFactorRecoveryCodesRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.twoFactorService.RegenerateRecoveryCodes(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("recovery codes regenerated", res))
+}
+
+// Verify completes a login that was answered with a 2FA challenge.
+//
+//	@Summary		Verify 2FA login
+//	@Description	Exchange the challenge_token returned by /auth/login plus a TOTP or recovery code for auth tokens. Each challenge allows a single attempt. In cookie auth mode the tokens are set as cookies instead of returned.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		dto.TwoFactorVerifyRequest	true	"Verify 2FA Request"
+//	@Success		200		{object}	response.Response{data=dto.AuthResponse}
+//	@Failure		400		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Router			/auth/2fa/verify [post]
+func (c *twoFactorController) Verify(ctx *gin.Context) {
+	var req dto.TwoFactorVerifyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	res, err := c.twoFactorService.VerifyLogin(ctx.Request.Context(), &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.cookies.SetSessionCookies(ctx, res)
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("login success", res))
+}
@@ -0,0 +1,124 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	authjwtmocks "github.com/PhantomX7/athleton/internal/modules/auth/jwt/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/controller"
+	twofactorservicemocks "github.com/PhantomX7/athleton/internal/modules/two_factor/service/mocks"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+)
+
+// bearerCookies stands in for SessionCookies in bearer mode: no cookies are
+// read or written.
+func bearerCookies() *authjwtmocks.SessionCookiesMock {
+	return &authjwtmocks.SessionCookiesMock{
+		SetSessionCookiesFunc:      func(*gin.Context, *dto.AuthResponse) {},
+		ClearSessionCookiesFunc:    func(*gin.Context) {},
+		RefreshTokenFromCookieFunc: func(*gin.Context) (string, bool) { return "", false },
+	}
+}
+
+func TestTwoFactorControllerVerifyReturnsTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &twofactorservicemocks.TwoFactorServiceMock{
+		VerifyLoginFunc: func(_ context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error) {
+			require.Equal(t, "challenge", req.ChallengeToken)
+			require.Equal(t, "123456", req.Code)
+			return &dto.AuthResponse{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer"}, nil
+		},
+	}
+
+	ctrl := controller.NewTwoFactorController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/verify",
+		bytes.NewBufferString(`{"challenge_token":"challenge","code":"123456"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Verify(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Message string           `json:"message"`
+		Data    dto.AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "login success", body.Message)
+	require.Equal(t, "access", body.Data.AccessToken)
+}
+
+func TestTwoFactorControllerVerifyRejectsMissingChallenge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := controller.NewTwoFactorController(&twofactorservicemocks.TwoFactorServiceMock{}, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/verify",
+		bytes.NewBufferString(`{"code":"123456"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Verify(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.Equal(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+}
+
+func TestTwoFactorControllerEnableReturnsRecoveryCodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &twofactorservicemocks.TwoFactorServiceMock{
+		EnableFunc: func(_ context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+			require.Equal(t, "654321", req.Code)
+			return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: []string{"abcde-fghij"}}, nil
+		},
+	}
+
+	ctrl := controller.NewTwoFactorController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/enable",
+		bytes.NewBufferString(`{"code":"654321"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Enable(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Data dto.TwoFactorRecoveryCodesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, []string{"abcde-fghij"}, body.Data.RecoveryCodes)
+}
+
+func TestTwoFactorControllerDisableForwardsServiceError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &twofactorservicemocks.TwoFactorServiceMock{
+		DisableFunc: func(context.Context, *dto.TwoFactorDisableRequest) error {
+			return cerrors.NewBadRequestError("password is incorrect")
+		},
+	}
+
+	ctrl := controller.NewTwoFactorController(svc, bearerCookies())
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/disable",
+		bytes.NewBufferString(`{"password":"wrong-pass","code":"123456"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	ctrl.Disable(ctx)
+
+	require.Len(t, ctx.Errors, 1)
+	require.NotEqual(t, gin.ErrorTypeBind, ctx.Errors[0].Type)
+}
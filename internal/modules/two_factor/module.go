@@ -0,0 +1,25 @@
+// Package two_factor wires the TOTP two-factor authentication module.
+package two_factor
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/controller"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/repository"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/service"
+	"github.com/PhantomX7/athleton/internal/routes"
+
+	"go.uber.org/fx"
+)
+
+// Module wires the two-factor module dependencies into the Fx container.
+var Module = fx.Options(
+	fx.Provide(
+		controller.NewTwoFactorController,
+		service.NewTwoFactorService,
+		repository.NewRecoveryCodeRepository,
+		fx.Annotate(
+			NewRoutes,
+			fx.As(new(routes.Registrar)),
+			fx.ResultTags(`group:"routes"`),
+		),
+	),
+)
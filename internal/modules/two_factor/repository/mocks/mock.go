@@ -0,0 +1,504 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	twofactorrepository "github.com/PhantomX7/athleton/internal/modules/two_factor/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that RecoveryCodeRepositoryMock does implement twofactorrepository.RecoveryCodeRepository.
+// If this is not the case, regenerate this file with moq.
+var _ twofactorrepository.RecoveryCodeRepository = &RecoveryCodeRepositoryMock{}
+
+// RecoveryCodeRepositoryMock is a mock implementation of twofactorrepository.RecoveryCodeRepository.
+//
+//	func TestSomethingThatUsesRecoveryCodeRepository(t *testing.T) {
+//
+//		// make and configure a mocked twofactorrepository.RecoveryCodeRepository
+//		mockedRecoveryCodeRepository := &RecoveryCodeRepositoryMock{
+//			ConsumeFunc: func(ctx context.Context, userID uint, code string) (bool, error) {
+//				panic("mock out the Consume method")
+//			},
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteByUserIDFunc: func(ctx context.Context, userID uint) error {
+//				panic("mock out the DeleteByUserID method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.TwoFactorRecoveryCode, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.TwoFactorRecoveryCode, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			ReplaceForUserFunc: func(ctx context.Context, userID uint, codes []string) error {
+//				panic("mock out the ReplaceForUser method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedRecoveryCodeRepository in code that requires twofactorrepository.RecoveryCodeRepository
+//		// and then make assertions.
+//
+//	}
+type RecoveryCodeRepositoryMock struct {
+	// ConsumeFunc mocks the Consume method.
+	ConsumeFunc func(ctx context.Context, userID uint, code string) (bool, error)
+
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error
+
+	// DeleteByUserIDFunc mocks the DeleteByUserID method.
+	DeleteByUserIDFunc func(ctx context.Context, userID uint) error
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.TwoFactorRecoveryCode, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.TwoFactorRecoveryCode, error)
+
+	// ReplaceForUserFunc mocks the ReplaceForUser method.
+	ReplaceForUserFunc func(ctx context.Context, userID uint, codes []string) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.TwoFactorRecoveryCode) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Consume holds details about calls to the Consume method.
+		Consume []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Code is the code argument value.
+			Code string
+		}
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.TwoFactorRecoveryCode
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.TwoFactorRecoveryCode
+		}
+		// DeleteByUserID holds details about calls to the DeleteByUserID method.
+		DeleteByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// ReplaceForUser holds details about calls to the ReplaceForUser method.
+		ReplaceForUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Codes is the codes argument value.
+			Codes []string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.TwoFactorRecoveryCode
+		}
+	}
+	lockConsume        sync.RWMutex
+	lockCount          sync.RWMutex
+	lockCreate         sync.RWMutex
+	lockDelete         sync.RWMutex
+	lockDeleteByUserID sync.RWMutex
+	lockFindAll        sync.RWMutex
+	lockFindByID       sync.RWMutex
+	lockReplaceForUser sync.RWMutex
+	lockUpdate         sync.RWMutex
+}
+
+// Consume calls ConsumeFunc.
+func (mock *RecoveryCodeRepositoryMock) Consume(ctx context.Context, userID uint, code string) (bool, error) {
+	if mock.ConsumeFunc == nil {
+		panic("RecoveryCodeRepositoryMock.ConsumeFunc: method is nil but RecoveryCodeRepository.Consume was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockConsume.Lock()
+	mock.calls.Consume = append(mock.calls.Consume, callInfo)
+	mock.lockConsume.Unlock()
+	return mock.ConsumeFunc(ctx, userID, code)
+}
+
+// ConsumeCalls gets all the calls that were made to Consume.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.ConsumeCalls())
+func (mock *RecoveryCodeRepositoryMock) ConsumeCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+		Code   string
+	}
+	mock.lockConsume.RLock()
+	calls = mock.calls.Consume
+	mock.lockConsume.RUnlock()
+	return calls
+}
+
+// Count calls CountFunc.
+func (mock *RecoveryCodeRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("RecoveryCodeRepositoryMock.CountFunc: method is nil but RecoveryCodeRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.CountCalls())
+func (mock *RecoveryCodeRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *RecoveryCodeRepositoryMock) Create(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+	if mock.CreateFunc == nil {
+		panic("RecoveryCodeRepositoryMock.CreateFunc: method is nil but RecoveryCodeRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.CreateCalls())
+func (mock *RecoveryCodeRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.TwoFactorRecoveryCode
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *RecoveryCodeRepositoryMock) Delete(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+	if mock.DeleteFunc == nil {
+		panic("RecoveryCodeRepositoryMock.DeleteFunc: method is nil but RecoveryCodeRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.DeleteCalls())
+func (mock *RecoveryCodeRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.TwoFactorRecoveryCode
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteByUserID calls DeleteByUserIDFunc.
+func (mock *RecoveryCodeRepositoryMock) DeleteByUserID(ctx context.Context, userID uint) error {
+	if mock.DeleteByUserIDFunc == nil {
+		panic("RecoveryCodeRepositoryMock.DeleteByUserIDFunc: method is nil but RecoveryCodeRepository.DeleteByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteByUserID.Lock()
+	mock.calls.DeleteByUserID = append(mock.calls.DeleteByUserID, callInfo)
+	mock.lockDeleteByUserID.Unlock()
+	return mock.DeleteByUserIDFunc(ctx, userID)
+}
+
+// DeleteByUserIDCalls gets all the calls that were made to DeleteByUserID.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.DeleteByUserIDCalls())
+func (mock *RecoveryCodeRepositoryMock) DeleteByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockDeleteByUserID.RLock()
+	calls = mock.calls.DeleteByUserID
+	mock.lockDeleteByUserID.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *RecoveryCodeRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.TwoFactorRecoveryCode, error) {
+	if mock.FindAllFunc == nil {
+		panic("RecoveryCodeRepositoryMock.FindAllFunc: method is nil but RecoveryCodeRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.FindAllCalls())
+func (mock *RecoveryCodeRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *RecoveryCodeRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.TwoFactorRecoveryCode, error) {
+	if mock.FindByIDFunc == nil {
+		panic("RecoveryCodeRepositoryMock.FindByIDFunc: method is nil but RecoveryCodeRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.FindByIDCalls())
+func (mock *RecoveryCodeRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// ReplaceForUser calls ReplaceForUserFunc.
+func (mock *RecoveryCodeRepositoryMock) ReplaceForUser(ctx context.Context, userID uint, codes []string) error {
+	if mock.ReplaceForUserFunc == nil {
+		panic("RecoveryCodeRepositoryMock.ReplaceForUserFunc: method is nil but RecoveryCodeRepository.ReplaceForUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+		Codes  []string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Codes:  codes,
+	}
+	mock.lockReplaceForUser.Lock()
+	mock.calls.ReplaceForUser = append(mock.calls.ReplaceForUser, callInfo)
+	mock.lockReplaceForUser.Unlock()
+	return mock.ReplaceForUserFunc(ctx, userID, codes)
+}
+
+// ReplaceForUserCalls gets all the calls that were made to ReplaceForUser.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.ReplaceForUserCalls())
+func (mock *RecoveryCodeRepositoryMock) ReplaceForUserCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+	Codes  []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+		Codes  []string
+	}
+	mock.lockReplaceForUser.RLock()
+	calls = mock.calls.ReplaceForUser
+	mock.lockReplaceForUser.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *RecoveryCodeRepositoryMock) Update(ctx context.Context, entity *models.TwoFactorRecoveryCode) error {
+	if mock.UpdateFunc == nil {
+		panic("RecoveryCodeRepositoryMock.UpdateFunc: method is nil but RecoveryCodeRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeRepository.UpdateCalls())
+func (mock *RecoveryCodeRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.TwoFactorRecoveryCode
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.TwoFactorRecoveryCode
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -0,0 +1,100 @@
+// Package repository contains data-access code for the two-factor module.
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+// HashRecoveryCode returns the at-rest form of a recovery code. Codes are
+// compared case-insensitively and ignoring the cosmetic dash/spaces, so the
+// hash is taken over that normalized form.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . RecoveryCodeRepository
+
+// RecoveryCodeRepository defines the interface for 2FA recovery-code operations.
+type RecoveryCodeRepository interface {
+	repository.Repository[models.TwoFactorRecoveryCode]
+	ReplaceForUser(ctx context.Context, userID uint, codes []string) error
+	Consume(ctx context.Context, userID uint, code string) (bool, error)
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+type recoveryCodeRepository struct {
+	repository.BaseRepository[models.TwoFactorRecoveryCode]
+}
+
+// NewRecoveryCodeRepository constructs a RecoveryCodeRepository.
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		BaseRepository: repository.NewBaseRepository[models.TwoFactorRecoveryCode](db),
+	}
+}
+
+// ReplaceForUser discards every recovery code the user has, used or not, and
+// stores the hashes of codes in their place. Run it inside a transaction so a
+// failed insert cannot leave the user with no codes at all.
+func (r *recoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uint, codes []string) error {
+	if err := r.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	rows := make([]models.TwoFactorRecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		rows = append(rows, models.TwoFactorRecoveryCode{
+			UserID:   userID,
+			CodeHash: HashRecoveryCode(code),
+		})
+	}
+
+	start := time.Now()
+	err := r.GetDB(ctx).WithContext(ctx).Create(&rows).Error
+	r.LogSlowWrite(ctx, "ReplaceForUser", time.Since(start))
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to store recovery codes for user id %d", userID), err)
+	}
+	return nil
+}
+
+// Consume marks the user's unused recovery code matching code as used and
+// reports whether one was found. Like user-token redemption it is a single
+// conditional UPDATE, so a code cannot be spent twice by racing requests.
+func (r *recoveryCodeRepository) Consume(ctx context.Context, userID uint, code string) (bool, error) {
+	rows, err := gorm.G[models.TwoFactorRecoveryCode](r.GetDB(ctx)).
+		Where(generated.TwoFactorRecoveryCode.UserID.Eq(userID)).
+		Where(generated.TwoFactorRecoveryCode.CodeHash.Eq(HashRecoveryCode(code))).
+		Where(generated.TwoFactorRecoveryCode.UsedAt.IsNull()).
+		Set(generated.TwoFactorRecoveryCode.UsedAt.Set(time.Now())).
+		Update(ctx)
+	if err != nil {
+		return false, cerrors.NewInternalServerError("failed to consume recovery code", err)
+	}
+	return rows > 0, nil
+}
+
+// DeleteByUserID hard-deletes every recovery code belonging to the user.
+func (r *recoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	_, err := gorm.G[models.TwoFactorRecoveryCode](r.GetDB(ctx)).
+		Where(generated.TwoFactorRecoveryCode.UserID.Eq(userID)).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to delete recovery codes for user id %d", userID), err)
+	}
+	return nil
+}
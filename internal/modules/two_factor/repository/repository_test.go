@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	twofactorrepository "github.com/PhantomX7/athleton/internal/modules/two_factor/repository"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.TwoFactorRecoveryCode{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func TestRecoveryCodeRepositoryReplaceForUserStoresHashesOnly(t *testing.T) {
+	db := setupDB(t)
+	repo := twofactorrepository.NewRecoveryCodeRepository(db)
+	user := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bert")
+
+	require.NoError(t, repo.ReplaceForUser(context.Background(), user.ID, []string{"aaaaa-11111", "bbbbb-22222"}))
+	require.NoError(t, repo.ReplaceForUser(context.Background(), other.ID, []string{"ccccc-33333"}))
+	// Replacing discards the previous batch.
+	require.NoError(t, repo.ReplaceForUser(context.Background(), user.ID, []string{"ddddd-44444"}))
+
+	var rows []models.TwoFactorRecoveryCode
+	require.NoError(t, db.Where("user_id = ?", user.ID).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	require.Equal(t, twofactorrepository.HashRecoveryCode("ddddd-44444"), rows[0].CodeHash)
+
+	var otherCount int64
+	require.NoError(t, db.Model(&models.TwoFactorRecoveryCode{}).Where("user_id = ?", other.ID).Count(&otherCount).Error)
+	require.Equal(t, int64(1), otherCount)
+}
+
+func TestRecoveryCodeRepositoryConsumeIsSingleUseAndScopedToUser(t *testing.T) {
+	db := setupDB(t)
+	repo := twofactorrepository.NewRecoveryCodeRepository(db)
+	user := seedUser(t, db, "alma")
+	other := seedUser(t, db, "bert")
+	require.NoError(t, repo.ReplaceForUser(context.Background(), user.ID, []string{"aaaaa-11111"}))
+
+	ok, err := repo.Consume(context.Background(), other.ID, "aaaaa-11111")
+	require.NoError(t, err)
+	require.False(t, ok, "another user's code must not match")
+
+	// Case and the cosmetic dash do not matter.
+	ok, err = repo.Consume(context.Background(), user.ID, " AAAAA11111 ")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = repo.Consume(context.Background(), user.ID, "aaaaa-11111")
+	require.NoError(t, err)
+	require.False(t, ok, "a used code cannot be spent again")
+}
+
+func TestRecoveryCodeRepositoryDeleteByUserID(t *testing.T) {
+	db := setupDB(t)
+	repo := twofactorrepository.NewRecoveryCodeRepository(db)
+	user := seedUser(t, db, "alma")
+	require.NoError(t, repo.ReplaceForUser(context.Background(), user.ID, []string{"aaaaa-11111", "bbbbb-22222"}))
+
+	require.NoError(t, repo.DeleteByUserID(context.Background(), user.ID))
+
+	var count int64
+	require.NoError(t, db.Model(&models.TwoFactorRecoveryCode{}).Count(&count).Error)
+	require.Zero(t, count)
+}
@@ -0,0 +1,30 @@
+// Package two_factor wires the TOTP two-factor authentication module.
+package two_factor
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/controller"
+	"github.com/PhantomX7/athleton/internal/routes"
+)
+
+type routeRegistrar struct {
+	controller controller.TwoFactorController
+}
+
+// NewRoutes constructs the two-factor route registrar.
+func NewRoutes(controller controller.TwoFactorController) routes.Registrar {
+	return &routeRegistrar{controller: controller}
+}
+
+// RegisterRoutes mounts the two-factor endpoints under /auth/2fa.
+func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	// Verify is the second half of login and is just as guessable a target,
+	// so it takes the same per-IP limiter as /auth/login.
+	publicTwoFactor := ctx.Root.Group("/auth/2fa")
+	publicTwoFactor.POST("/verify", ctx.MW.AuthRateLimiter(), r.controller.Verify)
+
+	privateTwoFactor := ctx.Root.Group("/auth/2fa", ctx.MW.RequireSessionAuth(), ctx.MW.ForbidImpersonation())
+	privateTwoFactor.POST("/setup", r.controller.Setup)
+	privateTwoFactor.POST("/enable", r.controller.Enable)
+	privateTwoFactor.POST("/disable", r.controller.Disable)
+	privateTwoFactor.POST("/recovery-codes", r.controller.RegenerateRecoveryCodes)
+}
@@ -0,0 +1,335 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/service"
+)
+
+// Ensure, that TwoFactorServiceMock does implement service.TwoFactorService.
+// If this is not the case, regenerate this file with moq.
+var _ service.TwoFactorService = &TwoFactorServiceMock{}
+
+// TwoFactorServiceMock is a mock implementation of service.TwoFactorService.
+//
+//	func TestSomethingThatUsesTwoFactorService(t *testing.T) {
+//
+//		// make and configure a mocked service.TwoFactorService
+//		mockedTwoFactorService := &TwoFactorServiceMock{
+//			DisableFunc: func(ctx context.Context, req *dto.TwoFactorDisableRequest) error {
+//				panic("mock out the Disable method")
+//			},
+//			EnableFunc: func(ctx context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+//				panic("mock out the Enable method")
+//			},
+//			RegenerateRecoveryCodesFunc: func(ctx context.Context, req *dto.TwoFactorRecoveryCodesRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+//				panic("mock out the RegenerateRecoveryCodes method")
+//			},
+//			SetupFunc: func(ctx context.Context) (*dto.TwoFactorSetupResponse, error) {
+//				panic("mock out the Setup method")
+//			},
+//			VerifyCodeFunc: func(ctx context.Context, user *models.User, code string) error {
+//				panic("mock out the VerifyCode method")
+//			},
+//			VerifyLoginFunc: func(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error) {
+//				panic("mock out the VerifyLogin method")
+//			},
+//		}
+//
+//		// use mockedTwoFactorService in code that requires service.TwoFactorService
+//		// and then make assertions.
+//
+//	}
+type TwoFactorServiceMock struct {
+	// DisableFunc mocks the Disable method.
+	DisableFunc func(ctx context.Context, req *dto.TwoFactorDisableRequest) error
+
+	// EnableFunc mocks the Enable method.
+	EnableFunc func(ctx context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error)
+
+	// RegenerateRecoveryCodesFunc mocks the RegenerateRecoveryCodes method.
+	RegenerateRecoveryCodesFunc func(ctx context.Context, req *dto.TwoFactorRecoveryCodesRequest) (*dto.TwoFactorRecoveryCodesResponse, error)
+
+	// SetupFunc mocks the Setup method.
+	SetupFunc func(ctx context.Context) (*dto.TwoFactorSetupResponse, error)
+
+	// VerifyCodeFunc mocks the VerifyCode method.
+	VerifyCodeFunc func(ctx context.Context, user *models.User, code string) error
+
+	// VerifyLoginFunc mocks the VerifyLogin method.
+	VerifyLoginFunc func(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Disable holds details about calls to the Disable method.
+		Disable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.TwoFactorDisableRequest
+		}
+		// Enable holds details about calls to the Enable method.
+		Enable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.TwoFactorEnableRequest
+		}
+		// RegenerateRecoveryCodes holds details about calls to the RegenerateRecoveryCodes method.
+		RegenerateRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.TwoFactorRecoveryCodesRequest
+		}
+		// Setup holds details about calls to the Setup method.
+		Setup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// VerifyCode holds details about calls to the VerifyCode method.
+		VerifyCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *models.User
+			// Code is the code argument value.
+			Code string
+		}
+		// VerifyLogin holds details about calls to the VerifyLogin method.
+		VerifyLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *dto.TwoFactorVerifyRequest
+		}
+	}
+	lockDisable                 sync.RWMutex
+	lockEnable                  sync.RWMutex
+	lockRegenerateRecoveryCodes sync.RWMutex
+	lockSetup                   sync.RWMutex
+	lockVerifyCode              sync.RWMutex
+	lockVerifyLogin             sync.RWMutex
+}
+
+// Disable calls DisableFunc.
+func (mock *TwoFactorServiceMock) Disable(ctx context.Context, req *dto.TwoFactorDisableRequest) error {
+	if mock.DisableFunc == nil {
+		panic("TwoFactorServiceMock.DisableFunc: method is nil but TwoFactorService.Disable was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.TwoFactorDisableRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDisable.Lock()
+	mock.calls.Disable = append(mock.calls.Disable, callInfo)
+	mock.lockDisable.Unlock()
+	return mock.DisableFunc(ctx, req)
+}
+
+// DisableCalls gets all the calls that were made to Disable.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.DisableCalls())
+func (mock *TwoFactorServiceMock) DisableCalls() []struct {
+	Ctx context.Context
+	Req *dto.TwoFactorDisableRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.TwoFactorDisableRequest
+	}
+	mock.lockDisable.RLock()
+	calls = mock.calls.Disable
+	mock.lockDisable.RUnlock()
+	return calls
+}
+
+// Enable calls EnableFunc.
+func (mock *TwoFactorServiceMock) Enable(ctx context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	if mock.EnableFunc == nil {
+		panic("TwoFactorServiceMock.EnableFunc: method is nil but TwoFactorService.Enable was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.TwoFactorEnableRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockEnable.Lock()
+	mock.calls.Enable = append(mock.calls.Enable, callInfo)
+	mock.lockEnable.Unlock()
+	return mock.EnableFunc(ctx, req)
+}
+
+// EnableCalls gets all the calls that were made to Enable.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.EnableCalls())
+func (mock *TwoFactorServiceMock) EnableCalls() []struct {
+	Ctx context.Context
+	Req *dto.TwoFactorEnableRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.TwoFactorEnableRequest
+	}
+	mock.lockEnable.RLock()
+	calls = mock.calls.Enable
+	mock.lockEnable.RUnlock()
+	return calls
+}
+
+// RegenerateRecoveryCodes calls RegenerateRecoveryCodesFunc.
+func (mock *TwoFactorServiceMock) RegenerateRecoveryCodes(ctx context.Context, req *dto.TwoFactorRecoveryCodesRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	if mock.RegenerateRecoveryCodesFunc == nil {
+		panic("TwoFactorServiceMock.RegenerateRecoveryCodesFunc: method is nil but TwoFactorService.RegenerateRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.TwoFactorRecoveryCodesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRegenerateRecoveryCodes.Lock()
+	mock.calls.RegenerateRecoveryCodes = append(mock.calls.RegenerateRecoveryCodes, callInfo)
+	mock.lockRegenerateRecoveryCodes.Unlock()
+	return mock.RegenerateRecoveryCodesFunc(ctx, req)
+}
+
+// RegenerateRecoveryCodesCalls gets all the calls that were made to RegenerateRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.RegenerateRecoveryCodesCalls())
+func (mock *TwoFactorServiceMock) RegenerateRecoveryCodesCalls() []struct {
+	Ctx context.Context
+	Req *dto.TwoFactorRecoveryCodesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.TwoFactorRecoveryCodesRequest
+	}
+	mock.lockRegenerateRecoveryCodes.RLock()
+	calls = mock.calls.RegenerateRecoveryCodes
+	mock.lockRegenerateRecoveryCodes.RUnlock()
+	return calls
+}
+
+// Setup calls SetupFunc.
+func (mock *TwoFactorServiceMock) Setup(ctx context.Context) (*dto.TwoFactorSetupResponse, error) {
+	if mock.SetupFunc == nil {
+		panic("TwoFactorServiceMock.SetupFunc: method is nil but TwoFactorService.Setup was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSetup.Lock()
+	mock.calls.Setup = append(mock.calls.Setup, callInfo)
+	mock.lockSetup.Unlock()
+	return mock.SetupFunc(ctx)
+}
+
+// SetupCalls gets all the calls that were made to Setup.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.SetupCalls())
+func (mock *TwoFactorServiceMock) SetupCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockSetup.RLock()
+	calls = mock.calls.Setup
+	mock.lockSetup.RUnlock()
+	return calls
+}
+
+// VerifyCode calls VerifyCodeFunc.
+func (mock *TwoFactorServiceMock) VerifyCode(ctx context.Context, user *models.User, code string) error {
+	if mock.VerifyCodeFunc == nil {
+		panic("TwoFactorServiceMock.VerifyCodeFunc: method is nil but TwoFactorService.VerifyCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		User *models.User
+		Code string
+	}{
+		Ctx:  ctx,
+		User: user,
+		Code: code,
+	}
+	mock.lockVerifyCode.Lock()
+	mock.calls.VerifyCode = append(mock.calls.VerifyCode, callInfo)
+	mock.lockVerifyCode.Unlock()
+	return mock.VerifyCodeFunc(ctx, user, code)
+}
+
+// VerifyCodeCalls gets all the calls that were made to VerifyCode.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.VerifyCodeCalls())
+func (mock *TwoFactorServiceMock) VerifyCodeCalls() []struct {
+	Ctx  context.Context
+	User *models.User
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		User *models.User
+		Code string
+	}
+	mock.lockVerifyCode.RLock()
+	calls = mock.calls.VerifyCode
+	mock.lockVerifyCode.RUnlock()
+	return calls
+}
+
+// VerifyLogin calls VerifyLoginFunc.
+func (mock *TwoFactorServiceMock) VerifyLogin(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error) {
+	if mock.VerifyLoginFunc == nil {
+		panic("TwoFactorServiceMock.VerifyLoginFunc: method is nil but TwoFactorService.VerifyLogin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *dto.TwoFactorVerifyRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyLogin.Lock()
+	mock.calls.VerifyLogin = append(mock.calls.VerifyLogin, callInfo)
+	mock.lockVerifyLogin.Unlock()
+	return mock.VerifyLoginFunc(ctx, req)
+}
+
+// VerifyLoginCalls gets all the calls that were made to VerifyLogin.
+// Check the length with:
+//
+//	len(mockedTwoFactorService.VerifyLoginCalls())
+func (mock *TwoFactorServiceMock) VerifyLoginCalls() []struct {
+	Ctx context.Context
+	Req *dto.TwoFactorVerifyRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *dto.TwoFactorVerifyRequest
+	}
+	mock.lockVerifyLogin.RLock()
+	calls = mock.calls.VerifyLogin
+	mock.lockVerifyLogin.RUnlock()
+	return calls
+}
@@ -0,0 +1,340 @@
+// Package service contains the two-factor module business logic.
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/audit"
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	logRepository "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
+	twofactorrepo "github.com/PhantomX7/athleton/internal/modules/two_factor/repository"
+	userrepo "github.com/PhantomX7/athleton/internal/modules/user/repository"
+	usertokenrepo "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
+	"github.com/PhantomX7/athleton/libs/transaction_manager"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/totp"
+	"github.com/PhantomX7/athleton/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes each enrolment hands out.
+	recoveryCodeCount = 10
+	// codeSkew is how many 30s steps either side of now a TOTP code may be
+	// from, to absorb clock drift between server and phone.
+	codeSkew = 1
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . TwoFactorService
+
+// TwoFactorService defines the interface for two-factor service operations
+type TwoFactorService interface {
+	Setup(ctx context.Context) (*dto.TwoFactorSetupResponse, error)
+	Enable(ctx context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error)
+	Disable(ctx context.Context, req *dto.TwoFactorDisableRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, req *dto.TwoFactorRecoveryCodesRequest) (*dto.TwoFactorRecoveryCodesResponse, error)
+	VerifyLogin(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error)
+	VerifyCode(ctx context.Context, user *models.User, code string) error
+}
+
+type twoFactorService struct {
+	cfg               *config.Config
+	userRepo          userrepo.UserRepository
+	recoveryCodeRepo  twofactorrepo.RecoveryCodeRepository
+	userTokenRepo     usertokenrepo.UserTokenRepository
+	logRepository     logRepository.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	authJWT           *authjwt.AuthJWT
+	txManager         transaction_manager.TransactionManager
+}
+
+// NewTwoFactorService builds the two-factor service from its dependencies.
+func NewTwoFactorService(
+	cfg *config.Config,
+	userRepo userrepo.UserRepository,
+	recoveryCodeRepo twofactorrepo.RecoveryCodeRepository,
+	userTokenRepo usertokenrepo.UserTokenRepository,
+	logRepository logRepository.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
+	authJWT *authjwt.AuthJWT,
+	txManager transaction_manager.TransactionManager,
+) TwoFactorService {
+	return &twoFactorService{
+		cfg:               cfg,
+		userRepo:          userRepo,
+		recoveryCodeRepo:  recoveryCodeRepo,
+		userTokenRepo:     userTokenRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		authJWT:           authJWT,
+		txManager:         txManager,
+	}
+}
+
+// Setup generates a new TOTP secret for the authenticated user and stores it
+// as pending. 2FA stays off until Enable proves the authenticator app holds
+// the secret; calling Setup again simply replaces the pending one.
+func (s *twoFactorService) Setup(ctx context.Context) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTwoFactorEnabled() {
+		return nil, cerrors.NewConflictError("two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to generate two-factor secret", err)
+	}
+
+	user.TwoFactorSecret = secret
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.cfg.App.Name, user.Email, secret),
+	}, nil
+}
+
+// Enable turns 2FA on once code matches the pending secret from Setup, and
+// returns the account's first set of recovery codes.
+func (s *twoFactorService) Enable(ctx context.Context, req *dto.TwoFactorEnableRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTwoFactorEnabled() {
+		return nil, cerrors.NewConflictError("two-factor authentication is already enabled")
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, cerrors.NewBadRequestError("two-factor setup has not been started")
+	}
+
+	step, ok := totp.Validate(user.TwoFactorSecret, req.Code, time.Now(), codeSkew)
+	if !ok || step <= user.TwoFactorLastStep {
+		return nil, cerrors.NewBadRequestError("invalid two-factor code")
+	}
+
+	codes := generateRecoveryCodes()
+
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+		user.TwoFactorEnabledAt = &now
+		user.TwoFactorLastStep = step
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		return s.recoveryCodeRepo.ReplaceForUser(txCtx, user.ID, codes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// RequireTwoFactor reads the enrolment from the cached user.
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Two-factor authentication enabled")
+	s.createLog(ctx, user, models.LogActionEnableTwoFactor, "enabled")
+
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns 2FA off after re-checking both the password and a current
+// code, and discards the secret and every recovery code.
+func (s *twoFactorService) Disable(ctx context.Context, req *dto.TwoFactorDisableRequest) error {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		return cerrors.NewBadRequestError("two-factor authentication is not enabled")
+	}
+
+	if err := s.authJWT.VerifyPassword(ctx, user, req.Password); err != nil {
+		logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Two-factor disable failed - incorrect password")
+		return cerrors.NewBadRequestError("password is incorrect")
+	}
+
+	if err := s.checkCode(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		user.TwoFactorSecret = ""
+		user.TwoFactorEnabledAt = nil
+		user.TwoFactorLastStep = 0
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		return s.recoveryCodeRepo.DeleteByUserID(txCtx, user.ID)
+	})
+	if err != nil {
+		return err
+	}
+	s.authJWT.InvalidateUser(user.ID)
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Two-factor authentication disabled")
+	s.createLog(ctx, user, models.LogActionDisableTwoFactor, "disabled")
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, used or not, with a
+// fresh set. It needs a current code so a stolen session cannot quietly mint
+// itself a permanent second factor.
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, req *dto.TwoFactorRecoveryCodesRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		return nil, cerrors.NewBadRequestError("two-factor authentication is not enabled")
+	}
+
+	if err := s.checkCode(ctx, user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes := generateRecoveryCodes()
+
+	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		return s.recoveryCodeRepo.ReplaceForUser(txCtx, user.ID, codes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Two-factor recovery codes regenerated")
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyLogin completes a password login that was answered with a 2FA
+// challenge. The challenge is consumed before the code is checked, outside
+// any transaction, so it is single-use even when the code is wrong: a
+// guesser gets one attempt per correct password, not one per request.
+// RememberMe is taken from this request: the password step opened no session.
+func (s *twoFactorService) VerifyLogin(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.AuthResponse, error) {
+	challenge, err := s.userTokenRepo.ConsumeByToken(ctx, models.UserTokenPurposeTwoFactorChallenge, req.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, cerrors.ErrNotFound) {
+			return nil, cerrors.NewBadRequestError("invalid or expired two-factor challenge")
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive || !user.IsTwoFactorEnabled() {
+		return nil, cerrors.NewBadRequestError("invalid or expired two-factor challenge")
+	}
+
+	if err := s.checkCode(ctx, user, req.Code); err != nil {
+		audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventLogin, models.SecurityEventOutcomeFailure, uuid.Nil)
+		return nil, err
+	}
+
+	return s.authJWT.CompleteLogin(ctx, user, req.RememberMe)
+}
+
+// VerifyCode checks a TOTP or recovery code for user and burns it, for flows
+// outside this module that accept the second factor as proof of identity
+// (step-up reauthentication).
+func (s *twoFactorService) VerifyCode(ctx context.Context, user *models.User, code string) error {
+	if !user.IsTwoFactorEnabled() {
+		return cerrors.NewBadRequestError("two-factor authentication is not enabled")
+	}
+	return s.checkCode(ctx, user, code)
+}
+
+// checkCode accepts either a TOTP code or an unused recovery code for user.
+// A TOTP code is burned by advancing the stored step, so the same code cannot
+// be replayed within its validity window; a recovery code is burned by
+// marking it used.
+func (s *twoFactorService) checkCode(ctx context.Context, user *models.User, code string) error {
+	code = strings.TrimSpace(code)
+
+	if len(code) == totp.Digits {
+		if step, ok := totp.Validate(user.TwoFactorSecret, code, time.Now(), codeSkew); ok {
+			advanced, err := s.userRepo.AdvanceTwoFactorStep(ctx, user.ID, step)
+			if err != nil {
+				return err
+			}
+			if advanced {
+				user.TwoFactorLastStep = step
+				return nil
+			}
+			logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Warn("Replayed two-factor code rejected")
+		}
+		return cerrors.NewBadRequestError("invalid two-factor code")
+	}
+
+	used, err := s.recoveryCodeRepo.Consume(ctx, user.ID, code)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return cerrors.NewBadRequestError("invalid two-factor code")
+	}
+
+	logger.Ctx(ctx, zap.Uint("user_id", user.ID)).Info("Two-factor recovery code used")
+	return nil
+}
+
+// currentUser loads the authenticated user from ctx.
+func (s *twoFactorService) currentUser(ctx context.Context) (*models.User, error) {
+	values, err := utils.ValuesFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.userRepo.FindByID(ctx, values.UserID)
+}
+
+// generateRecoveryCodes returns recoveryCodeCount random codes formatted as
+// "xxxxx-xxxxx" for readability; the dash is ignored when they are redeemed.
+func generateRecoveryCodes() []string {
+	codes := make([]string, 0, recoveryCodeCount)
+	seen := make(map[string]struct{}, recoveryCodeCount)
+	for len(codes) < recoveryCodeCount {
+		raw := strings.ToLower(rand.Text()[:10])
+		if _, dup := seen[raw]; dup {
+			continue
+		}
+		seen[raw] = struct{}{}
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+	}
+	return codes
+}
+
+// createLog audits a 2FA change on a privileged account; like password
+// changes, changes to the second factor of admin and root accounts are
+// security-relevant enough to keep a trail of.
+func (s *twoFactorService) createLog(ctx context.Context, user *models.User, action models.LogAction, verb string) {
+	if !user.Role.IsAdminType() {
+		return
+	}
+	audit.Record(ctx, s.logRepository, audit.Entry{
+		Action:     action,
+		EntityType: models.LogEntityTypeUser,
+		EntityID:   user.ID,
+		Message:    fmt.Sprintf("%s %s two-factor authentication", user.Name, verb),
+	})
+}
@@ -0,0 +1,282 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/internal/dto"
+	"github.com/PhantomX7/athleton/internal/models"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
+	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
+	twofactormocks "github.com/PhantomX7/athleton/internal/modules/two_factor/repository/mocks"
+	"github.com/PhantomX7/athleton/internal/modules/two_factor/service"
+	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
+	usertokenmocks "github.com/PhantomX7/athleton/internal/modules/user_token/repository/mocks"
+	txmocks "github.com/PhantomX7/athleton/libs/transaction_manager/mocks"
+	"github.com/PhantomX7/athleton/pkg/config"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/logger"
+	"github.com/PhantomX7/athleton/pkg/repository"
+	"github.com/PhantomX7/athleton/pkg/totp"
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func setupLogger(t *testing.T) {
+	t.Helper()
+
+	prev := logger.Log
+	logger.Log = zap.NewNop()
+	t.Cleanup(func() {
+		logger.Log = prev
+	})
+}
+
+func passthroughTx() *txmocks.TransactionManagerMock {
+	return &txmocks.TransactionManagerMock{
+		ExecuteInTransactionFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
+func userContext(userID uint) context.Context {
+	return utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: userID, Role: models.UserRoleUser.ToString()})
+}
+
+func codeAt(t *testing.T, offset int64) string {
+	t.Helper()
+	code, err := totp.CodeAt(testSecret, totp.Step(time.Now())+offset)
+	require.NoError(t, err)
+	return code
+}
+
+func requireAppError(t *testing.T, err error, code int, message string) {
+	t.Helper()
+	var appErr *cerrors.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	require.Equal(t, code, appErr.Code)
+	require.Equal(t, message, appErr.Message)
+}
+
+func newService(userRepo *usermocks.UserRepositoryMock, recoveryRepo *twofactormocks.RecoveryCodeRepositoryMock, userTokenRepo *usertokenmocks.UserTokenRepositoryMock) service.TwoFactorService {
+	cfg := &config.Config{App: config.AppConfig{Name: "Athleton Test"}}
+	return service.NewTwoFactorService(cfg, userRepo, recoveryRepo, userTokenRepo, &logmocks.LogRepositoryMock{}, &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(context.Context, *models.SecurityEvent) error { return nil },
+	}, &authjwt.AuthJWT{}, passthroughTx())
+}
+
+func TestTwoFactorServiceSetupStoresPendingSecret(t *testing.T) {
+	setupLogger(t)
+
+	var saved *models.User
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Email: "alice@example.com"}, nil
+		},
+		UpdateFunc: func(_ context.Context, user *models.User) error {
+			saved = user
+			return nil
+		},
+	}
+
+	res, err := newService(userRepo, nil, nil).Setup(userContext(5))
+
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Secret)
+	require.Contains(t, res.OTPAuthURI, "alice@example.com")
+	require.NotNil(t, saved)
+	require.Equal(t, res.Secret, saved.TwoFactorSecret)
+	require.False(t, saved.IsTwoFactorEnabled(), "setup alone must not turn 2FA on")
+}
+
+func TestTwoFactorServiceSetupRejectsAlreadyEnabled(t *testing.T) {
+	setupLogger(t)
+
+	enabledAt := time.Now()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, TwoFactorSecret: testSecret, TwoFactorEnabledAt: &enabledAt}, nil
+		},
+	}
+
+	_, err := newService(userRepo, nil, nil).Setup(userContext(5))
+
+	requireAppError(t, err, http.StatusConflict, "two-factor authentication is already enabled")
+}
+
+func TestTwoFactorServiceEnableValidatesCodeAndIssuesRecoveryCodes(t *testing.T) {
+	setupLogger(t)
+
+	var saved *models.User
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Role: models.UserRoleUser, TwoFactorSecret: testSecret}, nil
+		},
+		UpdateFunc: func(_ context.Context, user *models.User) error {
+			saved = user
+			return nil
+		},
+	}
+	var stored []string
+	recoveryRepo := &twofactormocks.RecoveryCodeRepositoryMock{
+		ReplaceForUserFunc: func(_ context.Context, userID uint, codes []string) error {
+			require.Equal(t, uint(5), userID)
+			stored = codes
+			return nil
+		},
+	}
+	svc := newService(userRepo, recoveryRepo, nil)
+
+	_, err := svc.Enable(userContext(5), &dto.TwoFactorEnableRequest{Code: "000000"})
+	requireAppError(t, err, http.StatusBadRequest, "invalid two-factor code")
+	require.Nil(t, saved)
+
+	res, err := svc.Enable(userContext(5), &dto.TwoFactorEnableRequest{Code: codeAt(t, 0)})
+	require.NoError(t, err)
+	require.Len(t, res.RecoveryCodes, 10)
+	require.Equal(t, res.RecoveryCodes, stored)
+	require.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, res.RecoveryCodes[0])
+	require.True(t, saved.IsTwoFactorEnabled())
+	require.Equal(t, totp.Step(time.Now()), saved.TwoFactorLastStep)
+}
+
+func TestTwoFactorServiceEnableRequiresSetup(t *testing.T) {
+	setupLogger(t)
+
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		},
+	}
+
+	_, err := newService(userRepo, nil, nil).Enable(userContext(5), &dto.TwoFactorEnableRequest{Code: "123456"})
+
+	requireAppError(t, err, http.StatusBadRequest, "two-factor setup has not been started")
+}
+
+func TestTwoFactorServiceDisableRequiresPassword(t *testing.T) {
+	setupLogger(t)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	enabledAt := time.Now()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Password: string(hashed), TwoFactorSecret: testSecret, TwoFactorEnabledAt: &enabledAt}, nil
+		},
+	}
+
+	err = newService(userRepo, nil, nil).Disable(userContext(5), &dto.TwoFactorDisableRequest{Password: "wrong-pass", Code: codeAt(t, 0)})
+
+	requireAppError(t, err, http.StatusBadRequest, "password is incorrect")
+	require.Empty(t, userRepo.AdvanceTwoFactorStepCalls(), "the code must not be burned by a failed password check")
+}
+
+func TestTwoFactorServiceVerifyLoginRejectsUnknownChallenge(t *testing.T) {
+	setupLogger(t)
+
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(_ context.Context, purpose models.UserTokenPurpose, _ string) (*models.UserToken, error) {
+			require.Equal(t, models.UserTokenPurposeTwoFactorChallenge, purpose)
+			return nil, cerrors.NewNotFoundError("invalid or expired token")
+		},
+	}
+
+	_, err := newService(&usermocks.UserRepositoryMock{}, nil, userTokenRepo).VerifyLogin(context.Background(), &dto.TwoFactorVerifyRequest{
+		ChallengeToken: "stale",
+		Code:           "123456",
+	})
+
+	requireAppError(t, err, http.StatusBadRequest, "invalid or expired two-factor challenge")
+}
+
+func TestTwoFactorServiceVerifyLoginRejectsReplayedCode(t *testing.T) {
+	setupLogger(t)
+
+	enabledAt := time.Now()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, IsActive: true, TwoFactorSecret: testSecret, TwoFactorEnabledAt: &enabledAt}, nil
+		},
+		// The step has already been accepted once.
+		AdvanceTwoFactorStepFunc: func(context.Context, uint, int64) (bool, error) {
+			return false, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(context.Context, models.UserTokenPurpose, string) (*models.UserToken, error) {
+			return &models.UserToken{UserID: 5}, nil
+		},
+	}
+
+	_, err := newService(userRepo, nil, userTokenRepo).VerifyLogin(context.Background(), &dto.TwoFactorVerifyRequest{
+		ChallengeToken: "challenge",
+		Code:           codeAt(t, 0),
+	})
+
+	requireAppError(t, err, http.StatusBadRequest, "invalid two-factor code")
+	require.Len(t, userRepo.AdvanceTwoFactorStepCalls(), 1)
+}
+
+func TestTwoFactorServiceVerifyLoginRejectsUnknownRecoveryCode(t *testing.T) {
+	setupLogger(t)
+
+	enabledAt := time.Now()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, IsActive: true, TwoFactorSecret: testSecret, TwoFactorEnabledAt: &enabledAt}, nil
+		},
+	}
+	recoveryRepo := &twofactormocks.RecoveryCodeRepositoryMock{
+		ConsumeFunc: func(_ context.Context, userID uint, code string) (bool, error) {
+			require.Equal(t, uint(5), userID)
+			require.Equal(t, "abcde-fghij", code)
+			return false, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(context.Context, models.UserTokenPurpose, string) (*models.UserToken, error) {
+			return &models.UserToken{UserID: 5}, nil
+		},
+	}
+
+	_, err := newService(userRepo, recoveryRepo, userTokenRepo).VerifyLogin(context.Background(), &dto.TwoFactorVerifyRequest{
+		ChallengeToken: "challenge",
+		Code:           " abcde-fghij ",
+	})
+
+	requireAppError(t, err, http.StatusBadRequest, "invalid two-factor code")
+}
+
+func TestTwoFactorServiceVerifyLoginRejectsInactiveUser(t *testing.T) {
+	setupLogger(t)
+
+	enabledAt := time.Now()
+	userRepo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, IsActive: false, TwoFactorSecret: testSecret, TwoFactorEnabledAt: &enabledAt}, nil
+		},
+	}
+	userTokenRepo := &usertokenmocks.UserTokenRepositoryMock{
+		ConsumeByTokenFunc: func(context.Context, models.UserTokenPurpose, string) (*models.UserToken, error) {
+			return &models.UserToken{UserID: 5}, nil
+		},
+	}
+
+	_, err := newService(userRepo, nil, userTokenRepo).VerifyLogin(context.Background(), &dto.TwoFactorVerifyRequest{
+		ChallengeToken: "challenge",
+		Code:           codeAt(t, 0),
+	})
+
+	requireAppError(t, err, http.StatusBadRequest, "invalid or expired two-factor challenge")
+}
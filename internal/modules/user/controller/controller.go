@@ -64,6 +64,7 @@ type UserController interface {
 	FindByID(ctx *gin.Context)
 	AssignAdminRole(ctx *gin.Context)
 	ChangePassword(ctx *gin.Context)
+	Unlock(ctx *gin.Context)
 	Delete(ctx *gin.Context)
 }
 
@@ -109,7 +110,7 @@ func (c *userController) Index(ctx *gin.Context) {
 }
 
 // @Summary		Create an admin user
-// @Description	Create a new admin account with an assigned admin role; the account must rotate its password on first login
+// @Description	Create a new admin account with one or more admin roles; the account must rotate its password on first login
 // @Tags			user
 // @Accept			json
 // @Produce		json
@@ -192,10 +193,10 @@ func (c *userController) FindByID(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("User found successfully", user))
 }
 
-// AssignAdminRole handles assigning an admin role to a user
+// AssignAdminRole handles changing a user's admin roles
 //
-//	@Summary		Assign admin role
-//	@Description	Assign an admin role to a user (changes user role to admin)
+//	@Summary		Assign admin roles
+//	@Description	Add, remove or set a user's admin roles (changes user role to admin); an admin must keep at least one role
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
@@ -263,6 +264,34 @@ func (c *userController) ChangePassword(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("Password changed successfully", nil))
 }
 
+// Unlock handles lifting a login lockout
+//
+//	@Summary		Unlock a user account
+//	@Description	Clear the failed-login counter and lift any lockout on an account
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		uint	true	"User ID"
+//	@Success		200	{object}	response.Response{data=dto.UserResponse}
+//	@Failure		403	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/admin/user/{id}/unlock [post]
+func (c *userController) Unlock(ctx *gin.Context) {
+	userID, ok := ginx.ParseUintParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	user, err := c.userService.Unlock(ctx.Request.Context(), userID)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.BuildResponseSuccess("User unlocked successfully", user.ToResponse()))
+}
+
 // Delete handles soft-deleting a user account
 //
 //	@Summary		Delete a user
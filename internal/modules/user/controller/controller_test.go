@@ -75,7 +75,7 @@ func TestUserControllerCreateReturnsCreated(t *testing.T) {
 		CreateFunc: func(ctx context.Context, req *dto.AdminUserCreateRequest) (*models.User, error) {
 			require.NotNil(t, ctx)
 			require.Equal(t, "new-admin", req.Username)
-			require.Equal(t, uint(3), req.AdminRoleID)
+			require.Equal(t, []uint{3, 4}, req.AdminRoleIDs)
 			return &models.User{
 				ID:       9,
 				Username: "new-admin",
@@ -91,7 +91,7 @@ func TestUserControllerCreateReturnsCreated(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
 	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/user", bytes.NewBufferString(
-		`{"username":"new-admin","name":"New Admin","email":"new.admin@test.local","phone":"083","password":"initial-pass-123","admin_role_id":3}`))
+		`{"username":"new-admin","name":"New Admin","email":"new.admin@test.local","phone":"083","password":"initial-pass-123","admin_role_ids":[3,4]}`))
 	ctx.Request.Header.Set("Content-Type", "application/json")
 
 	ctrl.Create(ctx)
@@ -360,7 +360,7 @@ func TestUserControllerAssignAdminRolePropagatesServiceError(t *testing.T) {
 	ctrl := controller.NewUserController(svc)
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
-	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/user/5/admin-role", bytes.NewBufferString(`{"admin_role_id":3}`))
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/user/5/admin-role", bytes.NewBufferString(`{"mode":"add","admin_role_ids":[3]}`))
 	ctx.Request.Header.Set("Content-Type", "application/json")
 	ctx.Params = gin.Params{{Key: "id", Value: "5"}}
 
@@ -411,3 +411,27 @@ func TestUserControllerIndexPropagatesServiceError(t *testing.T) {
 	require.Len(t, ctx.Errors, 1)
 	require.ErrorIs(t, ctx.Errors[0].Err, expectedErr)
 }
+
+func TestUserControllerUnlockReturnsSuccessResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &userservicemocks.UserServiceMock{
+		UnlockFunc: func(_ context.Context, userID uint) (*models.User, error) {
+			require.Equal(t, uint(6), userID)
+			return &models.User{ID: 6, Username: "locked", Role: models.UserRoleUser}, nil
+		},
+	}
+
+	ctrl := controller.NewUserController(svc)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/admin/user/6/unlock", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "6"}}
+
+	ctrl.Unlock(ctx)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "User unlocked successfully", body["message"])
+}
@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/PhantomX7/athleton/internal/models"
 	userrepository "github.com/PhantomX7/athleton/internal/modules/user/repository"
@@ -23,6 +24,12 @@ var _ userrepository.UserRepository = &UserRepositoryMock{}
 //
 //		// make and configure a mocked userrepository.UserRepository
 //		mockedUserRepository := &UserRepositoryMock{
+//			AdvanceTwoFactorStepFunc: func(ctx context.Context, id uint, step int64) (bool, error) {
+//				panic("mock out the AdvanceTwoFactorStep method")
+//			},
+//			ClearFailedLoginsFunc: func(ctx context.Context, id uint) error {
+//				panic("mock out the ClearFailedLogins method")
+//			},
 //			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
 //				panic("mock out the Count method")
 //			},
@@ -32,6 +39,9 @@ var _ userrepository.UserRepository = &UserRepositoryMock{}
 //			DeleteFunc: func(ctx context.Context, entity *models.User) error {
 //				panic("mock out the Delete method")
 //			},
+//			DeletePersonalDataHistoryFunc: func(ctx context.Context, id uint) error {
+//				panic("mock out the DeletePersonalDataHistory method")
+//			},
 //			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.User, error) {
 //				panic("mock out the FindAll method")
 //			},
@@ -47,9 +57,24 @@ var _ userrepository.UserRepository = &UserRepositoryMock{}
 //			FindByUsernameFunc: func(ctx context.Context, username string) (*models.User, error) {
 //				panic("mock out the FindByUsername method")
 //			},
+//			FindPersonalDataHistoryFunc: func(ctx context.Context, id uint) ([]string, error) {
+//				panic("mock out the FindPersonalDataHistory method")
+//			},
+//			LockUntilFunc: func(ctx context.Context, id uint, until time.Time) error {
+//				panic("mock out the LockUntil method")
+//			},
+//			RecordFailedLoginFunc: func(ctx context.Context, id uint, since time.Time) (int, error) {
+//				panic("mock out the RecordFailedLogin method")
+//			},
+//			SetAdminRolesFunc: func(ctx context.Context, id uint, roleIDs []uint) error {
+//				panic("mock out the SetAdminRoles method")
+//			},
 //			UpdateFunc: func(ctx context.Context, entity *models.User) error {
 //				panic("mock out the Update method")
 //			},
+//			UpdatePasswordHashFunc: func(ctx context.Context, id uint, hash string) error {
+//				panic("mock out the UpdatePasswordHash method")
+//			},
 //		}
 //
 //		// use mockedUserRepository in code that requires userrepository.UserRepository
@@ -57,6 +82,12 @@ var _ userrepository.UserRepository = &UserRepositoryMock{}
 //
 //	}
 type UserRepositoryMock struct {
+	// AdvanceTwoFactorStepFunc mocks the AdvanceTwoFactorStep method.
+	AdvanceTwoFactorStepFunc func(ctx context.Context, id uint, step int64) (bool, error)
+
+	// ClearFailedLoginsFunc mocks the ClearFailedLogins method.
+	ClearFailedLoginsFunc func(ctx context.Context, id uint) error
+
 	// CountFunc mocks the Count method.
 	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
 
@@ -66,6 +97,9 @@ type UserRepositoryMock struct {
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, entity *models.User) error
 
+	// DeletePersonalDataHistoryFunc mocks the DeletePersonalDataHistory method.
+	DeletePersonalDataHistoryFunc func(ctx context.Context, id uint) error
+
 	// FindAllFunc mocks the FindAll method.
 	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.User, error)
 
@@ -81,11 +115,42 @@ type UserRepositoryMock struct {
 	// FindByUsernameFunc mocks the FindByUsername method.
 	FindByUsernameFunc func(ctx context.Context, username string) (*models.User, error)
 
+	// FindPersonalDataHistoryFunc mocks the FindPersonalDataHistory method.
+	FindPersonalDataHistoryFunc func(ctx context.Context, id uint) ([]string, error)
+
+	// LockUntilFunc mocks the LockUntil method.
+	LockUntilFunc func(ctx context.Context, id uint, until time.Time) error
+
+	// RecordFailedLoginFunc mocks the RecordFailedLogin method.
+	RecordFailedLoginFunc func(ctx context.Context, id uint, since time.Time) (int, error)
+
+	// SetAdminRolesFunc mocks the SetAdminRoles method.
+	SetAdminRolesFunc func(ctx context.Context, id uint, roleIDs []uint) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, entity *models.User) error
 
+	// UpdatePasswordHashFunc mocks the UpdatePasswordHash method.
+	UpdatePasswordHashFunc func(ctx context.Context, id uint, hash string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AdvanceTwoFactorStep holds details about calls to the AdvanceTwoFactorStep method.
+		AdvanceTwoFactorStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Step is the step argument value.
+			Step int64
+		}
+		// ClearFailedLogins holds details about calls to the ClearFailedLogins method.
+		ClearFailedLogins []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+		}
 		// Count holds details about calls to the Count method.
 		Count []struct {
 			// Ctx is the ctx argument value.
@@ -107,6 +172,13 @@ type UserRepositoryMock struct {
 			// Entity is the entity argument value.
 			Entity *models.User
 		}
+		// DeletePersonalDataHistory holds details about calls to the DeletePersonalDataHistory method.
+		DeletePersonalDataHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+		}
 		// FindAll holds details about calls to the FindAll method.
 		FindAll []struct {
 			// Ctx is the ctx argument value.
@@ -144,6 +216,40 @@ type UserRepositoryMock struct {
 			// Username is the username argument value.
 			Username string
 		}
+		// FindPersonalDataHistory holds details about calls to the FindPersonalDataHistory method.
+		FindPersonalDataHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+		}
+		// LockUntil holds details about calls to the LockUntil method.
+		LockUntil []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Until is the until argument value.
+			Until time.Time
+		}
+		// RecordFailedLogin holds details about calls to the RecordFailedLogin method.
+		RecordFailedLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// SetAdminRoles holds details about calls to the SetAdminRoles method.
+		SetAdminRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// RoleIDs is the roleIDs argument value.
+			RoleIDs []uint
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -151,16 +257,109 @@ type UserRepositoryMock struct {
 			// Entity is the entity argument value.
 			Entity *models.User
 		}
+		// UpdatePasswordHash holds details about calls to the UpdatePasswordHash method.
+		UpdatePasswordHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockAdvanceTwoFactorStep      sync.RWMutex
+	lockClearFailedLogins         sync.RWMutex
+	lockCount                     sync.RWMutex
+	lockCreate                    sync.RWMutex
+	lockDelete                    sync.RWMutex
+	lockDeletePersonalDataHistory sync.RWMutex
+	lockFindAll                   sync.RWMutex
+	lockFindByEmail               sync.RWMutex
+	lockFindByID                  sync.RWMutex
+	lockFindByIDForUpdate         sync.RWMutex
+	lockFindByUsername            sync.RWMutex
+	lockFindPersonalDataHistory   sync.RWMutex
+	lockLockUntil                 sync.RWMutex
+	lockRecordFailedLogin         sync.RWMutex
+	lockSetAdminRoles             sync.RWMutex
+	lockUpdate                    sync.RWMutex
+	lockUpdatePasswordHash        sync.RWMutex
+}
+
+// AdvanceTwoFactorStep calls AdvanceTwoFactorStepFunc.
+func (mock *UserRepositoryMock) AdvanceTwoFactorStep(ctx context.Context, id uint, step int64) (bool, error) {
+	if mock.AdvanceTwoFactorStepFunc == nil {
+		panic("UserRepositoryMock.AdvanceTwoFactorStepFunc: method is nil but UserRepository.AdvanceTwoFactorStep was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   uint
+		Step int64
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Step: step,
+	}
+	mock.lockAdvanceTwoFactorStep.Lock()
+	mock.calls.AdvanceTwoFactorStep = append(mock.calls.AdvanceTwoFactorStep, callInfo)
+	mock.lockAdvanceTwoFactorStep.Unlock()
+	return mock.AdvanceTwoFactorStepFunc(ctx, id, step)
+}
+
+// AdvanceTwoFactorStepCalls gets all the calls that were made to AdvanceTwoFactorStep.
+// Check the length with:
+//
+//	len(mockedUserRepository.AdvanceTwoFactorStepCalls())
+func (mock *UserRepositoryMock) AdvanceTwoFactorStepCalls() []struct {
+	Ctx  context.Context
+	ID   uint
+	Step int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   uint
+		Step int64
+	}
+	mock.lockAdvanceTwoFactorStep.RLock()
+	calls = mock.calls.AdvanceTwoFactorStep
+	mock.lockAdvanceTwoFactorStep.RUnlock()
+	return calls
+}
+
+// ClearFailedLogins calls ClearFailedLoginsFunc.
+func (mock *UserRepositoryMock) ClearFailedLogins(ctx context.Context, id uint) error {
+	if mock.ClearFailedLoginsFunc == nil {
+		panic("UserRepositoryMock.ClearFailedLoginsFunc: method is nil but UserRepository.ClearFailedLogins was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uint
+	}{
+		Ctx: ctx,
+		ID:  id,
 	}
-	lockCount             sync.RWMutex
-	lockCreate            sync.RWMutex
-	lockDelete            sync.RWMutex
-	lockFindAll           sync.RWMutex
-	lockFindByEmail       sync.RWMutex
-	lockFindByID          sync.RWMutex
-	lockFindByIDForUpdate sync.RWMutex
-	lockFindByUsername    sync.RWMutex
-	lockUpdate            sync.RWMutex
+	mock.lockClearFailedLogins.Lock()
+	mock.calls.ClearFailedLogins = append(mock.calls.ClearFailedLogins, callInfo)
+	mock.lockClearFailedLogins.Unlock()
+	return mock.ClearFailedLoginsFunc(ctx, id)
+}
+
+// ClearFailedLoginsCalls gets all the calls that were made to ClearFailedLogins.
+// Check the length with:
+//
+//	len(mockedUserRepository.ClearFailedLoginsCalls())
+func (mock *UserRepositoryMock) ClearFailedLoginsCalls() []struct {
+	Ctx context.Context
+	ID  uint
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uint
+	}
+	mock.lockClearFailedLogins.RLock()
+	calls = mock.calls.ClearFailedLogins
+	mock.lockClearFailedLogins.RUnlock()
+	return calls
 }
 
 // Count calls CountFunc.
@@ -271,6 +470,42 @@ func (mock *UserRepositoryMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DeletePersonalDataHistory calls DeletePersonalDataHistoryFunc.
+func (mock *UserRepositoryMock) DeletePersonalDataHistory(ctx context.Context, id uint) error {
+	if mock.DeletePersonalDataHistoryFunc == nil {
+		panic("UserRepositoryMock.DeletePersonalDataHistoryFunc: method is nil but UserRepository.DeletePersonalDataHistory was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uint
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeletePersonalDataHistory.Lock()
+	mock.calls.DeletePersonalDataHistory = append(mock.calls.DeletePersonalDataHistory, callInfo)
+	mock.lockDeletePersonalDataHistory.Unlock()
+	return mock.DeletePersonalDataHistoryFunc(ctx, id)
+}
+
+// DeletePersonalDataHistoryCalls gets all the calls that were made to DeletePersonalDataHistory.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeletePersonalDataHistoryCalls())
+func (mock *UserRepositoryMock) DeletePersonalDataHistoryCalls() []struct {
+	Ctx context.Context
+	ID  uint
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uint
+	}
+	mock.lockDeletePersonalDataHistory.RLock()
+	calls = mock.calls.DeletePersonalDataHistory
+	mock.lockDeletePersonalDataHistory.RUnlock()
+	return calls
+}
+
 // FindAll calls FindAllFunc.
 func (mock *UserRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.User, error) {
 	if mock.FindAllFunc == nil {
@@ -455,6 +690,162 @@ func (mock *UserRepositoryMock) FindByUsernameCalls() []struct {
 	return calls
 }
 
+// FindPersonalDataHistory calls FindPersonalDataHistoryFunc.
+func (mock *UserRepositoryMock) FindPersonalDataHistory(ctx context.Context, id uint) ([]string, error) {
+	if mock.FindPersonalDataHistoryFunc == nil {
+		panic("UserRepositoryMock.FindPersonalDataHistoryFunc: method is nil but UserRepository.FindPersonalDataHistory was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uint
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockFindPersonalDataHistory.Lock()
+	mock.calls.FindPersonalDataHistory = append(mock.calls.FindPersonalDataHistory, callInfo)
+	mock.lockFindPersonalDataHistory.Unlock()
+	return mock.FindPersonalDataHistoryFunc(ctx, id)
+}
+
+// FindPersonalDataHistoryCalls gets all the calls that were made to FindPersonalDataHistory.
+// Check the length with:
+//
+//	len(mockedUserRepository.FindPersonalDataHistoryCalls())
+func (mock *UserRepositoryMock) FindPersonalDataHistoryCalls() []struct {
+	Ctx context.Context
+	ID  uint
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uint
+	}
+	mock.lockFindPersonalDataHistory.RLock()
+	calls = mock.calls.FindPersonalDataHistory
+	mock.lockFindPersonalDataHistory.RUnlock()
+	return calls
+}
+
+// LockUntil calls LockUntilFunc.
+func (mock *UserRepositoryMock) LockUntil(ctx context.Context, id uint, until time.Time) error {
+	if mock.LockUntilFunc == nil {
+		panic("UserRepositoryMock.LockUntilFunc: method is nil but UserRepository.LockUntil was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    uint
+		Until time.Time
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Until: until,
+	}
+	mock.lockLockUntil.Lock()
+	mock.calls.LockUntil = append(mock.calls.LockUntil, callInfo)
+	mock.lockLockUntil.Unlock()
+	return mock.LockUntilFunc(ctx, id, until)
+}
+
+// LockUntilCalls gets all the calls that were made to LockUntil.
+// Check the length with:
+//
+//	len(mockedUserRepository.LockUntilCalls())
+func (mock *UserRepositoryMock) LockUntilCalls() []struct {
+	Ctx   context.Context
+	ID    uint
+	Until time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    uint
+		Until time.Time
+	}
+	mock.lockLockUntil.RLock()
+	calls = mock.calls.LockUntil
+	mock.lockLockUntil.RUnlock()
+	return calls
+}
+
+// RecordFailedLogin calls RecordFailedLoginFunc.
+func (mock *UserRepositoryMock) RecordFailedLogin(ctx context.Context, id uint, since time.Time) (int, error) {
+	if mock.RecordFailedLoginFunc == nil {
+		panic("UserRepositoryMock.RecordFailedLoginFunc: method is nil but UserRepository.RecordFailedLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    uint
+		Since time.Time
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Since: since,
+	}
+	mock.lockRecordFailedLogin.Lock()
+	mock.calls.RecordFailedLogin = append(mock.calls.RecordFailedLogin, callInfo)
+	mock.lockRecordFailedLogin.Unlock()
+	return mock.RecordFailedLoginFunc(ctx, id, since)
+}
+
+// RecordFailedLoginCalls gets all the calls that were made to RecordFailedLogin.
+// Check the length with:
+//
+//	len(mockedUserRepository.RecordFailedLoginCalls())
+func (mock *UserRepositoryMock) RecordFailedLoginCalls() []struct {
+	Ctx   context.Context
+	ID    uint
+	Since time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    uint
+		Since time.Time
+	}
+	mock.lockRecordFailedLogin.RLock()
+	calls = mock.calls.RecordFailedLogin
+	mock.lockRecordFailedLogin.RUnlock()
+	return calls
+}
+
+// SetAdminRoles calls SetAdminRolesFunc.
+func (mock *UserRepositoryMock) SetAdminRoles(ctx context.Context, id uint, roleIDs []uint) error {
+	if mock.SetAdminRolesFunc == nil {
+		panic("UserRepositoryMock.SetAdminRolesFunc: method is nil but UserRepository.SetAdminRoles was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      uint
+		RoleIDs []uint
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		RoleIDs: roleIDs,
+	}
+	mock.lockSetAdminRoles.Lock()
+	mock.calls.SetAdminRoles = append(mock.calls.SetAdminRoles, callInfo)
+	mock.lockSetAdminRoles.Unlock()
+	return mock.SetAdminRolesFunc(ctx, id, roleIDs)
+}
+
+// SetAdminRolesCalls gets all the calls that were made to SetAdminRoles.
+// Check the length with:
+//
+//	len(mockedUserRepository.SetAdminRolesCalls())
+func (mock *UserRepositoryMock) SetAdminRolesCalls() []struct {
+	Ctx     context.Context
+	ID      uint
+	RoleIDs []uint
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      uint
+		RoleIDs []uint
+	}
+	mock.lockSetAdminRoles.RLock()
+	calls = mock.calls.SetAdminRoles
+	mock.lockSetAdminRoles.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *UserRepositoryMock) Update(ctx context.Context, entity *models.User) error {
 	if mock.UpdateFunc == nil {
@@ -490,3 +881,43 @@ func (mock *UserRepositoryMock) UpdateCalls() []struct {
 	mock.lockUpdate.RUnlock()
 	return calls
 }
+
+// UpdatePasswordHash calls UpdatePasswordHashFunc.
+func (mock *UserRepositoryMock) UpdatePasswordHash(ctx context.Context, id uint, hash string) error {
+	if mock.UpdatePasswordHashFunc == nil {
+		panic("UserRepositoryMock.UpdatePasswordHashFunc: method is nil but UserRepository.UpdatePasswordHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   uint
+		Hash string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Hash: hash,
+	}
+	mock.lockUpdatePasswordHash.Lock()
+	mock.calls.UpdatePasswordHash = append(mock.calls.UpdatePasswordHash, callInfo)
+	mock.lockUpdatePasswordHash.Unlock()
+	return mock.UpdatePasswordHashFunc(ctx, id, hash)
+}
+
+// UpdatePasswordHashCalls gets all the calls that were made to UpdatePasswordHash.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdatePasswordHashCalls())
+func (mock *UserRepositoryMock) UpdatePasswordHashCalls() []struct {
+	Ctx  context.Context
+	ID   uint
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   uint
+		Hash string
+	}
+	mock.lockUpdatePasswordHash.RLock()
+	calls = mock.calls.UpdatePasswordHash
+	mock.lockUpdatePasswordHash.RUnlock()
+	return calls
+}
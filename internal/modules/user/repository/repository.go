@@ -11,6 +11,7 @@ import (
 	"github.com/PhantomX7/athleton/internal/generated"
 	"github.com/PhantomX7/athleton/internal/models"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/pagination"
 	"github.com/PhantomX7/athleton/pkg/repository"
 
 	"gorm.io/gorm"
@@ -25,6 +26,14 @@ type UserRepository interface {
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindByIDForUpdate(ctx context.Context, id uint) (*models.User, error)
+	AdvanceTwoFactorStep(ctx context.Context, id uint, step int64) (bool, error)
+	RecordFailedLogin(ctx context.Context, id uint, since time.Time) (int, error)
+	LockUntil(ctx context.Context, id uint, until time.Time) error
+	ClearFailedLogins(ctx context.Context, id uint) error
+	UpdatePasswordHash(ctx context.Context, id uint, hash string) error
+	SetAdminRoles(ctx context.Context, id uint, roleIDs []uint) error
+	FindPersonalDataHistory(ctx context.Context, id uint) ([]string, error)
+	DeletePersonalDataHistory(ctx context.Context, id uint) error
 }
 
 type userRepository struct {
@@ -38,12 +47,118 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	}
 }
 
+// FindByID loads the user with their admin roles, plus any extra preloads.
+// Every single-user lookup loads the roles: permission checks and issued
+// tokens need the full set, and a user without them would look roleless.
+func (r *userRepository) FindByID(ctx context.Context, id uint, preloads ...repository.Association) (*models.User, error) {
+	return r.BaseRepository.FindByID(ctx, id, append([]repository.Association{generated.User.AdminRoles}, preloads...)...)
+}
+
+// FindAll runs the pagination like the base repository and loads each
+// user's admin roles, so list responses show them too.
+func (r *userRepository) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.User, error) {
+	users := make([]*models.User, 0)
+	start := time.Now()
+
+	err := r.GetDB(ctx).WithContext(ctx).
+		Scopes(pg.Apply).
+		Preload(generated.User.AdminRoles.Name()).
+		Find(&users).Error
+
+	r.LogSlowRead(ctx, "FindAll", time.Since(start))
+
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to find user records", err)
+	}
+	return users, nil
+}
+
+// Create inserts the user and records their personal data in its history.
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	if err := r.BaseRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	return r.recordPersonalData(ctx, user)
+}
+
+// Update saves the user row only. Admin roles are changed through
+// SetAdminRoles, so a loaded AdminRoles slice is never written back. Personal
+// values the row now holds are added to its history.
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	start := time.Now()
+
+	err := r.GetDB(ctx).WithContext(ctx).Omit(clause.Associations).Save(user).Error
+
+	r.LogSlowWrite(ctx, "Update", time.Since(start))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return cerrors.NewConflictError("user already exists")
+		}
+		return cerrors.NewInternalServerError("failed to update user record", err)
+	}
+	return r.recordPersonalData(ctx, user)
+}
+
+// recordPersonalData adds the user's current personal values to their
+// history; values already in it are left as they are. Every path that sets a
+// name, username, email, business name or phone goes through Create or
+// Update, so the history holds everything an audit message may have quoted.
+func (r *userRepository) recordPersonalData(ctx context.Context, user *models.User) error {
+	values := user.PersonalData()
+	if len(values) == 0 {
+		return nil
+	}
+
+	rows := make([]models.PersonalDataHistory, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, models.PersonalDataHistory{UserID: user.ID, Value: v})
+	}
+	err := r.GetDB(ctx).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rows).Error
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to record personal data history for user id %d", user.ID), err)
+	}
+	return nil
+}
+
+// FindPersonalDataHistory returns every personal value the user has held,
+// current ones included, oldest first.
+func (r *userRepository) FindPersonalDataHistory(ctx context.Context, id uint) ([]string, error) {
+	rows, err := gorm.G[models.PersonalDataHistory](r.GetDB(ctx)).
+		Where(generated.PersonalDataHistory.UserID.Eq(id)).
+		Order(generated.PersonalDataHistory.ID.Asc()).
+		Find(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError(fmt.Sprintf("failed to find personal data history for user id %d", id), err)
+	}
+
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, row.Value)
+	}
+	return values, nil
+}
+
+// DeletePersonalDataHistory erases the user's personal data history.
+func (r *userRepository) DeletePersonalDataHistory(ctx context.Context, id uint) error {
+	_, err := gorm.G[models.PersonalDataHistory](r.GetDB(ctx)).
+		Where(generated.PersonalDataHistory.UserID.Eq(id)).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to delete personal data history for user id %d", id), err)
+	}
+	return nil
+}
+
 // FindByUsername looks up a user by exact username match.
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 	start := time.Now()
 
 	user, err := gorm.G[models.User](r.GetDB(ctx)).
 		Where(generated.User.Username.Eq(username)).
+		Preload(generated.User.AdminRoles.Name(), nil).
 		First(ctx)
 
 	r.LogSlowRead(ctx, "FindByUsername", time.Since(start))
@@ -70,6 +185,7 @@ func (r *userRepository) FindByIDForUpdate(ctx context.Context, id uint) (*model
 	var user models.User
 	err := r.GetDB(ctx).WithContext(ctx).
 		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Preload(generated.User.AdminRoles.Name()).
 		First(&user, "id = ?", id).Error
 
 	r.LogSlowRead(ctx, "FindByIDForUpdate", time.Since(start))
@@ -93,6 +209,7 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 
 	user, err := gorm.G[models.User](r.GetDB(ctx)).
 		Where(generated.User.Email.Eq(normalized)).
+		Preload(generated.User.AdminRoles.Name(), nil).
 		First(ctx)
 
 	r.LogSlowRead(ctx, "FindByEmail", time.Since(start))
@@ -106,3 +223,147 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 
 	return &user, nil
 }
+
+// AdvanceTwoFactorStep records step as the user's last accepted TOTP step,
+// but only if it is newer than the stored one. The compare-and-set runs as a
+// single conditional UPDATE, so when the same code is submitted twice
+// concurrently exactly one caller gets true; the other is a replay.
+func (r *userRepository) AdvanceTwoFactorStep(ctx context.Context, id uint, step int64) (bool, error) {
+	start := time.Now()
+
+	rows, err := gorm.G[models.User](r.GetDB(ctx)).
+		Where(generated.User.ID.Eq(id)).
+		Where(generated.User.TwoFactorLastStep.Lt(step)).
+		Set(generated.User.TwoFactorLastStep.Set(step)).
+		Update(ctx)
+
+	r.LogSlowWrite(ctx, "AdvanceTwoFactorStep", time.Since(start))
+
+	if err != nil {
+		return false, cerrors.NewInternalServerError(fmt.Sprintf("failed to update two-factor step for user id %d", id), err)
+	}
+	return rows > 0, nil
+}
+
+// RecordFailedLogin increments the user's consecutive failed-login counter and
+// returns the new value. When the previous failure, and any lockout, ended
+// before since, the earlier failures are forgotten and the count restarts
+// at 1. The increment is a single UPDATE, so concurrent failures are all
+// counted even when they race.
+func (r *userRepository) RecordFailedLogin(ctx context.Context, id uint, since time.Time) (int, error) {
+	start := time.Now()
+
+	_, err := gorm.G[models.User](r.GetDB(ctx)).
+		Where(generated.User.ID.Eq(id)).
+		Set(
+			clause.Assignment{
+				Column: generated.User.FailedLoginAttempts.Column(),
+				Value: gorm.Expr(
+					"CASE WHEN ? IS NOT NULL AND ? >= ? OR ? IS NOT NULL AND ? >= ? THEN ? + 1 ELSE 1 END",
+					generated.User.LastFailedLoginAt.Column(), generated.User.LastFailedLoginAt.Column(), since,
+					generated.User.LockedUntil.Column(), generated.User.LockedUntil.Column(), since,
+					generated.User.FailedLoginAttempts.Column(),
+				),
+			},
+			generated.User.LastFailedLoginAt.Set(start),
+		).
+		Update(ctx)
+	if err != nil {
+		return 0, cerrors.NewInternalServerError(fmt.Sprintf("failed to record failed login for user id %d", id), err)
+	}
+
+	user, err := gorm.G[models.User](r.GetDB(ctx)).
+		Select(generated.User.FailedLoginAttempts.Column().Name).
+		Where(generated.User.ID.Eq(id)).
+		First(ctx)
+
+	r.LogSlowWrite(ctx, "RecordFailedLogin", time.Since(start))
+
+	if err != nil {
+		return 0, cerrors.NewInternalServerError(fmt.Sprintf("failed to read failed logins for user id %d", id), err)
+	}
+	return user.FailedLoginAttempts, nil
+}
+
+// LockUntil refuses password logins for the user until the given time.
+func (r *userRepository) LockUntil(ctx context.Context, id uint, until time.Time) error {
+	start := time.Now()
+
+	_, err := gorm.G[models.User](r.GetDB(ctx)).
+		Where(generated.User.ID.Eq(id)).
+		Set(generated.User.LockedUntil.Set(until)).
+		Update(ctx)
+
+	r.LogSlowWrite(ctx, "LockUntil", time.Since(start))
+
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to lock user id %d", id), err)
+	}
+	return nil
+}
+
+// ClearFailedLogins resets the failed-login counter and lifts any lockout.
+func (r *userRepository) ClearFailedLogins(ctx context.Context, id uint) error {
+	start := time.Now()
+
+	_, err := gorm.G[models.User](r.GetDB(ctx)).
+		Where(generated.User.ID.Eq(id)).
+		Set(
+			generated.User.FailedLoginAttempts.Set(0),
+			clause.Assignment{Column: generated.User.LastFailedLoginAt.Column(), Value: nil},
+			clause.Assignment{Column: generated.User.LockedUntil.Column(), Value: nil},
+		).
+		Update(ctx)
+
+	r.LogSlowWrite(ctx, "ClearFailedLogins", time.Since(start))
+
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to clear failed logins for user id %d", id), err)
+	}
+	return nil
+}
+
+// UpdatePasswordHash replaces the stored hash of the same password, as when a
+// legacy hash is upgraded at login. It is not a password change, so
+// password_changed_at is left alone.
+func (r *userRepository) UpdatePasswordHash(ctx context.Context, id uint, hash string) error {
+	start := time.Now()
+
+	_, err := gorm.G[models.User](r.GetDB(ctx)).
+		Where(generated.User.ID.Eq(id)).
+		Set(generated.User.Password.Set(hash)).
+		Update(ctx)
+
+	r.LogSlowWrite(ctx, "UpdatePasswordHash", time.Since(start))
+
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to update password hash for user id %d", id), err)
+	}
+	return nil
+}
+
+// SetAdminRoles replaces the user's admin roles with roleIDs; an empty list
+// removes them all. Run it inside a transaction so a failed insert cannot
+// leave the user with no roles at all.
+func (r *userRepository) SetAdminRoles(ctx context.Context, id uint, roleIDs []uint) error {
+	start := time.Now()
+	_, err := gorm.G[models.UserAdminRole](r.GetDB(ctx)).
+		Where(generated.UserAdminRole.UserID.Eq(id)).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to clear admin roles for user id %d", id), err)
+	}
+
+	if len(roleIDs) > 0 {
+		rows := make([]models.UserAdminRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			rows = append(rows, models.UserAdminRole{UserID: id, AdminRoleID: roleID})
+		}
+		err = r.GetDB(ctx).WithContext(ctx).Omit(clause.Associations).Create(&rows).Error
+	}
+	r.LogSlowWrite(ctx, "SetAdminRoles", time.Since(start))
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to assign admin roles to user id %d", id), err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/require"
@@ -23,7 +24,7 @@ func setupDB(t *testing.T) *gorm.DB {
 		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
 	})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}))
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.UserAdminRole{}, &models.PersonalDataHistory{}))
 
 	return db
 }
@@ -125,3 +126,232 @@ func TestUserRepositoryFindByEmailNormalizesInput(t *testing.T) {
 	require.Equal(t, seed.ID, got.ID)
 	require.Equal(t, "bob@example.com", got.Email)
 }
+
+func TestUserRepositoryAdvanceTwoFactorStepRejectsStaleSteps(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+
+	seed := &models.User{
+		Username: "dave",
+		Email:    "dave@example.com",
+		Phone:    "08123456782",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(seed).Error)
+
+	ok, err := repo.AdvanceTwoFactorStep(context.Background(), seed.ID, 100)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Same step again is a replay; an older one is too.
+	ok, err = repo.AdvanceTwoFactorStep(context.Background(), seed.ID, 100)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = repo.AdvanceTwoFactorStep(context.Background(), seed.ID, 99)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = repo.AdvanceTwoFactorStep(context.Background(), seed.ID, 101)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	var got models.User
+	require.NoError(t, db.First(&got, seed.ID).Error)
+	require.Equal(t, int64(101), got.TwoFactorLastStep)
+}
+
+func TestUserRepositoryUpdatePasswordHashKeepsPasswordChangedAt(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+
+	changedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	seed := &models.User{
+		Username:          "fay",
+		Email:             "fay@example.com",
+		Phone:             "08123456784",
+		IsActive:          true,
+		Role:              models.UserRoleUser,
+		Password:          "$2a$04$legacy",
+		PasswordChangedAt: &changedAt,
+	}
+	require.NoError(t, db.Create(seed).Error)
+
+	require.NoError(t, repo.UpdatePasswordHash(context.Background(), seed.ID, "$argon2id$upgraded"))
+
+	var got models.User
+	require.NoError(t, db.First(&got, seed.ID).Error)
+	require.Equal(t, "$argon2id$upgraded", got.Password)
+	require.NotNil(t, got.PasswordChangedAt)
+	require.True(t, got.PasswordChangedAt.Equal(changedAt), "rehashing is not a password change")
+}
+
+func TestUserRepositoryFailedLoginCounterLockAndClear(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+
+	seed := &models.User{
+		Username: "erin",
+		Email:    "erin@example.com",
+		Phone:    "08123456783",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(seed).Error)
+
+	since := time.Now().Add(-15 * time.Minute)
+	for want := 1; want <= 3; want++ {
+		attempts, err := repo.RecordFailedLogin(context.Background(), seed.ID, since)
+		require.NoError(t, err)
+		require.Equal(t, want, attempts)
+	}
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, repo.LockUntil(context.Background(), seed.ID, until))
+
+	var got models.User
+	require.NoError(t, db.First(&got, seed.ID).Error)
+	require.Equal(t, 3, got.FailedLoginAttempts)
+	require.NotNil(t, got.LockedUntil)
+	require.True(t, got.LockedUntil.Equal(until))
+
+	require.NoError(t, repo.ClearFailedLogins(context.Background(), seed.ID))
+
+	got = models.User{}
+	require.NoError(t, db.First(&got, seed.ID).Error)
+	require.Zero(t, got.FailedLoginAttempts)
+	require.Nil(t, got.LastFailedLoginAt)
+	require.Nil(t, got.LockedUntil)
+}
+
+func TestUserRepositoryFailedLoginCounterRestartsAfterWindow(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+	ctx := context.Background()
+
+	seed := &models.User{
+		Username: "frank",
+		Email:    "frank@example.com",
+		Phone:    "08123456784",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(seed).Error)
+
+	window := 15 * time.Minute
+	for want := 1; want <= 2; want++ {
+		attempts, err := repo.RecordFailedLogin(ctx, seed.ID, time.Now().Add(-window))
+		require.NoError(t, err)
+		require.Equal(t, want, attempts)
+	}
+
+	// The last failure was an hour ago: the two old typos are forgotten.
+	require.NoError(t, db.Model(seed).Update("last_failed_login_at", time.Now().Add(-time.Hour)).Error)
+	attempts, err := repo.RecordFailedLogin(ctx, seed.ID, time.Now().Add(-window))
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts)
+
+	// A lockout that ended inside the window keeps the count going, even
+	// though it outlasted the window, so the backoff still escalates.
+	require.NoError(t, db.Model(seed).Updates(map[string]any{
+		"failed_login_attempts": 5,
+		"last_failed_login_at":  time.Now().Add(-2 * time.Hour),
+		"locked_until":          time.Now().Add(-time.Minute),
+	}).Error)
+	attempts, err = repo.RecordFailedLogin(ctx, seed.ID, time.Now().Add(-window))
+	require.NoError(t, err)
+	require.Equal(t, 6, attempts)
+
+	var got models.User
+	require.NoError(t, db.First(&got, seed.ID).Error)
+	require.NotNil(t, got.LastFailedLoginAt)
+	require.WithinDuration(t, time.Now(), *got.LastFailedLoginAt, 5*time.Second)
+}
+
+func TestUserRepositorySetAdminRolesReplacesAssignments(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+	ctx := context.Background()
+
+	editor := &models.AdminRole{Name: "Editor", IsActive: true}
+	finance := &models.AdminRole{Name: "Finance", IsActive: true}
+	support := &models.AdminRole{Name: "Support", IsActive: true}
+	require.NoError(t, db.Create([]*models.AdminRole{editor, finance, support}).Error)
+	user := &models.User{
+		Username: "alice",
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	require.NoError(t, repo.SetAdminRoles(ctx, user.ID, []uint{editor.ID, finance.ID}))
+
+	// Every single-user lookup loads the roles.
+	got, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint{editor.ID, finance.ID}, got.AdminRoleIDs())
+	got, err = repo.FindByUsername(ctx, "alice")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint{editor.ID, finance.ID}, got.AdminRoleIDs())
+
+	// Saving the user never writes the loaded roles back.
+	got.AdminRoles = append(got.AdminRoles, *support)
+	require.NoError(t, repo.Update(ctx, got))
+	got, err = repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint{editor.ID, finance.ID}, got.AdminRoleIDs())
+
+	require.NoError(t, repo.SetAdminRoles(ctx, user.ID, []uint{finance.ID}))
+	got, err = repo.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.Equal(t, []uint{finance.ID}, got.AdminRoleIDs())
+
+	require.NoError(t, repo.SetAdminRoles(ctx, user.ID, nil))
+	got, err = repo.FindByIDForUpdate(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.AdminRoles)
+}
+
+// TestUserRepositoryKeepsPersonalDataHistory — Create and Update record every
+// personal value the account holds, so values it has since changed stay
+// known until the history is deleted.
+func TestUserRepositoryKeepsPersonalDataHistory(t *testing.T) {
+	db := setupDB(t)
+	repo := userrepository.NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &models.User{
+		Username: "dave",
+		Name:     "Dave",
+		Email:    "dave@example.com",
+		Phone:    "08123456782",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	user.Name = "David"
+	user.Email = "david@example.com"
+	require.NoError(t, repo.Update(ctx, user))
+	// Saving unchanged values adds nothing.
+	require.NoError(t, repo.Update(ctx, user))
+
+	history, err := repo.FindPersonalDataHistory(ctx, user.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"Dave", "dave", "dave@example.com", "08123456782", "David", "david@example.com",
+	}, history)
+
+	require.NoError(t, repo.DeletePersonalDataHistory(ctx, user.ID))
+	history, err = repo.FindPersonalDataHistory(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -4,26 +4,32 @@ package user
 import (
 	"github.com/PhantomX7/athleton/internal/modules/user/controller"
 	"github.com/PhantomX7/athleton/internal/routes"
+	"github.com/PhantomX7/athleton/pkg/config"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
 )
 
 type routeRegistrar struct {
 	controller controller.UserController
+	cfg        *config.Config
 }
 
 // NewRoutes constructs the user route registrar.
-func NewRoutes(controller controller.UserController) routes.Registrar {
-	return &routeRegistrar{controller: controller}
+func NewRoutes(controller controller.UserController, cfg *config.Config) routes.Registrar {
+	return &routeRegistrar{controller: controller, cfg: cfg}
 }
 
 // RegisterRoutes mounts the user-management endpoints.
 func (r *routeRegistrar) RegisterRoutes(ctx *routes.Context) {
+	// Destructive operations additionally demand step-up authentication.
+	recentAuth := ctx.MW.RequireRecentAuth(r.cfg.Auth.ReauthenticationWindow)
+
 	userRoute := ctx.Admin.Group("/user")
 	userRoute.GET("", ctx.MW.RequirePermission(permissions.UserRead), r.controller.Index)
 	userRoute.POST("", ctx.MW.RequirePermission(permissions.AdminUserCreate), r.controller.Create)
 	userRoute.GET("/:id", ctx.MW.RequirePermission(permissions.UserRead), r.controller.FindByID)
 	userRoute.PATCH("/:id", ctx.MW.RequirePermission(permissions.UserUpdate), r.controller.Update)
-	userRoute.DELETE("/:id", ctx.MW.RequirePermission(permissions.UserDelete), r.controller.Delete)
-	userRoute.POST("/:id/admin-role", ctx.MW.RequirePermission(permissions.UserAssignRole), r.controller.AssignAdminRole)
-	userRoute.POST("/:id/change-password", ctx.MW.RequirePermission(permissions.AdminUserChangePassword), r.controller.ChangePassword)
+	userRoute.DELETE("/:id", ctx.MW.RequirePermission(permissions.UserDelete), recentAuth, r.controller.Delete)
+	userRoute.POST("/:id/admin-role", ctx.MW.RequirePermission(permissions.UserAssignRole), recentAuth, r.controller.AssignAdminRole)
+	userRoute.POST("/:id/change-password", ctx.MW.ForbidImpersonation(), ctx.MW.RequirePermission(permissions.AdminUserChangePassword), recentAuth, r.controller.ChangePassword)
+	userRoute.POST("/:id/unlock", ctx.MW.RequirePermission(permissions.UserUpdate), r.controller.Unlock)
 }
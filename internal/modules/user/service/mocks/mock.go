@@ -42,6 +42,9 @@ var _ service.UserService = &UserServiceMock{}
 //			IndexFunc: func(ctx context.Context, req *pagination.Pagination) ([]*models.User, response.Meta, error) {
 //				panic("mock out the Index method")
 //			},
+//			UnlockFunc: func(ctx context.Context, userID uint) (*models.User, error) {
+//				panic("mock out the Unlock method")
+//			},
 //			UpdateFunc: func(ctx context.Context, userID uint, req *dto.UserUpdateRequest) (*models.User, error) {
 //				panic("mock out the Update method")
 //			},
@@ -70,6 +73,9 @@ type UserServiceMock struct {
 	// IndexFunc mocks the Index method.
 	IndexFunc func(ctx context.Context, req *pagination.Pagination) ([]*models.User, response.Meta, error)
 
+	// UnlockFunc mocks the Unlock method.
+	UnlockFunc func(ctx context.Context, userID uint) (*models.User, error)
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, userID uint, req *dto.UserUpdateRequest) (*models.User, error)
 
@@ -121,6 +127,13 @@ type UserServiceMock struct {
 			// Req is the req argument value.
 			Req *pagination.Pagination
 		}
+		// Unlock holds details about calls to the Unlock method.
+		Unlock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -137,6 +150,7 @@ type UserServiceMock struct {
 	lockDelete          sync.RWMutex
 	lockFindByID        sync.RWMutex
 	lockIndex           sync.RWMutex
+	lockUnlock          sync.RWMutex
 	lockUpdate          sync.RWMutex
 }
 
@@ -364,6 +378,42 @@ func (mock *UserServiceMock) IndexCalls() []struct {
 	return calls
 }
 
+// Unlock calls UnlockFunc.
+func (mock *UserServiceMock) Unlock(ctx context.Context, userID uint) (*models.User, error) {
+	if mock.UnlockFunc == nil {
+		panic("UserServiceMock.UnlockFunc: method is nil but UserService.Unlock was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uint
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockUnlock.Lock()
+	mock.calls.Unlock = append(mock.calls.Unlock, callInfo)
+	mock.lockUnlock.Unlock()
+	return mock.UnlockFunc(ctx, userID)
+}
+
+// UnlockCalls gets all the calls that were made to Unlock.
+// Check the length with:
+//
+//	len(mockedUserService.UnlockCalls())
+func (mock *UserServiceMock) UnlockCalls() []struct {
+	Ctx    context.Context
+	UserID uint
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uint
+	}
+	mock.lockUnlock.RLock()
+	calls = mock.calls.Unlock
+	mock.lockUnlock.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *UserServiceMock) Update(ctx context.Context, userID uint, req *dto.UserUpdateRequest) (*models.User, error) {
 	if mock.UpdateFunc == nil {
@@ -2,30 +2,33 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/PhantomX7/athleton/internal/audit"
 	"github.com/PhantomX7/athleton/internal/dto"
-	"github.com/PhantomX7/athleton/internal/generated"
 	"github.com/PhantomX7/athleton/internal/models"
 	adminrolerepo "github.com/PhantomX7/athleton/internal/modules/admin_role/repository"
+	authjwt "github.com/PhantomX7/athleton/internal/modules/auth/jwt"
 	logrepo "github.com/PhantomX7/athleton/internal/modules/log/repository"
+	passwordpolicy "github.com/PhantomX7/athleton/internal/modules/password_policy/service"
 	rtokenrepo "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository"
+	securityeventrepo "github.com/PhantomX7/athleton/internal/modules/security_event/repository"
 	"github.com/PhantomX7/athleton/internal/modules/user/repository"
 	"github.com/PhantomX7/athleton/libs/casbin"
 	"github.com/PhantomX7/athleton/libs/transaction_manager"
 	"github.com/PhantomX7/athleton/pkg/constants/permissions"
-	"github.com/PhantomX7/athleton/pkg/constants/security"
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 	"github.com/PhantomX7/athleton/pkg/logger"
 	"github.com/PhantomX7/athleton/pkg/pagination"
 	"github.com/PhantomX7/athleton/pkg/response"
 	"github.com/PhantomX7/athleton/pkg/utils"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -39,18 +42,22 @@ type UserService interface {
 	FindByID(ctx context.Context, userID uint) (*models.User, error)
 	AssignAdminRole(ctx context.Context, userID uint, req *dto.UserAssignAdminRoleRequest) (*models.User, error)
 	ChangePassword(ctx context.Context, userID uint, req *dto.ChangeAdminPasswordRequest) error
+	Unlock(ctx context.Context, userID uint) (*models.User, error)
 	Delete(ctx context.Context, userID uint) error
 }
 
 // userService implements the UserService interface
 type userService struct {
-	userRepository   repository.UserRepository
-	adminRoleRepo    adminrolerepo.AdminRoleRepository
-	refreshTokenRepo rtokenrepo.RefreshTokenRepository
-	logRepository    logrepo.LogRepository
-	casbinClient     casbin.Client
-	txManager        transaction_manager.TransactionManager
-	log              *zap.Logger
+	userRepository    repository.UserRepository
+	adminRoleRepo     adminrolerepo.AdminRoleRepository
+	refreshTokenRepo  rtokenrepo.RefreshTokenRepository
+	logRepository     logrepo.LogRepository
+	securityEventRepo securityeventrepo.SecurityEventRepository
+	passwordPolicy    passwordpolicy.PasswordPolicy
+	casbinClient      casbin.Client
+	authCache         *authjwt.AuthCache
+	txManager         transaction_manager.TransactionManager
+	log               *zap.Logger
 }
 
 // NewUserService creates a new instance of UserService
@@ -59,24 +66,31 @@ func NewUserService(
 	adminRoleRepo adminrolerepo.AdminRoleRepository,
 	refreshTokenRepo rtokenrepo.RefreshTokenRepository,
 	logRepository logrepo.LogRepository,
+	securityEventRepo securityeventrepo.SecurityEventRepository,
+	passwordPolicy passwordpolicy.PasswordPolicy,
 	casbinClient casbin.Client,
+	authCache *authjwt.AuthCache,
 	txManager transaction_manager.TransactionManager,
 	log *zap.Logger,
 ) UserService {
 	return &userService{
-		userRepository:   userRepository,
-		adminRoleRepo:    adminRoleRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		logRepository:    logRepository,
-		casbinClient:     casbinClient,
-		txManager:        txManager,
-		log:              log,
+		userRepository:    userRepository,
+		adminRoleRepo:     adminRoleRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		logRepository:     logRepository,
+		securityEventRepo: securityEventRepo,
+		passwordPolicy:    passwordPolicy,
+		casbinClient:      casbinClient,
+		authCache:         authCache,
+		txManager:         txManager,
+		log:               log,
 	}
 }
 
-// callerHasPermission reports whether the authenticated caller holds perm.
-// Root bypasses; a context without auth values counts as holding nothing
-// (fail closed) — the /admin group middleware guarantees values in practice.
+// callerHasPermission reports whether the authenticated caller holds perm,
+// within the scope of the API key the request used, if any. Root bypasses; a
+// context without auth values counts as holding nothing (fail closed) — the
+// /admin group middleware guarantees values in practice.
 func (s *userService) callerHasPermission(ctx context.Context, perm permissions.Permission) (bool, error) {
 	values, err := utils.ValuesFromContext(ctx)
 	if err != nil {
@@ -84,7 +98,7 @@ func (s *userService) callerHasPermission(ctx context.Context, perm permissions.
 		// "no grants", not an infrastructure failure.
 		return false, nil //nolint:nilerr // fail closed on missing auth context
 	}
-	return s.casbinClient.CheckPermissionWithRoot(values.Role, values.AdminRoleID, perm.String())
+	return casbin.CheckCaller(s.casbinClient, values, perm.String())
 }
 
 // requireAdminUserGrant enforces the stronger admin_user:* grant when the
@@ -120,11 +134,6 @@ func (s *userService) Index(ctx context.Context, pg *pagination.Pagination) ([]*
 		})
 	}
 
-	// Add preloads
-	pg.AddCustomScope(func(db *gorm.DB) *gorm.DB {
-		return db.Preload("AdminRole")
-	})
-
 	users, err := s.userRepository.FindAll(ctx, pg)
 	if err != nil {
 		return users, response.Meta{}, err
@@ -148,32 +157,36 @@ func (s *userService) Index(ctx context.Context, pg *pagination.Pagination) ([]*
 // must-change-default-password gate forces the new admin to rotate the
 // creator-chosen password on first login.
 func (s *userService) Create(ctx context.Context, req *dto.AdminUserCreateRequest) (*models.User, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), security.BcryptCost)
+	hashedPassword, err := s.passwordPolicy.Hash(ctx, nil, req.Password)
 	if err != nil {
-		return nil, cerrors.NewInternalServerError("failed to process password", err)
+		return nil, err
 	}
 
 	user := &models.User{
-		Username:    req.Username,
-		Name:        req.Name,
-		Email:       strings.ToLower(strings.TrimSpace(req.Email)),
-		Phone:       strings.TrimSpace(req.Phone),
-		IsActive:    true,
-		Role:        models.UserRoleAdmin,
-		AdminRoleID: &req.AdminRoleID,
-		Password:    string(hashedPassword),
+		Username: req.Username,
+		Name:     req.Name,
+		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
+		Phone:    strings.TrimSpace(req.Phone),
+		IsActive: true,
+		Role:     models.UserRoleAdmin,
+		Password: hashedPassword,
 	}
 
 	err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
-		// Lock the target admin-role row: this serializes with the role-delete
+		// Lock the target admin-role rows: this serializes with the role-delete
 		// flow (which locks the same row before its "no users assigned" check)
-		// and re-verifies the role exists inside the transaction instead of
+		// and re-verifies the roles exist inside the transaction instead of
 		// trusting the request-validation lookup that ran outside it.
-		if _, err := s.adminRoleRepo.FindByIDForUpdate(txCtx, req.AdminRoleID); err != nil {
+		roles, err := s.lockAdminRoles(txCtx, req.AdminRoleIDs)
+		if err != nil {
 			return err
 		}
 
-		return s.userRepository.Create(txCtx, user)
+		if err := s.userRepository.Create(txCtx, user); err != nil {
+			return err
+		}
+		user.AdminRoles = roles
+		return s.userRepository.SetAdminRoles(txCtx, user.ID, user.AdminRoleIDs())
 	})
 	if err != nil {
 		return nil, err
@@ -217,18 +230,25 @@ func (s *userService) Update(ctx context.Context, userID uint, req *dto.UserUpda
 		}
 		if req.Role != nil {
 			user.Role = models.UserRole(*req.Role)
-			// Demoting away from an admin-type role must clear the admin-role
-			// assignment in the same write so no dangling AdminRoleID remains.
-			if !user.Role.IsAdminType() {
-				user.AdminRoleID = nil
-			}
 		}
 
-		return s.userRepository.Update(txCtx, user)
+		if err := s.userRepository.Update(txCtx, user); err != nil {
+			return err
+		}
+		// Demoting away from an admin-type role must remove the admin roles
+		// in the same transaction so no dangling assignment remains.
+		if !user.Role.IsAdminType() && len(user.AdminRoles) > 0 {
+			user.AdminRoles = nil
+			return s.userRepository.SetAdminRoles(txCtx, user.ID, nil)
+		}
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	// The authorizer hands the cached row to later middleware and sets the
+	// caller's role from it, so drop it once the change has committed.
+	s.authCache.InvalidateUser(user.ID)
 
 	// Create audit log
 	s.createLog(ctx, models.LogActionUpdate, user.ID, user.Name)
@@ -238,7 +258,7 @@ func (s *userService) Update(ctx context.Context, userID uint, req *dto.UserUpda
 
 // FindByID implements UserService.
 func (s *userService) FindByID(ctx context.Context, userID uint) (*models.User, error) {
-	user, err := s.userRepository.FindByID(ctx, userID, generated.User.AdminRole)
+	user, err := s.userRepository.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -248,39 +268,43 @@ func (s *userService) FindByID(ctx context.Context, userID uint) (*models.User,
 		return nil, err
 	}
 
-	if user.AdminRole != nil {
-		user.AdminRole.Permissions = s.casbinClient.GetRolePermissions(user.AdminRole.ID)
+	for i := range user.AdminRoles {
+		role := &user.AdminRoles[i]
+		role.ParentRoleID = s.casbinClient.GetRoleParent(role.ID)
+		role.SetPermissions(s.casbinClient.GetDirectRolePermissions(role.ID), s.casbinClient.GetRolePermissions(role.ID))
+		role.SetDenials(s.casbinClient.GetDirectRoleDenials(role.ID), s.casbinClient.GetRoleDenials(role.ID))
 	}
 
 	return user, nil
 }
 
-// AssignAdminRole assigns an admin role to a user and promotes the account to
-// the "admin" role in the same write — Casbin's CheckPermissionWithRoot only
-// consults AdminRoleID when Role == "admin", so setting only one of the two
-// fields would grant nothing. There is no separate unassign endpoint: demotion
-// goes through Update (role "user"), which clears AdminRoleID in the same
-// write so both fields always change together.
+// AssignAdminRole changes a user's admin roles and promotes the account to
+// the "admin" role in the same transaction — Casbin's CheckPermissionWithRoot
+// only consults the admin roles when Role == "admin", so setting only one of
+// the two would grant nothing. Mode "add" grants the listed roles on top of
+// the current ones, "remove" takes them away and "set" replaces the set. An
+// admin always keeps at least one role: demotion goes through Update (role
+// "user"), which removes them all in the same transaction.
 func (s *userService) AssignAdminRole(ctx context.Context, userID uint, req *dto.UserAssignAdminRoleRequest) (*models.User, error) {
 	// Run the find→check→assign→update sequence inside a single transaction so
 	// a failure at any step rolls everything back and concurrent writers cannot
 	// interleave between the read and the role assignment.
 	var user *models.User
 	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
-		// Lock the target admin-role row first. This serializes with
+		// Lock the listed admin-role rows first. This serializes with
 		// adminRoleService.Delete, which locks the same row before its
 		// "no users assigned" check: either this assignment commits first (and
 		// the delete sees the user), or the delete commits first (and this
 		// locked read finds the role gone and fails). It also re-verifies the
-		// role's existence inside the transaction instead of trusting the
+		// roles' existence inside the transaction instead of trusting the
 		// request-validation lookup that ran outside it.
-		if _, err := s.adminRoleRepo.FindByIDForUpdate(txCtx, req.AdminRoleID); err != nil {
+		roles, err := s.lockAdminRoles(txCtx, req.AdminRoleIDs)
+		if err != nil {
 			return err
 		}
 
 		// Find and lock the user row so the full-row Save below cannot race
 		// other user-mutating flows.
-		var err error
 		user, err = s.userRepository.FindByIDForUpdate(txCtx, userID)
 		if err != nil {
 			return err
@@ -292,15 +316,47 @@ func (s *userService) AssignAdminRole(ctx context.Context, userID uint, req *dto
 			return cerrors.NewForbiddenError("cannot modify root user")
 		}
 
-		// Assign admin role and set role to admin
-		user.AdminRoleID = &req.AdminRoleID
-		user.Role = models.UserRoleAdmin
+		listed := make(map[uint]struct{}, len(roles))
+		for _, role := range roles {
+			listed[role.ID] = struct{}{}
+		}
+		var next []models.AdminRole
+		switch req.Mode {
+		case dto.AdminRoleAssignAdd:
+			next = roles
+			for _, role := range user.AdminRoles {
+				if _, ok := listed[role.ID]; !ok {
+					next = append(next, role)
+				}
+			}
+		case dto.AdminRoleAssignRemove:
+			for _, role := range user.AdminRoles {
+				if _, ok := listed[role.ID]; !ok {
+					next = append(next, role)
+				}
+			}
+		default:
+			next = roles
+		}
+		if len(next) == 0 {
+			return cerrors.NewBadRequestError("an admin must keep at least one admin role; demote the user instead")
+		}
 
-		return s.userRepository.Update(txCtx, user)
+		// Assign the admin roles and set role to admin
+		user.Role = models.UserRoleAdmin
+		if err := s.userRepository.Update(txCtx, user); err != nil {
+			return err
+		}
+		slices.SortFunc(next, func(a, b models.AdminRole) int { return cmp.Compare(a.ID, b.ID) })
+		user.AdminRoles = next
+		return s.userRepository.SetAdminRoles(txCtx, user.ID, user.AdminRoleIDs())
 	})
 	if err != nil {
 		return nil, err
 	}
+	// The authorizer hands the cached row to later middleware and sets the
+	// caller's role from it, so drop it once the change has committed.
+	s.authCache.InvalidateUser(user.ID)
 
 	// Create audit log
 	s.createLog(ctx, models.LogActionUpdate, user.ID, user.Name)
@@ -308,6 +364,25 @@ func (s *userService) AssignAdminRole(ctx context.Context, userID uint, req *dto
 	return user, nil
 }
 
+// lockAdminRoles loads the given admin roles under a row lock, in ID order so
+// that concurrent assignments cannot deadlock on each other. Duplicate IDs are
+// locked once.
+func (s *userService) lockAdminRoles(ctx context.Context, roleIDs []uint) ([]models.AdminRole, error) {
+	ids := slices.Clone(roleIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	roles := make([]models.AdminRole, 0, len(ids))
+	for _, id := range ids {
+		role, err := s.adminRoleRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, *role)
+	}
+	return roles, nil
+}
+
 // ChangePassword allows root to change another admin's password
 func (s *userService) ChangePassword(ctx context.Context, userID uint, req *dto.ChangeAdminPasswordRequest) error {
 	// Run the find→guard→hash→update→revoke sequence inside a single
@@ -335,13 +410,15 @@ func (s *userService) ChangePassword(ctx context.Context, userID uint, req *dto.
 			return cerrors.NewForbiddenError("cannot change root user password")
 		}
 
-		// Hash new password
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), security.BcryptCost)
+		hashedPassword, err := s.passwordPolicy.Hash(txCtx, user, req.NewPassword)
 		if err != nil {
-			return cerrors.NewInternalServerError("failed to process new password", err)
+			return err
+		}
+		if err := s.passwordPolicy.Retire(txCtx, user); err != nil {
+			return err
 		}
 
-		user.Password = string(hashedPassword)
+		user.Password = hashedPassword
 		// Clears the must-change-default-password gate for the target account.
 		now := time.Now()
 		user.PasswordChangedAt = &now
@@ -356,12 +433,41 @@ func (s *userService) ChangePassword(ctx context.Context, userID uint, req *dto.
 	if err != nil {
 		return err
 	}
+	s.authCache.InvalidateUser(user.ID)
 
 	s.createLog(ctx, models.LogActionChangePassword, user.ID, user.Name)
+	// The event lands on the target's own timeline; the audit log above
+	// records which admin did it.
+	audit.RecordSecurityEvent(ctx, s.securityEventRepo, user.ID, models.SecurityEventPasswordChange, models.SecurityEventOutcomeSuccess, uuid.Nil)
 
 	return nil
 }
 
+// Unlock lifts a login lockout and resets the failed-attempt counter, so the
+// account owner does not have to wait out the backoff once they have been
+// verified some other way.
+func (s *userService) Unlock(ctx context.Context, userID uint) (*models.User, error) {
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlocking another admin account requires the stronger grant.
+	if err := s.requireAdminUserGrant(ctx, user, permissions.AdminUserUpdate); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepository.ClearFailedLogins(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+
+	s.createLog(ctx, models.LogActionUnlockAccount, user.ID, user.Name)
+
+	return user, nil
+}
+
 // Delete soft-deletes a user account. The model's partial unique indexes
 // (WHERE deleted_at IS NULL) already free the username/email for reuse.
 func (s *userService) Delete(ctx context.Context, userID uint) error {
@@ -406,6 +512,7 @@ func (s *userService) Delete(ctx context.Context, userID uint) error {
 	if err != nil {
 		return err
 	}
+	s.authCache.InvalidateUser(user.ID)
 
 	s.createLog(ctx, models.LogActionDelete, user.ID, user.Name)
 
@@ -14,7 +14,9 @@ import (
 	"github.com/PhantomX7/athleton/internal/models"
 	adminrolemocks "github.com/PhantomX7/athleton/internal/modules/admin_role/repository/mocks"
 	logmocks "github.com/PhantomX7/athleton/internal/modules/log/repository/mocks"
+	passwordpolicymocks "github.com/PhantomX7/athleton/internal/modules/password_policy/service/mocks"
 	refreshtokenmocks "github.com/PhantomX7/athleton/internal/modules/refresh_token/repository/mocks"
+	securityeventmocks "github.com/PhantomX7/athleton/internal/modules/security_event/repository/mocks"
 	usermocks "github.com/PhantomX7/athleton/internal/modules/user/repository/mocks"
 	"github.com/PhantomX7/athleton/internal/modules/user/service"
 	casbinmocks "github.com/PhantomX7/athleton/libs/casbin/mocks"
@@ -39,6 +41,29 @@ func existingAdminRoleRepo(t *testing.T, roleID uint) *adminrolemocks.AdminRoleR
 	}
 }
 
+// stubSecurityEventRepo accepts every security event so the service tests
+// need not care which timeline entries a flow records.
+func stubSecurityEventRepo() *securityeventmocks.SecurityEventRepositoryMock {
+	return &securityeventmocks.SecurityEventRepositoryMock{
+		CreateFunc: func(context.Context, *models.SecurityEvent) error { return nil },
+	}
+}
+
+// stubPasswordPolicy returns a password policy mock that accepts every
+// candidate and hashes it at bcrypt's minimum cost, keeping the service tests
+// independent of the policy rules (covered in the password_policy module).
+func stubPasswordPolicy() *passwordpolicymocks.PasswordPolicyMock {
+	return &passwordpolicymocks.PasswordPolicyMock{
+		HashFunc: func(ctx context.Context, user *models.User, candidate string) (string, error) {
+			hashed, err := bcrypt.GenerateFromPassword([]byte(candidate), bcrypt.MinCost)
+			return string(hashed), err
+		},
+		RetireFunc: func(ctx context.Context, user *models.User) error {
+			return nil
+		},
+	}
+}
+
 // passthroughTxManager returns a mock transaction manager that simply invokes
 // the closure with the original context, mimicking a committed transaction.
 func passthroughTxManager() *txmocks.TransactionManagerMock {
@@ -67,7 +92,7 @@ func TestUserServiceIndexReturnsUsersAndMeta(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{}, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, &txmocks.TransactionManagerMock{}, zap.NewNop())
 	ctx := utils.SetRequestIDToContext(context.Background(), "req-1")
 
 	users, meta, err := svc.Index(ctx, pg)
@@ -78,35 +103,41 @@ func TestUserServiceIndexReturnsUsersAndMeta(t *testing.T) {
 }
 
 func TestUserServiceFindByIDHydratesAdminRolePermissions(t *testing.T) {
-	roleID := uint(9)
+	grants := map[uint][]string{
+		9:  {permissions.UserRead.String()},
+		10: {permissions.LogRead.String()},
+	}
+	denials := map[uint][]string{
+		9: {permissions.UserDelete.String()},
+	}
 	repo := &usermocks.UserRepositoryMock{
-		FindByIDFunc: func(ctx context.Context, id uint, preloads ...repository.Association) (*models.User, error) {
+		FindByIDFunc: func(ctx context.Context, id uint, _ ...repository.Association) (*models.User, error) {
 			require.Equal(t, "req-2", utils.GetRequestIDFromContext(ctx))
 			require.Equal(t, uint(7), id)
-			require.NotEmpty(t, preloads)
 			return &models.User{
 				ID:       7,
 				Username: "admin",
 				Role:     models.UserRoleAdmin,
-				AdminRole: &models.AdminRole{
-					ID:   roleID,
-					Name: "Manager",
+				AdminRoles: []models.AdminRole{
+					{ID: 9, Name: "Manager"},
+					{ID: 10, Name: "Auditor"},
 				},
 			}, nil
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
-		GetRolePermissionsFunc: func(gotRoleID uint) []string {
-			require.Equal(t, roleID, gotRoleID)
-			return []string{permissions.UserRead.String()}
-		},
+		GetRoleParentFunc:            func(uint) *uint { return nil },
+		GetRolePermissionsFunc:       func(roleID uint) []string { return grants[roleID] },
+		GetDirectRolePermissionsFunc: func(roleID uint) []string { return grants[roleID] },
+		GetRoleDenialsFunc:           func(roleID uint) []string { return denials[roleID] },
+		GetDirectRoleDenialsFunc:     func(roleID uint) []string { return denials[roleID] },
 		// Mirror the real client: root bypasses permission checks.
-		CheckPermissionWithRootFunc: func(role string, _ *uint, _ string) (bool, error) {
+		CheckPermissionWithRootFunc: func(role string, _ []uint, _ string) (bool, error) {
 			return role == models.UserRoleRoot.ToString(), nil
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{}, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, &txmocks.TransactionManagerMock{}, zap.NewNop())
 	ctx := utils.SetRequestIDToContext(context.Background(), "req-2")
 	// Root caller: bypasses the admin_user:read check for the admin target.
 	ctx = utils.NewContextWithValues(ctx, utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
@@ -114,29 +145,29 @@ func TestUserServiceFindByIDHydratesAdminRolePermissions(t *testing.T) {
 	user, err := svc.FindByID(ctx, 7)
 
 	require.NoError(t, err)
-	require.NotNil(t, user.AdminRole)
-	require.Equal(t, []string{permissions.UserRead.String()}, user.AdminRole.Permissions)
+	require.Len(t, user.AdminRoles, 2)
+	require.Equal(t, []string{permissions.UserRead.String()}, user.AdminRoles[0].Permissions)
+	require.Equal(t, []string{permissions.LogRead.String()}, user.AdminRoles[1].Permissions)
+	require.Equal(t, []string{permissions.UserDelete.String()}, user.AdminRoles[0].Denials)
 }
 
 func TestUserServiceCreateCreatesAdminAccount(t *testing.T) {
 	logCh := make(chan *models.Log, 1)
-	roleID := uint(5)
 
-	roleLocked := false
+	var locked []uint
 	adminRoleRepo := &adminrolemocks.AdminRoleRepositoryMock{
 		FindByIDForUpdateFunc: func(_ context.Context, id uint) (*models.AdminRole, error) {
-			require.Equal(t, roleID, id)
-			roleLocked = true
-			return &models.AdminRole{ID: roleID, Name: "Manager"}, nil
+			locked = append(locked, id)
+			return &models.AdminRole{ID: id, Name: "Manager"}, nil
 		},
 	}
 	repo := &usermocks.UserRepositoryMock{
 		CreateFunc: func(_ context.Context, entity *models.User) error {
-			require.True(t, roleLocked, "the admin-role row must be locked before the user insert")
+			// Locked in ID order, each once, before the user insert.
+			require.Equal(t, []uint{5, 8}, locked, "the admin-role rows must be locked before the user insert")
 			// The created account is always a plain admin — role is never
 			// taken from the request, so root can never be created.
 			require.Equal(t, models.UserRoleAdmin, entity.Role)
-			require.Equal(t, &roleID, entity.AdminRoleID)
 			require.True(t, entity.IsActive)
 			require.Equal(t, "new.admin@test.local", entity.Email, "email must be normalized to lowercase")
 			require.Equal(t, "new-admin", entity.Username)
@@ -148,6 +179,11 @@ func TestUserServiceCreateCreatesAdminAccount(t *testing.T) {
 			entity.ID = 9
 			return nil
 		},
+		SetAdminRolesFunc: func(_ context.Context, id uint, roleIDs []uint) error {
+			require.Equal(t, uint(9), id)
+			require.Equal(t, []uint{5, 8}, roleIDs)
+			return nil
+		},
 	}
 	logRepo := &logmocks.LogRepositoryMock{
 		CreateFunc: func(_ context.Context, entry *models.Log) error {
@@ -156,20 +192,22 @@ func TestUserServiceCreateCreatesAdminAccount(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root"})
 
 	user, err := svc.Create(ctx, &dto.AdminUserCreateRequest{
-		Username:    "new-admin",
-		Name:        "New Admin",
-		Email:       "  New.Admin@Test.Local ",
-		Phone:       "+620000000007",
-		Password:    "initial-pass-123",
-		AdminRoleID: roleID,
+		Username:     "new-admin",
+		Name:         "New Admin",
+		Email:        "  New.Admin@Test.Local ",
+		Phone:        "+620000000007",
+		Password:     "initial-pass-123",
+		AdminRoleIDs: []uint{8, 5, 8},
 	})
 
 	require.NoError(t, err)
 	require.Equal(t, uint(9), user.ID)
+	require.Equal(t, []uint{5, 8}, user.AdminRoleIDs())
+	require.Len(t, repo.SetAdminRolesCalls(), 1)
 
 	select {
 	case entry := <-logCh:
@@ -188,15 +226,15 @@ func TestUserServiceCreateFailsWhenRoleMissing(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(&usermocks.UserRepositoryMock{}, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(&usermocks.UserRepositoryMock{}, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	user, err := svc.Create(context.Background(), &dto.AdminUserCreateRequest{
-		Username:    "new-admin",
-		Name:        "New Admin",
-		Email:       "new.admin@test.local",
-		Phone:       "+620000000007",
-		Password:    "initial-pass-123",
-		AdminRoleID: 99,
+		Username:     "new-admin",
+		Name:         "New Admin",
+		Email:        "new.admin@test.local",
+		Phone:        "+620000000007",
+		Password:     "initial-pass-123",
+		AdminRoleIDs: []uint{99},
 	})
 
 	require.Nil(t, user)
@@ -208,16 +246,16 @@ func TestUserServiceCreateFailsWhenRoleMissing(t *testing.T) {
 func adminCallerValues() utils.ContextValues {
 	roleID := uint(3)
 	return utils.ContextValues{
-		UserID:      2,
-		UserName:    "Caller",
-		Role:        models.UserRoleAdmin.ToString(),
-		AdminRoleID: &roleID,
+		UserID:       2,
+		UserName:     "Caller",
+		Role:         models.UserRoleAdmin.ToString(),
+		AdminRoleIDs: []uint{roleID},
 	}
 }
 
 func TestUserServiceUpdateRequiresAdminUserGrantForAdminTargets(t *testing.T) {
 	roleID := uint(5)
-	target := &models.User{ID: 6, Name: "Other Admin", Role: models.UserRoleAdmin, AdminRoleID: &roleID}
+	target := &models.User{ID: 6, Name: "Other Admin", Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}
 
 	newSvc := func(granted bool, updated *bool) service.UserService {
 		repo := &usermocks.UserRepositoryMock{
@@ -230,14 +268,14 @@ func TestUserServiceUpdateRequiresAdminUserGrantForAdminTargets(t *testing.T) {
 			},
 		}
 		casbinClient := &casbinmocks.ClientMock{
-			CheckPermissionWithRootFunc: func(role string, adminRoleID *uint, perm string) (bool, error) {
+			CheckPermissionWithRootFunc: func(role string, _ []uint, perm string) (bool, error) {
 				require.Equal(t, models.UserRoleAdmin.ToString(), role)
 				require.Equal(t, permissions.AdminUserUpdate.String(), perm)
 				return granted, nil
 			},
 		}
 		logRepo := &logmocks.LogRepositoryMock{CreateFunc: func(context.Context, *models.Log) error { return nil }}
-		return service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, casbinClient, passthroughTxManager(), zap.NewNop())
+		return service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, passthroughTxManager(), zap.NewNop())
 	}
 
 	t.Run("denied without admin_user:update", func(t *testing.T) {
@@ -271,17 +309,17 @@ func TestUserServiceFindByIDRequiresAdminUserGrantForAdminTargets(t *testing.T)
 	roleID := uint(5)
 	repo := &usermocks.UserRepositoryMock{
 		FindByIDFunc: func(context.Context, uint, ...repository.Association) (*models.User, error) {
-			return &models.User{ID: 6, Role: models.UserRoleAdmin, AdminRoleID: &roleID}, nil
+			return &models.User{ID: 6, Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}, nil
 		},
 	}
 	casbinClient := &casbinmocks.ClientMock{
-		CheckPermissionWithRootFunc: func(_ string, _ *uint, perm string) (bool, error) {
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, perm string) (bool, error) {
 			require.Equal(t, permissions.AdminUserRead.String(), perm)
 			return false, nil
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, casbinClient, &txmocks.TransactionManagerMock{}, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, &txmocks.TransactionManagerMock{}, zap.NewNop())
 	ctx := utils.NewContextWithValues(context.Background(), adminCallerValues())
 
 	user, err := svc.FindByID(ctx, 6)
@@ -298,7 +336,7 @@ func TestUserServiceUpdateRejectsRootUser(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	name := "renamed"
 	user, err := svc.Update(context.Background(), 1, &dto.UserUpdateRequest{Name: &name})
@@ -311,7 +349,7 @@ func TestUserServiceUpdateRejectsRootUser(t *testing.T) {
 func TestUserServiceUpdateAppliesPatchSemanticsInTransaction(t *testing.T) {
 	logCh := make(chan *models.Log, 1)
 	roleID := uint(5)
-	current := &models.User{ID: 6, Name: "Old Name", Role: models.UserRoleAdmin, AdminRoleID: &roleID}
+	current := &models.User{ID: 6, Name: "Old Name", Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}
 	repo := &usermocks.UserRepositoryMock{
 		FindByIDForUpdateFunc: func(ctx context.Context, id uint) (*models.User, error) {
 			require.Equal(t, uint(6), id)
@@ -323,7 +361,7 @@ func TestUserServiceUpdateAppliesPatchSemanticsInTransaction(t *testing.T) {
 			// Role omitted from the request: role and admin-role assignment
 			// must be untouched.
 			require.Equal(t, models.UserRoleAdmin, entity.Role)
-			require.Equal(t, &roleID, entity.AdminRoleID)
+			require.Equal(t, []uint{roleID}, entity.AdminRoleIDs())
 			return nil
 		},
 	}
@@ -343,11 +381,11 @@ func TestUserServiceUpdateAppliesPatchSemanticsInTransaction(t *testing.T) {
 
 	casbinClient := &casbinmocks.ClientMock{
 		// Mirror the real client: root bypasses permission checks.
-		CheckPermissionWithRootFunc: func(role string, _ *uint, _ string) (bool, error) {
+		CheckPermissionWithRootFunc: func(role string, _ []uint, _ string) (bool, error) {
 			return role == models.UserRoleRoot.ToString(), nil
 		},
 	}
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, casbinClient, txManager, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, txManager, zap.NewNop())
 	// Root caller: bypasses the admin_user:update check for the admin target.
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
 
@@ -366,10 +404,10 @@ func TestUserServiceUpdateAppliesPatchSemanticsInTransaction(t *testing.T) {
 	}
 }
 
-func TestUserServiceUpdateDemotionClearsAdminRoleID(t *testing.T) {
+func TestUserServiceUpdateDemotionRemovesAdminRoles(t *testing.T) {
 	logCh := make(chan *models.Log, 1)
 	roleID := uint(5)
-	current := &models.User{ID: 6, Name: "Admin User", Role: models.UserRoleAdmin, AdminRoleID: &roleID}
+	current := &models.User{ID: 6, Name: "Admin User", Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}
 	repo := &usermocks.UserRepositoryMock{
 		FindByIDForUpdateFunc: func(ctx context.Context, id uint) (*models.User, error) {
 			require.Equal(t, uint(6), id)
@@ -378,7 +416,11 @@ func TestUserServiceUpdateDemotionClearsAdminRoleID(t *testing.T) {
 		UpdateFunc: func(ctx context.Context, entity *models.User) error {
 			require.Same(t, current, entity)
 			require.Equal(t, models.UserRoleUser, entity.Role)
-			require.Nil(t, entity.AdminRoleID, "demoting to a non-admin role must clear AdminRoleID in the same write")
+			return nil
+		},
+		SetAdminRolesFunc: func(_ context.Context, id uint, roleIDs []uint) error {
+			require.Equal(t, uint(6), id)
+			require.Empty(t, roleIDs, "demoting to a non-admin role must remove every admin role")
 			return nil
 		},
 	}
@@ -392,11 +434,11 @@ func TestUserServiceUpdateDemotionClearsAdminRoleID(t *testing.T) {
 	casbinClient := &casbinmocks.ClientMock{
 		// Mirror the real client: root bypasses permission checks (the target
 		// is an admin, so demotion requires the admin_user:update grant).
-		CheckPermissionWithRootFunc: func(role string, _ *uint, _ string) (bool, error) {
+		CheckPermissionWithRootFunc: func(role string, _ []uint, _ string) (bool, error) {
 			return role == models.UserRoleRoot.ToString(), nil
 		},
 	}
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, casbinClient, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, passthroughTxManager(), zap.NewNop())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
 
 	role := "user"
@@ -404,6 +446,8 @@ func TestUserServiceUpdateDemotionClearsAdminRoleID(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Same(t, current, user)
+	require.Len(t, repo.SetAdminRolesCalls(), 1)
+	require.Empty(t, user.AdminRoles)
 	select {
 	case entry := <-logCh:
 		require.Equal(t, models.LogActionUpdate, entry.Action)
@@ -420,7 +464,7 @@ func TestUserServiceUpdatePropagatesFindError(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	user, err := svc.Update(context.Background(), 6, &dto.UserUpdateRequest{})
 
@@ -435,9 +479,9 @@ func TestUserServiceAssignAdminRoleRejectsRootUser(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
-	user, err := svc.AssignAdminRole(context.Background(), 3, &dto.UserAssignAdminRoleRequest{AdminRoleID: 5})
+	user, err := svc.AssignAdminRole(context.Background(), 3, &dto.UserAssignAdminRoleRequest{Mode: dto.AdminRoleAssignAdd, AdminRoleIDs: []uint{5}})
 
 	require.Nil(t, user)
 	require.Error(t, err)
@@ -456,9 +500,9 @@ func TestUserServiceAssignAdminRoleFailsWhenRoleRowMissing(t *testing.T) {
 	}
 	repo := &usermocks.UserRepositoryMock{} // any user-repo call panics the test
 
-	svc := service.NewUserService(repo, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
-	user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{AdminRoleID: 5})
+	user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{Mode: dto.AdminRoleAssignAdd, AdminRoleIDs: []uint{5}})
 
 	require.Nil(t, user)
 	require.Error(t, err)
@@ -468,7 +512,7 @@ func TestUserServiceAssignAdminRoleFailsWhenRoleRowMissing(t *testing.T) {
 func TestUserServiceAssignAdminRoleUpdatesUserInTransaction(t *testing.T) {
 	logCh := make(chan *models.Log, 1)
 	// Start from a plain user: assignment must both attach the admin role and
-	// promote Role to admin, otherwise Casbin ignores the AdminRoleID entirely.
+	// promote Role to admin, otherwise Casbin ignores the admin roles entirely.
 	current := &models.User{ID: 6, Name: "Admin User", Role: models.UserRoleUser}
 	repo := &usermocks.UserRepositoryMock{
 		FindByIDForUpdateFunc: func(ctx context.Context, id uint) (*models.User, error) {
@@ -477,9 +521,12 @@ func TestUserServiceAssignAdminRoleUpdatesUserInTransaction(t *testing.T) {
 		},
 		UpdateFunc: func(ctx context.Context, entity *models.User) error {
 			require.Same(t, current, entity)
-			require.NotNil(t, entity.AdminRoleID)
-			require.Equal(t, uint(5), *entity.AdminRoleID)
-			require.Equal(t, models.UserRoleAdmin, entity.Role, "AssignAdminRole must set Role to admin in the same write")
+			require.Equal(t, models.UserRoleAdmin, entity.Role, "AssignAdminRole must set Role to admin in the same transaction")
+			return nil
+		},
+		SetAdminRolesFunc: func(_ context.Context, id uint, roleIDs []uint) error {
+			require.Equal(t, uint(6), id)
+			require.Equal(t, []uint{5}, roleIDs)
 			return nil
 		},
 	}
@@ -497,13 +544,14 @@ func TestUserServiceAssignAdminRoleUpdatesUserInTransaction(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, &casbinmocks.ClientMock{}, txManager, zap.NewNop())
+	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, txManager, zap.NewNop())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root"})
 
-	user, err := svc.AssignAdminRole(ctx, 6, &dto.UserAssignAdminRoleRequest{AdminRoleID: 5})
+	user, err := svc.AssignAdminRole(ctx, 6, &dto.UserAssignAdminRoleRequest{Mode: dto.AdminRoleAssignAdd, AdminRoleIDs: []uint{5}})
 
 	require.NoError(t, err)
 	require.Same(t, current, user)
+	require.Equal(t, []uint{5}, user.AdminRoleIDs())
 	require.Equal(t, 1, txCalls)
 	select {
 	case entry := <-logCh:
@@ -514,6 +562,74 @@ func TestUserServiceAssignAdminRoleUpdatesUserInTransaction(t *testing.T) {
 	}
 }
 
+func TestUserServiceAssignAdminRoleModes(t *testing.T) {
+	cases := []struct {
+		name    string
+		mode    string
+		listed  []uint
+		current []uint
+		want    []uint
+	}{
+		{name: "add keeps current roles", mode: dto.AdminRoleAssignAdd, listed: []uint{5}, current: []uint{3}, want: []uint{3, 5}},
+		{name: "add of a held role is a no-op", mode: dto.AdminRoleAssignAdd, listed: []uint{3}, current: []uint{3, 5}, want: []uint{3, 5}},
+		{name: "remove drops listed roles", mode: dto.AdminRoleAssignRemove, listed: []uint{3}, current: []uint{3, 5}, want: []uint{5}},
+		{name: "remove of an unheld role is a no-op", mode: dto.AdminRoleAssignRemove, listed: []uint{7}, current: []uint{3, 5}, want: []uint{3, 5}},
+		{name: "set replaces current roles", mode: dto.AdminRoleAssignSet, listed: []uint{7, 5}, current: []uint{3, 5}, want: []uint{5, 7}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			current := &models.User{ID: 6, Role: models.UserRoleAdmin}
+			for _, id := range tc.current {
+				current.AdminRoles = append(current.AdminRoles, models.AdminRole{ID: id})
+			}
+			adminRoleRepo := &adminrolemocks.AdminRoleRepositoryMock{
+				FindByIDForUpdateFunc: func(_ context.Context, id uint) (*models.AdminRole, error) {
+					require.Contains(t, tc.listed, id, "only the listed roles are locked")
+					return &models.AdminRole{ID: id}, nil
+				},
+			}
+			repo := &usermocks.UserRepositoryMock{
+				FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) { return current, nil },
+				UpdateFunc:            func(context.Context, *models.User) error { return nil },
+				SetAdminRolesFunc: func(_ context.Context, _ uint, roleIDs []uint) error {
+					require.Equal(t, tc.want, roleIDs)
+					return nil
+				},
+			}
+			logRepo := &logmocks.LogRepositoryMock{CreateFunc: func(context.Context, *models.Log) error { return nil }}
+
+			svc := service.NewUserService(repo, adminRoleRepo, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
+
+			user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{Mode: tc.mode, AdminRoleIDs: tc.listed})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, user.AdminRoleIDs())
+			require.Len(t, repo.SetAdminRolesCalls(), 1)
+		})
+	}
+}
+
+func TestUserServiceAssignAdminRoleRefusesToRemoveLastRole(t *testing.T) {
+	repo := &usermocks.UserRepositoryMock{
+		FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) {
+			return &models.User{ID: 6, Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: 5}}}, nil
+		},
+	}
+
+	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
+
+	user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{Mode: dto.AdminRoleAssignRemove, AdminRoleIDs: []uint{5}})
+
+	require.Nil(t, user)
+	var appErr *cerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.ErrorIs(t, err, cerrors.ErrInvalidInput)
+	require.Equal(t, "an admin must keep at least one admin role; demote the user instead", appErr.Message)
+	require.Empty(t, repo.UpdateCalls())
+	require.Empty(t, repo.SetAdminRolesCalls())
+}
+
 func TestUserServiceAssignAdminRolePropagatesUpdateErrorFromTransaction(t *testing.T) {
 	expectedErr := errors.New("update failed")
 	repo := &usermocks.UserRepositoryMock{
@@ -525,9 +641,9 @@ func TestUserServiceAssignAdminRolePropagatesUpdateErrorFromTransaction(t *testi
 		},
 	}
 
-	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, existingAdminRoleRepo(t, 5), &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
-	user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{AdminRoleID: 5})
+	user, err := svc.AssignAdminRole(context.Background(), 6, &dto.UserAssignAdminRoleRequest{Mode: dto.AdminRoleAssignAdd, AdminRoleIDs: []uint{5}})
 
 	require.Nil(t, user)
 	require.ErrorIs(t, err, expectedErr)
@@ -550,7 +666,7 @@ func TestUserServiceChangePasswordFailsWhenTokenRevocationFails(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	err := svc.ChangePassword(context.Background(), 10, &dto.ChangeAdminPasswordRequest{NewPassword: "new-password"})
 
@@ -595,7 +711,7 @@ func TestUserServiceChangePasswordUpdatesHashRevokesTokensAndLogs(t *testing.T)
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 	ctx := utils.SetRequestIDToContext(context.Background(), "req-3")
 	ctx = utils.NewContextWithValues(ctx, utils.ContextValues{UserID: 1, UserName: "Root"})
 
@@ -620,7 +736,7 @@ func TestUserServiceChangePasswordRejectsNonAdmin(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	err := svc.ChangePassword(context.Background(), 4, &dto.ChangeAdminPasswordRequest{NewPassword: "new-password"})
 
@@ -635,7 +751,7 @@ func TestUserServiceChangePasswordRejectsRootUser(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	err := svc.ChangePassword(context.Background(), 1, &dto.ChangeAdminPasswordRequest{NewPassword: "new-password"})
 
@@ -650,7 +766,7 @@ func TestUserServiceDeleteRejectsRootUser(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	err := svc.Delete(context.Background(), 1)
 
@@ -670,7 +786,7 @@ func TestUserServiceDeleteRejectsSelfDeletion(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 	// adminCallerValues has UserID 2 — target the same account.
 	ctx := utils.NewContextWithValues(context.Background(), adminCallerValues())
 
@@ -687,7 +803,7 @@ func TestUserServiceDeleteRequiresAdminUserGrantForAdminTargets(t *testing.T) {
 	newSvc := func(granted bool, deleted *bool) service.UserService {
 		repo := &usermocks.UserRepositoryMock{
 			FindByIDForUpdateFunc: func(context.Context, uint) (*models.User, error) {
-				return &models.User{ID: 6, Name: "Other Admin", Role: models.UserRoleAdmin, AdminRoleID: &roleID}, nil
+				return &models.User{ID: 6, Name: "Other Admin", Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}, nil
 			},
 			DeleteFunc: func(context.Context, *models.User) error {
 				*deleted = true
@@ -695,7 +811,7 @@ func TestUserServiceDeleteRequiresAdminUserGrantForAdminTargets(t *testing.T) {
 			},
 		}
 		casbinClient := &casbinmocks.ClientMock{
-			CheckPermissionWithRootFunc: func(role string, _ *uint, perm string) (bool, error) {
+			CheckPermissionWithRootFunc: func(role string, _ []uint, perm string) (bool, error) {
 				require.Equal(t, models.UserRoleAdmin.ToString(), role)
 				require.Equal(t, permissions.AdminUserDelete.String(), perm)
 				return granted, nil
@@ -705,7 +821,7 @@ func TestUserServiceDeleteRequiresAdminUserGrantForAdminTargets(t *testing.T) {
 			RevokeAllByUserIDFunc: func(context.Context, uint) error { return nil },
 		}
 		logRepo := &logmocks.LogRepositoryMock{CreateFunc: func(context.Context, *models.Log) error { return nil }}
-		return service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, casbinClient, passthroughTxManager(), zap.NewNop())
+		return service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, passthroughTxManager(), zap.NewNop())
 	}
 
 	t.Run("denied without admin_user:delete", func(t *testing.T) {
@@ -770,7 +886,7 @@ func TestUserServiceDeleteSoftDeletesRevokesTokensAndLogs(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, &casbinmocks.ClientMock{}, txManager, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, txManager, zap.NewNop())
 	ctx := utils.SetRequestIDToContext(context.Background(), "req-4")
 	ctx = utils.NewContextWithValues(ctx, utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
 
@@ -801,7 +917,7 @@ func TestUserServiceDeleteFailsWhenTokenRevocationFails(t *testing.T) {
 		RevokeAllByUserIDFunc: func(context.Context, uint) error { return expectedErr },
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, refreshRepo, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
 
 	err := svc.Delete(ctx, 6)
@@ -816,7 +932,7 @@ func TestUserServiceDeletePropagatesFindError(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, passthroughTxManager(), zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
 
 	err := svc.Delete(context.Background(), 99)
 
@@ -835,7 +951,7 @@ func TestUserServiceIndexReturnsRepositoryError(t *testing.T) {
 		},
 	}
 
-	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, &casbinmocks.ClientMock{}, &txmocks.TransactionManagerMock{}, zap.NewNop())
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, &txmocks.TransactionManagerMock{}, zap.NewNop())
 
 	users, meta, err := svc.Index(context.Background(), pagination.NewPagination(nil, nil, pagination.PaginationOptions{}))
 
@@ -843,3 +959,64 @@ func TestUserServiceIndexReturnsRepositoryError(t *testing.T) {
 	require.Equal(t, response.Meta{}, meta)
 	require.ErrorIs(t, err, expectedErr)
 }
+
+func TestUserServiceUnlockClearsLockoutAndLogs(t *testing.T) {
+	logCh := make(chan *models.Log, 1)
+	lockedUntil := time.Now().Add(time.Hour)
+	repo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Name: "Plain User", Role: models.UserRoleUser, FailedLoginAttempts: 7, LockedUntil: &lockedUntil}, nil
+		},
+		ClearFailedLoginsFunc: func(_ context.Context, id uint) error {
+			require.Equal(t, uint(6), id)
+			return nil
+		},
+	}
+	logRepo := &logmocks.LogRepositoryMock{
+		CreateFunc: func(_ context.Context, entry *models.Log) error {
+			logCh <- entry
+			return nil
+		},
+	}
+
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, logRepo, stubSecurityEventRepo(), stubPasswordPolicy(), &casbinmocks.ClientMock{}, nil, passthroughTxManager(), zap.NewNop())
+	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{UserID: 1, UserName: "Root", Role: models.UserRoleRoot.ToString()})
+
+	user, err := svc.Unlock(ctx, 6)
+
+	require.NoError(t, err)
+	require.Zero(t, user.FailedLoginAttempts)
+	require.Nil(t, user.LockedUntil)
+	require.Len(t, repo.ClearFailedLoginsCalls(), 1)
+	select {
+	case entry := <-logCh:
+		require.Equal(t, models.LogActionUnlockAccount, entry.Action)
+		require.Equal(t, uint(6), entry.EntityID)
+		require.Equal(t, "Root unlocked user: Plain User", entry.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("unlocking a user must produce an audit log")
+	}
+}
+
+func TestUserServiceUnlockRequiresAdminUserGrantForAdminTargets(t *testing.T) {
+	roleID := uint(5)
+	repo := &usermocks.UserRepositoryMock{
+		FindByIDFunc: func(_ context.Context, id uint, _ ...repository.Association) (*models.User, error) {
+			return &models.User{ID: id, Name: "Other Admin", Role: models.UserRoleAdmin, AdminRoles: []models.AdminRole{{ID: roleID}}}, nil
+		},
+	}
+	casbinClient := &casbinmocks.ClientMock{
+		CheckPermissionWithRootFunc: func(_ string, _ []uint, perm string) (bool, error) {
+			require.Equal(t, permissions.AdminUserUpdate.String(), perm)
+			return false, nil
+		},
+	}
+
+	svc := service.NewUserService(repo, &adminrolemocks.AdminRoleRepositoryMock{}, &refreshtokenmocks.RefreshTokenRepositoryMock{}, &logmocks.LogRepositoryMock{}, stubSecurityEventRepo(), stubPasswordPolicy(), casbinClient, nil, passthroughTxManager(), zap.NewNop())
+	ctx := utils.NewContextWithValues(context.Background(), adminCallerValues())
+
+	_, err := svc.Unlock(ctx, 6)
+
+	require.True(t, errors.Is(err, cerrors.ErrForbidden))
+	require.Empty(t, repo.ClearFailedLoginsCalls(), "the lockout must stay when the grant is missing")
+}
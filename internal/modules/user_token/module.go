@@ -0,0 +1,15 @@
+// Package user_token wires the one-time user-token module into the application container.
+package user_token
+
+import (
+	"github.com/PhantomX7/athleton/internal/modules/user_token/repository"
+
+	"go.uber.org/fx"
+)
+
+// Module registers the user-token module dependencies.
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewUserTokenRepository,
+	),
+)
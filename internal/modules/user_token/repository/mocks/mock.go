@@ -0,0 +1,554 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	usertokenrepository "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
+	"github.com/PhantomX7/athleton/pkg/pagination"
+	pkgrepository "github.com/PhantomX7/athleton/pkg/repository"
+)
+
+// Ensure, that UserTokenRepositoryMock does implement usertokenrepository.UserTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ usertokenrepository.UserTokenRepository = &UserTokenRepositoryMock{}
+
+// UserTokenRepositoryMock is a mock implementation of usertokenrepository.UserTokenRepository.
+//
+//	func TestSomethingThatUsesUserTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked usertokenrepository.UserTokenRepository
+//		mockedUserTokenRepository := &UserTokenRepositoryMock{
+//			ConsumeAllByUserIDFunc: func(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error {
+//				panic("mock out the ConsumeAllByUserID method")
+//			},
+//			ConsumeByTokenFunc: func(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+//				panic("mock out the ConsumeByToken method")
+//			},
+//			CountFunc: func(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+//				panic("mock out the Count method")
+//			},
+//			CreateFunc: func(ctx context.Context, entity *models.UserToken) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, entity *models.UserToken) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteInvalidTokenFunc: func(ctx context.Context) error {
+//				panic("mock out the DeleteInvalidToken method")
+//			},
+//			FindActiveByTokenFunc: func(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+//				panic("mock out the FindActiveByToken method")
+//			},
+//			FindAllFunc: func(ctx context.Context, pg *pagination.Pagination) ([]*models.UserToken, error) {
+//				panic("mock out the FindAll method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserToken, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			UpdateFunc: func(ctx context.Context, entity *models.UserToken) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedUserTokenRepository in code that requires usertokenrepository.UserTokenRepository
+//		// and then make assertions.
+//
+//	}
+type UserTokenRepositoryMock struct {
+	// ConsumeAllByUserIDFunc mocks the ConsumeAllByUserID method.
+	ConsumeAllByUserIDFunc func(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error
+
+	// ConsumeByTokenFunc mocks the ConsumeByToken method.
+	ConsumeByTokenFunc func(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error)
+
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, pg *pagination.Pagination) (int64, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, entity *models.UserToken) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, entity *models.UserToken) error
+
+	// DeleteInvalidTokenFunc mocks the DeleteInvalidToken method.
+	DeleteInvalidTokenFunc func(ctx context.Context) error
+
+	// FindActiveByTokenFunc mocks the FindActiveByToken method.
+	FindActiveByTokenFunc func(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error)
+
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(ctx context.Context, pg *pagination.Pagination) ([]*models.UserToken, error)
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserToken, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, entity *models.UserToken) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumeAllByUserID holds details about calls to the ConsumeAllByUserID method.
+		ConsumeAllByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uint
+			// Purpose is the purpose argument value.
+			Purpose models.UserTokenPurpose
+		}
+		// ConsumeByToken holds details about calls to the ConsumeByToken method.
+		ConsumeByToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Purpose is the purpose argument value.
+			Purpose models.UserTokenPurpose
+			// Token is the token argument value.
+			Token string
+		}
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserToken
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserToken
+		}
+		// DeleteInvalidToken holds details about calls to the DeleteInvalidToken method.
+		DeleteInvalidToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// FindActiveByToken holds details about calls to the FindActiveByToken method.
+		FindActiveByToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Purpose is the purpose argument value.
+			Purpose models.UserTokenPurpose
+			// Token is the token argument value.
+			Token string
+		}
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Pg is the pg argument value.
+			Pg *pagination.Pagination
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uint
+			// Preloads is the preloads argument value.
+			Preloads []pkgrepository.Association
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entity is the entity argument value.
+			Entity *models.UserToken
+		}
+	}
+	lockConsumeAllByUserID sync.RWMutex
+	lockConsumeByToken     sync.RWMutex
+	lockCount              sync.RWMutex
+	lockCreate             sync.RWMutex
+	lockDelete             sync.RWMutex
+	lockDeleteInvalidToken sync.RWMutex
+	lockFindActiveByToken  sync.RWMutex
+	lockFindAll            sync.RWMutex
+	lockFindByID           sync.RWMutex
+	lockUpdate             sync.RWMutex
+}
+
+// ConsumeAllByUserID calls ConsumeAllByUserIDFunc.
+func (mock *UserTokenRepositoryMock) ConsumeAllByUserID(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error {
+	if mock.ConsumeAllByUserIDFunc == nil {
+		panic("UserTokenRepositoryMock.ConsumeAllByUserIDFunc: method is nil but UserTokenRepository.ConsumeAllByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uint
+		Purpose models.UserTokenPurpose
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		Purpose: purpose,
+	}
+	mock.lockConsumeAllByUserID.Lock()
+	mock.calls.ConsumeAllByUserID = append(mock.calls.ConsumeAllByUserID, callInfo)
+	mock.lockConsumeAllByUserID.Unlock()
+	return mock.ConsumeAllByUserIDFunc(ctx, userID, purpose)
+}
+
+// ConsumeAllByUserIDCalls gets all the calls that were made to ConsumeAllByUserID.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.ConsumeAllByUserIDCalls())
+func (mock *UserTokenRepositoryMock) ConsumeAllByUserIDCalls() []struct {
+	Ctx     context.Context
+	UserID  uint
+	Purpose models.UserTokenPurpose
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uint
+		Purpose models.UserTokenPurpose
+	}
+	mock.lockConsumeAllByUserID.RLock()
+	calls = mock.calls.ConsumeAllByUserID
+	mock.lockConsumeAllByUserID.RUnlock()
+	return calls
+}
+
+// ConsumeByToken calls ConsumeByTokenFunc.
+func (mock *UserTokenRepositoryMock) ConsumeByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+	if mock.ConsumeByTokenFunc == nil {
+		panic("UserTokenRepositoryMock.ConsumeByTokenFunc: method is nil but UserTokenRepository.ConsumeByToken was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Purpose models.UserTokenPurpose
+		Token   string
+	}{
+		Ctx:     ctx,
+		Purpose: purpose,
+		Token:   token,
+	}
+	mock.lockConsumeByToken.Lock()
+	mock.calls.ConsumeByToken = append(mock.calls.ConsumeByToken, callInfo)
+	mock.lockConsumeByToken.Unlock()
+	return mock.ConsumeByTokenFunc(ctx, purpose, token)
+}
+
+// ConsumeByTokenCalls gets all the calls that were made to ConsumeByToken.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.ConsumeByTokenCalls())
+func (mock *UserTokenRepositoryMock) ConsumeByTokenCalls() []struct {
+	Ctx     context.Context
+	Purpose models.UserTokenPurpose
+	Token   string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Purpose models.UserTokenPurpose
+		Token   string
+	}
+	mock.lockConsumeByToken.RLock()
+	calls = mock.calls.ConsumeByToken
+	mock.lockConsumeByToken.RUnlock()
+	return calls
+}
+
+// Count calls CountFunc.
+func (mock *UserTokenRepositoryMock) Count(ctx context.Context, pg *pagination.Pagination) (int64, error) {
+	if mock.CountFunc == nil {
+		panic("UserTokenRepositoryMock.CountFunc: method is nil but UserTokenRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, pg)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.CountCalls())
+func (mock *UserTokenRepositoryMock) CountCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *UserTokenRepositoryMock) Create(ctx context.Context, entity *models.UserToken) error {
+	if mock.CreateFunc == nil {
+		panic("UserTokenRepositoryMock.CreateFunc: method is nil but UserTokenRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, entity)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.CreateCalls())
+func (mock *UserTokenRepositoryMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserToken
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *UserTokenRepositoryMock) Delete(ctx context.Context, entity *models.UserToken) error {
+	if mock.DeleteFunc == nil {
+		panic("UserTokenRepositoryMock.DeleteFunc: method is nil but UserTokenRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, entity)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.DeleteCalls())
+func (mock *UserTokenRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserToken
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteInvalidToken calls DeleteInvalidTokenFunc.
+func (mock *UserTokenRepositoryMock) DeleteInvalidToken(ctx context.Context) error {
+	if mock.DeleteInvalidTokenFunc == nil {
+		panic("UserTokenRepositoryMock.DeleteInvalidTokenFunc: method is nil but UserTokenRepository.DeleteInvalidToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDeleteInvalidToken.Lock()
+	mock.calls.DeleteInvalidToken = append(mock.calls.DeleteInvalidToken, callInfo)
+	mock.lockDeleteInvalidToken.Unlock()
+	return mock.DeleteInvalidTokenFunc(ctx)
+}
+
+// DeleteInvalidTokenCalls gets all the calls that were made to DeleteInvalidToken.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.DeleteInvalidTokenCalls())
+func (mock *UserTokenRepositoryMock) DeleteInvalidTokenCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDeleteInvalidToken.RLock()
+	calls = mock.calls.DeleteInvalidToken
+	mock.lockDeleteInvalidToken.RUnlock()
+	return calls
+}
+
+// FindActiveByToken calls FindActiveByTokenFunc.
+func (mock *UserTokenRepositoryMock) FindActiveByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+	if mock.FindActiveByTokenFunc == nil {
+		panic("UserTokenRepositoryMock.FindActiveByTokenFunc: method is nil but UserTokenRepository.FindActiveByToken was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Purpose models.UserTokenPurpose
+		Token   string
+	}{
+		Ctx:     ctx,
+		Purpose: purpose,
+		Token:   token,
+	}
+	mock.lockFindActiveByToken.Lock()
+	mock.calls.FindActiveByToken = append(mock.calls.FindActiveByToken, callInfo)
+	mock.lockFindActiveByToken.Unlock()
+	return mock.FindActiveByTokenFunc(ctx, purpose, token)
+}
+
+// FindActiveByTokenCalls gets all the calls that were made to FindActiveByToken.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.FindActiveByTokenCalls())
+func (mock *UserTokenRepositoryMock) FindActiveByTokenCalls() []struct {
+	Ctx     context.Context
+	Purpose models.UserTokenPurpose
+	Token   string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Purpose models.UserTokenPurpose
+		Token   string
+	}
+	mock.lockFindActiveByToken.RLock()
+	calls = mock.calls.FindActiveByToken
+	mock.lockFindActiveByToken.RUnlock()
+	return calls
+}
+
+// FindAll calls FindAllFunc.
+func (mock *UserTokenRepositoryMock) FindAll(ctx context.Context, pg *pagination.Pagination) ([]*models.UserToken, error) {
+	if mock.FindAllFunc == nil {
+		panic("UserTokenRepositoryMock.FindAllFunc: method is nil but UserTokenRepository.FindAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}{
+		Ctx: ctx,
+		Pg:  pg,
+	}
+	mock.lockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	mock.lockFindAll.Unlock()
+	return mock.FindAllFunc(ctx, pg)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.FindAllCalls())
+func (mock *UserTokenRepositoryMock) FindAllCalls() []struct {
+	Ctx context.Context
+	Pg  *pagination.Pagination
+} {
+	var calls []struct {
+		Ctx context.Context
+		Pg  *pagination.Pagination
+	}
+	mock.lockFindAll.RLock()
+	calls = mock.calls.FindAll
+	mock.lockFindAll.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *UserTokenRepositoryMock) FindByID(ctx context.Context, id uint, preloads ...pkgrepository.Association) (*models.UserToken, error) {
+	if mock.FindByIDFunc == nil {
+		panic("UserTokenRepositoryMock.FindByIDFunc: method is nil but UserTokenRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Preloads: preloads,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id, preloads...)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.FindByIDCalls())
+func (mock *UserTokenRepositoryMock) FindByIDCalls() []struct {
+	Ctx      context.Context
+	ID       uint
+	Preloads []pkgrepository.Association
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uint
+		Preloads []pkgrepository.Association
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *UserTokenRepositoryMock) Update(ctx context.Context, entity *models.UserToken) error {
+	if mock.UpdateFunc == nil {
+		panic("UserTokenRepositoryMock.UpdateFunc: method is nil but UserTokenRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}{
+		Ctx:    ctx,
+		Entity: entity,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, entity)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedUserTokenRepository.UpdateCalls())
+func (mock *UserTokenRepositoryMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	Entity *models.UserToken
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Entity *models.UserToken
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
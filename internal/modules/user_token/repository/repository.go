@@ -0,0 +1,153 @@
+// Package repository provides one-time user-token persistence primitives.
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PhantomX7/athleton/internal/generated"
+	"github.com/PhantomX7/athleton/internal/models"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+	"github.com/PhantomX7/athleton/pkg/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HashUserToken returns the at-rest form of a user-token value: a SHA-256 hex
+// digest, exactly like refresh tokens (see HashRefreshToken). The emailed
+// value is high-entropy random data, so an unsalted fast hash is sufficient
+// and keeps the column directly indexable.
+func HashUserToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . UserTokenRepository
+
+// UserTokenRepository defines the interface for one-time user-token operations.
+type UserTokenRepository interface {
+	repository.Repository[models.UserToken]
+	ConsumeByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error)
+	FindActiveByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error)
+	ConsumeAllByUserID(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error
+	DeleteInvalidToken(ctx context.Context) error
+}
+
+type userTokenRepository struct {
+	repository.BaseRepository[models.UserToken]
+}
+
+// NewUserTokenRepository constructs a UserTokenRepository.
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{
+		BaseRepository: repository.NewBaseRepository[models.UserToken](db),
+	}
+}
+
+// Create overrides BaseRepository.Create to hash the plaintext token before
+// persisting. Callers set TokenHash to the wire value they are about to email;
+// the stored row only ever contains its hash.
+func (r *userTokenRepository) Create(ctx context.Context, entity *models.UserToken) error {
+	if entity != nil && entity.TokenHash != "" {
+		entity.TokenHash = HashUserToken(entity.TokenHash)
+	}
+	return r.BaseRepository.Create(ctx, entity)
+}
+
+// purposeEq matches the purpose column. The column is a named string type, so
+// gorm-cli emits a struct helper for it rather than a comparable field.
+func purposeEq(purpose models.UserTokenPurpose) clause.Expression {
+	return clause.Eq{Column: clause.Column{Name: "purpose"}, Value: string(purpose)}
+}
+
+// ConsumeByToken redeems the unconsumed, unexpired token of the given purpose
+// matching the plaintext value and returns it. Redemption is a single
+// conditional UPDATE, so two concurrent requests presenting the same token
+// cannot both succeed: the loser's consumed_at IS NULL predicate no longer
+// matches. Every failure mode — unknown, expired, already used, wrong
+// purpose, lost race — is the same NotFound so callers cannot tell them apart.
+func (r *userTokenRepository) ConsumeByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+	now := time.Now()
+	hashed := HashUserToken(token)
+
+	ut, err := gorm.G[models.UserToken](r.GetDB(ctx)).
+		Where(generated.UserToken.TokenHash.Eq(hashed)).
+		Where(purposeEq(purpose)).
+		Where(generated.UserToken.ConsumedAt.IsNull()).
+		Where(generated.UserToken.ExpiresAt.Gt(now)).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cerrors.NewNotFoundError("invalid or expired token")
+		}
+		return nil, cerrors.NewInternalServerError("failed to find user token", err)
+	}
+
+	rows, err := gorm.G[models.UserToken](r.GetDB(ctx)).
+		Where(generated.UserToken.ID.Eq(ut.ID)).
+		Where(generated.UserToken.ConsumedAt.IsNull()).
+		Set(generated.UserToken.ConsumedAt.Set(now)).
+		Update(ctx)
+	if err != nil {
+		return nil, cerrors.NewInternalServerError("failed to consume user token", err)
+	}
+	if rows == 0 {
+		return nil, cerrors.NewNotFoundError("invalid or expired token")
+	}
+
+	ut.ConsumedAt = &now
+	return &ut, nil
+}
+
+// FindActiveByToken returns the unconsumed, unexpired token of the given
+// purpose matching the plaintext value without redeeming it, for links that
+// stay usable until they expire. Every failure mode is the same NotFound, as
+// with ConsumeByToken.
+func (r *userTokenRepository) FindActiveByToken(ctx context.Context, purpose models.UserTokenPurpose, token string) (*models.UserToken, error) {
+	ut, err := gorm.G[models.UserToken](r.GetDB(ctx)).
+		Where(generated.UserToken.TokenHash.Eq(HashUserToken(token))).
+		Where(purposeEq(purpose)).
+		Where(generated.UserToken.ConsumedAt.IsNull()).
+		Where(generated.UserToken.ExpiresAt.Gt(time.Now())).
+		First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cerrors.NewNotFoundError("invalid or expired token")
+		}
+		return nil, cerrors.NewInternalServerError("failed to find user token", err)
+	}
+	return &ut, nil
+}
+
+// ConsumeAllByUserID stamps consumed_at on every outstanding token of the
+// given purpose for the user. Issuing a fresh token calls this first so only
+// the most recently emailed link works.
+func (r *userTokenRepository) ConsumeAllByUserID(ctx context.Context, userID uint, purpose models.UserTokenPurpose) error {
+	_, err := gorm.G[models.UserToken](r.GetDB(ctx)).
+		Where(generated.UserToken.UserID.Eq(userID)).
+		Where(purposeEq(purpose)).
+		Where(generated.UserToken.ConsumedAt.IsNull()).
+		Set(generated.UserToken.ConsumedAt.Set(time.Now())).
+		Update(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError(fmt.Sprintf("failed to consume user tokens for user id %v", userID), err)
+	}
+	return nil
+}
+
+// DeleteInvalidToken hard-deletes tokens that have expired or been consumed.
+func (r *userTokenRepository) DeleteInvalidToken(ctx context.Context) error {
+	_, err := gorm.G[models.UserToken](r.GetDB(ctx)).
+		Where(generated.UserToken.ExpiresAt.Lt(time.Now())).
+		Or(generated.UserToken.ConsumedAt.IsNotNull()).
+		Delete(ctx)
+	if err != nil {
+		return cerrors.NewInternalServerError("failed to delete invalid user tokens", err)
+	}
+	return nil
+}
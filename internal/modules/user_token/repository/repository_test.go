@@ -0,0 +1,175 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/PhantomX7/athleton/internal/models"
+	usertokenrepository "github.com/PhantomX7/athleton/internal/modules/user_token/repository"
+	cerrors "github.com/PhantomX7/athleton/pkg/errors"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.AdminRole{}, &models.User{}, &models.UserToken{}))
+
+	return db
+}
+
+func seedUser(t *testing.T, db *gorm.DB, username string) *models.User {
+	t.Helper()
+
+	user := &models.User{
+		Username: username,
+		Name:     username,
+		Email:    username + "@example.com",
+		Phone:    "08123456789",
+		IsActive: true,
+		Role:     models.UserRoleUser,
+		Password: "secret",
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func createToken(t *testing.T, repo usertokenrepository.UserTokenRepository, userID uint, purpose models.UserTokenPurpose, plaintext string, expiresAt time.Time) {
+	t.Helper()
+
+	require.NoError(t, repo.Create(context.Background(), &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: plaintext,
+		ExpiresAt: expiresAt,
+	}))
+}
+
+func TestUserTokenRepositoryCreateStoresHash(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	user := seedUser(t, db, "alma")
+
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "emailed-value", time.Now().Add(time.Hour))
+
+	var stored models.UserToken
+	require.NoError(t, db.First(&stored).Error)
+	require.Equal(t, usertokenrepository.HashUserToken("emailed-value"), stored.TokenHash)
+	require.NotContains(t, stored.TokenHash, "emailed-value")
+}
+
+func TestUserTokenRepositoryConsumeByTokenIsSingleUse(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	user := seedUser(t, db, "alma")
+	ctx := context.Background()
+
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "once", time.Now().Add(time.Hour))
+
+	ut, err := repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "once")
+	require.NoError(t, err)
+	require.Equal(t, user.ID, ut.UserID)
+	require.NotNil(t, ut.ConsumedAt)
+
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "once")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+}
+
+func TestUserTokenRepositoryConsumeByTokenRejectsExpiredAndWrongPurpose(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	user := seedUser(t, db, "alma")
+	ctx := context.Background()
+
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "stale", time.Now().Add(-time.Minute))
+	_, err := repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "stale")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "scoped", time.Now().Add(time.Hour))
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurpose("other"), "scoped")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "never-issued")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+}
+
+func TestUserTokenRepositoryFindActiveByTokenDoesNotConsume(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	user := seedUser(t, db, "alma")
+	ctx := context.Background()
+
+	createToken(t, repo, user.ID, models.UserTokenPurposeDataExport, "reusable", time.Now().Add(time.Hour))
+	for range 2 {
+		ut, err := repo.FindActiveByToken(ctx, models.UserTokenPurposeDataExport, "reusable")
+		require.NoError(t, err)
+		require.Equal(t, user.ID, ut.UserID)
+		require.Nil(t, ut.ConsumedAt)
+	}
+
+	_, err := repo.FindActiveByToken(ctx, models.UserTokenPurposePasswordReset, "reusable")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	require.NoError(t, repo.ConsumeAllByUserID(ctx, user.ID, models.UserTokenPurposeDataExport))
+	_, err = repo.FindActiveByToken(ctx, models.UserTokenPurposeDataExport, "reusable")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	createToken(t, repo, user.ID, models.UserTokenPurposeDataExport, "stale", time.Now().Add(-time.Minute))
+	_, err = repo.FindActiveByToken(ctx, models.UserTokenPurposeDataExport, "stale")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+}
+
+func TestUserTokenRepositoryConsumeAllByUserIDOnlyTouchesOwnerAndPurpose(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	alma := seedUser(t, db, "alma")
+	bruno := seedUser(t, db, "bruno")
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+
+	createToken(t, repo, alma.ID, models.UserTokenPurposePasswordReset, "alma-1", expires)
+	createToken(t, repo, alma.ID, models.UserTokenPurposePasswordReset, "alma-2", expires)
+	createToken(t, repo, alma.ID, models.UserTokenPurpose("other"), "alma-other", expires)
+	createToken(t, repo, bruno.ID, models.UserTokenPurposePasswordReset, "bruno-1", expires)
+
+	require.NoError(t, repo.ConsumeAllByUserID(ctx, alma.ID, models.UserTokenPurposePasswordReset))
+
+	_, err := repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "alma-1")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "alma-2")
+	require.ErrorIs(t, err, cerrors.ErrNotFound)
+
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurpose("other"), "alma-other")
+	require.NoError(t, err)
+	_, err = repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "bruno-1")
+	require.NoError(t, err)
+}
+
+func TestUserTokenRepositoryDeleteInvalidToken(t *testing.T) {
+	db := setupDB(t)
+	repo := usertokenrepository.NewUserTokenRepository(db)
+	user := seedUser(t, db, "alma")
+	ctx := context.Background()
+
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "expired", time.Now().Add(-time.Minute))
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "consumed", time.Now().Add(time.Hour))
+	createToken(t, repo, user.ID, models.UserTokenPurposePasswordReset, "live", time.Now().Add(time.Hour))
+	_, err := repo.ConsumeByToken(ctx, models.UserTokenPurposePasswordReset, "consumed")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteInvalidToken(ctx))
+
+	var remaining []models.UserToken
+	require.NoError(t, db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	require.Equal(t, usertokenrepository.HashUserToken("live"), remaining[0].TokenHash)
+}
@@ -17,6 +17,9 @@ type Context struct {
 	Root *gin.RouterGroup
 	// Public is /api/v1/public for unauthenticated read endpoints.
 	Public *gin.RouterGroup
+	// WellKnown is the unversioned /.well-known prefix for discovery documents
+	// whose paths are fixed by their RFCs.
+	WellKnown *gin.RouterGroup
 	// Admin is /api/v1/admin with RequireAuth already attached.
 	Admin *gin.RouterGroup
 	// MW exposes the middleware bundle for per-route guards (permissions, roles).
@@ -38,9 +41,10 @@ type registerParams struct {
 func RegisterRoutes(engine *gin.Engine, middleware *middlewares.Middleware, params registerParams) {
 	root := engine.Group("/api/v1")
 	ctx := &Context{
-		Root:   root,
-		Public: root.Group("/public"),
-		MW:     middleware,
+		Root:      root,
+		Public:    root.Group("/public"),
+		WellKnown: engine.Group("/.well-known"),
+		MW:        middleware,
 	}
 	if middleware != nil {
 		// Rate limit before auth so rejected floods don't pay JWT parsing.
@@ -52,12 +56,15 @@ func RegisterRoutes(engine *gin.Engine, middleware *middlewares.Middleware, para
 		// seeded admin/root accounts that still use the default password. The
 		// escape hatches — /auth/change-password and /auth/logout — are
 		// mounted on Root by the auth module, outside this group, so gated
-		// accounts can always rotate their password.
+		// accounts can always rotate their password. RequireTwoFactor gates
+		// the same way on 2FA enrolment when it is mandatory for admins; its
+		// enrolment endpoints live under /auth/2fa for the same reason.
 		ctx.Admin = root.Group("/admin",
 			middleware.AdminRateLimiter(),
 			middleware.RequireAuth(),
 			middleware.RequireRole(models.UserRoleAdmin.ToString(), models.UserRoleRoot.ToString()),
 			middleware.RequirePasswordChanged(),
+			middleware.RequireTwoFactor(),
 		)
 	}
 
@@ -24,6 +24,9 @@ func (betaRegistrar) RegisterRoutes(ctx *Context) {
 	ctx.Public.GET("/beta", func(c *gin.Context) {
 		c.String(http.StatusOK, "beta")
 	})
+	ctx.WellKnown.GET("/gamma", func(c *gin.Context) {
+		c.String(http.StatusOK, "gamma")
+	})
 }
 
 func TestRegisterRoutesMountsGroupedRegistrars(t *testing.T) {
@@ -48,4 +51,10 @@ func TestRegisterRoutesMountsGroupedRegistrars(t *testing.T) {
 	engine.ServeHTTP(betaRec, betaReq)
 	require.Equal(t, http.StatusOK, betaRec.Code)
 	require.Equal(t, "beta", betaRec.Body.String())
+
+	gammaReq := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/.well-known/gamma", nil)
+	gammaRec := httptest.NewRecorder()
+	engine.ServeHTTP(gammaRec, gammaReq)
+	require.Equal(t, http.StatusOK, gammaRec.Code)
+	require.Equal(t, "gamma", gammaRec.Body.String())
 }
@@ -0,0 +1,20 @@
+package casbin
+
+import (
+	"slices"
+
+	"github.com/PhantomX7/athleton/pkg/utils"
+)
+
+// CheckCaller reports whether the authenticated caller described by values
+// holds perm. A request made with an API key must also have perm in the key's
+// scope: the key can narrow what its owner may do, never widen it, and a later
+// change to the owner's roles applies to the key immediately. Route guards and
+// the finer checks services make inside a handler both go through here, so a
+// narrow key is held to its scope everywhere.
+func CheckCaller(client Client, values *utils.ContextValues, perm string) (bool, error) {
+	if values.APIKeyID != 0 && !slices.Contains(values.APIKeyPermissions, perm) {
+		return false, nil
+	}
+	return client.CheckPermissionWithRoot(values.Role, values.AdminRoleIDs, perm)
+}
@@ -2,12 +2,16 @@
 package casbin
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/casbin/casbin/v3"
 	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/util"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"gorm.io/gorm"
 )
@@ -15,6 +19,29 @@ import (
 // Policy types for Casbin
 const (
 	PolicyTypePermission = "p" // role -> permission mapping
+	PolicyTypeRoleParent = "g" // role -> parent role mapping
+)
+
+// Policy effects. A role is allowed a permission when one of its (or its
+// ancestors') allow rules matches and none of their deny rules do.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// actionManage is the action that grants, or denies, every action on a
+// resource.
+const actionManage = "manage"
+
+// MaxRoleDepth is the longest chain of roles, counting the role itself, that
+// SetRoleParent allows. Casbin's role manager stops following links after 10
+// hops, so a deeper chain would silently lose its oldest ancestors' grants.
+const MaxRoleDepth = 10
+
+// Errors returned by SetRoleParent.
+var (
+	ErrRoleCycle            = errors.New("role hierarchy would contain a cycle")
+	ErrRoleHierarchyTooDeep = fmt.Errorf("role hierarchy would be deeper than %d roles", MaxRoleDepth)
 )
 
 //go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . Client
@@ -29,10 +56,25 @@ type Client interface {
 	RemoveRolePermissions(roleID uint, permissions []string) error
 	SetRolePermissions(roleID uint, permissions []string) error
 	GetRolePermissions(roleID uint) []string
+	GetDirectRolePermissions(roleID uint) []string
+
+	// Role-Denial management
+	SetRoleDenials(roleID uint, denials []string) error
+	GetRoleDenials(roleID uint) []string
+	GetDirectRoleDenials(roleID uint) []string
+
+	// Role hierarchy
+	SetRoleParent(roleID uint, parentID *uint) error
+	GetRoleParent(roleID uint) *uint
+	GetChildRoles(roleID uint) []uint
 
 	// Permission checking
 	CheckPermission(roleID uint, permission string) (bool, error)
-	CheckPermissionWithRoot(userRole string, adminRoleID *uint, permission string) (bool, error)
+	CheckPermissionWithRoot(userRole string, adminRoleIDs []uint, permission string) (bool, error)
+
+	// Role status
+	SetRoleActive(roleID uint, active bool)
+	IsRoleActive(roleID uint) bool
 
 	// Cleanup
 	DeleteRole(roleID uint) error
@@ -47,6 +89,14 @@ type client struct {
 	// would trigger a fatal "concurrent map read and map write" and crash the
 	// whole process. SyncedEnforcer wraps every method below with an RWMutex.
 	enforcer *casbin.SyncedEnforcer
+
+	// inactive holds the IDs of deactivated roles. It is kept beside the
+	// policies rather than in them: a deactivated role keeps its grants so
+	// that reactivating it restores them unchanged, but its holders are denied
+	// everything meanwhile. The admin_roles.is_active column is the durable
+	// copy; the admin-role module loads it at startup and updates both.
+	inactiveMu sync.RWMutex
+	inactive   map[uint]struct{}
 }
 
 // New creates a new Casbin client instance
@@ -56,15 +106,26 @@ func New(db *gorm.DB) (Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize casbin adapter: %w", err)
 	}
+	if err := upgradeLegacyPolicies(db); err != nil {
+		return nil, err
+	}
 
 	// Create casbin RBAC model
-	// Simple model: role has permissions
-	// Format: p, role_id, resource, action
+	// A role has allow and deny rules and inherits those of its ancestors; a
+	// matching deny rule always wins. A "manage" rule matches every action.
+	// Allow rules only count while every role between the requesting one and
+	// the rule's owner, both included, is active (grantsReach); deny rules
+	// apply whatever the status.
+	// Format: p, role_id, resource, action, allow|deny
+	//         g, role_id, parent_role_id
 	m := model.NewModel()
-	m.AddDef("r", "r", "sub, obj, act")                                                        // Request: role_id, resource, action
-	m.AddDef("p", "p", "sub, obj, act")                                                        // Policy: role_id, resource, action
-	m.AddDef("e", "e", "some(where (p.eft == allow))")                                         // Effect: allow if any policy matches
-	m.AddDef("m", "m", "r.sub == p.sub && keyMatch2(r.obj, p.obj) && keyMatch2(r.act, p.act)") // Matcher
+	m.AddDef("r", "r", "sub, obj, act")                                                // Request: role_id, resource, action
+	m.AddDef("p", "p", "sub, obj, act, eft")                                           // Policy: role_id, resource, action, effect
+	m.AddDef("g", "g", "_, _")                                                         // Role definition: role_id inherits parent_role_id
+	m.AddDef("e", "e", "some(where (p.eft == allow)) && !some(where (p.eft == deny))") // Effect: allow if an allow rule matches and no deny rule does
+	m.AddDef("e", "e2", "!some(where (p.eft == deny))")                                // Effect for denyContext: true unless a deny rule matches
+	m.AddDef("m", "m", `g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && (keyMatch2(r.act, p.act) || p.act == "manage") && `+
+		`(p.eft == "deny" || grantsReach(r.sub, p.sub))`) // Matcher
 
 	// Create a synced (mutex-guarded) enforcer with model and adapter. See the
 	// client.enforcer field comment for why the synced variant is required.
@@ -73,14 +134,25 @@ func New(db *gorm.DB) (Client, error) {
 		return nil, fmt.Errorf("failed to create casbin enforcer: %w", err)
 	}
 
+	c := &client{
+		enforcer: enforcer,
+		inactive: make(map[uint]struct{}),
+	}
+	enforcer.AddFunction("grantsReach", func(args ...any) (any, error) {
+		if len(args) != 2 {
+			return false, fmt.Errorf("grantsReach: expected 2 arguments, got %d", len(args))
+		}
+		subject, _ := args[0].(string)
+		owner, _ := args[1].(string)
+		return c.grantsReach(subject, owner), nil
+	})
+
 	// Load policies from database
 	if err := enforcer.LoadPolicy(); err != nil {
 		return nil, fmt.Errorf("failed to load policy from DB: %w", err)
 	}
 
-	return &client{
-		enforcer: enforcer,
-	}, nil
+	return c, nil
 }
 
 func (c *client) GetEnforcer() *casbin.Enforcer {
@@ -90,6 +162,19 @@ func (c *client) GetEnforcer() *casbin.Enforcer {
 	return c.enforcer.Enforcer
 }
 
+// upgradeLegacyPolicies marks permission rules stored before the model had an
+// effect field as allow rules. Casbin refuses to load a rule with fewer
+// fields than the model defines, so this must run before the enforcer does.
+func upgradeLegacyPolicies(db *gorm.DB) error {
+	err := db.Model(&gormadapter.CasbinRule{}).
+		Where("ptype = ? AND v3 = ?", PolicyTypePermission, "").
+		Update("v3", EffectAllow).Error
+	if err != nil {
+		return fmt.Errorf("failed to upgrade legacy policies: %w", err)
+	}
+	return nil
+}
+
 // roleSubject converts role ID to Casbin subject format
 func roleSubject(roleID uint) string {
 	return fmt.Sprintf("role:%d", roleID)
@@ -114,8 +199,8 @@ func (c *client) AddRolePermissions(roleID uint, permissions []string) error {
 			return err
 		}
 
-		// Add policy: role_id, resource, action
-		_, err = c.enforcer.AddPolicy(subject, resource, action)
+		// Add policy: role_id, resource, action, allow
+		_, err = c.enforcer.AddPolicy(subject, resource, action, EffectAllow)
 		if err != nil {
 			return fmt.Errorf("failed to add permission %s: %w", perm, err)
 		}
@@ -134,7 +219,7 @@ func (c *client) RemoveRolePermissions(roleID uint, permissions []string) error
 			return err
 		}
 
-		_, err = c.enforcer.RemovePolicy(subject, resource, action)
+		_, err = c.enforcer.RemovePolicy(subject, resource, action, EffectAllow)
 		if err != nil {
 			return fmt.Errorf("failed to remove permission %s: %w", perm, err)
 		}
@@ -158,6 +243,18 @@ func (c *client) RemoveRolePermissions(roleID uint, permissions []string) error
 // two steps can only ever leave the role briefly over-permissioned (logged via
 // the returned error), never missing a permission it should have.
 func (c *client) SetRolePermissions(roleID uint, permissions []string) error {
+	return c.setRoleRules(roleID, permissions, EffectAllow)
+}
+
+// SetRoleDenials replaces all deny rules for a role, diffing them the way
+// SetRolePermissions diffs grants. New deny rules are added before stale ones
+// are removed, so a failure can only leave the role briefly denied too much.
+func (c *client) SetRoleDenials(roleID uint, denials []string) error {
+	return c.setRoleRules(roleID, denials, EffectDeny)
+}
+
+// setRoleRules replaces the role's rules that have the given effect.
+func (c *client) setRoleRules(roleID uint, permissions []string, effect string) error {
 	subject := roleSubject(roleID)
 
 	// Build the desired rule set, validating every permission up front so a bad
@@ -174,23 +271,23 @@ func (c *client) SetRolePermissions(roleID uint, permissions []string) error {
 			continue
 		}
 		desiredSet[key] = struct{}{}
-		desiredRules[key] = []string{subject, resource, action}
+		desiredRules[key] = []string{subject, resource, action, effect}
 	}
 
-	current, err := c.enforcer.GetFilteredPolicy(0, subject)
+	current, err := c.enforcer.GetFilteredPolicy(0, subject, "", "", effect)
 	if err != nil {
-		return fmt.Errorf("failed to read current permissions for role %d: %w", roleID, err)
+		return fmt.Errorf("failed to read current %s rules for role %d: %w", effect, roleID, err)
 	}
 	currentSet := make(map[string]struct{}, len(current))
 	var toRemove [][]string
 	for _, p := range current {
-		if len(p) < 3 {
+		if len(p) < 4 {
 			continue
 		}
 		key := p[1] + ":" + p[2]
 		currentSet[key] = struct{}{}
 		if _, keep := desiredSet[key]; !keep {
-			toRemove = append(toRemove, []string{p[0], p[1], p[2]})
+			toRemove = append(toRemove, []string{p[0], p[1], p[2], p[3]})
 		}
 	}
 
@@ -203,88 +300,369 @@ func (c *client) SetRolePermissions(roleID uint, permissions []string) error {
 
 	if len(toAdd) > 0 {
 		if _, err := c.enforcer.AddPolicies(toAdd); err != nil {
-			return fmt.Errorf("failed to add permissions for role %d: %w", roleID, err)
+			return fmt.Errorf("failed to add %s rules for role %d: %w", effect, roleID, err)
 		}
 	}
 	if len(toRemove) > 0 {
 		if _, err := c.enforcer.RemovePolicies(toRemove); err != nil {
-			return fmt.Errorf("failed to remove stale permissions for role %d: %w", roleID, err)
+			return fmt.Errorf("failed to remove stale %s rules for role %d: %w", effect, roleID, err)
 		}
 	}
 
 	return nil
 }
 
-// GetRolePermissions returns all permissions for a role
+// GetRolePermissions returns the effective permissions of a role: its own
+// grants first, then those inherited from each ancestor, nearest first. A
+// grant wholly covered by one of the role's effective deny rules is left
+// out; one only partly denied, such as "user:*" beside a "user:delete" deny,
+// is kept.
 func (c *client) GetRolePermissions(roleID uint) []string {
+	denials := c.GetRoleDenials(roleID)
+
+	permissions := make([]string, 0)
+	for _, perm := range c.effectiveRules(roleID, EffectAllow) {
+		if !slices.ContainsFunc(denials, func(denial string) bool { return covers(denial, perm) }) {
+			permissions = append(permissions, perm)
+		}
+	}
+	return permissions
+}
+
+// GetDirectRolePermissions returns the permissions granted to the role
+// itself, without those it inherits.
+func (c *client) GetDirectRolePermissions(roleID uint) []string {
+	return c.directRules(roleID, EffectAllow)
+}
+
+// GetRoleDenials returns the effective deny rules of a role: its own first,
+// then those inherited from each ancestor, nearest first.
+func (c *client) GetRoleDenials(roleID uint) []string {
+	return c.effectiveRules(roleID, EffectDeny)
+}
+
+// GetDirectRoleDenials returns the deny rules set on the role itself,
+// without those it inherits.
+func (c *client) GetDirectRoleDenials(roleID uint) []string {
+	return c.directRules(roleID, EffectDeny)
+}
+
+// effectiveRules returns the role's own rules with the given effect, then
+// those of the ancestors it inherits them from, without duplicates. Deny
+// rules come from every ancestor; allow rules stop at an inactive one (see
+// inheritedAncestors).
+func (c *client) effectiveRules(roleID uint, effect string) []string {
+	rules := c.directRules(roleID, effect)
+
+	seen := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		seen[rule] = struct{}{}
+	}
+	ancestors := c.ancestors(roleID)
+	if effect == EffectAllow {
+		ancestors = c.inheritedAncestors(roleID)
+	}
+	for _, ancestorID := range ancestors {
+		for _, rule := range c.directRules(ancestorID, effect) {
+			if _, dup := seen[rule]; dup {
+				continue
+			}
+			seen[rule] = struct{}{}
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// directRules returns the role's own rules with the given effect in
+// "resource:action" format.
+func (c *client) directRules(roleID uint, effect string) []string {
 	subject := roleSubject(roleID)
-	policies, _ := c.enforcer.GetFilteredPolicy(0, subject)
+	policies, _ := c.enforcer.GetFilteredPolicy(0, subject, "", "", effect)
 
-	permissions := make([]string, 0, len(policies))
+	rules := make([]string, 0, len(policies))
 	for _, policy := range policies {
 		if len(policy) >= 3 {
 			// Reconstruct "resource:action" format
-			perm := policy[1] + ":" + policy[2]
-			permissions = append(permissions, perm)
+			rules = append(rules, policy[1]+":"+policy[2])
 		}
 	}
 
-	return permissions
+	return rules
 }
 
-// CheckPermission checks if a role has a specific permission
-func (c *client) CheckPermission(roleID uint, permission string) (bool, error) {
-	resource, action, err := parsePermission(permission)
+// covers reports whether the rule pattern matches every request perm
+// matches, by the same rules as the enforcer's matcher. It only shapes the
+// listings of GetRolePermissions; permission checks go through Enforce.
+func covers(pattern, perm string) bool {
+	patternResource, patternAction, err := parsePermission(pattern)
 	if err != nil {
-		return false, err
+		return false
+	}
+	resource, action, err := parsePermission(perm)
+	if err != nil {
+		return false
 	}
+	return util.KeyMatch2(resource, patternResource) &&
+		(util.KeyMatch2(action, patternAction) || patternAction == actionManage)
+}
 
+// SetRoleParent makes the role inherit every permission of parentID, or
+// removes its parent when parentID is nil. A role has at most one parent. It
+// fails with ErrRoleCycle if the role would become its own ancestor and with
+// ErrRoleHierarchyTooDeep if a chain would exceed MaxRoleDepth.
+func (c *client) SetRoleParent(roleID uint, parentID *uint) error {
 	subject := roleSubject(roleID)
 
-	// Check exact permission first
-	allowed, err := c.enforcer.Enforce(subject, resource, action)
-	if err != nil {
-		return false, fmt.Errorf("failed to check permission: %w", err)
+	if parentID != nil {
+		if *parentID == roleID || slices.Contains(c.ancestors(*parentID), roleID) {
+			return ErrRoleCycle
+		}
+		// The longest chain through the new link runs from the role's
+		// deepest descendant up to the parent's root.
+		if c.descendantDepth(roleID)+1+len(c.ancestors(*parentID))+1 > MaxRoleDepth {
+			return ErrRoleHierarchyTooDeep
+		}
 	}
-	if allowed {
-		return true, nil
+
+	current := c.GetRoleParent(roleID)
+	if current == nil && parentID == nil || current != nil && parentID != nil && *current == *parentID {
+		return nil
+	}
+
+	// Link the new parent before unlinking the old one, for the same reason
+	// SetRolePermissions adds before it removes.
+	if parentID != nil {
+		if _, err := c.enforcer.AddGroupingPolicy(subject, roleSubject(*parentID)); err != nil {
+			return fmt.Errorf("failed to set parent of role %d: %w", roleID, err)
+		}
+	}
+	if current != nil {
+		if _, err := c.enforcer.RemoveGroupingPolicy(subject, roleSubject(*current)); err != nil {
+			return fmt.Errorf("failed to remove old parent of role %d: %w", roleID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRoleParent returns the role's parent, or nil when it has none.
+func (c *client) GetRoleParent(roleID uint) *uint {
+	links, _ := c.enforcer.GetFilteredGroupingPolicy(0, roleSubject(roleID))
+	for _, link := range links {
+		if len(link) < 2 {
+			continue
+		}
+		if parentID, err := ParseRoleIDFromSubject(link[1]); err == nil {
+			return &parentID
+		}
+	}
+	return nil
+}
+
+// GetChildRoles returns the roles that directly inherit from roleID.
+func (c *client) GetChildRoles(roleID uint) []uint {
+	links, _ := c.enforcer.GetFilteredGroupingPolicy(1, roleSubject(roleID))
+
+	children := make([]uint, 0, len(links))
+	for _, link := range links {
+		if childID, err := ParseRoleIDFromSubject(link[0]); err == nil {
+			children = append(children, childID)
+		}
+	}
+	return children
+}
+
+// ancestors returns the role's parent, grandparent and so on, nearest first.
+// The walk is bounded by MaxRoleDepth so a cycle written to the policy table
+// by hand cannot loop forever.
+func (c *client) ancestors(roleID uint) []uint {
+	var chain []uint
+	current := roleID
+	for range MaxRoleDepth {
+		parentID := c.GetRoleParent(current)
+		if parentID == nil || *parentID == roleID || slices.Contains(chain, *parentID) {
+			break
+		}
+		chain = append(chain, *parentID)
+		current = *parentID
+	}
+	return chain
+}
+
+// inheritedAncestors returns the ancestors the role inherits grants from,
+// nearest first. It stops at the first inactive ancestor: a deactivated role
+// passes no grants down, neither its own nor those it inherits itself. Deny
+// rules are not cut off this way, so deactivating a role can only ever take
+// permissions away.
+func (c *client) inheritedAncestors(roleID uint) []uint {
+	chain := c.ancestors(roleID)
+	for i, ancestorID := range chain {
+		if !c.IsRoleActive(ancestorID) {
+			return chain[:i]
+		}
 	}
+	return chain
+}
 
-	// Check "manage" permission (grants ALL actions for a resource)
-	allowed, err = c.enforcer.Enforce(subject, resource, "manage")
+// descendantDepth returns how many levels of roles inherit from roleID.
+func (c *client) descendantDepth(roleID uint) int {
+	return c.descendantDepthFrom(roleID, 0)
+}
+
+func (c *client) descendantDepthFrom(roleID uint, level int) int {
+	if level >= MaxRoleDepth {
+		return level
+	}
+	deepest := 0
+	for _, childID := range c.GetChildRoles(roleID) {
+		deepest = max(deepest, 1+c.descendantDepthFrom(childID, level+1))
+	}
+	return deepest
+}
+
+// CheckPermission checks if a role has a specific permission. The matcher's
+// keyMatch2 lets a "*" resource or action in a policy match anything, and a
+// "manage" rule matches every action of its resource. A deny rule matching
+// the permission, on the role or an ancestor, wins over any allow rule. An
+// inactive role holds nothing, and grants stop at an inactive ancestor.
+func (c *client) CheckPermission(roleID uint, permission string) (bool, error) {
+	resource, action, err := parsePermission(permission)
+	if err != nil {
+		return false, err
+	}
+
+	allowed, err := c.enforcer.Enforce(roleSubject(roleID), resource, action)
 	if err != nil {
-		return false, fmt.Errorf("failed to check manage permission: %w", err)
+		return false, fmt.Errorf("failed to check permission: %w", err)
 	}
 
 	return allowed, nil
 }
 
+// grantsReach reports whether the allow rules of the role subject owner reach
+// the role subject: subject is owner or inherits from it, and every role on
+// the way, both ends included, is active. The matcher calls it while Enforce
+// holds the enforcer's read lock, so it reads the links through the base
+// enforcer's role manager rather than the locking wrappers.
+func (c *client) grantsReach(subject, owner string) bool {
+	rm := c.enforcer.Enforcer.GetRoleManager()
+	current := subject
+	for range MaxRoleDepth {
+		roleID, err := ParseRoleIDFromSubject(current)
+		if err != nil || !c.IsRoleActive(roleID) {
+			return false
+		}
+		if current == owner {
+			return true
+		}
+		parents, err := rm.GetRoles(current)
+		if err != nil || len(parents) == 0 {
+			return false
+		}
+		current = parents[0]
+	}
+	return false
+}
+
 // CheckPermissionWithRoot checks permission with root bypass
 // userRole: the user's role type (root, admin, user, etc.)
-// adminRoleID: the admin_role_id if user is admin (can be nil)
+// adminRoleIDs: the admin roles assigned to the user (empty if none)
 // permission: the permission to check (e.g., "product:create")
-func (c *client) CheckPermissionWithRoot(userRole string, adminRoleID *uint, permission string) (bool, error) {
+// An admin is allowed if any of their active roles grants the permission and
+// none of their roles denies it: a deny rule wins across roles as it does
+// within one, and stays in force while its role is inactive.
+func (c *client) CheckPermissionWithRoot(userRole string, adminRoleIDs []uint, permission string) (bool, error) {
 	// Root bypasses all permission checks
 	if userRole == "root" {
 		return true, nil
 	}
 
-	// If user is not admin or has no admin role, deny
-	if userRole != "admin" || adminRoleID == nil {
+	// If user is not admin, deny
+	if userRole != "admin" {
 		return false, nil
 	}
 
-	// Check permission for the admin role
-	return c.CheckPermission(*adminRoleID, permission)
+	allowed := false
+	for _, roleID := range adminRoleIDs {
+		roleAllowed, err := c.CheckPermission(roleID, permission)
+		if err != nil {
+			return false, err
+		}
+		if roleAllowed {
+			allowed = true
+			continue
+		}
+		// Not allowed by this role, possibly because it is inactive: its
+		// deny rules still hold against the other roles
+		denied, err := c.denies(roleID, permission)
+		if err != nil {
+			return false, err
+		}
+		if denied {
+			return false, nil
+		}
+	}
+	return allowed, nil
+}
+
+// denyContext evaluates the model's matcher under the e2 effect, which is
+// true unless a deny rule matches.
+var denyContext = casbin.EnforceContext{RType: "r", PType: "p", EType: "e2", MType: "m"}
+
+// denies reports whether one of the role's effective deny rules matches the
+// permission.
+func (c *client) denies(roleID uint, permission string) (bool, error) {
+	resource, action, err := parsePermission(permission)
+	if err != nil {
+		return false, err
+	}
+
+	notDenied, err := c.enforcer.Enforce(denyContext, roleSubject(roleID), resource, action)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+
+	return !notDenied, nil
+}
+
+// SetRoleActive marks a role active or inactive. While a role is inactive,
+// CheckPermissionWithRoot grants its holders nothing through it and the roles
+// below it inherit no grants through it; its deny rules, and those it
+// inherits, keep applying. The role's policies themselves are left untouched.
+func (c *client) SetRoleActive(roleID uint, active bool) {
+	c.inactiveMu.Lock()
+	defer c.inactiveMu.Unlock()
+
+	if active {
+		delete(c.inactive, roleID)
+		return
+	}
+	c.inactive[roleID] = struct{}{}
 }
 
-// DeleteRole removes all permissions for a role
+// IsRoleActive reports whether a role is active. Roles are active unless
+// SetRoleActive marked them otherwise.
+func (c *client) IsRoleActive(roleID uint) bool {
+	c.inactiveMu.RLock()
+	defer c.inactiveMu.RUnlock()
+
+	_, inactive := c.inactive[roleID]
+	return !inactive
+}
+
+// DeleteRole removes all permissions for a role and its parent link
 func (c *client) DeleteRole(roleID uint) error {
 	subject := roleSubject(roleID)
 	_, err := c.enforcer.RemoveFilteredPolicy(0, subject)
 	if err != nil {
 		return fmt.Errorf("failed to delete role permissions: %w", err)
 	}
+	if _, err := c.enforcer.RemoveFilteredGroupingPolicy(0, subject); err != nil {
+		return fmt.Errorf("failed to delete role parent: %w", err)
+	}
+	c.SetRoleActive(roleID, true)
 	return nil
 }
 
@@ -5,12 +5,14 @@ import (
 	"sync"
 	"testing"
 
+	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
 	libcasbin "github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/pkg/utils"
 )
 
 func setupDB(t *testing.T) *gorm.DB {
@@ -85,6 +87,34 @@ func TestCheckPermissionManageWildcard(t *testing.T) {
 	require.False(t, allowed)
 }
 
+func TestCheckPermissionStarWildcards(t *testing.T) {
+	c := newClient(t)
+
+	require.NoError(t, c.AddRolePermissions(7, []string{"product:*"}))
+	require.NoError(t, c.AddRolePermissions(8, []string{"*:read"}))
+
+	cases := []struct {
+		roleID     uint
+		permission string
+		want       bool
+	}{
+		{7, "product:create", true},
+		{7, "product:delete", true},
+		{7, "order:read", false},
+		{8, "product:read", true},
+		{8, "order:read", true},
+		{8, "order:update", false},
+	}
+	for _, tc := range cases {
+		allowed, err := c.CheckPermission(tc.roleID, tc.permission)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, allowed, "role %d, %s", tc.roleID, tc.permission)
+	}
+
+	// Wildcard grants are listed as granted, not expanded.
+	require.Equal(t, []string{"*:read"}, c.GetRolePermissions(8))
+}
+
 func TestRemoveRolePermissions(t *testing.T) {
 	c := newClient(t)
 
@@ -218,7 +248,7 @@ func TestCheckPermissionWithRoot(t *testing.T) {
 	require.True(t, allowed)
 
 	// Non-admin roles are denied.
-	allowed, err = c.CheckPermissionWithRoot("user", &roleID, "post:create")
+	allowed, err = c.CheckPermissionWithRoot("user", []uint{roleID}, "post:create")
 	require.NoError(t, err)
 	require.False(t, allowed)
 
@@ -228,13 +258,399 @@ func TestCheckPermissionWithRoot(t *testing.T) {
 	require.False(t, allowed)
 
 	// Admin with a role delegates to CheckPermission.
-	allowed, err = c.CheckPermissionWithRoot("admin", &roleID, "post:create")
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{roleID}, "post:create")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{roleID}, "post:delete")
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestCheckPermissionWithRootUnionsAssignedRoles(t *testing.T) {
+	c := newClient(t)
+
+	editor, finance := uint(20), uint(21)
+	require.NoError(t, c.AddRolePermissions(editor, []string{"post:update"}))
+	require.NoError(t, c.AddRolePermissions(finance, []string{"report:read"}))
+
+	// Each permission is granted by one of the roles.
+	for _, perm := range []string{"post:update", "report:read"} {
+		allowed, err := c.CheckPermissionWithRoot("admin", []uint{editor, finance}, perm)
+		require.NoError(t, err)
+		require.True(t, allowed, perm)
+	}
+
+	// Neither role grants it.
+	allowed, err := c.CheckPermissionWithRoot("admin", []uint{editor, finance}, "post:delete")
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	// An inactive role drops out of the union; the other still applies.
+	c.SetRoleActive(finance, false)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{editor, finance}, "report:read")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{editor, finance}, "post:update")
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+func TestInactiveRoleDeniesHoldersButKeepsPolicies(t *testing.T) {
+	c := newClient(t)
+
+	roleID := uint(12)
+	require.NoError(t, c.AddRolePermissions(roleID, []string{"post:create"}))
+	require.True(t, c.IsRoleActive(roleID), "roles start active")
+
+	c.SetRoleActive(roleID, false)
+	require.False(t, c.IsRoleActive(roleID))
+
+	// Holders of an inactive role are denied, but the grants survive.
+	allowed, err := c.CheckPermissionWithRoot("admin", []uint{roleID}, "post:create")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, []string{"post:create"}, c.GetRolePermissions(roleID))
+
+	// Root is unaffected by role status.
+	allowed, err = c.CheckPermissionWithRoot("root", []uint{roleID}, "post:create")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// Reactivating restores the original grants.
+	c.SetRoleActive(roleID, true)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{roleID}, "post:create")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// Deleting a role forgets its status, so a reused ID starts active.
+	c.SetRoleActive(roleID, false)
+	require.NoError(t, c.DeleteRole(roleID))
+	require.True(t, c.IsRoleActive(roleID))
+}
+
+func TestRoleInheritsParentPermissions(t *testing.T) {
+	c := newClient(t)
+
+	// support <- senior <- lead
+	support, senior, lead := uint(20), uint(21), uint(22)
+	require.NoError(t, c.AddRolePermissions(support, []string{"user:read"}))
+	require.NoError(t, c.AddRolePermissions(senior, []string{"user:update", "user:read"}))
+	require.NoError(t, c.AddRolePermissions(lead, []string{"log:read"}))
+	require.NoError(t, c.SetRoleParent(senior, &support))
+	require.NoError(t, c.SetRoleParent(lead, &senior))
+
+	for _, perm := range []string{"log:read", "user:update", "user:read"} {
+		allowed, err := c.CheckPermission(lead, perm)
+		require.NoError(t, err)
+		require.True(t, allowed, perm)
+	}
+	// Inheritance flows down only.
+	allowed, err := c.CheckPermission(support, "user:update")
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	// Effective permissions list the role's own first, then each ancestor's,
+	// without duplicates; direct permissions exclude inherited ones.
+	require.Equal(t, []string{"log:read", "user:update", "user:read"}, c.GetRolePermissions(lead))
+	require.Equal(t, []string{"log:read"}, c.GetDirectRolePermissions(lead))
+	require.Equal(t, &senior, c.GetRoleParent(lead))
+	require.Equal(t, []uint{lead}, c.GetChildRoles(senior))
+
+	// Removing the parent link drops the inherited grants.
+	require.NoError(t, c.SetRoleParent(lead, nil))
+	require.Nil(t, c.GetRoleParent(lead))
+	allowed, err = c.CheckPermission(lead, "user:read")
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+// TestInactiveAncestorStopsInheritance — deactivating a role cuts its
+// descendants off from it and from everything above it: they keep their own
+// grants and keep its deny rules, but inherit none of its grants until it is
+// reactivated.
+func TestInactiveAncestorStopsInheritance(t *testing.T) {
+	c := newClient(t)
+
+	// support <- senior <- lead
+	support, senior, lead := uint(20), uint(21), uint(22)
+	require.NoError(t, c.AddRolePermissions(support, []string{"user:read"}))
+	require.NoError(t, c.AddRolePermissions(senior, []string{"user:update"}))
+	require.NoError(t, c.SetRoleDenials(senior, []string{"log:delete"}))
+	require.NoError(t, c.AddRolePermissions(lead, []string{"log:*"}))
+	require.NoError(t, c.SetRoleParent(senior, &support))
+	require.NoError(t, c.SetRoleParent(lead, &senior))
+
+	c.SetRoleActive(senior, false)
+
+	for perm, want := range map[string]bool{
+		"log:read":    true,  // lead's own grant
+		"log:delete":  false, // senior's deny still reaches lead
+		"user:update": false, // senior's grant
+		"user:read":   false, // support's grant, only reachable through senior
+	} {
+		allowed, err := c.CheckPermissionWithRoot("admin", []uint{lead}, perm)
+		require.NoError(t, err)
+		require.Equal(t, want, allowed, perm)
+	}
+	// The decision is the model's own: the enforcer reaches it unaided.
+	allowed, err := c.GetEnforcer().Enforce("role:22", "user", "read")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, []string{"log:*"}, c.GetRolePermissions(lead))
+	require.Equal(t, []string{"log:delete"}, c.GetRoleDenials(lead))
+	require.Equal(t, &senior, c.GetRoleParent(lead), "the link itself is kept")
+
+	// The inactive role still reports what it inherits, so reactivating it can
+	// be authorized against what it will hand back.
+	require.Equal(t, []string{"user:update", "user:read"}, c.GetRolePermissions(senior))
+
+	c.SetRoleActive(senior, true)
+	require.Equal(t, []string{"log:*", "user:update", "user:read"}, c.GetRolePermissions(lead))
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{lead}, "log:delete")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{lead}, "user:read")
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+// TestDenyOnInactiveAncestorStillApplies — a deny rule on a deactivated
+// ancestor keeps binding every role below it, so switching the ancestor off
+// cannot widen what a descendant's holders may do.
+func TestDenyOnInactiveAncestorStillApplies(t *testing.T) {
+	db := setupDB(t)
+	c, err := libcasbin.New(db)
+	require.NoError(t, err)
+
+	// restrictions <- support lead
+	restrictions, lead := uint(40), uint(41)
+	require.NoError(t, c.SetRoleDenials(restrictions, []string{"admin_user:delete"}))
+	require.NoError(t, c.AddRolePermissions(restrictions, []string{"log:read"}))
+	require.NoError(t, c.AddRolePermissions(lead, []string{"admin_user:*"}))
+	require.NoError(t, c.SetRoleParent(lead, &restrictions))
+
+	check := func(c libcasbin.Client, perm string) bool {
+		t.Helper()
+		allowed, err := c.CheckPermissionWithRoot("admin", []uint{lead}, perm)
+		require.NoError(t, err)
+		return allowed
+	}
+	require.False(t, check(c, "admin_user:delete"))
+	require.True(t, check(c, "admin_user:update"))
+	require.True(t, check(c, "log:read"))
+
+	c.SetRoleActive(restrictions, false)
+	require.False(t, check(c, "admin_user:delete"), "the deny outlives the deactivation")
+	require.True(t, check(c, "admin_user:update"))
+	require.False(t, check(c, "log:read"), "only the grants are withdrawn")
+	require.Equal(t, []string{"admin_user:delete"}, c.GetRoleDenials(lead))
+
+	// A client loading the same policies, with the status restored as the
+	// admin-role module does at startup, decides the same way.
+	reloaded, err := libcasbin.New(db)
+	require.NoError(t, err)
+	reloaded.SetRoleActive(restrictions, false)
+	require.False(t, check(reloaded, "admin_user:delete"))
+	require.True(t, check(reloaded, "admin_user:update"))
+}
+
+func TestSetRoleParentReplacesExistingParent(t *testing.T) {
+	c := newClient(t)
+
+	first, second, child := uint(23), uint(24), uint(25)
+	require.NoError(t, c.AddRolePermissions(first, []string{"user:read"}))
+	require.NoError(t, c.AddRolePermissions(second, []string{"log:read"}))
+
+	require.NoError(t, c.SetRoleParent(child, &first))
+	require.NoError(t, c.SetRoleParent(child, &second))
+
+	require.Equal(t, &second, c.GetRoleParent(child))
+	require.Equal(t, []string{"log:read"}, c.GetRolePermissions(child))
+	require.Empty(t, c.GetChildRoles(first))
+}
+
+func TestSetRoleParentRejectsCycles(t *testing.T) {
+	c := newClient(t)
+
+	a, b, d := uint(30), uint(31), uint(32)
+	require.NoError(t, c.SetRoleParent(b, &a))
+	require.NoError(t, c.SetRoleParent(d, &b))
+
+	require.ErrorIs(t, c.SetRoleParent(a, &a), libcasbin.ErrRoleCycle)
+	require.ErrorIs(t, c.SetRoleParent(a, &d), libcasbin.ErrRoleCycle)
+	require.Nil(t, c.GetRoleParent(a), "a rejected link must not be written")
+}
+
+func TestSetRoleParentRejectsChainsDeeperThanMax(t *testing.T) {
+	c := newClient(t)
+
+	// Build a chain of exactly MaxRoleDepth roles: 41 <- 42 <- ... <- 50.
+	base := uint(40)
+	for i := uint(2); i <= libcasbin.MaxRoleDepth; i++ {
+		parent := base + i - 1
+		require.NoError(t, c.SetRoleParent(base+i, &parent))
+	}
+	deepest := base + libcasbin.MaxRoleDepth
+	require.NoError(t, c.AddRolePermissions(base+1, []string{"user:read"}))
+	allowed, err := c.CheckPermission(deepest, "user:read")
+	require.NoError(t, err)
+	require.True(t, allowed, "grants must reach the bottom of a maximal chain")
+
+	// Extending it at either end is refused.
+	require.ErrorIs(t, c.SetRoleParent(deepest+1, &deepest), libcasbin.ErrRoleHierarchyTooDeep)
+	top := base + 1
+	other := uint(99)
+	require.ErrorIs(t, c.SetRoleParent(top, &other), libcasbin.ErrRoleHierarchyTooDeep)
+}
+
+func TestDeleteRoleRemovesParentLink(t *testing.T) {
+	c := newClient(t)
+
+	parent, child := uint(60), uint(61)
+	require.NoError(t, c.SetRoleParent(child, &parent))
+
+	require.NoError(t, c.DeleteRole(child))
+
+	require.Nil(t, c.GetRoleParent(child))
+	require.Empty(t, c.GetChildRoles(parent))
+}
+
+// TestDenyRulesTakePrecedenceOverAllowRules covers the allow-and-not-deny
+// effect within one role: a deny rule wins over exact, wildcard and manage
+// grants, and a denied "manage" or wildcard denies every action it matches.
+func TestDenyRulesTakePrecedenceOverAllowRules(t *testing.T) {
+	c := newClient(t)
+
+	require.NoError(t, c.AddRolePermissions(1, []string{"user:*", "admin_user:manage", "post:read"}))
+	require.NoError(t, c.SetRoleDenials(1, []string{"admin_user:delete", "post:read"}))
+	require.NoError(t, c.AddRolePermissions(2, []string{"order:*", "invoice:read"}))
+	require.NoError(t, c.SetRoleDenials(2, []string{"order:manage", "*:read"}))
+
+	cases := []struct {
+		roleID     uint
+		permission string
+		want       bool
+	}{
+		{1, "user:delete", true},
+		{1, "admin_user:read", true},
+		{1, "admin_user:delete", false}, // deny beats manage
+		{1, "post:read", false},         // deny beats an exact grant
+		{2, "order:create", false},      // a denied manage denies every action
+		{2, "invoice:read", false},      // a denied wildcard resource too
+		{3, "user:read", false},         // no rules at all
+	}
+	for _, tc := range cases {
+		allowed, err := c.CheckPermission(tc.roleID, tc.permission)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, allowed, "role %d, %s", tc.roleID, tc.permission)
+	}
+
+	// Grants wholly covered by a deny drop out of the effective list; partly
+	// denied ones stay. The editable lists are untouched.
+	require.Equal(t, []string{"user:*", "admin_user:manage"}, c.GetRolePermissions(1))
+	require.ElementsMatch(t, []string{"user:*", "admin_user:manage", "post:read"}, c.GetDirectRolePermissions(1))
+	require.ElementsMatch(t, []string{"admin_user:delete", "post:read"}, c.GetDirectRoleDenials(1))
+	require.Empty(t, c.GetRolePermissions(2))
+}
+
+func TestSetRoleDenialsLeavesGrantsAlone(t *testing.T) {
+	c := newClient(t)
+
+	require.NoError(t, c.AddRolePermissions(4, []string{"user:*"}))
+	require.NoError(t, c.SetRoleDenials(4, []string{"user:delete", "user:update"}))
+	require.NoError(t, c.SetRoleDenials(4, []string{"user:delete"}))
+
+	require.Equal(t, []string{"user:*"}, c.GetDirectRolePermissions(4))
+	require.Equal(t, []string{"user:delete"}, c.GetDirectRoleDenials(4))
+	allowed, err := c.CheckPermission(4, "user:update")
+	require.NoError(t, err)
+	require.True(t, allowed, "a removed deny no longer applies")
+
+	// Clearing grants keeps the denials, and DeleteRole drops both.
+	require.NoError(t, c.SetRolePermissions(4, nil))
+	require.Equal(t, []string{"user:delete"}, c.GetDirectRoleDenials(4))
+	require.NoError(t, c.DeleteRole(4))
+	require.Empty(t, c.GetDirectRoleDenials(4))
+}
+
+// TestInheritedDenyRulesCannotBeOverridden — a child inherits its ancestors'
+// deny rules, and its own grants do not outweigh them.
+func TestInheritedDenyRulesCannotBeOverridden(t *testing.T) {
+	c := newClient(t)
+
+	parent, child := uint(20), uint(21)
+	require.NoError(t, c.AddRolePermissions(parent, []string{"user:*"}))
+	require.NoError(t, c.SetRoleDenials(parent, []string{"user:delete"}))
+	require.NoError(t, c.AddRolePermissions(child, []string{"user:delete"}))
+	require.NoError(t, c.SetRoleParent(child, &parent))
+
+	allowed, err := c.CheckPermission(child, "user:delete")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	allowed, err = c.CheckPermission(child, "user:read")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	require.Equal(t, []string{"user:delete"}, c.GetRoleDenials(child))
+	require.Empty(t, c.GetDirectRoleDenials(child))
+	require.Equal(t, []string{"user:*"}, c.GetRolePermissions(child))
+}
+
+// TestCheckPermissionWithRootDenyWinsAcrossRoles — for an admin holding
+// several roles, a deny in any of them beats an allow in another. An inactive
+// role loses its grants but not its deny rules.
+func TestCheckPermissionWithRootDenyWinsAcrossRoles(t *testing.T) {
+	c := newClient(t)
+
+	broad, restricted := uint(30), uint(31)
+	require.NoError(t, c.AddRolePermissions(broad, []string{"admin_user:*"}))
+	require.NoError(t, c.AddRolePermissions(restricted, []string{"log:read"}))
+	require.NoError(t, c.SetRoleDenials(restricted, []string{"admin_user:delete"}))
+
+	allowed, err := c.CheckPermissionWithRoot("admin", []uint{broad, restricted}, "admin_user:delete")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{restricted, broad}, "admin_user:delete")
+	require.NoError(t, err)
+	require.False(t, allowed, "the order of roles does not matter")
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{broad, restricted}, "admin_user:read")
 	require.NoError(t, err)
 	require.True(t, allowed)
 
-	allowed, err = c.CheckPermissionWithRoot("admin", &roleID, "post:delete")
+	c.SetRoleActive(restricted, false)
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{broad, restricted}, "admin_user:delete")
+	require.NoError(t, err)
+	require.False(t, allowed, "deactivating a role never lifts its deny rules")
+	allowed, err = c.CheckPermissionWithRoot("admin", []uint{broad, restricted}, "log:read")
 	require.NoError(t, err)
 	require.False(t, allowed)
+
+	allowed, err = c.CheckPermissionWithRoot("root", []uint{restricted}, "admin_user:delete")
+	require.NoError(t, err)
+	require.True(t, allowed, "root bypasses deny rules")
+}
+
+// TestNewUpgradesPoliciesWithoutEffect — rules stored before the model had an
+// effect field load as allow rules instead of failing every check.
+func TestNewUpgradesPoliciesWithoutEffect(t *testing.T) {
+	db := setupDB(t)
+
+	_, err := libcasbin.New(db)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&gormadapter.CasbinRule{Ptype: "p", V0: "role:40", V1: "post", V2: "read"}).Error)
+
+	c, err := libcasbin.New(db)
+	require.NoError(t, err)
+
+	allowed, err := c.CheckPermission(40, "post:read")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.Equal(t, []string{"post:read"}, c.GetDirectRolePermissions(40))
+
+	var stored gormadapter.CasbinRule
+	require.NoError(t, db.Where("v0 = ?", "role:40").First(&stored).Error)
+	require.Equal(t, libcasbin.EffectAllow, stored.V3)
 }
 
 func TestPoliciesPersistAcrossClients(t *testing.T) {
@@ -254,11 +670,30 @@ func TestPoliciesPersistAcrossClients(t *testing.T) {
 	require.Equal(t, []string{"post:create"}, second.GetRolePermissions(11))
 }
 
-// TestConcurrentReadsAndWritesAreSafe drives policy reads (Enforce /
-// GetFilteredPolicy via CheckPermission/GetRolePermissions) concurrently with
-// policy writes (Add/Set/DeleteRole) against the single shared enforcer — the
-// exact access pattern the production fx singleton sees when an admin edits a
-// role while the API serves authorized traffic. Against a bare casbin.Enforcer
+func TestRoleParentsPersistAcrossClients(t *testing.T) {
+	db := setupDB(t)
+
+	first, err := libcasbin.New(db)
+	require.NoError(t, err)
+	parent, child := uint(70), uint(71)
+	require.NoError(t, first.AddRolePermissions(parent, []string{"post:create"}))
+	require.NoError(t, first.SetRoleParent(child, &parent))
+
+	second, err := libcasbin.New(db)
+	require.NoError(t, err)
+
+	require.Equal(t, &parent, second.GetRoleParent(child))
+	allowed, err := second.CheckPermission(child, "post:create")
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+// TestConcurrentReadsAndWritesAreSafe drives policy reads (Enforce, with the
+// role links its matcher follows, and the filtered lookups behind
+// GetRolePermissions) concurrently with policy writes (Add/Set/DeleteRole,
+// parent links, role status) against the single shared enforcer — the exact
+// access pattern the production fx singleton sees when an admin edits a role
+// while the API serves authorized traffic. Against a bare casbin.Enforcer
 // this races on the policy maps and triggers a fatal "concurrent map read and
 // map write" (and is reported by `go test -race`); the SyncedEnforcer's RWMutex
 // makes it safe. Run with -race to catch regressions.
@@ -311,6 +746,18 @@ func TestConcurrentReadsAndWritesAreSafe(t *testing.T) {
 					t.Errorf("SetRolePermissions: %v", err)
 					return
 				}
+				// Re-link to a parent with no parent of its own, so no cycle
+				// can form, and toggle its status: the matcher reads both.
+				parent := uint(100)
+				var parentID *uint
+				if i%2 == 0 {
+					parentID = &parent
+				}
+				if err := c.SetRoleParent(roleID, parentID); err != nil {
+					t.Errorf("SetRoleParent: %v", err)
+					return
+				}
+				c.SetRoleActive(parent, i%3 != 0)
 			}
 		}(w)
 	}
@@ -332,3 +779,29 @@ func TestParseRoleIDFromSubject(t *testing.T) {
 	_, err = libcasbin.ParseRoleIDFromSubject("42")
 	require.Error(t, err)
 }
+
+func TestCheckCallerNarrowsToAPIKeyScope(t *testing.T) {
+	client := newClient(t)
+	require.NoError(t, client.AddRolePermissions(1, []string{"user:read", "user:delete", "admin_user:*"}))
+
+	session := &utils.ContextValues{Role: "admin", AdminRoleIDs: []uint{1}}
+	key := &utils.ContextValues{Role: "admin", AdminRoleIDs: []uint{1}, APIKeyID: 4, APIKeyPermissions: []string{"user:read", "user:delete"}}
+	rootKey := &utils.ContextValues{Role: "root", APIKeyID: 5, APIKeyPermissions: []string{"user:read"}}
+
+	for _, tc := range []struct {
+		values *utils.ContextValues
+		perm   string
+		want   bool
+	}{
+		{session, "admin_user:delete", true},
+		{key, "user:delete", true},
+		{key, "admin_user:delete", false},
+		{key, "log:read", false},
+		{rootKey, "user:read", true},
+		{rootKey, "user:delete", false},
+	} {
+		allowed, err := libcasbin.CheckCaller(client, tc.values, tc.perm)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, allowed, "%s (key %d)", tc.perm, tc.values.APIKeyID)
+	}
+}
@@ -26,21 +26,48 @@ var _ libscasbin.Client = &ClientMock{}
 //			CheckPermissionFunc: func(roleID uint, permission string) (bool, error) {
 //				panic("mock out the CheckPermission method")
 //			},
-//			CheckPermissionWithRootFunc: func(userRole string, adminRoleID *uint, permission string) (bool, error) {
+//			CheckPermissionWithRootFunc: func(userRole string, adminRoleIDs []uint, permission string) (bool, error) {
 //				panic("mock out the CheckPermissionWithRoot method")
 //			},
 //			DeleteRoleFunc: func(roleID uint) error {
 //				panic("mock out the DeleteRole method")
 //			},
+//			GetChildRolesFunc: func(roleID uint) []uint {
+//				panic("mock out the GetChildRoles method")
+//			},
+//			GetDirectRoleDenialsFunc: func(roleID uint) []string {
+//				panic("mock out the GetDirectRoleDenials method")
+//			},
+//			GetDirectRolePermissionsFunc: func(roleID uint) []string {
+//				panic("mock out the GetDirectRolePermissions method")
+//			},
 //			GetEnforcerFunc: func() *v3.Enforcer {
 //				panic("mock out the GetEnforcer method")
 //			},
+//			GetRoleDenialsFunc: func(roleID uint) []string {
+//				panic("mock out the GetRoleDenials method")
+//			},
+//			GetRoleParentFunc: func(roleID uint) *uint {
+//				panic("mock out the GetRoleParent method")
+//			},
 //			GetRolePermissionsFunc: func(roleID uint) []string {
 //				panic("mock out the GetRolePermissions method")
 //			},
+//			IsRoleActiveFunc: func(roleID uint) bool {
+//				panic("mock out the IsRoleActive method")
+//			},
 //			RemoveRolePermissionsFunc: func(roleID uint, permissions []string) error {
 //				panic("mock out the RemoveRolePermissions method")
 //			},
+//			SetRoleActiveFunc: func(roleID uint, active bool)  {
+//				panic("mock out the SetRoleActive method")
+//			},
+//			SetRoleDenialsFunc: func(roleID uint, denials []string) error {
+//				panic("mock out the SetRoleDenials method")
+//			},
+//			SetRoleParentFunc: func(roleID uint, parentID *uint) error {
+//				panic("mock out the SetRoleParent method")
+//			},
 //			SetRolePermissionsFunc: func(roleID uint, permissions []string) error {
 //				panic("mock out the SetRolePermissions method")
 //			},
@@ -58,20 +85,47 @@ type ClientMock struct {
 	CheckPermissionFunc func(roleID uint, permission string) (bool, error)
 
 	// CheckPermissionWithRootFunc mocks the CheckPermissionWithRoot method.
-	CheckPermissionWithRootFunc func(userRole string, adminRoleID *uint, permission string) (bool, error)
+	CheckPermissionWithRootFunc func(userRole string, adminRoleIDs []uint, permission string) (bool, error)
 
 	// DeleteRoleFunc mocks the DeleteRole method.
 	DeleteRoleFunc func(roleID uint) error
 
+	// GetChildRolesFunc mocks the GetChildRoles method.
+	GetChildRolesFunc func(roleID uint) []uint
+
+	// GetDirectRoleDenialsFunc mocks the GetDirectRoleDenials method.
+	GetDirectRoleDenialsFunc func(roleID uint) []string
+
+	// GetDirectRolePermissionsFunc mocks the GetDirectRolePermissions method.
+	GetDirectRolePermissionsFunc func(roleID uint) []string
+
 	// GetEnforcerFunc mocks the GetEnforcer method.
 	GetEnforcerFunc func() *v3.Enforcer
 
+	// GetRoleDenialsFunc mocks the GetRoleDenials method.
+	GetRoleDenialsFunc func(roleID uint) []string
+
+	// GetRoleParentFunc mocks the GetRoleParent method.
+	GetRoleParentFunc func(roleID uint) *uint
+
 	// GetRolePermissionsFunc mocks the GetRolePermissions method.
 	GetRolePermissionsFunc func(roleID uint) []string
 
+	// IsRoleActiveFunc mocks the IsRoleActive method.
+	IsRoleActiveFunc func(roleID uint) bool
+
 	// RemoveRolePermissionsFunc mocks the RemoveRolePermissions method.
 	RemoveRolePermissionsFunc func(roleID uint, permissions []string) error
 
+	// SetRoleActiveFunc mocks the SetRoleActive method.
+	SetRoleActiveFunc func(roleID uint, active bool)
+
+	// SetRoleDenialsFunc mocks the SetRoleDenials method.
+	SetRoleDenialsFunc func(roleID uint, denials []string) error
+
+	// SetRoleParentFunc mocks the SetRoleParent method.
+	SetRoleParentFunc func(roleID uint, parentID *uint) error
+
 	// SetRolePermissionsFunc mocks the SetRolePermissions method.
 	SetRolePermissionsFunc func(roleID uint, permissions []string) error
 
@@ -95,8 +149,8 @@ type ClientMock struct {
 		CheckPermissionWithRoot []struct {
 			// UserRole is the userRole argument value.
 			UserRole string
-			// AdminRoleID is the adminRoleID argument value.
-			AdminRoleID *uint
+			// AdminRoleIDs is the adminRoleIDs argument value.
+			AdminRoleIDs []uint
 			// Permission is the permission argument value.
 			Permission string
 		}
@@ -105,14 +159,44 @@ type ClientMock struct {
 			// RoleID is the roleID argument value.
 			RoleID uint
 		}
+		// GetChildRoles holds details about calls to the GetChildRoles method.
+		GetChildRoles []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
+		// GetDirectRoleDenials holds details about calls to the GetDirectRoleDenials method.
+		GetDirectRoleDenials []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
+		// GetDirectRolePermissions holds details about calls to the GetDirectRolePermissions method.
+		GetDirectRolePermissions []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
 		// GetEnforcer holds details about calls to the GetEnforcer method.
 		GetEnforcer []struct {
 		}
+		// GetRoleDenials holds details about calls to the GetRoleDenials method.
+		GetRoleDenials []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
+		// GetRoleParent holds details about calls to the GetRoleParent method.
+		GetRoleParent []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
 		// GetRolePermissions holds details about calls to the GetRolePermissions method.
 		GetRolePermissions []struct {
 			// RoleID is the roleID argument value.
 			RoleID uint
 		}
+		// IsRoleActive holds details about calls to the IsRoleActive method.
+		IsRoleActive []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+		}
 		// RemoveRolePermissions holds details about calls to the RemoveRolePermissions method.
 		RemoveRolePermissions []struct {
 			// RoleID is the roleID argument value.
@@ -120,6 +204,27 @@ type ClientMock struct {
 			// Permissions is the permissions argument value.
 			Permissions []string
 		}
+		// SetRoleActive holds details about calls to the SetRoleActive method.
+		SetRoleActive []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+			// Active is the active argument value.
+			Active bool
+		}
+		// SetRoleDenials holds details about calls to the SetRoleDenials method.
+		SetRoleDenials []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+			// Denials is the denials argument value.
+			Denials []string
+		}
+		// SetRoleParent holds details about calls to the SetRoleParent method.
+		SetRoleParent []struct {
+			// RoleID is the roleID argument value.
+			RoleID uint
+			// ParentID is the parentID argument value.
+			ParentID *uint
+		}
 		// SetRolePermissions holds details about calls to the SetRolePermissions method.
 		SetRolePermissions []struct {
 			// RoleID is the roleID argument value.
@@ -128,14 +233,23 @@ type ClientMock struct {
 			Permissions []string
 		}
 	}
-	lockAddRolePermissions      sync.RWMutex
-	lockCheckPermission         sync.RWMutex
-	lockCheckPermissionWithRoot sync.RWMutex
-	lockDeleteRole              sync.RWMutex
-	lockGetEnforcer             sync.RWMutex
-	lockGetRolePermissions      sync.RWMutex
-	lockRemoveRolePermissions   sync.RWMutex
-	lockSetRolePermissions      sync.RWMutex
+	lockAddRolePermissions       sync.RWMutex
+	lockCheckPermission          sync.RWMutex
+	lockCheckPermissionWithRoot  sync.RWMutex
+	lockDeleteRole               sync.RWMutex
+	lockGetChildRoles            sync.RWMutex
+	lockGetDirectRoleDenials     sync.RWMutex
+	lockGetDirectRolePermissions sync.RWMutex
+	lockGetEnforcer              sync.RWMutex
+	lockGetRoleDenials           sync.RWMutex
+	lockGetRoleParent            sync.RWMutex
+	lockGetRolePermissions       sync.RWMutex
+	lockIsRoleActive             sync.RWMutex
+	lockRemoveRolePermissions    sync.RWMutex
+	lockSetRoleActive            sync.RWMutex
+	lockSetRoleDenials           sync.RWMutex
+	lockSetRoleParent            sync.RWMutex
+	lockSetRolePermissions       sync.RWMutex
 }
 
 // AddRolePermissions calls AddRolePermissionsFunc.
@@ -211,23 +325,23 @@ func (mock *ClientMock) CheckPermissionCalls() []struct {
 }
 
 // CheckPermissionWithRoot calls CheckPermissionWithRootFunc.
-func (mock *ClientMock) CheckPermissionWithRoot(userRole string, adminRoleID *uint, permission string) (bool, error) {
+func (mock *ClientMock) CheckPermissionWithRoot(userRole string, adminRoleIDs []uint, permission string) (bool, error) {
 	if mock.CheckPermissionWithRootFunc == nil {
 		panic("ClientMock.CheckPermissionWithRootFunc: method is nil but Client.CheckPermissionWithRoot was just called")
 	}
 	callInfo := struct {
-		UserRole    string
-		AdminRoleID *uint
-		Permission  string
+		UserRole     string
+		AdminRoleIDs []uint
+		Permission   string
 	}{
-		UserRole:    userRole,
-		AdminRoleID: adminRoleID,
-		Permission:  permission,
+		UserRole:     userRole,
+		AdminRoleIDs: adminRoleIDs,
+		Permission:   permission,
 	}
 	mock.lockCheckPermissionWithRoot.Lock()
 	mock.calls.CheckPermissionWithRoot = append(mock.calls.CheckPermissionWithRoot, callInfo)
 	mock.lockCheckPermissionWithRoot.Unlock()
-	return mock.CheckPermissionWithRootFunc(userRole, adminRoleID, permission)
+	return mock.CheckPermissionWithRootFunc(userRole, adminRoleIDs, permission)
 }
 
 // CheckPermissionWithRootCalls gets all the calls that were made to CheckPermissionWithRoot.
@@ -235,14 +349,14 @@ func (mock *ClientMock) CheckPermissionWithRoot(userRole string, adminRoleID *ui
 //
 //	len(mockedClient.CheckPermissionWithRootCalls())
 func (mock *ClientMock) CheckPermissionWithRootCalls() []struct {
-	UserRole    string
-	AdminRoleID *uint
-	Permission  string
+	UserRole     string
+	AdminRoleIDs []uint
+	Permission   string
 } {
 	var calls []struct {
-		UserRole    string
-		AdminRoleID *uint
-		Permission  string
+		UserRole     string
+		AdminRoleIDs []uint
+		Permission   string
 	}
 	mock.lockCheckPermissionWithRoot.RLock()
 	calls = mock.calls.CheckPermissionWithRoot
@@ -282,6 +396,102 @@ func (mock *ClientMock) DeleteRoleCalls() []struct {
 	return calls
 }
 
+// GetChildRoles calls GetChildRolesFunc.
+func (mock *ClientMock) GetChildRoles(roleID uint) []uint {
+	if mock.GetChildRolesFunc == nil {
+		panic("ClientMock.GetChildRolesFunc: method is nil but Client.GetChildRoles was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockGetChildRoles.Lock()
+	mock.calls.GetChildRoles = append(mock.calls.GetChildRoles, callInfo)
+	mock.lockGetChildRoles.Unlock()
+	return mock.GetChildRolesFunc(roleID)
+}
+
+// GetChildRolesCalls gets all the calls that were made to GetChildRoles.
+// Check the length with:
+//
+//	len(mockedClient.GetChildRolesCalls())
+func (mock *ClientMock) GetChildRolesCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockGetChildRoles.RLock()
+	calls = mock.calls.GetChildRoles
+	mock.lockGetChildRoles.RUnlock()
+	return calls
+}
+
+// GetDirectRoleDenials calls GetDirectRoleDenialsFunc.
+func (mock *ClientMock) GetDirectRoleDenials(roleID uint) []string {
+	if mock.GetDirectRoleDenialsFunc == nil {
+		panic("ClientMock.GetDirectRoleDenialsFunc: method is nil but Client.GetDirectRoleDenials was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockGetDirectRoleDenials.Lock()
+	mock.calls.GetDirectRoleDenials = append(mock.calls.GetDirectRoleDenials, callInfo)
+	mock.lockGetDirectRoleDenials.Unlock()
+	return mock.GetDirectRoleDenialsFunc(roleID)
+}
+
+// GetDirectRoleDenialsCalls gets all the calls that were made to GetDirectRoleDenials.
+// Check the length with:
+//
+//	len(mockedClient.GetDirectRoleDenialsCalls())
+func (mock *ClientMock) GetDirectRoleDenialsCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockGetDirectRoleDenials.RLock()
+	calls = mock.calls.GetDirectRoleDenials
+	mock.lockGetDirectRoleDenials.RUnlock()
+	return calls
+}
+
+// GetDirectRolePermissions calls GetDirectRolePermissionsFunc.
+func (mock *ClientMock) GetDirectRolePermissions(roleID uint) []string {
+	if mock.GetDirectRolePermissionsFunc == nil {
+		panic("ClientMock.GetDirectRolePermissionsFunc: method is nil but Client.GetDirectRolePermissions was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockGetDirectRolePermissions.Lock()
+	mock.calls.GetDirectRolePermissions = append(mock.calls.GetDirectRolePermissions, callInfo)
+	mock.lockGetDirectRolePermissions.Unlock()
+	return mock.GetDirectRolePermissionsFunc(roleID)
+}
+
+// GetDirectRolePermissionsCalls gets all the calls that were made to GetDirectRolePermissions.
+// Check the length with:
+//
+//	len(mockedClient.GetDirectRolePermissionsCalls())
+func (mock *ClientMock) GetDirectRolePermissionsCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockGetDirectRolePermissions.RLock()
+	calls = mock.calls.GetDirectRolePermissions
+	mock.lockGetDirectRolePermissions.RUnlock()
+	return calls
+}
+
 // GetEnforcer calls GetEnforcerFunc.
 func (mock *ClientMock) GetEnforcer() *v3.Enforcer {
 	if mock.GetEnforcerFunc == nil {
@@ -309,6 +519,70 @@ func (mock *ClientMock) GetEnforcerCalls() []struct {
 	return calls
 }
 
+// GetRoleDenials calls GetRoleDenialsFunc.
+func (mock *ClientMock) GetRoleDenials(roleID uint) []string {
+	if mock.GetRoleDenialsFunc == nil {
+		panic("ClientMock.GetRoleDenialsFunc: method is nil but Client.GetRoleDenials was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockGetRoleDenials.Lock()
+	mock.calls.GetRoleDenials = append(mock.calls.GetRoleDenials, callInfo)
+	mock.lockGetRoleDenials.Unlock()
+	return mock.GetRoleDenialsFunc(roleID)
+}
+
+// GetRoleDenialsCalls gets all the calls that were made to GetRoleDenials.
+// Check the length with:
+//
+//	len(mockedClient.GetRoleDenialsCalls())
+func (mock *ClientMock) GetRoleDenialsCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockGetRoleDenials.RLock()
+	calls = mock.calls.GetRoleDenials
+	mock.lockGetRoleDenials.RUnlock()
+	return calls
+}
+
+// GetRoleParent calls GetRoleParentFunc.
+func (mock *ClientMock) GetRoleParent(roleID uint) *uint {
+	if mock.GetRoleParentFunc == nil {
+		panic("ClientMock.GetRoleParentFunc: method is nil but Client.GetRoleParent was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockGetRoleParent.Lock()
+	mock.calls.GetRoleParent = append(mock.calls.GetRoleParent, callInfo)
+	mock.lockGetRoleParent.Unlock()
+	return mock.GetRoleParentFunc(roleID)
+}
+
+// GetRoleParentCalls gets all the calls that were made to GetRoleParent.
+// Check the length with:
+//
+//	len(mockedClient.GetRoleParentCalls())
+func (mock *ClientMock) GetRoleParentCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockGetRoleParent.RLock()
+	calls = mock.calls.GetRoleParent
+	mock.lockGetRoleParent.RUnlock()
+	return calls
+}
+
 // GetRolePermissions calls GetRolePermissionsFunc.
 func (mock *ClientMock) GetRolePermissions(roleID uint) []string {
 	if mock.GetRolePermissionsFunc == nil {
@@ -341,6 +615,38 @@ func (mock *ClientMock) GetRolePermissionsCalls() []struct {
 	return calls
 }
 
+// IsRoleActive calls IsRoleActiveFunc.
+func (mock *ClientMock) IsRoleActive(roleID uint) bool {
+	if mock.IsRoleActiveFunc == nil {
+		panic("ClientMock.IsRoleActiveFunc: method is nil but Client.IsRoleActive was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+	}{
+		RoleID: roleID,
+	}
+	mock.lockIsRoleActive.Lock()
+	mock.calls.IsRoleActive = append(mock.calls.IsRoleActive, callInfo)
+	mock.lockIsRoleActive.Unlock()
+	return mock.IsRoleActiveFunc(roleID)
+}
+
+// IsRoleActiveCalls gets all the calls that were made to IsRoleActive.
+// Check the length with:
+//
+//	len(mockedClient.IsRoleActiveCalls())
+func (mock *ClientMock) IsRoleActiveCalls() []struct {
+	RoleID uint
+} {
+	var calls []struct {
+		RoleID uint
+	}
+	mock.lockIsRoleActive.RLock()
+	calls = mock.calls.IsRoleActive
+	mock.lockIsRoleActive.RUnlock()
+	return calls
+}
+
 // RemoveRolePermissions calls RemoveRolePermissionsFunc.
 func (mock *ClientMock) RemoveRolePermissions(roleID uint, permissions []string) error {
 	if mock.RemoveRolePermissionsFunc == nil {
@@ -377,6 +683,114 @@ func (mock *ClientMock) RemoveRolePermissionsCalls() []struct {
 	return calls
 }
 
+// SetRoleActive calls SetRoleActiveFunc.
+func (mock *ClientMock) SetRoleActive(roleID uint, active bool) {
+	if mock.SetRoleActiveFunc == nil {
+		panic("ClientMock.SetRoleActiveFunc: method is nil but Client.SetRoleActive was just called")
+	}
+	callInfo := struct {
+		RoleID uint
+		Active bool
+	}{
+		RoleID: roleID,
+		Active: active,
+	}
+	mock.lockSetRoleActive.Lock()
+	mock.calls.SetRoleActive = append(mock.calls.SetRoleActive, callInfo)
+	mock.lockSetRoleActive.Unlock()
+	mock.SetRoleActiveFunc(roleID, active)
+}
+
+// SetRoleActiveCalls gets all the calls that were made to SetRoleActive.
+// Check the length with:
+//
+//	len(mockedClient.SetRoleActiveCalls())
+func (mock *ClientMock) SetRoleActiveCalls() []struct {
+	RoleID uint
+	Active bool
+} {
+	var calls []struct {
+		RoleID uint
+		Active bool
+	}
+	mock.lockSetRoleActive.RLock()
+	calls = mock.calls.SetRoleActive
+	mock.lockSetRoleActive.RUnlock()
+	return calls
+}
+
+// SetRoleDenials calls SetRoleDenialsFunc.
+func (mock *ClientMock) SetRoleDenials(roleID uint, denials []string) error {
+	if mock.SetRoleDenialsFunc == nil {
+		panic("ClientMock.SetRoleDenialsFunc: method is nil but Client.SetRoleDenials was just called")
+	}
+	callInfo := struct {
+		RoleID  uint
+		Denials []string
+	}{
+		RoleID:  roleID,
+		Denials: denials,
+	}
+	mock.lockSetRoleDenials.Lock()
+	mock.calls.SetRoleDenials = append(mock.calls.SetRoleDenials, callInfo)
+	mock.lockSetRoleDenials.Unlock()
+	return mock.SetRoleDenialsFunc(roleID, denials)
+}
+
+// SetRoleDenialsCalls gets all the calls that were made to SetRoleDenials.
+// Check the length with:
+//
+//	len(mockedClient.SetRoleDenialsCalls())
+func (mock *ClientMock) SetRoleDenialsCalls() []struct {
+	RoleID  uint
+	Denials []string
+} {
+	var calls []struct {
+		RoleID  uint
+		Denials []string
+	}
+	mock.lockSetRoleDenials.RLock()
+	calls = mock.calls.SetRoleDenials
+	mock.lockSetRoleDenials.RUnlock()
+	return calls
+}
+
+// SetRoleParent calls SetRoleParentFunc.
+func (mock *ClientMock) SetRoleParent(roleID uint, parentID *uint) error {
+	if mock.SetRoleParentFunc == nil {
+		panic("ClientMock.SetRoleParentFunc: method is nil but Client.SetRoleParent was just called")
+	}
+	callInfo := struct {
+		RoleID   uint
+		ParentID *uint
+	}{
+		RoleID:   roleID,
+		ParentID: parentID,
+	}
+	mock.lockSetRoleParent.Lock()
+	mock.calls.SetRoleParent = append(mock.calls.SetRoleParent, callInfo)
+	mock.lockSetRoleParent.Unlock()
+	return mock.SetRoleParentFunc(roleID, parentID)
+}
+
+// SetRoleParentCalls gets all the calls that were made to SetRoleParent.
+// Check the length with:
+//
+//	len(mockedClient.SetRoleParentCalls())
+func (mock *ClientMock) SetRoleParentCalls() []struct {
+	RoleID   uint
+	ParentID *uint
+} {
+	var calls []struct {
+		RoleID   uint
+		ParentID *uint
+	}
+	mock.lockSetRoleParent.RLock()
+	calls = mock.calls.SetRoleParent
+	mock.lockSetRoleParent.RUnlock()
+	return calls
+}
+
 // SetRolePermissions calls SetRolePermissionsFunc.
 func (mock *ClientMock) SetRolePermissions(roleID uint, permissions []string) error {
 	if mock.SetRolePermissionsFunc == nil {
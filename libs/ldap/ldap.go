@@ -0,0 +1,147 @@
+// Package ldap provides the directory (LDAP / Active Directory) login
+// integration: it checks a username and password against the configured
+// server and reads back the attributes used to provision the account.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . Client
+
+// ErrInvalidCredentials is returned when the username matches no single
+// directory entry or the password does not bind as it. The two are not told
+// apart, so a caller cannot probe which accounts exist.
+var ErrInvalidCredentials = errors.New("invalid directory credentials")
+
+// Entry is the directory entry a login bound as.
+type Entry struct {
+	DN       string
+	Username string
+	Email    string
+	Name     string
+	// Groups holds the values of the group attribute (memberOf by default),
+	// normally the DNs of the groups the entry belongs to.
+	Groups []string
+}
+
+// Client authenticates users against the directory. Implementations must be
+// safe for concurrent use.
+type Client interface {
+	// Authenticate binds as username with password and returns its entry. It
+	// returns ErrInvalidCredentials for a refused login; any other error means
+	// the directory could not be asked.
+	Authenticate(ctx context.Context, username, password string) (*Entry, error)
+}
+
+type client struct {
+	cfg config.LDAPConfig
+}
+
+// New builds a Client for the LDAP_* configuration. It does not connect: each
+// Authenticate call dials the server, so a directory outage only fails the
+// logins that need it.
+func New(cfg *config.Config) Client {
+	return &client{cfg: cfg.LDAP}
+}
+
+func (c *client) Authenticate(ctx context.Context, username, password string) (*Entry, error) {
+	// A simple bind with an empty password is an unauthenticated bind, which
+	// most servers accept for any DN: refuse it before it reaches the wire.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// The connection is synchronous; closing it is how a cancelled request
+	// stops waiting on the server.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	if c.cfg.BindDN != "" {
+		if err := conn.Bind(c.cfg.BindDN, c.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap service bind: %w", err)
+		}
+	}
+
+	attributes := []string{c.cfg.UsernameAttribute, c.cfg.EmailAttribute, c.cfg.GroupAttribute}
+	if c.cfg.NameAttribute != "" {
+		attributes = append(attributes, c.cfg.NameAttribute)
+	}
+	// A size limit of 2 is enough to tell one match from several.
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.cfg.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf(c.cfg.UserFilter, goldap.EscapeFilter(username)),
+		attributes,
+		nil,
+	))
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap user search: %w", err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	found := result.Entries[0]
+
+	if err := conn.Bind(found.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap user bind: %w", err)
+	}
+
+	entry := &Entry{
+		DN:       found.DN,
+		Username: found.GetEqualFoldAttributeValue(c.cfg.UsernameAttribute),
+		Email:    found.GetEqualFoldAttributeValue(c.cfg.EmailAttribute),
+		Groups:   found.GetEqualFoldAttributeValues(c.cfg.GroupAttribute),
+	}
+	if c.cfg.NameAttribute != "" {
+		entry.Name = found.GetEqualFoldAttributeValue(c.cfg.NameAttribute)
+	}
+	return entry, nil
+}
+
+// dial connects to the server, upgrading with StartTLS when configured.
+func (c *client) dial() (*goldap.Conn, error) {
+	u, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap url: %w", err)
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+
+	conn, err := goldap.DialURL(c.cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: c.cfg.Timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	conn.SetTimeout(c.cfg.Timeout)
+
+	if c.cfg.StartTLS && strings.EqualFold(u.Scheme, "ldap") {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("ldap start tls: %w", err)
+		}
+	}
+	return conn, nil
+}
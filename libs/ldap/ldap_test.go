@@ -0,0 +1,110 @@
+package ldap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/libs/ldap"
+	"github.com/PhantomX7/athleton/libs/ldap/ldaptest"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+const (
+	serviceDN = "cn=service,dc=example,dc=com"
+	aliceDN   = "uid=alice,ou=people,dc=example,dc=com"
+	editorsDN = "cn=editors,ou=groups,dc=example,dc=com"
+)
+
+func newDirectory(t *testing.T) (*ldaptest.Server, *config.Config) {
+	t.Helper()
+
+	server := ldaptest.NewServer(t,
+		ldaptest.Entry{DN: serviceDN, Password: "service-secret"},
+		ldaptest.Entry{
+			DN:       aliceDN,
+			Password: "alice-secret",
+			Attributes: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"alice"},
+				"mail":        {"alice@example.com"},
+				"cn":          {"Alice Admin"},
+				"memberOf":    {editorsDN},
+			},
+		},
+		// Two entries share a uid, so neither may be picked.
+		ldaptest.Entry{DN: "uid=twin,ou=people,dc=example,dc=com", Password: "twin-secret", Attributes: map[string][]string{"objectClass": {"person"}, "uid": {"twin"}}},
+		ldaptest.Entry{DN: "uid=twin,ou=contractors,dc=example,dc=com", Password: "twin-secret", Attributes: map[string][]string{"objectClass": {"person"}, "uid": {"twin"}}},
+	)
+
+	cfg := &config.Config{}
+	cfg.LDAP = config.LDAPConfig{
+		Enabled:           true,
+		URL:               server.URL,
+		BindDN:            serviceDN,
+		BindPassword:      "service-secret",
+		BaseDN:            "dc=example,dc=com",
+		UserFilter:        "(&(objectClass=person)(uid=%s))",
+		UsernameAttribute: "uid",
+		EmailAttribute:    "mail",
+		NameAttribute:     "cn",
+		GroupAttribute:    "memberOf",
+		Timeout:           time.Second,
+	}
+	return server, cfg
+}
+
+func TestAuthenticateReturnsTheBoundEntry(t *testing.T) {
+	server, cfg := newDirectory(t)
+
+	entry, err := ldap.New(cfg).Authenticate(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	require.Equal(t, &ldap.Entry{
+		DN:       aliceDN,
+		Username: "alice",
+		Email:    "alice@example.com",
+		Name:     "Alice Admin",
+		Groups:   []string{editorsDN},
+	}, entry)
+	require.Equal(t, []string{serviceDN, aliceDN}, server.Binds())
+}
+
+func TestAuthenticateRefusesBadCredentials(t *testing.T) {
+	_, cfg := newDirectory(t)
+	client := ldap.New(cfg)
+
+	cases := map[string]struct{ username, password string }{
+		"wrong password":      {"alice", "not-her-secret"},
+		"unknown user":        {"mallory", "alice-secret"},
+		"empty password":      {"alice", ""},
+		"ambiguous username":  {"twin", "twin-secret"},
+		"filter injection":    {"*", "alice-secret"},
+		"injected alternates": {"alice)(uid=*", "alice-secret"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Authenticate(context.Background(), tc.username, tc.password)
+			require.ErrorIs(t, err, ldap.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestAuthenticateReportsServiceBindFailure(t *testing.T) {
+	_, cfg := newDirectory(t)
+	cfg.LDAP.BindPassword = "rotated"
+
+	_, err := ldap.New(cfg).Authenticate(context.Background(), "alice", "alice-secret")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ldap.ErrInvalidCredentials, "a misconfigured service account is not the user's fault")
+}
+
+func TestAuthenticateReportsUnreachableServer(t *testing.T) {
+	server, cfg := newDirectory(t)
+	server.Close()
+
+	_, err := ldap.New(cfg).Authenticate(context.Background(), "alice", "alice-secret")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ldap.ErrInvalidCredentials)
+}
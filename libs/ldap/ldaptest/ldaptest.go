@@ -0,0 +1,330 @@
+// Package ldaptest provides an in-process LDAP server stand-in for tests. It
+// speaks just enough of the protocol for libs/ldap — simple bind, subtree
+// search with equality, presence, and/or/not filters, unbind — over plain
+// TCP on the loopback interface.
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// Result codes the server answers with.
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+	resultUnwillingToPerform = 53
+)
+
+// Protocol operation tags (APPLICATION class).
+const (
+	opBindRequest      = 0
+	opBindResponse     = 1
+	opUnbindRequest    = 2
+	opSearchRequest    = 3
+	opSearchEntry      = 4
+	opSearchDone       = 5
+	opExtendedRequest  = 23
+	opExtendedResponse = 24
+)
+
+// Filter and authentication choice tags (CONTEXT class).
+const (
+	filterAnd           = 0
+	filterOr            = 1
+	filterNot           = 2
+	filterEqualityMatch = 3
+	filterPresent       = 7
+	authSimple          = 0
+)
+
+// Entry is one directory entry. An entry with a Password accepts simple binds
+// with it; one without refuses them.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server is a running stand-in. It is safe for concurrent use.
+type Server struct {
+	// URL is the ldap:// address the server listens on.
+	URL string
+
+	listener net.Listener
+	mu       sync.Mutex
+	entries  []Entry
+	binds    []string
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server holding entries on a random loopback port and
+// stops it when the test ends.
+func NewServer(t testing.TB, entries ...Entry) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ldaptest: listen: %v", err)
+	}
+	s := &Server{
+		URL:      "ldap://" + listener.Addr().String(),
+		listener: listener,
+		entries:  entries,
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Add stores another entry.
+func (s *Server) Add(entry Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+// Binds returns the DNs of every successful bind so far, in order.
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+// Close stops the server, dropping any connection still open.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle answers the requests of one connection until the client unbinds or
+// hangs up.
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		var replies []*ber.Packet
+		switch op.Tag {
+		case opBindRequest:
+			replies = []*ber.Packet{s.bind(op)}
+		case opSearchRequest:
+			replies = s.search(op)
+		case opUnbindRequest:
+			return
+		case opExtendedRequest:
+			// StartTLS and the other extended operations are not supported.
+			replies = []*ber.Packet{result(opExtendedResponse, resultProtocolError, "extended operations are not supported")}
+		default:
+			return
+		}
+
+		for _, reply := range replies {
+			envelope := ber.NewSequence("LDAPMessage")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "messageID"))
+			envelope.AppendChild(reply)
+			if _, err := conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind answers a simple BindRequest: version, name, [0] password.
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 || op.Children[2].Tag != authSimple {
+		return result(opBindResponse, resultUnwillingToPerform, "only simple binds are supported")
+	}
+	dn := stringValue(op.Children[1])
+	password := stringValue(op.Children[2])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if strings.EqualFold(e.DN, dn) && e.Password != "" && e.Password == password {
+			s.binds = append(s.binds, e.DN)
+			return result(opBindResponse, resultSuccess, "")
+		}
+	}
+	return result(opBindResponse, resultInvalidCredentials, "invalid credentials")
+}
+
+// search answers a SearchRequest: baseObject, scope, derefAliases, sizeLimit,
+// timeLimit, typesOnly, filter, attributes. Every scope searches the whole
+// subtree under baseObject.
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(opSearchDone, resultProtocolError, "malformed search request")}
+	}
+	base := stringValue(op.Children[0])
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	var requested []string
+	for _, a := range op.Children[7].Children {
+		requested = append(requested, stringValue(a))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var replies []*ber.Packet
+	for _, e := range s.entries {
+		if !inSubtree(e.DN, base) || !matches(e, filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(replies)) == sizeLimit {
+			return append(replies, result(opSearchDone, resultSizeLimitExceeded, "size limit exceeded"))
+		}
+		replies = append(replies, searchEntry(e, requested))
+	}
+	return append(replies, result(opSearchDone, resultSuccess, ""))
+}
+
+// matches evaluates the subset of RFC 4511 filters the server understands;
+// any other filter matches nothing. Values compare case-insensitively, as
+// they do for the usual caseIgnoreMatch attributes.
+func matches(e Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case filterAnd:
+		for _, f := range filter.Children {
+			if !matches(e, f) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, f := range filter.Children {
+			if matches(e, f) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(filter.Children) == 1 && !matches(e, filter.Children[0])
+	case filterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		want := stringValue(filter.Children[1])
+		for _, v := range attribute(e, stringValue(filter.Children[0])) {
+			if strings.EqualFold(v, want) {
+				return true
+			}
+		}
+		return false
+	case filterPresent:
+		name := stringValue(filter)
+		return strings.EqualFold(name, "objectClass") || len(attribute(e, name)) > 0
+	default:
+		return false
+	}
+}
+
+// attribute returns the values of the attribute called name, whatever its case.
+func attribute(e Entry, name string) []string {
+	for k, v := range e.Attributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// inSubtree reports whether dn is base or lies under it.
+func inSubtree(dn, base string) bool {
+	dn, base = strings.ToLower(dn), strings.ToLower(base)
+	return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+}
+
+// searchEntry encodes e as a SearchResultEntry holding the requested
+// attributes, or all of them when none are named.
+func searchEntry(e Entry, requested []string) *ber.Packet {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchEntry, nil, "SearchResultEntry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "objectName"))
+
+	attributes := ber.NewSequence("attributes")
+	for name, values := range e.Attributes {
+		if len(requested) > 0 && !containsFold(requested, name) {
+			continue
+		}
+		attr := ber.NewSequence("attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, v := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+		}
+		attr.AppendChild(vals)
+		attributes.AppendChild(attr)
+	}
+	entry.AppendChild(attributes)
+	return entry
+}
+
+// result encodes an LDAPResult under the given application tag.
+func result(tag ber.Tag, code int64, message string) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "LDAPResult")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "diagnosticMessage"))
+	return p
+}
+
+// stringValue returns the raw content of a primitive packet, which is how
+// both universal and context-tagged LDAP strings arrive.
+func stringValue(p *ber.Packet) string {
+	return p.Data.String()
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
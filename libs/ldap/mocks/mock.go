@@ -0,0 +1,89 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/libs/ldap"
+)
+
+// Ensure, that ClientMock does implement ldap.Client.
+// If this is not the case, regenerate this file with moq.
+var _ ldap.Client = &ClientMock{}
+
+// ClientMock is a mock implementation of ldap.Client.
+//
+//	func TestSomethingThatUsesClient(t *testing.T) {
+//
+//		// make and configure a mocked ldap.Client
+//		mockedClient := &ClientMock{
+//			AuthenticateFunc: func(ctx context.Context, username string, password string) (*ldap.Entry, error) {
+//				panic("mock out the Authenticate method")
+//			},
+//		}
+//
+//		// use mockedClient in code that requires ldap.Client
+//		// and then make assertions.
+//
+//	}
+type ClientMock struct {
+	// AuthenticateFunc mocks the Authenticate method.
+	AuthenticateFunc func(ctx context.Context, username string, password string) (*ldap.Entry, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Authenticate holds details about calls to the Authenticate method.
+		Authenticate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Username is the username argument value.
+			Username string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockAuthenticate sync.RWMutex
+}
+
+// Authenticate calls AuthenticateFunc.
+func (mock *ClientMock) Authenticate(ctx context.Context, username string, password string) (*ldap.Entry, error) {
+	if mock.AuthenticateFunc == nil {
+		panic("ClientMock.AuthenticateFunc: method is nil but Client.Authenticate was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Username string
+		Password string
+	}{
+		Ctx:      ctx,
+		Username: username,
+		Password: password,
+	}
+	mock.lockAuthenticate.Lock()
+	mock.calls.Authenticate = append(mock.calls.Authenticate, callInfo)
+	mock.lockAuthenticate.Unlock()
+	return mock.AuthenticateFunc(ctx, username, password)
+}
+
+// AuthenticateCalls gets all the calls that were made to Authenticate.
+// Check the length with:
+//
+//	len(mockedClient.AuthenticateCalls())
+func (mock *ClientMock) AuthenticateCalls() []struct {
+	Ctx      context.Context
+	Username string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Username string
+		Password string
+	}
+	mock.lockAuthenticate.RLock()
+	calls = mock.calls.Authenticate
+	mock.lockAuthenticate.RUnlock()
+	return calls
+}
@@ -4,6 +4,9 @@ package libs
 import (
 	"github.com/PhantomX7/athleton/libs/bleve"
 	"github.com/PhantomX7/athleton/libs/casbin"
+	"github.com/PhantomX7/athleton/libs/ldap"
+	"github.com/PhantomX7/athleton/libs/mailer"
+	"github.com/PhantomX7/athleton/libs/oidc"
 	"github.com/PhantomX7/athleton/libs/s3"
 	"github.com/PhantomX7/athleton/libs/transaction_manager"
 
@@ -17,5 +20,8 @@ var Module = fx.Options(
 		s3.NewS3Client,
 		bleve.NewBleveClient,
 		casbin.New,
+		mailer.New,
+		ldap.New,
+		oidc.New,
 	),
 )
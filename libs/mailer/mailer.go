@@ -0,0 +1,112 @@
+// Package mailer provides the application's outbound-mail integration behind a
+// driver-agnostic interface.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . Mailer
+
+// Message is a single plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers outbound email. Implementations must be safe for concurrent
+// use; callers treat a returned error as "not delivered".
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New builds the Mailer selected by MAIL_DRIVER. Only offline drivers ship
+// today — "log" and "file" — which is enough for development and tests; a
+// real transport (SMTP, an HTTP API) plugs in as another case here.
+func New(cfg *config.Config) (Mailer, error) {
+	switch cfg.Mail.Driver {
+	case "log":
+		if cfg.IsProduction() {
+			// The log driver writes token-bearing links into the application
+			// log; tolerable for a staging smoke test, not as a delivery path.
+			logger.Warn("MAIL_DRIVER=log in production: emails are written to the log, not delivered")
+		}
+		return &logMailer{from: cfg.Mail.From}, nil
+	case "file":
+		return NewFileMailer(cfg.Mail.From, cfg.Mail.FileDir)
+	default:
+		// unreachable: validateMail rejects unknown drivers at startup
+		return nil, fmt.Errorf("unsupported mail driver: %q", cfg.Mail.Driver)
+	}
+}
+
+// logMailer writes every message to the application log at info level.
+type logMailer struct {
+	from string
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	logger.Ctx(ctx).Info("Outbound email (log driver)",
+		zap.String("from", m.from),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
+
+// FileMailer writes each message to its own .eml file under Dir, so tests and
+// local development can read back exactly what would have been sent.
+type FileMailer struct {
+	From string
+	Dir  string
+
+	seq atomic.Uint64
+}
+
+// NewFileMailer builds a FileMailer writing into dir, creating it if needed.
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &FileMailer{From: from, Dir: dir}, nil
+}
+
+// unsafeFileChars matches everything that should not appear in a file name
+// derived from a recipient address.
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._@-]+`)
+
+// Send writes msg as <unix-nanos>-<seq>-<recipient>.eml. The sequence number
+// keeps names unique (and lexically ordered) when two messages land within
+// the same clock tick.
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	name := fmt.Sprintf("%020d-%06d-%s.eml",
+		now.UnixNano(), m.seq.Add(1), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	if err := os.WriteFile(filepath.Join(m.Dir, name), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+	return nil
+}
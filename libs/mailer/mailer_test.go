@@ -0,0 +1,73 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/PhantomX7/athleton/libs/mailer"
+	"github.com/PhantomX7/athleton/pkg/config"
+	"github.com/PhantomX7/athleton/pkg/logger"
+)
+
+func TestFileMailerWritesOneFilePerMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "mail")
+	m, err := mailer.NewFileMailer("no-reply@test.local", dir)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), mailer.Message{
+		To:      "alice@example.com",
+		Subject: "First",
+		Body:    "hello alice",
+	}))
+	require.NoError(t, m.Send(context.Background(), mailer.Message{
+		To:      "bob/../../evil@example.com",
+		Subject: "Second",
+		Body:    "hello bob",
+	}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// Names sort in send order, and a hostile recipient cannot escape dir.
+	first, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(first), "From: no-reply@test.local\r\n")
+	require.Contains(t, string(first), "To: alice@example.com\r\n")
+	require.Contains(t, string(first), "Subject: First\r\n")
+	require.Contains(t, string(first), "\r\n\r\nhello alice")
+
+	require.NotContains(t, entries[1].Name(), "/")
+	second, err := os.ReadFile(filepath.Join(dir, entries[1].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(second), "hello bob")
+}
+
+func TestNewSelectsDriver(t *testing.T) {
+	prev := logger.Log
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger.Log = zap.New(core)
+	t.Cleanup(func() { logger.Log = prev })
+
+	cfg := &config.Config{Mail: config.MailConfig{Driver: "log", From: "no-reply@test.local"}}
+	m, err := mailer.New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, m.Send(context.Background(), mailer.Message{To: "a@example.com", Subject: "Hi", Body: "body"}))
+	require.Equal(t, 1, logs.FilterMessage("Outbound email (log driver)").Len())
+
+	cfg.Mail = config.MailConfig{Driver: "file", From: "no-reply@test.local", FileDir: t.TempDir()}
+	m, err = mailer.New(cfg)
+	require.NoError(t, err)
+	require.IsType(t, &mailer.FileMailer{}, m)
+
+	cfg.Mail.Driver = "carrier-pigeon"
+	_, err = mailer.New(cfg)
+	require.ErrorContains(t, err, "unsupported mail driver")
+}
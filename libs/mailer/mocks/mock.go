@@ -0,0 +1,83 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/libs/mailer"
+)
+
+// Ensure, that MailerMock does implement mailer.Mailer.
+// If this is not the case, regenerate this file with moq.
+var _ mailer.Mailer = &MailerMock{}
+
+// MailerMock is a mock implementation of mailer.Mailer.
+//
+//	func TestSomethingThatUsesMailer(t *testing.T) {
+//
+//		// make and configure a mocked mailer.Mailer
+//		mockedMailer := &MailerMock{
+//			SendFunc: func(ctx context.Context, msg mailer.Message) error {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedMailer in code that requires mailer.Mailer
+//		// and then make assertions.
+//
+//	}
+type MailerMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, msg mailer.Message) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Msg is the msg argument value.
+			Msg mailer.Message
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *MailerMock) Send(ctx context.Context, msg mailer.Message) error {
+	if mock.SendFunc == nil {
+		panic("MailerMock.SendFunc: method is nil but Mailer.Send was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Msg mailer.Message
+	}{
+		Ctx: ctx,
+		Msg: msg,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(ctx, msg)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedMailer.SendCalls())
+func (mock *MailerMock) SendCalls() []struct {
+	Ctx context.Context
+	Msg mailer.Message
+} {
+	var calls []struct {
+		Ctx context.Context
+		Msg mailer.Message
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
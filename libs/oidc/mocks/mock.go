@@ -0,0 +1,214 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PhantomX7/athleton/libs/oidc"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+// Ensure, that ClientMock does implement oidc.Client.
+// If this is not the case, regenerate this file with moq.
+var _ oidc.Client = &ClientMock{}
+
+// ClientMock is a mock implementation of oidc.Client.
+//
+//	func TestSomethingThatUsesClient(t *testing.T) {
+//
+//		// make and configure a mocked oidc.Client
+//		mockedClient := &ClientMock{
+//			AuthCodeURLFunc: func(ctx context.Context, provider string, state string, nonce string, verifier string) (string, error) {
+//				panic("mock out the AuthCodeURL method")
+//			},
+//			ExchangeFunc: func(ctx context.Context, provider string, code string, verifier string, nonce string) (*oidc.Identity, error) {
+//				panic("mock out the Exchange method")
+//			},
+//			ProviderFunc: func(name string) (config.OIDCProvider, bool) {
+//				panic("mock out the Provider method")
+//			},
+//		}
+//
+//		// use mockedClient in code that requires oidc.Client
+//		// and then make assertions.
+//
+//	}
+type ClientMock struct {
+	// AuthCodeURLFunc mocks the AuthCodeURL method.
+	AuthCodeURLFunc func(ctx context.Context, provider string, state string, nonce string, verifier string) (string, error)
+
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(ctx context.Context, provider string, code string, verifier string, nonce string) (*oidc.Identity, error)
+
+	// ProviderFunc mocks the Provider method.
+	ProviderFunc func(name string) (config.OIDCProvider, bool)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthCodeURL holds details about calls to the AuthCodeURL method.
+		AuthCodeURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// State is the state argument value.
+			State string
+			// Nonce is the nonce argument value.
+			Nonce string
+			// Verifier is the verifier argument value.
+			Verifier string
+		}
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Code is the code argument value.
+			Code string
+			// Verifier is the verifier argument value.
+			Verifier string
+			// Nonce is the nonce argument value.
+			Nonce string
+		}
+		// Provider holds details about calls to the Provider method.
+		Provider []struct {
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAuthCodeURL sync.RWMutex
+	lockExchange    sync.RWMutex
+	lockProvider    sync.RWMutex
+}
+
+// AuthCodeURL calls AuthCodeURLFunc.
+func (mock *ClientMock) AuthCodeURL(ctx context.Context, provider string, state string, nonce string, verifier string) (string, error) {
+	if mock.AuthCodeURLFunc == nil {
+		panic("ClientMock.AuthCodeURLFunc: method is nil but Client.AuthCodeURL was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		State    string
+		Nonce    string
+		Verifier string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}
+	mock.lockAuthCodeURL.Lock()
+	mock.calls.AuthCodeURL = append(mock.calls.AuthCodeURL, callInfo)
+	mock.lockAuthCodeURL.Unlock()
+	return mock.AuthCodeURLFunc(ctx, provider, state, nonce, verifier)
+}
+
+// AuthCodeURLCalls gets all the calls that were made to AuthCodeURL.
+// Check the length with:
+//
+//	len(mockedClient.AuthCodeURLCalls())
+func (mock *ClientMock) AuthCodeURLCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	State    string
+	Nonce    string
+	Verifier string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		State    string
+		Nonce    string
+		Verifier string
+	}
+	mock.lockAuthCodeURL.RLock()
+	calls = mock.calls.AuthCodeURL
+	mock.lockAuthCodeURL.RUnlock()
+	return calls
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *ClientMock) Exchange(ctx context.Context, provider string, code string, verifier string, nonce string) (*oidc.Identity, error) {
+	if mock.ExchangeFunc == nil {
+		panic("ClientMock.ExchangeFunc: method is nil but Client.Exchange was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Code     string
+		Verifier string
+		Nonce    string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Code:     code,
+		Verifier: verifier,
+		Nonce:    nonce,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(ctx, provider, code, verifier, nonce)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedClient.ExchangeCalls())
+func (mock *ClientMock) ExchangeCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Code     string
+	Verifier string
+	Nonce    string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Code     string
+		Verifier string
+		Nonce    string
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
+
+// Provider calls ProviderFunc.
+func (mock *ClientMock) Provider(name string) (config.OIDCProvider, bool) {
+	if mock.ProviderFunc == nil {
+		panic("ClientMock.ProviderFunc: method is nil but Client.Provider was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockProvider.Lock()
+	mock.calls.Provider = append(mock.calls.Provider, callInfo)
+	mock.lockProvider.Unlock()
+	return mock.ProviderFunc(name)
+}
+
+// ProviderCalls gets all the calls that were made to Provider.
+// Check the length with:
+//
+//	len(mockedClient.ProviderCalls())
+func (mock *ClientMock) ProviderCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockProvider.RLock()
+	calls = mock.calls.Provider
+	mock.lockProvider.RUnlock()
+	return calls
+}
@@ -0,0 +1,197 @@
+// Package oidc provides the OpenID Connect relying-party integration: it
+// builds the authorization URL for a provider and redeems the code the
+// provider sends back for a verified identity.
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/PhantomX7/athleton/pkg/config"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+//go:generate go tool moq -out mocks/mock.go -pkg mocks -fmt goimports . Client
+
+// ErrUnknownProvider is returned for a provider name OIDC_PROVIDERS does not
+// configure.
+var ErrUnknownProvider = errors.New("unknown oidc provider")
+
+// Identity is what a verified ID token says about the user.
+type Identity struct {
+	// Subject is the provider's stable identifier for the user; unlike the
+	// email it never changes.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Client runs the authorization code flow with PKCE against the configured
+// providers. Implementations must be safe for concurrent use.
+type Client interface {
+	// Provider returns the configuration of the named provider.
+	Provider(name string) (config.OIDCProvider, bool)
+	// AuthCodeURL returns the URL that sends the user to the provider to log
+	// in. state and nonce come back in the callback and the ID token; the
+	// provider only sees the S256 challenge of verifier.
+	AuthCodeURL(ctx context.Context, provider, state, nonce, verifier string) (string, error)
+	// Exchange redeems code with verifier and verifies the returned ID token:
+	// its signature against the provider's JWKS, issuer, audience, expiry and
+	// nonce.
+	Exchange(ctx context.Context, provider, code, verifier, nonce string) (*Identity, error)
+}
+
+type client struct {
+	redirectBaseURL string
+	providers       map[string]config.OIDCProvider
+
+	mu         sync.Mutex
+	discovered map[string]*discovered
+}
+
+// discovered is a provider whose discovery document has been read.
+type discovered struct {
+	oauth2   oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// New builds a Client for the OIDC_* configuration. It does not contact the
+// providers: each one's discovery document is fetched on its first login, so
+// a provider that is down only fails the logins that need it.
+func New(cfg *config.Config) (Client, error) {
+	list, err := cfg.OIDC.ProviderList()
+	if err != nil {
+		return nil, err
+	}
+	providers := make(map[string]config.OIDCProvider, len(list))
+	for _, p := range list {
+		providers[p.Name] = p
+	}
+	return &client{
+		redirectBaseURL: strings.TrimRight(cfg.OIDC.RedirectBaseURL, "/"),
+		providers:       providers,
+		discovered:      make(map[string]*discovered),
+	}, nil
+}
+
+func (c *client) Provider(name string) (config.OIDCProvider, bool) {
+	p, ok := c.providers[name]
+	return p, ok
+}
+
+func (c *client) AuthCodeURL(ctx context.Context, provider, state, nonce, verifier string) (string, error) {
+	d, err := c.discover(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	return d.oauth2.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (c *client) Exchange(ctx context.Context, provider, code, verifier, nonce string) (*Identity, error) {
+	d, err := c.discover(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := d.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	idToken, err := d.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce does not match the login")
+	}
+
+	var claims struct {
+		Email         string    `json:"email"`
+		EmailVerified claimBool `json:"email_verified"`
+		Name          string    `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("decode id token claims: %w", err)
+	}
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// discover returns provider's endpoints and ID token verifier, reading its
+// discovery document the first time. A failed discovery is not cached.
+//
+// The document is fetched without holding mu, so a slow or unreachable
+// provider does not hold up logins through the others. Concurrent first
+// logins may each fetch it; the first result stored is the one kept.
+func (c *client) discover(ctx context.Context, provider string) (*discovered, error) {
+	p, ok := c.providers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	c.mu.Lock()
+	d, ok := c.discovered[provider]
+	c.mu.Unlock()
+	if ok {
+		return d, nil
+	}
+
+	remote, err := gooidc.NewProvider(ctx, p.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s: %w", provider, err)
+	}
+	d = &discovered{
+		oauth2: oauth2.Config{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Endpoint:     remote.Endpoint(),
+			RedirectURL:  c.redirectBaseURL + "/" + provider + "/callback",
+			Scopes:       p.Scopes,
+		},
+		verifier: remote.Verifier(&gooidc.Config{ClientID: p.ClientID}),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stored, ok := c.discovered[provider]; ok {
+		return stored, nil
+	}
+	c.discovered[provider] = d
+	return d, nil
+}
+
+// claimBool decodes a boolean claim that some providers send as the string
+// "true" or "false".
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = claimBool(v)
+	case string:
+		*b = claimBool(strings.EqualFold(v, "true"))
+	default:
+		*b = false
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/PhantomX7/athleton/libs/oidc"
+	"github.com/PhantomX7/athleton/libs/oidc/oidctest"
+	"github.com/PhantomX7/athleton/pkg/config"
+)
+
+const redirectBase = "https://api.example.com/api/v1/auth/oidc"
+
+var alice = oidctest.User{Subject: "alice-sub", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}
+
+func newClient(t *testing.T) (oidc.Client, *oidctest.Issuer) {
+	t.Helper()
+
+	issuer := oidctest.NewIssuer(t, "athleton", "client-secret", alice)
+	cfg := &config.Config{OIDC: config.OIDCConfig{
+		Providers:       `[{"name":"local","issuer":"` + issuer.URL + `","client_id":"athleton","client_secret":"client-secret"}]`,
+		RedirectBaseURL: redirectBase + "/",
+	}}
+	client, err := oidc.New(cfg)
+	require.NoError(t, err)
+	return client, issuer
+}
+
+// login runs the flow up to the callback and returns its code.
+func login(t *testing.T, client oidc.Client, issuer *oidctest.Issuer, verifier, nonce string) string {
+	t.Helper()
+
+	authURL, err := client.AuthCodeURL(context.Background(), "local", "the-state", nonce, verifier)
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, redirectBase+"/local/callback", u.Query().Get("redirect_uri"))
+	require.Equal(t, "openid email profile", u.Query().Get("scope"))
+	require.Empty(t, u.Query().Get("code_verifier"), "the verifier never leaves the relying party")
+
+	callback := issuer.Authorize(t, authURL)
+	require.Equal(t, "the-state", callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestExchangeReturnsTheVerifiedIdentity(t *testing.T) {
+	client, issuer := newClient(t)
+	verifier := oauth2.GenerateVerifier()
+
+	code := login(t, client, issuer, verifier, "the-nonce")
+	identity, err := client.Exchange(context.Background(), "local", code, verifier, "the-nonce")
+	require.NoError(t, err)
+	require.Equal(t, &oidc.Identity{Subject: "alice-sub", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}, identity)
+
+	// A code is redeemed once.
+	_, err = client.Exchange(context.Background(), "local", code, verifier, "the-nonce")
+	require.Error(t, err)
+}
+
+func TestExchangeRefusals(t *testing.T) {
+	cases := map[string]struct {
+		claims   func(map[string]any)
+		verifier string
+		nonce    string
+	}{
+		"wrong verifier":   {verifier: oauth2.GenerateVerifier()},
+		"replayed nonce":   {nonce: "another-login"},
+		"other audience":   {claims: func(c map[string]any) { c["aud"] = "someone-else" }},
+		"other issuer":     {claims: func(c map[string]any) { c["iss"] = "https://evil.example.com" }},
+		"expired id token": {claims: func(c map[string]any) { c["exp"] = 1 }},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, issuer := newClient(t)
+			issuer.Claims = tc.claims
+			verifier := oauth2.GenerateVerifier()
+
+			code := login(t, client, issuer, verifier, "the-nonce")
+			if tc.verifier != "" {
+				verifier = tc.verifier
+			}
+			nonce := "the-nonce"
+			if tc.nonce != "" {
+				nonce = tc.nonce
+			}
+			identity, err := client.Exchange(context.Background(), "local", code, verifier, nonce)
+			require.Error(t, err)
+			require.Nil(t, identity)
+		})
+	}
+}
+
+func TestUnknownProvider(t *testing.T) {
+	client, _ := newClient(t)
+
+	_, ok := client.Provider("google")
+	require.False(t, ok)
+	_, err := client.AuthCodeURL(context.Background(), "google", "state", "nonce", "verifier")
+	require.ErrorIs(t, err, oidc.ErrUnknownProvider)
+
+	p, ok := client.Provider("local")
+	require.True(t, ok)
+	require.Equal(t, []string{"user"}, p.AllowedRoles)
+}
+
+// TestSlowDiscoveryDoesNotBlockOtherProviders — while one provider's
+// discovery document is still loading, logins through another go ahead.
+func TestSlowDiscoveryDoesNotBlockOtherProviders(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, "athleton", "client-secret", alice)
+	arrived, release := make(chan struct{}), make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(arrived)
+		<-release
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	// Runs before slow.Close, which waits for the blocked handler.
+	t.Cleanup(unblock)
+
+	client, err := oidc.New(&config.Config{OIDC: config.OIDCConfig{
+		Providers: `[{"name":"local","issuer":"` + issuer.URL + `","client_id":"athleton","client_secret":"client-secret"},` +
+			`{"name":"slow","issuer":"` + slow.URL + `","client_id":"athleton","client_secret":"client-secret"}]`,
+		RedirectBaseURL: redirectBase,
+	}})
+	require.NoError(t, err)
+
+	slowErr := make(chan error, 1)
+	go func() {
+		_, err := client.AuthCodeURL(context.Background(), "slow", "state", "nonce", "verifier")
+		slowErr <- err
+	}()
+	<-arrived
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.AuthCodeURL(context.Background(), "local", "state", "nonce", "verifier")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("discovery of another provider waited for the slow one")
+	}
+
+	unblock()
+	require.Error(t, <-slowErr)
+}
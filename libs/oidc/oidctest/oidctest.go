@@ -0,0 +1,264 @@
+// Package oidctest provides an in-process OpenID Connect provider stand-in
+// for tests. It serves discovery, a JWKS, an authorization endpoint that logs
+// the configured user straight in, and a token endpoint that checks the
+// client credentials and PKCE verifier before issuing an RS256 ID token.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+const keyID = "oidctest"
+
+// User is the account the provider logs in.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// grant is an issued, not yet redeemed authorization code.
+type grant struct {
+	user        User
+	redirectURI string
+	challenge   string
+	nonce       string
+}
+
+// Issuer is a running provider. It is safe for concurrent use.
+type Issuer struct {
+	// URL is the issuer identifier, the base of every endpoint.
+	URL          string
+	ClientID     string
+	ClientSecret string
+	// Claims, when set, may change the ID token claims before they are
+	// signed, to test how a relying party handles a bad token.
+	Claims func(claims map[string]any)
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	user   User
+	codes  map[string]grant
+}
+
+// NewIssuer starts a provider that accepts clientID and clientSecret and
+// stops it when the test ends. It logs user in until SetUser says otherwise.
+func NewIssuer(t testing.TB, clientID, clientSecret string, user User) *Issuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("oidctest: generate key: %v", err)
+	}
+	i := &Issuer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		user:         user,
+		codes:        make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("GET /jwks", i.jwks)
+	mux.HandleFunc("GET /authorize", i.authorize)
+	mux.HandleFunc("POST /token", i.token)
+	i.server = httptest.NewServer(mux)
+	i.URL = i.server.URL
+	t.Cleanup(i.server.Close)
+	return i
+}
+
+// SetUser changes the account the next authorization logs in.
+func (i *Issuer) SetUser(user User) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = user
+}
+
+// Authorize plays the browser's part at the provider: it follows authURL, as
+// returned by the relying party, and returns the callback URL the provider
+// redirects back to.
+func (i *Issuer) Authorize(t testing.TB, authURL string) *url.URL {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("oidctest: authorize: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("oidctest: authorize answered %d", resp.StatusCode)
+	}
+	callback, err := resp.Location()
+	if err != nil {
+		t.Fatalf("oidctest: authorize: %v", err)
+	}
+	return callback
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                i.URL,
+		"authorization_endpoint":                i.URL + "/authorize",
+		"token_endpoint":                        i.URL + "/token",
+		"jwks_uri":                              i.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &i.key.PublicKey,
+		KeyID:     keyID,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}}})
+}
+
+// authorize logs the configured user in without a prompt and redirects back
+// with a fresh code. Only S256 PKCE requests from the configured client are
+// accepted.
+func (i *Issuer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("response_type") != "code" || q.Get("client_id") != i.ClientID || redirectURI == "" ||
+		q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	i.mu.Lock()
+	i.codes[code] = grant{
+		user:        i.user,
+		redirectURI: redirectURI,
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+	}
+	i.mu.Unlock()
+
+	callback, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	params := callback.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	callback.RawQuery = params.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+// token redeems a code once, for the client it was issued to, with the
+// verifier whose challenge it was issued for.
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != i.ClientID || clientSecret != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	i.mu.Lock()
+	g, ok := i.codes[code]
+	delete(i.codes, code)
+	i.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || g.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := i.sign(g)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+// sign issues the ID token for g.
+func (i *Issuer) sign(g grant) (string, error) {
+	now := time.Now()
+	claims := map[string]any{
+		"iss":            i.URL,
+		"sub":            g.user.Subject,
+		"aud":            i.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          g.user.Email,
+		"email_verified": g.user.EmailVerified,
+		"name":           g.user.Name,
+	}
+	if g.nonce != "" {
+		claims["nonce"] = g.nonce
+	}
+	if i.Claims != nil {
+		i.Claims(claims)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: i.key, KeyID: keyID}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return "", err
+	}
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return signed.CompactSerialize()
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
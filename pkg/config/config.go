@@ -4,14 +4,20 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
 
+	"github.com/PhantomX7/athleton/pkg/constants/security"
+	"github.com/PhantomX7/athleton/pkg/password"
+
 	"github.com/spf13/viper"
 )
 
@@ -25,6 +31,10 @@ type Config struct {
 	Bleve    BleveConfig    `mapstructure:",squash"`
 	Admin    AdminConfig    `mapstructure:",squash"`
 	Log      LogConfig      `mapstructure:",squash"`
+	Auth     AuthConfig     `mapstructure:",squash"`
+	Mail     MailConfig     `mapstructure:",squash"`
+	LDAP     LDAPConfig     `mapstructure:",squash"`
+	OIDC     OIDCConfig     `mapstructure:",squash"`
 }
 
 // ServerConfig holds server-related configuration
@@ -48,7 +58,8 @@ type ServerConfig struct {
 	TrustedProxies []string `mapstructure:"SERVER_TRUSTED_PROXIES"`
 	// CORSAllowedOrigins is the allowlist of origins echoed back in
 	// Access-Control-Allow-Origin. Empty means wildcard ("*"), which is only
-	// safe while the API is pure bearer-token (no cookies). Comma-separated.
+	// safe while the API is pure bearer-token (no cookies); in AUTH_MODE=cookie
+	// the listed origins are also allowed to send credentials. Comma-separated.
 	CORSAllowedOrigins []string `mapstructure:"SERVER_CORS_ALLOWED_ORIGINS"`
 }
 
@@ -65,6 +76,7 @@ type DatabaseConfig struct {
 
 // JWTConfig holds JWT-related configuration
 type JWTConfig struct {
+	// Secret is the HS256 signing key, used only while SigningKeyFile is unset.
 	Secret            string        `mapstructure:"JWT_SECRET"`
 	Expiration        time.Duration `mapstructure:"JWT_EXPIRATION"`
 	RefreshExpiration time.Duration `mapstructure:"JWT_REFRESH_EXPIRATION"`
@@ -72,6 +84,15 @@ type JWTConfig struct {
 	// MaxActiveSessions caps concurrent refresh-token sessions per user; the
 	// oldest session is revoked when a login would exceed it. 0 disables the cap.
 	MaxActiveSessions int `mapstructure:"JWT_MAX_ACTIVE_SESSIONS"`
+	// SigningKeyFile is a PEM private key (RSA for RS256, Ed25519 for EdDSA)
+	// that signs access tokens. When set it replaces Secret, and its public
+	// half is published at /.well-known/jwks.json.
+	SigningKeyFile string `mapstructure:"JWT_SIGNING_KEY_FILE"`
+	// VerificationKeyFiles are PEM keys (public or private) of retired signing
+	// keys. Tokens they signed are still accepted — and the keys still
+	// published — until they are removed from this list, which is safe once
+	// JWT_EXPIRATION has passed since the rotation. Comma-separated in env.
+	VerificationKeyFiles []string `mapstructure:"JWT_VERIFICATION_KEY_FILES"`
 }
 
 // AppConfig holds general application configuration
@@ -119,6 +140,306 @@ type LogConfig struct {
 	Console    bool   `mapstructure:"LOG_CONSOLE"`
 }
 
+// AuthConfig holds self-service account-flow configuration
+type AuthConfig struct {
+	// PasswordResetTTL is how long an emailed password-reset token stays
+	// redeemable. Keep it short: the token is a bearer credential for the
+	// account until it is used or expires.
+	PasswordResetTTL time.Duration `mapstructure:"AUTH_PASSWORD_RESET_TTL"`
+	// PasswordResetURL is the frontend page the reset email links to; the
+	// token is appended as the "token" query parameter.
+	PasswordResetURL string `mapstructure:"AUTH_PASSWORD_RESET_URL"`
+	// EmailVerificationTTL is how long an emailed verification link stays
+	// redeemable; the user can request a fresh one once it lapses.
+	EmailVerificationTTL time.Duration `mapstructure:"AUTH_EMAIL_VERIFICATION_TTL"`
+	// EmailVerificationURL is the frontend page the verification email links
+	// to; the token is appended as the "token" query parameter.
+	EmailVerificationURL string `mapstructure:"AUTH_EMAIL_VERIFICATION_URL"`
+	// RequireEmailVerification refuses login (and access with tokens already
+	// issued) to self-registered accounts until they verify their address.
+	// When false, the verification state is only reported to the client.
+	RequireEmailVerification bool `mapstructure:"AUTH_REQUIRE_EMAIL_VERIFICATION"`
+	// EmailChangeTTL is how long the confirmation link sent to a new address
+	// stays redeemable; the old address keeps working until it is used.
+	EmailChangeTTL time.Duration `mapstructure:"AUTH_EMAIL_CHANGE_TTL"`
+	// EmailChangeURL is the frontend page the confirmation email links to;
+	// the token is appended as the "token" query parameter.
+	EmailChangeURL string `mapstructure:"AUTH_EMAIL_CHANGE_URL"`
+	// DataExportTTL is how long the download link returned by
+	// POST /auth/me/export keeps serving the user's data archive.
+	DataExportTTL time.Duration `mapstructure:"AUTH_DATA_EXPORT_TTL"`
+	// MagicLinkRoles lists the user roles ("user", "admin", "root") allowed
+	// to log in through an emailed single-use link instead of a password.
+	// Empty disables passwordless login; admin-type roles are only included
+	// when listed explicitly.
+	MagicLinkRoles []string `mapstructure:"AUTH_MAGIC_LINK_ROLES"`
+	// MagicLinkTTL is how long an emailed login link stays redeemable.
+	MagicLinkTTL time.Duration `mapstructure:"AUTH_MAGIC_LINK_TTL"`
+	// MagicLinkURL is the frontend page the login email links to; the token
+	// is appended as the "token" query parameter.
+	MagicLinkURL string `mapstructure:"AUTH_MAGIC_LINK_URL"`
+	// TwoFactorChallengeTTL is how long the challenge token returned by a
+	// password login of a 2FA-enabled account can be exchanged for a session.
+	TwoFactorChallengeTTL time.Duration `mapstructure:"AUTH_TWO_FACTOR_CHALLENGE_TTL"`
+	// TwoFactorRequiredForAdmins blocks admin and root accounts from /admin
+	// until they have enrolled in TOTP two-factor authentication.
+	TwoFactorRequiredForAdmins bool `mapstructure:"AUTH_TWO_FACTOR_REQUIRED_FOR_ADMINS"`
+	// LockoutThreshold is the number of consecutive failed logins after which
+	// an account is locked; 0 disables per-account lockout.
+	LockoutThreshold int `mapstructure:"AUTH_LOCKOUT_THRESHOLD"`
+	// LockoutDuration is the length of the first lockout. Every further
+	// failure once the threshold is reached doubles it, up to
+	// LockoutMaxDuration.
+	LockoutDuration    time.Duration `mapstructure:"AUTH_LOCKOUT_DURATION"`
+	LockoutMaxDuration time.Duration `mapstructure:"AUTH_LOCKOUT_MAX_DURATION"`
+	// LockoutWindow is how long failures are remembered: a failure more than
+	// this long after the previous one, and after any lockout has lapsed,
+	// starts the count over.
+	LockoutWindow time.Duration `mapstructure:"AUTH_LOCKOUT_WINDOW"`
+	// ImpersonationTTL bounds an impersonation session. Its access token
+	// cannot be refreshed, so support staff start a new one once it lapses.
+	ImpersonationTTL time.Duration `mapstructure:"AUTH_IMPERSONATION_TTL"`
+	// ReauthenticationWindow is how long after a login or a
+	// POST /auth/reauthenticate a session may perform sensitive operations
+	// (deleting users, changing another admin's password, granting admin
+	// permissions) before it must prove its identity again.
+	ReauthenticationWindow time.Duration `mapstructure:"AUTH_REAUTHENTICATION_WINDOW"`
+	// CacheTTL is how long the authorizer may reuse a user or session row it
+	// loaded instead of querying it again. Revocations on this instance take
+	// effect at once; on other instances within CacheTTL. Zero disables it.
+	CacheTTL time.Duration `mapstructure:"AUTH_CACHE_TTL"`
+	// Mode selects how clients carry tokens. "bearer" returns them in the
+	// response body for an Authorization header; "cookie" sets them as
+	// HttpOnly cookies instead and requires a CSRF token on unsafe requests.
+	Mode string `mapstructure:"AUTH_MODE"`
+	// CookieDomain scopes the auth cookies; empty means the API host only.
+	CookieDomain string `mapstructure:"AUTH_COOKIE_DOMAIN"`
+	// CookieSameSite is the SameSite attribute of the auth cookies: "strict",
+	// "lax" or "none" (for a frontend on another site).
+	CookieSameSite string `mapstructure:"AUTH_COOKIE_SAME_SITE"`
+	// PasswordMinLength is the shortest password accepted anywhere one is
+	// chosen; security.MaxPasswordBytes caps the other end.
+	PasswordMinLength int `mapstructure:"AUTH_PASSWORD_MIN_LENGTH"`
+	// PasswordMinCharacterClasses is how many of lowercase, uppercase, digit
+	// and symbol a password must mix; 0 imposes no composition rule.
+	PasswordMinCharacterClasses int `mapstructure:"AUTH_PASSWORD_MIN_CHARACTER_CLASSES"`
+	// PasswordHistory is how many of an account's most recent passwords,
+	// the current one included, cannot be chosen again; 0 disables the check.
+	PasswordHistory int `mapstructure:"AUTH_PASSWORD_HISTORY"`
+	// PasswordBreachedListFile points at an offline list of SHA-1 hashes of
+	// breached passwords, one hex digest per line (an optional ":count"
+	// suffix, as in the Have I Been Pwned downloads, is ignored). Empty
+	// disables the check beyond a small built-in list of common passwords.
+	PasswordBreachedListFile string `mapstructure:"AUTH_PASSWORD_BREACHED_LIST_FILE"`
+	// PasswordMaxAge forces a password change once a password is this old,
+	// through the same gate that makes seeded admins rotate theirs; 0 lets
+	// passwords live forever.
+	PasswordMaxAge time.Duration `mapstructure:"AUTH_PASSWORD_MAX_AGE"`
+	// Argon2Memory (KiB), Argon2Iterations and Argon2Parallelism are the
+	// Argon2id cost of new password hashes. Raising them upgrades existing
+	// hashes as their owners log in, as it does legacy bcrypt hashes.
+	Argon2Memory      int `mapstructure:"AUTH_ARGON2_MEMORY"`
+	Argon2Iterations  int `mapstructure:"AUTH_ARGON2_ITERATIONS"`
+	Argon2Parallelism int `mapstructure:"AUTH_ARGON2_PARALLELISM"`
+	// SessionIdleTimeout ends a session that has not been refreshed for this
+	// long. JWT_REFRESH_EXPIRATION bounds its age however often it is.
+	SessionIdleTimeout time.Duration `mapstructure:"AUTH_SESSION_IDLE_TIMEOUT"`
+	// RememberMeMaxAge and RememberMeIdleTimeout replace both limits for a
+	// login that sets remember_me.
+	RememberMeMaxAge      time.Duration `mapstructure:"AUTH_REMEMBER_ME_MAX_AGE"`
+	RememberMeIdleTimeout time.Duration `mapstructure:"AUTH_REMEMBER_ME_IDLE_TIMEOUT"`
+	// The AdminSession* and AdminRememberMe* limits apply to admin and root
+	// sessions instead. 0 keeps the matching user limit; a set value may only
+	// be stricter.
+	AdminSessionMaxAge         time.Duration `mapstructure:"AUTH_ADMIN_SESSION_MAX_AGE"`
+	AdminSessionIdleTimeout    time.Duration `mapstructure:"AUTH_ADMIN_SESSION_IDLE_TIMEOUT"`
+	AdminRememberMeMaxAge      time.Duration `mapstructure:"AUTH_ADMIN_REMEMBER_ME_MAX_AGE"`
+	AdminRememberMeIdleTimeout time.Duration `mapstructure:"AUTH_ADMIN_REMEMBER_ME_IDLE_TIMEOUT"`
+}
+
+// Auth modes accepted by AUTH_MODE.
+const (
+	AuthModeBearer = "bearer"
+	AuthModeCookie = "cookie"
+)
+
+// MagicLinkEnabledFor reports whether accounts with role may log in by
+// emailed link.
+func (a AuthConfig) MagicLinkEnabledFor(role string) bool {
+	return slices.Contains(a.MagicLinkRoles, role)
+}
+
+// SessionPolicy bounds a refresh-token session: it ends MaxAge after the
+// login that opened it, or IdleTimeout after it was last refreshed.
+type SessionPolicy struct {
+	MaxAge      time.Duration
+	IdleTimeout time.Duration
+}
+
+// SessionPolicy returns the limits of a session for an admin-type or a
+// regular account, opened with or without remember_me.
+func (c *Config) SessionPolicy(admin, rememberMe bool) SessionPolicy {
+	policy := SessionPolicy{MaxAge: c.JWT.RefreshExpiration, IdleTimeout: c.Auth.SessionIdleTimeout}
+	adminPolicy := SessionPolicy{MaxAge: c.Auth.AdminSessionMaxAge, IdleTimeout: c.Auth.AdminSessionIdleTimeout}
+	if rememberMe {
+		policy = SessionPolicy{MaxAge: c.Auth.RememberMeMaxAge, IdleTimeout: c.Auth.RememberMeIdleTimeout}
+		adminPolicy = SessionPolicy{MaxAge: c.Auth.AdminRememberMeMaxAge, IdleTimeout: c.Auth.AdminRememberMeIdleTimeout}
+	}
+	if admin {
+		if adminPolicy.MaxAge > 0 {
+			policy.MaxAge = adminPolicy.MaxAge
+		}
+		if adminPolicy.IdleTimeout > 0 {
+			policy.IdleTimeout = adminPolicy.IdleTimeout
+		}
+	}
+	return policy
+}
+
+// LongestSessionMaxAge is the age no session outlives, under any policy.
+func (c *Config) LongestSessionMaxAge() time.Duration {
+	return max(c.JWT.RefreshExpiration, c.Auth.RememberMeMaxAge)
+}
+
+// PasswordHashParams returns the Argon2id parameters for new password hashes.
+func (a AuthConfig) PasswordHashParams() password.Params {
+	return password.Params{
+		Memory:      uint32(a.Argon2Memory),     // #nosec G115 -- bounded by validateAuth
+		Iterations:  uint32(a.Argon2Iterations), // #nosec G115 -- bounded by validateAuth
+		Parallelism: uint8(a.Argon2Parallelism), // #nosec G115 -- bounded by validateAuth
+	}
+}
+
+// CookieMode reports whether tokens travel in cookies rather than the body.
+func (a AuthConfig) CookieMode() bool {
+	return a.Mode == AuthModeCookie
+}
+
+// MailConfig holds outbound-mail configuration
+type MailConfig struct {
+	// Driver selects the mailer implementation: "log" writes messages to the
+	// application log, "file" writes one .eml file per message to FileDir.
+	Driver  string `mapstructure:"MAIL_DRIVER"`
+	From    string `mapstructure:"MAIL_FROM"`
+	FileDir string `mapstructure:"MAIL_FILE_DIR"`
+}
+
+// LDAPConfig holds the directory (LDAP / Active Directory) login backend
+// configuration. When enabled, admin accounts log in with their directory
+// credentials and are provisioned on first login; root and regular users
+// keep their local passwords.
+type LDAPConfig struct {
+	Enabled bool `mapstructure:"LDAP_ENABLED"`
+	// URL is the server to dial, ldap:// or ldaps://.
+	URL string `mapstructure:"LDAP_URL"`
+	// StartTLS upgrades an ldap:// connection before any credentials are sent.
+	StartTLS bool `mapstructure:"LDAP_START_TLS"`
+	// BindDN and BindPassword are the service account used to look users up.
+	// Empty binds anonymously, which many directories refuse.
+	BindDN       string `mapstructure:"LDAP_BIND_DN"`
+	BindPassword string `mapstructure:"LDAP_BIND_PASSWORD"`
+	// BaseDN is the subtree searched for users.
+	BaseDN string `mapstructure:"LDAP_BASE_DN"`
+	// UserFilter finds the entry of the username typed at login, which
+	// replaces its single %s (escaped). Active Directory typically uses
+	// (sAMAccountName=%s).
+	UserFilter string `mapstructure:"LDAP_USER_FILTER"`
+	// The attributes read from the user entry.
+	UsernameAttribute string `mapstructure:"LDAP_USERNAME_ATTRIBUTE"`
+	EmailAttribute    string `mapstructure:"LDAP_EMAIL_ATTRIBUTE"`
+	NameAttribute     string `mapstructure:"LDAP_NAME_ATTRIBUTE"`
+	GroupAttribute    string `mapstructure:"LDAP_GROUP_ATTRIBUTE"`
+	// GroupRoles maps directory groups to admin roles, as semicolon-separated
+	// "<group DN>=><admin role name>" pairs. A user in several mapped groups
+	// gets the roles of all of them; a user in none cannot log in.
+	GroupRoles string `mapstructure:"LDAP_GROUP_ROLES"`
+	// Timeout bounds dialing and each request to the server.
+	Timeout time.Duration `mapstructure:"LDAP_TIMEOUT"`
+}
+
+// LDAPGroupRole is one entry of LDAP_GROUP_ROLES.
+type LDAPGroupRole struct {
+	GroupDN   string
+	AdminRole string
+}
+
+// GroupRoleMappings parses GroupRoles, in the order they are listed.
+func (l LDAPConfig) GroupRoleMappings() ([]LDAPGroupRole, error) {
+	var mappings []LDAPGroupRole
+	for pair := range strings.SplitSeq(l.GroupRoles, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=>")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" || role == "" {
+			return nil, fmt.Errorf("invalid group role mapping: %q (want <group DN>=><admin role name>)", pair)
+		}
+		mappings = append(mappings, LDAPGroupRole{GroupDN: group, AdminRole: role})
+	}
+	return mappings, nil
+}
+
+// OIDCConfig holds the OpenID Connect providers users can log in with. The
+// login is the authorization code flow with PKCE; the account is found by the
+// identity linked to it, or linked and, if need be, provisioned by the
+// verified email the provider reports.
+type OIDCConfig struct {
+	// Providers is a JSON array of OIDCProvider objects; empty disables OIDC
+	// login. See OIDCConfig.ProviderList.
+	Providers string `mapstructure:"OIDC_PROVIDERS"`
+	// RedirectBaseURL is the public URL the /auth/oidc routes are served
+	// under. Provider <name> sends users back to
+	// <RedirectBaseURL>/<name>/callback, which must be registered with it.
+	RedirectBaseURL string `mapstructure:"OIDC_REDIRECT_BASE_URL"`
+	// StateTTL is how long a user may take at the provider before the login
+	// has to be started again.
+	StateTTL time.Duration `mapstructure:"OIDC_STATE_TTL"`
+}
+
+// OIDCProvider is one entry of OIDC_PROVIDERS.
+type OIDCProvider struct {
+	// Name identifies the provider in the /auth/oidc/:provider routes and in
+	// linked identities, so it must not change once users have logged in.
+	Name string `json:"name"`
+	// Issuer is the provider's issuer URL; its discovery document is read
+	// from <Issuer>/.well-known/openid-configuration.
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// Scopes requested at the provider; "openid", "email" and "profile" when
+	// omitted.
+	Scopes []string `json:"scopes"`
+	// AllowedRoles lists the user roles ("user", "admin", "root") that may log
+	// in through the provider; only "user" when omitted. New accounts are
+	// only provisioned while it includes "user".
+	AllowedRoles []string `json:"allowed_roles"`
+}
+
+// oidcProviderName is the form a provider name must take to appear in a URL
+// path.
+var oidcProviderName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ProviderList parses Providers, filling in the default scopes and roles.
+func (o OIDCConfig) ProviderList() ([]OIDCProvider, error) {
+	if strings.TrimSpace(o.Providers) == "" {
+		return nil, nil
+	}
+	var providers []OIDCProvider
+	if err := json.Unmarshal([]byte(o.Providers), &providers); err != nil {
+		return nil, fmt.Errorf("invalid providers: %w", err)
+	}
+	for i := range providers {
+		if len(providers[i].Scopes) == 0 {
+			providers[i].Scopes = []string{"openid", "email", "profile"}
+		}
+		if len(providers[i].AllowedRoles) == 0 {
+			providers[i].AllowedRoles = []string{"user"}
+		}
+	}
+	return providers, nil
+}
+
 // Load initializes and loads the configuration from various sources. The
 // returned *Config is the single instance the application wires through its
 // fx container (fx.Supply); there is no process-global accessor by design.
@@ -184,11 +505,13 @@ func setDefaults(v *viper.Viper) {
 		"DATABASE_SSLMODE":  "disable",
 
 		// JWT
-		"JWT_SECRET":              "your-secret-key",
-		"JWT_EXPIRATION":          "10m",
-		"JWT_REFRESH_EXPIRATION":  "72h",
-		"JWT_ISSUER":              "starter",
-		"JWT_MAX_ACTIVE_SESSIONS": 10,
+		"JWT_SECRET":                 "your-secret-key",
+		"JWT_EXPIRATION":             "10m",
+		"JWT_REFRESH_EXPIRATION":     "72h",
+		"JWT_ISSUER":                 "starter",
+		"JWT_MAX_ACTIVE_SESSIONS":    10,
+		"JWT_SIGNING_KEY_FILE":       "", // HS256 with JWT_SECRET unless set
+		"JWT_VERIFICATION_KEY_FILES": "",
 
 		// App
 		"APP_NAME":        "Starter",
@@ -221,6 +544,71 @@ func setDefaults(v *viper.Viper) {
 		"LOG_MAX_AGE":     30,
 		"LOG_COMPRESS":    true,
 		"LOG_CONSOLE":     true,
+
+		// Auth
+		"AUTH_PASSWORD_RESET_TTL":             "30m",
+		"AUTH_PASSWORD_RESET_URL":             "http://localhost:3000/reset-password",
+		"AUTH_EMAIL_VERIFICATION_TTL":         "24h",
+		"AUTH_EMAIL_VERIFICATION_URL":         "http://localhost:3000/verify-email",
+		"AUTH_REQUIRE_EMAIL_VERIFICATION":     false,
+		"AUTH_EMAIL_CHANGE_TTL":               "1h",
+		"AUTH_EMAIL_CHANGE_URL":               "http://localhost:3000/confirm-email-change",
+		"AUTH_DATA_EXPORT_TTL":                "15m",
+		"AUTH_MAGIC_LINK_ROLES":               "", // passwordless login is opt-in per role
+		"AUTH_MAGIC_LINK_TTL":                 "15m",
+		"AUTH_MAGIC_LINK_URL":                 "http://localhost:3000/magic-link",
+		"AUTH_TWO_FACTOR_CHALLENGE_TTL":       "5m",
+		"AUTH_TWO_FACTOR_REQUIRED_FOR_ADMINS": false,
+		"AUTH_LOCKOUT_THRESHOLD":              5,
+		"AUTH_LOCKOUT_DURATION":               "1m",
+		"AUTH_LOCKOUT_MAX_DURATION":           "1h",
+		"AUTH_LOCKOUT_WINDOW":                 "15m",
+		"AUTH_IMPERSONATION_TTL":              "15m",
+		"AUTH_REAUTHENTICATION_WINDOW":        "5m",
+		"AUTH_CACHE_TTL":                      "10s",
+		"AUTH_MODE":                           AuthModeBearer,
+		"AUTH_COOKIE_DOMAIN":                  "",
+		"AUTH_COOKIE_SAME_SITE":               "strict",
+		"AUTH_PASSWORD_MIN_LENGTH":            8,
+		"AUTH_PASSWORD_MIN_CHARACTER_CLASSES": 0,
+		"AUTH_PASSWORD_HISTORY":               5,
+		"AUTH_PASSWORD_BREACHED_LIST_FILE":    "",
+		"AUTH_PASSWORD_MAX_AGE":               "0s",
+		"AUTH_ARGON2_MEMORY":                  password.DefaultParams.Memory,
+		"AUTH_ARGON2_ITERATIONS":              password.DefaultParams.Iterations,
+		"AUTH_ARGON2_PARALLELISM":             password.DefaultParams.Parallelism,
+		"AUTH_SESSION_IDLE_TIMEOUT":           "24h",
+		"AUTH_REMEMBER_ME_MAX_AGE":            "720h",
+		"AUTH_REMEMBER_ME_IDLE_TIMEOUT":       "168h",
+		"AUTH_ADMIN_SESSION_MAX_AGE":          "0s", // 0 = same as users
+		"AUTH_ADMIN_SESSION_IDLE_TIMEOUT":     "0s",
+		"AUTH_ADMIN_REMEMBER_ME_MAX_AGE":      "0s",
+		"AUTH_ADMIN_REMEMBER_ME_IDLE_TIMEOUT": "0s",
+
+		// Mail
+		"MAIL_DRIVER":   "log",
+		"MAIL_FROM":     "no-reply@localhost",
+		"MAIL_FILE_DIR": "./mail",
+
+		// LDAP
+		"LDAP_ENABLED":            false,
+		"LDAP_URL":                "",
+		"LDAP_START_TLS":          false,
+		"LDAP_BIND_DN":            "",
+		"LDAP_BIND_PASSWORD":      "",
+		"LDAP_BASE_DN":            "",
+		"LDAP_USER_FILTER":        "(uid=%s)",
+		"LDAP_USERNAME_ATTRIBUTE": "uid",
+		"LDAP_EMAIL_ATTRIBUTE":    "mail",
+		"LDAP_NAME_ATTRIBUTE":     "cn",
+		"LDAP_GROUP_ATTRIBUTE":    "memberOf",
+		"LDAP_GROUP_ROLES":        "",
+		"LDAP_TIMEOUT":            "5s",
+
+		// OIDC
+		"OIDC_PROVIDERS":         "",
+		"OIDC_REDIRECT_BASE_URL": "",
+		"OIDC_STATE_TTL":         "10m",
 	}
 
 	for key, value := range defaults {
@@ -240,6 +628,10 @@ func (c *Config) validate() error {
 		{"app", c.validateApp},
 		{"admin", c.validateAdmin},
 		{"log", c.validateLog},
+		{"auth", c.validateAuth},
+		{"mail", c.validateMail},
+		{"ldap", c.validateLDAP},
+		{"oidc", c.validateOIDC},
 	}
 
 	for _, v := range validators {
@@ -288,6 +680,27 @@ var placeholderJWTSecrets = []string{
 
 // validateJWT validates JWT configuration
 func (c *Config) validateJWT() error {
+	// The secret only matters for HS256; with a signing key file it is unused.
+	// The key files themselves are parsed (and rejected) when the auth
+	// middleware is built.
+	if c.JWT.SigningKeyFile == "" {
+		if err := c.validateJWTSecret(); err != nil {
+			return err
+		}
+	}
+	if c.JWT.Expiration <= 0 {
+		return fmt.Errorf("expiration must be greater than 0")
+	}
+	if c.JWT.RefreshExpiration <= 0 {
+		return fmt.Errorf("refresh expiration must be greater than 0")
+	}
+	if c.JWT.MaxActiveSessions < 0 {
+		return fmt.Errorf("max active sessions cannot be negative")
+	}
+	return nil
+}
+
+func (c *Config) validateJWTSecret() error {
 	if c.JWT.Secret == "" || c.JWT.Secret == "your-secret-key" {
 		return fmt.Errorf("secret must be set and not use default value")
 	}
@@ -300,15 +713,6 @@ func (c *Config) validateJWT() error {
 		}
 		log.Println("WARNING: JWT_SECRET is a known placeholder value; this is rejected in production")
 	}
-	if c.JWT.Expiration <= 0 {
-		return fmt.Errorf("expiration must be greater than 0")
-	}
-	if c.JWT.RefreshExpiration <= 0 {
-		return fmt.Errorf("refresh expiration must be greater than 0")
-	}
-	if c.JWT.MaxActiveSessions < 0 {
-		return fmt.Errorf("max active sessions cannot be negative")
-	}
 	return nil
 }
 
@@ -327,18 +731,12 @@ func (c *Config) validateApp() error {
 	return nil
 }
 
-// weakAdminPasswords are well-known values (former defaults, keyboard walks)
-// that would hand out working superuser credentials if seeded.
-var weakAdminPasswords = []string{
-	"q1w2e3r4", "password", "admin", "admin123", "12345678", "changeme",
-}
-
 // validateAdmin validates admin configuration
 func (c *Config) validateAdmin() error {
 	if c.Admin.DefaultPassword == "" {
 		return fmt.Errorf("default password must be set (no default is provided on purpose)")
 	}
-	if slices.Contains(weakAdminPasswords, strings.ToLower(c.Admin.DefaultPassword)) {
+	if security.IsCommonPassword(c.Admin.DefaultPassword) {
 		if c.IsProduction() {
 			return fmt.Errorf("default password is a well-known weak value; choose a strong password")
 		}
@@ -376,6 +774,236 @@ func (c *Config) validateLog() error {
 	return nil
 }
 
+// validateAuth validates self-service account-flow configuration
+func (c *Config) validateAuth() error {
+	if c.Auth.PasswordResetTTL <= 0 {
+		return fmt.Errorf("password reset ttl must be greater than 0")
+	}
+	if !isAbsoluteURL(c.Auth.PasswordResetURL) {
+		return fmt.Errorf("password reset url must be an absolute URL (got %q)", c.Auth.PasswordResetURL)
+	}
+	if c.Auth.EmailVerificationTTL <= 0 {
+		return fmt.Errorf("email verification ttl must be greater than 0")
+	}
+	if !isAbsoluteURL(c.Auth.EmailVerificationURL) {
+		return fmt.Errorf("email verification url must be an absolute URL (got %q)", c.Auth.EmailVerificationURL)
+	}
+	if c.Auth.EmailChangeTTL <= 0 {
+		return fmt.Errorf("email change ttl must be greater than 0")
+	}
+	if !isAbsoluteURL(c.Auth.EmailChangeURL) {
+		return fmt.Errorf("email change url must be an absolute URL (got %q)", c.Auth.EmailChangeURL)
+	}
+	if c.Auth.DataExportTTL <= 0 {
+		return fmt.Errorf("data export ttl must be greater than 0")
+	}
+	for _, role := range c.Auth.MagicLinkRoles {
+		if !slices.Contains(supportedUserRoles, role) {
+			return fmt.Errorf("invalid magic link role: %q (must be one of %v)", role, supportedUserRoles)
+		}
+	}
+	if c.Auth.MagicLinkTTL <= 0 {
+		return fmt.Errorf("magic link ttl must be greater than 0")
+	}
+	if !isAbsoluteURL(c.Auth.MagicLinkURL) {
+		return fmt.Errorf("magic link url must be an absolute URL (got %q)", c.Auth.MagicLinkURL)
+	}
+	if c.Auth.TwoFactorChallengeTTL <= 0 {
+		return fmt.Errorf("two-factor challenge ttl must be greater than 0")
+	}
+	if c.Auth.LockoutThreshold < 0 {
+		return fmt.Errorf("lockout threshold cannot be negative")
+	}
+	if c.Auth.LockoutThreshold > 0 {
+		if c.Auth.LockoutDuration <= 0 {
+			return fmt.Errorf("lockout duration must be greater than 0")
+		}
+		if c.Auth.LockoutMaxDuration < c.Auth.LockoutDuration {
+			return fmt.Errorf("lockout max duration must not be shorter than lockout duration")
+		}
+		if c.Auth.LockoutWindow <= 0 {
+			return fmt.Errorf("lockout window must be greater than 0")
+		}
+	}
+	if c.Auth.ImpersonationTTL <= 0 {
+		return fmt.Errorf("impersonation ttl must be greater than 0")
+	}
+	if c.Auth.ReauthenticationWindow <= 0 {
+		return fmt.Errorf("reauthentication window must be greater than 0")
+	}
+	if c.Auth.CacheTTL < 0 {
+		return fmt.Errorf("cache ttl cannot be negative")
+	}
+	if !slices.Contains(supportedAuthModes, c.Auth.Mode) {
+		return fmt.Errorf("invalid mode: %q (must be one of %v)", c.Auth.Mode, supportedAuthModes)
+	}
+	if !slices.Contains(supportedCookieSameSite, c.Auth.CookieSameSite) {
+		return fmt.Errorf("invalid cookie same site: %q (must be one of %v)", c.Auth.CookieSameSite, supportedCookieSameSite)
+	}
+	if c.Auth.PasswordMinLength < 8 || c.Auth.PasswordMinLength > security.MaxPasswordBytes {
+		return fmt.Errorf("password min length must be between 8 and %d", security.MaxPasswordBytes)
+	}
+	if c.Auth.PasswordMinCharacterClasses < 0 || c.Auth.PasswordMinCharacterClasses > 4 {
+		return fmt.Errorf("password min character classes must be between 0 and 4")
+	}
+	if c.Auth.PasswordHistory < 0 {
+		return fmt.Errorf("password history cannot be negative")
+	}
+	if c.Auth.PasswordMaxAge < 0 {
+		return fmt.Errorf("password max age cannot be negative")
+	}
+	if c.Auth.Argon2Parallelism < 1 || c.Auth.Argon2Parallelism > math.MaxUint8 {
+		return fmt.Errorf("argon2 parallelism must be between 1 and %d", math.MaxUint8)
+	}
+	// Argon2 needs at least 8 KiB per lane.
+	if c.Auth.Argon2Memory < 8*c.Auth.Argon2Parallelism || int64(c.Auth.Argon2Memory) > math.MaxUint32 {
+		return fmt.Errorf("argon2 memory must be at least 8 KiB per lane (%d KiB)", 8*c.Auth.Argon2Parallelism)
+	}
+	if c.Auth.Argon2Iterations < 1 || int64(c.Auth.Argon2Iterations) > math.MaxUint32 {
+		return fmt.Errorf("argon2 iterations must be at least 1")
+	}
+	if c.Auth.SessionIdleTimeout <= 0 {
+		return fmt.Errorf("session idle timeout must be greater than 0")
+	}
+	if c.Auth.RememberMeMaxAge <= 0 {
+		return fmt.Errorf("remember me max age must be greater than 0")
+	}
+	if c.Auth.RememberMeIdleTimeout <= 0 {
+		return fmt.Errorf("remember me idle timeout must be greater than 0")
+	}
+	for _, limit := range []struct {
+		name        string
+		admin, user time.Duration
+	}{
+		{"admin session max age", c.Auth.AdminSessionMaxAge, c.JWT.RefreshExpiration},
+		{"admin session idle timeout", c.Auth.AdminSessionIdleTimeout, c.Auth.SessionIdleTimeout},
+		{"admin remember me max age", c.Auth.AdminRememberMeMaxAge, c.Auth.RememberMeMaxAge},
+		{"admin remember me idle timeout", c.Auth.AdminRememberMeIdleTimeout, c.Auth.RememberMeIdleTimeout},
+	} {
+		if limit.admin < 0 {
+			return fmt.Errorf("%s cannot be negative", limit.name)
+		}
+		if limit.admin > limit.user {
+			return fmt.Errorf("%s must not be longer than the user limit (%s)", limit.name, limit.user)
+		}
+	}
+	return nil
+}
+
+var (
+	supportedAuthModes      = []string{AuthModeBearer, AuthModeCookie}
+	supportedCookieSameSite = []string{"strict", "lax", "none"}
+	// supportedUserRoles mirrors models.UserRole, which config cannot import.
+	supportedUserRoles = []string{"user", "admin", "root"}
+)
+
+// isAbsoluteURL reports whether raw parses as a URL with a scheme and host,
+// i.e. something that can be emailed as a clickable link.
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// supportedMailDrivers are the mailer implementations libs/mailer ships.
+var supportedMailDrivers = []string{"log", "file"}
+
+// validateMail validates outbound-mail configuration
+func (c *Config) validateMail() error {
+	if !slices.Contains(supportedMailDrivers, c.Mail.Driver) {
+		return fmt.Errorf("invalid driver: %q (must be one of %v)", c.Mail.Driver, supportedMailDrivers)
+	}
+	if c.Mail.From == "" {
+		return fmt.Errorf("from address is required")
+	}
+	if c.Mail.Driver == "file" && c.Mail.FileDir == "" {
+		return fmt.Errorf("file dir is required for the file driver")
+	}
+	return nil
+}
+
+// validateLDAP validates the directory login backend. Nothing is checked
+// while it is disabled.
+func (c *Config) validateLDAP() error {
+	if !c.LDAP.Enabled {
+		return nil
+	}
+	u, err := url.Parse(c.LDAP.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return fmt.Errorf("url must be an ldap:// or ldaps:// URL (got %q)", c.LDAP.URL)
+	}
+	if c.LDAP.StartTLS && u.Scheme == "ldaps" {
+		return fmt.Errorf("start tls cannot be combined with an ldaps:// url")
+	}
+	if c.LDAP.BaseDN == "" {
+		return fmt.Errorf("base dn is required")
+	}
+	if strings.Count(c.LDAP.UserFilter, "%s") != 1 {
+		return fmt.Errorf("user filter must contain exactly one %%s (got %q)", c.LDAP.UserFilter)
+	}
+	if c.LDAP.UsernameAttribute == "" || c.LDAP.EmailAttribute == "" || c.LDAP.GroupAttribute == "" {
+		return fmt.Errorf("username, email and group attributes are required")
+	}
+	mappings, err := c.LDAP.GroupRoleMappings()
+	if err != nil {
+		return err
+	}
+	if len(mappings) == 0 {
+		return fmt.Errorf("group roles must map at least one group to an admin role")
+	}
+	if c.LDAP.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	return nil
+}
+
+// validateOIDC validates the OpenID Connect providers. Issuers must use https
+// in production; elsewhere plain http is accepted for local test issuers.
+func (c *Config) validateOIDC() error {
+	providers, err := c.OIDC.ProviderList()
+	if err != nil {
+		return err
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(c.OIDC.RedirectBaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("redirect base url must be an absolute http(s) URL (got %q)", c.OIDC.RedirectBaseURL)
+	}
+	if c.OIDC.StateTTL <= 0 {
+		return fmt.Errorf("state ttl must be greater than 0")
+	}
+
+	seen := make(map[string]bool, len(providers))
+	for _, p := range providers {
+		if !oidcProviderName.MatchString(p.Name) {
+			return fmt.Errorf("invalid provider name %q (lowercase letters, digits, - and _ only)", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate provider %q", p.Name)
+		}
+		seen[p.Name] = true
+
+		issuer, err := url.Parse(p.Issuer)
+		if err != nil || issuer.Host == "" || (issuer.Scheme != "https" && (issuer.Scheme != "http" || c.App.Environment == "production")) {
+			return fmt.Errorf("provider %s: issuer must be an https URL (got %q)", p.Name, p.Issuer)
+		}
+		if p.ClientID == "" {
+			return fmt.Errorf("provider %s: client id is required", p.Name)
+		}
+		if !slices.Contains(p.Scopes, "openid") {
+			return fmt.Errorf("provider %s: scopes must include openid", p.Name)
+		}
+		for _, role := range p.AllowedRoles {
+			if !slices.Contains(supportedUserRoles, role) {
+				return fmt.Errorf("provider %s: invalid allowed role %q (must be one of %v)", p.Name, role, supportedUserRoles)
+			}
+		}
+	}
+	return nil
+}
+
 // GetDatabaseURL constructs and returns the database connection URL.
 // Credentials are URL-escaped so passwords containing @ : / % # cannot
 // corrupt the DSN (or silently redirect the host portion).
@@ -50,6 +50,38 @@ func validConfig() *Config {
 			MaxBackups: 7,
 			MaxAge:     30,
 		},
+		Auth: AuthConfig{
+			PasswordResetTTL:       30 * time.Minute,
+			PasswordResetURL:       "http://localhost:3000/reset-password",
+			EmailVerificationTTL:   24 * time.Hour,
+			EmailVerificationURL:   "http://localhost:3000/verify-email",
+			EmailChangeTTL:         time.Hour,
+			EmailChangeURL:         "http://localhost:3000/confirm-email-change",
+			DataExportTTL:          15 * time.Minute,
+			MagicLinkTTL:           15 * time.Minute,
+			MagicLinkURL:           "http://localhost:3000/magic-link",
+			TwoFactorChallengeTTL:  5 * time.Minute,
+			LockoutThreshold:       5,
+			LockoutDuration:        time.Minute,
+			LockoutMaxDuration:     time.Hour,
+			LockoutWindow:          15 * time.Minute,
+			ImpersonationTTL:       15 * time.Minute,
+			ReauthenticationWindow: 5 * time.Minute,
+			Mode:                   AuthModeBearer,
+			CookieSameSite:         "strict",
+			PasswordMinLength:      8,
+			PasswordHistory:        5,
+			Argon2Memory:           64 * 1024,
+			Argon2Iterations:       3,
+			Argon2Parallelism:      4,
+			SessionIdleTimeout:     24 * time.Hour,
+			RememberMeMaxAge:       30 * 24 * time.Hour,
+			RememberMeIdleTimeout:  7 * 24 * time.Hour,
+		},
+		Mail: MailConfig{
+			Driver: "log",
+			From:   "no-reply@localhost",
+		},
 	}
 }
 
@@ -188,6 +220,13 @@ func TestValidateJWT(t *testing.T) {
 	c.JWT.MaxActiveSessions = -1
 	require.ErrorContains(t, c.validateJWT(), "max active sessions")
 
+	// With an asymmetric signing key the secret is unused, so it is not
+	// required.
+	c = validConfig()
+	c.JWT.Secret = ""
+	c.JWT.SigningKeyFile = "/etc/athleton/jwt.pem"
+	require.NoError(t, c.validateJWT())
+
 	require.NoError(t, validConfig().validateJWT())
 }
 
@@ -266,6 +305,322 @@ func TestValidateLog(t *testing.T) {
 	require.ErrorContains(t, c.validateLog(), "max age cannot be negative")
 }
 
+func TestValidateAuth(t *testing.T) {
+	t.Parallel()
+
+	c := validConfig()
+	c.Auth.PasswordResetTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "password reset ttl")
+
+	for _, raw := range []string{"", "reset-password", "/reset-password"} {
+		c = validConfig()
+		c.Auth.PasswordResetURL = raw
+		require.ErrorContains(t, c.validateAuth(), "absolute URL", raw)
+	}
+
+	c = validConfig()
+	c.Auth.EmailVerificationTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "email verification ttl")
+
+	c = validConfig()
+	c.Auth.EmailVerificationURL = "/verify-email"
+	require.ErrorContains(t, c.validateAuth(), "email verification url")
+
+	c = validConfig()
+	c.Auth.EmailChangeTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "email change ttl")
+
+	c = validConfig()
+	c.Auth.EmailChangeURL = "/confirm-email-change"
+	require.ErrorContains(t, c.validateAuth(), "email change url")
+
+	c = validConfig()
+	c.Auth.DataExportTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "data export ttl")
+
+	c = validConfig()
+	c.Auth.MagicLinkRoles = []string{"user", "editor"}
+	require.ErrorContains(t, c.validateAuth(), "invalid magic link role")
+
+	c = validConfig()
+	c.Auth.MagicLinkTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "magic link ttl")
+
+	c = validConfig()
+	c.Auth.MagicLinkURL = "/magic-link"
+	require.ErrorContains(t, c.validateAuth(), "magic link url")
+
+	c = validConfig()
+	c.Auth.TwoFactorChallengeTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "two-factor challenge ttl")
+
+	c = validConfig()
+	c.Auth.LockoutThreshold = -1
+	require.ErrorContains(t, c.validateAuth(), "lockout threshold")
+
+	c = validConfig()
+	c.Auth.LockoutDuration = 0
+	require.ErrorContains(t, c.validateAuth(), "lockout duration")
+
+	c = validConfig()
+	c.Auth.LockoutMaxDuration = 30 * time.Second
+	require.ErrorContains(t, c.validateAuth(), "lockout max duration")
+
+	c = validConfig()
+	c.Auth.LockoutWindow = 0
+	require.ErrorContains(t, c.validateAuth(), "lockout window")
+
+	c = validConfig()
+	c.Auth.ImpersonationTTL = 0
+	require.ErrorContains(t, c.validateAuth(), "impersonation ttl")
+
+	c = validConfig()
+	c.Auth.ReauthenticationWindow = 0
+	require.ErrorContains(t, c.validateAuth(), "reauthentication window")
+
+	c = validConfig()
+	c.Auth.CacheTTL = -time.Second
+	require.ErrorContains(t, c.validateAuth(), "cache ttl")
+
+	c = validConfig()
+	c.Auth.Mode = "session"
+	require.ErrorContains(t, c.validateAuth(), "invalid mode")
+
+	c = validConfig()
+	c.Auth.CookieSameSite = "default"
+	require.ErrorContains(t, c.validateAuth(), "invalid cookie same site")
+
+	for _, n := range []int{7, 129} {
+		c = validConfig()
+		c.Auth.PasswordMinLength = n
+		require.ErrorContains(t, c.validateAuth(), "password min length")
+	}
+
+	c = validConfig()
+	c.Auth.PasswordMinCharacterClasses = 5
+	require.ErrorContains(t, c.validateAuth(), "password min character classes")
+
+	c = validConfig()
+	c.Auth.PasswordHistory = -1
+	require.ErrorContains(t, c.validateAuth(), "password history")
+
+	c = validConfig()
+	c.Auth.PasswordMaxAge = -time.Hour
+	require.ErrorContains(t, c.validateAuth(), "password max age")
+
+	c = validConfig()
+	c.Auth.Argon2Parallelism = 0
+	require.ErrorContains(t, c.validateAuth(), "argon2 parallelism")
+
+	c = validConfig()
+	c.Auth.Argon2Memory = 31
+	require.ErrorContains(t, c.validateAuth(), "argon2 memory")
+
+	c = validConfig()
+	c.Auth.Argon2Iterations = 0
+	require.ErrorContains(t, c.validateAuth(), "argon2 iterations")
+
+	c = validConfig()
+	c.Auth.SessionIdleTimeout = 0
+	require.ErrorContains(t, c.validateAuth(), "session idle timeout")
+
+	c = validConfig()
+	c.Auth.RememberMeMaxAge = 0
+	require.ErrorContains(t, c.validateAuth(), "remember me max age")
+
+	c = validConfig()
+	c.Auth.RememberMeIdleTimeout = 0
+	require.ErrorContains(t, c.validateAuth(), "remember me idle timeout")
+
+	c = validConfig()
+	c.Auth.AdminSessionIdleTimeout = -time.Minute
+	require.ErrorContains(t, c.validateAuth(), "admin session idle timeout cannot be negative")
+
+	// Admin limits may only tighten the user ones.
+	c = validConfig()
+	c.Auth.AdminSessionMaxAge = c.JWT.RefreshExpiration + time.Hour
+	require.ErrorContains(t, c.validateAuth(), "admin session max age must not be longer")
+
+	c = validConfig()
+	c.Auth.AdminRememberMeIdleTimeout = c.Auth.RememberMeIdleTimeout + time.Hour
+	require.ErrorContains(t, c.validateAuth(), "admin remember me idle timeout must not be longer")
+
+	c = validConfig()
+	c.Auth.AdminSessionMaxAge = time.Hour
+	c.Auth.AdminRememberMeMaxAge = 24 * time.Hour
+	require.NoError(t, c.validateAuth())
+
+	// With lockout disabled the durations are unused.
+	c = validConfig()
+	c.Auth.LockoutThreshold = 0
+	c.Auth.LockoutDuration = 0
+	require.NoError(t, c.validateAuth())
+
+	require.NoError(t, validConfig().validateAuth())
+}
+
+func TestSessionPolicy(t *testing.T) {
+	c := validConfig()
+	c.Auth.AdminSessionIdleTimeout = time.Hour
+	c.Auth.AdminRememberMeMaxAge = 24 * time.Hour
+
+	require.Equal(t, SessionPolicy{MaxAge: c.JWT.RefreshExpiration, IdleTimeout: 24 * time.Hour}, c.SessionPolicy(false, false))
+	require.Equal(t, SessionPolicy{MaxAge: 30 * 24 * time.Hour, IdleTimeout: 7 * 24 * time.Hour}, c.SessionPolicy(false, true))
+	// An unset admin limit keeps the user's.
+	require.Equal(t, SessionPolicy{MaxAge: c.JWT.RefreshExpiration, IdleTimeout: time.Hour}, c.SessionPolicy(true, false))
+	require.Equal(t, SessionPolicy{MaxAge: 24 * time.Hour, IdleTimeout: 7 * 24 * time.Hour}, c.SessionPolicy(true, true))
+
+	require.Equal(t, 30*24*time.Hour, c.LongestSessionMaxAge())
+}
+
+func TestValidateMail(t *testing.T) {
+	t.Parallel()
+
+	c := validConfig()
+	c.Mail.Driver = "smtp"
+	require.ErrorContains(t, c.validateMail(), "invalid driver")
+
+	c = validConfig()
+	c.Mail.From = ""
+	require.ErrorContains(t, c.validateMail(), "from address is required")
+
+	// The file driver needs somewhere to write; the log driver does not.
+	c = validConfig()
+	c.Mail.Driver = "file"
+	require.ErrorContains(t, c.validateMail(), "file dir is required")
+	c.Mail.FileDir = "./mail"
+	require.NoError(t, c.validateMail())
+
+	require.NoError(t, validConfig().validateMail())
+}
+
+func TestValidateLDAP(t *testing.T) {
+	t.Parallel()
+
+	// Disabled, nothing is required.
+	require.NoError(t, validConfig().validateLDAP())
+
+	enabled := func() *Config {
+		c := validConfig()
+		c.LDAP = LDAPConfig{
+			Enabled:           true,
+			URL:               "ldap://directory.example.com:389",
+			BaseDN:            "dc=example,dc=com",
+			UserFilter:        "(uid=%s)",
+			UsernameAttribute: "uid",
+			EmailAttribute:    "mail",
+			NameAttribute:     "cn",
+			GroupAttribute:    "memberOf",
+			GroupRoles:        "cn=editors,ou=groups,dc=example,dc=com=>Editor",
+			Timeout:           5 * time.Second,
+		}
+		return c
+	}
+	require.NoError(t, enabled().validateLDAP())
+
+	c := enabled()
+	c.LDAP.URL = "https://directory.example.com"
+	require.ErrorContains(t, c.validateLDAP(), "url must be")
+
+	c = enabled()
+	c.LDAP.URL = "ldaps://directory.example.com"
+	c.LDAP.StartTLS = true
+	require.ErrorContains(t, c.validateLDAP(), "start tls")
+
+	c = enabled()
+	c.LDAP.BaseDN = ""
+	require.ErrorContains(t, c.validateLDAP(), "base dn")
+
+	c = enabled()
+	c.LDAP.UserFilter = "(uid=alice)"
+	require.ErrorContains(t, c.validateLDAP(), "user filter")
+
+	c = enabled()
+	c.LDAP.GroupRoles = "cn=editors,ou=groups,dc=example,dc=com"
+	require.ErrorContains(t, c.validateLDAP(), "invalid group role mapping")
+
+	c = enabled()
+	c.LDAP.GroupRoles = " ; "
+	require.ErrorContains(t, c.validateLDAP(), "at least one group")
+
+	c = enabled()
+	c.LDAP.Timeout = 0
+	require.ErrorContains(t, c.validateLDAP(), "timeout")
+}
+
+func TestLDAPGroupRoleMappingsKeepOrder(t *testing.T) {
+	t.Parallel()
+
+	mappings, err := LDAPConfig{
+		GroupRoles: " cn=ops,dc=example,dc=com => Operations ;cn=editors,dc=example,dc=com=>Editor;",
+	}.GroupRoleMappings()
+	require.NoError(t, err)
+	require.Equal(t, []LDAPGroupRole{
+		{GroupDN: "cn=ops,dc=example,dc=com", AdminRole: "Operations"},
+		{GroupDN: "cn=editors,dc=example,dc=com", AdminRole: "Editor"},
+	}, mappings)
+}
+
+func TestValidateOIDC(t *testing.T) {
+	t.Parallel()
+
+	// No providers, nothing is required.
+	require.NoError(t, validConfig().validateOIDC())
+
+	withProviders := func(providers string) *Config {
+		c := validConfig()
+		c.OIDC = OIDCConfig{
+			Providers:       providers,
+			RedirectBaseURL: "https://api.example.com/api/v1/auth/oidc",
+			StateTTL:        10 * time.Minute,
+		}
+		return c
+	}
+	require.NoError(t, withProviders(`[{"name":"google","issuer":"https://accounts.google.com","client_id":"id","client_secret":"secret"}]`).validateOIDC())
+
+	// Plain http issuers are for local testing only.
+	local := `[{"name":"local","issuer":"http://127.0.0.1:9000","client_id":"id"}]`
+	require.NoError(t, withProviders(local).validateOIDC())
+	c := withProviders(local)
+	c.App.Environment = "production"
+	require.ErrorContains(t, c.validateOIDC(), "issuer must be an https URL")
+
+	for providers, want := range map[string]string{
+		`{"name":"google"}`: "invalid providers",
+		`[{"name":"Google","issuer":"https://accounts.google.com","client_id":"id"}]`:                                                     "invalid provider name",
+		`[{"name":"a","issuer":"https://a.example.com","client_id":"id"},{"name":"a","issuer":"https://b.example.com","client_id":"id"}]`: "duplicate provider",
+		`[{"name":"google","issuer":"accounts.google.com","client_id":"id"}]`:                                                             "issuer must be",
+		`[{"name":"google","issuer":"https://accounts.google.com"}]`:                                                                      "client id",
+		`[{"name":"google","issuer":"https://accounts.google.com","client_id":"id","scopes":["email"]}]`:                                  "openid",
+		`[{"name":"google","issuer":"https://accounts.google.com","client_id":"id","allowed_roles":["owner"]}]`:                           "invalid allowed role",
+	} {
+		require.ErrorContains(t, withProviders(providers).validateOIDC(), want, providers)
+	}
+
+	c = withProviders(local)
+	c.OIDC.RedirectBaseURL = "/auth/oidc"
+	require.ErrorContains(t, c.validateOIDC(), "redirect base url")
+
+	c = withProviders(local)
+	c.OIDC.StateTTL = 0
+	require.ErrorContains(t, c.validateOIDC(), "state ttl")
+}
+
+func TestOIDCProviderListFillsDefaults(t *testing.T) {
+	t.Parallel()
+
+	providers, err := OIDCConfig{
+		Providers: `[{"name":"google","issuer":"https://accounts.google.com","client_id":"id"},` +
+			`{"name":"corp","issuer":"https://sso.example.com","client_id":"id","scopes":["openid","email"],"allowed_roles":["user","admin"]}]`,
+	}.ProviderList()
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	require.Equal(t, []string{"openid", "email", "profile"}, providers[0].Scopes)
+	require.Equal(t, []string{"user"}, providers[0].AllowedRoles)
+	require.Equal(t, []string{"openid", "email"}, providers[1].Scopes)
+	require.Equal(t, []string{"user", "admin"}, providers[1].AllowedRoles)
+}
+
 func TestValidateWrapsSectionName(t *testing.T) {
 	t.Parallel()
 
@@ -2,8 +2,10 @@
 package permissions
 
 import (
+	"maps"
 	"slices"
 	"sort"
+	"strings"
 )
 
 // Permission represents a single permission string (format: "resource:action")
@@ -22,6 +24,10 @@ const (
 	ActionManage = "manage"
 )
 
+// Wildcard stands for every resource or every action in a grant such as
+// "user:*" or "*:read".
+const Wildcard = "*"
+
 // Resources
 const (
 	ResourceAdminUser = "admin_user"
@@ -40,6 +46,7 @@ const (
 	AdminUserUpdate         Permission = "admin_user:update"
 	AdminUserDelete         Permission = "admin_user:delete"
 	AdminUserChangePassword Permission = "admin_user:change_password"
+	AdminUserImpersonate    Permission = "admin_user:impersonate"
 )
 
 // ============================================================================
@@ -71,10 +78,11 @@ const (
 // USER PERMISSIONS (no create — users register themselves)
 // ============================================================================
 const (
-	UserRead       Permission = "user:read"
-	UserUpdate     Permission = "user:update"
-	UserAssignRole Permission = "user:assign_role"
-	UserDelete     Permission = "user:delete"
+	UserRead        Permission = "user:read"
+	UserUpdate      Permission = "user:update"
+	UserAssignRole  Permission = "user:assign_role"
+	UserDelete      Permission = "user:delete"
+	UserImpersonate Permission = "user:impersonate"
 )
 
 // ============================================================================
@@ -97,6 +105,7 @@ var AllPermissions = map[string][]PermissionInfo{
 		{AdminUserUpdate, ResourceAdminUser, ActionUpdate, "Update admin users"},
 		{AdminUserDelete, ResourceAdminUser, ActionDelete, "Delete admin users"},
 		{AdminUserChangePassword, ResourceAdminUser, "change_password", "Change admin user password"},
+		{AdminUserImpersonate, ResourceAdminUser, "impersonate", "Impersonate admin users"},
 	},
 	ResourceAdminRole: {
 		{AdminRoleCreate, ResourceAdminRole, ActionCreate, "Create admin roles"},
@@ -116,6 +125,7 @@ var AllPermissions = map[string][]PermissionInfo{
 		{UserUpdate, ResourceUser, ActionUpdate, "Update users"},
 		{UserAssignRole, ResourceUser, "assign_role", "Assign roles to user"},
 		{UserDelete, ResourceUser, ActionDelete, "Delete users"},
+		{UserImpersonate, ResourceUser, "impersonate", "Impersonate users"},
 	},
 }
 
@@ -146,6 +156,51 @@ func IsValidPermission(perm string) bool {
 	return permissionSet[perm]
 }
 
+// IsValidGrant checks if a permission string may be granted to an admin
+// role: any valid permission, "resource:*" or "resource:manage" for a
+// registered resource, or "*:action" for an action at least one resource
+// defines. "*:*" is not grantable; that is what root is for.
+func IsValidGrant(perm string) bool {
+	return len(ExpandGrant(perm)) > 0
+}
+
+// ExpandGrant returns the sorted permissions a grant covers, or nil if the
+// grant is not valid. Wildcard grants cover only the permissions registered
+// today; permissions added later are matched at check time by Casbin, so a
+// caller granting a wildcard must hold the pattern too, not just this list.
+func ExpandGrant(perm string) []string {
+	if IsValidPermission(perm) {
+		return []string{perm}
+	}
+
+	resource, action, ok := strings.Cut(perm, ":")
+	if !ok {
+		return nil
+	}
+
+	var result []string
+	switch {
+	case resource == Wildcard:
+		if action == Wildcard || action == ActionManage {
+			return nil
+		}
+		for r, perms := range AllPermissions {
+			for _, p := range perms {
+				if p.Action == action {
+					result = append(result, r+":"+action)
+				}
+			}
+		}
+	case action == Wildcard || action == ActionManage:
+		for _, p := range AllPermissions[resource] {
+			result = append(result, p.Permission.String())
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
 // GetResourceActions returns all valid actions for a resource (excluding
 // "manage"). The slice is a copy so callers cannot mutate the registry.
 func GetResourceActions(resource string) []string {
@@ -163,19 +218,47 @@ func GetAllPermissionsList() []string {
 	return result
 }
 
-// GetPermissionsForFrontend returns permissions formatted for frontend use
+// GetPermissionsForFrontend returns permissions formatted for frontend use.
+// Each resource also lists its "resource:*" and "resource:manage" grants,
+// and the Wildcard key lists the "*:action" grants.
 func GetPermissionsForFrontend() map[string][]map[string]string {
 	result := make(map[string][]map[string]string)
+	actions := make(map[string]struct{})
 	for resource, perms := range AllPermissions {
-		permList := make([]map[string]string, len(perms))
-		for i, p := range perms {
-			permList[i] = map[string]string{
+		label := strings.ReplaceAll(resource, "_", " ")
+		permList := make([]map[string]string, 0, len(perms)+2)
+		for _, p := range perms {
+			permList = append(permList, map[string]string{
 				"permission":  p.Permission.String(),
 				"action":      p.Action,
 				"description": p.Description,
-			}
+			})
+			actions[p.Action] = struct{}{}
 		}
+		permList = append(permList,
+			map[string]string{
+				"permission":  resource + ":" + Wildcard,
+				"action":      Wildcard,
+				"description": "All " + label + " permissions",
+			},
+			map[string]string{
+				"permission":  resource + ":" + ActionManage,
+				"action":      ActionManage,
+				"description": "Manage " + label + " (all " + label + " permissions)",
+			},
+		)
 		result[resource] = permList
 	}
+
+	wildcardList := make([]map[string]string, 0, len(actions))
+	for _, action := range slices.Sorted(maps.Keys(actions)) {
+		wildcardList = append(wildcardList, map[string]string{
+			"permission":  Wildcard + ":" + action,
+			"action":      action,
+			"description": "Every " + strings.ReplaceAll(action, "_", " ") + " permission on all resources",
+		})
+	}
+	result[Wildcard] = wildcardList
+
 	return result
 }
@@ -112,3 +112,50 @@ func TestGetPermissionsForFrontendShape(t *testing.T) {
 	}
 	require.True(t, found, "user:read must be exposed to the frontend")
 }
+
+func TestIsValidGrantAcceptsRegisteredWildcards(t *testing.T) {
+	t.Parallel()
+
+	for _, grant := range []string{UserRead.String(), "user:*", "user:manage", "*:read", "*:impersonate"} {
+		require.True(t, IsValidGrant(grant), grant)
+	}
+	for _, grant := range []string{"", "user", "nope:*", "nope:manage", "*:nope", "*:*", "*:manage", "user:nope"} {
+		require.False(t, IsValidGrant(grant), grant)
+	}
+}
+
+func TestExpandGrantListsCoveredPermissions(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{UserRead.String()}, ExpandGrant(UserRead.String()))
+	require.Equal(t, []string{ConfigRead.String(), ConfigUpdate.String()}, ExpandGrant("config:*"))
+	require.Equal(t, ExpandGrant("config:*"), ExpandGrant("config:manage"))
+	require.Equal(t, []string{
+		AdminRoleRead.String(),
+		AdminUserRead.String(),
+		ConfigRead.String(),
+		LogRead.String(),
+		UserRead.String(),
+	}, ExpandGrant("*:read"))
+	require.Nil(t, ExpandGrant("*:*"))
+}
+
+// TestGetPermissionsForFrontendListsWildcardGrants — every grant the catalog
+// offers must be one IsValidGrant accepts.
+func TestGetPermissionsForFrontendListsWildcardGrants(t *testing.T) {
+	t.Parallel()
+
+	result := GetPermissionsForFrontend()
+
+	var listed []string
+	for _, entries := range result {
+		for _, entry := range entries {
+			require.True(t, IsValidGrant(entry["permission"]), entry["permission"])
+			listed = append(listed, entry["permission"])
+		}
+	}
+	require.Contains(t, listed, "user:*")
+	require.Contains(t, listed, "user:manage")
+	require.Contains(t, listed, "*:read")
+	require.NotContains(t, listed, "*:manage")
+}
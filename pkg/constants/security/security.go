@@ -1,10 +1,29 @@
 // Package security centralizes security-related tuning constants shared
-// across modules, so values like the bcrypt work factor cannot drift between
-// the flows that hash passwords.
+// across modules, so values like the password length limit cannot drift
+// between the flows that accept passwords.
 package security
 
-// BcryptCost is the bcrypt work factor used for every password hash in the
-// application (registration, self-service password changes, and admin-driven
-// password resets). Keep all hashing on the same cost so every stored hash
-// ages together and can be rotated with a single change here.
-const BcryptCost = 12
+import (
+	"slices"
+	"strings"
+)
+
+// MaxPasswordBytes is the longest password accepted. Argon2id has no input
+// limit of its own; the cap keeps the request bodies that get hashed small.
+// It was 72 while passwords were hashed with bcrypt, which ignored anything
+// longer, so no legacy hash needs to be checked against a longer password.
+const MaxPasswordBytes = 128
+
+// commonPasswords are well-known values (former defaults, keyboard walks)
+// refused everywhere a password is chosen, even without a breached-password
+// list, and refused as the seeded admin password in production.
+var commonPasswords = []string{
+	"q1w2e3r4", "password", "admin", "admin123", "12345678", "changeme",
+	"123456789", "1234567890", "qwerty123", "password1", "iloveyou",
+}
+
+// IsCommonPassword reports whether password is one of the built-in common
+// passwords, ignoring case.
+func IsCommonPassword(password string) bool {
+	return slices.Contains(commonPasswords, strings.ToLower(password))
+}
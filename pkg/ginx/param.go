@@ -8,6 +8,7 @@ import (
 	cerrors "github.com/PhantomX7/athleton/pkg/errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // ParseUintParam reads an unsigned-integer path parameter (e.g. :id) and
@@ -32,3 +33,13 @@ func ParseUintParam(ctx *gin.Context, name string) (uint, bool) {
 	}
 	return uint(v), true
 }
+
+// ParseUUIDParam is ParseUintParam for UUID path parameters.
+func ParseUUIDParam(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	v, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		_ = ctx.Error(cerrors.NewBadRequestError(fmt.Sprintf("invalid %s parameter", name)))
+		return uuid.Nil, false
+	}
+	return v, true
+}
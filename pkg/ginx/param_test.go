@@ -10,6 +10,7 @@ import (
 	"github.com/PhantomX7/athleton/pkg/ginx"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -56,3 +57,25 @@ func TestParseUintParamRejectsNegative(t *testing.T) {
 
 	require.False(t, ok)
 }
+
+func TestParseUUIDParam(t *testing.T) {
+	want := uuid.New()
+	c := ctxWithParam("id", want.String())
+
+	id, ok := ginx.ParseUUIDParam(c, "id")
+
+	require.True(t, ok)
+	require.Equal(t, want, id)
+	require.Empty(t, c.Errors)
+
+	c = ctxWithParam("id", "42")
+
+	id, ok = ginx.ParseUUIDParam(c, "id")
+
+	require.False(t, ok)
+	require.Equal(t, uuid.Nil, id)
+	require.Len(t, c.Errors, 1)
+	var ae *cerrors.AppError
+	require.True(t, errors.As(c.Errors[0].Err, &ae))
+	require.Equal(t, http.StatusBadRequest, ae.Code)
+}
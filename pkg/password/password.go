@@ -0,0 +1,177 @@
+// Package password hashes passwords with Argon2id, encoded as PHC strings
+// ($argon2id$v=19$m=…,t=…,p=…$salt$key), and verifies both those and the
+// bcrypt hashes written before Argon2id was adopted, so stored hashes can be
+// upgraded one successful login at a time.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+	saltLength     = 16
+	keyLength      = 32
+)
+
+var (
+	// ErrMismatchedPassword is returned by Compare when the password does
+	// not match the hash.
+	ErrMismatchedPassword = errors.New("password does not match hash")
+	// ErrUnknownScheme is returned for a hash that is neither Argon2id nor
+	// bcrypt.
+	ErrUnknownScheme = errors.New("unknown password hash scheme")
+	// ErrMalformedHash is returned for an Argon2id hash that cannot be parsed.
+	ErrMalformedHash = errors.New("malformed argon2id hash")
+)
+
+// encoding is unpadded standard base64, the form the PHC string format uses.
+var encoding = base64.RawStdEncoding
+
+// Params are the Argon2id cost parameters. A zero field takes its value from
+// DefaultParams, the same way bcrypt treats a cost below its minimum.
+type Params struct {
+	// Memory is the memory cost in KiB.
+	Memory uint32
+	// Iterations is the number of passes over the memory.
+	Iterations uint32
+	// Parallelism is the number of lanes, and threads, used.
+	Parallelism uint8
+}
+
+// DefaultParams is the second recommended option of RFC 9106: 64 MiB, three
+// passes, four lanes.
+var DefaultParams = Params{Memory: 64 * 1024, Iterations: 3, Parallelism: 4}
+
+func (p Params) withDefaults() Params {
+	if p.Memory == 0 {
+		p.Memory = DefaultParams.Memory
+	}
+	if p.Iterations == 0 {
+		p.Iterations = DefaultParams.Iterations
+	}
+	if p.Parallelism == 0 {
+		p.Parallelism = DefaultParams.Parallelism
+	}
+	return p
+}
+
+// Hash returns the Argon2id PHC string of plain under p with a random salt.
+func Hash(plain string, p Params) (string, error) {
+	p = p.withDefaults()
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate password salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(plain), salt, p.Iterations, p.Memory, p.Parallelism, keyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		encoding.EncodeToString(salt), encoding.EncodeToString(key)), nil
+}
+
+// Compare reports whether plain matches encoded, which may be an Argon2id
+// PHC string or a legacy bcrypt hash. It returns nil on a match and
+// ErrMismatchedPassword, or the reason the hash could not be checked,
+// otherwise.
+func Compare(encoded, plain string) error {
+	switch {
+	case strings.HasPrefix(encoded, argon2idPrefix):
+		p, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return err
+		}
+		candidate := argon2.IDKey([]byte(plain), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key))) // #nosec G115 -- key length is bounded by the stored hash
+		if subtle.ConstantTimeCompare(candidate, key) != 1 {
+			return ErrMismatchedPassword
+		}
+		return nil
+	case isBcrypt(encoded):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(plain))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) || errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return ErrMismatchedPassword
+		}
+		return err
+	default:
+		return ErrUnknownScheme
+	}
+}
+
+// NeedsRehash reports whether encoded should be replaced by a fresh Hash
+// under p: it is a legacy bcrypt hash, or an Argon2id hash made with other
+// parameters. Unknown or malformed hashes cannot be upgraded and report
+// false.
+func NeedsRehash(encoded string, p Params) bool {
+	if isBcrypt(encoded) {
+		return true
+	}
+	stored, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false
+	}
+	return stored != p.withDefaults() || len(salt) != saltLength || len(key) != keyLength
+}
+
+// dummies caches one Dummy hash per parameter set.
+var dummies sync.Map
+
+// Dummy returns a valid Argon2id hash under p that no real password matches.
+// Comparing against it on the unknown-user path costs the same as checking a
+// real password, so response timing does not reveal which accounts exist.
+func Dummy(p Params) string {
+	p = p.withDefaults()
+	if h, ok := dummies.Load(p); ok {
+		return h.(string)
+	}
+	h, err := Hash(rand.Text(), p)
+	if err != nil {
+		panic(fmt.Sprintf("password: failed to generate dummy hash: %v", err))
+	}
+	actual, _ := dummies.LoadOrStore(p, h)
+	return actual.(string)
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// decodeArgon2id parses an Argon2id PHC string into its parameters, salt and
+// key.
+func decodeArgon2id(encoded string) (Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=…,t=…,p=…", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	var p Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil ||
+		p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := encoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+	key, err := encoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,92 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/PhantomX7/athleton/pkg/password"
+)
+
+// cheap keeps the tests fast; production parameters come from config.
+var cheap = password.Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func TestHashProducesVerifiableArgon2idPHCString(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := password.Hash("correct horse battery", cheap)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"), encoded)
+
+	other, err := password.Hash("correct horse battery", cheap)
+	require.NoError(t, err)
+	require.NotEqual(t, encoded, other, "every hash gets a fresh salt")
+
+	require.NoError(t, password.Compare(encoded, "correct horse battery"))
+	require.ErrorIs(t, password.Compare(encoded, "wrong horse battery"), password.ErrMismatchedPassword)
+}
+
+func TestCompareAcceptsLegacyBcrypt(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	require.NoError(t, password.Compare(string(legacy), "old-password"))
+	require.ErrorIs(t, password.Compare(string(legacy), "new-password"), password.ErrMismatchedPassword)
+	require.ErrorIs(t, password.Compare(string(legacy), strings.Repeat("a", 100)), password.ErrMismatchedPassword)
+}
+
+func TestCompareRejectsUnknownAndMalformedHashes(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, password.Compare("", "secret"), password.ErrUnknownScheme)
+	require.ErrorIs(t, password.Compare("plain-text", "secret"), password.ErrUnknownScheme)
+
+	for _, bad := range []string{
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdHNhbHQ$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$not base64$a2V5",
+	} {
+		require.ErrorIs(t, password.Compare(bad, "secret"), password.ErrMalformedHash, bad)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	current, err := password.Hash("secret-password", cheap)
+	require.NoError(t, err)
+	require.False(t, password.NeedsRehash(current, cheap))
+
+	stronger := cheap
+	stronger.Iterations = 2
+	require.True(t, password.NeedsRehash(current, stronger))
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.True(t, password.NeedsRehash(string(legacy), cheap))
+
+	require.False(t, password.NeedsRehash("plain-text", cheap))
+}
+
+func TestZeroParamsUseDefaults(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := password.Hash("secret-password", password.Params{Iterations: 1})
+	require.NoError(t, err)
+	require.Contains(t, encoded, "$m=65536,t=1,p=4$")
+	require.False(t, password.NeedsRehash(encoded, password.Params{Memory: password.DefaultParams.Memory, Iterations: 1}))
+}
+
+func TestDummyFollowsParamsAndMatchesNothing(t *testing.T) {
+	t.Parallel()
+
+	dummy := password.Dummy(cheap)
+	require.Equal(t, dummy, password.Dummy(cheap), "dummy hashes are cached per parameter set")
+	require.False(t, password.NeedsRehash(dummy, cheap))
+	require.ErrorIs(t, password.Compare(dummy, ""), password.ErrMismatchedPassword)
+}
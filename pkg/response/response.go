@@ -24,6 +24,13 @@ type Meta struct {
 	Facet  any   `json:"facet,omitempty"`
 }
 
+// ErrorCode is the error payload of a failure the client is expected to
+// recognize and act on (for example by prompting the user and retrying)
+// rather than just display.
+type ErrorCode struct {
+	Code string `json:"code"`
+}
+
 // ModelResponse is implemented by types that can convert themselves into API DTOs.
 type ModelResponse[T any] interface {
 	ToResponse() T
@@ -50,6 +57,18 @@ func BuildResponseFailed(message string) Response {
 	return res
 }
 
+// BuildResponseFailedWithCode wraps a failed result payload carrying a
+// machine-readable error code.
+func BuildResponseFailedWithCode(message, code string) Response {
+	res := Response{
+		Status:  false,
+		Message: message,
+		Error:   ErrorCode{Code: code},
+	}
+
+	return res
+}
+
 // BuildResponseValidationError wraps a validation failure payload.
 func BuildResponseValidationError(err validator.ValidationErrors) Response {
 	res := Response{
@@ -42,6 +42,15 @@ func TestBuildResponseFailed(t *testing.T) {
 	require.Nil(t, res.Data)
 }
 
+func TestBuildResponseFailedWithCode(t *testing.T) {
+	res := response.BuildResponseFailedWithCode("try again", "retry_me")
+
+	require.False(t, res.Status)
+	require.Equal(t, "try again", res.Message)
+	require.Equal(t, response.ErrorCode{Code: "retry_me"}, res.Error)
+	require.Nil(t, res.Data)
+}
+
 func TestBuildResponseValidationError(t *testing.T) {
 	type payload struct {
 		Email string `validate:"required,email"`
@@ -0,0 +1,79 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PhantomX7/athleton/pkg/totp"
+)
+
+// rfcSecret is the SHA-1 seed from RFC 6238 Appendix B ("12345678901234567890").
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAtMatchesRFC6238Vectors(t *testing.T) {
+	t.Parallel()
+
+	// Appendix B lists 8-digit codes; the 6-digit code is their last six digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		got, err := totp.CodeAt(rfcSecret, totp.Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, want, got, "t=%d", unix)
+	}
+}
+
+func TestValidateAcceptsSkewAndReturnsMatchedStep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1111111111, 0)
+	current := totp.Step(now)
+	previous, err := totp.CodeAt(rfcSecret, current-1)
+	require.NoError(t, err)
+
+	step, ok := totp.Validate(rfcSecret, previous, now, 1)
+	require.True(t, ok)
+	require.Equal(t, current-1, step)
+
+	_, ok = totp.Validate(rfcSecret, previous, now, 0)
+	require.False(t, ok, "a previous-step code is outside a zero skew window")
+
+	for _, bad := range []string{"", "12345", "1234567", "abcdef"} {
+		_, ok = totp.Validate(rfcSecret, bad, now, 1)
+		require.False(t, ok, bad)
+	}
+
+	_, ok = totp.Validate("not base32!", "123456", now, 1)
+	require.False(t, ok)
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32) // 20 bytes, unpadded base32
+
+	other, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	u, err := url.Parse(totp.URI("Athleton", "alice@example.com", secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Athleton:alice@example.com", u.Path)
+	require.Equal(t, secret, u.Query().Get("secret"))
+	require.Equal(t, "Athleton", u.Query().Get("issuer"))
+	require.Equal(t, "6", u.Query().Get("digits"))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/PhantomX7/athleton/pkg/errors"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,15 +17,37 @@ const (
 	txKey        contextKey = "db_transaction"
 	valuesKey    contextKey = "values"
 	requestIDKey contextKey = "request_id"
+	clientKey    contextKey = "client"
 )
 
 // ContextValues holds values extracted from the context.
 type ContextValues struct {
-	UserID      uint
-	UserName    string
-	Role        string
-	AdminRoleID *uint
-	RequestID   string
+	UserID   uint
+	UserName string
+	Role     string
+	// AdminRoleIDs are the admin roles assigned to the user; an admin is
+	// granted the union of their permissions.
+	AdminRoleIDs []uint
+	RequestID    string
+	// SessionID is the refresh-token session the access token is bound to
+	// (its jti claim); uuid.Nil outside an authenticated request.
+	SessionID uuid.UUID
+	// APIKeyID is the key a request authenticated with, or 0 for a session.
+	// APIKeyPermissions is that key's scope: permission guards grant only
+	// what is both in this list and held by the owner's role.
+	APIKeyID          uint
+	APIKeyPermissions []string
+	// ImpersonatorID and ImpersonatorName identify the real actor when the
+	// request was made with an impersonation token for UserID; zero otherwise.
+	ImpersonatorID   uint
+	ImpersonatorName string
+}
+
+// ClientInfo describes the client behind a request. It is recorded on
+// sessions so users can recognize their devices.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
 }
 
 // NewContextWithValues creates a new context with the provided ContextValues.
@@ -72,14 +95,14 @@ func GetRoleFromContext(ctx context.Context) (string, bool) {
 	return values.Role, true
 }
 
-// GetAdminRoleIDFromContext retrieves admin role ID from context.
+// GetAdminRoleIDsFromContext retrieves the admin role IDs from context.
 // Returns nil if not found or not set.
-func GetAdminRoleIDFromContext(ctx context.Context) *uint {
+func GetAdminRoleIDsFromContext(ctx context.Context) []uint {
 	values, ok := ctx.Value(valuesKey).(ContextValues)
 	if !ok {
 		return nil
 	}
-	return values.AdminRoleID
+	return values.AdminRoleIDs
 }
 
 // SetRequestIDToContext sets the request ID to the context.
@@ -96,6 +119,18 @@ func GetRequestIDFromContext(ctx context.Context) string {
 	return ""
 }
 
+// SetClientInfoToContext sets the request's client info on the context.
+func SetClientInfoToContext(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientKey, client)
+}
+
+// GetClientInfoFromContext retrieves the client info from the context. It
+// returns the zero value when none was set (e.g. background jobs).
+func GetClientInfoFromContext(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(clientKey).(ClientInfo)
+	return client
+}
+
 // SetTxToContext sets the transaction to the context for database operations.
 func SetTxToContext(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, txKey, tx)
@@ -13,13 +13,12 @@ import (
 )
 
 func TestValuesFromContextRoundTrip(t *testing.T) {
-	roleID := uint(3)
 	values := utils.ContextValues{
-		UserID:      7,
-		UserName:    "alice",
-		Role:        "admin",
-		AdminRoleID: &roleID,
-		RequestID:   "req-1",
+		UserID:       7,
+		UserName:     "alice",
+		Role:         "admin",
+		AdminRoleIDs: []uint{3, 4},
+		RequestID:    "req-1",
 	}
 
 	ctx := utils.NewContextWithValues(context.Background(), values)
@@ -38,12 +37,11 @@ func TestValuesFromContextRejectsMissingValues(t *testing.T) {
 }
 
 func TestIndividualGettersReturnStoredValues(t *testing.T) {
-	roleID := uint(3)
 	ctx := utils.NewContextWithValues(context.Background(), utils.ContextValues{
-		UserID:      7,
-		UserName:    "alice",
-		Role:        "admin",
-		AdminRoleID: &roleID,
+		UserID:       7,
+		UserName:     "alice",
+		Role:         "admin",
+		AdminRoleIDs: []uint{3, 4},
 	})
 
 	userID, ok := utils.GetUserIDFromContext(ctx)
@@ -58,7 +56,7 @@ func TestIndividualGettersReturnStoredValues(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "admin", role)
 
-	require.Equal(t, &roleID, utils.GetAdminRoleIDFromContext(ctx))
+	require.Equal(t, []uint{3, 4}, utils.GetAdminRoleIDsFromContext(ctx))
 }
 
 func TestIndividualGettersReportMissingValues(t *testing.T) {
@@ -73,7 +71,7 @@ func TestIndividualGettersReportMissingValues(t *testing.T) {
 	_, ok = utils.GetRoleFromContext(ctx)
 	require.False(t, ok)
 
-	require.Nil(t, utils.GetAdminRoleIDFromContext(ctx))
+	require.Nil(t, utils.GetAdminRoleIDsFromContext(ctx))
 }
 
 func TestRequestIDRoundTrip(t *testing.T) {
@@ -83,6 +81,14 @@ func TestRequestIDRoundTrip(t *testing.T) {
 	require.Empty(t, utils.GetRequestIDFromContext(context.Background()))
 }
 
+func TestClientInfoRoundTrip(t *testing.T) {
+	client := utils.ClientInfo{UserAgent: "curl/8.0", IP: "203.0.113.7"}
+	ctx := utils.SetClientInfoToContext(context.Background(), client)
+
+	require.Equal(t, client, utils.GetClientInfoFromContext(ctx))
+	require.Equal(t, utils.ClientInfo{}, utils.GetClientInfoFromContext(context.Background()))
+}
+
 func TestTxRoundTrip(t *testing.T) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)